@@ -0,0 +1,474 @@
+package apfs
+
+// This is an accessor which parses an APFS container
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type APFSFileInfo struct {
+	volume     *Volume
+	inode      *Inode
+	entry      *DirEntry
+	_full_path *accessors.OSPath
+	size       int64
+	link       string
+}
+
+func newAPFSFileInfo(full_path *accessors.OSPath,
+	res *LookupResult) *APFSFileInfo {
+	result := &APFSFileInfo{
+		volume:     res.Volume,
+		inode:      res.Inode,
+		entry:      res.Entry,
+		_full_path: full_path,
+	}
+
+	if !res.Inode.IsDir() {
+		result.size = res.Volume.FileSize(res.Inode)
+	}
+
+	if res.Inode.IsLink() {
+		result.link, _ = res.Volume.Readlink(res.Inode)
+	}
+
+	return result
+}
+
+func (self *APFSFileInfo) Name() string {
+	return self._full_path.Basename()
+}
+
+func (self *APFSFileInfo) IsDir() bool {
+	return self.inode.IsDir()
+}
+
+func (self *APFSFileInfo) Size() int64 {
+	return self.size
+}
+
+func (self *APFSFileInfo) Mode() os.FileMode {
+	return self.inode.FileMode()
+}
+
+func (self *APFSFileInfo) ModTime() time.Time {
+	return self.inode.Modified
+}
+
+func (self *APFSFileInfo) Mtime() time.Time {
+	return self.inode.Modified
+}
+
+func (self *APFSFileInfo) Atime() time.Time {
+	return self.inode.Accessed
+}
+
+func (self *APFSFileInfo) Ctime() time.Time {
+	return self.inode.Changed
+}
+
+func (self *APFSFileInfo) Btime() time.Time {
+	return self.inode.Created
+}
+
+func (self *APFSFileInfo) Data() *ordereddict.Dict {
+	result := ordereddict.NewDict().
+		Set("Inode", self.inode.ID).
+		Set("ParentInode", self.inode.ParentID).
+		Set("Uid", self.inode.Owner).
+		Set("Gid", self.inode.Group).
+		Set("Flags", self.inode.BSDFlags)
+
+	if self.inode.IsDir() {
+		result.Set("Children", self.inode.NChildren)
+	} else {
+		result.Set("Nlink", self.inode.NChildren)
+	}
+
+	if self.entry != nil && !self.entry.DateAdded.IsZero() {
+		result.Set("DateAdded", self.entry.DateAdded)
+	}
+
+	if self.inode.IsCompressed() {
+		result.Set("Compressed", true)
+	}
+
+	if self.link != "" {
+		result.Set("Link", self.link)
+	}
+
+	return result
+}
+
+func (self *APFSFileInfo) FullPath() string {
+	return self._full_path.String()
+}
+
+func (self *APFSFileInfo) OSPath() *accessors.OSPath {
+	return self._full_path
+}
+
+func (self *APFSFileInfo) IsLink() bool {
+	return self.inode.IsLink()
+}
+
+// Symlinks are resolved relative to the root of the volume.
+func (self *APFSFileInfo) GetLink() (*accessors.OSPath, error) {
+	if self.link == "" {
+		return nil, errors.New("Not a symlink")
+	}
+
+	components := self._full_path.Components
+	if len(components) == 0 {
+		return nil, errors.New("Not a symlink")
+	}
+
+	target := self.link
+	if !path.IsAbs(target) {
+		dir := components[1 : len(components)-1]
+		target = path.Join("/", strings.Join(dir, "/"), target)
+	}
+
+	result := self._full_path.Copy()
+	result.Components = []string{components[0]}
+	for _, c := range strings.Split(path.Clean("/"+target), "/") {
+		if c != "" {
+			result.Components = append(result.Components, c)
+		}
+	}
+	return result, nil
+}
+
+// Extended attributes are available to the xattr() function.
+func (self *APFSFileInfo) ListXAttr() ([]string, error) {
+	return self.volume.ListXAttr(self.inode)
+}
+
+func (self *APFSFileInfo) GetXAttr(name string) ([]byte, error) {
+	return self.volume.ReadXAttrByName(self.inode, name)
+}
+
+// The top level directory holds the volumes in the container.
+func newVolumeFileInfo(full_path *accessors.OSPath,
+	volume *Volume) *accessors.VirtualFileInfo {
+	data := ordereddict.NewDict().
+		Set("UUID", volume.UUID).
+		Set("Role", volume.Role).
+		Set("Encrypted", volume.IsEncrypted()).
+		Set("CaseSensitive", volume.IsCaseSensitive()).
+		Set("Files", volume.NumFiles).
+		Set("Folders", volume.NumFolders)
+
+	if volume.Snapshot != "" {
+		data.Set("Snapshot", volume.Snapshot).
+			Set("XID", volume.XID)
+
+	} else {
+		snapshots := []string{}
+		all, _ := volume.Snapshots()
+		for _, s := range all {
+			snapshots = append(snapshots, s.Name)
+		}
+		data.Set("Snapshots", snapshots)
+	}
+
+	return &accessors.VirtualFileInfo{
+		Path:   full_path,
+		IsDir_: true,
+		Btime_: volume.Created,
+		Mtime_: volume.LastMod,
+		Data_:  data,
+	}
+}
+
+type APFSFileSystemAccessor struct {
+	scope vfilter.Scope
+
+	// The delegate accessor we use to open the underlying volume.
+	accessor string
+	device   *accessors.OSPath
+
+	root *accessors.OSPath
+}
+
+func NewAPFSFileSystemAccessor(
+	scope vfilter.Scope,
+	root_path *accessors.OSPath,
+	device *accessors.OSPath, accessor string) *APFSFileSystemAccessor {
+	return &APFSFileSystemAccessor{
+		scope:    scope,
+		accessor: accessor,
+		device:   device,
+		root:     root_path,
+	}
+}
+
+func (self APFSFileSystemAccessor) New(scope vfilter.Scope) (
+	accessors.FileSystemAccessor, error) {
+	return &APFSFileSystemAccessor{
+		scope:    scope,
+		device:   self.device,
+		accessor: self.accessor,
+		root:     self.root,
+	}, nil
+}
+
+func (self APFSFileSystemAccessor) ParsePath(path string) (
+	*accessors.OSPath, error) {
+	return accessors.NewLinuxOSPath(path)
+}
+
+func (self *APFSFileSystemAccessor) ReadDir(path string) (
+	res []accessors.FileInfo, err error) {
+	fullpath, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.ReadDirWithOSPath(fullpath)
+}
+
+func (self *APFSFileSystemAccessor) ReadDirWithOSPath(
+	fullpath *accessors.OSPath) (res []accessors.FileInfo, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_apfs: %v", r)
+		}
+	}()
+
+	apfs_ctx, err := GetAPFSContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []accessors.FileInfo{}
+
+	// List the volumes in the container.
+	if len(fullpath.Components) == 0 {
+		volumes, err := apfs_ctx.Volumes()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, volume := range volumes {
+			result = append(result, newVolumeFileInfo(
+				fullpath.Append(volume.Name), volume))
+		}
+		return result, nil
+	}
+
+	dir, err := apfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dir.Inode.IsDir() {
+		return nil, errors.New("raw_apfs: Not a directory")
+	}
+
+	entries, err := dir.Volume.ReadDir(dir.Inode.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		inode, err := dir.Volume.GetInode(entry.FileID)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, newAPFSFileInfo(
+			fullpath.Append(entry.Name), &LookupResult{
+				Volume: dir.Volume,
+				Inode:  inode,
+				Entry:  entry,
+			}))
+	}
+	return result, nil
+}
+
+func (self *APFSFileSystemAccessor) Open(
+	path string) (res accessors.ReadSeekCloser, err error) {
+	full_path, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.OpenWithOSPath(full_path)
+}
+
+func (self *APFSFileSystemAccessor) OpenWithOSPath(
+	fullpath *accessors.OSPath) (res accessors.ReadSeekCloser, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_apfs: %v", r)
+		}
+	}()
+
+	apfs_ctx, err := GetAPFSContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fullpath.Components) == 0 {
+		return nil, errors.New("raw_apfs: Can not open a directory")
+	}
+
+	file, err := apfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Inode.IsDir() {
+		return nil, errors.New("raw_apfs: Can not open a directory")
+	}
+
+	reader, size, err := file.Volume.Reader(file.Inode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileReader{reader: reader, size: size}, nil
+}
+
+func (self *APFSFileSystemAccessor) Lstat(
+	path string) (res accessors.FileInfo, err error) {
+	fullpath, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.LstatWithOSPath(fullpath)
+}
+
+func (self *APFSFileSystemAccessor) LstatWithOSPath(
+	fullpath *accessors.OSPath) (res accessors.FileInfo, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_apfs: %v", r)
+		}
+	}()
+
+	apfs_ctx, err := GetAPFSContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(fullpath.Components) {
+	case 0:
+		return &accessors.VirtualFileInfo{
+			Path:   fullpath,
+			IsDir_: true,
+			Data_: ordereddict.NewDict().
+				Set("UUID", apfs_ctx.Superblock.UUID),
+		}, nil
+
+	case 1:
+		volume, err := apfs_ctx.OpenVolume(fullpath.Components[0])
+		if err != nil {
+			return nil, err
+		}
+		return newVolumeFileInfo(fullpath, volume), nil
+	}
+
+	file, err := apfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAPFSFileInfo(fullpath, file), nil
+}
+
+// A seekable reader over the file content.
+type fileReader struct {
+	reader io.ReaderAt
+	size   int64
+	offset int64
+}
+
+func (self *fileReader) Read(buf []byte) (int, error) {
+	n, err := self.reader.ReadAt(buf, self.offset)
+	self.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (self *fileReader) ReadAt(buf []byte, offset int64) (int, error) {
+	return self.reader.ReadAt(buf, offset)
+}
+
+func (self *fileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += self.offset
+	case io.SeekEnd:
+		offset += self.size
+	default:
+		return 0, errors.New("Invalid whence")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("Negative seek")
+	}
+	self.offset = offset
+	return offset, nil
+}
+
+func (self *fileReader) Close() error {
+	return nil
+}
+
+func init() {
+	accessors.Register("raw_apfs", &APFSFileSystemAccessor{},
+		`Access the volumes in an APFS container by parsing the image.
+
+This accessor is designed to operate on images directly. It requires a
+delegate accessor to get the raw container (e.g. the APFS partition of
+a disk image) and will open files using the full path.
+
+The top level directory lists the volumes in the container by
+name. The snapshots of a volume are listed in the volume's
+Data.Snapshots field and may be accessed using the volume name and
+the snapshot name separated by @ (e.g. "/Macintosh HD - Data@com.apple.TimeMachine.2024-05-01-101010.local").
+
+Only unencrypted volumes may be read. Files compressed by the
+filesystem (zlib) are decompressed transparently. The creation time
+is returned as the file's birth time. Extended attributes (including
+the resource fork com.apple.ResourceFork) are available using the
+xattr() function with this accessor.
+
+## Example
+
+The following query will list the fseventsd logs on the data volume
+of a macOS disk image.
+
+SELECT OSPath, Size, Btime
+FROM glob(globs='/Macintosh HD - Data/.fseventsd/*',
+  accessor="raw_apfs",
+  root=pathspec(
+    DelegateAccessor="offset",
+    Delegate=pathspec(
+      DelegateAccessor="file",
+      DelegatePath="/images/mac.dd",
+      Path="/209735680")))
+
+`)
+
+	json.RegisterCustomEncoder(&APFSFileInfo{}, accessors.MarshalGlobFileInfo)
+}
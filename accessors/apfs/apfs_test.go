@@ -0,0 +1,456 @@
+package apfs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/accessors/decmpfs"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+const (
+	testBlockSize = 4096
+	testBlocks    = 20
+
+	testVolumeOID = 1026
+	testTreeOID   = 1028
+	testLeafAOID  = 1030
+	testLeafBOID  = 1031
+
+	testXID         = 5
+	testSnapshotXID = 3
+)
+
+var (
+	testCreated   = time.Date(2021, 3, 4, 5, 6, 7, 800, time.UTC)
+	testModified  = time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+	testFormatted = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCompressed = bytes.Repeat([]byte("compressed content "), 10)
+)
+
+type kv struct {
+	key, value []byte
+}
+
+type testImage struct {
+	data []byte
+}
+
+func (self *testImage) block(n int) []byte {
+	return self.data[n*testBlockSize : (n+1)*testBlockSize]
+}
+
+func putHeader(buf []byte, oid, xid uint64, object_type uint32) {
+	binary.LittleEndian.PutUint64(buf[8:], oid)
+	binary.LittleEndian.PutUint64(buf[16:], xid)
+	binary.LittleEndian.PutUint32(buf[24:], object_type)
+}
+
+func seal(buf []byte) {
+	binary.LittleEndian.PutUint64(buf, fletcher64(buf))
+}
+
+// Build a B-tree node. Fixed size entries are used for object maps.
+func (self *testImage) putNode(n int, oid uint64, flags uint16, level uint16,
+	entries []kv, key_size, val_size int) {
+	buf := self.block(n)
+
+	object_type := uint32(OBJECT_TYPE_BTREE_NODE | OBJ_PHYSICAL)
+	if flags&BTNODE_ROOT != 0 {
+		object_type = OBJECT_TYPE_BTREE | OBJ_PHYSICAL
+	}
+	putHeader(buf, oid, testXID, object_type)
+
+	fixed := key_size > 0
+	if fixed {
+		flags |= BTNODE_FIXED_KV_SIZE
+	}
+
+	entry_size := 8
+	if fixed {
+		entry_size = 4
+	}
+
+	binary.LittleEndian.PutUint16(buf[32:], flags)
+	binary.LittleEndian.PutUint16(buf[34:], level)
+	binary.LittleEndian.PutUint32(buf[36:], uint32(len(entries)))
+	binary.LittleEndian.PutUint16(buf[42:], uint16(len(entries)*entry_size))
+
+	val_end := testBlockSize
+	if flags&BTNODE_ROOT != 0 {
+		val_end -= BTREE_INFO_SIZE
+		info := buf[val_end:]
+		binary.LittleEndian.PutUint32(info[4:], testBlockSize)
+		binary.LittleEndian.PutUint32(info[8:], uint32(key_size))
+		binary.LittleEndian.PutUint32(info[12:], uint32(val_size))
+	}
+
+	key_start := BTNODE_DATA + len(entries)*entry_size
+	k_off, v_off := 0, 0
+	for i, e := range entries {
+		copy(buf[key_start+k_off:], e.key)
+		v_off += len(e.value)
+		copy(buf[val_end-v_off:], e.value)
+
+		toc := buf[BTNODE_DATA+i*entry_size:]
+		if fixed {
+			binary.LittleEndian.PutUint16(toc, uint16(k_off))
+			binary.LittleEndian.PutUint16(toc[2:], uint16(v_off))
+		} else {
+			binary.LittleEndian.PutUint16(toc, uint16(k_off))
+			binary.LittleEndian.PutUint16(toc[2:], uint16(len(e.key)))
+			binary.LittleEndian.PutUint16(toc[4:], uint16(v_off))
+			binary.LittleEndian.PutUint16(toc[6:], uint16(len(e.value)))
+		}
+		k_off += len(e.key)
+	}
+
+	seal(buf)
+}
+
+func u64(values ...uint64) []byte {
+	buf := make([]byte, len(values)*8)
+	for i, v := range values {
+		binary.LittleEndian.PutUint64(buf[i*8:], v)
+	}
+	return buf
+}
+
+func omapEntry(oid, xid, paddr uint64) kv {
+	value := make([]byte, 16)
+	binary.LittleEndian.PutUint32(value[4:], testBlockSize)
+	binary.LittleEndian.PutUint64(value[8:], paddr)
+	return kv{key: u64(oid, xid), value: value}
+}
+
+func (self *testImage) putOMap(n int, tree int, entries []kv) {
+	buf := self.block(n)
+	putHeader(buf, uint64(n), testXID, OBJECT_TYPE_OMAP|OBJ_PHYSICAL)
+	binary.LittleEndian.PutUint64(buf[48:], uint64(tree))
+	seal(buf)
+
+	self.putNode(tree, uint64(tree), BTNODE_ROOT|BTNODE_LEAF, 0, entries, 16, 16)
+}
+
+func jKey(oid uint64, record_type uint8) []byte {
+	return u64(oid | uint64(record_type)<<OBJ_TYPE_SHIFT)
+}
+
+func inodeRecord(oid uint64, mode uint16, bsd_flags uint32, size int64) kv {
+	value := make([]byte, J_INODE_VAL_SIZE)
+	binary.LittleEndian.PutUint64(value, ROOT_DIR_INO_NUM)
+	binary.LittleEndian.PutUint64(value[8:], oid)
+	binary.LittleEndian.PutUint64(value[16:], uint64(testCreated.UnixNano()))
+	binary.LittleEndian.PutUint64(value[24:], uint64(testModified.UnixNano()))
+	binary.LittleEndian.PutUint32(value[56:], 1)
+	binary.LittleEndian.PutUint32(value[68:], bsd_flags)
+	binary.LittleEndian.PutUint32(value[72:], 501)
+	binary.LittleEndian.PutUint32(value[76:], 20)
+	binary.LittleEndian.PutUint16(value[80:], mode)
+
+	if size >= 0 {
+		xfields := make([]byte, 8+40)
+		binary.LittleEndian.PutUint16(xfields, 1)
+		binary.LittleEndian.PutUint16(xfields[2:], 40)
+		xfields[4] = INO_EXT_TYPE_DSTREAM
+		binary.LittleEndian.PutUint16(xfields[6:], 40)
+		binary.LittleEndian.PutUint64(xfields[8:], uint64(size))
+		value = append(value, xfields...)
+	}
+
+	return kv{key: jKey(oid, APFS_TYPE_INODE), value: value}
+}
+
+func dirRecord(parent uint64, name string, oid uint64) kv {
+	key := jKey(parent, APFS_TYPE_DIR_REC)
+	length := make([]byte, 4)
+	binary.LittleEndian.PutUint32(length, uint32(len(name)+1)|0xabc<<10)
+	key = append(append(key, length...), append([]byte(name), 0)...)
+
+	value := make([]byte, 18)
+	binary.LittleEndian.PutUint64(value, oid)
+	binary.LittleEndian.PutUint64(value[8:], uint64(testModified.UnixNano()))
+	return kv{key: key, value: value}
+}
+
+func xattrRecord(oid uint64, name string, flags uint16, data []byte) kv {
+	key := jKey(oid, APFS_TYPE_XATTR)
+	length := make([]byte, 2)
+	binary.LittleEndian.PutUint16(length, uint16(len(name)+1))
+	key = append(append(key, length...), append([]byte(name), 0)...)
+
+	value := make([]byte, 4)
+	binary.LittleEndian.PutUint16(value, flags)
+	binary.LittleEndian.PutUint16(value[2:], uint16(len(data)))
+	return kv{key: key, value: append(value, data...)}
+}
+
+func streamXAttr(oid uint64, name string, stream_id, size uint64) kv {
+	return xattrRecord(oid, name, XATTR_DATA_STREAM,
+		append(u64(stream_id, size), make([]byte, 32)...))
+}
+
+func extentRecord(oid, logical, length, block uint64) kv {
+	return kv{
+		key:   append(jKey(oid, APFS_TYPE_FILE_EXTENT), u64(logical)...),
+		value: u64(length, block, 0),
+	}
+}
+
+func (self *testImage) putVolume(n int, xid uint64, name string) {
+	buf := self.block(n)
+	putHeader(buf, testVolumeOID, xid, OBJECT_TYPE_FS)
+	binary.LittleEndian.PutUint32(buf[32:], APFS_MAGIC)
+	binary.LittleEndian.PutUint64(buf[56:], APFS_INCOMPAT_CASE_INSENSITIVE)
+	binary.LittleEndian.PutUint32(buf[116:], OBJECT_TYPE_BTREE)
+	binary.LittleEndian.PutUint64(buf[128:], 6)
+	binary.LittleEndian.PutUint64(buf[136:], testTreeOID)
+	binary.LittleEndian.PutUint64(buf[152:], 10)
+	binary.LittleEndian.PutUint64(buf[184:], 3)
+	binary.LittleEndian.PutUint64(buf[192:], 1)
+	copy(buf[240:], "0123456789abcdef")
+	binary.LittleEndian.PutUint64(buf[264:], APFS_FS_UNENCRYPTED)
+	binary.LittleEndian.PutUint64(buf[304:], uint64(testFormatted.UnixNano()))
+	copy(buf[704:], name)
+	binary.LittleEndian.PutUint16(buf[964:], 0x40)
+	seal(buf)
+}
+
+func (self *testImage) putSuperblock(n int, xid uint64, omap uint64) {
+	buf := self.block(n)
+	putHeader(buf, 1, xid, OBJECT_TYPE_NX_SUPERBLOCK|0x80000000)
+	binary.LittleEndian.PutUint32(buf[32:], NX_MAGIC)
+	binary.LittleEndian.PutUint32(buf[36:], testBlockSize)
+	binary.LittleEndian.PutUint64(buf[40:], testBlocks)
+	binary.LittleEndian.PutUint32(buf[104:], 2)
+	binary.LittleEndian.PutUint64(buf[112:], 1)
+	binary.LittleEndian.PutUint64(buf[160:], omap)
+	binary.LittleEndian.PutUint32(buf[180:], NX_MAX_FILE_SYSTEMS)
+	binary.LittleEndian.PutUint64(buf[184:], testVolumeOID)
+	seal(buf)
+}
+
+// A resource fork holding a single zlib compressed block.
+func compressedResourceFork(data []byte) []byte {
+	b := &bytes.Buffer{}
+	w := zlib.NewWriter(b)
+	w.Write(data)
+	w.Close()
+
+	result := make([]byte, 0x104+12)
+	binary.BigEndian.PutUint32(result, 0x100)
+	binary.BigEndian.PutUint32(result[0x100:], uint32(12+b.Len()))
+	binary.LittleEndian.PutUint32(result[0x104:], 1)
+	binary.LittleEndian.PutUint32(result[0x108:], 12)
+	binary.LittleEndian.PutUint32(result[0x10c:], uint32(b.Len()))
+	return append(result, b.Bytes()...)
+}
+
+// Layout of the test image in blocks:
+// 0: Stale container superblock
+// 1: Latest container superblock in the checkpoint area
+// 3-4: Container object map
+// 5: Volume superblock
+// 6-7: Volume object map
+// 8: File system tree as of the snapshot
+// 9: Index node of the current file system tree
+// 10: Snapshot metadata tree
+// 11-12: Leaf nodes of the current file system tree
+// 13: Volume superblock of the snapshot
+// 14: Content of hello.txt
+// 15: Resource fork of hello.txt
+// 16: Resource fork of compressed.txt
+func buildImage() []byte {
+	image := &testImage{data: make([]byte, testBlocks*testBlockSize)}
+
+	// The stale superblock points at an invalid object map.
+	image.putSuperblock(0, 1, 19)
+	image.putSuperblock(1, testXID, 3)
+
+	image.putOMap(3, 4, []kv{omapEntry(testVolumeOID, testXID, 5)})
+	image.putVolume(5, testXID, "Data")
+	image.putVolume(13, testSnapshotXID, "Data")
+
+	image.putOMap(6, 7, []kv{
+		omapEntry(testTreeOID, testSnapshotXID, 8),
+		omapEntry(testTreeOID, testXID, 9),
+		omapEntry(testLeafAOID, testXID, 11),
+		omapEntry(testLeafBOID, testXID, 12),
+	})
+
+	image.putNode(8, testTreeOID, BTNODE_ROOT|BTNODE_LEAF, 0, []kv{
+		inodeRecord(ROOT_DIR_INO_NUM, S_IFDIR|0755, 0, -1),
+		dirRecord(ROOT_DIR_INO_NUM, "old.txt", 20),
+		inodeRecord(20, S_IFREG|0644, 0, 0),
+	}, 0, 0)
+
+	// The current tree has two levels.
+	image.putNode(9, testTreeOID, BTNODE_ROOT, 1, []kv{
+		{key: jKey(ROOT_DIR_INO_NUM, APFS_TYPE_INODE), value: u64(testLeafAOID)},
+		{key: jKey(16, APFS_TYPE_INODE), value: u64(testLeafBOID)},
+	}, 0, 0)
+
+	image.putNode(11, testLeafAOID, BTNODE_LEAF, 0, []kv{
+		inodeRecord(ROOT_DIR_INO_NUM, S_IFDIR|0755, 0, -1),
+		dirRecord(ROOT_DIR_INO_NUM, "compressed.txt", 18),
+		dirRecord(ROOT_DIR_INO_NUM, "hello.txt", 16),
+		dirRecord(ROOT_DIR_INO_NUM, "link", 17),
+	}, 0, 0)
+
+	rsrc := compressedResourceFork(testCompressed)
+	decmpfs_header := make([]byte, decmpfs.DECMPFS_HEADER_SIZE)
+	binary.LittleEndian.PutUint32(decmpfs_header, decmpfs.DECMPFS_MAGIC)
+	binary.LittleEndian.PutUint32(decmpfs_header[4:], decmpfs.CMP_TYPE_RESOURCE_ZLIB)
+	binary.LittleEndian.PutUint64(decmpfs_header[8:], uint64(len(testCompressed)))
+
+	finder_info := make([]byte, 32)
+	copy(finder_info, "TEXTttxt")
+
+	image.putNode(12, testLeafBOID, BTNODE_LEAF, 0, []kv{
+		inodeRecord(16, S_IFREG|0644, 0, 10),
+		xattrRecord(16, "com.apple.FinderInfo", XATTR_DATA_EMBEDDED, finder_info),
+		streamXAttr(16, "com.apple.ResourceFork", 40, 8),
+		extentRecord(16, 0, testBlockSize, 14),
+		inodeRecord(17, S_IFLNK|0755, 0, -1),
+		xattrRecord(17, SYMLINK_XATTR,
+			XATTR_DATA_EMBEDDED|XATTR_FILE_SYSTEM_OWNED, []byte("hello.txt\x00")),
+		inodeRecord(18, S_IFREG|0644, decmpfs.UF_COMPRESSED, -1),
+		streamXAttr(18, "com.apple.ResourceFork", 41, uint64(len(rsrc))),
+		xattrRecord(18, "com.apple.decmpfs", XATTR_DATA_EMBEDDED, decmpfs_header),
+		extentRecord(40, 0, testBlockSize, 15),
+		extentRecord(41, 0, testBlockSize, 16),
+	}, 0, 0)
+
+	snapshot := make([]byte, 50)
+	binary.LittleEndian.PutUint64(snapshot[8:], 13)
+	binary.LittleEndian.PutUint64(snapshot[16:], uint64(testModified.UnixNano()))
+	binary.LittleEndian.PutUint16(snapshot[48:], 6)
+	image.putNode(10, 10, BTNODE_ROOT|BTNODE_LEAF, 0, []kv{{
+		key:   jKey(testSnapshotXID, APFS_TYPE_SNAP_METADATA),
+		value: append(snapshot, "snap1\x00"...),
+	}}, 0, 0)
+
+	copy(image.block(14), "hello apfs")
+	copy(image.block(15), "resource")
+	copy(image.block(16), rsrc)
+
+	return image.data
+}
+
+func TestAPFS(t *testing.T) {
+	dir := t.TempDir()
+	image := filepath.Join(dir, "apfs.dd")
+	assert.NoError(t, os.WriteFile(image, buildImage(), 0600))
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	accessor, err := accessors.GetAccessor("raw_apfs", scope)
+	assert.NoError(t, err)
+
+	root := accessors.MustNewLinuxOSPath("")
+	root.SetPathSpec(&accessors.PathSpec{
+		DelegateAccessor: "file",
+		DelegatePath:     image,
+	})
+
+	list := func(path *accessors.OSPath) []string {
+		children, err := accessor.ReadDirWithOSPath(path)
+		assert.NoError(t, err)
+
+		result := []string{}
+		for _, c := range children {
+			result = append(result, c.Name())
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	read := func(path *accessors.OSPath) string {
+		fd, err := accessor.OpenWithOSPath(path)
+		assert.NoError(t, err)
+		defer fd.Close()
+
+		data, err := ioutil.ReadAll(fd)
+		assert.NoError(t, err)
+		return string(data)
+	}
+
+	// The top level lists the volumes.
+	assert.Equal(t, []string{"Data"}, list(root))
+
+	info, err := accessor.LstatWithOSPath(root.Append("Data"))
+	assert.NoError(t, err)
+	assert.True(t, info.IsDir())
+	assert.Equal(t, testFormatted, info.Btime())
+	snapshots, _ := info.Data().Get("Snapshots")
+	assert.Equal(t, []string{"snap1"}, snapshots)
+
+	volume := root.Append("Data")
+	assert.Equal(t, []string{"compressed.txt", "hello.txt", "link"},
+		list(volume))
+
+	info, err = accessor.LstatWithOSPath(volume.Append("hello.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), info.Size())
+	assert.Equal(t, testCreated, info.Btime())
+	assert.Equal(t, testModified, info.Mtime())
+	assert.Equal(t, "-rw-r--r--", info.Mode().String())
+	assert.Equal(t, "hello apfs", read(volume.Append("hello.txt")))
+
+	// The volume is case insensitive.
+	assert.Equal(t, "hello apfs", read(volume.Append("HELLO.TXT")))
+
+	xattrs, ok := info.(accessors.ExtendedAttributes)
+	assert.True(t, ok)
+
+	names, err := xattrs.ListXAttr()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"com.apple.FinderInfo", "com.apple.ResourceFork"},
+		names)
+
+	value, err := xattrs.GetXAttr("com.apple.ResourceFork")
+	assert.NoError(t, err)
+	assert.Equal(t, "resource", string(value))
+
+	// Compressed files are decompressed from the resource fork and
+	// the attributes implementing compression are hidden.
+	info, err = accessor.LstatWithOSPath(volume.Append("compressed.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(testCompressed)), info.Size())
+	assert.Equal(t, string(testCompressed), read(volume.Append("compressed.txt")))
+
+	names, err = info.(accessors.ExtendedAttributes).ListXAttr()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, names)
+
+	info, err = accessor.LstatWithOSPath(volume.Append("link"))
+	assert.NoError(t, err)
+	assert.True(t, info.IsLink())
+	target, err := info.GetLink()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Data", "hello.txt"}, target.Components)
+
+	// Snapshots see the tree as of their transaction.
+	assert.Equal(t, []string{"old.txt"}, list(root.Append("Data@snap1")))
+
+	info, err = accessor.LstatWithOSPath(root.Append("Data@snap1"))
+	assert.NoError(t, err)
+	snapshot, _ := info.Data().Get("Snapshot")
+	assert.Equal(t, "snap1", snapshot)
+}
@@ -0,0 +1,254 @@
+package apfs
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	BTNODE_ROOT          = 0x0001
+	BTNODE_LEAF          = 0x0002
+	BTNODE_FIXED_KV_SIZE = 0x0004
+
+	BTREE_PHYSICAL = 0x00000010
+
+	BTREE_INFO_SIZE = 40
+	BTNODE_DATA     = 56
+
+	// Values at this offset are ghosts without a value.
+	BTOFF_INVALID = 0xffff
+
+	MAX_BTREE_DEPTH = 16
+)
+
+type btreeNode struct {
+	buf   []byte
+	flags uint16
+	level uint16
+	nkeys uint32
+
+	toc_start int
+	key_start int
+	val_end   int
+
+	key_size int
+	val_size int
+}
+
+func (self *btreeNode) IsLeaf() bool {
+	return self.flags&BTNODE_LEAF != 0
+}
+
+// Get the key and value of the entry.
+func (self *btreeNode) Entry(i int) ([]byte, []byte, error) {
+	var k_off, k_len, v_off, v_len int
+
+	if self.flags&BTNODE_FIXED_KV_SIZE != 0 {
+		entry := self.toc_start + i*4
+		k_off = int(binary.LittleEndian.Uint16(self.buf[entry:]))
+		v_off = int(binary.LittleEndian.Uint16(self.buf[entry+2:]))
+		k_len = self.key_size
+		v_len = self.val_size
+
+		// Non leaf nodes always hold child object ids.
+		if !self.IsLeaf() {
+			v_len = 8
+		}
+
+	} else {
+		entry := self.toc_start + i*8
+		k_off = int(binary.LittleEndian.Uint16(self.buf[entry:]))
+		k_len = int(binary.LittleEndian.Uint16(self.buf[entry+2:]))
+		v_off = int(binary.LittleEndian.Uint16(self.buf[entry+4:]))
+		v_len = int(binary.LittleEndian.Uint16(self.buf[entry+6:]))
+	}
+
+	key_start := self.key_start + k_off
+	if key_start+k_len > self.val_end {
+		return nil, nil, fmt.Errorf("apfs: Invalid key in B-tree node")
+	}
+	key := self.buf[key_start : key_start+k_len]
+
+	if v_off == BTOFF_INVALID {
+		return key, nil, nil
+	}
+
+	val_start := self.val_end - v_off
+	if val_start < self.key_start || val_start+v_len > self.val_end {
+		return nil, nil, fmt.Errorf("apfs: Invalid value in B-tree node")
+	}
+
+	return key, self.buf[val_start : val_start+v_len], nil
+}
+
+// A B-tree. Nodes of virtual trees are found through an object map.
+type BTree struct {
+	container *Container
+
+	root uint64
+
+	// Used to resolve the child nodes of virtual trees.
+	omap *OMap
+	xid  uint64
+
+	flags    uint32
+	key_size int
+	val_size int
+}
+
+// Open a B-tree from its root node. If omap is set the root is a
+// virtual object.
+func (self *Container) NewBTree(root uint64, omap *OMap, xid uint64) (
+	*BTree, error) {
+	if omap != nil {
+		paddr, err := omap.Lookup(root, xid)
+		if err != nil {
+			return nil, err
+		}
+		root = paddr
+	}
+
+	buf, _, err := self.readObject(root, OBJECT_TYPE_BTREE)
+	if err != nil {
+		return nil, err
+	}
+
+	info := buf[len(buf)-BTREE_INFO_SIZE:]
+	result := &BTree{
+		container: self,
+		root:      root,
+		omap:      omap,
+		xid:       xid,
+		flags:     binary.LittleEndian.Uint32(info),
+		key_size:  int(binary.LittleEndian.Uint32(info[8:])),
+		val_size:  int(binary.LittleEndian.Uint32(info[12:])),
+	}
+
+	// The child nodes of physical trees are addressed directly.
+	if result.flags&BTREE_PHYSICAL != 0 {
+		result.omap = nil
+	}
+
+	return result, nil
+}
+
+func (self *BTree) readNode(paddr uint64, is_root bool) (*btreeNode, error) {
+	object_type := uint32(OBJECT_TYPE_BTREE_NODE)
+	if is_root {
+		object_type = OBJECT_TYPE_BTREE
+	}
+
+	buf, _, err := self.container.readObject(paddr, object_type)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &btreeNode{
+		buf:      buf,
+		flags:    binary.LittleEndian.Uint16(buf[32:]),
+		level:    binary.LittleEndian.Uint16(buf[34:]),
+		nkeys:    binary.LittleEndian.Uint32(buf[36:]),
+		key_size: self.key_size,
+		val_size: self.val_size,
+		val_end:  len(buf),
+	}
+
+	toc_off := int(binary.LittleEndian.Uint16(buf[40:]))
+	toc_len := int(binary.LittleEndian.Uint16(buf[42:]))
+
+	result.toc_start = BTNODE_DATA + toc_off
+	result.key_start = result.toc_start + toc_len
+
+	// The root node holds the tree info at the end.
+	if result.flags&BTNODE_ROOT != 0 {
+		result.val_end -= BTREE_INFO_SIZE
+	}
+
+	entry_size := 8
+	if result.flags&BTNODE_FIXED_KV_SIZE != 0 {
+		entry_size = 4
+	}
+
+	if result.key_start > result.val_end ||
+		int(result.nkeys)*entry_size > toc_len {
+		return nil, fmt.Errorf("apfs: Invalid B-tree node at block %v", paddr)
+	}
+
+	return result, nil
+}
+
+// Calls cb with all the leaf entries in the range selected by
+// cmp. The cmp function returns -1 for keys before the range, 0 for
+// keys inside it and 1 for keys after it.
+func (self *BTree) Scan(cmp func(key []byte) int,
+	cb func(key, value []byte) error) error {
+	_, err := self.scan(self.root, true, 0, cmp, cb)
+	return err
+}
+
+// Returns true when the end of the range was reached.
+func (self *BTree) scan(paddr uint64, is_root bool, depth int,
+	cmp func(key []byte) int,
+	cb func(key, value []byte) error) (bool, error) {
+	if depth > MAX_BTREE_DEPTH {
+		return false, fmt.Errorf("apfs: B-tree too deep")
+	}
+
+	node, err := self.readNode(paddr, is_root)
+	if err != nil {
+		return false, err
+	}
+
+	nkeys := int(node.nkeys)
+	for i := 0; i < nkeys; i++ {
+		key, value, err := node.Entry(i)
+		if err != nil {
+			return false, err
+		}
+
+		c := cmp(key)
+		if c > 0 {
+			return true, nil
+		}
+
+		if node.IsLeaf() {
+			if c == 0 && value != nil {
+				err = cb(key, value)
+				if err != nil {
+					return false, err
+				}
+			}
+			continue
+		}
+
+		// Skip children which end before the range starts.
+		if i+1 < nkeys {
+			next_key, _, err := node.Entry(i + 1)
+			if err != nil {
+				return false, err
+			}
+			if cmp(next_key) < 0 {
+				continue
+			}
+		}
+
+		if len(value) < 8 {
+			return false, fmt.Errorf("apfs: Invalid B-tree index entry")
+		}
+
+		child := binary.LittleEndian.Uint64(value)
+		if self.omap != nil {
+			child, err = self.omap.Lookup(child, self.xid)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		done, err := self.scan(child, false, depth+1, cmp, cb)
+		if err != nil || done {
+			return done, err
+		}
+	}
+
+	return false, nil
+}
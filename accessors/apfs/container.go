@@ -0,0 +1,219 @@
+package apfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	NX_MAGIC            = 0x4253584e // NXSB
+	NX_MAX_FILE_SYSTEMS = 100
+
+	NX_MINIMUM_BLOCK_SIZE = 4096
+	NX_MAXIMUM_BLOCK_SIZE = 65536
+
+	// The checkpoint descriptor area is a B-tree rather than a
+	// contiguous range of blocks.
+	XP_DESC_BLOCKS_BTREE = 0x80000000
+)
+
+type NXSuperblock struct {
+	XID        uint64
+	BlockSize  uint32
+	BlockCount uint64
+	UUID       string
+
+	XPDescBlocks uint32
+	XPDescBase   uint64
+
+	OMapOID uint64
+	FSOIDs  []uint64
+}
+
+func parseNXSuperblock(buf []byte) (*NXSuperblock, error) {
+	if len(buf) < 184+NX_MAX_FILE_SYSTEMS*8 ||
+		binary.LittleEndian.Uint32(buf[32:]) != NX_MAGIC {
+		return nil, errors.New("apfs: Invalid container superblock")
+	}
+
+	result := &NXSuperblock{
+		XID:          binary.LittleEndian.Uint64(buf[16:]),
+		BlockSize:    binary.LittleEndian.Uint32(buf[36:]),
+		BlockCount:   binary.LittleEndian.Uint64(buf[40:]),
+		UUID:         formatUUID(buf[72:88]),
+		XPDescBlocks: binary.LittleEndian.Uint32(buf[104:]),
+		XPDescBase:   binary.LittleEndian.Uint64(buf[112:]),
+		OMapOID:      binary.LittleEndian.Uint64(buf[160:]),
+	}
+
+	max_file_systems := int(binary.LittleEndian.Uint32(buf[180:]))
+	if max_file_systems > NX_MAX_FILE_SYSTEMS {
+		max_file_systems = NX_MAX_FILE_SYSTEMS
+	}
+
+	for i := 0; i < max_file_systems; i++ {
+		oid := binary.LittleEndian.Uint64(buf[184+i*8:])
+		if oid != 0 {
+			result.FSOIDs = append(result.FSOIDs, oid)
+		}
+	}
+
+	if result.BlockSize < NX_MINIMUM_BLOCK_SIZE ||
+		result.BlockSize > NX_MAXIMUM_BLOCK_SIZE ||
+		result.BlockSize&(result.BlockSize-1) != 0 {
+		return nil, fmt.Errorf("apfs: Invalid block size %v", result.BlockSize)
+	}
+
+	return result, nil
+}
+
+func formatUUID(buf []byte) string {
+	return fmt.Sprintf("%X-%X-%X-%X-%X",
+		buf[0:4], buf[4:6], buf[6:8], buf[8:10], buf[10:16])
+}
+
+// An APFS container holds a number of volumes sharing the same
+// space.
+type Container struct {
+	reader     io.ReaderAt
+	BlockSize  uint64
+	Superblock *NXSuperblock
+
+	omap *OMap
+
+	mu      sync.Mutex
+	volumes map[string]*Volume
+}
+
+func NewContainer(reader io.ReaderAt) (*Container, error) {
+	buf := make([]byte, NX_MINIMUM_BLOCK_SIZE)
+	n, err := reader.ReadAt(buf, 0)
+	if n < len(buf) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("apfs: Reading container superblock: %w", err)
+	}
+
+	superblock, err := parseNXSuperblock(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Container{
+		reader:    reader,
+		BlockSize: uint64(superblock.BlockSize),
+		volumes:   make(map[string]*Volume),
+	}
+
+	// The copy in block 0 may be stale so use the latest valid
+	// superblock from the checkpoint area.
+	result.Superblock, err = result.latestSuperblock(superblock)
+	if err != nil {
+		return nil, err
+	}
+
+	result.omap, err = result.NewOMap(result.Superblock.OMapOID)
+	if err != nil {
+		return nil, fmt.Errorf("apfs: Container object map: %w", err)
+	}
+
+	return result, nil
+}
+
+func (self *Container) latestSuperblock(
+	superblock *NXSuperblock) (*NXSuperblock, error) {
+	result := superblock
+
+	// Use the block 0 copy when the descriptor area is not
+	// contiguous.
+	if superblock.XPDescBlocks&XP_DESC_BLOCKS_BTREE != 0 {
+		return result, nil
+	}
+
+	for i := uint64(0); i < uint64(superblock.XPDescBlocks); i++ {
+		buf, err := self.readBlock(superblock.XPDescBase + i)
+		if err != nil || !verifyChecksum(buf) ||
+			parseObjectHeader(buf).ObjectType() != OBJECT_TYPE_NX_SUPERBLOCK {
+			continue
+		}
+
+		candidate, err := parseNXSuperblock(buf)
+		if err != nil || candidate.BlockSize != superblock.BlockSize {
+			continue
+		}
+
+		if candidate.XID > result.XID {
+			result = candidate
+		}
+	}
+
+	return result, nil
+}
+
+// Read the superblocks of all volumes in the container.
+func (self *Container) Volumes() ([]*Volume, error) {
+	result := []*Volume{}
+	for _, oid := range self.Superblock.FSOIDs {
+		paddr, err := self.omap.Lookup(oid, self.Superblock.XID)
+		if err != nil {
+			return nil, err
+		}
+
+		volume, err := self.newVolume(paddr, self.Superblock.XID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, volume)
+	}
+	return result, nil
+}
+
+// Open a volume by name. Snapshots are named by the volume name and
+// the snapshot name separated by @.
+func (self *Container) OpenVolume(name string) (*Volume, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	result, pres := self.volumes[name]
+	if pres {
+		return result, nil
+	}
+
+	volumes, err := self.Volumes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, volume := range volumes {
+		if volume.Name == name {
+			result = volume
+			break
+		}
+
+		if strings.HasPrefix(name, volume.Name+"@") {
+			result, err = volume.OpenSnapshot(
+				strings.TrimPrefix(name, volume.Name+"@"))
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("apfs: Volume %v not found", name)
+	}
+
+	err = result.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	self.volumes[name] = result
+	return result, nil
+}
@@ -0,0 +1,265 @@
+package apfs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"www.velocidex.com/golang/velociraptor/accessors/decmpfs"
+)
+
+const (
+	SYMLINK_XATTR = "com.apple.fs.symlink"
+
+	// Refuse to read larger attributes into memory.
+	MAX_XATTR_SIZE = 64 * 1024 * 1024
+)
+
+// Reads a data stream from its extents.
+type streamReader struct {
+	container *Container
+	extents   []*FileExtent
+	size      int64
+}
+
+func (self *Volume) StreamReader(stream_id uint64, size uint64) (
+	*streamReader, error) {
+	extents, err := self.Extents(stream_id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamReader{
+		container: self.container,
+		extents:   extents,
+		size:      int64(size),
+	}, nil
+}
+
+func (self *streamReader) ReadAt(buf []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, errors.New("apfs: Negative offset")
+	}
+
+	if offset >= self.size {
+		return 0, io.EOF
+	}
+
+	to_read := int64(len(buf))
+	if offset+to_read > self.size {
+		to_read = self.size - offset
+	}
+
+	total := int64(0)
+	for total < to_read {
+		current := uint64(offset + total)
+
+		// Find the first extent which ends after the offset.
+		idx := sort.Search(len(self.extents), func(i int) bool {
+			e := self.extents[i]
+			return e.Logical+e.Length > current
+		})
+
+		var n int64
+		if idx >= len(self.extents) || self.extents[idx].Logical > current {
+			// Holes read as zeros.
+			n = to_read - total
+			if idx < len(self.extents) {
+				if gap := int64(self.extents[idx].Logical - current); gap < n {
+					n = gap
+				}
+			}
+			for i := int64(0); i < n; i++ {
+				buf[total+i] = 0
+			}
+
+		} else {
+			e := self.extents[idx]
+			n = int64(e.Logical + e.Length - current)
+			if n > to_read-total {
+				n = to_read - total
+			}
+
+			// Sparse extents have no physical blocks.
+			if e.Physical == 0 {
+				for i := int64(0); i < n; i++ {
+					buf[total+i] = 0
+				}
+
+			} else {
+				physical := int64(e.Physical*self.container.BlockSize +
+					current - e.Logical)
+				read, err := self.container.reader.ReadAt(
+					buf[total:total+n], physical)
+				if int64(read) < n {
+					if err == nil || err == io.EOF {
+						err = io.ErrUnexpectedEOF
+					}
+					return int(total) + read, err
+				}
+			}
+		}
+
+		total += n
+	}
+
+	if total < int64(len(buf)) {
+		return int(total), io.EOF
+	}
+	return int(total), nil
+}
+
+func (self *Volume) ReadXAttr(xattr *XAttr) ([]byte, error) {
+	if xattr.StreamID == 0 {
+		return xattr.Data, nil
+	}
+
+	if xattr.StreamSize > MAX_XATTR_SIZE {
+		return nil, fmt.Errorf("apfs: Attribute too large (%v bytes)",
+			xattr.StreamSize)
+	}
+
+	reader, err := self.StreamReader(xattr.StreamID, xattr.StreamSize)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, xattr.StreamSize)
+	n, err := reader.ReadAt(buf, 0)
+	if n < len(buf) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Get a reader over the file's content. Compressed files are
+// decompressed transparently.
+func (self *Volume) Reader(inode *Inode) (io.ReaderAt, int64, error) {
+	if inode.IsDir() {
+		return nil, 0, errors.New("apfs: Not a file")
+	}
+
+	if inode.IsCompressed() {
+		xattr, err := self.GetXAttr(inode.ID, decmpfs.XATTR_NAME)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		data, err := self.ReadXAttr(xattr)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var rsrc io.ReaderAt
+		rsrc_xattr, err := self.GetXAttr(inode.ID, decmpfs.RESOURCE_FORK_XATTR)
+		if err == nil {
+			rsrc = bytes.NewReader(rsrc_xattr.Data)
+			if rsrc_xattr.StreamID != 0 {
+				rsrc, err = self.StreamReader(
+					rsrc_xattr.StreamID, rsrc_xattr.StreamSize)
+				if err != nil {
+					return nil, 0, err
+				}
+			}
+		}
+
+		return decmpfs.NewReader(data, rsrc)
+	}
+
+	if !inode.HasStream {
+		return &streamReader{container: self.container}, 0, nil
+	}
+
+	reader, err := self.StreamReader(inode.PrivateID, inode.Size)
+	if err != nil {
+		return nil, 0, err
+	}
+	return reader, reader.size, nil
+}
+
+// The size of the file content. For compressed files this is the
+// uncompressed size.
+func (self *Volume) FileSize(inode *Inode) int64 {
+	if !inode.IsCompressed() {
+		return int64(inode.Size)
+	}
+
+	xattr, err := self.GetXAttr(inode.ID, decmpfs.XATTR_NAME)
+	if err != nil {
+		return 0
+	}
+
+	data, err := self.ReadXAttr(xattr)
+	if err != nil {
+		return 0
+	}
+
+	header, err := decmpfs.ParseHeader(data)
+	if err != nil {
+		return 0
+	}
+	return header.Size
+}
+
+// Symlink targets are stored in an extended attribute.
+func (self *Volume) Readlink(inode *Inode) (string, error) {
+	if !inode.IsLink() {
+		return "", errors.New("apfs: Not a symlink")
+	}
+
+	xattr, err := self.GetXAttr(inode.ID, SYMLINK_XATTR)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := self.ReadXAttr(xattr)
+	if err != nil {
+		return "", err
+	}
+	return cString(data), nil
+}
+
+// Extended attributes as presented by macOS. Attributes owned by the
+// filesystem and those implementing compression are hidden.
+func (self *Volume) ListXAttr(inode *Inode) ([]string, error) {
+	xattrs, err := self.XAttrs(inode.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for _, xattr := range xattrs {
+		if !self.isHiddenXAttr(inode, xattr) {
+			result = append(result, xattr.Name)
+		}
+	}
+	return result, nil
+}
+
+func (self *Volume) isHiddenXAttr(inode *Inode, xattr *XAttr) bool {
+	if xattr.IsFileSystemOwned() || xattr.Name == SYMLINK_XATTR ||
+		xattr.Name == decmpfs.XATTR_NAME {
+		return true
+	}
+
+	return inode.IsCompressed() && xattr.Name == decmpfs.RESOURCE_FORK_XATTR
+}
+
+func (self *Volume) ReadXAttrByName(inode *Inode, name string) ([]byte, error) {
+	xattr, err := self.GetXAttr(inode.ID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if self.isHiddenXAttr(inode, xattr) {
+		return nil, os.ErrNotExist
+	}
+
+	return self.ReadXAttr(xattr)
+}
@@ -0,0 +1,417 @@
+package apfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/accessors/decmpfs"
+)
+
+const (
+	OBJ_ID_MASK     = 0x0fffffffffffffff
+	OBJ_TYPE_SHIFT  = 60
+	J_DREC_LEN_MASK = 0x000003ff
+
+	APFS_TYPE_SNAP_METADATA = 1
+	APFS_TYPE_INODE         = 3
+	APFS_TYPE_XATTR         = 4
+	APFS_TYPE_FILE_EXTENT   = 8
+	APFS_TYPE_DIR_REC       = 9
+
+	ROOT_DIR_INO_NUM = 2
+
+	J_INODE_VAL_SIZE = 92
+
+	INO_EXT_TYPE_NAME    = 4
+	INO_EXT_TYPE_DSTREAM = 8
+
+	XATTR_DATA_STREAM       = 0x0001
+	XATTR_DATA_EMBEDDED     = 0x0002
+	XATTR_FILE_SYSTEM_OWNED = 0x0004
+
+	J_FILE_EXTENT_LEN_MASK = 0x00ffffffffffffff
+
+	S_IFMT   = 0170000
+	S_IFIFO  = 0010000
+	S_IFCHR  = 0020000
+	S_IFDIR  = 0040000
+	S_IFBLK  = 0060000
+	S_IFREG  = 0100000
+	S_IFLNK  = 0120000
+	S_IFSOCK = 0140000
+)
+
+func splitKeyHeader(key []byte) (uint64, uint8) {
+	value := binary.LittleEndian.Uint64(key)
+	return value & OBJ_ID_MASK, uint8(value >> OBJ_TYPE_SHIFT)
+}
+
+// Select all the records of a type for an object.
+func recordRange(oid uint64, record_type uint8) func(key []byte) int {
+	return func(key []byte) int {
+		if len(key) < 8 {
+			return -1
+		}
+		key_oid, key_type := splitKeyHeader(key)
+		switch {
+		case key_oid < oid:
+			return -1
+		case key_oid > oid:
+			return 1
+		case key_type < record_type:
+			return -1
+		case key_type > record_type:
+			return 1
+		}
+		return 0
+	}
+}
+
+func cString(buf []byte) string {
+	if idx := bytes.IndexByte(buf, 0); idx >= 0 {
+		buf = buf[:idx]
+	}
+	return string(buf)
+}
+
+type Inode struct {
+	ID        uint64
+	ParentID  uint64
+	PrivateID uint64
+
+	Created  time.Time
+	Modified time.Time
+	Changed  time.Time
+	Accessed time.Time
+
+	// The number of children for directories or links for files.
+	NChildren int32
+
+	BSDFlags uint32
+	Owner    uint32
+	Group    uint32
+	Mode     uint16
+
+	Name string
+
+	// The size of the data stream.
+	Size      uint64
+	HasStream bool
+}
+
+// Extended fields follow the fixed part of inodes and directory
+// entries. Each value is padded to 8 bytes.
+func parseXFields(buf []byte) map[uint8][]byte {
+	result := make(map[uint8][]byte)
+	if len(buf) < 4 {
+		return result
+	}
+
+	count := int(binary.LittleEndian.Uint16(buf))
+	offset := 4 + count*4
+	for i := 0; i < count && 4+i*4+4 <= len(buf); i++ {
+		field := buf[4+i*4:]
+		size := int(binary.LittleEndian.Uint16(field[2:]))
+		if offset+size > len(buf) {
+			break
+		}
+		result[field[0]] = buf[offset : offset+size]
+		offset += (size + 7) &^ 7
+	}
+	return result
+}
+
+func parseInode(id uint64, value []byte) (*Inode, error) {
+	if len(value) < J_INODE_VAL_SIZE {
+		return nil, fmt.Errorf("apfs: Inode %v too short", id)
+	}
+
+	result := &Inode{
+		ID:        id,
+		ParentID:  binary.LittleEndian.Uint64(value),
+		PrivateID: binary.LittleEndian.Uint64(value[8:]),
+		Created:   apfsTime(binary.LittleEndian.Uint64(value[16:])),
+		Modified:  apfsTime(binary.LittleEndian.Uint64(value[24:])),
+		Changed:   apfsTime(binary.LittleEndian.Uint64(value[32:])),
+		Accessed:  apfsTime(binary.LittleEndian.Uint64(value[40:])),
+		NChildren: int32(binary.LittleEndian.Uint32(value[56:])),
+		BSDFlags:  binary.LittleEndian.Uint32(value[68:]),
+		Owner:     binary.LittleEndian.Uint32(value[72:]),
+		Group:     binary.LittleEndian.Uint32(value[76:]),
+		Mode:      binary.LittleEndian.Uint16(value[80:]),
+	}
+
+	xfields := parseXFields(value[J_INODE_VAL_SIZE:])
+	if name, pres := xfields[INO_EXT_TYPE_NAME]; pres {
+		result.Name = cString(name)
+	}
+
+	if dstream, pres := xfields[INO_EXT_TYPE_DSTREAM]; pres && len(dstream) >= 8 {
+		result.Size = binary.LittleEndian.Uint64(dstream)
+		result.HasStream = true
+	}
+
+	return result, nil
+}
+
+func (self *Inode) IsDir() bool {
+	return self.Mode&S_IFMT == S_IFDIR
+}
+
+func (self *Inode) IsLink() bool {
+	return self.Mode&S_IFMT == S_IFLNK
+}
+
+func (self *Inode) IsCompressed() bool {
+	return self.BSDFlags&decmpfs.UF_COMPRESSED != 0
+}
+
+func (self *Inode) FileMode() os.FileMode {
+	perm := os.FileMode(self.Mode & 0777)
+
+	switch self.Mode & S_IFMT {
+	case S_IFDIR:
+		return perm | os.ModeDir
+	case S_IFLNK:
+		return perm | os.ModeSymlink
+	case S_IFIFO:
+		return perm | os.ModeNamedPipe
+	case S_IFCHR:
+		return perm | os.ModeDevice | os.ModeCharDevice
+	case S_IFBLK:
+		return perm | os.ModeDevice
+	case S_IFSOCK:
+		return perm | os.ModeSocket
+	}
+	return perm
+}
+
+type DirEntry struct {
+	Name      string
+	FileID    uint64
+	DateAdded time.Time
+	Type      uint16
+}
+
+func (self *Volume) GetInode(id uint64) (*Inode, error) {
+	var result *Inode
+	err := self.tree.Scan(recordRange(id, APFS_TYPE_INODE),
+		func(key, value []byte) (err error) {
+			result, err = parseInode(id, value)
+			return err
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("apfs: Inode %v not found", id)
+	}
+	return result, nil
+}
+
+func (self *Volume) ReadDir(id uint64) ([]*DirEntry, error) {
+	hashed := self.hashedNames()
+
+	result := []*DirEntry{}
+	err := self.tree.Scan(recordRange(id, APFS_TYPE_DIR_REC),
+		func(key, value []byte) error {
+			var name []byte
+			if hashed {
+				if len(key) < 12 {
+					return fmt.Errorf("apfs: Invalid directory entry")
+				}
+				length := int(binary.LittleEndian.Uint32(key[8:]) & J_DREC_LEN_MASK)
+				if 12+length > len(key) {
+					return fmt.Errorf("apfs: Invalid directory entry")
+				}
+				name = key[12 : 12+length]
+
+			} else {
+				if len(key) < 10 {
+					return fmt.Errorf("apfs: Invalid directory entry")
+				}
+				length := int(binary.LittleEndian.Uint16(key[8:]))
+				if 10+length > len(key) {
+					return fmt.Errorf("apfs: Invalid directory entry")
+				}
+				name = key[10 : 10+length]
+			}
+
+			if len(value) < 18 {
+				return fmt.Errorf("apfs: Invalid directory entry")
+			}
+
+			result = append(result, &DirEntry{
+				Name:      cString(name),
+				FileID:    binary.LittleEndian.Uint64(value),
+				DateAdded: apfsTime(binary.LittleEndian.Uint64(value[8:])),
+				Type:      binary.LittleEndian.Uint16(value[16:]) & 0x000f,
+			})
+			return nil
+		})
+
+	return result, err
+}
+
+// Find the entry with the name in the directory. Case insensitive
+// volumes fold the case of the name.
+func (self *Volume) FindChild(id uint64, name string) (*DirEntry, error) {
+	entries, err := self.ReadDir(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.Name == name {
+			return entry, nil
+		}
+	}
+
+	if !self.IsCaseSensitive() {
+		for _, entry := range entries {
+			if strings.EqualFold(entry.Name, name) {
+				return entry, nil
+			}
+		}
+	}
+
+	return nil, os.ErrNotExist
+}
+
+type XAttr struct {
+	Name  string
+	Flags uint16
+
+	// Small attributes are embedded in the record.
+	Data []byte
+
+	// Large attributes are stored in a data stream.
+	StreamID   uint64
+	StreamSize uint64
+}
+
+func (self *XAttr) IsFileSystemOwned() bool {
+	return self.Flags&XATTR_FILE_SYSTEM_OWNED != 0
+}
+
+func (self *Volume) XAttrs(id uint64) ([]*XAttr, error) {
+	result := []*XAttr{}
+	err := self.tree.Scan(recordRange(id, APFS_TYPE_XATTR),
+		func(key, value []byte) error {
+			if len(key) < 10 || len(value) < 4 {
+				return fmt.Errorf("apfs: Invalid extended attribute")
+			}
+
+			length := int(binary.LittleEndian.Uint16(key[8:]))
+			if 10+length > len(key) {
+				return fmt.Errorf("apfs: Invalid extended attribute name")
+			}
+
+			xattr := &XAttr{
+				Name:  cString(key[10 : 10+length]),
+				Flags: binary.LittleEndian.Uint16(value),
+			}
+
+			data_len := int(binary.LittleEndian.Uint16(value[2:]))
+			if 4+data_len > len(value) {
+				return fmt.Errorf("apfs: Invalid extended attribute %v",
+					xattr.Name)
+			}
+			data := value[4 : 4+data_len]
+
+			switch {
+			case xattr.Flags&XATTR_DATA_EMBEDDED != 0:
+				xattr.Data = data
+
+			case xattr.Flags&XATTR_DATA_STREAM != 0:
+				if len(data) < 16 {
+					return fmt.Errorf("apfs: Invalid extended attribute %v",
+						xattr.Name)
+				}
+				xattr.StreamID = binary.LittleEndian.Uint64(data)
+				xattr.StreamSize = binary.LittleEndian.Uint64(data[8:])
+			}
+
+			result = append(result, xattr)
+			return nil
+		})
+
+	return result, err
+}
+
+func (self *Volume) GetXAttr(id uint64, name string) (*XAttr, error) {
+	xattrs, err := self.XAttrs(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, xattr := range xattrs {
+		if xattr.Name == name {
+			return xattr, nil
+		}
+	}
+	return nil, os.ErrNotExist
+}
+
+type FileExtent struct {
+	Logical  uint64
+	Length   uint64
+	Physical uint64
+}
+
+// Get the extents of a data stream. Sealed volumes store them in
+// their own tree.
+func (self *Volume) Extents(stream_id uint64) ([]*FileExtent, error) {
+	result := []*FileExtent{}
+
+	if self.fext != nil {
+		err := self.fext.Scan(func(key []byte) int {
+			if len(key) < 16 {
+				return -1
+			}
+			key_id := binary.LittleEndian.Uint64(key)
+			switch {
+			case key_id < stream_id:
+				return -1
+			case key_id > stream_id:
+				return 1
+			}
+			return 0
+		}, func(key, value []byte) error {
+			if len(value) < 16 {
+				return fmt.Errorf("apfs: Invalid file extent")
+			}
+			result = append(result, &FileExtent{
+				Logical:  binary.LittleEndian.Uint64(key[8:]),
+				Length:   binary.LittleEndian.Uint64(value) & J_FILE_EXTENT_LEN_MASK,
+				Physical: binary.LittleEndian.Uint64(value[8:]),
+			})
+			return nil
+		})
+		return result, err
+	}
+
+	err := self.tree.Scan(recordRange(stream_id, APFS_TYPE_FILE_EXTENT),
+		func(key, value []byte) error {
+			if len(key) < 16 || len(value) < 16 {
+				return fmt.Errorf("apfs: Invalid file extent")
+			}
+			result = append(result, &FileExtent{
+				Logical:  binary.LittleEndian.Uint64(key[8:]),
+				Length:   binary.LittleEndian.Uint64(value) & J_FILE_EXTENT_LEN_MASK,
+				Physical: binary.LittleEndian.Uint64(value[8:]),
+			})
+			return nil
+		})
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Logical < result[j].Logical
+	})
+	return result, err
+}
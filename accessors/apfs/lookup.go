@@ -0,0 +1,65 @@
+package apfs
+
+import (
+	"fmt"
+	"os"
+)
+
+// The maximum number of path components we walk.
+const MAX_PATH_DEPTH = 256
+
+type LookupResult struct {
+	Volume *Volume
+	Inode  *Inode
+
+	// The directory entry which led to the inode. This is nil for
+	// the root directory of the volume.
+	Entry *DirEntry
+}
+
+// Walk the path from the root directory of the volume.
+func (self *Volume) Lookup(components []string) (*LookupResult, error) {
+	if len(components) > MAX_PATH_DEPTH {
+		return nil, fmt.Errorf("apfs: Path too deep")
+	}
+
+	inode, err := self.GetInode(ROOT_DIR_INO_NUM)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LookupResult{Volume: self, Inode: inode}
+	for _, name := range components {
+		if !result.Inode.IsDir() {
+			return nil, os.ErrNotExist
+		}
+
+		entry, err := self.FindChild(result.Inode.ID, name)
+		if err != nil {
+			return nil, err
+		}
+
+		inode, err := self.GetInode(entry.FileID)
+		if err != nil {
+			return nil, err
+		}
+
+		result = &LookupResult{Volume: self, Inode: inode, Entry: entry}
+	}
+
+	return result, nil
+}
+
+// The first component of the path selects the volume.
+func (self *Container) Lookup(components []string) (*LookupResult, error) {
+	if len(components) == 0 {
+		return nil, fmt.Errorf("apfs: No volume specified")
+	}
+
+	volume, err := self.OpenVolume(components[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return volume.Lookup(components[1:])
+}
@@ -0,0 +1,102 @@
+package apfs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	OBJ_HEADER_SIZE = 32
+
+	OBJECT_TYPE_MASK          = 0x0000ffff
+	OBJ_STORAGETYPE_MASK      = 0xc0000000
+	OBJ_PHYSICAL              = 0x40000000
+	OBJECT_TYPE_NX_SUPERBLOCK = 0x01
+	OBJECT_TYPE_BTREE         = 0x02
+	OBJECT_TYPE_BTREE_NODE    = 0x03
+	OBJECT_TYPE_OMAP          = 0x0b
+	OBJECT_TYPE_FS            = 0x0d
+)
+
+// The header common to all objects.
+type ObjectHeader struct {
+	Checksum uint64
+	OID      uint64
+	XID      uint64
+	Type     uint32
+	Subtype  uint32
+}
+
+func parseObjectHeader(buf []byte) *ObjectHeader {
+	return &ObjectHeader{
+		Checksum: binary.LittleEndian.Uint64(buf),
+		OID:      binary.LittleEndian.Uint64(buf[8:]),
+		XID:      binary.LittleEndian.Uint64(buf[16:]),
+		Type:     binary.LittleEndian.Uint32(buf[24:]),
+		Subtype:  binary.LittleEndian.Uint32(buf[28:]),
+	}
+}
+
+func (self *ObjectHeader) ObjectType() uint32 {
+	return self.Type & OBJECT_TYPE_MASK
+}
+
+// Objects are protected by a Fletcher 64 checksum over everything
+// after the checksum field.
+func fletcher64(buf []byte) uint64 {
+	var sum1, sum2 uint64
+	for i := 8; i+4 <= len(buf); i += 4 {
+		sum1 = (sum1 + uint64(binary.LittleEndian.Uint32(buf[i:]))) % 0xffffffff
+		sum2 = (sum2 + sum1) % 0xffffffff
+	}
+
+	c1 := 0xffffffff - (sum1+sum2)%0xffffffff
+	c2 := 0xffffffff - (sum1+c1)%0xffffffff
+	return c2<<32 | c1
+}
+
+func verifyChecksum(buf []byte) bool {
+	return len(buf) >= OBJ_HEADER_SIZE &&
+		binary.LittleEndian.Uint64(buf) == fletcher64(buf)
+}
+
+// Read the block at the physical address.
+func (self *Container) readBlock(paddr uint64) ([]byte, error) {
+	if paddr == 0 || (self.Superblock != nil &&
+		paddr >= self.Superblock.BlockCount) {
+		return nil, fmt.Errorf("apfs: Invalid block address %v", paddr)
+	}
+
+	buf := make([]byte, self.BlockSize)
+	n, err := self.reader.ReadAt(buf, int64(paddr*self.BlockSize))
+	if n < len(buf) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Read an object at the physical address and check its type.
+func (self *Container) readObject(paddr uint64, object_type uint32) (
+	[]byte, *ObjectHeader, error) {
+	buf, err := self.readBlock(paddr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !verifyChecksum(buf) {
+		return nil, nil, fmt.Errorf("apfs: Invalid checksum for block %v", paddr)
+	}
+
+	header := parseObjectHeader(buf)
+	if header.ObjectType() != object_type {
+		return nil, nil, fmt.Errorf(
+			"apfs: Unexpected object type %#x at block %v (expected %#x)",
+			header.ObjectType(), paddr, object_type)
+	}
+
+	return buf, header, nil
+}
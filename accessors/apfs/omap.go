@@ -0,0 +1,81 @@
+package apfs
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	OMAP_VAL_DELETED   = 0x00000001
+	OMAP_VAL_ENCRYPTED = 0x00000004
+)
+
+// An object map translates virtual object ids to physical addresses
+// as of a transaction.
+type OMap struct {
+	tree *BTree
+}
+
+func (self *Container) NewOMap(paddr uint64) (*OMap, error) {
+	buf, _, err := self.readObject(paddr, OBJECT_TYPE_OMAP)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := self.NewBTree(binary.LittleEndian.Uint64(buf[48:]), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OMap{tree: tree}, nil
+}
+
+// Find the most recent mapping of the object which is not newer than
+// the transaction.
+func (self *OMap) Lookup(oid, xid uint64) (uint64, error) {
+	var best_xid, paddr uint64
+	var flags uint32
+	found := false
+
+	err := self.tree.Scan(func(key []byte) int {
+		if len(key) < 16 {
+			return -1
+		}
+		key_oid := binary.LittleEndian.Uint64(key)
+		switch {
+		case key_oid < oid:
+			return -1
+		case key_oid > oid:
+			return 1
+		}
+		return 0
+	}, func(key, value []byte) error {
+		if len(value) < 16 {
+			return fmt.Errorf("apfs: Invalid object map entry")
+		}
+
+		key_xid := binary.LittleEndian.Uint64(key[8:])
+		if key_xid > xid || (found && key_xid < best_xid) {
+			return nil
+		}
+
+		found = true
+		best_xid = key_xid
+		flags = binary.LittleEndian.Uint32(value)
+		paddr = binary.LittleEndian.Uint64(value[8:])
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if !found || flags&OMAP_VAL_DELETED != 0 {
+		return 0, fmt.Errorf("apfs: Object %v not found in object map", oid)
+	}
+
+	if flags&OMAP_VAL_ENCRYPTED != 0 {
+		return 0, fmt.Errorf("apfs: Object %v is encrypted", oid)
+	}
+
+	return paddr, nil
+}
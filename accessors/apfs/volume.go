@@ -0,0 +1,252 @@
+package apfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	APFS_MAGIC = 0x42535041 // APSB
+
+	APFS_FS_UNENCRYPTED = 0x00000001
+
+	APFS_INCOMPAT_CASE_INSENSITIVE          = 0x00000001
+	APFS_INCOMPAT_NORMALIZATION_INSENSITIVE = 0x00000008
+	APFS_INCOMPAT_SEALED_VOLUME             = 0x00000020
+
+	APFS_VOLNAME_LEN = 256
+	APFS_SB_SIZE     = 1048
+)
+
+var volumeRoles = map[uint16]string{
+	0x0001: "System",
+	0x0002: "User",
+	0x0004: "Recovery",
+	0x0008: "VM",
+	0x0010: "Preboot",
+	0x0020: "Installer",
+	0x0040: "Data",
+	0x0080: "Baseband",
+	0x00c0: "Update",
+	0x0100: "xART",
+	0x0140: "Hardware",
+	0x0180: "Backup",
+	0x01c0: "Sidecar",
+	0x0200: "Enterprise",
+}
+
+func apfsTime(value uint64) time.Time {
+	if value == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(value)).UTC()
+}
+
+type Snapshot struct {
+	Name    string
+	XID     uint64
+	Created time.Time
+
+	// The physical address of the volume superblock.
+	superblock uint64
+}
+
+type Volume struct {
+	container *Container
+
+	Name       string
+	UUID       string
+	Role       string
+	Index      uint32
+	Created    time.Time
+	LastMod    time.Time
+	FSFlags    uint64
+	Incompat   uint64
+	NumFiles   uint64
+	NumFolders uint64
+
+	// Set when this is a snapshot of the volume.
+	Snapshot string
+
+	// The transaction of the volume or snapshot.
+	XID uint64
+
+	omap_oid       uint64
+	root_tree_oid  uint64
+	root_tree_type uint32
+	snap_meta_tree uint64
+	fext_tree_oid  uint64
+
+	omap *OMap
+	tree *BTree
+
+	// Sealed volumes keep the file extents in a separate tree.
+	fext *BTree
+}
+
+func (self *Container) newVolume(paddr, xid uint64) (*Volume, error) {
+	buf, _, err := self.readObject(paddr, OBJECT_TYPE_FS)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buf) < APFS_SB_SIZE ||
+		binary.LittleEndian.Uint32(buf[32:]) != APFS_MAGIC {
+		return nil, errors.New("apfs: Invalid volume superblock")
+	}
+
+	name := buf[704 : 704+APFS_VOLNAME_LEN]
+	if idx := bytes.IndexByte(name, 0); idx >= 0 {
+		name = name[:idx]
+	}
+
+	role := binary.LittleEndian.Uint16(buf[964:])
+	role_name, pres := volumeRoles[role]
+	if !pres && role != 0 {
+		role_name = fmt.Sprintf("%#x", role)
+	}
+
+	result := &Volume{
+		container:      self,
+		Name:           string(name),
+		UUID:           formatUUID(buf[240:256]),
+		Role:           role_name,
+		Index:          binary.LittleEndian.Uint32(buf[36:]),
+		Created:        apfsTime(binary.LittleEndian.Uint64(buf[304:])),
+		LastMod:        apfsTime(binary.LittleEndian.Uint64(buf[256:])),
+		FSFlags:        binary.LittleEndian.Uint64(buf[264:]),
+		Incompat:       binary.LittleEndian.Uint64(buf[56:]),
+		NumFiles:       binary.LittleEndian.Uint64(buf[184:]),
+		NumFolders:     binary.LittleEndian.Uint64(buf[192:]),
+		XID:            xid,
+		omap_oid:       binary.LittleEndian.Uint64(buf[128:]),
+		root_tree_oid:  binary.LittleEndian.Uint64(buf[136:]),
+		snap_meta_tree: binary.LittleEndian.Uint64(buf[152:]),
+		root_tree_type: binary.LittleEndian.Uint32(buf[116:]),
+		fext_tree_oid:  binary.LittleEndian.Uint64(buf[1032:]),
+	}
+
+	return result, nil
+}
+
+func (self *Volume) IsEncrypted() bool {
+	return self.FSFlags&APFS_FS_UNENCRYPTED == 0
+}
+
+func (self *Volume) IsCaseSensitive() bool {
+	return self.Incompat&APFS_INCOMPAT_CASE_INSENSITIVE == 0
+}
+
+// Directory entry keys include a hash of the name on all but the
+// oldest case sensitive volumes.
+func (self *Volume) hashedNames() bool {
+	return self.Incompat&(APFS_INCOMPAT_CASE_INSENSITIVE|
+		APFS_INCOMPAT_NORMALIZATION_INSENSITIVE) != 0
+}
+
+// List the snapshots of the volume.
+func (self *Volume) Snapshots() ([]*Snapshot, error) {
+	result := []*Snapshot{}
+	if self.snap_meta_tree == 0 {
+		return result, nil
+	}
+
+	tree, err := self.container.NewBTree(self.snap_meta_tree, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tree.Scan(func(key []byte) int {
+		return 0
+	}, func(key, value []byte) error {
+		if len(key) < 8 || len(value) < 50 {
+			return nil
+		}
+
+		oid, record_type := splitKeyHeader(key)
+		if record_type != APFS_TYPE_SNAP_METADATA {
+			return nil
+		}
+
+		name_len := int(binary.LittleEndian.Uint16(value[48:]))
+		if 50+name_len > len(value) {
+			return fmt.Errorf("apfs: Invalid snapshot name")
+		}
+
+		result = append(result, &Snapshot{
+			Name:       cString(value[50 : 50+name_len]),
+			XID:        oid,
+			Created:    apfsTime(binary.LittleEndian.Uint64(value[16:])),
+			superblock: binary.LittleEndian.Uint64(value[8:]),
+		})
+		return nil
+	})
+
+	return result, err
+}
+
+func (self *Volume) OpenSnapshot(name string) (*Volume, error) {
+	snapshots, err := self.Snapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Name != name {
+			continue
+		}
+
+		result, err := self.container.newVolume(
+			snapshot.superblock, snapshot.XID)
+		if err != nil {
+			return nil, fmt.Errorf("apfs: Snapshot %v: %w", name, err)
+		}
+		result.Snapshot = name
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("apfs: Snapshot %v not found", name)
+}
+
+// Open the file system tree of the volume.
+func (self *Volume) Open() error {
+	if self.tree != nil {
+		return nil
+	}
+
+	if self.IsEncrypted() {
+		return fmt.Errorf("apfs: Volume %v is encrypted", self.Name)
+	}
+
+	var err error
+	self.omap, err = self.container.NewOMap(self.omap_oid)
+	if err != nil {
+		return fmt.Errorf("apfs: Volume %v object map: %w", self.Name, err)
+	}
+
+	omap := self.omap
+	if self.root_tree_type&OBJ_STORAGETYPE_MASK == OBJ_PHYSICAL {
+		omap = nil
+	}
+
+	tree, err := self.container.NewBTree(self.root_tree_oid, omap, self.XID)
+	if err != nil {
+		return fmt.Errorf("apfs: Volume %v file system tree: %w",
+			self.Name, err)
+	}
+
+	if self.fext_tree_oid != 0 &&
+		self.Incompat&APFS_INCOMPAT_SEALED_VOLUME != 0 {
+		self.fext, err = self.container.NewBTree(self.fext_tree_oid, nil, 0)
+		if err != nil {
+			return fmt.Errorf("apfs: Volume %v extents tree: %w",
+				self.Name, err)
+		}
+	}
+
+	self.tree = tree
+	return nil
+}
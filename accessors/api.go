@@ -304,6 +304,16 @@ type UniqueBasename interface {
 	UniqueName() string
 }
 
+// Accessors which parse a filesystem directly may expose the
+// extended attributes of a file through its FileInfo. Attribute names
+// follow the conventions of the OS (e.g. the resource fork is
+// com.apple.ResourceFork) so the xattr() function works the same way
+// on raw images.
+type ExtendedAttributes interface {
+	ListXAttr() ([]string, error)
+	GetXAttr(name string) ([]byte, error)
+}
+
 // A File reader with
 type ReadSeekCloser interface {
 	io.ReadSeeker
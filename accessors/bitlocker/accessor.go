@@ -0,0 +1,325 @@
+// An accessor for BitLocker encrypted volumes.
+//
+// The full volume encryption key is recovered using a recovery
+// password, a user password, a startup key (.BEK file) or a clear
+// key and the decrypted volume is presented as a single file which
+// other accessors (e.g. raw_ntfs) can parse.
+
+package bitlocker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/accessors/zip"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/readers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	BITLOCKER_CACHE_TAG = "__BITLOCKER_CACHE"
+
+	// Startup key files are small.
+	BITLOCKER_MAX_STARTUP_KEY_SIZE = 64 * 1024
+)
+
+type cachedVolume struct {
+	volume *Volume
+	closer func()
+}
+
+// Stretching passwords is deliberately expensive so we keep
+// unlocked volumes until the end of the query.
+type bitlockerCache struct {
+	mu sync.Mutex
+
+	cache map[string]*cachedVolume
+}
+
+func (self *bitlockerCache) Close() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	for _, r := range self.cache {
+		r.closer()
+	}
+	self.cache = make(map[string]*cachedVolume)
+}
+
+// Get the secret holding the key material from the secrets service.
+func getSecret(scope vfilter.Scope, secret string) (
+	*ordereddict.Dict, error) {
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		return nil, errors.New("Secrets may only be used on the server")
+	}
+
+	secrets_service, err := services.GetSecretsService(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+
+	// Extract the context from the scope.
+	ctx := context.TODO()
+
+	secret_record, err := secrets_service.GetSecret(ctx, principal,
+		constants.BITLOCKER_KEY, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret_record.Data, nil
+}
+
+func readStartupKey(scope vfilter.Scope, accessor_name, filename string) (
+	[]byte, error) {
+	if accessor_name == "" {
+		accessor_name = "auto"
+	}
+
+	err := vql_subsystem.CheckFilesystemAccess(scope, accessor_name)
+	if err != nil {
+		return nil, err
+	}
+
+	accessor, err := accessors.GetAccessor(accessor_name, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := accessor.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return io.ReadAll(io.LimitReader(fd, BITLOCKER_MAX_STARTUP_KEY_SIZE))
+}
+
+// Collect the key material for the volume. Key material is only
+// taken from a "BitLocker Key" secret so it never appears in the
+// query, the collection parameters or the logs. The
+// BITLOCKER_CONFIG variable names the secret. If it is not set we
+// look for a secret named after the volume's GUID, and without one
+// only volumes protected by a clear key can be opened.
+func getKeys(scope vfilter.Scope, guid string) ([]*Key, error) {
+	var setting vfilter.Any = ordereddict.NewDict()
+	config, pres := scope.Resolve(constants.BITLOCKER_CONFIG)
+	if pres {
+		secret := vql_subsystem.GetStringFromRow(scope, config, "secret")
+		if secret == "" {
+			return nil, fmt.Errorf(
+				"bitlocker: %v must name a secret using 'LET %v <= dict(secret=...)'",
+				constants.BITLOCKER_CONFIG, constants.BITLOCKER_CONFIG)
+		}
+
+		data, err := getSecret(scope, secret)
+		if err != nil {
+			return nil, err
+		}
+		setting = data
+
+	} else {
+		for _, name := range []string{guid, "{" + guid + "}"} {
+			data, err := getSecret(scope, name)
+			if err == nil {
+				setting = data
+				break
+			}
+		}
+	}
+
+	key := &Key{
+		RecoveryPassword: vql_subsystem.GetStringFromRow(
+			scope, setting, "recovery_password"),
+		Password: vql_subsystem.GetStringFromRow(scope, setting, "password"),
+	}
+
+	startup_key := vql_subsystem.GetStringFromRow(scope, setting, "bek")
+	if startup_key != "" {
+		data, err := readStartupKey(scope, vql_subsystem.GetStringFromRow(
+			scope, setting, "bek_accessor"), startup_key)
+		if err != nil {
+			return nil, fmt.Errorf("bitlocker: Reading startup key: %w", err)
+		}
+		key.StartupKey = data
+	}
+
+	return []*Key{key}, nil
+}
+
+func getCachedVolume(full_path *accessors.OSPath, scope vfilter.Scope) (
+	*Volume, error) {
+	cache, pres := vql_subsystem.CacheGet(scope, BITLOCKER_CACHE_TAG).(*bitlockerCache)
+	if !pres {
+		cache = &bitlockerCache{
+			cache: make(map[string]*cachedVolume),
+		}
+		// Cache will remain alive for the duration of the query.
+		vql_subsystem.GetRootScope(scope).AddDestructor(cache.Close)
+		vql_subsystem.CacheSet(scope, BITLOCKER_CACHE_TAG, cache)
+	}
+
+	pathspec := full_path.PathSpec()
+	delegate, err := full_path.Delegate(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	key := pathspec.DelegateAccessor + ":" + delegate.String()
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	res, pres := cache.cache[key]
+	if pres {
+		return res.volume, nil
+	}
+
+	accessor, err := accessors.GetAccessor(pathspec.DelegateAccessor, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := accessor.LstatWithOSPath(delegate)
+	if err != nil {
+		return nil, err
+	}
+
+	lru_size := vql_subsystem.GetIntFromRow(
+		scope, scope, constants.NTFS_CACHE_SIZE)
+	paged_reader, err := readers.NewAccessorReader(
+		scope, pathspec.DelegateAccessor, delegate, int(lru_size))
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := ParseMetadata(paged_reader)
+	if err != nil {
+		paged_reader.Close()
+		return nil, err
+	}
+
+	keys, err := getKeys(scope, metadata.GUID)
+	if err != nil {
+		paged_reader.Close()
+		return nil, err
+	}
+
+	volume, err := newVolumeFromMetadata(
+		paged_reader, stat.Size(), metadata, keys)
+	if err != nil {
+		paged_reader.Close()
+		return nil, err
+	}
+
+	cache.cache[key] = &cachedVolume{
+		volume: volume,
+		closer: func() { paged_reader.Close() },
+	}
+	scope.Log("bitlocker: Unlocked volume %v using %v protector %v",
+		metadata.GUID, ProtectionType(volume.Protector.Protection),
+		volume.Protector.ID)
+
+	return volume, nil
+}
+
+type volumeReader struct {
+	*utils.ReadSeekReaderAdapter
+	info accessors.FileInfo
+}
+
+func (self *volumeReader) LStat() (accessors.FileInfo, error) {
+	return self.info, nil
+}
+
+func GetBitLockerVolume(full_path *accessors.OSPath, scope vfilter.Scope) (
+	zip.ReaderStat, error) {
+
+	pathspec := full_path.PathSpec()
+
+	// If a delegate is not provided we use the "auto" accessor to
+	// open the volume.
+	if pathspec.DelegateAccessor == "" && pathspec.GetDelegatePath() == "" {
+		pathspec.DelegatePath = pathspec.Path
+		pathspec.DelegateAccessor = "auto"
+		pathspec.Path = "/"
+		full_path.SetPathSpec(pathspec)
+	}
+
+	err := vql_subsystem.CheckFilesystemAccess(scope, pathspec.DelegateAccessor)
+	if err != nil {
+		scope.Log("%v: DelegateAccessor denied", err)
+		return nil, err
+	}
+
+	volume, err := getCachedVolume(full_path, scope)
+	if err != nil {
+		return nil, fmt.Errorf("bitlocker: %v: %w",
+			pathspec.GetDelegatePath(), err)
+	}
+
+	metadata := volume.Metadata
+	return &volumeReader{
+		ReadSeekReaderAdapter: utils.NewReadSeekReaderAdapter(volume),
+		info: &accessors.VirtualFileInfo{
+			Path:   full_path,
+			Size_:  volume.Size(),
+			Btime_: metadata.Created,
+			Data_: ordereddict.NewDict().
+				Set("GUID", metadata.GUID).
+				Set("Description", metadata.Description).
+				Set("EncryptionMethod",
+					EncryptionMethod(volume.EncryptionMethod)).
+				Set("Protectors", metadata.Protectors()).
+				Set("UnlockedWith", ProtectionType(volume.Protector.Protection)),
+		},
+	}, nil
+}
+
+func init() {
+	accessors.Register("bitlocker", zip.NewGzipFileSystemAccessor(
+		accessors.MustNewLinuxOSPath(""), GetBitLockerVolume),
+		`Access the decrypted contents of a BitLocker encrypted volume.
+
+The delegate is the encrypted volume (e.g. a partition of an ewf,
+vhdx or raw image opened through the offset accessor). Volumes created
+by Windows 7 and later (including BitLocker To Go) are supported with
+AES-CBC (with or without the Elephant diffuser) and AES-XTS.
+
+Volumes are unlocked using a recovery password, a user password, a
+startup key (.BEK file) or a clear key (when protection is
+suspended). The key material is taken from a "BitLocker Key" secret
+managed by the secrets service so it does not appear in the
+query. Name the secret in the BITLOCKER_CONFIG variable, otherwise the
+secret named after the volume's GUID is used.
+
+For Example:
+
+    LET BITLOCKER_CONFIG <= dict(secret="LaptopRecoveryKey")
+
+    SELECT * FROM glob(globs="/Windows/System32/config/*",
+       accessor="raw_ntfs",
+       root=pathspec(
+          DelegateAccessor="bitlocker",
+          Delegate=pathspec(
+             DelegateAccessor="offset",
+             Delegate=pathspec(
+                DelegateAccessor="ewf",
+                DelegatePath="/images/laptop.E01",
+                Path="/472907776"),
+             Path="/"),
+          Path="/"))
+`)
+}
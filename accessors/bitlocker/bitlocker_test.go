@@ -0,0 +1,493 @@
+package bitlocker
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math/bits"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/xts"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+	"www.velocidex.com/golang/vfilter"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+const (
+	testVolumeSize    = 0x100000
+	testHeaderOffset  = 0x80000
+	testHeaderSectors = 16
+	testPassword      = "Secret Passw0rd"
+	testSectorSize    = 512
+)
+
+var (
+	testBlockOffsets = []uint64{0x20000, 0x40000, 0x60000}
+	testStartupKeyID = []byte("0123456789abcdef")
+)
+
+func randomBytes(r *rand.Rand, size int) []byte {
+	result := make([]byte, size)
+	r.Read(result)
+	return result
+}
+
+func makeEntry(entry_type, value_type uint16, data ...[]byte) []byte {
+	payload := bytes.Join(data, nil)
+	result := make([]byte, FVE_ENTRY_HEADER_SIZE)
+	binary.LittleEndian.PutUint16(result, uint16(len(result)+len(payload)))
+	binary.LittleEndian.PutUint16(result[2:], entry_type)
+	binary.LittleEndian.PutUint16(result[4:], value_type)
+	binary.LittleEndian.PutUint16(result[6:], 1)
+	return append(result, payload...)
+}
+
+func makeKeyEntry(method uint32, key []byte) []byte {
+	header := make([]byte, 4)
+	binary.LittleEndian.PutUint32(header, method)
+	return makeEntry(ENTRY_TYPE_PROPERTY, VALUE_TYPE_KEY, header, key)
+}
+
+// The inverse of decryptKeyEntry.
+func makeCCMEntry(t *testing.T, r *rand.Rand, entry_type uint16,
+	key, payload []byte) []byte {
+	block, err := aes.NewCipher(key)
+	assert.NoError(t, err)
+
+	nonce := randomBytes(r, CCM_NONCE_SIZE)
+	data := append(ccmMAC(block, nonce, payload), payload...)
+
+	counter := make([]byte, aes.BlockSize)
+	counter[0] = 14 - CCM_NONCE_SIZE
+	copy(counter[1:], nonce)
+	cipher.NewCTR(block, counter).XORKeyStream(data, data)
+
+	return makeEntry(entry_type, VALUE_TYPE_AES_CCM_KEY, nonce, data)
+}
+
+func makeVMKEntry(id []byte, protection uint16, nested ...[]byte) []byte {
+	header := make([]byte, 28)
+	copy(header, id)
+	binary.LittleEndian.PutUint16(header[26:], protection)
+	return makeEntry(ENTRY_TYPE_VMK, VALUE_TYPE_VMK,
+		append([][]byte{header}, nested...)...)
+}
+
+func makeStretchEntry(salt []byte) []byte {
+	header := make([]byte, 4)
+	binary.LittleEndian.PutUint32(header, 0x1000)
+	return makeEntry(ENTRY_TYPE_PROPERTY, VALUE_TYPE_STRETCH_KEY, header, salt)
+}
+
+func makeRecoveryPassword(r *rand.Rand) string {
+	groups := []string{}
+	for i := 0; i < 8; i++ {
+		groups = append(groups, fmt.Sprintf("%06d", r.Intn(0x10000)*11))
+	}
+	return strings.Join(groups, "-")
+}
+
+func makeStartupKey(external_key []byte) []byte {
+	header := make([]byte, 24)
+	copy(header, testStartupKeyID)
+
+	entries := makeEntry(ENTRY_TYPE_STARTUP_KEY, VALUE_TYPE_EXTERNAL_KEY,
+		header, makeKeyEntry(0x2000, external_key))
+
+	result := make([]byte, FVE_METADATA_HEADER_SIZE)
+	binary.LittleEndian.PutUint32(result, uint32(len(result)+len(entries)))
+	binary.LittleEndian.PutUint32(result[4:], 1)
+	binary.LittleEndian.PutUint32(result[8:], FVE_METADATA_HEADER_SIZE)
+	binary.LittleEndian.PutUint32(result[12:], uint32(len(result)+len(entries)))
+	copy(result[16:], testStartupKeyID)
+	return append(result, entries...)
+}
+
+func diffuserAEncrypt(words []uint32) {
+	n := len(words)
+	for cycle := 0; cycle < 5; cycle++ {
+		for i := n - 1; i >= 0; i-- {
+			words[i] -= words[(i-2+n)%n] ^
+				bits.RotateLeft32(words[(i-5+n)%n], diffuserARotations[i%4])
+		}
+	}
+}
+
+func diffuserBEncrypt(words []uint32) {
+	n := len(words)
+	for cycle := 0; cycle < 3; cycle++ {
+		for i := n - 1; i >= 0; i-- {
+			words[i] -= words[(i+2)%n] ^
+				bits.RotateLeft32(words[(i+5)%n], diffuserBRotations[i%4])
+		}
+	}
+}
+
+// The inverse of sectorDecryptor.
+func encryptSector(t *testing.T, method uint32, fvek, buf []byte,
+	offset uint64) {
+	switch method {
+	case AES_128_XTS, AES_256_XTS:
+		if method == AES_128_XTS {
+			fvek = fvek[:32]
+		}
+		c, err := xts.NewCipher(aes.NewCipher, fvek)
+		assert.NoError(t, err)
+		c.Encrypt(buf, buf, offset/testSectorSize)
+		return
+	}
+
+	key_size := 16
+	if method == AES_256_CBC || method == AES_256_CBC_DIFFUSER {
+		key_size = 32
+	}
+
+	block, err := aes.NewCipher(fvek[:key_size])
+	assert.NoError(t, err)
+
+	if method == AES_128_CBC_DIFFUSER || method == AES_256_CBC_DIFFUSER {
+		tweak, err := aes.NewCipher(fvek[32 : 32+key_size])
+		assert.NoError(t, err)
+
+		sector_key := make([]byte, 32)
+		binary.LittleEndian.PutUint64(sector_key, offset)
+		tweak.Encrypt(sector_key[:16], sector_key[:16])
+		binary.LittleEndian.PutUint64(sector_key[16:], offset)
+		sector_key[31] = 0x80
+		tweak.Encrypt(sector_key[16:], sector_key[16:])
+
+		for i := range buf {
+			buf[i] ^= sector_key[i%32]
+		}
+
+		words := make([]uint32, len(buf)/4)
+		for i := range words {
+			words[i] = binary.LittleEndian.Uint32(buf[i*4:])
+		}
+		diffuserAEncrypt(words)
+		diffuserBEncrypt(words)
+		for i, w := range words {
+			binary.LittleEndian.PutUint32(buf[i*4:], w)
+		}
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	binary.LittleEndian.PutUint64(iv, offset)
+	block.Encrypt(iv, iv)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(buf, buf)
+}
+
+type testVolume struct {
+	image       []byte
+	plain       []byte
+	recovery    string
+	startup_key []byte
+}
+
+func buildVolume(t *testing.T, method uint32, clear_key bool) *testVolume {
+	r := rand.New(rand.NewSource(int64(method)))
+	result := &testVolume{
+		recovery: makeRecoveryPassword(r),
+	}
+
+	// The original volume header and some data.
+	plain := randomBytes(r, testVolumeSize)
+	original_header := make([]byte, testHeaderSectors*testSectorSize)
+	copy(original_header[3:], "NTFS    ")
+	copy(plain, original_header)
+	copy(plain[testHeaderOffset:], original_header)
+	for _, offset := range testBlockOffsets {
+		copy(plain[offset:], make([]byte, FVE_METADATA_BLOCK_SIZE))
+	}
+	result.plain = plain
+
+	fvek := randomBytes(r, 64)
+	vmk := randomBytes(r, 32)
+
+	// Encrypt the volume
+	image := append([]byte{}, plain...)
+	for offset := uint64(0); offset < testVolumeSize; offset += testSectorSize {
+		encryptSector(t, method, fvek,
+			image[offset:offset+testSectorSize], offset)
+	}
+
+	// The BitLocker volume header replaces the original.
+	header := image[:testHeaderSectors*testSectorSize]
+	copy(header, make([]byte, len(header)))
+	copy(header[3:], FVE_SIGNATURE)
+	binary.LittleEndian.PutUint16(header[0x0b:], testSectorSize)
+	copy(header[0xa0:], bitlockerGUID)
+	for i, offset := range testBlockOffsets {
+		binary.LittleEndian.PutUint64(header[0xb0+i*8:], offset)
+	}
+
+	// Build the protectors
+	var vmks [][]byte
+	vmk_entry := makeKeyEntry(0x2000, vmk)
+	if clear_key {
+		clear := randomBytes(r, 32)
+		vmks = append(vmks, makeVMKEntry(randomBytes(r, 16),
+			PROTECTION_CLEAR_KEY,
+			makeKeyEntry(0x2000, clear),
+			makeCCMEntry(t, r, 0, clear, vmk_entry)))
+	}
+
+	salt := randomBytes(r, 16)
+	recovery_key, err := recoveryPasswordKey(result.recovery, salt)
+	assert.NoError(t, err)
+	vmks = append(vmks, makeVMKEntry(randomBytes(r, 16),
+		PROTECTION_RECOVERY_PASSWORD, makeStretchEntry(salt),
+		makeCCMEntry(t, r, 0, recovery_key, vmk_entry)))
+
+	salt = randomBytes(r, 16)
+	vmks = append(vmks, makeVMKEntry(randomBytes(r, 16),
+		PROTECTION_PASSWORD, makeStretchEntry(salt),
+		makeCCMEntry(t, r, 0, passwordKey(testPassword, salt), vmk_entry)))
+
+	external_key := randomBytes(r, 32)
+	result.startup_key = makeStartupKey(external_key)
+	vmks = append(vmks, makeVMKEntry(testStartupKeyID,
+		PROTECTION_STARTUP_KEY,
+		makeCCMEntry(t, r, 0, external_key, vmk_entry)))
+
+	description := utf16.Encode([]rune("TESTHOST C: 1/1/2024\x00"))
+	description_data := make([]byte, len(description)*2)
+	for i, c := range description {
+		binary.LittleEndian.PutUint16(description_data[i*2:], c)
+	}
+
+	entries := bytes.Join(append(vmks,
+		makeCCMEntry(t, r, ENTRY_TYPE_FVEK, vmk, makeKeyEntry(method, fvek)),
+		makeEntry(ENTRY_TYPE_DESCRIPTION, VALUE_TYPE_UNICODE, description_data)),
+		nil)
+
+	block := make([]byte, FVE_BLOCK_HEADER_SIZE+FVE_METADATA_HEADER_SIZE)
+	copy(block, FVE_SIGNATURE)
+	binary.LittleEndian.PutUint16(block[10:], 2)
+	binary.LittleEndian.PutUint64(block[16:], testVolumeSize)
+	binary.LittleEndian.PutUint32(block[28:], testHeaderSectors)
+	for i, offset := range testBlockOffsets {
+		binary.LittleEndian.PutUint64(block[32+i*8:], offset)
+	}
+	binary.LittleEndian.PutUint64(block[56:], testHeaderOffset)
+
+	metadata := block[FVE_BLOCK_HEADER_SIZE:]
+	size := uint32(FVE_METADATA_HEADER_SIZE + len(entries))
+	binary.LittleEndian.PutUint32(metadata, size)
+	binary.LittleEndian.PutUint32(metadata[4:], 1)
+	binary.LittleEndian.PutUint32(metadata[8:], FVE_METADATA_HEADER_SIZE)
+	binary.LittleEndian.PutUint32(metadata[12:], size)
+	copy(metadata[16:], testStartupKeyID)
+	binary.LittleEndian.PutUint32(metadata[36:], method)
+	block = append(block, entries...)
+
+	for _, offset := range testBlockOffsets {
+		copy(image[offset:], block)
+	}
+	result.image = image
+
+	return result
+}
+
+func readVolume(t *testing.T, scope vfilter.Scope, filename string) (
+	accessors.FileInfo, []byte, error) {
+	accessor, err := accessors.GetAccessor("bitlocker", scope)
+	assert.NoError(t, err)
+
+	path := accessors.MustNewLinuxOSPath("/")
+	path.SetPathSpec(&accessors.PathSpec{
+		DelegateAccessor: "file",
+		DelegatePath:     filename,
+		Path:             "/",
+	})
+
+	fd, err := accessor.OpenWithOSPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fd.Close()
+
+	info, err := accessor.LstatWithOSPath(path)
+	assert.NoError(t, err)
+
+	// Read from an unaligned offset.
+	_, err = fd.Seek(100, 0)
+	assert.NoError(t, err)
+
+	data, err := ioutil.ReadAll(fd)
+	return info, data, err
+}
+
+type BitLockerTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *BitLockerTestSuite) SetupTest() {
+	self.TestSuite.SetupTest()
+
+	err := services.GrantRoles(self.ConfigObj, "admin", []string{"administrator"})
+	assert.NoError(self.T(), err)
+
+	secrets, err := services.GetSecretsService(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = secrets.DefineSecret(self.Ctx, &api_proto.SecretDefinition{
+		TypeName: constants.BITLOCKER_KEY})
+	assert.NoError(self.T(), err)
+}
+
+func (self *BitLockerTestSuite) addSecret(name string, data *ordereddict.Dict) {
+	secrets, err := services.GetSecretsService(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = secrets.AddSecret(self.Ctx, vql_subsystem.MakeScope(),
+		constants.BITLOCKER_KEY, name, data)
+	assert.NoError(self.T(), err)
+
+	err = secrets.ModifySecret(self.Ctx, &api_proto.ModifySecretRequest{
+		TypeName: constants.BITLOCKER_KEY,
+		Name:     name,
+		AddUsers: []string{"admin"},
+	})
+	assert.NoError(self.T(), err)
+}
+
+func (self *BitLockerTestSuite) makeScope(config *ordereddict.Dict) vfilter.Scope {
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	env := ordereddict.NewDict()
+	if config != nil {
+		env.Set(constants.BITLOCKER_CONFIG, config)
+	}
+
+	return manager.BuildScope(services.ScopeBuilder{
+		Config:     self.ConfigObj,
+		ACLManager: acl_managers.NewServerACLManager(self.ConfigObj, "admin"),
+		Logger: logging.NewPlainLogger(
+			self.ConfigObj, &logging.FrontendComponent),
+		Env: env,
+	})
+}
+
+func (self *BitLockerTestSuite) TestBitLocker() {
+	t := self.T()
+	dir, err := ioutil.TempDir("", "bitlocker")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	bek := filepath.Join(dir, "key.bek")
+
+	for _, method := range []uint32{
+		AES_128_CBC, AES_256_CBC_DIFFUSER, AES_128_XTS, AES_256_XTS} {
+		volume := buildVolume(t, method, false)
+		name := EncryptionMethod(method)
+
+		filename := filepath.Join(dir, "volume.img")
+		assert.NoError(t, ioutil.WriteFile(filename, volume.image, 0600))
+		assert.NoError(t, ioutil.WriteFile(bek, volume.startup_key, 0600))
+
+		// Each volume has its own keys so use separate secrets.
+		self.addSecret(name+"Wrong", ordereddict.NewDict().
+			Set("recovery_password", makeRecoveryPassword(rand.New(rand.NewSource(99)))))
+		self.addSecret(name+"RecoveryPassword", ordereddict.NewDict().
+			Set("recovery_password", volume.recovery))
+		self.addSecret(name+"Password", ordereddict.NewDict().
+			Set("password", testPassword))
+		self.addSecret(name+"StartupKey", ordereddict.NewDict().
+			Set("bek", bek).Set("bek_accessor", "file"))
+
+		// No keys
+		scope := self.makeScope(nil)
+		_, _, err = readVolume(t, scope, filename)
+		assert.Error(t, err)
+		scope.Close()
+
+		// Keys given inline are not accepted.
+		scope = self.makeScope(ordereddict.NewDict().
+			Set("recovery_password", volume.recovery))
+		_, _, err = readVolume(t, scope, filename)
+		assert.Error(t, err)
+		scope.Close()
+
+		// Missing secret
+		scope = self.makeScope(ordereddict.NewDict().Set("secret", "Missing"))
+		_, _, err = readVolume(t, scope, filename)
+		assert.Error(t, err)
+		scope.Close()
+
+		// Wrong recovery password
+		scope = self.makeScope(ordereddict.NewDict().Set("secret", name+"Wrong"))
+		_, _, err = readVolume(t, scope, filename)
+		assert.Error(t, err)
+		scope.Close()
+
+		for _, secret := range []string{
+			"RecoveryPassword", "Password", "StartupKey"} {
+			scope = self.makeScope(ordereddict.NewDict().Set("secret", name+secret))
+			info, data, err := readVolume(t, scope, filename)
+			assert.NoError(t, err, name)
+			scope.Close()
+
+			assert.Equal(t, int64(testVolumeSize), info.Size())
+			assert.True(t, bytes.Equal(volume.plain[100:], data), name)
+
+			method_name, _ := info.Data().Get("EncryptionMethod")
+			assert.Equal(t, name, method_name)
+
+			description, _ := info.Data().Get("Description")
+			assert.Equal(t, "TESTHOST C: 1/1/2024", description)
+		}
+	}
+
+	// Without a config the secret named after the volume GUID is used.
+	volume := buildVolume(t, AES_128_XTS, false)
+	filename := filepath.Join(dir, "guid.img")
+	assert.NoError(t, ioutil.WriteFile(filename, volume.image, 0600))
+
+	self.addSecret(formatGUID(testStartupKeyID), ordereddict.NewDict().
+		Set("recovery_password", volume.recovery))
+
+	scope := self.makeScope(nil)
+	_, data, err := readVolume(t, scope, filename)
+	assert.NoError(t, err)
+	scope.Close()
+	assert.True(t, bytes.Equal(volume.plain[100:], data))
+
+	// Suspended protection stores a clear key.
+	volume = buildVolume(t, AES_128_XTS, true)
+	filename = filepath.Join(dir, "clear.img")
+	assert.NoError(t, ioutil.WriteFile(filename, volume.image, 0600))
+
+	scope = self.makeScope(nil)
+	info, data, err := readVolume(t, scope, filename)
+	assert.NoError(t, err)
+	scope.Close()
+
+	assert.True(t, bytes.Equal(volume.plain[100:], data))
+	unlocked, _ := info.Data().Get("UnlockedWith")
+	assert.Equal(t, "ClearKey", unlocked)
+}
+
+func TestBitLocker(t *testing.T) {
+	suite.Run(t, &BitLockerTestSuite{})
+}
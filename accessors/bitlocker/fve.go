@@ -0,0 +1,386 @@
+package bitlocker
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// The on disk format is described in the libbde documentation
+// "BitLocker Drive Encryption (BDE) format specification".
+const (
+	FVE_SIGNATURE = "-FVE-FS-"
+
+	// BitLocker To Go volumes keep a FAT boot sector.
+	FVE_TOGO_OEM = "MSWIN4.1"
+
+	FVE_BLOCK_HEADER_SIZE    = 64
+	FVE_METADATA_HEADER_SIZE = 48
+
+	// Each metadata block occupies 64kb of the volume.
+	FVE_METADATA_BLOCK_SIZE = 0x10000
+
+	FVE_MAX_METADATA_SIZE = 0x10000
+
+	FVE_ENTRY_HEADER_SIZE = 8
+)
+
+// Entry types
+const (
+	ENTRY_TYPE_PROPERTY    = 0x0000
+	ENTRY_TYPE_VMK         = 0x0002
+	ENTRY_TYPE_FVEK        = 0x0003
+	ENTRY_TYPE_STARTUP_KEY = 0x0006
+	ENTRY_TYPE_DESCRIPTION = 0x0007
+)
+
+// Value types
+const (
+	VALUE_TYPE_KEY          = 0x0001
+	VALUE_TYPE_UNICODE      = 0x0002
+	VALUE_TYPE_STRETCH_KEY  = 0x0003
+	VALUE_TYPE_AES_CCM_KEY  = 0x0005
+	VALUE_TYPE_VMK          = 0x0008
+	VALUE_TYPE_EXTERNAL_KEY = 0x0009
+	VALUE_TYPE_OFFSET_SIZE  = 0x000f
+)
+
+// Key protection types of a VMK.
+const (
+	PROTECTION_CLEAR_KEY         = 0x0000
+	PROTECTION_TPM               = 0x0100
+	PROTECTION_STARTUP_KEY       = 0x0200
+	PROTECTION_TPM_PIN           = 0x0500
+	PROTECTION_RECOVERY_PASSWORD = 0x0800
+	PROTECTION_PASSWORD          = 0x2000
+)
+
+// Encryption methods
+const (
+	AES_128_CBC_DIFFUSER = 0x8000
+	AES_256_CBC_DIFFUSER = 0x8001
+	AES_128_CBC          = 0x8002
+	AES_256_CBC          = 0x8003
+	AES_128_XTS          = 0x8004
+	AES_256_XTS          = 0x8005
+)
+
+var (
+	// The GUID of BitLocker volumes at 0xA0 (0x1A0 for To Go).
+	bitlockerGUID = []byte{
+		0x3b, 0xd6, 0x67, 0x49, 0x29, 0x2e, 0xd8, 0x4a,
+		0x83, 0x99, 0xf6, 0xa3, 0x39, 0xe3, 0xd0, 0x01}
+)
+
+func EncryptionMethod(method uint32) string {
+	switch method {
+	case AES_128_CBC_DIFFUSER:
+		return "AES-CBC 128-bit with Diffuser"
+	case AES_256_CBC_DIFFUSER:
+		return "AES-CBC 256-bit with Diffuser"
+	case AES_128_CBC:
+		return "AES-CBC 128-bit"
+	case AES_256_CBC:
+		return "AES-CBC 256-bit"
+	case AES_128_XTS:
+		return "AES-XTS 128-bit"
+	case AES_256_XTS:
+		return "AES-XTS 256-bit"
+	}
+	return fmt.Sprintf("Unknown (%#x)", method)
+}
+
+func ProtectionType(protection uint16) string {
+	switch protection {
+	case PROTECTION_CLEAR_KEY:
+		return "ClearKey"
+	case PROTECTION_TPM:
+		return "TPM"
+	case PROTECTION_STARTUP_KEY:
+		return "StartupKey"
+	case PROTECTION_TPM_PIN:
+		return "TPMAndPIN"
+	case PROTECTION_RECOVERY_PASSWORD:
+		return "RecoveryPassword"
+	case PROTECTION_PASSWORD:
+		return "Password"
+	}
+	return fmt.Sprintf("Unknown (%#x)", protection)
+}
+
+func formatGUID(b []byte) string {
+	return fmt.Sprintf("%08X-%04X-%04X-%X-%X",
+		binary.LittleEndian.Uint32(b[0:4]),
+		binary.LittleEndian.Uint16(b[4:6]),
+		binary.LittleEndian.Uint16(b[6:8]),
+		b[8:10], b[10:16])
+}
+
+func filetimeToTime(value uint64) time.Time {
+	if value == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(value/10000000)-11644473600,
+		int64(value%10000000)*100).UTC()
+}
+
+func utf16ToString(b []byte) string {
+	u16 := make([]uint16, len(b)/2)
+	for i := range u16 {
+		u16[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return strings.TrimRight(string(utf16.Decode(u16)), "\x00")
+}
+
+// A metadata entry. Some value types contain nested entries.
+type Entry struct {
+	Type      uint16
+	ValueType uint16
+	Version   uint16
+	Data      []byte
+}
+
+func parseEntries(buf []byte) []*Entry {
+	var result []*Entry
+	for len(buf) >= FVE_ENTRY_HEADER_SIZE {
+		size := int(binary.LittleEndian.Uint16(buf))
+		if size < FVE_ENTRY_HEADER_SIZE || size > len(buf) {
+			break
+		}
+
+		result = append(result, &Entry{
+			Type:      binary.LittleEndian.Uint16(buf[2:]),
+			ValueType: binary.LittleEndian.Uint16(buf[4:]),
+			Version:   binary.LittleEndian.Uint16(buf[6:]),
+			Data:      buf[FVE_ENTRY_HEADER_SIZE:size],
+		})
+		buf = buf[size:]
+	}
+	return result
+}
+
+// Entries nested inside this entry.
+func (self *Entry) Nested() []*Entry {
+	offset := 0
+	switch self.ValueType {
+	case VALUE_TYPE_VMK:
+		offset = 28
+	case VALUE_TYPE_STRETCH_KEY:
+		offset = 20
+	case VALUE_TYPE_EXTERNAL_KEY:
+		offset = 24
+	default:
+		return nil
+	}
+
+	if len(self.Data) < offset {
+		return nil
+	}
+	return parseEntries(self.Data[offset:])
+}
+
+func (self *Entry) FindNested(value_type uint16) *Entry {
+	for _, e := range self.Nested() {
+		if e.ValueType == value_type {
+			return e
+		}
+	}
+	return nil
+}
+
+// A volume master key entry.
+type VMK struct {
+	ID         string
+	Modified   time.Time
+	Protection uint16
+
+	entry *Entry
+}
+
+func newVMK(entry *Entry) (*VMK, error) {
+	if len(entry.Data) < 28 {
+		return nil, errors.New("bitlocker: VMK entry too short")
+	}
+
+	return &VMK{
+		ID:         formatGUID(entry.Data),
+		Modified:   filetimeToTime(binary.LittleEndian.Uint64(entry.Data[16:])),
+		Protection: binary.LittleEndian.Uint16(entry.Data[26:]),
+		entry:      entry,
+	}, nil
+}
+
+type Metadata struct {
+	// The volume identifier.
+	GUID             string
+	EncryptionMethod uint32
+	Created          time.Time
+	Description      string
+
+	// In bytes
+	EncryptedSize uint64
+
+	// The original volume header is moved to this location.
+	VolumeHeaderOffset  uint64
+	VolumeHeaderSectors uint64
+
+	BlockOffsets []uint64
+
+	SectorSize uint64
+
+	VMKs    []*VMK
+	Entries []*Entry
+}
+
+// Returns true if the device starts with a BitLocker volume header.
+func IsBitLocker(reader io.ReaderAt) bool {
+	_, _, err := readVolumeHeader(reader)
+	return err == nil
+}
+
+// Returns the sector size and the offsets of the metadata blocks.
+func readVolumeHeader(reader io.ReaderAt) (uint64, []uint64, error) {
+	header := make([]byte, 512)
+	_, err := reader.ReadAt(header, 0)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var offsets []byte
+	switch {
+	case string(header[3:11]) == FVE_SIGNATURE &&
+		string(header[0xa0:0xb0]) == string(bitlockerGUID):
+		offsets = header[0xb0:0xc8]
+
+	case string(header[3:11]) == FVE_TOGO_OEM &&
+		string(header[0x1a0:0x1b0]) == string(bitlockerGUID):
+		offsets = header[0x1b0:0x1c8]
+
+	case string(header[3:11]) == FVE_SIGNATURE:
+		return 0, nil, errors.New(
+			"bitlocker: Windows Vista BitLocker volumes are not supported")
+
+	default:
+		return 0, nil, errors.New("bitlocker: Not a BitLocker volume")
+	}
+
+	sector_size := uint64(binary.LittleEndian.Uint16(header[0x0b:]))
+	switch sector_size {
+	case 512, 1024, 2048, 4096:
+	default:
+		return 0, nil, fmt.Errorf("bitlocker: Invalid sector size %v", sector_size)
+	}
+
+	var result []uint64
+	for i := 0; i < 3; i++ {
+		result = append(result, binary.LittleEndian.Uint64(offsets[i*8:]))
+	}
+	return sector_size, result, nil
+}
+
+func ParseMetadata(reader io.ReaderAt) (*Metadata, error) {
+	sector_size, offsets, err := readVolumeHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// All three copies should be the same so use the first valid
+	// one.
+	for _, offset := range offsets {
+		result, err := parseMetadataBlock(reader, offset)
+		if err != nil {
+			continue
+		}
+		result.SectorSize = sector_size
+		return result, nil
+	}
+
+	return nil, errors.New("bitlocker: No valid FVE metadata block found")
+}
+
+func parseMetadataBlock(reader io.ReaderAt, offset uint64) (*Metadata, error) {
+	header := make([]byte, FVE_BLOCK_HEADER_SIZE+FVE_METADATA_HEADER_SIZE)
+	_, err := reader.ReadAt(header, int64(offset))
+	if err != nil {
+		return nil, err
+	}
+
+	if string(header[:8]) != FVE_SIGNATURE {
+		return nil, errors.New("bitlocker: Invalid FVE metadata block signature")
+	}
+
+	version := binary.LittleEndian.Uint16(header[10:])
+	if version != 2 {
+		return nil, fmt.Errorf(
+			"bitlocker: Unsupported FVE metadata block version %v", version)
+	}
+
+	result := &Metadata{
+		EncryptedSize:       binary.LittleEndian.Uint64(header[16:]),
+		VolumeHeaderSectors: uint64(binary.LittleEndian.Uint32(header[28:])),
+		VolumeHeaderOffset:  binary.LittleEndian.Uint64(header[56:]),
+	}
+	for i := 0; i < 3; i++ {
+		result.BlockOffsets = append(result.BlockOffsets,
+			binary.LittleEndian.Uint64(header[32+i*8:]))
+	}
+
+	metadata_header := header[FVE_BLOCK_HEADER_SIZE:]
+	size := binary.LittleEndian.Uint32(metadata_header)
+	header_size := binary.LittleEndian.Uint32(metadata_header[8:])
+	if header_size != FVE_METADATA_HEADER_SIZE ||
+		size < FVE_METADATA_HEADER_SIZE || size > FVE_MAX_METADATA_SIZE {
+		return nil, errors.New("bitlocker: Invalid FVE metadata header")
+	}
+
+	result.GUID = formatGUID(metadata_header[16:])
+	result.EncryptionMethod = binary.LittleEndian.Uint32(metadata_header[36:])
+	result.Created = filetimeToTime(binary.LittleEndian.Uint64(metadata_header[40:]))
+
+	entries := make([]byte, size-FVE_METADATA_HEADER_SIZE)
+	_, err = reader.ReadAt(entries, int64(offset)+
+		FVE_BLOCK_HEADER_SIZE+FVE_METADATA_HEADER_SIZE)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Entries = parseEntries(entries)
+	for _, entry := range result.Entries {
+		switch {
+		case entry.Type == ENTRY_TYPE_VMK && entry.ValueType == VALUE_TYPE_VMK:
+			vmk, err := newVMK(entry)
+			if err == nil {
+				result.VMKs = append(result.VMKs, vmk)
+			}
+
+		case entry.Type == ENTRY_TYPE_DESCRIPTION &&
+			entry.ValueType == VALUE_TYPE_UNICODE:
+			result.Description = utf16ToString(entry.Data)
+		}
+	}
+
+	return result, nil
+}
+
+func (self *Metadata) FVEK() *Entry {
+	for _, entry := range self.Entries {
+		if entry.Type == ENTRY_TYPE_FVEK &&
+			entry.ValueType == VALUE_TYPE_AES_CCM_KEY {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Protector names for reporting.
+func (self *Metadata) Protectors() []string {
+	result := []string{}
+	for _, vmk := range self.VMKs {
+		result = append(result, ProtectionType(vmk.Protection))
+	}
+	return result
+}
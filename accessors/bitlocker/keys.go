@@ -0,0 +1,300 @@
+package bitlocker
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	// Number of SHA256 rounds used to stretch passwords.
+	STRETCH_ROUNDS = 0x100000
+
+	CCM_NONCE_SIZE = 12
+	CCM_MAC_SIZE   = 16
+)
+
+// The material which may unlock a volume master key.
+type Key struct {
+	RecoveryPassword string
+	Password         string
+
+	// The contents of a .BEK file.
+	StartupKey []byte
+}
+
+// A recovery password is 8 groups of 6 digits. Each group is a
+// multiple of 11 and encodes 16 bits of the key.
+func parseRecoveryPassword(password string) ([]byte, error) {
+	groups := strings.Split(strings.TrimSpace(password), "-")
+	if len(groups) != 8 {
+		return nil, errors.New("bitlocker: Recovery password should have 8 groups")
+	}
+
+	result := make([]byte, 16)
+	for i, group := range groups {
+		value, err := strconv.ParseUint(group, 10, 32)
+		if err != nil || len(group) != 6 || value%11 != 0 || value/11 > 0xffff {
+			return nil, fmt.Errorf(
+				"bitlocker: Invalid recovery password group %v", i+1)
+		}
+		binary.LittleEndian.PutUint16(result[i*2:], uint16(value/11))
+	}
+	return result, nil
+}
+
+// Stretch the password hash with the salt from the VMK.
+func stretchKey(password_hash, salt []byte) []byte {
+	// The updated hash, the password hash, the salt and the
+	// iteration count.
+	state := make([]byte, 32+32+16+8)
+	copy(state[32:], password_hash)
+	copy(state[64:], salt)
+
+	for i := uint64(0); i < STRETCH_ROUNDS; i++ {
+		binary.LittleEndian.PutUint64(state[80:], i)
+		hash := sha256.Sum256(state)
+		copy(state, hash[:])
+	}
+	return append([]byte{}, state[:32]...)
+}
+
+func recoveryPasswordKey(password string, salt []byte) ([]byte, error) {
+	key, err := parseRecoveryPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(key)
+	return stretchKey(hash[:], salt), nil
+}
+
+func passwordKey(password string, salt []byte) []byte {
+	u16 := utf16.Encode([]rune(password))
+	buf := make([]byte, len(u16)*2)
+	for i, c := range u16 {
+		binary.LittleEndian.PutUint16(buf[i*2:], c)
+	}
+
+	hash := sha256.Sum256(buf)
+	hash = sha256.Sum256(hash[:])
+	return stretchKey(hash[:], salt)
+}
+
+// Decrypt an AES-CCM encrypted key entry. The result is the
+// decrypted key entry.
+func decryptKeyEntry(entry *Entry, key []byte) (*Entry, error) {
+	if entry.ValueType != VALUE_TYPE_AES_CCM_KEY ||
+		len(entry.Data) < CCM_NONCE_SIZE+CCM_MAC_SIZE {
+		return nil, errors.New("bitlocker: Invalid AES-CCM entry")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := entry.Data[:CCM_NONCE_SIZE]
+	data := append([]byte{}, entry.Data[CCM_NONCE_SIZE:]...)
+
+	// The MAC is encrypted with the first counter block, followed
+	// by the payload.
+	counter := make([]byte, aes.BlockSize)
+	counter[0] = 14 - CCM_NONCE_SIZE
+	copy(counter[1:], nonce)
+	cipher.NewCTR(block, counter).XORKeyStream(data, data)
+
+	mac := data[:CCM_MAC_SIZE]
+	payload := data[CCM_MAC_SIZE:]
+
+	if subtle.ConstantTimeCompare(mac, ccmMAC(block, nonce, payload)) != 1 {
+		return nil, errors.New("bitlocker: Key does not match")
+	}
+
+	entries := parseEntries(payload)
+	if len(entries) == 0 || entries[0].ValueType != VALUE_TYPE_KEY ||
+		len(entries[0].Data) < 4 {
+		return nil, errors.New("bitlocker: Invalid decrypted key")
+	}
+	return entries[0], nil
+}
+
+// The CBC-MAC over the payload as specified by RFC 3610.
+func ccmMAC(block cipher.Block, nonce, payload []byte) []byte {
+	b0 := make([]byte, aes.BlockSize)
+
+	// No additional data, 16 byte MAC and a 3 byte length.
+	b0[0] = ((CCM_MAC_SIZE-2)/2)<<3 | (14 - CCM_NONCE_SIZE)
+	copy(b0[1:], nonce)
+	b0[13] = byte(len(payload) >> 16)
+	b0[14] = byte(len(payload) >> 8)
+	b0[15] = byte(len(payload))
+
+	mac := make([]byte, aes.BlockSize)
+	block.Encrypt(mac, b0)
+
+	for i := 0; i < len(payload); i += aes.BlockSize {
+		end := i + aes.BlockSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		for j := i; j < end; j++ {
+			mac[j-i] ^= payload[j]
+		}
+		block.Encrypt(mac, mac)
+	}
+	return mac
+}
+
+// The key inside a key entry follows the encryption method.
+func keyFromEntry(entry *Entry) []byte {
+	return entry.Data[4:]
+}
+
+// Parse a .BEK startup key file. Returns the key identifier and the
+// external key.
+func parseStartupKey(data []byte) (string, []byte, error) {
+	if len(data) < FVE_METADATA_HEADER_SIZE {
+		return "", nil, errors.New("bitlocker: Startup key file too short")
+	}
+
+	size := int(binary.LittleEndian.Uint32(data))
+	if size > len(data) || size < FVE_METADATA_HEADER_SIZE {
+		return "", nil, errors.New("bitlocker: Invalid startup key file")
+	}
+
+	for _, entry := range parseEntries(data[FVE_METADATA_HEADER_SIZE:size]) {
+		if entry.Type != ENTRY_TYPE_STARTUP_KEY ||
+			entry.ValueType != VALUE_TYPE_EXTERNAL_KEY || len(entry.Data) < 16 {
+			continue
+		}
+
+		key := entry.FindNested(VALUE_TYPE_KEY)
+		if key != nil && len(key.Data) > 4 {
+			return formatGUID(entry.Data), keyFromEntry(key), nil
+		}
+	}
+
+	return "", nil, errors.New("bitlocker: No external key in startup key file")
+}
+
+// Derive the key which decrypts the VMK from the key material.
+func (self *VMK) protectorKey(key *Key) ([]byte, error) {
+	switch self.Protection {
+	case PROTECTION_CLEAR_KEY:
+		clear_key := self.entry.FindNested(VALUE_TYPE_KEY)
+		if clear_key == nil || len(clear_key.Data) <= 4 {
+			return nil, errors.New("bitlocker: No clear key")
+		}
+		return keyFromEntry(clear_key), nil
+
+	case PROTECTION_RECOVERY_PASSWORD, PROTECTION_PASSWORD:
+		stretch := self.entry.FindNested(VALUE_TYPE_STRETCH_KEY)
+		if stretch == nil || len(stretch.Data) < 20 {
+			return nil, errors.New("bitlocker: No stretch key")
+		}
+		salt := stretch.Data[4:20]
+
+		if self.Protection == PROTECTION_PASSWORD {
+			if key.Password == "" {
+				return nil, nil
+			}
+			return passwordKey(key.Password, salt), nil
+		}
+
+		if key.RecoveryPassword == "" {
+			return nil, nil
+		}
+		return recoveryPasswordKey(key.RecoveryPassword, salt)
+
+	case PROTECTION_STARTUP_KEY:
+		if len(key.StartupKey) == 0 {
+			return nil, nil
+		}
+
+		id, external_key, err := parseStartupKey(key.StartupKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if id != self.ID {
+			return nil, nil
+		}
+		return external_key, nil
+	}
+
+	return nil, nil
+}
+
+// Decrypt the volume master key. Returns nil if the key material
+// does not apply to this protector.
+func (self *VMK) Unlock(key *Key) ([]byte, error) {
+	protector_key, err := self.protectorKey(key)
+	if err != nil || protector_key == nil {
+		return nil, err
+	}
+
+	encrypted := self.entry.FindNested(VALUE_TYPE_AES_CCM_KEY)
+	if encrypted == nil {
+		return nil, errors.New("bitlocker: No encrypted VMK")
+	}
+
+	vmk, err := decryptKeyEntry(encrypted, protector_key)
+	if err != nil {
+		return nil, err
+	}
+
+	return keyFromEntry(vmk), nil
+}
+
+// Recover the full volume encryption key and its encryption method
+// using the first protector that the key material unlocks.
+func (self *Metadata) Unlock(keys []*Key) ([]byte, uint32, *VMK, error) {
+	fvek_entry := self.FVEK()
+	if fvek_entry == nil {
+		return nil, 0, nil, errors.New("bitlocker: No FVEK in metadata")
+	}
+
+	// A clear key is always tried first.
+	keys = append([]*Key{{}}, keys...)
+
+	var last_err error
+	for _, key := range keys {
+		for _, vmk := range self.VMKs {
+			vmk_key, err := vmk.Unlock(key)
+			if err != nil {
+				last_err = fmt.Errorf("%v protector: %w",
+					ProtectionType(vmk.Protection), err)
+				continue
+			}
+
+			if vmk_key == nil {
+				continue
+			}
+
+			fvek, err := decryptKeyEntry(fvek_entry, vmk_key)
+			if err != nil {
+				last_err = err
+				continue
+			}
+
+			method := binary.LittleEndian.Uint32(fvek.Data)
+			return keyFromEntry(fvek), method, vmk, nil
+		}
+	}
+
+	if last_err != nil {
+		return nil, 0, nil, last_err
+	}
+
+	return nil, 0, nil, fmt.Errorf(
+		"bitlocker: No key provided for the volume protectors (%v)",
+		strings.Join(self.Protectors(), ", "))
+}
@@ -0,0 +1,287 @@
+package bitlocker
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+
+	"golang.org/x/crypto/xts"
+)
+
+// Decrypts a single sector in place given its byte offset in the
+// volume.
+type sectorDecryptor interface {
+	Decrypt(buf []byte, offset uint64) error
+}
+
+func newSectorDecryptor(method uint32, fvek []byte,
+	sector_size uint64) (sectorDecryptor, error) {
+	var key_size int
+	switch method {
+	case AES_128_CBC_DIFFUSER, AES_128_CBC:
+		key_size = 16
+	case AES_256_CBC_DIFFUSER, AES_256_CBC:
+		key_size = 32
+	case AES_128_XTS:
+		key_size = 32
+	case AES_256_XTS:
+		key_size = 64
+	default:
+		return nil, fmt.Errorf("bitlocker: Unsupported encryption method %#x",
+			method)
+	}
+
+	if len(fvek) < key_size {
+		return nil, errors.New("bitlocker: FVEK too short")
+	}
+
+	switch method {
+	case AES_128_XTS, AES_256_XTS:
+		c, err := xts.NewCipher(aes.NewCipher, fvek[:key_size])
+		if err != nil {
+			return nil, err
+		}
+		return &xtsDecryptor{cipher: c, sector_size: sector_size}, nil
+	}
+
+	block, err := aes.NewCipher(fvek[:key_size])
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cbcDecryptor{block: block}
+
+	// The tweak key follows the FVEK at offset 32.
+	if method == AES_128_CBC_DIFFUSER || method == AES_256_CBC_DIFFUSER {
+		if len(fvek) < 32+key_size {
+			return nil, errors.New("bitlocker: FVEK too short")
+		}
+
+		result.tweak, err = aes.NewCipher(fvek[32 : 32+key_size])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+type xtsDecryptor struct {
+	cipher      *xts.Cipher
+	sector_size uint64
+}
+
+func (self *xtsDecryptor) Decrypt(buf []byte, offset uint64) error {
+	self.cipher.Decrypt(buf, buf, offset/self.sector_size)
+	return nil
+}
+
+type cbcDecryptor struct {
+	block cipher.Block
+
+	// Only set for the Elephant diffuser.
+	tweak cipher.Block
+}
+
+func (self *cbcDecryptor) Decrypt(buf []byte, offset uint64) error {
+	if len(buf)%aes.BlockSize != 0 {
+		return fmt.Errorf("bitlocker: Invalid sector size %v", len(buf))
+	}
+
+	// The IV is the encrypted byte offset of the sector.
+	iv := make([]byte, aes.BlockSize)
+	binary.LittleEndian.PutUint64(iv, offset)
+	self.block.Encrypt(iv, iv)
+
+	cipher.NewCBCDecrypter(self.block, iv).CryptBlocks(buf, buf)
+
+	if self.tweak == nil {
+		return nil
+	}
+
+	// The sector key is derived from the offset with the tweak key.
+	sector_key := make([]byte, 32)
+	binary.LittleEndian.PutUint64(sector_key, offset)
+	self.tweak.Encrypt(sector_key[:16], sector_key[:16])
+
+	binary.LittleEndian.PutUint64(sector_key[16:], offset)
+	sector_key[31] = 0x80
+	self.tweak.Encrypt(sector_key[16:], sector_key[16:])
+
+	words := make([]uint32, len(buf)/4)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(buf[i*4:])
+	}
+
+	diffuserBDecrypt(words)
+	diffuserADecrypt(words)
+
+	for i, w := range words {
+		binary.LittleEndian.PutUint32(buf[i*4:], w)
+	}
+
+	for i := range buf {
+		buf[i] ^= sector_key[i%32]
+	}
+	return nil
+}
+
+var (
+	diffuserARotations = []int{9, 0, 13, 0}
+	diffuserBRotations = []int{0, 10, 0, 25}
+)
+
+func diffuserADecrypt(words []uint32) {
+	n := len(words)
+	for cycle := 0; cycle < 5; cycle++ {
+		for i := 0; i < n; i++ {
+			words[i] += words[(i-2+n)%n] ^
+				bits.RotateLeft32(words[(i-5+n)%n], diffuserARotations[i%4])
+		}
+	}
+}
+
+func diffuserBDecrypt(words []uint32) {
+	n := len(words)
+	for cycle := 0; cycle < 3; cycle++ {
+		for i := 0; i < n; i++ {
+			words[i] += words[(i+2)%n] ^
+				bits.RotateLeft32(words[(i+5)%n], diffuserBRotations[i%4])
+		}
+	}
+}
+
+// An unlocked BitLocker volume. Sectors are decrypted as they are
+// read.
+type Volume struct {
+	Metadata *Metadata
+
+	// The method used to encrypt the data.
+	EncryptionMethod uint32
+
+	// The protector which unlocked the volume.
+	Protector *VMK
+
+	reader    io.ReaderAt
+	decryptor sectorDecryptor
+	size      int64
+}
+
+func NewVolume(reader io.ReaderAt, size int64, keys []*Key) (*Volume, error) {
+	metadata, err := ParseMetadata(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return newVolumeFromMetadata(reader, size, metadata, keys)
+}
+
+func newVolumeFromMetadata(reader io.ReaderAt, size int64,
+	metadata *Metadata, keys []*Key) (*Volume, error) {
+	fvek, method, vmk, err := metadata.Unlock(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	decryptor, err := newSectorDecryptor(method, fvek, metadata.SectorSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Volume{
+		Metadata:         metadata,
+		EncryptionMethod: method,
+		Protector:        vmk,
+		reader:           reader,
+		decryptor:        decryptor,
+		size:             size,
+	}, nil
+}
+
+func (self *Volume) Size() int64 {
+	return self.size
+}
+
+// Is the sector part of an FVE metadata block?
+func (self *Volume) isMetadata(offset uint64) bool {
+	for _, block := range self.Metadata.BlockOffsets {
+		if offset >= block && offset < block+FVE_METADATA_BLOCK_SIZE {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *Volume) readSector(buf []byte, offset uint64) error {
+	metadata := self.Metadata
+	sector_size := metadata.SectorSize
+
+	// The original volume header is stored encrypted elsewhere.
+	physical := offset
+	if offset < metadata.VolumeHeaderSectors*sector_size {
+		physical = metadata.VolumeHeaderOffset + offset
+
+	} else if self.isMetadata(offset) {
+		for i := range buf {
+			buf[i] = 0
+		}
+		return nil
+	}
+
+	n, err := self.reader.ReadAt(buf, int64(physical))
+	if n < len(buf) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	// Volumes which are only partially encrypted are plain text
+	// past the encrypted size.
+	if physical >= metadata.EncryptedSize {
+		return nil
+	}
+
+	return self.decryptor.Decrypt(buf, physical)
+}
+
+func (self *Volume) ReadAt(buf []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, errors.New("bitlocker: Negative offset")
+	}
+
+	if offset >= self.size {
+		return 0, io.EOF
+	}
+
+	to_read := int64(len(buf))
+	if offset+to_read > self.size {
+		to_read = self.size - offset
+	}
+
+	sector_size := int64(self.Metadata.SectorSize)
+	sector := make([]byte, sector_size)
+
+	total := int64(0)
+	for total < to_read {
+		current := offset + total
+		start := current / sector_size * sector_size
+
+		err := self.readSector(sector, uint64(start))
+		if err != nil {
+			return int(total), err
+		}
+
+		n := int64(copy(buf[total:to_read], sector[current-start:]))
+		total += n
+	}
+
+	if total < int64(len(buf)) {
+		return int(total), io.EOF
+	}
+	return int(total), nil
+}
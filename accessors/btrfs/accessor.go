@@ -0,0 +1,373 @@
+package btrfs
+
+// This is an accessor which parses a Btrfs filesystem
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type BtrfsFileInfo struct {
+	inode      *Inode
+	name       string
+	_full_path *accessors.OSPath
+	deleted    bool
+	link       string
+}
+
+func newBtrfsFileInfo(full_path *accessors.OSPath,
+	res *LookupResult) *BtrfsFileInfo {
+	result := &BtrfsFileInfo{
+		inode:      res.Inode,
+		name:       full_path.Basename(),
+		_full_path: full_path,
+		deleted:    res.Deleted,
+	}
+
+	if res.Inode.IsLink() {
+		result.link, _ = res.Inode.Readlink()
+	}
+
+	return result
+}
+
+func (self *BtrfsFileInfo) Name() string {
+	return self.name
+}
+
+func (self *BtrfsFileInfo) IsDir() bool {
+	return self.inode.IsDir()
+}
+
+func (self *BtrfsFileInfo) Size() int64 {
+	return self.inode.Size
+}
+
+func (self *BtrfsFileInfo) Mode() os.FileMode {
+	return self.inode.FileMode()
+}
+
+func (self *BtrfsFileInfo) ModTime() time.Time {
+	return self.inode.Mtime
+}
+
+func (self *BtrfsFileInfo) Mtime() time.Time {
+	return self.inode.Mtime
+}
+
+func (self *BtrfsFileInfo) Atime() time.Time {
+	return self.inode.Atime
+}
+
+func (self *BtrfsFileInfo) Ctime() time.Time {
+	return self.inode.Ctime
+}
+
+// Btrfs calls the creation time otime.
+func (self *BtrfsFileInfo) Btime() time.Time {
+	return self.inode.Otime
+}
+
+func (self *BtrfsFileInfo) Data() *ordereddict.Dict {
+	result := ordereddict.NewDict().
+		Set("Inode", self.inode.Ino).
+		Set("Subvolume", self.inode.Tree.Id).
+		Set("Uid", self.inode.Uid).
+		Set("Gid", self.inode.Gid).
+		Set("Nlink", self.inode.Nlink).
+		Set("Generation", self.inode.Generation)
+
+	if self.link != "" {
+		result.Set("Link", self.link)
+	}
+
+	if self.deleted {
+		result.Set("Deleted", true)
+	}
+	return result
+}
+
+// Deleted entries may share a name with a live entry.
+func (self *BtrfsFileInfo) UniqueName() string {
+	if self.deleted {
+		return fmt.Sprintf("%v:%v", self._full_path.String(), self.inode.Ino)
+	}
+	return self._full_path.String()
+}
+
+func (self *BtrfsFileInfo) FullPath() string {
+	return self._full_path.String()
+}
+
+func (self *BtrfsFileInfo) OSPath() *accessors.OSPath {
+	return self._full_path
+}
+
+func (self *BtrfsFileInfo) IsLink() bool {
+	return self.inode.IsLink()
+}
+
+// Symlinks are resolved relative to the root of the filesystem.
+func (self *BtrfsFileInfo) GetLink() (*accessors.OSPath, error) {
+	if self.link == "" {
+		return nil, errors.New("Not a symlink")
+	}
+
+	target := self.link
+	if !path.IsAbs(target) {
+		dir := self._full_path.Dirname().Components
+		target = path.Join("/", strings.Join(dir, "/"), target)
+	}
+
+	result := self._full_path.Copy()
+	result.Components = nil
+	for _, c := range strings.Split(path.Clean("/"+target), "/") {
+		if c != "" {
+			result.Components = append(result.Components, c)
+		}
+	}
+	return result, nil
+}
+
+type BtrfsFileSystemAccessor struct {
+	scope vfilter.Scope
+
+	// The delegate accessor we use to open the underlying volume.
+	accessor string
+	device   *accessors.OSPath
+
+	root *accessors.OSPath
+}
+
+func NewBtrfsFileSystemAccessor(
+	scope vfilter.Scope,
+	root_path *accessors.OSPath,
+	device *accessors.OSPath, accessor string) *BtrfsFileSystemAccessor {
+	return &BtrfsFileSystemAccessor{
+		scope:    scope,
+		accessor: accessor,
+		device:   device,
+		root:     root_path,
+	}
+}
+
+func (self BtrfsFileSystemAccessor) New(scope vfilter.Scope) (
+	accessors.FileSystemAccessor, error) {
+	return &BtrfsFileSystemAccessor{
+		scope:    scope,
+		device:   self.device,
+		accessor: self.accessor,
+		root:     self.root,
+	}, nil
+}
+
+func (self BtrfsFileSystemAccessor) ParsePath(path string) (
+	*accessors.OSPath, error) {
+	return accessors.NewLinuxOSPath(path)
+}
+
+func (self *BtrfsFileSystemAccessor) ReadDir(path string) (
+	res []accessors.FileInfo, err error) {
+	fullpath, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.ReadDirWithOSPath(fullpath)
+}
+
+func (self *BtrfsFileSystemAccessor) ReadDirWithOSPath(
+	fullpath *accessors.OSPath) (res []accessors.FileInfo, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_btrfs: %v", r)
+		}
+	}()
+
+	btrfs_ctx, err := GetBtrfsContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := btrfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := dir.Inode.ReadDir()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []accessors.FileInfo{}
+	for _, entry := range entries {
+		inode, err := btrfs_ctx.OpenEntry(entry)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, newBtrfsFileInfo(
+			fullpath.Append(entry.Name), &LookupResult{
+				Inode:   inode,
+				Entry:   entry,
+				Deleted: dir.Deleted || entry.Deleted,
+			}))
+	}
+	return result, nil
+}
+
+func (self *BtrfsFileSystemAccessor) Open(
+	path string) (res accessors.ReadSeekCloser, err error) {
+	full_path, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.OpenWithOSPath(full_path)
+}
+
+func (self *BtrfsFileSystemAccessor) OpenWithOSPath(
+	fullpath *accessors.OSPath) (res accessors.ReadSeekCloser, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_btrfs: %v", r)
+		}
+	}()
+
+	btrfs_ctx, err := GetBtrfsContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := btrfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Inode.IsDir() {
+		return nil, errors.New("raw_btrfs: Can not open a directory")
+	}
+
+	reader, err := file.Inode.Reader()
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileReader{reader: reader, size: file.Inode.Size}, nil
+}
+
+func (self *BtrfsFileSystemAccessor) Lstat(
+	path string) (res accessors.FileInfo, err error) {
+	fullpath, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.LstatWithOSPath(fullpath)
+}
+
+func (self *BtrfsFileSystemAccessor) LstatWithOSPath(
+	fullpath *accessors.OSPath) (res accessors.FileInfo, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_btrfs: %v", r)
+		}
+	}()
+
+	btrfs_ctx, err := GetBtrfsContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := btrfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBtrfsFileInfo(fullpath, file), nil
+}
+
+// A seekable reader over the file content.
+type fileReader struct {
+	reader io.ReaderAt
+	size   int64
+	offset int64
+}
+
+func (self *fileReader) Read(buf []byte) (int, error) {
+	n, err := self.reader.ReadAt(buf, self.offset)
+	self.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (self *fileReader) ReadAt(buf []byte, offset int64) (int, error) {
+	return self.reader.ReadAt(buf, offset)
+}
+
+func (self *fileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += self.offset
+	case io.SeekEnd:
+		offset += self.size
+	default:
+		return 0, errors.New("Invalid whence")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("Negative seek")
+	}
+	self.offset = offset
+	return offset, nil
+}
+
+func (self *fileReader) Close() error {
+	return nil
+}
+
+func init() {
+	accessors.Register("raw_btrfs", &BtrfsFileSystemAccessor{},
+		`Access the Btrfs filesystem inside an image by parsing the image.
+
+This accessor is designed to operate on images directly. It requires a
+delegate accessor to get the raw image and will open files using the
+full path rooted at the top level subvolume. Other subvolumes and
+snapshots appear as directories at the place they are linked.
+
+Files which were deleted recently may still be present in the older
+filesystem trees referenced by the superblock backup roots. These are
+listed with Data.Deleted set.
+
+## Example
+
+The following query will glob all the files under the directory 'a'
+inside a Btrfs image file
+
+SELECT *
+FROM glob(globs='/**',
+  accessor="raw_btrfs",
+  root=pathspec(
+    Path="a",
+    DelegateAccessor="file",
+    DelegatePath='btrfs.dd'))
+
+`)
+
+	json.RegisterCustomEncoder(&BtrfsFileInfo{}, accessors.MarshalGlobFileInfo)
+}
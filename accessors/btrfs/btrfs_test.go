@@ -0,0 +1,365 @@
+package btrfs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/klauspost/compress/zstd"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+const (
+	testNodeSize = 4096
+	testChunk    = 0x100000
+)
+
+var (
+	testFSID   = []byte("0123456789abcdef")
+	testMtime  = time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+	testOtime  = time.Date(2023, 6, 7, 8, 9, 10, 0, time.UTC)
+	zlibData   = strings.Repeat("zlib data ", 400)
+	zstdData   = strings.Repeat("zstd data ", 30)
+	dataBinRaw = "regular extent"
+)
+
+type testItem struct {
+	key  Key
+	data []byte
+}
+
+type testImage struct {
+	data []byte
+}
+
+// Blocks are allocated from the single chunk which maps logical to
+// physical addresses one to one.
+func (self *testImage) block(n int) []byte {
+	offset := testChunk + n*testNodeSize
+	return self.data[offset : offset+testNodeSize]
+}
+
+func blockAddr(n int) uint64 {
+	return uint64(testChunk + n*testNodeSize)
+}
+
+func putKey(buf []byte, key Key) {
+	binary.LittleEndian.PutUint64(buf, key.ObjectId)
+	buf[8] = key.Type
+	binary.LittleEndian.PutUint64(buf[9:], key.Offset)
+}
+
+func (self *testImage) putHeader(n int, owner, generation uint64,
+	nritems int, level uint8) []byte {
+	buf := self.block(n)
+	copy(buf[32:], testFSID)
+	binary.LittleEndian.PutUint64(buf[48:], blockAddr(n))
+	binary.LittleEndian.PutUint64(buf[80:], generation)
+	binary.LittleEndian.PutUint64(buf[88:], owner)
+	binary.LittleEndian.PutUint32(buf[96:], uint32(nritems))
+	buf[100] = level
+	return buf
+}
+
+func (self *testImage) putLeaf(n int, owner, generation uint64, items []testItem) {
+	buf := self.putHeader(n, owner, generation, len(items), 0)
+	data := buf[BTRFS_HEADER_SIZE:]
+	end := len(data)
+	for i, item := range items {
+		end -= len(item.data)
+		copy(data[end:], item.data)
+
+		hdr := data[i*BTRFS_ITEM_SIZE:]
+		putKey(hdr, item.key)
+		binary.LittleEndian.PutUint32(hdr[17:], uint32(end))
+		binary.LittleEndian.PutUint32(hdr[21:], uint32(len(item.data)))
+	}
+}
+
+func (self *testImage) putNode(n int, owner, generation uint64,
+	keys []Key, children []int) {
+	buf := self.putHeader(n, owner, generation, len(keys), 1)
+	data := buf[BTRFS_HEADER_SIZE:]
+	for i, key := range keys {
+		ptr := data[i*BTRFS_KEY_PTR_SIZE:]
+		putKey(ptr, key)
+		binary.LittleEndian.PutUint64(ptr[17:], blockAddr(children[i]))
+	}
+}
+
+func chunkItem() []byte {
+	buf := make([]byte, BTRFS_CHUNK_ITEM_SIZE+BTRFS_STRIPE_SIZE)
+	binary.LittleEndian.PutUint64(buf, testChunk)
+	binary.LittleEndian.PutUint64(buf[16:], 0x10000)
+	binary.LittleEndian.PutUint64(buf[24:], 2)
+	binary.LittleEndian.PutUint16(buf[44:], 1)
+	binary.LittleEndian.PutUint64(buf[48:], 1)
+	binary.LittleEndian.PutUint64(buf[56:], testChunk)
+	return buf
+}
+
+func putTimespec(buf []byte, t time.Time) {
+	binary.LittleEndian.PutUint64(buf, uint64(t.Unix()))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(t.Nanosecond()))
+}
+
+func inodeItem(ino uint64, mode uint32, size int) testItem {
+	buf := make([]byte, BTRFS_INODE_ITEM_SIZE)
+	binary.LittleEndian.PutUint64(buf[16:], uint64(size))
+	binary.LittleEndian.PutUint32(buf[40:], 1)
+	binary.LittleEndian.PutUint32(buf[44:], 1000)
+	binary.LittleEndian.PutUint32(buf[48:], 1000)
+	binary.LittleEndian.PutUint32(buf[52:], mode)
+	for _, offset := range []int{112, 124, 136} {
+		putTimespec(buf[offset:], testMtime)
+	}
+	putTimespec(buf[148:], testOtime)
+	return testItem{Key{ino, BTRFS_INODE_ITEM_KEY, 0}, buf}
+}
+
+func dirIndex(dir, index uint64, name string, location Key, ftype uint8) testItem {
+	buf := make([]byte, BTRFS_DIR_ITEM_SIZE+len(name))
+	putKey(buf, location)
+	binary.LittleEndian.PutUint16(buf[27:], uint16(len(name)))
+	buf[29] = ftype
+	copy(buf[BTRFS_DIR_ITEM_SIZE:], name)
+	return testItem{Key{dir, BTRFS_DIR_INDEX_KEY, index}, buf}
+}
+
+func fileEntry(index, ino uint64, name string, ftype uint8) testItem {
+	return dirIndex(256, index, name, Key{ino, BTRFS_INODE_ITEM_KEY, 0}, ftype)
+}
+
+func inlineExtent(ino uint64, compression uint8, ram_bytes int, data []byte) testItem {
+	buf := make([]byte, BTRFS_FILE_EXTENT_INLINE_DATA_START+len(data))
+	binary.LittleEndian.PutUint64(buf[8:], uint64(ram_bytes))
+	buf[16] = compression
+	buf[20] = BTRFS_FILE_EXTENT_INLINE
+	copy(buf[BTRFS_FILE_EXTENT_INLINE_DATA_START:], data)
+	return testItem{Key{ino, BTRFS_EXTENT_DATA_KEY, 0}, buf}
+}
+
+func regularExtent(ino, offset uint64, compression uint8,
+	ram_bytes, disk_bytenr, disk_num_bytes, num_bytes uint64) testItem {
+	buf := make([]byte, BTRFS_FILE_EXTENT_ITEM_SIZE)
+	binary.LittleEndian.PutUint64(buf[8:], ram_bytes)
+	buf[16] = compression
+	buf[20] = BTRFS_FILE_EXTENT_REG
+	binary.LittleEndian.PutUint64(buf[21:], disk_bytenr)
+	binary.LittleEndian.PutUint64(buf[29:], disk_num_bytes)
+	binary.LittleEndian.PutUint64(buf[45:], num_bytes)
+	return testItem{Key{ino, BTRFS_EXTENT_DATA_KEY, offset}, buf}
+}
+
+func rootItem(id uint64, block int, level uint8) testItem {
+	buf := make([]byte, 439)
+	binary.LittleEndian.PutUint64(buf[168:], 256)
+	binary.LittleEndian.PutUint64(buf[176:], blockAddr(block))
+	buf[238] = level
+	return testItem{Key{id, BTRFS_ROOT_ITEM_KEY, 0}, buf}
+}
+
+// A literal run followed by an overlapping match: "abcdabcd".
+var lzoSegment = []byte{21, 'a', 'b', 'c', 'd', 108, 0, 0x11, 0, 0}
+
+func buildImage() []byte {
+	img := &testImage{data: make([]byte, 2*testChunk)}
+
+	sb := img.data[BTRFS_SUPER_INFO_OFFSET:]
+	copy(sb[0x20:], testFSID)
+	copy(sb[0x40:], BTRFS_MAGIC)
+	binary.LittleEndian.PutUint64(sb[0x48:], 10)
+	binary.LittleEndian.PutUint64(sb[0x50:], blockAddr(1))
+	binary.LittleEndian.PutUint64(sb[0x58:], blockAddr(0))
+	binary.LittleEndian.PutUint32(sb[0x90:], testNodeSize)
+	binary.LittleEndian.PutUint32(sb[0x94:], testNodeSize)
+	binary.LittleEndian.PutUint64(sb[0xc9:], 1)
+	copy(sb[0x12b:], "testfs")
+
+	chunk := chunkItem()
+	array := sb[BTRFS_SYSTEM_CHUNK_ARRAY_OFFSET:]
+	putKey(array, Key{BTRFS_FIRST_CHUNK_TREE, BTRFS_CHUNK_ITEM_KEY, testChunk})
+	copy(array[BTRFS_DISK_KEY_SIZE:], chunk)
+	binary.LittleEndian.PutUint32(sb[0xa0:], uint32(BTRFS_DISK_KEY_SIZE+len(chunk)))
+
+	// The previous transaction still had a deleted file.
+	backup := sb[BTRFS_SUPER_ROOTS_OFFSET:]
+	binary.LittleEndian.PutUint64(backup[48:], blockAddr(4))
+	binary.LittleEndian.PutUint64(backup[56:], 9)
+
+	img.putLeaf(0, 3, 10, []testItem{{
+		Key{BTRFS_FIRST_CHUNK_TREE, BTRFS_CHUNK_ITEM_KEY, testChunk}, chunk}})
+
+	img.putLeaf(1, 1, 10, []testItem{
+		rootItem(BTRFS_FS_TREE_OBJECTID, 2, 1),
+		rootItem(256, 3, 0),
+	})
+
+	// The top level tree has two leaves.
+	leaf_a := []testItem{
+		inodeItem(256, 040755, 0),
+		fileEntry(2, 257, "hello.txt", BTRFS_FT_REG_FILE),
+		fileEntry(3, 258, "data.bin", BTRFS_FT_REG_FILE),
+		fileEntry(4, 259, "link", BTRFS_FT_SYMLINK),
+		fileEntry(5, 260, "zlib.txt", BTRFS_FT_REG_FILE),
+		fileEntry(6, 261, "zstd.txt", BTRFS_FT_REG_FILE),
+		fileEntry(7, 262, "lzo.txt", BTRFS_FT_REG_FILE),
+		dirIndex(256, 8, "subvol",
+			Key{256, BTRFS_ROOT_ITEM_KEY, MAX_UINT64}, BTRFS_FT_DIR),
+		inodeItem(257, 0100644, 11),
+		inlineExtent(257, BTRFS_COMPRESS_NONE, 11, []byte("hello world")),
+		inodeItem(258, 0100644, 3*testNodeSize),
+		regularExtent(258, 0, BTRFS_COMPRESS_NONE, testNodeSize,
+			blockAddr(5), testNodeSize, testNodeSize),
+		regularExtent(258, testNodeSize, BTRFS_COMPRESS_NONE, testNodeSize,
+			0, 0, testNodeSize),
+	}
+	copy(img.block(5), dataBinRaw)
+
+	var zlib_buf bytes.Buffer
+	w := zlib.NewWriter(&zlib_buf)
+	w.Write([]byte(zlibData))
+	w.Close()
+	copy(img.block(6), zlib_buf.Bytes())
+
+	encoder, _ := zstd.NewWriter(nil)
+	zstd_buf := encoder.EncodeAll([]byte(zstdData), nil)
+
+	lzo_buf := make([]byte, 8)
+	binary.LittleEndian.PutUint32(lzo_buf, uint32(8+len(lzoSegment)))
+	binary.LittleEndian.PutUint32(lzo_buf[4:], uint32(len(lzoSegment)))
+	lzo_buf = append(lzo_buf, lzoSegment...)
+
+	leaf_b := []testItem{
+		inodeItem(259, 0120777, 9),
+		inlineExtent(259, BTRFS_COMPRESS_NONE, 9, []byte("hello.txt")),
+		inodeItem(260, 0100644, len(zlibData)),
+		regularExtent(260, 0, BTRFS_COMPRESS_ZLIB, uint64(len(zlibData)),
+			blockAddr(6), uint64(zlib_buf.Len()), uint64(len(zlibData))),
+		inodeItem(261, 0100644, len(zstdData)),
+		inlineExtent(261, BTRFS_COMPRESS_ZSTD, len(zstdData), zstd_buf),
+		inodeItem(262, 0100644, 8),
+		inlineExtent(262, BTRFS_COMPRESS_LZO, 8, lzo_buf),
+	}
+
+	img.putNode(2, BTRFS_FS_TREE_OBJECTID, 10,
+		[]Key{leaf_a[0].key, leaf_b[0].key}, []int{7, 8})
+	img.putLeaf(7, BTRFS_FS_TREE_OBJECTID, 10, leaf_a)
+	img.putLeaf(8, BTRFS_FS_TREE_OBJECTID, 10, leaf_b)
+
+	img.putLeaf(3, 256, 10, []testItem{
+		inodeItem(256, 040755, 0),
+		fileEntry(2, 257, "inner.txt", BTRFS_FT_REG_FILE),
+		inodeItem(257, 0100644, 5),
+		inlineExtent(257, BTRFS_COMPRESS_NONE, 5, []byte("inner")),
+	})
+
+	img.putLeaf(4, BTRFS_FS_TREE_OBJECTID, 9, []testItem{
+		inodeItem(256, 040755, 0),
+		fileEntry(2, 257, "hello.txt", BTRFS_FT_REG_FILE),
+		fileEntry(9, 263, "deleted.txt", BTRFS_FT_REG_FILE),
+		inodeItem(257, 0100644, 11),
+		inlineExtent(257, BTRFS_COMPRESS_NONE, 11, []byte("hello world")),
+		inodeItem(263, 0100644, 3),
+		inlineExtent(263, BTRFS_COMPRESS_NONE, 3, []byte("old")),
+	})
+
+	return img.data
+}
+
+func TestBtrfs(t *testing.T) {
+	dir := t.TempDir()
+	image := filepath.Join(dir, "btrfs.dd")
+	assert.NoError(t, os.WriteFile(image, buildImage(), 0600))
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	accessor, err := accessors.GetAccessor("raw_btrfs", scope)
+	assert.NoError(t, err)
+
+	root := accessors.MustNewLinuxOSPath("")
+	root.SetPathSpec(&accessors.PathSpec{
+		DelegateAccessor: "file",
+		DelegatePath:     image,
+	})
+
+	list := func(path *accessors.OSPath) []string {
+		children, err := accessor.ReadDirWithOSPath(path)
+		assert.NoError(t, err)
+
+		result := []string{}
+		for _, c := range children {
+			result = append(result, c.Name())
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	read := func(path *accessors.OSPath) string {
+		fd, err := accessor.OpenWithOSPath(path)
+		assert.NoError(t, err)
+		defer fd.Close()
+
+		data, err := ioutil.ReadAll(fd)
+		assert.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Equal(t, []string{"data.bin", "deleted.txt", "hello.txt", "link",
+		"lzo.txt", "subvol", "zlib.txt", "zstd.txt"}, list(root))
+
+	info, err := accessor.LstatWithOSPath(root.Append("hello.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), info.Size())
+	assert.Equal(t, testMtime, info.Mtime())
+	assert.Equal(t, testOtime, info.Btime())
+	assert.Equal(t, "-rw-r--r--", info.Mode().String())
+	assert.Equal(t, "hello world", read(root.Append("hello.txt")))
+
+	// Holes read as zeros.
+	data := read(root.Append("data.bin"))
+	assert.Equal(t, 3*testNodeSize, len(data))
+	assert.Equal(t, dataBinRaw, data[:len(dataBinRaw)])
+	assert.Equal(t, string(make([]byte, 2*testNodeSize)), data[testNodeSize:])
+
+	assert.Equal(t, zlibData, read(root.Append("zlib.txt")))
+	assert.Equal(t, zstdData, read(root.Append("zstd.txt")))
+	assert.Equal(t, "abcdabcd", read(root.Append("lzo.txt")))
+
+	// Subvolumes are entered transparently.
+	assert.Equal(t, []string{"inner.txt"}, list(root.Append("subvol")))
+	info, err = accessor.LstatWithOSPath(root.Append("subvol", "inner.txt"))
+	assert.NoError(t, err)
+	subvolume, _ := info.Data().Get("Subvolume")
+	assert.Equal(t, uint64(256), subvolume)
+	assert.Equal(t, "inner", read(root.Append("subvol", "inner.txt")))
+
+	// Deleted files are recovered from the backup roots.
+	info, err = accessor.LstatWithOSPath(root.Append("deleted.txt"))
+	assert.NoError(t, err)
+	deleted, _ := info.Data().Get("Deleted")
+	assert.Equal(t, true, deleted)
+	assert.Equal(t, "old", read(root.Append("deleted.txt")))
+
+	info, err = accessor.LstatWithOSPath(root.Append("link"))
+	assert.NoError(t, err)
+	assert.True(t, info.IsLink())
+	target, err := info.GetLink()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hello.txt"}, target.Components)
+}
@@ -0,0 +1,293 @@
+package btrfs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	BTRFS_COMPRESS_NONE = 0
+	BTRFS_COMPRESS_ZLIB = 1
+	BTRFS_COMPRESS_LZO  = 2
+	BTRFS_COMPRESS_ZSTD = 3
+
+	// Compressed extents are limited to 128kb of data.
+	BTRFS_MAX_UNCOMPRESSED = 128 * 1024
+)
+
+var (
+	errLZOCorrupt = errors.New("btrfs: Corrupt lzo stream")
+
+	zstdDecoder, _ = zstd.NewReader(nil,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderMaxMemory(BTRFS_MAX_UNCOMPRESSED*2))
+)
+
+// Decompress an extent into at most ram_bytes.
+func decompress(compression uint8, data []byte,
+	ram_bytes uint64, sector_size uint32) ([]byte, error) {
+	if ram_bytes > BTRFS_MAX_UNCOMPRESSED {
+		ram_bytes = BTRFS_MAX_UNCOMPRESSED
+	}
+
+	switch compression {
+	case BTRFS_COMPRESS_NONE:
+		return data, nil
+
+	case BTRFS_COMPRESS_ZLIB:
+		reader, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		result, err := io.ReadAll(io.LimitReader(reader, int64(ram_bytes)))
+		if err != nil && len(result) == 0 {
+			return nil, err
+		}
+		return result, nil
+
+	case BTRFS_COMPRESS_ZSTD:
+		// Btrfs does not store the content size in the frame.
+		return zstdDecoder.DecodeAll(data, make([]byte, 0, ram_bytes))
+
+	case BTRFS_COMPRESS_LZO:
+		return decompressLZOSegments(data, ram_bytes, sector_size)
+	}
+
+	return nil, fmt.Errorf("btrfs: Unsupported compression %v", compression)
+}
+
+// Btrfs stores LZO extents as a total length followed by segments,
+// each prefixed by its compressed length. A segment length never
+// straddles a sector boundary.
+func decompressLZOSegments(data []byte, ram_bytes uint64,
+	sector_size uint32) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, errLZOCorrupt
+	}
+
+	total := int(binary.LittleEndian.Uint32(data))
+	if total > len(data) {
+		total = len(data)
+	}
+
+	result := make([]byte, 0, ram_bytes)
+	cur := 4
+	for cur+4 <= total && uint64(len(result)) < ram_bytes {
+		seg_len := int(binary.LittleEndian.Uint32(data[cur:]))
+		cur += 4
+		if seg_len > total-cur {
+			return nil, errLZOCorrupt
+		}
+
+		out, err := lzo1xDecompress(data[cur:cur+seg_len], int(sector_size))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, out...)
+		cur += seg_len
+
+		left := int(sector_size) - cur%int(sector_size)
+		if left < 4 {
+			cur += left
+		}
+	}
+
+	if uint64(len(result)) > ram_bytes {
+		result = result[:ram_bytes]
+	}
+	return result, nil
+}
+
+type lzoState struct {
+	in  []byte
+	ip  int
+	out []byte
+	max int
+}
+
+func (self *lzoState) byte() (int, error) {
+	if self.ip >= len(self.in) {
+		return 0, errLZOCorrupt
+	}
+	b := self.in[self.ip]
+	self.ip++
+	return int(b), nil
+}
+
+// Lengths longer than the instruction can hold are encoded as a run of
+// zero bytes followed by the remainder.
+func (self *lzoState) length(base int) (int, error) {
+	t := 0
+	for {
+		b, err := self.byte()
+		if err != nil {
+			return 0, err
+		}
+		if b != 0 {
+			return t + base + b, nil
+		}
+		t += 255
+		if t > self.max {
+			return 0, errLZOCorrupt
+		}
+	}
+}
+
+func (self *lzoState) le16() (int, error) {
+	if self.ip+2 > len(self.in) {
+		return 0, errLZOCorrupt
+	}
+	v := int(binary.LittleEndian.Uint16(self.in[self.ip:]))
+	self.ip += 2
+	return v, nil
+}
+
+func (self *lzoState) literals(n int) error {
+	if self.ip+n > len(self.in) || len(self.out)+n > self.max {
+		return errLZOCorrupt
+	}
+	self.out = append(self.out, self.in[self.ip:self.ip+n]...)
+	self.ip += n
+	return nil
+}
+
+// Matches may overlap the output so copy byte by byte.
+func (self *lzoState) match(distance, n int) error {
+	start := len(self.out) - distance
+	if distance <= 0 || start < 0 || len(self.out)+n > self.max {
+		return errLZOCorrupt
+	}
+	for i := 0; i < n; i++ {
+		self.out = append(self.out, self.out[start+i])
+	}
+	return nil
+}
+
+// A decompressor for the LZO1X format as produced by the kernel's
+// lzo1x_1_compress().
+func lzo1xDecompress(in []byte, max int) ([]byte, error) {
+	s := &lzoState{in: in, max: max}
+	state := 0
+
+	if len(in) > 0 && in[0] > 17 {
+		s.ip++
+		t := int(in[0]) - 17
+		err := s.literals(t)
+		if err != nil {
+			return nil, err
+		}
+		state = 4
+		if t < 4 {
+			state = t
+		}
+	}
+
+	for {
+		t, err := s.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		var distance, length, next int
+
+		switch {
+		case t < 16 && state == 0:
+			if t == 0 {
+				t, err = s.length(15)
+				if err != nil {
+					return nil, err
+				}
+			}
+			err = s.literals(t + 3)
+			if err != nil {
+				return nil, err
+			}
+			state = 4
+			continue
+
+		case t < 16 && state != 4:
+			b, err := s.byte()
+			if err != nil {
+				return nil, err
+			}
+			next = t & 3
+			distance = 1 + t>>2 + b<<2
+			length = 2
+
+		case t < 16:
+			b, err := s.byte()
+			if err != nil {
+				return nil, err
+			}
+			next = t & 3
+			distance = 1 + 0x800 + t>>2 + b<<2
+			length = 3
+
+		case t >= 64:
+			b, err := s.byte()
+			if err != nil {
+				return nil, err
+			}
+			next = t & 3
+			distance = 1 + (t>>2)&7 + b<<3
+			length = t>>5 + 1
+
+		case t >= 32:
+			length = t&31 + 2
+			if length == 2 {
+				length, err = s.length(31 + 2)
+				if err != nil {
+					return nil, err
+				}
+			}
+			v, err := s.le16()
+			if err != nil {
+				return nil, err
+			}
+			next = v & 3
+			distance = 1 + v>>2
+
+		default:
+			high := (t & 8) << 11
+			length = t&7 + 2
+			if length == 2 {
+				length, err = s.length(7 + 2)
+				if err != nil {
+					return nil, err
+				}
+			}
+			v, err := s.le16()
+			if err != nil {
+				return nil, err
+			}
+			next = v & 3
+			distance = high + v>>2
+			if distance == 0 {
+				if length != 3 {
+					return nil, errLZOCorrupt
+				}
+				return s.out, nil
+			}
+			distance += 0x4000
+		}
+
+		err = s.match(distance, length)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.literals(next)
+		if err != nil {
+			return nil, err
+		}
+		state = next
+	}
+}
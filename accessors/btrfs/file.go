@@ -0,0 +1,256 @@
+package btrfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+)
+
+const (
+	BTRFS_FILE_EXTENT_INLINE   = 0
+	BTRFS_FILE_EXTENT_REG      = 1
+	BTRFS_FILE_EXTENT_PREALLOC = 2
+
+	BTRFS_FILE_EXTENT_INLINE_DATA_START = 21
+	BTRFS_FILE_EXTENT_ITEM_SIZE         = 53
+)
+
+type Extent struct {
+	// Offset within the file.
+	FileOffset uint64
+	Type       uint8
+	Compressed uint8
+	RamBytes   uint64
+
+	// Inline data.
+	Data []byte
+
+	// Where the (possibly compressed) data lives in the logical
+	// address space. DiskBytenr of 0 is a hole.
+	DiskBytenr   uint64
+	DiskNumBytes uint64
+
+	// The range of the uncompressed extent which belongs to the
+	// file.
+	Offset   uint64
+	NumBytes uint64
+}
+
+func (self *Extent) Length() uint64 {
+	if self.Type == BTRFS_FILE_EXTENT_INLINE {
+		return self.RamBytes
+	}
+	return self.NumBytes
+}
+
+func (self *Inode) Extents() ([]*Extent, error) {
+	result := []*Extent{}
+	err := self.fs.Search(self.Tree,
+		Key{self.Ino, BTRFS_EXTENT_DATA_KEY, 0},
+		Key{self.Ino, BTRFS_EXTENT_DATA_KEY, MAX_UINT64},
+		func(key Key, data []byte) error {
+			if len(data) < BTRFS_FILE_EXTENT_INLINE_DATA_START {
+				return errors.New("btrfs: Extent item too short")
+			}
+
+			extent := &Extent{
+				FileOffset: key.Offset,
+				RamBytes:   binary.LittleEndian.Uint64(data[8:]),
+				Compressed: data[16],
+				Type:       data[20],
+			}
+
+			switch extent.Type {
+			case BTRFS_FILE_EXTENT_INLINE:
+				extent.Data = data[BTRFS_FILE_EXTENT_INLINE_DATA_START:]
+
+			case BTRFS_FILE_EXTENT_REG, BTRFS_FILE_EXTENT_PREALLOC:
+				if len(data) < BTRFS_FILE_EXTENT_ITEM_SIZE {
+					return errors.New("btrfs: Extent item too short")
+				}
+				extent.DiskBytenr = binary.LittleEndian.Uint64(data[21:])
+				extent.DiskNumBytes = binary.LittleEndian.Uint64(data[29:])
+				extent.Offset = binary.LittleEndian.Uint64(data[37:])
+				extent.NumBytes = binary.LittleEndian.Uint64(data[45:])
+
+			default:
+				return errors.New("btrfs: Unknown extent type")
+			}
+
+			result = append(result, extent)
+			return nil
+		})
+	return result, err
+}
+
+// Symlink targets are stored as an inline extent.
+func (self *Inode) Readlink() (string, error) {
+	if !self.IsLink() {
+		return "", errors.New("btrfs: Not a symlink")
+	}
+
+	reader, err := self.Reader()
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, self.Size)
+	n, err := reader.ReadAt(buf, 0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return string(buf[:n]), nil
+}
+
+func (self *Inode) Reader() (io.ReaderAt, error) {
+	extents, err := self.Extents()
+	if err != nil {
+		return nil, err
+	}
+
+	return &extentReader{
+		fs:      self.fs,
+		extents: extents,
+		size:    self.Size,
+	}, nil
+}
+
+// Reads the file data through the extents. Holes and preallocated
+// extents read as zeros.
+type extentReader struct {
+	fs      *FileSystem
+	extents []*Extent
+	size    int64
+
+	mu sync.Mutex
+
+	// The last decompressed extent.
+	cached      *Extent
+	cached_data []byte
+}
+
+func (self *extentReader) ReadAt(buf []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, errors.New("btrfs: Negative offset")
+	}
+
+	if offset >= self.size {
+		return 0, io.EOF
+	}
+
+	to_read := int64(len(buf))
+	if offset+to_read > self.size {
+		to_read = self.size - offset
+	}
+
+	total := 0
+	for int64(total) < to_read {
+		n, err := self.readChunk(buf[total:to_read], uint64(offset)+uint64(total))
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	if int64(total) < int64(len(buf)) {
+		return total, io.EOF
+	}
+	return total, nil
+}
+
+// Read data from a single extent, or zeros up to the next extent.
+func (self *extentReader) readChunk(buf []byte, offset uint64) (int, error) {
+	idx := sort.Search(len(self.extents), func(i int) bool {
+		return self.extents[i].FileOffset+self.extents[i].Length() > offset
+	})
+
+	if idx >= len(self.extents) || self.extents[idx].FileOffset > offset {
+		size := uint64(len(buf))
+		if idx < len(self.extents) &&
+			self.extents[idx].FileOffset-offset < size {
+			size = self.extents[idx].FileOffset - offset
+		}
+		for i := uint64(0); i < size; i++ {
+			buf[i] = 0
+		}
+		return int(size), nil
+	}
+
+	extent := self.extents[idx]
+	delta := offset - extent.FileOffset
+	available := extent.Length() - delta
+	if uint64(len(buf)) > available {
+		buf = buf[:available]
+	}
+
+	switch {
+	case extent.Type == BTRFS_FILE_EXTENT_PREALLOC || (extent.Type ==
+		BTRFS_FILE_EXTENT_REG && extent.DiskBytenr == 0):
+		for i := range buf {
+			buf[i] = 0
+		}
+		return len(buf), nil
+
+	case extent.Type == BTRFS_FILE_EXTENT_REG &&
+		extent.Compressed == BTRFS_COMPRESS_NONE:
+		err := self.fs.ReadLogical(buf,
+			extent.DiskBytenr+extent.Offset+delta)
+		if err != nil {
+			return 0, err
+		}
+		return len(buf), nil
+	}
+
+	data, err := self.extentData(extent)
+	if err != nil {
+		return 0, err
+	}
+
+	if extent.Type == BTRFS_FILE_EXTENT_REG {
+		delta += extent.Offset
+	}
+
+	n := 0
+	if delta < uint64(len(data)) {
+		n = copy(buf, data[delta:])
+	}
+
+	// Anything not covered by the decompressed data is zero.
+	for i := n; i < len(buf); i++ {
+		buf[i] = 0
+	}
+	return len(buf), nil
+}
+
+func (self *extentReader) extentData(extent *Extent) ([]byte, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.cached == extent {
+		return self.cached_data, nil
+	}
+
+	compressed := extent.Data
+	if extent.Type == BTRFS_FILE_EXTENT_REG {
+		if extent.DiskNumBytes > BTRFS_MAX_UNCOMPRESSED {
+			return nil, errors.New("btrfs: Compressed extent too large")
+		}
+		compressed = make([]byte, extent.DiskNumBytes)
+		err := self.fs.ReadLogical(compressed, extent.DiskBytenr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := decompress(extent.Compressed, compressed,
+		extent.RamBytes, self.fs.sb.SectorSize)
+	if err != nil {
+		return nil, err
+	}
+
+	self.cached = extent
+	self.cached_data = data
+	return data, nil
+}
@@ -0,0 +1,305 @@
+package btrfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	BTRFS_ROOT_TREE_OBJECTID  = 1
+	BTRFS_FS_TREE_OBJECTID    = 5
+	BTRFS_FIRST_FREE_OBJECTID = 256
+
+	BTRFS_INODE_ITEM_SIZE = 160
+	BTRFS_ROOT_ITEM_SIZE  = 239
+	BTRFS_DIR_ITEM_SIZE   = 30
+
+	BTRFS_FT_REG_FILE = 1
+	BTRFS_FT_DIR      = 2
+	BTRFS_FT_SYMLINK  = 7
+
+	BTRFS_MAX_DIR_CACHE = 1024
+)
+
+// Resolve a subvolume id to its tree using the root tree.
+func (self *FileSystem) OpenTree(id uint64) (*Tree, error) {
+	var result *Tree
+
+	root_tree := &Tree{
+		Id:    BTRFS_ROOT_TREE_OBJECTID,
+		Root:  self.sb.Root,
+		Level: self.sb.RootLevel,
+	}
+
+	// Snapshots have the creating transid as the key offset so take
+	// the last item.
+	err := self.Search(root_tree,
+		Key{id, BTRFS_ROOT_ITEM_KEY, 0},
+		Key{id, BTRFS_ROOT_ITEM_KEY, MAX_UINT64},
+		func(key Key, data []byte) error {
+			if len(data) < BTRFS_ROOT_ITEM_SIZE {
+				return errors.New("btrfs: Root item too short")
+			}
+			result = &Tree{
+				Id:    id,
+				Root:  binary.LittleEndian.Uint64(data[176:]),
+				Level: data[238],
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, os.ErrNotExist
+	}
+	return result, nil
+}
+
+// Older copies of the top level filesystem tree from the superblock
+// backup roots. Only roots which were not yet overwritten are
+// returned.
+func (self *FileSystem) BackupTrees() []*Tree {
+	current, err := self.OpenTree(BTRFS_FS_TREE_OBJECTID)
+	if err != nil {
+		return nil
+	}
+
+	result := []*Tree{}
+	seen := map[uint64]bool{current.Root: true}
+	for _, backup := range self.sb.BackupRoots {
+		if backup.FsRoot == 0 || seen[backup.FsRoot] {
+			continue
+		}
+		seen[backup.FsRoot] = true
+
+		node, err := self.ReadNode(backup.FsRoot)
+		if err != nil || node.Generation != backup.FsRootGen ||
+			node.Level != backup.FsRootLevel {
+			continue
+		}
+
+		result = append(result, &Tree{
+			Id:     BTRFS_FS_TREE_OBJECTID,
+			Root:   backup.FsRoot,
+			Level:  backup.FsRootLevel,
+			Backup: true,
+		})
+	}
+	return result
+}
+
+type Inode struct {
+	Tree       *Tree
+	Ino        uint64
+	Generation uint64
+	Size       int64
+	NBytes     uint64
+	Nlink      uint32
+	Uid        uint32
+	Gid        uint32
+	Mode       uint32
+	Rdev       uint64
+	Flags      uint64
+	Atime      time.Time
+	Ctime      time.Time
+	Mtime      time.Time
+	Otime      time.Time
+
+	fs *FileSystem
+}
+
+func parseTimespec(buf []byte) time.Time {
+	return time.Unix(int64(binary.LittleEndian.Uint64(buf)),
+		int64(binary.LittleEndian.Uint32(buf[8:]))).UTC()
+}
+
+func (self *FileSystem) OpenInode(tree *Tree, ino uint64) (*Inode, error) {
+	var result *Inode
+
+	err := self.Search(tree,
+		Key{ino, BTRFS_INODE_ITEM_KEY, 0},
+		Key{ino, BTRFS_INODE_ITEM_KEY, MAX_UINT64},
+		func(key Key, data []byte) error {
+			if len(data) < BTRFS_INODE_ITEM_SIZE {
+				return errors.New("btrfs: Inode item too short")
+			}
+			result = &Inode{
+				Tree:       tree,
+				Ino:        ino,
+				Generation: binary.LittleEndian.Uint64(data[0:]),
+				Size:       int64(binary.LittleEndian.Uint64(data[16:])),
+				NBytes:     binary.LittleEndian.Uint64(data[24:]),
+				Nlink:      binary.LittleEndian.Uint32(data[40:]),
+				Uid:        binary.LittleEndian.Uint32(data[44:]),
+				Gid:        binary.LittleEndian.Uint32(data[48:]),
+				Mode:       binary.LittleEndian.Uint32(data[52:]),
+				Rdev:       binary.LittleEndian.Uint64(data[56:]),
+				Flags:      binary.LittleEndian.Uint64(data[64:]),
+				Atime:      parseTimespec(data[112:]),
+				Ctime:      parseTimespec(data[124:]),
+				Mtime:      parseTimespec(data[136:]),
+				Otime:      parseTimespec(data[148:]),
+				fs:         self,
+			}
+			return errStopSearch
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, os.ErrNotExist
+	}
+	return result, nil
+}
+
+func (self *Inode) FileMode() os.FileMode {
+	return utils.UnixFileMode(self.Mode)
+}
+
+func (self *Inode) IsDir() bool {
+	return self.FileMode().IsDir()
+}
+
+func (self *Inode) IsLink() bool {
+	return self.FileMode()&os.ModeSymlink != 0
+}
+
+type DirEntry struct {
+	Name  string
+	Ftype uint8
+
+	// The entry refers to an inode in this tree.
+	Tree *Tree
+	Ino  uint64
+
+	// The entry only exists in an older copy of the tree.
+	Deleted bool
+}
+
+type dirKey struct {
+	root uint64
+	ino  uint64
+}
+
+func parseDirItem(tree *Tree, data []byte) (*DirEntry, error) {
+	if len(data) < BTRFS_DIR_ITEM_SIZE {
+		return nil, errors.New("btrfs: Dir item too short")
+	}
+
+	location := parseKey(data)
+	name_len := int(binary.LittleEndian.Uint16(data[27:]))
+	if BTRFS_DIR_ITEM_SIZE+name_len > len(data) {
+		return nil, errors.New("btrfs: Dir item name too long")
+	}
+
+	result := &DirEntry{
+		Name:  string(data[BTRFS_DIR_ITEM_SIZE : BTRFS_DIR_ITEM_SIZE+name_len]),
+		Ftype: data[29],
+		Tree:  tree,
+		Ino:   location.ObjectId,
+	}
+
+	// The entry is a subvolume so it refers to the root directory
+	// of another tree. We resolve it lazily.
+	if location.Type == BTRFS_ROOT_ITEM_KEY {
+		result.Tree = &Tree{Id: location.ObjectId}
+		result.Ino = 0
+	}
+	return result, nil
+}
+
+// Resolve the entry to an inode, entering subvolumes as needed.
+func (self *FileSystem) OpenEntry(entry *DirEntry) (*Inode, error) {
+	tree := entry.Tree
+	ino := entry.Ino
+	if ino == 0 {
+		var err error
+		tree, err = self.OpenTree(entry.Tree.Id)
+		if err != nil {
+			return nil, err
+		}
+		ino = BTRFS_FIRST_FREE_OBJECTID
+	}
+	return self.OpenInode(tree, ino)
+}
+
+func (self *FileSystem) listDir(tree *Tree, ino uint64) ([]*DirEntry, error) {
+	key := dirKey{tree.Root, ino}
+	self.mu.Lock()
+	cached, pres := self.dirs[key]
+	self.mu.Unlock()
+	if pres {
+		return cached, nil
+	}
+
+	result := []*DirEntry{}
+	err := self.Search(tree,
+		Key{ino, BTRFS_DIR_INDEX_KEY, 0},
+		Key{ino, BTRFS_DIR_INDEX_KEY, MAX_UINT64},
+		func(key Key, data []byte) error {
+			entry, err := parseDirItem(tree, data)
+			if err != nil {
+				return err
+			}
+			result = append(result, entry)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	self.mu.Lock()
+	if len(self.dirs) > BTRFS_MAX_DIR_CACHE {
+		self.dirs = make(map[dirKey][]*DirEntry)
+	}
+	self.dirs[key] = result
+	self.mu.Unlock()
+
+	return result, nil
+}
+
+// List the directory. For the top level tree, entries which only
+// appear in the backup roots are returned as deleted.
+func (self *Inode) ReadDir() ([]*DirEntry, error) {
+	entries, err := self.fs.listDir(self.Tree, self.Ino)
+	if err != nil {
+		return nil, err
+	}
+
+	if self.Tree.Id != BTRFS_FS_TREE_OBJECTID || self.Tree.Backup {
+		return entries, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		seen[e.Name] = true
+	}
+
+	result := append([]*DirEntry{}, entries...)
+	for _, backup := range self.fs.BackupTrees() {
+		old, err := self.fs.listDir(backup, self.Ino)
+		if err != nil {
+			continue
+		}
+
+		for _, e := range old {
+			if seen[e.Name] {
+				continue
+			}
+			seen[e.Name] = true
+
+			deleted := *e
+			deleted.Deleted = true
+			result = append(result, &deleted)
+		}
+	}
+
+	return result, nil
+}
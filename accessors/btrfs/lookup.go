@@ -0,0 +1,76 @@
+package btrfs
+
+import (
+	"fmt"
+	"os"
+)
+
+// The maximum number of path components we walk.
+const MAX_PATH_DEPTH = 256
+
+type LookupResult struct {
+	Inode *Inode
+
+	// The directory entry which led to the inode. This is nil for
+	// the root directory.
+	Entry *DirEntry
+
+	// The file or one of its parent directories was deleted.
+	Deleted bool
+}
+
+// Walk the path from the root of the top level subvolume. Deleted
+// entries are only used if there is no live entry with the same
+// name.
+func (self *FileSystem) Lookup(components []string) (*LookupResult, error) {
+	if len(components) > MAX_PATH_DEPTH {
+		return nil, fmt.Errorf("btrfs: Path too deep")
+	}
+
+	tree, err := self.OpenTree(BTRFS_FS_TREE_OBJECTID)
+	if err != nil {
+		return nil, err
+	}
+
+	inode, err := self.OpenInode(tree, BTRFS_FIRST_FREE_OBJECTID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LookupResult{Inode: inode}
+	for _, name := range components {
+		if !result.Inode.IsDir() {
+			return nil, os.ErrNotExist
+		}
+
+		entries, err := result.Inode.ReadDir()
+		if err != nil {
+			return nil, err
+		}
+
+		var found *DirEntry
+		for _, entry := range entries {
+			if entry.Name == name {
+				found = entry
+				break
+			}
+		}
+
+		if found == nil {
+			return nil, os.ErrNotExist
+		}
+
+		inode, err := self.OpenEntry(found)
+		if err != nil {
+			return nil, err
+		}
+
+		result = &LookupResult{
+			Inode:   inode,
+			Entry:   found,
+			Deleted: result.Deleted || found.Deleted,
+		}
+	}
+
+	return result, nil
+}
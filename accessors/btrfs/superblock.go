@@ -0,0 +1,312 @@
+package btrfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// On disk structures are described in
+// https://btrfs.readthedocs.io/en/latest/dev/On-disk-format.html
+const (
+	BTRFS_SUPER_INFO_OFFSET = 0x10000
+	BTRFS_SUPER_INFO_SIZE   = 4096
+	BTRFS_MAGIC             = "_BHRfS_M"
+
+	BTRFS_SYSTEM_CHUNK_ARRAY_OFFSET = 0x32b
+	BTRFS_SYSTEM_CHUNK_ARRAY_SIZE   = 2048
+	BTRFS_SUPER_ROOTS_OFFSET        = 0xb2b
+	BTRFS_NUM_BACKUP_ROOTS          = 4
+	BTRFS_ROOT_BACKUP_SIZE          = 168
+
+	BTRFS_CHUNK_ITEM_SIZE  = 48
+	BTRFS_STRIPE_SIZE      = 32
+	BTRFS_DISK_KEY_SIZE    = 17
+	BTRFS_MAX_NODE_SIZE    = 64 * 1024
+	BTRFS_MAX_NUM_STRIPES  = 256
+	BTRFS_MAX_SECTOR_SIZE  = 64 * 1024
+	BTRFS_FIRST_CHUNK_TREE = 256
+
+	BTRFS_BLOCK_GROUP_RAID0  = 1 << 3
+	BTRFS_BLOCK_GROUP_RAID10 = 1 << 6
+	BTRFS_BLOCK_GROUP_RAID5  = 1 << 7
+	BTRFS_BLOCK_GROUP_RAID6  = 1 << 8
+)
+
+type BackupRoot struct {
+	FsRoot       uint64
+	FsRootGen    uint64
+	FsRootLevel  uint8
+	TreeRoot     uint64
+	TreeRootGen  uint64
+	TreeRootLvl  uint8
+	ChunkRoot    uint64
+	ChunkRootGen uint64
+}
+
+type Superblock struct {
+	FSID           [16]byte
+	Generation     uint64
+	Root           uint64
+	ChunkRoot      uint64
+	TotalBytes     uint64
+	SectorSize     uint32
+	NodeSize       uint32
+	RootLevel      uint8
+	ChunkRootLevel uint8
+	DevId          uint64
+	Label          string
+	SysChunkArray  []byte
+	BackupRoots    []BackupRoot
+}
+
+func parseSuperblock(buf []byte) (*Superblock, error) {
+	if len(buf) < BTRFS_SUPER_INFO_SIZE ||
+		string(buf[0x40:0x48]) != BTRFS_MAGIC {
+		return nil, errors.New("btrfs: Invalid superblock magic")
+	}
+
+	result := &Superblock{
+		Generation:     binary.LittleEndian.Uint64(buf[0x48:]),
+		Root:           binary.LittleEndian.Uint64(buf[0x50:]),
+		ChunkRoot:      binary.LittleEndian.Uint64(buf[0x58:]),
+		TotalBytes:     binary.LittleEndian.Uint64(buf[0x70:]),
+		SectorSize:     binary.LittleEndian.Uint32(buf[0x90:]),
+		NodeSize:       binary.LittleEndian.Uint32(buf[0x94:]),
+		RootLevel:      buf[0xc6],
+		ChunkRootLevel: buf[0xc7],
+		DevId:          binary.LittleEndian.Uint64(buf[0xc9:]),
+		Label:          cString(buf[0x12b:0x22b]),
+	}
+	copy(result.FSID[:], buf[0x20:0x30])
+
+	if result.NodeSize < 4096 || result.NodeSize > BTRFS_MAX_NODE_SIZE ||
+		result.NodeSize&(result.NodeSize-1) != 0 {
+		return nil, fmt.Errorf("btrfs: Invalid node size %v", result.NodeSize)
+	}
+
+	if result.SectorSize < 512 || result.SectorSize > BTRFS_MAX_SECTOR_SIZE {
+		return nil, fmt.Errorf("btrfs: Invalid sector size %v", result.SectorSize)
+	}
+
+	array_size := binary.LittleEndian.Uint32(buf[0xa0:])
+	if array_size > BTRFS_SYSTEM_CHUNK_ARRAY_SIZE {
+		return nil, errors.New("btrfs: Invalid system chunk array")
+	}
+	result.SysChunkArray = buf[BTRFS_SYSTEM_CHUNK_ARRAY_OFFSET : BTRFS_SYSTEM_CHUNK_ARRAY_OFFSET+array_size]
+
+	for i := 0; i < BTRFS_NUM_BACKUP_ROOTS; i++ {
+		b := buf[BTRFS_SUPER_ROOTS_OFFSET+i*BTRFS_ROOT_BACKUP_SIZE:]
+		result.BackupRoots = append(result.BackupRoots, BackupRoot{
+			TreeRoot:     binary.LittleEndian.Uint64(b[0:]),
+			TreeRootGen:  binary.LittleEndian.Uint64(b[8:]),
+			ChunkRoot:    binary.LittleEndian.Uint64(b[16:]),
+			ChunkRootGen: binary.LittleEndian.Uint64(b[24:]),
+			FsRoot:       binary.LittleEndian.Uint64(b[48:]),
+			FsRootGen:    binary.LittleEndian.Uint64(b[56:]),
+			TreeRootLvl:  b[152],
+			FsRootLevel:  b[155],
+		})
+	}
+
+	return result, nil
+}
+
+type Stripe struct {
+	DevId  uint64
+	Offset uint64
+}
+
+// A chunk maps a range of the logical address space to the devices.
+type Chunk struct {
+	Logical   uint64
+	Length    uint64
+	StripeLen uint64
+	Type      uint64
+	Stripes   []Stripe
+}
+
+func parseChunk(logical uint64, buf []byte) (*Chunk, int, error) {
+	if len(buf) < BTRFS_CHUNK_ITEM_SIZE {
+		return nil, 0, errors.New("btrfs: Chunk item too short")
+	}
+
+	result := &Chunk{
+		Logical:   logical,
+		Length:    binary.LittleEndian.Uint64(buf[0:]),
+		StripeLen: binary.LittleEndian.Uint64(buf[16:]),
+		Type:      binary.LittleEndian.Uint64(buf[24:]),
+	}
+
+	num_stripes := int(binary.LittleEndian.Uint16(buf[44:]))
+	size := BTRFS_CHUNK_ITEM_SIZE + num_stripes*BTRFS_STRIPE_SIZE
+	if num_stripes == 0 || num_stripes > BTRFS_MAX_NUM_STRIPES || size > len(buf) {
+		return nil, 0, errors.New("btrfs: Invalid chunk item")
+	}
+
+	for i := 0; i < num_stripes; i++ {
+		s := buf[BTRFS_CHUNK_ITEM_SIZE+i*BTRFS_STRIPE_SIZE:]
+		result.Stripes = append(result.Stripes, Stripe{
+			DevId:  binary.LittleEndian.Uint64(s),
+			Offset: binary.LittleEndian.Uint64(s[8:]),
+		})
+	}
+	return result, size, nil
+}
+
+type FileSystem struct {
+	reader io.ReaderAt
+	sb     *Superblock
+
+	mu     sync.Mutex
+	chunks []*Chunk
+	nodes  map[uint64]*Node
+	dirs   map[dirKey][]*DirEntry
+}
+
+func NewFileSystem(reader io.ReaderAt) (*FileSystem, error) {
+	buf := make([]byte, BTRFS_SUPER_INFO_SIZE)
+	err := readAt(reader, buf, BTRFS_SUPER_INFO_OFFSET)
+	if err != nil {
+		return nil, err
+	}
+
+	sb, err := parseSuperblock(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FileSystem{
+		reader: reader,
+		sb:     sb,
+		nodes:  make(map[uint64]*Node),
+		dirs:   make(map[dirKey][]*DirEntry),
+	}
+
+	// The system chunks in the superblock map the chunk tree.
+	array := sb.SysChunkArray
+	for len(array) > 0 {
+		if len(array) < BTRFS_DISK_KEY_SIZE {
+			return nil, errors.New("btrfs: Invalid system chunk array")
+		}
+		key := parseKey(array)
+		chunk, size, err := parseChunk(key.Offset, array[BTRFS_DISK_KEY_SIZE:])
+		if err != nil {
+			return nil, err
+		}
+		result.addChunk(chunk)
+		array = array[BTRFS_DISK_KEY_SIZE+size:]
+	}
+
+	// Now load all the chunks from the chunk tree.
+	err = result.Search(&Tree{Root: sb.ChunkRoot, Level: sb.ChunkRootLevel},
+		Key{BTRFS_FIRST_CHUNK_TREE, BTRFS_CHUNK_ITEM_KEY, 0},
+		Key{BTRFS_FIRST_CHUNK_TREE, BTRFS_CHUNK_ITEM_KEY, MAX_UINT64},
+		func(key Key, data []byte) error {
+			chunk, _, err := parseChunk(key.Offset, data)
+			if err != nil {
+				return err
+			}
+			result.addChunk(chunk)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (self *FileSystem) Superblock() *Superblock {
+	return self.sb
+}
+
+func (self *FileSystem) addChunk(chunk *Chunk) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	for _, c := range self.chunks {
+		if c.Logical == chunk.Logical {
+			return
+		}
+	}
+
+	self.chunks = append(self.chunks, chunk)
+	sort.Slice(self.chunks, func(i, j int) bool {
+		return self.chunks[i].Logical < self.chunks[j].Logical
+	})
+}
+
+// Map a logical address to the physical offset on our device. Returns
+// the number of bytes which are contiguous from there.
+func (self *FileSystem) mapLogical(logical uint64) (int64, uint64, error) {
+	self.mu.Lock()
+	idx := sort.Search(len(self.chunks), func(i int) bool {
+		return self.chunks[i].Logical+self.chunks[i].Length > logical
+	})
+	var chunk *Chunk
+	if idx < len(self.chunks) && self.chunks[idx].Logical <= logical {
+		chunk = self.chunks[idx]
+	}
+	self.mu.Unlock()
+
+	if chunk == nil {
+		return 0, 0, fmt.Errorf("btrfs: Logical address %#x is not mapped", logical)
+	}
+
+	if chunk.Type&(BTRFS_BLOCK_GROUP_RAID0|BTRFS_BLOCK_GROUP_RAID10|
+		BTRFS_BLOCK_GROUP_RAID5|BTRFS_BLOCK_GROUP_RAID6) != 0 {
+		return 0, 0, fmt.Errorf("btrfs: Striped chunk profile %#x not supported",
+			chunk.Type)
+	}
+
+	// Single, DUP and mirrored chunks have a complete copy on any
+	// stripe on our device.
+	delta := logical - chunk.Logical
+	for _, stripe := range chunk.Stripes {
+		if stripe.DevId == self.sb.DevId {
+			return int64(stripe.Offset + delta), chunk.Length - delta, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("btrfs: Chunk at %#x is on another device", chunk.Logical)
+}
+
+// Read from the logical address space.
+func (self *FileSystem) ReadLogical(buf []byte, logical uint64) error {
+	for len(buf) > 0 {
+		physical, available, err := self.mapLogical(logical)
+		if err != nil {
+			return err
+		}
+
+		to_read := uint64(len(buf))
+		if to_read > available {
+			to_read = available
+		}
+
+		err = readAt(self.reader, buf[:to_read], physical)
+		if err != nil {
+			return err
+		}
+		buf = buf[to_read:]
+		logical += to_read
+	}
+	return nil
+}
+
+func readAt(reader io.ReaderAt, buf []byte, offset int64) error {
+	_, err := io.ReadFull(io.NewSectionReader(reader, offset, int64(len(buf))), buf)
+	return err
+}
+
+func cString(buf []byte) string {
+	idx := bytes.IndexByte(buf, 0)
+	if idx >= 0 {
+		return string(buf[:idx])
+	}
+	return string(buf)
+}
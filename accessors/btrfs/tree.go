@@ -0,0 +1,223 @@
+package btrfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	BTRFS_HEADER_SIZE    = 101
+	BTRFS_ITEM_SIZE      = 25
+	BTRFS_KEY_PTR_SIZE   = 33
+	BTRFS_MAX_LEVEL      = 8
+	BTRFS_MAX_NODE_CACHE = 1024
+
+	MAX_UINT64 = ^uint64(0)
+
+	BTRFS_INODE_ITEM_KEY  = 1
+	BTRFS_INODE_REF_KEY   = 12
+	BTRFS_DIR_ITEM_KEY    = 84
+	BTRFS_DIR_INDEX_KEY   = 96
+	BTRFS_EXTENT_DATA_KEY = 108
+	BTRFS_ROOT_ITEM_KEY   = 132
+	BTRFS_CHUNK_ITEM_KEY  = 228
+)
+
+type Key struct {
+	ObjectId uint64
+	Type     uint8
+	Offset   uint64
+}
+
+func parseKey(buf []byte) Key {
+	return Key{
+		ObjectId: binary.LittleEndian.Uint64(buf),
+		Type:     buf[8],
+		Offset:   binary.LittleEndian.Uint64(buf[9:]),
+	}
+}
+
+func (self Key) Compare(other Key) int {
+	switch {
+	case self.ObjectId < other.ObjectId:
+		return -1
+	case self.ObjectId > other.ObjectId:
+		return 1
+	case self.Type < other.Type:
+		return -1
+	case self.Type > other.Type:
+		return 1
+	case self.Offset < other.Offset:
+		return -1
+	case self.Offset > other.Offset:
+		return 1
+	}
+	return 0
+}
+
+// A tree is identified by its root node.
+type Tree struct {
+	Id    uint64
+	Root  uint64
+	Level uint8
+
+	// Trees found from the backup roots are older copies of the
+	// filesystem.
+	Backup bool
+}
+
+type Node struct {
+	Bytenr     uint64
+	Generation uint64
+	Owner      uint64
+	Level      uint8
+	Keys       []Key
+
+	// For internal nodes the child block pointers.
+	Children []uint64
+
+	// For leaves the item data.
+	Items [][]byte
+}
+
+func parseNode(bytenr uint64, fsid []byte, buf []byte) (*Node, error) {
+	if !bytes.Equal(buf[32:48], fsid) {
+		return nil, fmt.Errorf("btrfs: Node at %#x has wrong fsid", bytenr)
+	}
+
+	result := &Node{
+		Bytenr:     binary.LittleEndian.Uint64(buf[48:]),
+		Generation: binary.LittleEndian.Uint64(buf[80:]),
+		Owner:      binary.LittleEndian.Uint64(buf[88:]),
+		Level:      buf[100],
+	}
+
+	if result.Bytenr != bytenr {
+		return nil, fmt.Errorf("btrfs: Node at %#x has wrong bytenr", bytenr)
+	}
+
+	if result.Level >= BTRFS_MAX_LEVEL {
+		return nil, fmt.Errorf("btrfs: Node at %#x has invalid level", bytenr)
+	}
+
+	nritems := int(binary.LittleEndian.Uint32(buf[96:]))
+	data := buf[BTRFS_HEADER_SIZE:]
+
+	if result.Level > 0 {
+		if nritems*BTRFS_KEY_PTR_SIZE > len(data) {
+			return nil, fmt.Errorf("btrfs: Node at %#x has too many items", bytenr)
+		}
+		for i := 0; i < nritems; i++ {
+			ptr := data[i*BTRFS_KEY_PTR_SIZE:]
+			result.Keys = append(result.Keys, parseKey(ptr))
+			result.Children = append(result.Children,
+				binary.LittleEndian.Uint64(ptr[BTRFS_DISK_KEY_SIZE:]))
+		}
+		return result, nil
+	}
+
+	if nritems*BTRFS_ITEM_SIZE > len(data) {
+		return nil, fmt.Errorf("btrfs: Leaf at %#x has too many items", bytenr)
+	}
+	for i := 0; i < nritems; i++ {
+		item := data[i*BTRFS_ITEM_SIZE:]
+		offset := uint64(binary.LittleEndian.Uint32(item[BTRFS_DISK_KEY_SIZE:]))
+		size := uint64(binary.LittleEndian.Uint32(item[BTRFS_DISK_KEY_SIZE+4:]))
+		if offset+size > uint64(len(data)) {
+			return nil, fmt.Errorf("btrfs: Leaf at %#x has invalid item", bytenr)
+		}
+		result.Keys = append(result.Keys, parseKey(item))
+		result.Items = append(result.Items, data[offset:offset+size])
+	}
+	return result, nil
+}
+
+func (self *FileSystem) ReadNode(bytenr uint64) (*Node, error) {
+	self.mu.Lock()
+	node, pres := self.nodes[bytenr]
+	self.mu.Unlock()
+	if pres {
+		return node, nil
+	}
+
+	buf := make([]byte, self.sb.NodeSize)
+	err := self.ReadLogical(buf, bytenr)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err = parseNode(bytenr, self.sb.FSID[:], buf)
+	if err != nil {
+		return nil, err
+	}
+
+	self.mu.Lock()
+	if len(self.nodes) > BTRFS_MAX_NODE_CACHE {
+		self.nodes = make(map[uint64]*Node)
+	}
+	self.nodes[bytenr] = node
+	self.mu.Unlock()
+
+	return node, nil
+}
+
+var errStopSearch = errors.New("Stop")
+
+// Call cb for all items in the tree with keys between min and max
+// inclusive, in key order.
+func (self *FileSystem) Search(tree *Tree, min, max Key,
+	cb func(key Key, data []byte) error) error {
+	err := self.search(tree.Root, int(tree.Level), min, max, cb)
+	if err == errStopSearch {
+		return nil
+	}
+	return err
+}
+
+func (self *FileSystem) search(bytenr uint64, level int, min, max Key,
+	cb func(key Key, data []byte) error) error {
+	node, err := self.ReadNode(bytenr)
+	if err != nil {
+		return err
+	}
+
+	// Nodes must be strictly below their parents.
+	if int(node.Level) != level {
+		return fmt.Errorf("btrfs: Node at %#x has unexpected level", bytenr)
+	}
+
+	if node.Level == 0 {
+		for i, key := range node.Keys {
+			if key.Compare(min) < 0 {
+				continue
+			}
+			if key.Compare(max) > 0 {
+				return errStopSearch
+			}
+			err := cb(key, node.Items[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i, key := range node.Keys {
+		if key.Compare(max) > 0 {
+			return errStopSearch
+		}
+
+		// Child i holds keys up to the next child's key.
+		if i+1 < len(node.Keys) && node.Keys[i+1].Compare(min) <= 0 {
+			continue
+		}
+
+		err := self.search(node.Children[i], level-1, min, max, cb)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
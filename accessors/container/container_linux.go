@@ -0,0 +1,373 @@
+//go:build linux
+// +build linux
+
+package container
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"golang.org/x/sys/unix"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/psutils"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type ContainerFileInfo struct {
+	os.FileInfo
+
+	path *accessors.OSPath
+
+	// Symlink target as seen inside the container.
+	link string
+}
+
+func (self *ContainerFileInfo) OSPath() *accessors.OSPath {
+	return self.path
+}
+
+func (self *ContainerFileInfo) FullPath() string {
+	return self.path.String()
+}
+
+func (self *ContainerFileInfo) stat() *syscall.Stat_t {
+	sys, _ := self.Sys().(*syscall.Stat_t)
+	return sys
+}
+
+func (self *ContainerFileInfo) Btime() time.Time {
+	return time.Time{}
+}
+
+func (self *ContainerFileInfo) Mtime() time.Time {
+	return self.ModTime()
+}
+
+func (self *ContainerFileInfo) Ctime() time.Time {
+	sys := self.stat()
+	if sys == nil {
+		return time.Time{}
+	}
+	return time.Unix(int64(sys.Ctim.Sec), 0)
+}
+
+func (self *ContainerFileInfo) Atime() time.Time {
+	sys := self.stat()
+	if sys == nil {
+		return time.Time{}
+	}
+	return time.Unix(int64(sys.Atim.Sec), 0)
+}
+
+func (self *ContainerFileInfo) Data() *ordereddict.Dict {
+	result := ordereddict.NewDict()
+	if self.link != "" {
+		result.Set("Link", self.link)
+	}
+
+	sys := self.stat()
+	if sys != nil {
+		result.Set("Uid", sys.Uid).
+			Set("Gid", sys.Gid).
+			Set("Inode", sys.Ino)
+	}
+	return result
+}
+
+func (self *ContainerFileInfo) IsLink() bool {
+	return self.Mode()&os.ModeSymlink != 0
+}
+
+// Links are resolved relative to the container's root so absolute
+// links do not escape into the host filesystem.
+func (self *ContainerFileInfo) GetLink() (*accessors.OSPath, error) {
+	if !self.IsLink() || self.link == "" {
+		return nil, errors.New("Not a symlink")
+	}
+
+	target := self.link
+	if !path.IsAbs(target) {
+		dir := self.path.Dirname()
+		target = path.Join(
+			"/", strings.Join(dir.Components[1:], "/"), target)
+	}
+
+	result := self.path.Copy()
+	result.Components = []string{self.path.Components[0]}
+	for _, c := range strings.Split(path.Clean(target), "/") {
+		if c != "" {
+			result.Components = append(result.Components, c)
+		}
+	}
+	return result, nil
+}
+
+type ContainerAccessor struct {
+	mu         sync.Mutex
+	containers []*psutils.Container
+}
+
+func (self *ContainerAccessor) New(scope vfilter.Scope) (
+	accessors.FileSystemAccessor, error) {
+
+	// Check we have permission to open files.
+	err := vql_subsystem.CheckAccess(scope, acls.FILESYSTEM_READ)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContainerAccessor{}, nil
+}
+
+func (self *ContainerAccessor) ParsePath(path string) (*accessors.OSPath, error) {
+	return accessors.NewLinuxOSPath(path)
+}
+
+// The containers are listed once for the lifetime of the accessor.
+func (self *ContainerAccessor) getContainers() ([]*psutils.Container, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.containers != nil {
+		return self.containers, nil
+	}
+
+	containers, err := psutils.ListContainers(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	self.containers = containers
+	return containers, nil
+}
+
+func (self *ContainerAccessor) getContainer(
+	full_path *accessors.OSPath) (*psutils.Container, error) {
+	containers, err := self.getContainers()
+	if err != nil {
+		return nil, err
+	}
+
+	return psutils.FindRunningContainer(containers, full_path.Components[0])
+}
+
+// Open the path relative to the root of the container's init
+// process. The kernel resolves symlinks and ".." inside that root.
+func (self *ContainerAccessor) openInContainer(
+	full_path *accessors.OSPath, flags int) (*os.File, error) {
+	container, err := self.getContainer(full_path)
+	if err != nil {
+		return nil, err
+	}
+
+	root := psutils.GetHostProc(container.Pid) + "/root"
+	relative := strings.Join(full_path.Components[1:], "/")
+	if relative == "" {
+		relative = "."
+	}
+
+	root_fd, err := unix.Open(root, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer unix.Close(root_fd)
+
+	fd, err := unix.Openat2(root_fd, relative, &unix.OpenHow{
+		Flags:   uint64(flags | unix.O_CLOEXEC),
+		Resolve: unix.RESOLVE_IN_ROOT | unix.RESOLVE_NO_MAGICLINKS,
+	})
+
+	// Before Linux 5.6 absolute symlinks are resolved in the host
+	// filesystem.
+	if errors.Is(err, unix.ENOSYS) {
+		fd, err = unix.Openat(root_fd, relative, flags|unix.O_CLOEXEC, 0)
+	}
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: full_path.String(), Err: err}
+	}
+
+	return os.NewFile(uintptr(fd), full_path.String()), nil
+}
+
+func (self *ContainerAccessor) describeContainer(
+	full_path *accessors.OSPath, container *psutils.Container) accessors.FileInfo {
+	return &accessors.VirtualFileInfo{
+		IsDir_: true,
+		Path:   full_path,
+		Data_: ordereddict.NewDict().
+			Set("Name", container.Name).
+			Set("Runtime", container.Runtime).
+			Set("Image", container.Image).
+			Set("Pid", container.Pid),
+	}
+}
+
+func (self *ContainerAccessor) Lstat(filename string) (accessors.FileInfo, error) {
+	full_path, err := self.ParsePath(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.LstatWithOSPath(full_path)
+}
+
+func (self *ContainerAccessor) LstatWithOSPath(
+	full_path *accessors.OSPath) (accessors.FileInfo, error) {
+	if len(full_path.Components) == 0 {
+		return &accessors.VirtualFileInfo{
+			IsDir_: true,
+			Path:   full_path,
+		}, nil
+	}
+
+	if len(full_path.Components) == 1 {
+		container, err := self.getContainer(full_path)
+		if err != nil {
+			return nil, err
+		}
+		return self.describeContainer(full_path, container), nil
+	}
+
+	fd, err := self.openInContainer(full_path, unix.O_PATH|unix.O_NOFOLLOW)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	stat, err := fd.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ContainerFileInfo{
+		FileInfo: stat,
+		path:     full_path,
+	}
+
+	if result.IsLink() {
+		result.link, _ = readlinkat(int(fd.Fd()), "")
+	}
+	return result, nil
+}
+
+func (self *ContainerAccessor) ReadDir(dir string) ([]accessors.FileInfo, error) {
+	full_path, err := self.ParsePath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.ReadDirWithOSPath(full_path)
+}
+
+func (self *ContainerAccessor) ReadDirWithOSPath(
+	full_path *accessors.OSPath) ([]accessors.FileInfo, error) {
+	var result []accessors.FileInfo
+
+	// The top level lists the running containers.
+	if len(full_path.Components) == 0 {
+		containers, err := self.getContainers()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, container := range containers {
+			if container.Pid == 0 {
+				continue
+			}
+			result = append(result, self.describeContainer(
+				full_path.Append(container.Id), container))
+		}
+		return result, nil
+	}
+
+	fd, err := self.openInContainer(full_path, unix.O_RDONLY|unix.O_DIRECTORY)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	children, err := fd.Readdir(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Always return the canonical container ID so paths are
+	// stable.
+	container, err := self.getContainer(full_path)
+	if err != nil {
+		return nil, err
+	}
+	base := full_path.Copy()
+	base.Components[0] = container.Id
+
+	for _, child := range children {
+		info := &ContainerFileInfo{
+			FileInfo: child,
+			path:     base.Append(child.Name()),
+		}
+
+		if info.IsLink() {
+			info.link, _ = readlinkat(int(fd.Fd()), child.Name())
+		}
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+func (self *ContainerAccessor) Open(filename string) (accessors.ReadSeekCloser, error) {
+	full_path, err := self.ParsePath(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.OpenWithOSPath(full_path)
+}
+
+func (self *ContainerAccessor) OpenWithOSPath(
+	full_path *accessors.OSPath) (accessors.ReadSeekCloser, error) {
+	if len(full_path.Components) < 2 {
+		return nil, errors.New("Container accessor expects a path inside a container")
+	}
+
+	fd, err := self.openInContainer(full_path, unix.O_RDONLY)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := fd.Stat()
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+
+	if stat.IsDir() {
+		fd.Close()
+		return nil, errors.New("Can not open a directory")
+	}
+
+	return fd, nil
+}
+
+func readlinkat(dirfd int, name string) (string, error) {
+	buf := make([]byte, unix.PathMax)
+	n, err := unix.Readlinkat(dirfd, name, buf)
+	if err != nil {
+		return "", err
+	}
+	return string(buf[:n]), nil
+}
+
+func init() {
+	accessors.Register("container", &ContainerAccessor{},
+		`Access files inside a running container. The first path component is the container ID (or a unique prefix or name) and the rest is the path as seen inside the container.`)
+}
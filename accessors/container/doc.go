@@ -0,0 +1,5 @@
+// Accessor for reading files inside containers. The filesystem is
+// reached through the root of the container's init process so it
+// is only available on Linux.
+
+package container
@@ -0,0 +1,268 @@
+// Support for transparently compressed files on HFS+ and APFS.
+//
+// A compressed file has an empty data fork. The com.apple.decmpfs
+// extended attribute holds a header and either the compressed data
+// itself or a pointer to the resource fork where the compressed
+// blocks are stored.
+package decmpfs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+const (
+	XATTR_NAME          = "com.apple.decmpfs"
+	RESOURCE_FORK_XATTR = "com.apple.ResourceFork"
+
+	DECMPFS_MAGIC       = 0x636d7066 // fpmc
+	DECMPFS_HEADER_SIZE = 16
+
+	// The BSD flag which marks a file as compressed.
+	UF_COMPRESSED = 0x20
+
+	// Uncompressed data inline in the attribute.
+	CMP_TYPE_INLINE_RAW = 1
+
+	// zlib compressed data inline in the attribute.
+	CMP_TYPE_INLINE_ZLIB = 3
+
+	// zlib compressed blocks in the resource fork.
+	CMP_TYPE_RESOURCE_ZLIB = 4
+
+	// Each block in the resource fork expands to this size.
+	BLOCK_SIZE = 0x10000
+
+	// Refuse to allocate more than this for inline data.
+	MAX_INLINE_SIZE = 16 * 1024 * 1024
+)
+
+type Header struct {
+	Type uint32
+	Size int64
+	Data []byte
+}
+
+func ParseHeader(attr []byte) (*Header, error) {
+	if len(attr) < DECMPFS_HEADER_SIZE ||
+		binary.LittleEndian.Uint32(attr) != DECMPFS_MAGIC {
+		return nil, errors.New("decmpfs: Invalid compression header")
+	}
+
+	return &Header{
+		Type: binary.LittleEndian.Uint32(attr[4:]),
+		Size: int64(binary.LittleEndian.Uint64(attr[8:])),
+		Data: attr[DECMPFS_HEADER_SIZE:],
+	}, nil
+}
+
+// Blocks with the low nibble of the first byte set are stored
+// uncompressed after that byte.
+func decompressBlock(data []byte, max_size int64) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	if data[0]&0x0f == 0x0f {
+		return data[1:], nil
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(io.LimitReader(reader, max_size))
+}
+
+// Returns a reader over the uncompressed content of a file given its
+// com.apple.decmpfs attribute and its resource fork.
+func NewReader(attr []byte, resource_fork io.ReaderAt) (
+	io.ReaderAt, int64, error) {
+	header, err := ParseHeader(attr)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch header.Type {
+	case CMP_TYPE_INLINE_RAW:
+		data := header.Data
+		if int64(len(data)) > header.Size {
+			data = data[:header.Size]
+		}
+		return bytes.NewReader(data), int64(len(data)), nil
+
+	case CMP_TYPE_INLINE_ZLIB:
+		if header.Size > MAX_INLINE_SIZE {
+			return nil, 0, errors.New("decmpfs: Inline data too large")
+		}
+
+		data, err := decompressBlock(header.Data, header.Size)
+		if err != nil {
+			return nil, 0, fmt.Errorf("decmpfs: %w", err)
+		}
+		return bytes.NewReader(data), int64(len(data)), nil
+
+	case CMP_TYPE_RESOURCE_ZLIB:
+		if resource_fork == nil {
+			return nil, 0, errors.New("decmpfs: No resource fork")
+		}
+
+		reader, err := newResourceReader(resource_fork, header.Size)
+		if err != nil {
+			return nil, 0, err
+		}
+		return reader, header.Size, nil
+	}
+
+	return nil, 0, fmt.Errorf("decmpfs: Unsupported compression type %v",
+		header.Type)
+}
+
+type blockRun struct {
+	offset int64
+	size   int64
+}
+
+// Reads zlib compressed blocks from the resource fork. The most
+// recently used block is kept since reads are usually sequential.
+type resourceReader struct {
+	mu sync.Mutex
+
+	reader io.ReaderAt
+	blocks []blockRun
+	size   int64
+
+	last_idx  int
+	last_data []byte
+}
+
+func newResourceReader(reader io.ReaderAt, size int64) (*resourceReader, error) {
+	// The resource fork header is big endian and points at the
+	// resource data. The first resource is preceded by its length.
+	header := make([]byte, 16)
+	_, err := reader.ReadAt(header, 0)
+	if err != nil {
+		return nil, fmt.Errorf("decmpfs: Reading resource fork: %w", err)
+	}
+
+	base := int64(binary.BigEndian.Uint32(header)) + 4
+
+	count_buf := make([]byte, 4)
+	_, err = reader.ReadAt(count_buf, base)
+	if err != nil {
+		return nil, fmt.Errorf("decmpfs: Reading block table: %w", err)
+	}
+
+	count := int64(binary.LittleEndian.Uint32(count_buf))
+	if count != (size+BLOCK_SIZE-1)/BLOCK_SIZE {
+		return nil, errors.New("decmpfs: Block count does not match size")
+	}
+
+	table := make([]byte, count*8)
+	_, err = reader.ReadAt(table, base+4)
+	if err != nil {
+		return nil, fmt.Errorf("decmpfs: Reading block table: %w", err)
+	}
+
+	result := &resourceReader{
+		reader:   reader,
+		size:     size,
+		last_idx: -1,
+	}
+
+	for i := int64(0); i < count; i++ {
+		result.blocks = append(result.blocks, blockRun{
+			offset: base + int64(binary.LittleEndian.Uint32(table[i*8:])),
+			size:   int64(binary.LittleEndian.Uint32(table[i*8+4:])),
+		})
+	}
+
+	return result, nil
+}
+
+func (self *resourceReader) getBlock(idx int) ([]byte, error) {
+	if idx == self.last_idx {
+		return self.last_data, nil
+	}
+
+	run := self.blocks[idx]
+	if run.size > 2*BLOCK_SIZE {
+		return nil, errors.New("decmpfs: Compressed block too large")
+	}
+
+	compressed := make([]byte, run.size)
+	n, err := self.reader.ReadAt(compressed, run.offset)
+	if int64(n) < run.size {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	data, err := decompressBlock(compressed, BLOCK_SIZE)
+	if err != nil {
+		return nil, fmt.Errorf("decmpfs: Block %v: %w", idx, err)
+	}
+
+	self.last_idx = idx
+	self.last_data = data
+	return data, nil
+}
+
+func (self *resourceReader) ReadAt(buf []byte, offset int64) (int, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if offset < 0 {
+		return 0, errors.New("decmpfs: Negative offset")
+	}
+
+	total := 0
+	for total < len(buf) {
+		current := offset + int64(total)
+		if current >= self.size {
+			return total, io.EOF
+		}
+
+		idx := int(current / BLOCK_SIZE)
+		if idx >= len(self.blocks) {
+			return total, io.EOF
+		}
+
+		data, err := self.getBlock(idx)
+		if err != nil {
+			return total, err
+		}
+
+		block_offset := current % BLOCK_SIZE
+		end := int64(len(buf) - total)
+		if remaining := self.size - current; end > remaining {
+			end = remaining
+		}
+
+		// Short blocks are padded with zeros.
+		if block_offset >= int64(len(data)) {
+			n := BLOCK_SIZE - block_offset
+			if n > end {
+				n = end
+			}
+			for i := int64(0); i < n; i++ {
+				buf[total+int(i)] = 0
+			}
+			total += int(n)
+			continue
+		}
+
+		n := copy(buf[total:int64(total)+end], data[block_offset:])
+		total += n
+	}
+
+	return total, nil
+}
@@ -0,0 +1,406 @@
+package hfs
+
+// This is an accessor which parses an HFS+ filesystem
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type HFSFileInfo struct {
+	fs         *FileSystem
+	record     *CatalogRecord
+	_full_path *accessors.OSPath
+	size       int64
+	link       string
+}
+
+func newHFSFileInfo(fs *FileSystem, full_path *accessors.OSPath,
+	record *CatalogRecord) *HFSFileInfo {
+	result := &HFSFileInfo{
+		fs:         fs,
+		record:     record,
+		_full_path: full_path,
+	}
+
+	if record.Type == RECORD_TYPE_FILE {
+		result.size = fs.FileSize(record)
+	}
+
+	if record.IsLink() {
+		result.link, _ = fs.Readlink(record)
+	}
+
+	return result
+}
+
+func (self *HFSFileInfo) Name() string {
+	return self._full_path.Basename()
+}
+
+func (self *HFSFileInfo) IsDir() bool {
+	return self.record.IsDir()
+}
+
+func (self *HFSFileInfo) Size() int64 {
+	return self.size
+}
+
+func (self *HFSFileInfo) Mode() os.FileMode {
+	return self.record.FileMode()
+}
+
+func (self *HFSFileInfo) ModTime() time.Time {
+	return self.record.ContentModDate
+}
+
+func (self *HFSFileInfo) Mtime() time.Time {
+	return self.record.ContentModDate
+}
+
+func (self *HFSFileInfo) Atime() time.Time {
+	return self.record.AccessDate
+}
+
+func (self *HFSFileInfo) Ctime() time.Time {
+	return self.record.AttributeModDate
+}
+
+func (self *HFSFileInfo) Btime() time.Time {
+	return self.record.CreateDate
+}
+
+func fourCC(value uint32) string {
+	return string([]byte{byte(value >> 24), byte(value >> 16),
+		byte(value >> 8), byte(value)})
+}
+
+func (self *HFSFileInfo) Data() *ordereddict.Dict {
+	record := self.record
+	result := ordereddict.NewDict().
+		Set("CNID", record.ID).
+		Set("ParentID", record.ParentID).
+		Set("Uid", record.OwnerID).
+		Set("Gid", record.GroupID).
+		Set("Flags", uint32(record.AdminFlags)<<16|uint32(record.OwnerFlags))
+
+	if !record.BackupDate.IsZero() {
+		result.Set("BackupDate", record.BackupDate)
+	}
+
+	if record.Type == RECORD_TYPE_FILE {
+		if record.FileType() != 0 || record.FileCreator() != 0 {
+			result.Set("FileType", fourCC(record.FileType())).
+				Set("FileCreator", fourCC(record.FileCreator()))
+		}
+
+		if record.ResourceFork.LogicalSize > 0 {
+			result.Set("ResourceForkSize", record.ResourceFork.LogicalSize)
+		}
+	}
+
+	if record.IsCompressed() {
+		result.Set("Compressed", true)
+	}
+
+	if record.HardLink != "" {
+		result.Set("HardLink", record.HardLink)
+	}
+
+	if self.link != "" {
+		result.Set("Link", self.link)
+	}
+
+	return result
+}
+
+func (self *HFSFileInfo) FullPath() string {
+	return self._full_path.String()
+}
+
+func (self *HFSFileInfo) OSPath() *accessors.OSPath {
+	return self._full_path
+}
+
+func (self *HFSFileInfo) IsLink() bool {
+	return self.record.IsLink()
+}
+
+// Symlinks are resolved relative to the root of the filesystem.
+func (self *HFSFileInfo) GetLink() (*accessors.OSPath, error) {
+	if self.link == "" {
+		return nil, errors.New("Not a symlink")
+	}
+
+	target := self.link
+	if !path.IsAbs(target) {
+		dir := self._full_path.Dirname().Components
+		target = path.Join("/", strings.Join(dir, "/"), target)
+	}
+
+	result := self._full_path.Copy()
+	result.Components = nil
+	for _, c := range strings.Split(path.Clean("/"+target), "/") {
+		if c != "" {
+			result.Components = append(result.Components, c)
+		}
+	}
+	return result, nil
+}
+
+// Extended attributes are available to the xattr() function.
+func (self *HFSFileInfo) ListXAttr() ([]string, error) {
+	return self.fs.ListXAttr(self.record)
+}
+
+func (self *HFSFileInfo) GetXAttr(name string) ([]byte, error) {
+	return self.fs.GetXAttr(self.record, name)
+}
+
+type HFSFileSystemAccessor struct {
+	scope vfilter.Scope
+
+	// The delegate accessor we use to open the underlying volume.
+	accessor string
+	device   *accessors.OSPath
+
+	root *accessors.OSPath
+}
+
+func NewHFSFileSystemAccessor(
+	scope vfilter.Scope,
+	root_path *accessors.OSPath,
+	device *accessors.OSPath, accessor string) *HFSFileSystemAccessor {
+	return &HFSFileSystemAccessor{
+		scope:    scope,
+		accessor: accessor,
+		device:   device,
+		root:     root_path,
+	}
+}
+
+func (self HFSFileSystemAccessor) New(scope vfilter.Scope) (
+	accessors.FileSystemAccessor, error) {
+	return &HFSFileSystemAccessor{
+		scope:    scope,
+		device:   self.device,
+		accessor: self.accessor,
+		root:     self.root,
+	}, nil
+}
+
+func (self HFSFileSystemAccessor) ParsePath(path string) (
+	*accessors.OSPath, error) {
+	return accessors.NewLinuxOSPath(path)
+}
+
+func (self *HFSFileSystemAccessor) ReadDir(path string) (
+	res []accessors.FileInfo, err error) {
+	fullpath, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.ReadDirWithOSPath(fullpath)
+}
+
+func (self *HFSFileSystemAccessor) ReadDirWithOSPath(
+	fullpath *accessors.OSPath) (res []accessors.FileInfo, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_hfs: %v", r)
+		}
+	}()
+
+	hfs_ctx, err := GetHFSContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := hfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dir.IsDir() {
+		return nil, errors.New("raw_hfs: Not a directory")
+	}
+
+	children, err := hfs_ctx.ReadDir(dir.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []accessors.FileInfo{}
+	for _, child := range children {
+		record, err := hfs_ctx.ResolveHardLink(child)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, newHFSFileInfo(
+			hfs_ctx, fullpath.Append(child.Name), record))
+	}
+	return result, nil
+}
+
+func (self *HFSFileSystemAccessor) Open(
+	path string) (res accessors.ReadSeekCloser, err error) {
+	full_path, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.OpenWithOSPath(full_path)
+}
+
+func (self *HFSFileSystemAccessor) OpenWithOSPath(
+	fullpath *accessors.OSPath) (res accessors.ReadSeekCloser, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_hfs: %v", r)
+		}
+	}()
+
+	hfs_ctx, err := GetHFSContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := hfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.IsDir() {
+		return nil, errors.New("raw_hfs: Can not open a directory")
+	}
+
+	reader, size, err := hfs_ctx.Reader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileReader{reader: reader, size: size}, nil
+}
+
+func (self *HFSFileSystemAccessor) Lstat(
+	path string) (res accessors.FileInfo, err error) {
+	fullpath, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.LstatWithOSPath(fullpath)
+}
+
+func (self *HFSFileSystemAccessor) LstatWithOSPath(
+	fullpath *accessors.OSPath) (res accessors.FileInfo, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_hfs: %v", r)
+		}
+	}()
+
+	hfs_ctx, err := GetHFSContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := hfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	return newHFSFileInfo(hfs_ctx, fullpath, file), nil
+}
+
+// A seekable reader over the file content.
+type fileReader struct {
+	reader io.ReaderAt
+	size   int64
+	offset int64
+}
+
+func (self *fileReader) Read(buf []byte) (int, error) {
+	n, err := self.reader.ReadAt(buf, self.offset)
+	self.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (self *fileReader) ReadAt(buf []byte, offset int64) (int, error) {
+	return self.reader.ReadAt(buf, offset)
+}
+
+func (self *fileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += self.offset
+	case io.SeekEnd:
+		offset += self.size
+	default:
+		return 0, errors.New("Invalid whence")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("Negative seek")
+	}
+	self.offset = offset
+	return offset, nil
+}
+
+func (self *fileReader) Close() error {
+	return nil
+}
+
+func init() {
+	accessors.Register("raw_hfs", &HFSFileSystemAccessor{},
+		`Access the HFS+ (and HFSX) filesystem inside an image by parsing the image.
+
+This accessor is designed to operate on images directly. It requires a
+delegate accessor to get the raw image and will open files using the
+full path rooted at the top of the filesystem.
+
+Hard links are resolved to their inode and files compressed by the
+filesystem (zlib) are decompressed transparently. The creation date is
+returned as the file's birth time. Extended attributes, the resource
+fork (com.apple.ResourceFork) and the Finder information
+(com.apple.FinderInfo) are available using the xattr() function with
+this accessor.
+
+## Example
+
+The following query will list the extended attributes of all files in
+the user's Downloads directory inside an HFS+ partition.
+
+SELECT OSPath, Btime,
+       xattr(filename=OSPath, accessor="raw_hfs") AS XAttr
+FROM glob(globs='/Users/*/Downloads/*',
+  accessor="raw_hfs",
+  root=pathspec(
+    DelegateAccessor="offset",
+    Delegate=pathspec(
+      DelegateAccessor="file",
+      DelegatePath="/images/mac.dd",
+      Path="/209735680")))
+
+`)
+
+	json.RegisterCustomEncoder(&HFSFileInfo{}, accessors.MarshalGlobFileInfo)
+}
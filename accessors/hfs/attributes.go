@@ -0,0 +1,135 @@
+package hfs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	ATTR_INLINE_DATA = 0x10
+	ATTR_FORK_DATA   = 0x20
+	ATTR_EXTENTS     = 0x30
+
+	// Refuse to read larger attributes into memory.
+	MAX_XATTR_SIZE = 64 * 1024 * 1024
+)
+
+// An extended attribute from the attributes file.
+type Attribute struct {
+	Name string
+
+	// Small attributes are stored inline.
+	Data []byte
+
+	// Large attributes are stored in a fork.
+	Fork     *ForkData
+	Overflow []Extent
+}
+
+// Get all the extended attributes of the file.
+func (self *FileSystem) Attributes(file_id uint32) ([]*Attribute, error) {
+	result := []*Attribute{}
+	if self.attributes == nil {
+		return result, nil
+	}
+
+	by_name := make(map[string]*Attribute)
+	err := self.attributes.Scan(func(key []byte) int {
+		if len(key) < 6 {
+			return -1
+		}
+		id := binary.BigEndian.Uint32(key[2:])
+		switch {
+		case id < file_id:
+			return -1
+		case id > file_id:
+			return 1
+		}
+		return 0
+	}, func(key, data []byte) error {
+		if len(key) < 12 || len(data) < 4 {
+			return fmt.Errorf("hfs: Invalid attribute record")
+		}
+
+		length := int(binary.BigEndian.Uint16(key[10:]))
+		if 12+length*2 > len(key) {
+			return fmt.Errorf("hfs: Invalid attribute name")
+		}
+		name := decodeName(key[12 : 12+length*2])
+
+		switch binary.BigEndian.Uint32(data) {
+		case ATTR_INLINE_DATA:
+			if len(data) < 16 {
+				return fmt.Errorf("hfs: Invalid attribute record")
+			}
+			size := int(binary.BigEndian.Uint32(data[12:]))
+			if 16+size > len(data) {
+				return fmt.Errorf("hfs: Invalid attribute size")
+			}
+			attr := &Attribute{Name: name, Data: data[16 : 16+size]}
+			by_name[name] = attr
+			result = append(result, attr)
+
+		case ATTR_FORK_DATA:
+			if len(data) < 8+FORK_DATA_SIZE {
+				return fmt.Errorf("hfs: Invalid attribute record")
+			}
+			attr := &Attribute{Name: name, Fork: parseForkData(data[8:])}
+			by_name[name] = attr
+			result = append(result, attr)
+
+		// Forks with more than 8 extents continue in further
+		// records.
+		case ATTR_EXTENTS:
+			attr, pres := by_name[name]
+			if pres && attr.Fork != nil && len(data) >= 72 {
+				attr.Overflow = append(attr.Overflow, parseExtents(data[8:])...)
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func (self *FileSystem) GetAttribute(file_id uint32, name string) (
+	*Attribute, error) {
+	attributes, err := self.Attributes(file_id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attr := range attributes {
+		if attr.Name == name {
+			return attr, nil
+		}
+	}
+	return nil, os.ErrNotExist
+}
+
+func (self *FileSystem) ReadAttribute(attr *Attribute) ([]byte, error) {
+	if attr.Fork == nil {
+		return attr.Data, nil
+	}
+
+	return readAll(self.newForkReader(attr.Fork, attr.Overflow),
+		int64(attr.Fork.LogicalSize))
+}
+
+func readAll(reader io.ReaderAt, size int64) ([]byte, error) {
+	if size > MAX_XATTR_SIZE {
+		return nil, fmt.Errorf("hfs: Attribute too large (%v bytes)", size)
+	}
+
+	buf := make([]byte, size)
+	n, err := reader.ReadAt(buf, 0)
+	if int64(n) < size {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,212 @@
+package hfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	NODE_DESCRIPTOR_SIZE = 14
+
+	KIND_LEAF   = -1
+	KIND_INDEX  = 0
+	KIND_HEADER = 1
+
+	KEY_COMPARE_CASE_FOLDING = 0xcf
+	KEY_COMPARE_BINARY       = 0xbc
+
+	MIN_NODE_SIZE = 512
+	MAX_NODE_SIZE = 32768
+)
+
+// The catalog, extents overflow and attributes files are all B-trees
+// with the same node layout.
+type BTree struct {
+	reader *forkReader
+
+	NodeSize    uint32
+	RootNode    uint32
+	Depth       uint16
+	TotalNodes  uint32
+	CompareType uint8
+}
+
+type Node struct {
+	Kind    int8
+	Height  uint8
+	FLink   uint32
+	Records [][]byte
+}
+
+func NewBTree(reader *forkReader) (*BTree, error) {
+	buf := make([]byte, NODE_DESCRIPTOR_SIZE+106)
+	n, err := reader.ReadAt(buf, 0)
+	if n < len(buf) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	if int8(buf[8]) != KIND_HEADER {
+		return nil, errors.New("hfs: Invalid B-tree header node")
+	}
+
+	header := buf[NODE_DESCRIPTOR_SIZE:]
+	result := &BTree{
+		reader:      reader,
+		Depth:       binary.BigEndian.Uint16(header),
+		RootNode:    binary.BigEndian.Uint32(header[2:]),
+		NodeSize:    uint32(binary.BigEndian.Uint16(header[18:])),
+		TotalNodes:  binary.BigEndian.Uint32(header[22:]),
+		CompareType: header[37],
+	}
+
+	if result.NodeSize < MIN_NODE_SIZE || result.NodeSize > MAX_NODE_SIZE {
+		return nil, fmt.Errorf("hfs: Invalid B-tree node size %v",
+			result.NodeSize)
+	}
+
+	return result, nil
+}
+
+func (self *BTree) ReadNode(number uint32) (*Node, error) {
+	if number >= self.TotalNodes {
+		return nil, fmt.Errorf("hfs: Invalid B-tree node %v", number)
+	}
+
+	buf := make([]byte, self.NodeSize)
+	n, err := self.reader.ReadAt(buf, int64(number)*int64(self.NodeSize))
+	if n < len(buf) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	result := &Node{
+		FLink:  binary.BigEndian.Uint32(buf),
+		Kind:   int8(buf[8]),
+		Height: buf[9],
+	}
+
+	// The record offsets are stored backwards from the end of the
+	// node. Each record ends where the next one starts.
+	count := int(binary.BigEndian.Uint16(buf[10:]))
+	size := int(self.NodeSize)
+	if NODE_DESCRIPTOR_SIZE+2*(count+1) > size {
+		return nil, fmt.Errorf("hfs: Too many records in node %v", number)
+	}
+
+	for i := 0; i < count; i++ {
+		start := int(binary.BigEndian.Uint16(buf[size-2*(i+1):]))
+		end := int(binary.BigEndian.Uint16(buf[size-2*(i+2):]))
+		if start < NODE_DESCRIPTOR_SIZE || end < start || end > size {
+			return nil, fmt.Errorf("hfs: Invalid record %v in node %v",
+				i, number)
+		}
+		result.Records = append(result.Records, buf[start:end])
+	}
+
+	return result, nil
+}
+
+// Split a record into its key and data. The key does not include
+// the key length.
+func splitRecord(record []byte) ([]byte, []byte, error) {
+	if len(record) < 2 {
+		return nil, nil, errors.New("hfs: Record too short")
+	}
+
+	key_length := int(binary.BigEndian.Uint16(record))
+	if 2+key_length > len(record) {
+		return nil, nil, errors.New("hfs: Invalid key length")
+	}
+
+	return record[2 : 2+key_length], record[2+key_length:], nil
+}
+
+// Calls cb with all the leaf records in the range selected by
+// cmp. The cmp function returns -1 for keys before the range, 0 for
+// keys inside it and 1 for keys after it.
+func (self *BTree) Scan(cmp func(key []byte) int,
+	cb func(key, data []byte) error) error {
+	number := self.RootNode
+	if number == 0 {
+		// The tree is empty.
+		return nil
+	}
+
+	// Descend to the leftmost leaf which may hold the range.
+	for depth := 0; ; depth++ {
+		if depth > int(self.Depth)+1 {
+			return errors.New("hfs: B-tree too deep")
+		}
+
+		node, err := self.ReadNode(number)
+		if err != nil {
+			return err
+		}
+
+		if node.Kind == KIND_LEAF {
+			break
+		}
+
+		if node.Kind != KIND_INDEX || len(node.Records) == 0 {
+			return fmt.Errorf("hfs: Invalid index node %v", number)
+		}
+
+		next := uint32(0)
+		for i, record := range node.Records {
+			key, data, err := splitRecord(record)
+			if err != nil || len(data) < 4 {
+				return fmt.Errorf("hfs: Invalid index node %v", number)
+			}
+
+			if i > 0 && cmp(key) >= 0 {
+				break
+			}
+			next = binary.BigEndian.Uint32(data)
+		}
+		number = next
+	}
+
+	// Walk the leaves in order until we pass the range.
+	for visited := uint32(0); number != 0; visited++ {
+		if visited > self.TotalNodes {
+			return errors.New("hfs: Loop in B-tree leaves")
+		}
+
+		node, err := self.ReadNode(number)
+		if err != nil {
+			return err
+		}
+
+		if node.Kind != KIND_LEAF {
+			return fmt.Errorf("hfs: Invalid leaf node %v", number)
+		}
+
+		for _, record := range node.Records {
+			key, data, err := splitRecord(record)
+			if err != nil {
+				return err
+			}
+
+			switch cmp(key) {
+			case 0:
+				err = cb(key, data)
+				if err != nil {
+					return err
+				}
+			case 1:
+				return nil
+			}
+		}
+
+		number = node.FLink
+	}
+
+	return nil
+}
@@ -0,0 +1,318 @@
+package hfs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"www.velocidex.com/golang/velociraptor/accessors/decmpfs"
+)
+
+const (
+	RECORD_TYPE_FOLDER        = 1
+	RECORD_TYPE_FILE          = 2
+	RECORD_TYPE_FOLDER_THREAD = 3
+	RECORD_TYPE_FILE_THREAD   = 4
+
+	FOLDER_RECORD_SIZE = 88
+	FILE_RECORD_SIZE   = 248
+
+	S_IFMT   = 0170000
+	S_IFIFO  = 0010000
+	S_IFCHR  = 0020000
+	S_IFDIR  = 0040000
+	S_IFBLK  = 0060000
+	S_IFREG  = 0100000
+	S_IFLNK  = 0120000
+	S_IFSOCK = 0140000
+
+	// File hard links point at an inode in the private data folder.
+	HARD_LINK_FILE_TYPE    = 0x686c6e6b // hlnk
+	HARD_LINK_FILE_CREATOR = 0x6866732b // hfs+
+
+	PRIVATE_DATA_FOLDER = "\x00\x00\x00\x00HFS+ Private Data"
+)
+
+// A file or folder record from the catalog.
+type CatalogRecord struct {
+	Type     int16
+	Name     string
+	ParentID uint32
+	ID       uint32
+	Valence  uint32
+
+	CreateDate       time.Time
+	ContentModDate   time.Time
+	AttributeModDate time.Time
+	AccessDate       time.Time
+	BackupDate       time.Time
+
+	OwnerID    uint32
+	GroupID    uint32
+	AdminFlags uint8
+	OwnerFlags uint8
+	Mode       uint16
+
+	// The inode number of a hard link or the link count of an
+	// inode.
+	Special uint32
+
+	// The FileInfo/FolderInfo and the extended finder info.
+	FinderInfo []byte
+
+	DataFork     *ForkData
+	ResourceFork *ForkData
+
+	// The name of the hard link if this record was reached through
+	// one.
+	HardLink string
+}
+
+// Names are stored as UTF-16. A '/' in the catalog is a ':' in the
+// POSIX name.
+func decodeName(buf []byte) string {
+	u16 := make([]uint16, len(buf)/2)
+	for i := range u16 {
+		u16[i] = binary.BigEndian.Uint16(buf[i*2:])
+	}
+	return strings.Replace(string(utf16.Decode(u16)), "/", ":", -1)
+}
+
+func parseCatalogKey(key []byte) (uint32, string, error) {
+	if len(key) < 6 {
+		return 0, "", fmt.Errorf("hfs: Catalog key too short")
+	}
+
+	length := int(binary.BigEndian.Uint16(key[4:]))
+	if 6+length*2 > len(key) {
+		return 0, "", fmt.Errorf("hfs: Invalid catalog key name")
+	}
+
+	return binary.BigEndian.Uint32(key), decodeName(key[6 : 6+length*2]), nil
+}
+
+func parseCatalogRecord(key, data []byte) (*CatalogRecord, error) {
+	parent_id, name, err := parseCatalogKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 2 {
+		return nil, fmt.Errorf("hfs: Catalog record too short")
+	}
+
+	result := &CatalogRecord{
+		Type:     int16(binary.BigEndian.Uint16(data)),
+		Name:     name,
+		ParentID: parent_id,
+	}
+
+	switch result.Type {
+	case RECORD_TYPE_FOLDER:
+		if len(data) < FOLDER_RECORD_SIZE {
+			return nil, fmt.Errorf("hfs: Folder record too short")
+		}
+		result.Valence = binary.BigEndian.Uint32(data[4:])
+
+	case RECORD_TYPE_FILE:
+		if len(data) < FILE_RECORD_SIZE {
+			return nil, fmt.Errorf("hfs: File record too short")
+		}
+		result.DataFork = parseForkData(data[88:])
+		result.ResourceFork = parseForkData(data[168:])
+
+	default:
+		return result, nil
+	}
+
+	result.ID = binary.BigEndian.Uint32(data[8:])
+	result.CreateDate = hfsTime(binary.BigEndian.Uint32(data[12:]))
+	result.ContentModDate = hfsTime(binary.BigEndian.Uint32(data[16:]))
+	result.AttributeModDate = hfsTime(binary.BigEndian.Uint32(data[20:]))
+	result.AccessDate = hfsTime(binary.BigEndian.Uint32(data[24:]))
+	result.BackupDate = hfsTime(binary.BigEndian.Uint32(data[28:]))
+	result.OwnerID = binary.BigEndian.Uint32(data[32:])
+	result.GroupID = binary.BigEndian.Uint32(data[36:])
+	result.AdminFlags = data[40]
+	result.OwnerFlags = data[41]
+	result.Mode = binary.BigEndian.Uint16(data[42:])
+	result.Special = binary.BigEndian.Uint32(data[44:])
+	result.FinderInfo = append([]byte{}, data[48:80]...)
+
+	return result, nil
+}
+
+func (self *CatalogRecord) IsDir() bool {
+	return self.Type == RECORD_TYPE_FOLDER
+}
+
+func (self *CatalogRecord) FileType() uint32 {
+	return binary.BigEndian.Uint32(self.FinderInfo)
+}
+
+func (self *CatalogRecord) FileCreator() uint32 {
+	return binary.BigEndian.Uint32(self.FinderInfo[4:])
+}
+
+func (self *CatalogRecord) IsHardLink() bool {
+	return self.Type == RECORD_TYPE_FILE &&
+		self.FileType() == HARD_LINK_FILE_TYPE &&
+		self.FileCreator() == HARD_LINK_FILE_CREATOR
+}
+
+func (self *CatalogRecord) IsCompressed() bool {
+	return self.Type == RECORD_TYPE_FILE &&
+		self.OwnerFlags&decmpfs.UF_COMPRESSED != 0
+}
+
+func (self *CatalogRecord) FileMode() os.FileMode {
+	perm := os.FileMode(self.Mode & 0777)
+
+	switch self.Mode & S_IFMT {
+	case S_IFDIR:
+		return perm | os.ModeDir
+	case S_IFLNK:
+		return perm | os.ModeSymlink
+	case S_IFIFO:
+		return perm | os.ModeNamedPipe
+	case S_IFCHR:
+		return perm | os.ModeDevice | os.ModeCharDevice
+	case S_IFBLK:
+		return perm | os.ModeDevice
+	case S_IFSOCK:
+		return perm | os.ModeSocket
+	case S_IFREG:
+		return perm
+	}
+
+	// Volumes created by older systems may not record permissions.
+	if self.IsDir() {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
+
+func (self *CatalogRecord) IsLink() bool {
+	return self.Mode&S_IFMT == S_IFLNK
+}
+
+func (self *CatalogRecord) Size() int64 {
+	if self.DataFork == nil {
+		return 0
+	}
+	return int64(self.DataFork.LogicalSize)
+}
+
+// List the files and folders in a folder.
+func (self *FileSystem) ReadDir(folder_id uint32) ([]*CatalogRecord, error) {
+	result := []*CatalogRecord{}
+	err := self.catalog.Scan(func(key []byte) int {
+		if len(key) < 4 {
+			return -1
+		}
+		parent_id := binary.BigEndian.Uint32(key)
+		switch {
+		case parent_id < folder_id:
+			return -1
+		case parent_id > folder_id:
+			return 1
+		}
+		return 0
+	}, func(key, data []byte) error {
+		record, err := parseCatalogRecord(key, data)
+		if err != nil {
+			return err
+		}
+
+		// Skip the thread record of the folder itself.
+		if record.Type == RECORD_TYPE_FOLDER || record.Type == RECORD_TYPE_FILE {
+			result = append(result, record)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Find the record with the name in the folder. Case insensitive
+// volumes fold the case of the name.
+func (self *FileSystem) FindChild(folder_id uint32, name string) (
+	*CatalogRecord, error) {
+	children, err := self.ReadDir(folder_id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		if child.Name == name {
+			return child, nil
+		}
+	}
+
+	if !self.CaseSensitive {
+		for _, child := range children {
+			if strings.EqualFold(child.Name, name) {
+				return child, nil
+			}
+		}
+	}
+
+	return nil, os.ErrNotExist
+}
+
+func (self *FileSystem) RootFolder() (*CatalogRecord, error) {
+	children, err := self.ReadDir(HFS_ROOT_PARENT_ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		if child.ID == HFS_ROOT_FOLDER_ID {
+			return child, nil
+		}
+	}
+	return nil, fmt.Errorf("hfs: Root folder not found")
+}
+
+func (self *FileSystem) privateDataFolder() (uint32, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.private_data_id == 0 {
+		private, err := self.FindChild(HFS_ROOT_FOLDER_ID, PRIVATE_DATA_FOLDER)
+		if err != nil {
+			return 0, err
+		}
+		self.private_data_id = private.ID
+	}
+	return self.private_data_id, nil
+}
+
+// Hard links are files pointing at an inode file in the private
+// data folder. Returns the inode record under the name of the link.
+func (self *FileSystem) ResolveHardLink(record *CatalogRecord) (
+	*CatalogRecord, error) {
+	if !record.IsHardLink() {
+		return record, nil
+	}
+
+	private_data_id, err := self.privateDataFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	inode, err := self.FindChild(private_data_id,
+		fmt.Sprintf("iNode%d", record.Special))
+	if err != nil {
+		return nil, err
+	}
+
+	result := *inode
+	result.Name = record.Name
+	result.ParentID = record.ParentID
+	result.HardLink = inode.Name
+	return &result, nil
+}
@@ -0,0 +1,158 @@
+package hfs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+
+	"www.velocidex.com/golang/velociraptor/accessors/decmpfs"
+)
+
+const (
+	FINDER_INFO_XATTR = "com.apple.FinderInfo"
+
+	// Symlink targets are limited to this length.
+	MAX_LINK_SIZE = 1024
+)
+
+// Get a reader over the file's content. Compressed files are
+// decompressed transparently.
+func (self *FileSystem) Reader(record *CatalogRecord) (io.ReaderAt, int64, error) {
+	if record.Type != RECORD_TYPE_FILE {
+		return nil, 0, errors.New("hfs: Not a file")
+	}
+
+	if record.IsCompressed() {
+		attr, err := self.GetAttribute(record.ID, decmpfs.XATTR_NAME)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		data, err := self.ReadAttribute(attr)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		rsrc, err := self.ForkReader(
+			record.ID, FORK_TYPE_RESOURCE, record.ResourceFork)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return decmpfs.NewReader(data, rsrc)
+	}
+
+	reader, err := self.ForkReader(record.ID, FORK_TYPE_DATA, record.DataFork)
+	if err != nil {
+		return nil, 0, err
+	}
+	return reader, reader.Size(), nil
+}
+
+// The size of the file content. For compressed files this is the
+// uncompressed size.
+func (self *FileSystem) FileSize(record *CatalogRecord) int64 {
+	if !record.IsCompressed() {
+		return record.Size()
+	}
+
+	attr, err := self.GetAttribute(record.ID, decmpfs.XATTR_NAME)
+	if err != nil {
+		return 0
+	}
+
+	data, err := self.ReadAttribute(attr)
+	if err != nil {
+		return 0
+	}
+
+	header, err := decmpfs.ParseHeader(data)
+	if err != nil {
+		return 0
+	}
+	return header.Size
+}
+
+func (self *FileSystem) Readlink(record *CatalogRecord) (string, error) {
+	if !record.IsLink() {
+		return "", errors.New("hfs: Not a symlink")
+	}
+
+	reader, size, err := self.Reader(record)
+	if err != nil {
+		return "", err
+	}
+
+	if size > MAX_LINK_SIZE {
+		return "", errors.New("hfs: Symlink target too long")
+	}
+
+	data, err := readAll(reader, size)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Extended attribute names as presented by macOS. The resource fork
+// and Finder info are stored outside the attributes file and the
+// compression attribute is hidden.
+func (self *FileSystem) ListXAttr(record *CatalogRecord) ([]string, error) {
+	result := []string{}
+
+	if !bytes.Equal(record.FinderInfo, make([]byte, len(record.FinderInfo))) {
+		result = append(result, FINDER_INFO_XATTR)
+	}
+
+	if record.ResourceFork != nil && record.ResourceFork.LogicalSize > 0 &&
+		!record.IsCompressed() {
+		result = append(result, decmpfs.RESOURCE_FORK_XATTR)
+	}
+
+	attributes, err := self.Attributes(record.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attr := range attributes {
+		if attr.Name != decmpfs.XATTR_NAME {
+			result = append(result, attr.Name)
+		}
+	}
+
+	return result, nil
+}
+
+func (self *FileSystem) GetXAttr(record *CatalogRecord, name string) (
+	[]byte, error) {
+	switch name {
+	case FINDER_INFO_XATTR:
+		if bytes.Equal(record.FinderInfo, make([]byte, len(record.FinderInfo))) {
+			return nil, os.ErrNotExist
+		}
+		return record.FinderInfo, nil
+
+	case decmpfs.RESOURCE_FORK_XATTR:
+		if record.ResourceFork == nil || record.ResourceFork.LogicalSize == 0 ||
+			record.IsCompressed() {
+			return nil, os.ErrNotExist
+		}
+
+		reader, err := self.ForkReader(
+			record.ID, FORK_TYPE_RESOURCE, record.ResourceFork)
+		if err != nil {
+			return nil, err
+		}
+		return readAll(reader, reader.Size())
+
+	case decmpfs.XATTR_NAME:
+		return nil, os.ErrNotExist
+	}
+
+	attr, err := self.GetAttribute(record.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	return self.ReadAttribute(attr)
+}
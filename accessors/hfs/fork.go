@@ -0,0 +1,155 @@
+package hfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	FORK_TYPE_DATA     = 0x00
+	FORK_TYPE_RESOURCE = 0xff
+)
+
+// A contiguous run of blocks in the fork.
+type run struct {
+	logical  uint64
+	physical uint64
+	count    uint64
+}
+
+type forkReader struct {
+	fs   *FileSystem
+	runs []run
+	size int64
+}
+
+func (self *FileSystem) newForkReader(
+	fork *ForkData, overflow []Extent) *forkReader {
+	result := &forkReader{
+		fs:   self,
+		size: int64(fork.LogicalSize),
+	}
+
+	logical := uint64(0)
+	for _, e := range append(append([]Extent{}, fork.Extents...), overflow...) {
+		result.runs = append(result.runs, run{
+			logical:  logical,
+			physical: uint64(e.StartBlock),
+			count:    uint64(e.BlockCount),
+		})
+		logical += uint64(e.BlockCount)
+	}
+
+	return result
+}
+
+// Get a reader over a fork. Forks with more than 8 extents keep the
+// rest in the extents overflow file.
+func (self *FileSystem) ForkReader(file_id uint32, fork_type uint8,
+	fork *ForkData) (*forkReader, error) {
+	total := uint64(0)
+	for _, e := range fork.Extents {
+		total += uint64(e.BlockCount)
+	}
+
+	var overflow []Extent
+	if total < uint64(fork.TotalBlocks) && self.extents != nil {
+		err := self.extents.Scan(func(key []byte) int {
+			if len(key) < 8 {
+				return -1
+			}
+			id := binary.BigEndian.Uint32(key[2:])
+			switch {
+			case id < file_id:
+				return -1
+			case id > file_id:
+				return 1
+			case key[0] < fork_type:
+				return -1
+			case key[0] > fork_type:
+				return 1
+			}
+			return 0
+		}, func(key, data []byte) error {
+			overflow = append(overflow, parseExtents(data)...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return self.newForkReader(fork, overflow), nil
+}
+
+func (self *forkReader) Size() int64 {
+	return self.size
+}
+
+func (self *forkReader) ReadAt(buf []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, errors.New("hfs: Negative offset")
+	}
+
+	if offset >= self.size {
+		return 0, io.EOF
+	}
+
+	to_read := int64(len(buf))
+	if offset+to_read > self.size {
+		to_read = self.size - offset
+	}
+
+	block_size := int64(self.fs.Header.BlockSize)
+	total := int64(0)
+	for total < to_read {
+		current := offset + total
+		block := uint64(current / block_size)
+		block_offset := current % block_size
+
+		var found *run
+		for i := range self.runs {
+			r := &self.runs[i]
+			if block >= r.logical && block < r.logical+r.count {
+				found = r
+				break
+			}
+		}
+
+		// Blocks which are not allocated read as zeros.
+		if found == nil {
+			n := block_size - block_offset
+			if n > to_read-total {
+				n = to_read - total
+			}
+			for i := int64(0); i < n; i++ {
+				buf[total+i] = 0
+			}
+			total += n
+			continue
+		}
+
+		run_end := int64(found.logical+found.count) * block_size
+		n := run_end - current
+		if n > to_read-total {
+			n = to_read - total
+		}
+
+		physical := int64(found.physical+block-found.logical)*block_size +
+			block_offset
+		read, err := self.fs.reader.ReadAt(buf[total:total+n], physical)
+		total += int64(read)
+		if int64(read) < n {
+			if err == nil || err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return int(total), err
+		}
+	}
+
+	if total < int64(len(buf)) {
+		return int(total), io.EOF
+	}
+	return int(total), nil
+}
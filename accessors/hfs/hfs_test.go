@@ -0,0 +1,310 @@
+package hfs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/accessors/decmpfs"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+const (
+	testBlockSize = 4096
+	testNodeSize  = 4096
+)
+
+var (
+	testCreate = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	testModify = time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+)
+
+func putTime(buf []byte, t time.Time) {
+	binary.BigEndian.PutUint32(buf, uint32(t.Unix()+HFS_EPOCH_DELTA))
+}
+
+func encodeName(name string) []byte {
+	u16 := utf16.Encode([]rune(name))
+	buf := make([]byte, 2+len(u16)*2)
+	binary.BigEndian.PutUint16(buf, uint16(len(u16)))
+	for i, c := range u16 {
+		binary.BigEndian.PutUint16(buf[2+i*2:], c)
+	}
+	return buf
+}
+
+func buildNode(kind int8, flink uint32, records [][]byte) []byte {
+	buf := make([]byte, testNodeSize)
+	binary.BigEndian.PutUint32(buf, flink)
+	buf[8] = byte(kind)
+	binary.BigEndian.PutUint16(buf[10:], uint16(len(records)))
+
+	offset := NODE_DESCRIPTOR_SIZE
+	for i, record := range records {
+		binary.BigEndian.PutUint16(buf[testNodeSize-2*(i+1):], uint16(offset))
+		offset += copy(buf[offset:], record)
+	}
+	binary.BigEndian.PutUint16(buf[testNodeSize-2*(len(records)+1):],
+		uint16(offset))
+	return buf
+}
+
+func headerNode(root uint32, depth uint16, total uint32) []byte {
+	header := make([]byte, 106)
+	binary.BigEndian.PutUint16(header, depth)
+	binary.BigEndian.PutUint32(header[2:], root)
+	binary.BigEndian.PutUint16(header[18:], testNodeSize)
+	binary.BigEndian.PutUint32(header[22:], total)
+	header[37] = KEY_COMPARE_CASE_FOLDING
+	return buildNode(KIND_HEADER, 0, [][]byte{header})
+}
+
+func withKey(key []byte, data []byte) []byte {
+	buf := make([]byte, 2)
+	binary.BigEndian.PutUint16(buf, uint16(len(key)))
+	return append(append(buf, key...), data...)
+}
+
+func catalogKey(parent uint32, name string) []byte {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, parent)
+	return append(buf, encodeName(name)...)
+}
+
+func forkData(size uint64, start, count uint32) []byte {
+	buf := make([]byte, FORK_DATA_SIZE)
+	binary.BigEndian.PutUint64(buf, size)
+	binary.BigEndian.PutUint32(buf[12:], count)
+	binary.BigEndian.PutUint32(buf[16:], start)
+	binary.BigEndian.PutUint32(buf[20:], count)
+	return buf
+}
+
+func catalogRecord(record_type int16, id uint32, mode uint16) []byte {
+	size := FOLDER_RECORD_SIZE
+	if record_type == RECORD_TYPE_FILE {
+		size = FILE_RECORD_SIZE
+	}
+
+	buf := make([]byte, size)
+	binary.BigEndian.PutUint16(buf, uint16(record_type))
+	binary.BigEndian.PutUint32(buf[8:], id)
+	putTime(buf[12:], testCreate)
+	putTime(buf[16:], testModify)
+	binary.BigEndian.PutUint32(buf[32:], 501)
+	binary.BigEndian.PutUint32(buf[36:], 20)
+	binary.BigEndian.PutUint16(buf[42:], mode)
+	return buf
+}
+
+func threadRecord(parent uint32, name string) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint16(buf, RECORD_TYPE_FOLDER_THREAD)
+	binary.BigEndian.PutUint32(buf[4:], parent)
+	return append(buf, encodeName(name)...)
+}
+
+func attributeKey(file_id uint32, name string) []byte {
+	buf := make([]byte, 10)
+	binary.BigEndian.PutUint32(buf[2:], file_id)
+	return append(buf, encodeName(name)...)
+}
+
+func inlineAttribute(data []byte) []byte {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint32(buf, ATTR_INLINE_DATA)
+	binary.BigEndian.PutUint32(buf[12:], uint32(len(data)))
+	return append(buf, data...)
+}
+
+func compressedAttribute(data []byte) []byte {
+	b := &bytes.Buffer{}
+	w := zlib.NewWriter(b)
+	w.Write(data)
+	w.Close()
+
+	header := make([]byte, decmpfs.DECMPFS_HEADER_SIZE)
+	binary.LittleEndian.PutUint32(header, decmpfs.DECMPFS_MAGIC)
+	binary.LittleEndian.PutUint32(header[4:], decmpfs.CMP_TYPE_INLINE_ZLIB)
+	binary.LittleEndian.PutUint64(header[8:], uint64(len(data)))
+	return append(header, b.Bytes()...)
+}
+
+// Layout of the test image in blocks:
+// 0: Volume header
+// 1: Extents overflow file (empty)
+// 2-5: Catalog file (header, index and two leaves)
+// 6-7: Attributes file (header and leaf)
+// 8: Content of hello.txt
+// 9: Resource fork of hello.txt
+// 10: Symlink target
+func buildImage() []byte {
+	image := make([]byte, 11*testBlockSize)
+	block := func(n int) []byte {
+		return image[n*testBlockSize : (n+1)*testBlockSize]
+	}
+
+	header := block(0)[HFS_VOLUME_HEADER_OFFSET:]
+	binary.BigEndian.PutUint16(header, HFS_PLUS_SIGNATURE)
+	binary.BigEndian.PutUint16(header[2:], 4)
+	binary.BigEndian.PutUint32(header[40:], testBlockSize)
+	binary.BigEndian.PutUint32(header[44:], 11)
+	copy(header[192:], forkData(testBlockSize, 1, 1))
+	copy(header[272:], forkData(4*testBlockSize, 2, 4))
+	copy(header[352:], forkData(2*testBlockSize, 6, 2))
+
+	copy(block(1), headerNode(0, 0, 1))
+
+	// The catalog has an index node so lookups must descend and
+	// follow the leaf links.
+	copy(block(2), headerNode(1, 2, 4))
+	copy(block(3), buildNode(KIND_INDEX, 0, [][]byte{
+		withKey(catalogKey(1, "Macintosh HD"), []byte{0, 0, 0, 2}),
+		withKey(catalogKey(2, "hello.txt"), []byte{0, 0, 0, 3}),
+	}))
+
+	hello := catalogRecord(RECORD_TYPE_FILE, 17, S_IFREG|0644)
+	copy(hello[48:], "TEXTttxt")
+	copy(hello[88:], forkData(11, 8, 1))
+	copy(hello[168:], forkData(8, 9, 1))
+
+	compressed := catalogRecord(RECORD_TYPE_FILE, 18, S_IFREG|0644)
+	compressed[41] = decmpfs.UF_COMPRESSED
+
+	link := catalogRecord(RECORD_TYPE_FILE, 19, S_IFLNK|0755)
+	copy(link[88:], forkData(9, 10, 1))
+
+	copy(block(4), buildNode(KIND_LEAF, 3, [][]byte{
+		withKey(catalogKey(1, "Macintosh HD"),
+			catalogRecord(RECORD_TYPE_FOLDER, 2, S_IFDIR|0755)),
+		withKey(catalogKey(2, ""), threadRecord(1, "Macintosh HD")),
+		withKey(catalogKey(2, "Users"),
+			catalogRecord(RECORD_TYPE_FOLDER, 16, S_IFDIR|0755)),
+		withKey(catalogKey(2, "compressed.txt"), compressed),
+	}))
+	copy(block(5), buildNode(KIND_LEAF, 0, [][]byte{
+		withKey(catalogKey(2, "hello.txt"), hello),
+		withKey(catalogKey(2, "link"), link),
+		withKey(catalogKey(16, ""), threadRecord(2, "Users")),
+	}))
+
+	copy(block(6), headerNode(1, 1, 2))
+	copy(block(7), buildNode(KIND_LEAF, 0, [][]byte{
+		withKey(attributeKey(17, "com.example.tag"),
+			inlineAttribute([]byte("tag value"))),
+		withKey(attributeKey(18, decmpfs.XATTR_NAME),
+			inlineAttribute(compressedAttribute([]byte("compressed content")))),
+	}))
+
+	copy(block(8), "hello world")
+	copy(block(9), "resource")
+	copy(block(10), "hello.txt")
+
+	return image
+}
+
+func TestHFS(t *testing.T) {
+	dir := t.TempDir()
+	image := filepath.Join(dir, "hfs.dd")
+	assert.NoError(t, os.WriteFile(image, buildImage(), 0600))
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	accessor, err := accessors.GetAccessor("raw_hfs", scope)
+	assert.NoError(t, err)
+
+	root := accessors.MustNewLinuxOSPath("")
+	root.SetPathSpec(&accessors.PathSpec{
+		DelegateAccessor: "file",
+		DelegatePath:     image,
+	})
+
+	list := func(path *accessors.OSPath) []string {
+		children, err := accessor.ReadDirWithOSPath(path)
+		assert.NoError(t, err)
+
+		result := []string{}
+		for _, c := range children {
+			result = append(result, c.Name())
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	read := func(path *accessors.OSPath) string {
+		fd, err := accessor.OpenWithOSPath(path)
+		assert.NoError(t, err)
+		defer fd.Close()
+
+		data, err := ioutil.ReadAll(fd)
+		assert.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Equal(t, []string{"Users", "compressed.txt", "hello.txt", "link"},
+		list(root))
+	assert.Equal(t, []string{}, list(root.Append("Users")))
+
+	info, err := accessor.LstatWithOSPath(root.Append("hello.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), info.Size())
+	assert.Equal(t, testCreate, info.Btime())
+	assert.Equal(t, testModify, info.Mtime())
+	assert.Equal(t, "-rw-r--r--", info.Mode().String())
+	assert.Equal(t, "hello world", read(root.Append("hello.txt")))
+
+	file_type, _ := info.Data().Get("FileType")
+	assert.Equal(t, "TEXT", file_type)
+
+	// The volume is case insensitive.
+	assert.Equal(t, "hello world", read(root.Append("HELLO.TXT")))
+
+	xattrs, ok := info.(accessors.ExtendedAttributes)
+	assert.True(t, ok)
+
+	names, err := xattrs.ListXAttr()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"com.apple.FinderInfo", "com.apple.ResourceFork",
+		"com.example.tag"}, names)
+
+	value, err := xattrs.GetXAttr("com.apple.ResourceFork")
+	assert.NoError(t, err)
+	assert.Equal(t, "resource", string(value))
+
+	value, err = xattrs.GetXAttr("com.example.tag")
+	assert.NoError(t, err)
+	assert.Equal(t, "tag value", string(value))
+
+	// Compressed files are decompressed and the compression
+	// attribute is hidden.
+	info, err = accessor.LstatWithOSPath(root.Append("compressed.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(18), info.Size())
+	assert.Equal(t, "compressed content", read(root.Append("compressed.txt")))
+
+	names, err = info.(accessors.ExtendedAttributes).ListXAttr()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, names)
+
+	info, err = accessor.LstatWithOSPath(root.Append("link"))
+	assert.NoError(t, err)
+	assert.True(t, info.IsLink())
+	target, err := info.GetLink()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hello.txt"}, target.Components)
+}
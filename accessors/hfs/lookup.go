@@ -0,0 +1,40 @@
+package hfs
+
+import (
+	"fmt"
+	"os"
+)
+
+// The maximum number of path components we walk.
+const MAX_PATH_DEPTH = 256
+
+// Walk the path from the root folder. Hard links are resolved to
+// their inode.
+func (self *FileSystem) Lookup(components []string) (*CatalogRecord, error) {
+	if len(components) > MAX_PATH_DEPTH {
+		return nil, fmt.Errorf("hfs: Path too deep")
+	}
+
+	result, err := self.RootFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range components {
+		if !result.IsDir() {
+			return nil, os.ErrNotExist
+		}
+
+		child, err := self.FindChild(result.ID, name)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = self.ResolveHardLink(child)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package hfs
+
+import (
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/constants"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/readers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+func GetHFSContext(scope vfilter.Scope,
+	device, fullpath *accessors.OSPath, accessor string) (
+	result *FileSystem, err error) {
+
+	if device == nil {
+		device, err = fullpath.Delegate(scope)
+		if err != nil {
+			return nil, err
+		}
+		accessor = fullpath.DelegateAccessor()
+	}
+
+	return GetHFSCache(scope, device, accessor)
+}
+
+func GetHFSCache(scope vfilter.Scope,
+	device *accessors.OSPath, accessor string) (*FileSystem, error) {
+	key := "hfs_cache" + device.String() + accessor
+
+	// Get the cache context from the root scope's cache
+	cache_ctx, ok := vql_subsystem.CacheGet(scope, key).(*FileSystem)
+	if !ok {
+		lru_size := vql_subsystem.GetIntFromRow(
+			scope, scope, constants.NTFS_CACHE_SIZE)
+
+		paged_reader, err := readers.NewAccessorReader(
+			scope, accessor, device, int(lru_size))
+		if err != nil {
+			return nil, err
+		}
+
+		cache_ctx, err = NewFileSystem(paged_reader)
+		if err != nil {
+			paged_reader.Close()
+			return nil, err
+		}
+		vql_subsystem.CacheSet(scope, key, cache_ctx)
+
+		// Close the device when we are done with this query.
+		err = vql_subsystem.GetRootScope(scope).AddDestructor(func() {
+			paged_reader.Close()
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cache_ctx, nil
+}
@@ -0,0 +1,191 @@
+package hfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	HFS_VOLUME_HEADER_OFFSET = 1024
+	HFS_VOLUME_HEADER_SIZE   = 512
+
+	HFS_PLUS_SIGNATURE = 0x482b // H+
+	HFSX_SIGNATURE     = 0x4858 // HX
+
+	// Reserved catalog node IDs.
+	HFS_ROOT_PARENT_ID     = 1
+	HFS_ROOT_FOLDER_ID     = 2
+	HFS_EXTENTS_FILE_ID    = 3
+	HFS_CATALOG_FILE_ID    = 4
+	HFS_ATTRIBUTES_FILE_ID = 8
+
+	// Seconds between 1904-01-01 and the unix epoch.
+	HFS_EPOCH_DELTA = 2082844800
+
+	FORK_DATA_SIZE = 80
+)
+
+type Extent struct {
+	StartBlock uint32
+	BlockCount uint32
+}
+
+type ForkData struct {
+	LogicalSize uint64
+	TotalBlocks uint32
+	Extents     []Extent
+}
+
+func parseExtents(buf []byte) []Extent {
+	result := []Extent{}
+	for i := 0; i+8 <= len(buf) && i < 64; i += 8 {
+		extent := Extent{
+			StartBlock: binary.BigEndian.Uint32(buf[i:]),
+			BlockCount: binary.BigEndian.Uint32(buf[i+4:]),
+		}
+		if extent.BlockCount == 0 {
+			break
+		}
+		result = append(result, extent)
+	}
+	return result
+}
+
+func parseForkData(buf []byte) *ForkData {
+	return &ForkData{
+		LogicalSize: binary.BigEndian.Uint64(buf),
+		TotalBlocks: binary.BigEndian.Uint32(buf[12:]),
+		Extents:     parseExtents(buf[16:FORK_DATA_SIZE]),
+	}
+}
+
+type VolumeHeader struct {
+	Signature   uint16
+	Version     uint16
+	Attributes  uint32
+	FileCount   uint32
+	FolderCount uint32
+	BlockSize   uint32
+	TotalBlocks uint32
+
+	// The volume dates are in local time.
+	CreateDate time.Time
+	ModifyDate time.Time
+
+	ExtentsFile    *ForkData
+	CatalogFile    *ForkData
+	AttributesFile *ForkData
+}
+
+func hfsTime(value uint32) time.Time {
+	if value == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(value)-HFS_EPOCH_DELTA, 0).UTC()
+}
+
+func ParseVolumeHeader(reader io.ReaderAt) (*VolumeHeader, error) {
+	buf := make([]byte, HFS_VOLUME_HEADER_SIZE)
+	n, err := reader.ReadAt(buf, HFS_VOLUME_HEADER_OFFSET)
+	if n < len(buf) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("hfs: Reading volume header: %w", err)
+	}
+
+	result := &VolumeHeader{
+		Signature:      binary.BigEndian.Uint16(buf),
+		Version:        binary.BigEndian.Uint16(buf[2:]),
+		Attributes:     binary.BigEndian.Uint32(buf[4:]),
+		CreateDate:     hfsTime(binary.BigEndian.Uint32(buf[16:])),
+		ModifyDate:     hfsTime(binary.BigEndian.Uint32(buf[20:])),
+		FileCount:      binary.BigEndian.Uint32(buf[32:]),
+		FolderCount:    binary.BigEndian.Uint32(buf[36:]),
+		BlockSize:      binary.BigEndian.Uint32(buf[40:]),
+		TotalBlocks:    binary.BigEndian.Uint32(buf[44:]),
+		ExtentsFile:    parseForkData(buf[192:]),
+		CatalogFile:    parseForkData(buf[272:]),
+		AttributesFile: parseForkData(buf[352:]),
+	}
+
+	if result.Signature != HFS_PLUS_SIGNATURE &&
+		result.Signature != HFSX_SIGNATURE {
+		return nil, errors.New("hfs: Invalid volume signature")
+	}
+
+	if result.BlockSize < 512 || result.BlockSize&(result.BlockSize-1) != 0 {
+		return nil, fmt.Errorf("hfs: Invalid block size %v", result.BlockSize)
+	}
+
+	return result, nil
+}
+
+type FileSystem struct {
+	reader io.ReaderAt
+	Header *VolumeHeader
+
+	catalog    *BTree
+	extents    *BTree
+	attributes *BTree
+
+	// HFSX volumes may be case sensitive.
+	CaseSensitive bool
+
+	// The folder holding the targets of file hard links.
+	mu              sync.Mutex
+	private_data_id uint32
+}
+
+func NewFileSystem(reader io.ReaderAt) (*FileSystem, error) {
+	header, err := ParseVolumeHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FileSystem{
+		reader: reader,
+		Header: header,
+	}
+
+	// The extents file can not have overflow extents itself.
+	result.extents, err = NewBTree(result.newForkReader(
+		header.ExtentsFile, nil))
+	if err != nil {
+		return nil, fmt.Errorf("hfs: Extents file: %w", err)
+	}
+
+	catalog_reader, err := result.ForkReader(
+		HFS_CATALOG_FILE_ID, FORK_TYPE_DATA, header.CatalogFile)
+	if err != nil {
+		return nil, err
+	}
+
+	result.catalog, err = NewBTree(catalog_reader)
+	if err != nil {
+		return nil, fmt.Errorf("hfs: Catalog file: %w", err)
+	}
+
+	// Binary comparison is only used by case sensitive HFSX volumes.
+	result.CaseSensitive = header.Signature == HFSX_SIGNATURE &&
+		result.catalog.CompareType == KEY_COMPARE_BINARY
+
+	if header.AttributesFile.LogicalSize > 0 {
+		attributes_reader, err := result.ForkReader(
+			HFS_ATTRIBUTES_FILE_ID, FORK_TYPE_DATA, header.AttributesFile)
+		if err != nil {
+			return nil, err
+		}
+
+		result.attributes, err = NewBTree(attributes_reader)
+		if err != nil {
+			return nil, fmt.Errorf("hfs: Attributes file: %w", err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,91 @@
+// An accessor for Windows hibernation files.
+//
+// The hibernation file (hiberfil.sys) stores a compressed snapshot
+// of physical memory. Windows 8 and later store the memory in
+// compression sets, each holding up to 16 runs of pages compressed
+// with Xpress or Xpress Huffman. We index the sets and present the
+// physical memory as a sparse file, decompressing sets on demand.
+
+package hiberfil
+
+import (
+	"fmt"
+
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/accessors/lime"
+	"www.velocidex.com/golang/velociraptor/accessors/zip"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+)
+
+// The file's lifetime is managed by the cache.
+type nopCloser struct{}
+
+func (self nopCloser) Close() error {
+	return nil
+}
+
+func GetHiberfilImage(full_path *accessors.OSPath, scope vfilter.Scope) (
+	zip.ReaderStat, error) {
+
+	pathspec := full_path.PathSpec()
+
+	// If a delegate is not provided we use the "auto" accessor to
+	// open the file and expose physical memory.
+	if pathspec.DelegateAccessor == "" && pathspec.GetDelegatePath() == "" {
+		pathspec.DelegatePath = pathspec.Path
+		pathspec.DelegateAccessor = "auto"
+		pathspec.Path = "/"
+		full_path.SetPathSpec(pathspec)
+	}
+
+	err := vql_subsystem.CheckFilesystemAccess(scope, pathspec.DelegateAccessor)
+	if err != nil {
+		scope.Log("%v: DelegateAccessor denied", err)
+		return nil, err
+	}
+
+	accessor, err := accessors.GetAccessor(pathspec.DelegateAccessor, scope)
+	if err != nil {
+		scope.Log("hiberfil: %v: did you provide a DelegateAccessor PathSpec?", err)
+		return nil, err
+	}
+
+	hiberfil, err := getCachedHibernationFile(full_path, accessor, scope)
+	if err != nil {
+		return nil, fmt.Errorf("hiberfil: %v: %w",
+			pathspec.GetDelegatePath(), err)
+	}
+
+	space, err := lime.GetAddressSpace(hiberfil, full_path)
+	if err != nil {
+		return nil, fmt.Errorf("hiberfil: %w", err)
+	}
+
+	return lime.NewMemoryReader(space, nopCloser{}, full_path), nil
+}
+
+func init() {
+	accessors.Register("hiberfil", zip.NewGzipFileSystemAccessor(
+		accessors.MustNewLinuxOSPath(""), GetHiberfilImage),
+		`Access the physical memory stored in a Windows hibernation file.
+
+Windows 8 and later hibernation files are supported. Pages missing
+from the file are presented as sparse. As with the "lime" accessor, if
+the Path is a DTB (a process's DirectoryTableBase) the user space
+virtual address space of that process is presented instead.
+
+Note that Windows clears the file's header when it resumes so only
+files collected from a hibernated (or offline) system can be read.
+
+For Example, scan physical memory in a hibernation file collected
+from a disk image:
+
+    SELECT * FROM yara(
+       rules=MyRules, accessor="hiberfil",
+       files=pathspec(
+          DelegateAccessor="file",
+          DelegatePath="/cases/hiberfil.sys",
+          Path="/"))
+`)
+}
@@ -0,0 +1,109 @@
+package hiberfil
+
+import (
+	"sync"
+
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	HIBERFIL_CACHE_TAG = "__HIBERFIL_CACHE"
+)
+
+type cachedFile struct {
+	hiberfil *HibernationFile
+	closer   func()
+}
+
+// Walking the compression sets is expensive so we keep the parsed
+// file until the end of the query.
+type hiberfilCache struct {
+	mu sync.Mutex
+
+	cache map[string]*cachedFile
+}
+
+func (self *hiberfilCache) Get(key string) (*cachedFile, bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	r, pres := self.cache[key]
+	return r, pres
+}
+
+func (self *hiberfilCache) Set(key string, r *cachedFile) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.cache[key] = r
+}
+
+func (self *hiberfilCache) Close() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	for _, r := range self.cache {
+		r.closer()
+	}
+}
+
+func getCachedHibernationFile(
+	full_path *accessors.OSPath,
+	accessor accessors.FileSystemAccessor,
+	scope vfilter.Scope) (*HibernationFile, error) {
+
+	cache, pres := vql_subsystem.CacheGet(scope, HIBERFIL_CACHE_TAG).(*hiberfilCache)
+	if !pres {
+		cache = &hiberfilCache{
+			cache: make(map[string]*cachedFile),
+		}
+		// Cache will remain alive for the duration of the query.
+		vql_subsystem.GetRootScope(scope).AddDestructor(cache.Close)
+		vql_subsystem.CacheSet(scope, HIBERFIL_CACHE_TAG, cache)
+	}
+
+	delegate, err := full_path.Delegate(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same file may be opened with different DTBs.
+	pathspec := full_path.PathSpec()
+	key := pathspec.DelegateAccessor + ":" + delegate.String()
+	res, pres := cache.Get(key)
+	if pres {
+		return res.hiberfil, nil
+	}
+
+	fd, err := accessor.OpenWithOSPath(delegate)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := accessor.LstatWithOSPath(delegate)
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+
+	hiberfil, err := NewHibernationFile(utils.MakeReaderAtter(fd), stat.Size())
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+
+	cache.Set(key, &cachedFile{
+		hiberfil: hiberfil,
+		closer: func() {
+			scope.Log("hiberfil: Closing hibernation file %v\n", key)
+			fd.Close()
+		},
+	})
+	scope.Log("hiberfil: Opened hibernation file %v (%v)\n",
+		key, hiberfil.Layout())
+
+	return hiberfil, nil
+}
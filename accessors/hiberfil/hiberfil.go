@@ -0,0 +1,379 @@
+package hiberfil
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	prefetch "www.velocidex.com/golang/go-prefetch"
+	"www.velocidex.com/golang/velociraptor/uploads"
+)
+
+const (
+	PAGE_SIZE = 0x1000
+
+	// The PO_MEMORY_IMAGE header occupies the first page.
+	HEADER_SIZE = PAGE_SIZE
+
+	NUM_PAGES_FOR_LOADER_OFFSET = 0x58
+
+	COMPRESSION_SET_HEADER_SIZE = 4
+	PAGE_DESCRIPTOR_SIZE        = 8
+	MAX_PAGE_DESCRIPTORS        = 16
+	MAX_PAGES_PER_DESCRIPTOR    = 16
+
+	// Sanity limit on the number of pages (16TB)
+	MAX_PAGES = 1 << 32
+)
+
+// The offsets of the restore page fields in the PO_MEMORY_IMAGE
+// header differ between Windows versions. The header does not
+// record its version so we try each layout in turn.
+type headerLayout struct {
+	Name                   string
+	FirstBootRestorePage   int
+	FirstKernelRestorePage int
+	KernelPagesProcessed   int
+}
+
+var (
+	headerLayouts = []headerLayout{
+		{"Windows 10 1703+", 0x68, 0x70, 0x230},
+		{"Windows 10 1607", 0x68, 0x70, 0x220},
+		{"Windows 10 1507", 0x68, 0x70, 0x218},
+		{"Windows 8", 0x60, 0x68, 0x1c8},
+	}
+)
+
+// A compression set holds up to 16 runs of pages compressed
+// together.
+type compressionSet struct {
+	// File offset of the compressed data
+	offset int64
+	size   int64
+	pages  int64
+
+	compressed bool
+	huffman    bool
+}
+
+// A run of consecutive physical pages stored in a compression set.
+type pageRun struct {
+	physical_page int64
+	pages         int64
+
+	set *compressionSet
+
+	// The index of the run's first page in the decompressed set.
+	set_page int64
+}
+
+// The physical memory stored in a Windows 8+ hibernation file.
+type HibernationFile struct {
+	reader io.ReaderAt
+	layout string
+
+	// Sorted by physical page.
+	runs []*pageRun
+
+	// Cache the last decompressed set since reads are usually
+	// sequential.
+	mu       sync.Mutex
+	last_set *compressionSet
+	last     []byte
+}
+
+func NewHibernationFile(reader io.ReaderAt, size int64) (*HibernationFile, error) {
+	header := make([]byte, HEADER_SIZE)
+	n, err := reader.ReadAt(header, 0)
+	if n < HEADER_SIZE {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	signature := string(header[:4])
+	if strings.ToUpper(signature) != "HIBR" {
+		// Windows clears the header when it resumes.
+		if strings.ToUpper(signature) == "WAKE" ||
+			binary.LittleEndian.Uint32(header) == 0 {
+			return nil, errors.New(
+				"Hibernation file has been resumed and contains no image")
+		}
+		return nil, fmt.Errorf("Invalid hibernation file signature %q", signature)
+	}
+
+	loader_pages := int64(binary.LittleEndian.Uint64(
+		header[NUM_PAGES_FOR_LOADER_OFFSET:]))
+
+	errs := []string{}
+	for _, layout := range headerLayouts {
+		first_boot := int64(binary.LittleEndian.Uint64(
+			header[layout.FirstBootRestorePage:]))
+		first_kernel := int64(binary.LittleEndian.Uint64(
+			header[layout.FirstKernelRestorePage:]))
+		kernel_pages := int64(binary.LittleEndian.Uint64(
+			header[layout.KernelPagesProcessed:]))
+		if kernel_pages == 0 {
+			errs = append(errs, fmt.Sprintf("%v: No kernel pages", layout.Name))
+			continue
+		}
+
+		boot_runs, err := readCompressionSets(
+			reader, size, first_boot, loader_pages)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%v: %v", layout.Name, err))
+			continue
+		}
+
+		kernel_runs, err := readCompressionSets(
+			reader, size, first_kernel, kernel_pages)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%v: %v", layout.Name, err))
+			continue
+		}
+
+		runs := append(boot_runs, kernel_runs...)
+		sort.SliceStable(runs, func(i, j int) bool {
+			return runs[i].physical_page < runs[j].physical_page
+		})
+
+		return &HibernationFile{
+			reader: reader,
+			layout: layout.Name,
+			runs:   runs,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("Unsupported hibernation file (%v)",
+		strings.Join(errs, ", "))
+}
+
+// Walk the compression sets starting at the page until the expected
+// number of pages are seen.
+func readCompressionSets(reader io.ReaderAt, size int64,
+	first_page int64, total_pages int64) ([]*pageRun, error) {
+	if total_pages < 0 || total_pages > MAX_PAGES {
+		return nil, fmt.Errorf("Invalid page count %v", total_pages)
+	}
+
+	if total_pages == 0 {
+		return nil, nil
+	}
+
+	offset := first_page * PAGE_SIZE
+	if first_page <= 0 || offset >= size {
+		return nil, fmt.Errorf("Invalid restore page %#x", first_page)
+	}
+
+	result := []*pageRun{}
+	header := make([]byte, COMPRESSION_SET_HEADER_SIZE)
+	descriptors := make([]byte, MAX_PAGE_DESCRIPTORS*PAGE_DESCRIPTOR_SIZE)
+
+	for seen := int64(0); seen < total_pages; {
+		n, err := reader.ReadAt(header, offset)
+		if n < len(header) {
+			return nil, fmt.Errorf("Truncated compression set at %#x: %v",
+				offset, err)
+		}
+
+		value := binary.LittleEndian.Uint32(header)
+		count := int(value & 0xff)
+		if count == 0 || count > MAX_PAGE_DESCRIPTORS {
+			return nil, fmt.Errorf("Invalid compression set at %#x", offset)
+		}
+
+		set := &compressionSet{
+			offset: offset + COMPRESSION_SET_HEADER_SIZE +
+				int64(count*PAGE_DESCRIPTOR_SIZE),
+			size:    int64((value >> 8) & 0x3fffff),
+			huffman: value&(1<<30) != 0,
+		}
+
+		n, err = reader.ReadAt(descriptors[:count*PAGE_DESCRIPTOR_SIZE],
+			offset+COMPRESSION_SET_HEADER_SIZE)
+		if n < count*PAGE_DESCRIPTOR_SIZE {
+			return nil, fmt.Errorf("Truncated compression set at %#x: %v",
+				offset, err)
+		}
+
+		for i := 0; i < count; i++ {
+			descriptor := binary.LittleEndian.Uint64(
+				descriptors[i*PAGE_DESCRIPTOR_SIZE:])
+			run := &pageRun{
+				physical_page: int64(descriptor >> 4),
+				pages:         int64(descriptor&0xf) + 1,
+				set:           set,
+				set_page:      set.pages,
+			}
+			set.pages += run.pages
+			result = append(result, run)
+		}
+
+		uncompressed_size := set.pages * PAGE_SIZE
+		if set.size == 0 || set.size > uncompressed_size ||
+			set.offset+set.size > size {
+			return nil, fmt.Errorf("Invalid compression set at %#x", offset)
+		}
+		set.compressed = set.size != uncompressed_size
+
+		seen += set.pages
+		offset = set.offset + set.size
+	}
+
+	return result, nil
+}
+
+// The header layout that was detected.
+func (self *HibernationFile) Layout() string {
+	return self.layout
+}
+
+// Find the run containing the page, or the next run after it.
+func (self *HibernationFile) findRun(page int64) (*pageRun, bool) {
+	idx := sort.Search(len(self.runs), func(i int) bool {
+		run := self.runs[i]
+		return run.physical_page+run.pages > page
+	})
+	if idx >= len(self.runs) {
+		return nil, false
+	}
+
+	run := self.runs[idx]
+	return run, run.physical_page <= page
+}
+
+func (self *HibernationFile) decompress(set *compressionSet) (
+	result []byte, err error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.last_set == set {
+		return self.last, nil
+	}
+
+	// The decompressors may panic on corrupt data.
+	defer func() {
+		r := recover()
+		if r != nil {
+			result = nil
+			err = fmt.Errorf("Decompression failed at %#x: %v", set.offset, r)
+		}
+	}()
+
+	data := make([]byte, set.size)
+	n, err := self.reader.ReadAt(data, set.offset)
+	if n < len(data) {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	uncompressed_size := int(set.pages * PAGE_SIZE)
+	if set.compressed {
+		if set.huffman {
+			data, err = prefetch.LZXpressHuffmanDecompressWithFallback(
+				data, uncompressed_size)
+		} else {
+			data, err = XpressDecompress(data, uncompressed_size)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Pad short output.
+	if len(data) < uncompressed_size {
+		data = append(data, make([]byte, uncompressed_size-len(data))...)
+	}
+
+	self.last_set = set
+	self.last = data
+
+	return data, nil
+}
+
+// Reads from pages that are not in the file return zeros.
+func (self *HibernationFile) ReadAt(buf []byte, offset int64) (int, error) {
+	size := self.Size()
+	if offset < 0 || offset >= size {
+		return 0, io.EOF
+	}
+
+	result := 0
+	for result < len(buf) && offset < size {
+		page := offset / PAGE_SIZE
+		to_read := PAGE_SIZE - int(offset%PAGE_SIZE)
+		if to_read > len(buf)-result {
+			to_read = len(buf) - result
+		}
+		chunk := buf[result : result+to_read]
+
+		var data []byte
+		run, inside := self.findRun(page)
+		if inside {
+			set_data, err := self.decompress(run.set)
+			if err == nil {
+				start := (run.set_page+page-run.physical_page)*PAGE_SIZE +
+					offset%PAGE_SIZE
+				data = set_data[start:]
+			}
+		}
+
+		if data != nil {
+			copy(chunk, data)
+		} else {
+			for i := range chunk {
+				chunk[i] = 0
+			}
+		}
+
+		result += to_read
+		offset += int64(to_read)
+	}
+
+	if result < len(buf) {
+		return result, io.EOF
+	}
+	return result, nil
+}
+
+// The end of the highest physical page.
+func (self *HibernationFile) Size() int64 {
+	if len(self.runs) == 0 {
+		return 0
+	}
+	last := self.runs[len(self.runs)-1]
+	return (last.physical_page + last.pages) * PAGE_SIZE
+}
+
+func (self *HibernationFile) Ranges() []*uploads.Range {
+	result := []*uploads.Range{}
+	for _, run := range self.runs {
+		offset := run.physical_page * PAGE_SIZE
+		length := run.pages * PAGE_SIZE
+
+		if len(result) > 0 {
+			last := result[len(result)-1]
+			end := last.Offset + last.Length
+			if offset <= end {
+				if offset+length > end {
+					last.Length = offset + length - last.Offset
+				}
+				continue
+			}
+		}
+
+		result = append(result, &uploads.Range{
+			Offset: offset,
+			Length: length,
+		})
+	}
+	return result
+}
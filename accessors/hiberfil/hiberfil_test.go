@@ -0,0 +1,239 @@
+package hiberfil
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/uploads"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+func TestXpressDecompress(t *testing.T) {
+	// Examples from MS-XCA section 3.1
+	data, err := XpressDecompress([]byte(
+		"\x3f\x00\x00\x00abcdefghijklmnopqrstuvwxyz"), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, "abcdefghijklmnopqrstuvwxyz", string(data))
+
+	data, err = XpressDecompress([]byte(
+		"\xff\xff\xff\x1fabc\x17\x00\x0f\xff\x26\x01"), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("abc", 100), string(data))
+
+	// Matches before the start of the output are rejected.
+	_, err = XpressDecompress([]byte("\x00\x00\x00\x80\x17\x00"), 1000)
+	assert.Error(t, err)
+}
+
+// A simple compressor which encodes runs of repeated bytes as matches.
+func xpressCompress(data []byte) []byte {
+	out := []byte{}
+	flags_offset := 0
+	flag_count := 0
+	half_byte := -1
+
+	addFlag := func(bit uint32) {
+		if flag_count == 0 {
+			flags_offset = len(out)
+			out = append(out, 0, 0, 0, 0)
+			flag_count = 32
+		}
+		flag_count--
+		flags := binary.LittleEndian.Uint32(out[flags_offset:])
+		binary.LittleEndian.PutUint32(out[flags_offset:], flags|bit<<flag_count)
+	}
+
+	for i := 0; i < len(data); {
+		run := 0
+		for i > 0 && i+run < len(data) && run < 0xffff &&
+			data[i+run] == data[i-1] {
+			run++
+		}
+
+		if run < 3 {
+			addFlag(0)
+			out = append(out, data[i])
+			i++
+			continue
+		}
+
+		// A match with offset 1
+		addFlag(1)
+		length := run - 3
+		if length < 7 {
+			out = append(out, byte(length), 0)
+
+		} else {
+			out = append(out, 7, 0)
+			nibble := length - 7
+			if nibble > 15 {
+				nibble = 15
+			}
+
+			if half_byte < 0 {
+				half_byte = len(out)
+				out = append(out, byte(nibble))
+			} else {
+				out[half_byte] |= byte(nibble << 4)
+				half_byte = -1
+			}
+
+			if length-7 >= 15 {
+				out = append(out, 255)
+				out = binary.LittleEndian.AppendUint16(out, uint16(length))
+			}
+		}
+		i += run
+	}
+
+	// Mark the end of the stream.
+	addFlag(1)
+	return out
+}
+
+func page(text string) []byte {
+	result := make([]byte, PAGE_SIZE)
+	copy(result, text)
+	return result
+}
+
+type testRun struct {
+	physical_page uint64
+	pages         []string
+}
+
+func buildCompressionSet(compress bool, runs ...testRun) []byte {
+	descriptors := []byte{}
+	data := []byte{}
+	for _, run := range runs {
+		descriptors = binary.LittleEndian.AppendUint64(descriptors,
+			run.physical_page<<4|uint64(len(run.pages)-1))
+		for _, text := range run.pages {
+			data = append(data, page(text)...)
+		}
+	}
+
+	if compress {
+		data = xpressCompress(data)
+	}
+
+	header := uint32(len(runs)) | uint32(len(data))<<8
+	result := binary.LittleEndian.AppendUint32(nil, header)
+	result = append(result, descriptors...)
+	return append(result, data...)
+}
+
+func buildHiberfil(layout headerLayout) []byte {
+	header := make([]byte, HEADER_SIZE)
+	copy(header, "HIBR")
+	binary.LittleEndian.PutUint64(header[NUM_PAGES_FOR_LOADER_OFFSET:], 1)
+	binary.LittleEndian.PutUint64(header[layout.FirstBootRestorePage:], 1)
+	binary.LittleEndian.PutUint64(header[layout.FirstKernelRestorePage:], 3)
+	binary.LittleEndian.PutUint64(header[layout.KernelPagesProcessed:], 4)
+
+	// The uncompressed boot set is larger than a page so the kernel
+	// sets start at page 3.
+	boot := buildCompressionSet(false, testRun{0x10, []string{"loader page"}})
+	boot = append(boot, make([]byte, 2*PAGE_SIZE-len(boot))...)
+
+	kernel := buildCompressionSet(true,
+		testRun{0x20, []string{"page 0x20"}},
+		testRun{0x30, []string{"page 0x30", "page 0x31"}})
+	kernel = append(kernel, buildCompressionSet(false,
+		testRun{0x40, []string{"uncompressed page"}})...)
+
+	result := append(header, boot...)
+	return append(result, kernel...)
+}
+
+func openHiberfil(t *testing.T, image []byte, cb func(fd accessors.ReadSeekCloser)) {
+	tempfile, err := ioutil.TempFile("", "hiberfil")
+	assert.NoError(t, err)
+	defer os.Remove(tempfile.Name())
+
+	_, err = tempfile.Write(image)
+	assert.NoError(t, err)
+	tempfile.Close()
+
+	// Closing the scope closes the cached file.
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	accessor, err := accessors.GetAccessor("hiberfil", scope)
+	assert.NoError(t, err)
+
+	pathspec := &accessors.PathSpec{
+		DelegateAccessor: "file",
+		DelegatePath:     tempfile.Name(),
+		Path:             "/",
+	}
+
+	fd, err := accessor.Open(pathspec.String())
+	assert.NoError(t, err)
+	defer fd.Close()
+
+	cb(fd)
+}
+
+func readAt(t *testing.T, fd accessors.ReadSeekCloser, offset int64, size int) string {
+	_, err := fd.Seek(offset, os.SEEK_SET)
+	assert.NoError(t, err)
+
+	buf := make([]byte, size)
+	n, err := fd.Read(buf)
+	assert.NoError(t, err)
+	return strings.TrimRight(string(buf[:n]), "\x00")
+}
+
+func TestHiberfil(t *testing.T) {
+	for _, layout := range []headerLayout{headerLayouts[0], headerLayouts[3]} {
+		openHiberfil(t, buildHiberfil(layout), func(fd accessors.ReadSeekCloser) {
+			assert.Equal(t, "loader page", readAt(t, fd, 0x10000, 20))
+			assert.Equal(t, "page 0x20", readAt(t, fd, 0x20000, 20))
+			assert.Equal(t, "page 0x30", readAt(t, fd, 0x30000, 20))
+			assert.Equal(t, "page 0x31", readAt(t, fd, 0x31000, 20))
+			assert.Equal(t, "uncompressed page", readAt(t, fd, 0x40000, 20))
+			assert.Equal(t, "", readAt(t, fd, 0x38000, 20))
+
+			assert.Equal(t, []uploads.Range{
+				{Offset: 0, Length: 0x10000, IsSparse: true},
+				{Offset: 0x10000, Length: 0x1000},
+				{Offset: 0x11000, Length: 0xf000, IsSparse: true},
+				{Offset: 0x20000, Length: 0x1000},
+				{Offset: 0x21000, Length: 0xf000, IsSparse: true},
+				{Offset: 0x30000, Length: 0x2000},
+				{Offset: 0x32000, Length: 0xe000, IsSparse: true},
+				{Offset: 0x40000, Length: 0x1000},
+			}, fd.(uploads.RangeReader).Ranges())
+
+			// Reads stop at the end of the last page.
+			_, err := fd.Seek(0x40000, os.SEEK_SET)
+			assert.NoError(t, err)
+
+			data, err := ioutil.ReadAll(fd)
+			assert.NoError(t, err)
+			assert.Equal(t, PAGE_SIZE, len(data))
+		})
+	}
+}
+
+func TestResumedHiberfil(t *testing.T) {
+	image := buildHiberfil(headerLayouts[0])
+	copy(image, "wake")
+
+	_, err := NewHibernationFile(
+		&utils.BufferReaderAt{Buffer: image}, int64(len(image)))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "resumed")
+}
@@ -0,0 +1,116 @@
+package hiberfil
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	xpressCorruptError = errors.New("Corrupt Xpress stream")
+)
+
+// Decompress a plain LZ77 Xpress stream (MS-XCA section 2.4). The
+// output is at most output_size bytes.
+func XpressDecompress(input []byte, output_size int) ([]byte, error) {
+	output := make([]byte, 0, output_size)
+
+	flags := uint32(0)
+	flag_count := 0
+	in_idx := 0
+	last_length_half_byte := 0
+
+	for len(output) < output_size {
+		if flag_count == 0 {
+			if in_idx+4 > len(input) {
+				break
+			}
+			flags = binary.LittleEndian.Uint32(input[in_idx:])
+			in_idx += 4
+			flag_count = 32
+		}
+		flag_count--
+
+		// A literal byte.
+		if flags&(1<<flag_count) == 0 {
+			if in_idx >= len(input) {
+				break
+			}
+			output = append(output, input[in_idx])
+			in_idx++
+			continue
+		}
+
+		// The end of the stream is marked by a match flag with no
+		// more input.
+		if in_idx == len(input) {
+			break
+		}
+		if in_idx+2 > len(input) {
+			return output, xpressCorruptError
+		}
+
+		match := int(binary.LittleEndian.Uint16(input[in_idx:]))
+		in_idx += 2
+
+		length := match % 8
+		offset := match/8 + 1
+
+		if length == 7 {
+			// Extended lengths share a byte between two matches.
+			if last_length_half_byte == 0 {
+				if in_idx >= len(input) {
+					return output, xpressCorruptError
+				}
+				length = int(input[in_idx] % 16)
+				last_length_half_byte = in_idx
+				in_idx++
+			} else {
+				length = int(input[last_length_half_byte] / 16)
+				last_length_half_byte = 0
+			}
+
+			if length == 15 {
+				if in_idx >= len(input) {
+					return output, xpressCorruptError
+				}
+				length = int(input[in_idx])
+				in_idx++
+
+				if length == 255 {
+					if in_idx+2 > len(input) {
+						return output, xpressCorruptError
+					}
+					length = int(binary.LittleEndian.Uint16(input[in_idx:]))
+					in_idx += 2
+
+					if length == 0 {
+						if in_idx+4 > len(input) {
+							return output, xpressCorruptError
+						}
+						length = int(binary.LittleEndian.Uint32(input[in_idx:]))
+						in_idx += 4
+					}
+
+					if length < 15+7 {
+						return output, xpressCorruptError
+					}
+					length -= 15 + 7
+				}
+				length += 15
+			}
+			length += 7
+		}
+		length += 3
+
+		if offset > len(output) {
+			return output, xpressCorruptError
+		}
+
+		// The match may overlap the output so copy byte by byte.
+		for i := 0; i < length && len(output) < output_size; i++ {
+			output = append(output, output[len(output)-offset])
+		}
+	}
+
+	return output, nil
+}
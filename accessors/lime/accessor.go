@@ -0,0 +1,216 @@
+// An accessor for Linux memory images (LiME or raw format).
+//
+// The image is presented as a sparse file of physical memory. When
+// the path contains a DTB (the physical address of a process's top
+// level page table), the process's virtual address space is
+// presented instead.
+
+package lime
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/accessors/zip"
+	"www.velocidex.com/golang/velociraptor/uploads"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+)
+
+// A physical or virtual address space. Reads from addresses that are
+// not present return zeros.
+type AddressSpace interface {
+	io.ReaderAt
+	Size() int64
+
+	// The ranges in the address space that are present.
+	Ranges() []*uploads.Range
+}
+
+// A sparse file over an address space.
+type MemoryReader struct {
+	mu     sync.Mutex
+	offset int64
+
+	space AddressSpace
+	info  accessors.FileInfo
+
+	// A file handle to the underlying image.
+	handle io.Closer
+}
+
+func NewMemoryReader(space AddressSpace, handle io.Closer,
+	full_path *accessors.OSPath) *MemoryReader {
+	return &MemoryReader{
+		space:  space,
+		handle: handle,
+		info: &accessors.VirtualFileInfo{
+			Path:  full_path,
+			Size_: space.Size(),
+		},
+	}
+}
+
+func (self *MemoryReader) Read(buf []byte) (int, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	n, err := self.space.ReadAt(buf, self.offset)
+	self.offset += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+func (self *MemoryReader) Seek(offset int64, whence int) (int64, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	switch whence {
+	case 0:
+		self.offset = offset
+	case 1:
+		self.offset += offset
+	case 2:
+		self.offset = self.space.Size()
+	}
+
+	return self.offset, nil
+}
+
+// Report the unmapped regions as sparse so uploads and yara scans
+// skip them.
+func (self *MemoryReader) Ranges() []uploads.Range {
+	result := []uploads.Range{}
+	size := int64(0)
+	for _, rng := range self.space.Ranges() {
+		// Fill in a sparse range if needed
+		if rng.Offset > size {
+			result = append(result, uploads.Range{
+				Offset:   size,
+				Length:   rng.Offset - size,
+				IsSparse: true,
+			})
+		}
+
+		// Move the pointer past the end of this range.
+		size = rng.Offset + rng.Length
+
+		// Add a real data run
+		result = append(result, *rng)
+	}
+	return result
+}
+
+func (self *MemoryReader) Close() error {
+	return self.handle.Close()
+}
+
+func (self *MemoryReader) LStat() (accessors.FileInfo, error) {
+	return self.info, nil
+}
+
+func GetLimeFile(full_path *accessors.OSPath, scope vfilter.Scope) (
+	zip.ReaderStat, error) {
+
+	pathspec := full_path.PathSpec()
+
+	// If a delegate is not provided we use the "auto" accessor to
+	// open the image and expose physical memory.
+	if pathspec.DelegateAccessor == "" && pathspec.GetDelegatePath() == "" {
+		pathspec.DelegatePath = pathspec.Path
+		pathspec.DelegateAccessor = "auto"
+		pathspec.Path = "/"
+		full_path.SetPathSpec(pathspec)
+	}
+
+	err := vql_subsystem.CheckFilesystemAccess(scope, pathspec.DelegateAccessor)
+	if err != nil {
+		scope.Log("%v: DelegateAccessor denied", err)
+		return nil, err
+	}
+
+	accessor, err := accessors.GetAccessor(pathspec.DelegateAccessor, scope)
+	if err != nil {
+		scope.Log("lime: %v: did you provide a DelegateAccessor PathSpec?", err)
+		return nil, err
+	}
+
+	delegate_path := pathspec.GetDelegatePath()
+	fd, err := accessor.Open(delegate_path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The size is only needed for raw images.
+	size := int64(0)
+	stat, err := accessor.Lstat(delegate_path)
+	if err == nil {
+		size = stat.Size()
+	}
+
+	reader := utils.MakeReaderAtter(fd)
+	segments, err := ParseSegments(reader, size)
+	if err != nil {
+		fd.Close()
+		return nil, fmt.Errorf("lime: %v: %w", delegate_path, err)
+	}
+
+	space, err := GetAddressSpace(
+		NewPhysicalAddressSpace(reader, segments), full_path)
+	if err != nil {
+		fd.Close()
+		return nil, fmt.Errorf("lime: %w", err)
+	}
+
+	return NewMemoryReader(space, fd, full_path), nil
+}
+
+// If the first component of the path is a DTB, return the virtual
+// address space it describes. Otherwise return the physical address
+// space.
+func GetAddressSpace(physical AddressSpace,
+	full_path *accessors.OSPath) (AddressSpace, error) {
+	if len(full_path.Components) == 0 {
+		return physical, nil
+	}
+
+	dtb, err := strconv.ParseUint(full_path.Components[0], 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Path should be a DTB: %w", err)
+	}
+
+	space, err := NewVirtualAddressSpace(physical, dtb)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read page tables at %#x: %w",
+			dtb, err)
+	}
+	return space, nil
+}
+
+func init() {
+	accessors.Register("lime", zip.NewGzipFileSystemAccessor(
+		accessors.MustNewLinuxOSPath(""), GetLimeFile),
+		`Access a Linux memory image in LiME or raw format.
+
+The image is presented as a sparse file of physical memory, where
+regions missing from the image are sparse. If the Path is a DTB (the
+physical address of a process's page tables, i.e. its CR3 value), the
+user space virtual address space of that process is presented
+instead. Only x86-64 4 level paging is supported.
+
+For Example, scan a process's memory in an image:
+
+    SELECT * FROM yara(
+       rules=MyRules, accessor="lime",
+       files=pathspec(
+          DelegateAccessor="file",
+          DelegatePath="/images/memory.lime",
+          Path="/0x1a2b3000"))
+`)
+}
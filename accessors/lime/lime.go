@@ -0,0 +1,215 @@
+package lime
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"www.velocidex.com/golang/velociraptor/uploads"
+)
+
+const (
+	// "EMiL" in little endian.
+	LIME_MAGIC       = 0x4C694D45
+	LIME_VERSION     = 1
+	LIME_HEADER_SIZE = 32
+
+	// "AVML" - AVML's snappy compressed format.
+	AVML_MAGIC = 0x4C4D5641
+
+	// Sanity limit on the number of ranges in an image.
+	MAX_SEGMENTS = 100000
+)
+
+// A run of physical memory stored in the image.
+type Segment struct {
+	PhysicalOffset int64
+	FileOffset     int64
+	Length         int64
+}
+
+// Parse the LiME range headers in the image. Images without a LiME
+// header are treated as raw dumps of physical memory starting at
+// address 0 (e.g. as produced by avml --format raw).
+func ParseSegments(reader io.ReaderAt, size int64) ([]*Segment, error) {
+	header := make([]byte, LIME_HEADER_SIZE)
+	n, err := reader.ReadAt(header, 0)
+	if n < 4 {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	switch binary.LittleEndian.Uint32(header) {
+	case LIME_MAGIC:
+		return parseLimeSegments(reader)
+
+	case AVML_MAGIC:
+		return nil, errors.New(
+			"AVML compressed images are not supported, convert them with avml-convert first")
+	}
+
+	if size <= 0 {
+		return nil, errors.New("Unable to determine the size of the raw image")
+	}
+
+	return []*Segment{{Length: size}}, nil
+}
+
+func parseLimeSegments(reader io.ReaderAt) ([]*Segment, error) {
+	result := []*Segment{}
+	header := make([]byte, LIME_HEADER_SIZE)
+	offset := int64(0)
+
+	for len(result) < MAX_SEGMENTS {
+		n, err := reader.ReadAt(header, offset)
+		if n < LIME_HEADER_SIZE {
+			break
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		if binary.LittleEndian.Uint32(header) != LIME_MAGIC {
+			// The image may be padded after the last range.
+			if len(result) > 0 {
+				break
+			}
+			return nil, errors.New("Not a LiME image")
+		}
+
+		version := binary.LittleEndian.Uint32(header[4:])
+		if version != LIME_VERSION {
+			return nil, fmt.Errorf("Unsupported LiME version %v", version)
+		}
+
+		// The end address is inclusive.
+		start := binary.LittleEndian.Uint64(header[8:])
+		end := binary.LittleEndian.Uint64(header[16:])
+		if end < start || end-start >= 1<<62 || start >= 1<<62 {
+			return nil, fmt.Errorf("Invalid LiME range %#x-%#x at %#x",
+				start, end, offset)
+		}
+
+		segment := &Segment{
+			PhysicalOffset: int64(start),
+			FileOffset:     offset + LIME_HEADER_SIZE,
+			Length:         int64(end-start) + 1,
+		}
+		result = append(result, segment)
+		offset = segment.FileOffset + segment.Length
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PhysicalOffset < result[j].PhysicalOffset
+	})
+
+	return result, nil
+}
+
+// The physical address space described by the segments. Addresses
+// that are not present in the image read as zeros.
+type PhysicalAddressSpace struct {
+	reader   io.ReaderAt
+	segments []*Segment
+}
+
+func NewPhysicalAddressSpace(
+	reader io.ReaderAt, segments []*Segment) *PhysicalAddressSpace {
+	return &PhysicalAddressSpace{
+		reader:   reader,
+		segments: segments,
+	}
+}
+
+// Find the segment containing the offset, or the next segment after
+// it.
+func (self *PhysicalAddressSpace) findSegment(offset int64) (*Segment, bool) {
+	idx := sort.Search(len(self.segments), func(i int) bool {
+		seg := self.segments[i]
+		return seg.PhysicalOffset+seg.Length > offset
+	})
+	if idx >= len(self.segments) {
+		return nil, false
+	}
+
+	seg := self.segments[idx]
+	return seg, seg.PhysicalOffset <= offset
+}
+
+func (self *PhysicalAddressSpace) ReadAt(buf []byte, offset int64) (int, error) {
+	size := self.Size()
+	if offset >= size {
+		return 0, io.EOF
+	}
+
+	result := 0
+	for result < len(buf) && offset < size {
+		seg, inside := self.findSegment(offset)
+		if seg == nil {
+			break
+		}
+
+		// Zero pad up to the next segment.
+		if !inside {
+			to_pad := seg.PhysicalOffset - offset
+			if to_pad > int64(len(buf)-result) {
+				to_pad = int64(len(buf) - result)
+			}
+			for i := int64(0); i < to_pad; i++ {
+				buf[result] = 0
+				result++
+			}
+			offset += to_pad
+			continue
+		}
+
+		to_read := seg.PhysicalOffset + seg.Length - offset
+		if to_read > int64(len(buf)-result) {
+			to_read = int64(len(buf) - result)
+		}
+
+		n, err := self.reader.ReadAt(buf[result:result+int(to_read)],
+			seg.FileOffset+offset-seg.PhysicalOffset)
+
+		// A truncated image - pad the rest of the segment.
+		for i := n; i < int(to_read); i++ {
+			buf[result+i] = 0
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return result + n, err
+		}
+
+		result += int(to_read)
+		offset += to_read
+	}
+
+	if result < len(buf) {
+		return result, io.EOF
+	}
+	return result, nil
+}
+
+// The end of the highest physical address in the image.
+func (self *PhysicalAddressSpace) Size() int64 {
+	if len(self.segments) == 0 {
+		return 0
+	}
+	last := self.segments[len(self.segments)-1]
+	return last.PhysicalOffset + last.Length
+}
+
+// The physical ranges present in the image.
+func (self *PhysicalAddressSpace) Ranges() []*uploads.Range {
+	result := make([]*uploads.Range, 0, len(self.segments))
+	for _, seg := range self.segments {
+		result = append(result, &uploads.Range{
+			Offset: seg.PhysicalOffset,
+			Length: seg.Length,
+		})
+	}
+	return result
+}
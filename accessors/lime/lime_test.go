@@ -0,0 +1,168 @@
+package lime
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/uploads"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+const (
+	testDTB = 0x1000
+)
+
+func limeRange(start int64, data []byte) []byte {
+	header := make([]byte, LIME_HEADER_SIZE)
+	binary.LittleEndian.PutUint32(header, LIME_MAGIC)
+	binary.LittleEndian.PutUint32(header[4:], LIME_VERSION)
+	binary.LittleEndian.PutUint64(header[8:], uint64(start))
+	binary.LittleEndian.PutUint64(header[16:], uint64(start)+uint64(len(data))-1)
+	return append(header, data...)
+}
+
+func setEntry(memory []byte, table, idx, value uint64) {
+	binary.LittleEndian.PutUint64(memory[table-testDTB+idx*8:], value)
+}
+
+// Builds an image with two ranges. The first range (0x1000-0x8000)
+// holds a set of page tables and some data pages:
+//
+//	0x10000 -> 0x5000
+//	0x11000 -> 0x7000
+//	0x13000 -> 0x6000
+//	0x200000 - 0x400000 -> 0x400000 (2mb page not in the image)
+func buildImage() []byte {
+	memory := make([]byte, 0x7000)
+	setEntry(memory, 0x1000, 0, 0x2000|PTE_PRESENT)
+	setEntry(memory, 0x2000, 0, 0x3000|PTE_PRESENT)
+	setEntry(memory, 0x3000, 0, 0x4000|PTE_PRESENT)
+	setEntry(memory, 0x3000, 1, 0x400000|PTE_PRESENT|PTE_PAGE_SIZE)
+	setEntry(memory, 0x4000, 0x10, 0x5000|PTE_PRESENT)
+	setEntry(memory, 0x4000, 0x11, 0x7000|PTE_PRESENT)
+	setEntry(memory, 0x4000, 0x12, 0x6000)
+	setEntry(memory, 0x4000, 0x13, 0x6000|PTE_PRESENT)
+
+	copy(memory[0x5000-testDTB:], "hello")
+	copy(memory[0x6000-testDTB-4:], "last")
+	copy(memory[0x6000-testDTB:], "third page")
+	copy(memory[0x7000-testDTB:], "second page")
+
+	image := limeRange(0x1000, memory)
+	return append(image, limeRange(0x100000, []byte("high memory"))...)
+}
+
+func writeImage(t *testing.T, image []byte) string {
+	tempfile, err := ioutil.TempFile("", "lime")
+	assert.NoError(t, err)
+	defer tempfile.Close()
+
+	_, err = tempfile.Write(image)
+	assert.NoError(t, err)
+
+	return tempfile.Name()
+}
+
+func openImage(t *testing.T, filename string, path string) accessors.ReadSeekCloser {
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	accessor, err := accessors.GetAccessor("lime", scope)
+	assert.NoError(t, err)
+
+	pathspec := &accessors.PathSpec{
+		DelegateAccessor: "file",
+		DelegatePath:     filename,
+		Path:             path,
+	}
+
+	fd, err := accessor.Open(pathspec.String())
+	assert.NoError(t, err)
+	return fd
+}
+
+func readAt(t *testing.T, fd accessors.ReadSeekCloser, offset int64, size int) string {
+	_, err := fd.Seek(offset, os.SEEK_SET)
+	assert.NoError(t, err)
+
+	buf := make([]byte, size)
+	n, err := fd.Read(buf)
+	assert.NoError(t, err)
+	return string(buf[:n])
+}
+
+func TestPhysicalAddressSpace(t *testing.T) {
+	filename := writeImage(t, buildImage())
+	defer os.Remove(filename)
+
+	fd := openImage(t, filename, "/")
+	defer fd.Close()
+
+	assert.Equal(t, "hello", readAt(t, fd, 0x5000, 5))
+	assert.Equal(t, "high memory", readAt(t, fd, 0x100000, 11))
+
+	// Reads across a gap are zero padded.
+	assert.Equal(t, "page\x00\x00", readAt(t, fd, 0x7007, 6))
+
+	assert.Equal(t, []uploads.Range{
+		{Offset: 0, Length: 0x1000, IsSparse: true},
+		{Offset: 0x1000, Length: 0x7000},
+		{Offset: 0x8000, Length: 0xf8000, IsSparse: true},
+		{Offset: 0x100000, Length: 11},
+	}, fd.(uploads.RangeReader).Ranges())
+
+	// Reading past the end of the image.
+	_, err := fd.Seek(0, os.SEEK_END)
+	assert.NoError(t, err)
+
+	data, err := ioutil.ReadAll(fd)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(data))
+}
+
+func TestVirtualAddressSpace(t *testing.T) {
+	filename := writeImage(t, buildImage())
+	defer os.Remove(filename)
+
+	fd := openImage(t, filename, "/0x1000")
+	defer fd.Close()
+
+	assert.Equal(t, "hello", readAt(t, fd, 0x10000, 5))
+	assert.Equal(t, "second page", readAt(t, fd, 0x11000, 11))
+	assert.Equal(t, "third page", readAt(t, fd, 0x13000, 10))
+
+	// Reads spanning pages are translated page by page.
+	assert.Equal(t, "lastse", readAt(t, fd, 0x10ffc, 6))
+	assert.Equal(t, "\x00\x00third", readAt(t, fd, 0x12ffe, 7))
+
+	// The 2mb page is mapped but not present in the image.
+	assert.Equal(t, "\x00\x00\x00\x00", readAt(t, fd, 0x200000, 4))
+
+	assert.Equal(t, []uploads.Range{
+		{Offset: 0, Length: 0x10000, IsSparse: true},
+		{Offset: 0x10000, Length: 0x2000},
+		{Offset: 0x12000, Length: 0x1000, IsSparse: true},
+		{Offset: 0x13000, Length: 0x1000},
+		{Offset: 0x14000, Length: 0x1ec000, IsSparse: true},
+		{Offset: 0x200000, Length: 0x200000},
+	}, fd.(uploads.RangeReader).Ranges())
+}
+
+func TestRawImage(t *testing.T) {
+	filename := writeImage(t, []byte("This is a raw memory image"))
+	defer os.Remove(filename)
+
+	fd := openImage(t, filename, "/")
+	defer fd.Close()
+
+	data, err := ioutil.ReadAll(fd)
+	assert.NoError(t, err)
+	assert.Equal(t, "This is a raw memory image", string(data))
+}
@@ -0,0 +1,250 @@
+package lime
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"www.velocidex.com/golang/velociraptor/uploads"
+)
+
+// x86-64 4 level paging.
+const (
+	PAGE_SIZE       = 0x1000
+	LARGE_PAGE_SIZE = 0x200000
+	HUGE_PAGE_SIZE  = 0x40000000
+
+	ENTRIES_PER_TABLE = 512
+
+	PTE_PRESENT   = 1
+	PTE_PAGE_SIZE = 1 << 7
+
+	ADDRESS_MASK    = 0x000ffffffffff000
+	LARGE_PAGE_MASK = 0x000fffffffe00000
+	HUGE_PAGE_MASK  = 0x000fffffc0000000
+
+	// We only expose the user half of the address space. Kernel
+	// addresses are sign extended and do not fit in a file offset.
+	USER_PML4_ENTRIES = 256
+)
+
+var (
+	notMappedError = errors.New("Address not mapped")
+)
+
+// A process's virtual address space, translated through its page
+// tables (DTB is the physical address of the PML4 table, i.e. the
+// value of CR3 when the process is running).
+type VirtualAddressSpace struct {
+	physical io.ReaderAt
+	dtb      uint64
+
+	// The mapped user space ranges in virtual address order.
+	ranges []*uploads.Range
+}
+
+func NewVirtualAddressSpace(
+	physical io.ReaderAt, dtb uint64) (*VirtualAddressSpace, error) {
+	self := &VirtualAddressSpace{
+		physical: physical,
+		// The low bits may contain the PCID.
+		dtb: dtb & ADDRESS_MASK,
+	}
+
+	err := self.walk()
+	if err != nil {
+		return nil, err
+	}
+
+	return self, nil
+}
+
+func (self *VirtualAddressSpace) readTable(address uint64) ([]uint64, error) {
+	buf := make([]byte, PAGE_SIZE)
+	n, err := self.physical.ReadAt(buf, int64(address&ADDRESS_MASK))
+	if n < PAGE_SIZE {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	result := make([]uint64, ENTRIES_PER_TABLE)
+	for i := range result {
+		result[i] = binary.LittleEndian.Uint64(buf[i*8:])
+	}
+	return result, nil
+}
+
+func (self *VirtualAddressSpace) readEntry(address uint64) (uint64, error) {
+	buf := make([]byte, 8)
+	n, err := self.physical.ReadAt(buf, int64(address))
+	if n < len(buf) {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+// Translate a virtual address to a physical address.
+func (self *VirtualAddressSpace) Translate(vaddr uint64) (uint64, error) {
+	table := self.dtb
+	for level, shift := range []uint{39, 30, 21, 12} {
+		idx := (vaddr >> shift) & (ENTRIES_PER_TABLE - 1)
+		entry, err := self.readEntry((table & ADDRESS_MASK) + idx*8)
+		if err != nil {
+			return 0, err
+		}
+
+		if entry&PTE_PRESENT == 0 {
+			return 0, notMappedError
+		}
+
+		switch {
+		case level == 1 && entry&PTE_PAGE_SIZE != 0:
+			return entry&HUGE_PAGE_MASK | vaddr&(HUGE_PAGE_SIZE-1), nil
+
+		case level == 2 && entry&PTE_PAGE_SIZE != 0:
+			return entry&LARGE_PAGE_MASK | vaddr&(LARGE_PAGE_SIZE-1), nil
+
+		case level == 3:
+			return entry&ADDRESS_MASK | vaddr&(PAGE_SIZE-1), nil
+		}
+
+		table = entry
+	}
+
+	return 0, notMappedError
+}
+
+func (self *VirtualAddressSpace) addRange(start, length uint64) {
+	if len(self.ranges) > 0 {
+		last := self.ranges[len(self.ranges)-1]
+		if uint64(last.Offset+last.Length) == start {
+			last.Length += int64(length)
+			return
+		}
+	}
+
+	self.ranges = append(self.ranges, &uploads.Range{
+		Offset: int64(start),
+		Length: int64(length),
+	})
+}
+
+// Walk the page tables to find all the mapped pages. Tables that can
+// not be read are skipped.
+func (self *VirtualAddressSpace) walk() error {
+	pml4, err := self.readTable(self.dtb)
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < USER_PML4_ENTRIES; i++ {
+		if pml4[i]&PTE_PRESENT == 0 {
+			continue
+		}
+
+		pdpt, err := self.readTable(pml4[i])
+		if err != nil {
+			continue
+		}
+
+		for j, pdpte := range pdpt {
+			if pdpte&PTE_PRESENT == 0 {
+				continue
+			}
+
+			pdpt_addr := i<<39 | uint64(j)<<30
+			if pdpte&PTE_PAGE_SIZE != 0 {
+				self.addRange(pdpt_addr, HUGE_PAGE_SIZE)
+				continue
+			}
+
+			pd, err := self.readTable(pdpte)
+			if err != nil {
+				continue
+			}
+
+			for k, pde := range pd {
+				if pde&PTE_PRESENT == 0 {
+					continue
+				}
+
+				pd_addr := pdpt_addr | uint64(k)<<21
+				if pde&PTE_PAGE_SIZE != 0 {
+					self.addRange(pd_addr, LARGE_PAGE_SIZE)
+					continue
+				}
+
+				pt, err := self.readTable(pde)
+				if err != nil {
+					continue
+				}
+
+				for l, pte := range pt {
+					if pte&PTE_PRESENT != 0 {
+						self.addRange(pd_addr|uint64(l)<<12, PAGE_SIZE)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reads from unmapped pages return zeros.
+func (self *VirtualAddressSpace) ReadAt(buf []byte, offset int64) (int, error) {
+	size := self.Size()
+	if offset < 0 || offset >= size {
+		return 0, io.EOF
+	}
+
+	result := 0
+	for result < len(buf) && offset < size {
+		to_read := PAGE_SIZE - int(offset%PAGE_SIZE)
+		if to_read > len(buf)-result {
+			to_read = len(buf) - result
+		}
+		chunk := buf[result : result+to_read]
+
+		paddr, err := self.Translate(uint64(offset))
+		if err == nil {
+			var n int
+			n, err = self.physical.ReadAt(chunk, int64(paddr))
+			if n == len(chunk) {
+				err = nil
+			}
+		}
+
+		if err != nil {
+			for i := range chunk {
+				chunk[i] = 0
+			}
+		}
+
+		result += to_read
+		offset += int64(to_read)
+	}
+
+	if result < len(buf) {
+		return result, io.EOF
+	}
+	return result, nil
+}
+
+// The end of the highest mapped user space address.
+func (self *VirtualAddressSpace) Size() int64 {
+	if len(self.ranges) == 0 {
+		return 0
+	}
+	last := self.ranges[len(self.ranges)-1]
+	return last.Offset + last.Length
+}
+
+func (self *VirtualAddressSpace) Ranges() []*uploads.Range {
+	return self.ranges
+}
@@ -0,0 +1,316 @@
+// An accessor for LUKS1 and LUKS2 encrypted volumes.
+//
+// The volume key is recovered from a key slot using a passphrase or
+// key file and the decrypted device is presented as a single file
+// which other accessors (e.g. raw_ext4 or lvm) can parse.
+
+package luks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/accessors/zip"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/readers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	LUKS_CACHE_TAG = "__LUKS_CACHE"
+
+	// The largest key file cryptsetup reads by default.
+	LUKS_MAX_KEYFILE_SIZE = 8 * 1024 * 1024
+)
+
+type cachedVolume struct {
+	volume *Volume
+	closer func()
+}
+
+// Deriving the key is deliberately expensive so we keep unlocked
+// volumes until the end of the query.
+type luksCache struct {
+	mu sync.Mutex
+
+	cache map[string]*cachedVolume
+}
+
+func (self *luksCache) Close() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	for _, r := range self.cache {
+		r.closer()
+	}
+	self.cache = make(map[string]*cachedVolume)
+}
+
+// Get the secret holding the key material from the secrets service.
+func getSecret(scope vfilter.Scope, secret string) (
+	*ordereddict.Dict, error) {
+	config_obj, ok := vql_subsystem.GetServerConfig(scope)
+	if !ok {
+		return nil, errors.New("Secrets may only be used on the server")
+	}
+
+	secrets_service, err := services.GetSecretsService(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := vql_subsystem.GetPrincipal(scope)
+
+	// Extract the context from the scope.
+	ctx := context.TODO()
+
+	secret_record, err := secrets_service.GetSecret(ctx, principal,
+		constants.LUKS_KEY, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret_record.Data, nil
+}
+
+func readKeyFile(scope vfilter.Scope, accessor_name, filename string) (
+	[]byte, error) {
+	if accessor_name == "" {
+		accessor_name = "auto"
+	}
+
+	err := vql_subsystem.CheckFilesystemAccess(scope, accessor_name)
+	if err != nil {
+		return nil, err
+	}
+
+	accessor, err := accessors.GetAccessor(accessor_name, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := accessor.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return io.ReadAll(io.LimitReader(fd, LUKS_MAX_KEYFILE_SIZE))
+}
+
+// Collect the candidate keys for the volume. Key material is only
+// taken from a "LUKS Key" secret so it never appears in the query,
+// the collection parameters or the logs. The LUKS_CONFIG variable
+// names the secret. If it is not set we look for a secret named after
+// the volume's UUID.
+func getKeys(scope vfilter.Scope, uuid string) ([][]byte, error) {
+	secret := uuid
+	config, pres := scope.Resolve(constants.LUKS_CONFIG)
+	if pres {
+		secret = vql_subsystem.GetStringFromRow(scope, config, "secret")
+		if secret == "" {
+			return nil, fmt.Errorf(
+				"luks: %v must name a secret using 'LET %v <= dict(secret=...)'",
+				constants.LUKS_CONFIG, constants.LUKS_CONFIG)
+		}
+	}
+
+	setting, err := getSecret(scope, secret)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"luks: No key for volume %v: Configure a secret using 'LET %v <= dict(secret=...)': %w",
+			uuid, constants.LUKS_CONFIG, err)
+	}
+
+	var result [][]byte
+	passphrase := vql_subsystem.GetStringFromRow(scope, setting, "passphrase")
+	if passphrase != "" {
+		result = append(result, []byte(passphrase))
+	}
+
+	keyfile := vql_subsystem.GetStringFromRow(scope, setting, "keyfile")
+	if keyfile != "" {
+		data, err := readKeyFile(scope, vql_subsystem.GetStringFromRow(
+			scope, setting, "keyfile_accessor"), keyfile)
+		if err != nil {
+			return nil, fmt.Errorf("luks: Reading key file: %w", err)
+		}
+		result = append(result, data)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("luks: Secret %v holds no key", secret)
+	}
+
+	return result, nil
+}
+
+func getCachedVolume(full_path *accessors.OSPath, scope vfilter.Scope) (
+	*Volume, error) {
+	cache, pres := vql_subsystem.CacheGet(scope, LUKS_CACHE_TAG).(*luksCache)
+	if !pres {
+		cache = &luksCache{
+			cache: make(map[string]*cachedVolume),
+		}
+		// Cache will remain alive for the duration of the query.
+		vql_subsystem.GetRootScope(scope).AddDestructor(cache.Close)
+		vql_subsystem.CacheSet(scope, LUKS_CACHE_TAG, cache)
+	}
+
+	pathspec := full_path.PathSpec()
+	delegate, err := full_path.Delegate(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	key := pathspec.DelegateAccessor + ":" + delegate.String()
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	res, pres := cache.cache[key]
+	if pres {
+		return res.volume, nil
+	}
+
+	accessor, err := accessors.GetAccessor(pathspec.DelegateAccessor, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Devices are not always statable - the size is only needed
+	// when the segment extends to the end of the device.
+	var device_size int64
+	stat, err := accessor.LstatWithOSPath(delegate)
+	if err == nil {
+		device_size = stat.Size()
+	}
+
+	lru_size := vql_subsystem.GetIntFromRow(
+		scope, scope, constants.NTFS_CACHE_SIZE)
+	paged_reader, err := readers.NewAccessorReader(
+		scope, pathspec.DelegateAccessor, delegate, int(lru_size))
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := ParseHeader(paged_reader)
+	if err != nil {
+		paged_reader.Close()
+		return nil, err
+	}
+
+	keys, err := getKeys(scope, header.UUID)
+	if err != nil {
+		paged_reader.Close()
+		return nil, err
+	}
+
+	volume, err := NewVolume(paged_reader, device_size, keys)
+	if err != nil {
+		paged_reader.Close()
+		return nil, err
+	}
+
+	cache.cache[key] = &cachedVolume{
+		volume: volume,
+		closer: func() { paged_reader.Close() },
+	}
+	scope.Log("luks: Unlocked LUKS%v volume %v using key slot %v",
+		header.Version, header.UUID, volume.Keyslot)
+
+	return volume, nil
+}
+
+type volumeReader struct {
+	*utils.ReadSeekReaderAdapter
+	info accessors.FileInfo
+}
+
+func (self *volumeReader) LStat() (accessors.FileInfo, error) {
+	return self.info, nil
+}
+
+func GetLUKSDevice(full_path *accessors.OSPath, scope vfilter.Scope) (
+	zip.ReaderStat, error) {
+
+	pathspec := full_path.PathSpec()
+
+	// If a delegate is not provided we use the "auto" accessor to
+	// open the device.
+	if pathspec.DelegateAccessor == "" && pathspec.GetDelegatePath() == "" {
+		pathspec.DelegatePath = pathspec.Path
+		pathspec.DelegateAccessor = "auto"
+		pathspec.Path = "/"
+		full_path.SetPathSpec(pathspec)
+	}
+
+	err := vql_subsystem.CheckFilesystemAccess(scope, pathspec.DelegateAccessor)
+	if err != nil {
+		scope.Log("%v: DelegateAccessor denied", err)
+		return nil, err
+	}
+
+	volume, err := getCachedVolume(full_path, scope)
+	if err != nil {
+		return nil, fmt.Errorf("luks: %v: %w", pathspec.GetDelegatePath(), err)
+	}
+
+	header := volume.Header
+	return &volumeReader{
+		ReadSeekReaderAdapter: utils.NewReadSeekReaderAdapter(volume),
+		info: &accessors.VirtualFileInfo{
+			Path:  full_path,
+			Size_: volume.Size(),
+			Data_: ordereddict.NewDict().
+				Set("Version", header.Version).
+				Set("UUID", header.UUID).
+				Set("Label", header.Label).
+				Set("Cipher", header.Cipher).
+				Set("KeySize", header.KeySize*8).
+				Set("SectorSize", header.SectorSize).
+				Set("Keyslot", volume.Keyslot),
+		},
+	}, nil
+}
+
+func init() {
+	accessors.Register("luks", zip.NewGzipFileSystemAccessor(
+		accessors.MustNewLinuxOSPath(""), GetLUKSDevice),
+		`Access the decrypted device inside a LUKS1 or LUKS2 encrypted volume.
+
+The delegate is the encrypted volume (e.g. a partition of a disk image
+opened through the offset accessor). Key slots using PBKDF2, argon2i
+and argon2id are supported with the aes-xts-plain64 cipher (as well as
+the older aes-cbc-essiv:sha256).
+
+The passphrase or key file is taken from a "LUKS Key" secret managed
+by the secrets service so it does not appear in the query. Name the
+secret in the LUKS_CONFIG variable, otherwise the secret named after
+the volume's UUID is used.
+
+For Example:
+
+    LET LUKS_CONFIG <= dict(secret="LaptopPassphrase")
+
+    SELECT * FROM glob(globs="/etc/*", accessor="raw_ext4",
+       root=pathspec(
+          DelegateAccessor="luks",
+          Delegate=pathspec(
+             DelegateAccessor="offset",
+             Delegate=pathspec(
+                DelegateAccessor="file",
+                DelegatePath="/images/laptop.dd",
+                Path="/525336576"),
+             Path="/"),
+          Path="/"))
+`)
+}
@@ -0,0 +1,118 @@
+package luks
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/xts"
+)
+
+// Decrypts a single sector in place. The sector number is used to
+// derive the IV.
+type sectorCipher interface {
+	Decrypt(buf []byte, sector uint64) error
+}
+
+// Parse a dm-crypt cipher specification (e.g. aes-xts-plain64 or
+// aes-cbc-essiv:sha256).
+func newSectorCipher(spec string, key []byte) (sectorCipher, error) {
+	parts := strings.SplitN(spec, "-", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("luks: Unsupported cipher %v", spec)
+	}
+
+	if parts[0] != "aes" {
+		return nil, fmt.Errorf("luks: Unsupported cipher %v", spec)
+	}
+
+	iv_mode := parts[2]
+	switch parts[1] {
+	case "xts":
+		if iv_mode != "plain64" && iv_mode != "plain" {
+			return nil, fmt.Errorf("luks: Unsupported IV mode %v", spec)
+		}
+
+		c, err := xts.NewCipher(aes.NewCipher, key)
+		if err != nil {
+			return nil, fmt.Errorf("luks: %v: %w", spec, err)
+		}
+		return &xtsCipher{cipher: c, plain64: iv_mode == "plain64"}, nil
+
+	case "cbc":
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("luks: %v: %w", spec, err)
+		}
+
+		result := &cbcCipher{block: block, plain64: iv_mode == "plain64"}
+		switch {
+		case iv_mode == "plain64" || iv_mode == "plain":
+
+		case strings.HasPrefix(iv_mode, "essiv:"):
+			// The IV is the sector number encrypted with the hash
+			// of the key.
+			hasher, err := newHash(strings.TrimPrefix(iv_mode, "essiv:"))
+			if err != nil {
+				return nil, err
+			}
+			hasher.Write(key)
+
+			result.essiv, err = aes.NewCipher(hasher.Sum(nil))
+			if err != nil {
+				return nil, fmt.Errorf("luks: %v: %w", spec, err)
+			}
+
+		default:
+			return nil, fmt.Errorf("luks: Unsupported IV mode %v", spec)
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("luks: Unsupported cipher %v", spec)
+}
+
+type xtsCipher struct {
+	cipher  *xts.Cipher
+	plain64 bool
+}
+
+func (self *xtsCipher) Decrypt(buf []byte, sector uint64) error {
+	if len(buf)%aes.BlockSize != 0 {
+		return fmt.Errorf("luks: Invalid sector size %v", len(buf))
+	}
+
+	// The plain IV is truncated to 32 bits.
+	if !self.plain64 {
+		sector &= 0xffffffff
+	}
+	self.cipher.Decrypt(buf, buf, sector)
+	return nil
+}
+
+type cbcCipher struct {
+	block   cipher.Block
+	essiv   cipher.Block
+	plain64 bool
+}
+
+func (self *cbcCipher) Decrypt(buf []byte, sector uint64) error {
+	if len(buf)%aes.BlockSize != 0 {
+		return fmt.Errorf("luks: Invalid sector size %v", len(buf))
+	}
+
+	if self.essiv == nil && !self.plain64 {
+		sector &= 0xffffffff
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	binary.LittleEndian.PutUint64(iv, sector)
+	if self.essiv != nil {
+		self.essiv.Encrypt(iv, iv)
+	}
+
+	cipher.NewCBCDecrypter(self.block, iv).CryptBlocks(buf, buf)
+	return nil
+}
@@ -0,0 +1,542 @@
+package luks
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The on disk format is described in the LUKS1 On-Disk Format
+// Specification (version 1.2.3) and the LUKS2 On-Disk Format
+// Specification (version 1.1.1).
+const (
+	LUKS_MAGIC         = "LUKS\xba\xbe"
+	LUKS2_SECOND_MAGIC = "SKUL\xba\xbe"
+
+	LUKS_SECTOR_SIZE = 512
+
+	LUKS1_HEADER_SIZE    = 592
+	LUKS1_NUM_KEYS       = 8
+	LUKS1_KEYSLOT_SIZE   = 48
+	LUKS1_DIGEST_SIZE    = 20
+	LUKS1_SALT_SIZE      = 32
+	LUKS1_KEY_ENABLED    = 0x00AC71F3
+	LUKS1_KEYSLOT_OFFSET = 208
+
+	LUKS2_BINARY_HEADER_SIZE = 4096
+	LUKS2_CHECKSUM_OFFSET    = 448
+	LUKS2_CHECKSUM_SIZE      = 64
+
+	// The JSON area is at most 4mb.
+	LUKS2_MAX_HEADER_SIZE = 4 * 1024 * 1024
+
+	// Guard against corrupted headers.
+	LUKS_MAX_STRIPES  = 1024 * 1024
+	LUKS_MAX_KEY_SIZE = 512
+
+	// Limits on the KDF cost so a hostile header can not exhaust
+	// memory or CPU. The memory limit (in kb) is the cryptsetup
+	// maximum of 4gb.
+	LUKS_MAX_ARGON2_MEMORY     = 4 * 1024 * 1024
+	LUKS_MAX_ARGON2_TIME       = 1000
+	LUKS_MAX_PBKDF2_ITERATIONS = 100 * 1000 * 1000
+)
+
+// Possible locations of the secondary LUKS2 header.
+var luks2SecondaryOffsets = []int64{
+	0x4000, 0x8000, 0x10000, 0x20000, 0x40000,
+	0x80000, 0x100000, 0x200000, 0x400000,
+}
+
+type KDF struct {
+	Type string
+
+	// PBKDF2 parameters
+	Hash       string
+	Iterations int
+
+	// Argon2 parameters (memory in kb)
+	Time   uint32
+	Memory uint32
+	CPUs   uint8
+
+	Salt []byte
+}
+
+// A key slot holds a copy of the volume key, split by the anti
+// forensic splitter and encrypted with a key derived from the
+// passphrase.
+type Keyslot struct {
+	ID string
+
+	// Size of the volume key.
+	KeySize int
+
+	// Anti forensic splitter
+	Stripes int
+	AFHash  string
+
+	// The location of the encrypted key material.
+	AreaOffset     uint64
+	AreaSize       uint64
+	AreaCipher     string
+	AreaKeySize    int
+	AreaSectorSize int
+
+	KDF KDF
+}
+
+// Verifies a candidate volume key.
+type Digest struct {
+	Hash       string
+	Iterations int
+	Salt       []byte
+	Digest     []byte
+
+	Keyslots []string
+}
+
+func (self *Digest) Verify(key []byte) (bool, error) {
+	derived, err := pbkdf2Key(self.Hash, key, self.Salt,
+		self.Iterations, len(self.Digest))
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(derived, self.Digest) == 1, nil
+}
+
+func (self *Digest) Covers(keyslot string) bool {
+	for _, k := range self.Keyslots {
+		if k == keyslot {
+			return true
+		}
+	}
+	return false
+}
+
+// The common parts of LUKS1 and LUKS2 headers.
+type Header struct {
+	Version int
+	UUID    string
+	Label   string
+
+	// Describes the encrypted data segment.
+	Cipher     string
+	KeySize    int
+	Offset     uint64
+	SectorSize int
+	IVTweak    uint64
+
+	// Size of the segment in bytes. 0 means the segment extends to
+	// the end of the device.
+	Size uint64
+
+	Keyslots []*Keyslot
+	Digests  []*Digest
+}
+
+// Returns true if the device starts with a LUKS header.
+func IsLUKS(reader io.ReaderAt) bool {
+	buf := make([]byte, len(LUKS_MAGIC))
+	_, err := reader.ReadAt(buf, 0)
+	return err == nil && string(buf) == LUKS_MAGIC
+}
+
+func ParseHeader(reader io.ReaderAt) (*Header, error) {
+	buf := make([]byte, 8)
+	_, err := reader.ReadAt(buf, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case string(buf[:6]) == LUKS_MAGIC &&
+		binary.BigEndian.Uint16(buf[6:]) == 1:
+		return parseLUKS1(reader)
+
+	case string(buf[:6]) == LUKS_MAGIC &&
+		binary.BigEndian.Uint16(buf[6:]) == 2:
+		return parseLUKS2(reader)
+
+	case string(buf[:6]) == LUKS_MAGIC:
+		return nil, fmt.Errorf("luks: Unsupported version %v",
+			binary.BigEndian.Uint16(buf[6:]))
+	}
+
+	// A damaged primary header may still have a usable secondary
+	// LUKS2 header.
+	result, err := parseLUKS2(reader)
+	if err != nil {
+		return nil, errors.New("luks: No LUKS header found")
+	}
+	return result, nil
+}
+
+func cString(buf []byte) string {
+	idx := bytes.IndexByte(buf, 0)
+	if idx >= 0 {
+		buf = buf[:idx]
+	}
+	return string(buf)
+}
+
+func parseLUKS1(reader io.ReaderAt) (*Header, error) {
+	buf := make([]byte, LUKS1_HEADER_SIZE)
+	_, err := reader.ReadAt(buf, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	key_size := int(binary.BigEndian.Uint32(buf[108:]))
+	if key_size == 0 || key_size > LUKS_MAX_KEY_SIZE {
+		return nil, fmt.Errorf("luks: Invalid key size %v", key_size)
+	}
+
+	cipher := cString(buf[8:40]) + "-" + cString(buf[40:72])
+	hash := cString(buf[72:104])
+
+	result := &Header{
+		Version:    1,
+		UUID:       cString(buf[168:208]),
+		Cipher:     cipher,
+		KeySize:    key_size,
+		Offset:     uint64(binary.BigEndian.Uint32(buf[104:])) * LUKS_SECTOR_SIZE,
+		SectorSize: LUKS_SECTOR_SIZE,
+		Digests: []*Digest{{
+			Hash:       hash,
+			Iterations: int(binary.BigEndian.Uint32(buf[164:])),
+			Salt:       append([]byte{}, buf[132:132+LUKS1_SALT_SIZE]...),
+			Digest:     append([]byte{}, buf[112:112+LUKS1_DIGEST_SIZE]...),
+		}},
+	}
+
+	for i := 0; i < LUKS1_NUM_KEYS; i++ {
+		slot := buf[LUKS1_KEYSLOT_OFFSET+i*LUKS1_KEYSLOT_SIZE:]
+		if binary.BigEndian.Uint32(slot) != LUKS1_KEY_ENABLED {
+			continue
+		}
+
+		stripes := int(binary.BigEndian.Uint32(slot[44:]))
+		if stripes == 0 || stripes > LUKS_MAX_STRIPES {
+			continue
+		}
+
+		// LUKS1 does not record the size of the key material area
+		// but it must be before the payload.
+		area_offset := uint64(binary.BigEndian.Uint32(slot[40:])) * LUKS_SECTOR_SIZE
+		if area_offset >= result.Offset {
+			continue
+		}
+
+		id := strconv.Itoa(i)
+		result.Keyslots = append(result.Keyslots, &Keyslot{
+			ID:             id,
+			KeySize:        key_size,
+			Stripes:        stripes,
+			AFHash:         hash,
+			AreaOffset:     area_offset,
+			AreaSize:       result.Offset - area_offset,
+			AreaCipher:     cipher,
+			AreaKeySize:    key_size,
+			AreaSectorSize: LUKS_SECTOR_SIZE,
+			KDF: KDF{
+				Type:       "pbkdf2",
+				Hash:       hash,
+				Iterations: int(binary.BigEndian.Uint32(slot[4:])),
+				Salt:       append([]byte{}, slot[8:8+LUKS1_SALT_SIZE]...),
+			},
+		})
+		result.Digests[0].Keyslots = append(result.Digests[0].Keyslots, id)
+	}
+
+	return result, nil
+}
+
+// The LUKS2 JSON metadata. Large numbers are encoded as strings.
+type luks2Metadata struct {
+	Keyslots map[string]struct {
+		Type    string `json:"type"`
+		KeySize int    `json:"key_size"`
+		AF      struct {
+			Type    string `json:"type"`
+			Stripes int    `json:"stripes"`
+			Hash    string `json:"hash"`
+		} `json:"af"`
+		Area struct {
+			Type       string `json:"type"`
+			Offset     string `json:"offset"`
+			Size       string `json:"size"`
+			Encryption string `json:"encryption"`
+			KeySize    int    `json:"key_size"`
+		} `json:"area"`
+		KDF struct {
+			Type       string `json:"type"`
+			Hash       string `json:"hash"`
+			Iterations int    `json:"iterations"`
+			Time       uint32 `json:"time"`
+			Memory     uint32 `json:"memory"`
+			CPUs       uint8  `json:"cpus"`
+			Salt       []byte `json:"salt"`
+		} `json:"kdf"`
+	} `json:"keyslots"`
+
+	Segments map[string]struct {
+		Type       string `json:"type"`
+		Offset     string `json:"offset"`
+		Size       string `json:"size"`
+		IVTweak    string `json:"iv_tweak"`
+		Encryption string `json:"encryption"`
+		SectorSize int    `json:"sector_size"`
+	} `json:"segments"`
+
+	Digests map[string]struct {
+		Type       string   `json:"type"`
+		Keyslots   []string `json:"keyslots"`
+		Segments   []string `json:"segments"`
+		Hash       string   `json:"hash"`
+		Iterations int      `json:"iterations"`
+		Salt       []byte   `json:"salt"`
+		Digest     []byte   `json:"digest"`
+	} `json:"digests"`
+}
+
+type luks2Header struct {
+	seqid    uint64
+	label    string
+	uuid     string
+	metadata []byte
+}
+
+// Read and verify the binary header and JSON area at offset.
+func readLUKS2Header(reader io.ReaderAt, offset int64, magic string) (
+	*luks2Header, error) {
+	buf := make([]byte, LUKS2_BINARY_HEADER_SIZE)
+	_, err := reader.ReadAt(buf, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(buf[:6]) != magic || binary.BigEndian.Uint16(buf[6:]) != 2 {
+		return nil, errors.New("luks: Invalid LUKS2 header magic")
+	}
+
+	hdr_size := binary.BigEndian.Uint64(buf[8:])
+	if hdr_size <= LUKS2_BINARY_HEADER_SIZE || hdr_size > LUKS2_MAX_HEADER_SIZE {
+		return nil, fmt.Errorf("luks: Invalid LUKS2 header size %v", hdr_size)
+	}
+
+	if binary.BigEndian.Uint64(buf[256:]) != uint64(offset) {
+		return nil, errors.New("luks: LUKS2 header offset mismatch")
+	}
+
+	json_area := make([]byte, hdr_size-LUKS2_BINARY_HEADER_SIZE)
+	_, err = reader.ReadAt(json_area, offset+LUKS2_BINARY_HEADER_SIZE)
+	if err != nil {
+		return nil, err
+	}
+
+	// The checksum covers the entire header with the checksum field
+	// zeroed.
+	hasher, err := newHash(cString(buf[72:104]))
+	if err != nil {
+		return nil, err
+	}
+
+	expected := append([]byte{}, buf[LUKS2_CHECKSUM_OFFSET:][:hasher.Size()]...)
+	for i := 0; i < LUKS2_CHECKSUM_SIZE; i++ {
+		buf[LUKS2_CHECKSUM_OFFSET+i] = 0
+	}
+	hasher.Write(buf)
+	hasher.Write(json_area)
+	if !bytes.Equal(hasher.Sum(nil), expected) {
+		return nil, errors.New("luks: LUKS2 header checksum mismatch")
+	}
+
+	return &luks2Header{
+		seqid:    binary.BigEndian.Uint64(buf[16:]),
+		label:    cString(buf[24:72]),
+		uuid:     cString(buf[168:208]),
+		metadata: bytes.TrimRight(json_area, "\x00"),
+	}, nil
+}
+
+func parseLUKS2(reader io.ReaderAt) (*Header, error) {
+	// Use the most recent valid copy of the header.
+	header, primary_err := readLUKS2Header(reader, 0, LUKS_MAGIC)
+	for _, offset := range luks2SecondaryOffsets {
+		secondary, err := readLUKS2Header(reader, offset, LUKS2_SECOND_MAGIC)
+		if err != nil {
+			continue
+		}
+		if header == nil || secondary.seqid > header.seqid {
+			header = secondary
+		}
+	}
+
+	if header == nil {
+		return nil, primary_err
+	}
+
+	metadata := &luks2Metadata{}
+	err := json.Unmarshal(header.metadata, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("luks: Invalid LUKS2 metadata: %w", err)
+	}
+
+	result := &Header{
+		Version: 2,
+		UUID:    header.uuid,
+		Label:   header.label,
+	}
+
+	// We only support the first crypt segment.
+	var segment_ids []string
+	for id, segment := range metadata.Segments {
+		if segment.Type == "crypt" {
+			segment_ids = append(segment_ids, id)
+		}
+	}
+	if len(segment_ids) == 0 {
+		return nil, errors.New("luks: No crypt segment in LUKS2 metadata")
+	}
+	sortIds(segment_ids)
+	segment_id := segment_ids[0]
+	segment := metadata.Segments[segment_id]
+
+	result.Cipher = segment.Encryption
+	result.SectorSize = segment.SectorSize
+	result.Offset, err = strconv.ParseUint(segment.Offset, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("luks: Invalid segment offset: %w", err)
+	}
+
+	if segment.IVTweak != "" {
+		result.IVTweak, err = strconv.ParseUint(segment.IVTweak, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("luks: Invalid segment iv_tweak: %w", err)
+		}
+	}
+
+	if segment.Size != "dynamic" {
+		result.Size, err = strconv.ParseUint(segment.Size, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("luks: Invalid segment size: %w", err)
+		}
+	}
+
+	switch result.SectorSize {
+	case 512, 1024, 2048, 4096:
+	default:
+		return nil, fmt.Errorf("luks: Invalid sector size %v", result.SectorSize)
+	}
+
+	for _, digest := range metadata.Digests {
+		if digest.Type != "pbkdf2" || !stringIn(digest.Segments, segment_id) {
+			continue
+		}
+
+		result.Digests = append(result.Digests, &Digest{
+			Hash:       digest.Hash,
+			Iterations: digest.Iterations,
+			Salt:       digest.Salt,
+			Digest:     digest.Digest,
+			Keyslots:   digest.Keyslots,
+		})
+	}
+
+	for id, slot := range metadata.Keyslots {
+		if slot.Type != "luks2" || slot.AF.Type != "luks1" ||
+			slot.Area.Type != "raw" {
+			continue
+		}
+
+		if slot.KeySize <= 0 || slot.KeySize > LUKS_MAX_KEY_SIZE ||
+			slot.AF.Stripes <= 0 || slot.AF.Stripes > LUKS_MAX_STRIPES ||
+			slot.Area.KeySize <= 0 || slot.Area.KeySize > LUKS_MAX_KEY_SIZE {
+			continue
+		}
+
+		if slot.KDF.Memory > LUKS_MAX_ARGON2_MEMORY ||
+			slot.KDF.Time > LUKS_MAX_ARGON2_TIME ||
+			slot.KDF.Iterations > LUKS_MAX_PBKDF2_ITERATIONS {
+			continue
+		}
+
+		offset, err := strconv.ParseUint(slot.Area.Offset, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		area_size, err := strconv.ParseUint(slot.Area.Size, 10, 64)
+		if err != nil || uint64(slot.KeySize*slot.AF.Stripes) > area_size {
+			continue
+		}
+
+		result.Keyslots = append(result.Keyslots, &Keyslot{
+			ID:             id,
+			KeySize:        slot.KeySize,
+			Stripes:        slot.AF.Stripes,
+			AFHash:         slot.AF.Hash,
+			AreaOffset:     offset,
+			AreaSize:       area_size,
+			AreaCipher:     slot.Area.Encryption,
+			AreaKeySize:    slot.Area.KeySize,
+			AreaSectorSize: LUKS_SECTOR_SIZE,
+			KDF: KDF{
+				Type:       slot.KDF.Type,
+				Hash:       slot.KDF.Hash,
+				Iterations: slot.KDF.Iterations,
+				Time:       slot.KDF.Time,
+				Memory:     slot.KDF.Memory,
+				CPUs:       slot.KDF.CPUs,
+				Salt:       slot.KDF.Salt,
+			},
+		})
+	}
+
+	sort.Slice(result.Keyslots, func(i, j int) bool {
+		return idLess(result.Keyslots[i].ID, result.Keyslots[j].ID)
+	})
+
+	for _, digest := range result.Digests {
+		for _, slot := range result.Keyslots {
+			if digest.Covers(slot.ID) {
+				result.KeySize = slot.KeySize
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func stringIn(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// Object ids are decimal strings.
+func idLess(a, b string) bool {
+	a_int, a_err := strconv.Atoi(a)
+	b_int, b_err := strconv.Atoi(b)
+	if a_err != nil || b_err != nil {
+		return strings.Compare(a, b) < 0
+	}
+	return a_int < b_int
+}
+
+func sortIds(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		return idLess(ids[i], ids[j])
+	})
+}
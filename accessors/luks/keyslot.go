@@ -0,0 +1,216 @@
+package luks
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/ripemd160"
+)
+
+func newHash(name string) (hash.Hash, error) {
+	switch name {
+	case "sha1":
+		return sha1.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	case "sha384":
+		return sha512.New384(), nil
+	case "sha512":
+		return sha512.New(), nil
+	case "ripemd160":
+		return ripemd160.New(), nil
+	}
+	return nil, fmt.Errorf("luks: Unsupported hash %v", name)
+}
+
+func pbkdf2Key(hash_name string, password, salt []byte,
+	iterations, key_len int) ([]byte, error) {
+	// Check the hash is supported.
+	_, err := newHash(hash_name)
+	if err != nil {
+		return nil, err
+	}
+
+	if iterations <= 0 || iterations > LUKS_MAX_PBKDF2_ITERATIONS {
+		return nil, errors.New("luks: Invalid PBKDF2 iterations")
+	}
+
+	return pbkdf2.Key(password, salt, iterations, key_len, func() hash.Hash {
+		h, _ := newHash(hash_name)
+		return h
+	}), nil
+}
+
+// Derive the key which encrypts the key slot area from the
+// passphrase.
+func (self *KDF) DeriveKey(password []byte, key_len int) ([]byte, error) {
+	if key_len <= 0 || key_len > LUKS_MAX_KEY_SIZE {
+		return nil, fmt.Errorf("luks: Invalid key size %v", key_len)
+	}
+
+	switch self.Type {
+	case "pbkdf2":
+		return pbkdf2Key(self.Hash, password, self.Salt,
+			self.Iterations, key_len)
+
+	case "argon2i", "argon2id":
+		if self.Time == 0 || self.Memory == 0 || self.CPUs == 0 ||
+			self.Time > LUKS_MAX_ARGON2_TIME ||
+			self.Memory > LUKS_MAX_ARGON2_MEMORY {
+			return nil, errors.New("luks: Invalid argon2 parameters")
+		}
+
+		if self.Type == "argon2i" {
+			return argon2.Key(password, self.Salt, self.Time,
+				self.Memory, self.CPUs, uint32(key_len)), nil
+		}
+		return argon2.IDKey(password, self.Salt, self.Time,
+			self.Memory, self.CPUs, uint32(key_len)), nil
+	}
+
+	return nil, fmt.Errorf("luks: Unsupported KDF %v", self.Type)
+}
+
+// The anti forensic diffuser hashes each digest sized block of the
+// buffer, prefixed with its big endian block number.
+func diffuse(buf []byte, hash_name string) error {
+	hasher, err := newHash(hash_name)
+	if err != nil {
+		return err
+	}
+
+	digest_size := hasher.Size()
+	iv := make([]byte, 4)
+	for i := 0; i*digest_size < len(buf); i++ {
+		block := buf[i*digest_size:]
+		if len(block) > digest_size {
+			block = block[:digest_size]
+		}
+
+		hasher.Reset()
+		binary.BigEndian.PutUint32(iv, uint32(i))
+		hasher.Write(iv)
+		hasher.Write(block)
+		copy(block, hasher.Sum(nil))
+	}
+	return nil
+}
+
+// Recover the key from the anti forensic split material.
+func afMerge(material []byte, key_size, stripes int,
+	hash_name string) ([]byte, error) {
+	if len(material) < key_size*stripes {
+		return nil, errors.New("luks: Key material too short")
+	}
+
+	result := make([]byte, key_size)
+	for i := 0; i < stripes-1; i++ {
+		stripe := material[i*key_size:]
+		for j := 0; j < key_size; j++ {
+			result[j] ^= stripe[j]
+		}
+
+		err := diffuse(result, hash_name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	last := material[(stripes-1)*key_size:]
+	for j := 0; j < key_size; j++ {
+		result[j] ^= last[j]
+	}
+
+	return result, nil
+}
+
+// Try to recover the volume key from the key slot using the
+// passphrase. Returns nil if the passphrase does not unlock the key
+// slot.
+func (self *Header) unlockKeyslot(reader io.ReaderAt, slot *Keyslot,
+	passphrase []byte) ([]byte, error) {
+	// The key material is always a whole number of sectors.
+	size := slot.KeySize * slot.Stripes
+	sector_size := slot.AreaSectorSize
+	size = (size + sector_size - 1) / sector_size * sector_size
+	if uint64(size) > slot.AreaSize {
+		return nil, errors.New("luks: Key material is larger than the key slot area")
+	}
+
+	area_key, err := slot.KDF.DeriveKey(passphrase, slot.AreaKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	cipher, err := newSectorCipher(slot.AreaCipher, area_key)
+	if err != nil {
+		return nil, err
+	}
+
+	material := make([]byte, size)
+	_, err = reader.ReadAt(material, int64(slot.AreaOffset))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < size/sector_size; i++ {
+		err = cipher.Decrypt(material[i*sector_size:(i+1)*sector_size],
+			uint64(i))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := afMerge(material, slot.KeySize, slot.Stripes, slot.AFHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, digest := range self.Digests {
+		if !digest.Covers(slot.ID) {
+			continue
+		}
+
+		ok, err := digest.Verify(key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return key, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Recover the volume key by trying the passphrase on each key
+// slot. Returns the key and the key slot which unlocked it.
+func (self *Header) Unlock(reader io.ReaderAt, passphrase []byte) (
+	[]byte, *Keyslot, error) {
+	var last_err error
+
+	for _, slot := range self.Keyslots {
+		key, err := self.unlockKeyslot(reader, slot, passphrase)
+		if err != nil {
+			last_err = fmt.Errorf("keyslot %v: %w", slot.ID, err)
+			continue
+		}
+
+		if key != nil {
+			return key, slot, nil
+		}
+	}
+
+	if last_err != nil {
+		return nil, nil, last_err
+	}
+	return nil, nil, errors.New("luks: No key slot matches the key")
+}
@@ -0,0 +1,447 @@
+package luks
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/xts"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+	"www.velocidex.com/golang/vfilter"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+const (
+	testPassphrase = "correct horse battery staple"
+	testStripes    = 4000
+	testUUID       = "12345678-1234-1234-1234-123456789abc"
+)
+
+func randomBytes(r *rand.Rand, size int) []byte {
+	result := make([]byte, size)
+	r.Read(result)
+	return result
+}
+
+// Encrypt sectors in place - the inverse of sectorCipher.
+func encryptSectors(t *testing.T, spec string, key, buf []byte,
+	sector_size int, first_sector uint64) {
+	for i := 0; i*sector_size < len(buf); i++ {
+		sector := buf[i*sector_size : (i+1)*sector_size]
+		iv_sector := first_sector + uint64(i*sector_size/LUKS_SECTOR_SIZE)
+
+		switch spec {
+		case "aes-xts-plain64":
+			c, err := xts.NewCipher(aes.NewCipher, key)
+			assert.NoError(t, err)
+			c.Encrypt(sector, sector, iv_sector)
+
+		case "aes-cbc-essiv:sha256":
+			block, err := aes.NewCipher(key)
+			assert.NoError(t, err)
+
+			salt := sha256.Sum256(key)
+			essiv, err := aes.NewCipher(salt[:])
+			assert.NoError(t, err)
+
+			iv := make([]byte, aes.BlockSize)
+			binary.LittleEndian.PutUint64(iv, iv_sector)
+			essiv.Encrypt(iv, iv)
+			cipher.NewCBCEncrypter(block, iv).CryptBlocks(sector, sector)
+
+		default:
+			t.Fatalf("Unsupported cipher %v", spec)
+		}
+	}
+}
+
+// The anti forensic splitter.
+func afSplit(t *testing.T, r *rand.Rand, key []byte) []byte {
+	result := randomBytes(r, len(key)*testStripes)
+	d := make([]byte, len(key))
+	for i := 0; i < testStripes-1; i++ {
+		for j := range d {
+			d[j] ^= result[i*len(key)+j]
+		}
+		assert.NoError(t, diffuse(d, "sha256"))
+	}
+
+	last := result[(testStripes-1)*len(key):]
+	for j := range d {
+		last[j] = d[j] ^ key[j]
+	}
+	return result
+}
+
+// Build the encrypted key material for a key slot.
+func keyMaterial(t *testing.T, r *rand.Rand, spec string,
+	area_key, volume_key []byte) []byte {
+	material := afSplit(t, r, volume_key)
+	size := (len(material) + LUKS_SECTOR_SIZE - 1) /
+		LUKS_SECTOR_SIZE * LUKS_SECTOR_SIZE
+	material = append(material, make([]byte, size-len(material))...)
+	encryptSectors(t, spec, area_key, material, LUKS_SECTOR_SIZE, 0)
+	return material
+}
+
+func testData(size int) []byte {
+	result := make([]byte, size)
+	for i := 0; i < size; i += 16 {
+		copy(result[i:], fmt.Sprintf("%015x\n", i))
+	}
+	return result
+}
+
+func buildLUKS1(t *testing.T, cipher_name, cipher_mode string,
+	key_size int, data []byte) []byte {
+	r := rand.New(rand.NewSource(1))
+	spec := cipher_name + "-" + cipher_mode
+
+	const payload_sectors = 1024
+	const material_sector = 8
+	image := make([]byte, payload_sectors*LUKS_SECTOR_SIZE)
+
+	copy(image, LUKS_MAGIC)
+	binary.BigEndian.PutUint16(image[6:], 1)
+	copy(image[8:], cipher_name)
+	copy(image[40:], cipher_mode)
+	copy(image[72:], "sha256")
+	binary.BigEndian.PutUint32(image[104:], payload_sectors)
+	binary.BigEndian.PutUint32(image[108:], uint32(key_size))
+	copy(image[168:], testUUID)
+
+	volume_key := randomBytes(r, key_size)
+	digest_salt := randomBytes(r, LUKS1_SALT_SIZE)
+	digest, err := pbkdf2Key("sha256", volume_key, digest_salt, 1000,
+		LUKS1_DIGEST_SIZE)
+	assert.NoError(t, err)
+	copy(image[112:], digest)
+	copy(image[132:], digest_salt)
+	binary.BigEndian.PutUint32(image[164:], 1000)
+
+	// Key slot 0 is disabled, key slot 1 holds the key.
+	slot := image[LUKS1_KEYSLOT_OFFSET+LUKS1_KEYSLOT_SIZE:]
+	salt := randomBytes(r, LUKS1_SALT_SIZE)
+	binary.BigEndian.PutUint32(slot, LUKS1_KEY_ENABLED)
+	binary.BigEndian.PutUint32(slot[4:], 1000)
+	copy(slot[8:], salt)
+	binary.BigEndian.PutUint32(slot[40:], material_sector)
+	binary.BigEndian.PutUint32(slot[44:], testStripes)
+
+	area_key, err := pbkdf2Key("sha256", []byte(testPassphrase), salt,
+		1000, key_size)
+	assert.NoError(t, err)
+	copy(image[material_sector*LUKS_SECTOR_SIZE:],
+		keyMaterial(t, r, spec, area_key, volume_key))
+
+	payload := append([]byte{}, data...)
+	encryptSectors(t, spec, volume_key, payload, LUKS_SECTOR_SIZE, 0)
+	return append(image, payload...)
+}
+
+func buildLUKS2(t *testing.T, data []byte) []byte {
+	r := rand.New(rand.NewSource(2))
+
+	const hdr_size = 0x4000
+	const area_offset = 0x8000
+	const segment_offset = 0x100000
+	const sector_size = 4096
+	const iv_tweak = 8
+	spec := "aes-xts-plain64"
+
+	volume_key := randomBytes(r, 64)
+	image := make([]byte, segment_offset)
+
+	// Key slot 0 uses a different passphrase.
+	kdfs := []*ordereddict.Dict{}
+	for idx, passphrase := range []string{"other", testPassphrase} {
+		salt := randomBytes(r, 32)
+		area_key := argon2.IDKey([]byte(passphrase), salt, 1, 32, 1, 64)
+		offset := area_offset + idx*0x40000
+		copy(image[offset:], keyMaterial(t, r, spec, area_key, volume_key))
+
+		kdfs = append(kdfs, ordereddict.NewDict().
+			Set("type", "luks2").
+			Set("key_size", 64).
+			Set("af", ordereddict.NewDict().
+				Set("type", "luks1").
+				Set("stripes", testStripes).
+				Set("hash", "sha256")).
+			Set("area", ordereddict.NewDict().
+				Set("type", "raw").
+				Set("offset", fmt.Sprintf("%d", offset)).
+				Set("size", "258048").
+				Set("encryption", spec).
+				Set("key_size", 64)).
+			Set("kdf", ordereddict.NewDict().
+				Set("type", "argon2id").
+				Set("time", 1).
+				Set("memory", 32).
+				Set("cpus", 1).
+				Set("salt", base64.StdEncoding.EncodeToString(salt))))
+	}
+
+	digest_salt := randomBytes(r, 32)
+	digest, err := pbkdf2Key("sha256", volume_key, digest_salt, 1000, 32)
+	assert.NoError(t, err)
+
+	metadata := ordereddict.NewDict().
+		Set("keyslots", ordereddict.NewDict().
+			Set("0", kdfs[0]).
+			Set("1", kdfs[1])).
+		Set("segments", ordereddict.NewDict().
+			Set("0", ordereddict.NewDict().
+				Set("type", "crypt").
+				Set("offset", fmt.Sprintf("%d", segment_offset)).
+				Set("size", "dynamic").
+				Set("iv_tweak", fmt.Sprintf("%d", iv_tweak)).
+				Set("encryption", spec).
+				Set("sector_size", sector_size))).
+		Set("digests", ordereddict.NewDict().
+			Set("0", ordereddict.NewDict().
+				Set("type", "pbkdf2").
+				Set("keyslots", []string{"0", "1"}).
+				Set("segments", []string{"0"}).
+				Set("hash", "sha256").
+				Set("iterations", 1000).
+				Set("salt", base64.StdEncoding.EncodeToString(digest_salt)).
+				Set("digest", base64.StdEncoding.EncodeToString(digest))))
+
+	serialized, err := metadata.MarshalJSON()
+	assert.NoError(t, err)
+
+	header := image[:hdr_size]
+	copy(header, LUKS_MAGIC)
+	binary.BigEndian.PutUint16(header[6:], 2)
+	binary.BigEndian.PutUint64(header[8:], hdr_size)
+	binary.BigEndian.PutUint64(header[16:], 1)
+	copy(header[24:], "testlabel")
+	copy(header[72:], "sha256")
+	copy(header[168:], testUUID)
+	copy(header[LUKS2_BINARY_HEADER_SIZE:], serialized)
+
+	checksum := sha256.Sum256(header)
+	copy(header[LUKS2_CHECKSUM_OFFSET:], checksum[:])
+
+	payload := append([]byte{}, data...)
+	encryptSectors(t, spec, volume_key, payload, sector_size, iv_tweak)
+	return append(image, payload...)
+}
+
+func readVolume(t *testing.T, scope vfilter.Scope, filename string) (
+	accessors.FileInfo, []byte, error) {
+	accessor, err := accessors.GetAccessor("luks", scope)
+	assert.NoError(t, err)
+
+	path := accessors.MustNewLinuxOSPath("/")
+	path.SetPathSpec(&accessors.PathSpec{
+		DelegateAccessor: "file",
+		DelegatePath:     filename,
+		Path:             "/",
+	})
+
+	fd, err := accessor.OpenWithOSPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fd.Close()
+
+	info, err := accessor.LstatWithOSPath(path)
+	assert.NoError(t, err)
+
+	// Read from an unaligned offset.
+	_, err = fd.Seek(100, 0)
+	assert.NoError(t, err)
+
+	data, err := ioutil.ReadAll(fd)
+	return info, data, err
+}
+
+type LUKSTestSuite struct {
+	test_utils.TestSuite
+}
+
+func (self *LUKSTestSuite) SetupTest() {
+	self.TestSuite.SetupTest()
+
+	err := services.GrantRoles(self.ConfigObj, "admin", []string{"administrator"})
+	assert.NoError(self.T(), err)
+
+	secrets, err := services.GetSecretsService(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = secrets.DefineSecret(self.Ctx, &api_proto.SecretDefinition{
+		TypeName: constants.LUKS_KEY})
+	assert.NoError(self.T(), err)
+}
+
+func (self *LUKSTestSuite) addSecret(name string, data *ordereddict.Dict) {
+	secrets, err := services.GetSecretsService(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	err = secrets.AddSecret(self.Ctx, vql_subsystem.MakeScope(),
+		constants.LUKS_KEY, name, data)
+	assert.NoError(self.T(), err)
+
+	err = secrets.ModifySecret(self.Ctx, &api_proto.ModifySecretRequest{
+		TypeName: constants.LUKS_KEY,
+		Name:     name,
+		AddUsers: []string{"admin"},
+	})
+	assert.NoError(self.T(), err)
+}
+
+func (self *LUKSTestSuite) makeScope(env *ordereddict.Dict) vfilter.Scope {
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	return manager.BuildScope(services.ScopeBuilder{
+		Config:     self.ConfigObj,
+		ACLManager: acl_managers.NewServerACLManager(self.ConfigObj, "admin"),
+		Logger: logging.NewPlainLogger(
+			self.ConfigObj, &logging.FrontendComponent),
+		Env: env,
+	})
+}
+
+func (self *LUKSTestSuite) TestLUKS() {
+	t := self.T()
+	dir, err := ioutil.TempDir("", "luks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	data := testData(4 * 4096)
+	images := []struct {
+		name   string
+		image  []byte
+		cipher string
+	}{
+		{"luks1_xts", buildLUKS1(t, "aes", "xts-plain64", 64, data),
+			"aes-xts-plain64"},
+		{"luks1_cbc", buildLUKS1(t, "aes", "cbc-essiv:sha256", 32, data),
+			"aes-cbc-essiv:sha256"},
+		{"luks2", buildLUKS2(t, data), "aes-xts-plain64"},
+	}
+
+	keyfile := filepath.Join(dir, "keyfile")
+	assert.NoError(t, ioutil.WriteFile(keyfile, []byte(testPassphrase), 0600))
+
+	self.addSecret("Wrong", ordereddict.NewDict().
+		Set("passphrase", "wrong"))
+	self.addSecret("KeyFile", ordereddict.NewDict().
+		Set("keyfile", keyfile).
+		Set("keyfile_accessor", "file"))
+
+	for _, image := range images {
+		filename := filepath.Join(dir, image.name)
+		assert.NoError(t, ioutil.WriteFile(filename, image.image, 0600))
+
+		// No key
+		scope := self.makeScope(ordereddict.NewDict())
+		_, _, err := readVolume(t, scope, filename)
+		assert.Error(t, err)
+		scope.Close()
+
+		// Keys can not be given in the query.
+		scope = self.makeScope(ordereddict.NewDict().
+			Set(constants.LUKS_CONFIG, ordereddict.NewDict().
+				Set("passphrase", testPassphrase)))
+		_, _, err = readVolume(t, scope, filename)
+		assert.Error(t, err)
+		scope.Close()
+
+		// Wrong passphrase
+		scope = self.makeScope(ordereddict.NewDict().
+			Set(constants.LUKS_CONFIG, ordereddict.NewDict().
+				Set("secret", "Wrong")))
+		_, _, err = readVolume(t, scope, filename)
+		assert.Error(t, err)
+		scope.Close()
+
+		// The passphrase in a key file.
+		scope = self.makeScope(ordereddict.NewDict().
+			Set(constants.LUKS_CONFIG, ordereddict.NewDict().
+				Set("secret", "KeyFile")))
+		info, decrypted, err := readVolume(t, scope, filename)
+		assert.NoError(t, err, image.name)
+		scope.Close()
+
+		assert.Equal(t, int64(len(data)), info.Size(), image.name)
+		assert.True(t, bytes.Equal(data[100:], decrypted), image.name)
+
+		cipher, _ := info.Data().Get("Cipher")
+		assert.Equal(t, image.cipher, cipher)
+
+		uuid, _ := info.Data().Get("UUID")
+		assert.Equal(t, testUUID, uuid)
+	}
+
+	// Without LUKS_CONFIG the secret named after the volume's UUID
+	// is used.
+	self.addSecret(testUUID, ordereddict.NewDict().
+		Set("passphrase", testPassphrase))
+
+	for _, image := range images {
+		scope := self.makeScope(ordereddict.NewDict())
+		_, decrypted, err := readVolume(t, scope,
+			filepath.Join(dir, image.name))
+		assert.NoError(t, err, image.name)
+		assert.True(t, bytes.Equal(data[100:], decrypted), image.name)
+		scope.Close()
+	}
+}
+
+// Hostile headers can not make us allocate huge buffers or spend
+// forever deriving keys.
+func TestLUKSLimits(t *testing.T) {
+	kdf := &KDF{Type: "argon2id", Time: 1, Memory: LUKS_MAX_ARGON2_MEMORY + 1,
+		CPUs: 1}
+	_, err := kdf.DeriveKey([]byte("x"), 32)
+	assert.Error(t, err)
+
+	kdf = &KDF{Type: "argon2id", Time: 1, Memory: 32, CPUs: 1}
+	_, err = kdf.DeriveKey([]byte("x"), LUKS_MAX_KEY_SIZE+1)
+	assert.Error(t, err)
+
+	kdf = &KDF{Type: "pbkdf2", Hash: "sha256",
+		Iterations: LUKS_MAX_PBKDF2_ITERATIONS + 1}
+	_, err = kdf.DeriveKey([]byte("x"), 32)
+	assert.Error(t, err)
+
+	// Key material larger than the key slot area.
+	header := &Header{}
+	_, err = header.unlockKeyslot(bytes.NewReader(nil), &Keyslot{
+		KeySize:        LUKS_MAX_KEY_SIZE,
+		Stripes:        LUKS_MAX_STRIPES,
+		AreaSize:       258048,
+		AreaSectorSize: LUKS_SECTOR_SIZE,
+		AreaKeySize:    64,
+		KDF:            KDF{Type: "pbkdf2", Hash: "sha256", Iterations: 1},
+	}, []byte("x"))
+	assert.Error(t, err)
+}
+
+func TestLUKSAccessor(t *testing.T) {
+	suite.Run(t, &LUKSTestSuite{})
+}
@@ -0,0 +1,131 @@
+package luks
+
+import (
+	"errors"
+	"io"
+)
+
+// An unlocked LUKS volume. Sectors are decrypted as they are read.
+type Volume struct {
+	Header *Header
+
+	// The key slot which unlocked the volume.
+	Keyslot string
+
+	reader io.ReaderAt
+	cipher sectorCipher
+	size   int64
+}
+
+// Unlock the volume using the first of the keys which matches a key
+// slot.
+func NewVolume(reader io.ReaderAt, device_size int64,
+	keys [][]byte) (*Volume, error) {
+	header, err := ParseHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("luks: No passphrase or key file provided")
+	}
+
+	var last_err error
+	for _, key := range keys {
+		volume_key, slot, err := header.Unlock(reader, key)
+		if err != nil {
+			last_err = err
+			continue
+		}
+
+		return newVolumeFromKey(reader, device_size, header,
+			volume_key, slot.ID)
+	}
+
+	return nil, last_err
+}
+
+func newVolumeFromKey(reader io.ReaderAt, device_size int64,
+	header *Header, volume_key []byte, keyslot string) (*Volume, error) {
+	cipher, err := newSectorCipher(header.Cipher, volume_key)
+	if err != nil {
+		return nil, err
+	}
+
+	size := int64(header.Size)
+	if size == 0 {
+		size = device_size - int64(header.Offset)
+	}
+
+	if size < 0 {
+		size = 0
+	}
+
+	return &Volume{
+		Header:  header,
+		Keyslot: keyslot,
+		reader:  reader,
+		cipher:  cipher,
+		size:    size,
+	}, nil
+}
+
+func (self *Volume) Size() int64 {
+	return self.size
+}
+
+func (self *Volume) ReadAt(buf []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, errors.New("luks: Negative offset")
+	}
+
+	if offset >= self.size {
+		return 0, io.EOF
+	}
+
+	to_read := int64(len(buf))
+	if offset+to_read > self.size {
+		to_read = self.size - offset
+	}
+
+	// Read whole sectors covering the range.
+	sector_size := int64(self.Header.SectorSize)
+	start := offset / sector_size * sector_size
+	end := (offset + to_read + sector_size - 1) / sector_size * sector_size
+
+	sectors := make([]byte, end-start)
+	n, err := self.reader.ReadAt(sectors, int64(self.Header.Offset)+start)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+
+	// Only decrypt complete sectors.
+	n = n / int(sector_size) * int(sector_size)
+	sectors = sectors[:n]
+
+	// The IV is the sector number in 512 byte units regardless of
+	// the sector size.
+	for i := int64(0); i*sector_size < int64(n); i++ {
+		sector := (start+i*sector_size)/LUKS_SECTOR_SIZE + int64(self.Header.IVTweak)
+		err = self.cipher.Decrypt(
+			sectors[i*sector_size:(i+1)*sector_size], uint64(sector))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	skip := offset - start
+	if int64(n) <= skip {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	copied := copy(buf[:to_read], sectors[skip:])
+	if int64(copied) < to_read {
+		return copied, io.ErrUnexpectedEOF
+	}
+
+	if copied < len(buf) {
+		return copied, io.EOF
+	}
+	return copied, nil
+}
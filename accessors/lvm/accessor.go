@@ -0,0 +1,370 @@
+package lvm
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/readers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	LVM_CACHE_TAG = "__LVM_CACHE"
+)
+
+// Describes the physical volumes when a volume group spans several
+// devices. This is serialized into the DelegatePath of the lvm
+// accessor's pathspec.
+type LVMSpec struct {
+	PVs []*accessors.PathSpec `json:"PVs"`
+}
+
+func (self *LVMSpec) String() string {
+	return json.MustMarshalString(self)
+}
+
+// Build the root path for the lvm accessor over a set of physical
+// volumes.
+func NewLVMPath(spec *LVMSpec) *accessors.OSPath {
+	result := accessors.MustNewLinuxOSPath("")
+	result.SetPathSpec(&accessors.PathSpec{
+		DelegatePath: spec.String(),
+		Path:         "/",
+	})
+	return result
+}
+
+type volumeSetEntry struct {
+	set     *VolumeSet
+	closers []func()
+}
+
+// Parsing the metadata is cheap but we keep the devices open until
+// the end of the query.
+type lvmCache struct {
+	mu sync.Mutex
+
+	sets map[string]*volumeSetEntry
+}
+
+func (self *lvmCache) Close() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	for _, entry := range self.sets {
+		for _, closer := range entry.closers {
+			closer()
+		}
+	}
+	self.sets = make(map[string]*volumeSetEntry)
+}
+
+func openPV(scope vfilter.Scope, accessor string,
+	device *accessors.OSPath, entry *volumeSetEntry) (*PhysicalVolume, error) {
+	lru_size := vql_subsystem.GetIntFromRow(
+		scope, scope, constants.NTFS_CACHE_SIZE)
+
+	paged_reader, err := readers.NewAccessorReader(
+		scope, accessor, device, int(lru_size))
+	if err != nil {
+		return nil, err
+	}
+	entry.closers = append(entry.closers, func() { paged_reader.Close() })
+
+	return NewPhysicalVolume(paged_reader)
+}
+
+func getCachedVolumeSet(
+	full_path *accessors.OSPath, scope vfilter.Scope) (*VolumeSet, error) {
+	cache, pres := vql_subsystem.CacheGet(scope, LVM_CACHE_TAG).(*lvmCache)
+	if !pres {
+		cache = &lvmCache{
+			sets: make(map[string]*volumeSetEntry),
+		}
+		// Cache will remain alive for the duration of the query.
+		vql_subsystem.GetRootScope(scope).AddDestructor(cache.Close)
+		vql_subsystem.CacheSet(scope, LVM_CACHE_TAG, cache)
+	}
+
+	delegate_accessor := full_path.DelegateAccessor()
+	delegate_path := full_path.DelegatePath()
+	key := delegate_accessor + delegate_path
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry, pres := cache.sets[key]
+	if pres {
+		return entry.set, nil
+	}
+
+	entry = &volumeSetEntry{}
+	var pvs []*PhysicalVolume
+
+	// Without a delegate accessor the DelegatePath lists the PVs,
+	// otherwise the delegate is the only PV.
+	if delegate_accessor == "" {
+		spec := &LVMSpec{}
+		err := json.Unmarshal([]byte(delegate_path), spec)
+		if err != nil || len(spec.PVs) == 0 {
+			return nil, errors.New(
+				"lvm: DelegatePath should list the PVs or a DelegateAccessor is required")
+		}
+
+		for _, pv_spec := range spec.PVs {
+			accessor_name := pv_spec.DelegateAccessor
+			if accessor_name == "" {
+				accessor_name = "auto"
+			}
+
+			accessor, err := accessors.GetAccessor(accessor_name, scope)
+			if err != nil {
+				entry.close()
+				return nil, err
+			}
+
+			device, err := accessor.ParsePath(pv_spec.GetDelegatePath())
+			if err != nil {
+				entry.close()
+				return nil, err
+			}
+
+			pv, err := openPV(scope, accessor_name, device, entry)
+			if err != nil {
+				// A missing PV only affects the LVs which use it.
+				scope.Log("lvm: Skipping %v: %v", pv_spec.String(), err)
+				continue
+			}
+			pvs = append(pvs, pv)
+		}
+
+	} else {
+		device, err := full_path.Delegate(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		pv, err := openPV(scope, delegate_accessor, device, entry)
+		if err != nil {
+			entry.close()
+			return nil, err
+		}
+		pvs = append(pvs, pv)
+	}
+
+	set, err := NewVolumeSet(pvs)
+	if err != nil {
+		entry.close()
+		return nil, err
+	}
+	entry.set = set
+
+	cache.sets[key] = entry
+	return set, nil
+}
+
+func (self *volumeSetEntry) close() {
+	for _, closer := range self.closers {
+		closer()
+	}
+}
+
+type LVMFileSystemAccessor struct {
+	scope vfilter.Scope
+}
+
+func (self LVMFileSystemAccessor) New(scope vfilter.Scope) (
+	accessors.FileSystemAccessor, error) {
+	return &LVMFileSystemAccessor{scope: scope}, nil
+}
+
+func (self LVMFileSystemAccessor) ParsePath(path string) (
+	*accessors.OSPath, error) {
+	return accessors.NewLinuxOSPath(path)
+}
+
+func vgFileInfo(full_path *accessors.OSPath,
+	vg *VolumeGroup) *accessors.VirtualFileInfo {
+	return &accessors.VirtualFileInfo{
+		IsDir_: true,
+		Path:   full_path,
+		Data_: ordereddict.NewDict().
+			Set("ID", vg.ID).
+			Set("Seqno", vg.Seqno).
+			Set("ExtentSize", vg.extentBytes()),
+	}
+}
+
+func lvFileInfo(full_path *accessors.OSPath,
+	lv *LogicalVolume) *accessors.VirtualFileInfo {
+	return &accessors.VirtualFileInfo{
+		Path:   full_path,
+		Size_:  lv.Size(),
+		Btime_: lv.CreationTime,
+		Data_: ordereddict.NewDict().
+			Set("ID", lv.ID).
+			Set("Status", lv.Status).
+			Set("Visible", lv.IsVisible()).
+			Set("Type", lv.Types()).
+			Set("CreationHost", lv.CreationHost),
+	}
+}
+
+func (self LVMFileSystemAccessor) Lstat(filename string) (
+	accessors.FileInfo, error) {
+	full_path, err := self.ParsePath(filename)
+	if err != nil {
+		return nil, err
+	}
+	return self.LstatWithOSPath(full_path)
+}
+
+func (self LVMFileSystemAccessor) LstatWithOSPath(
+	full_path *accessors.OSPath) (accessors.FileInfo, error) {
+	set, err := getCachedVolumeSet(full_path, self.scope)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(full_path.Components) {
+	case 0:
+		return &accessors.VirtualFileInfo{
+			IsDir_: true,
+			Path:   full_path,
+		}, nil
+
+	case 1:
+		vg, err := set.GetVG(full_path.Components[0])
+		if err != nil {
+			return nil, err
+		}
+		return vgFileInfo(full_path, vg), nil
+
+	case 2:
+		vg, err := set.GetVG(full_path.Components[0])
+		if err != nil {
+			return nil, err
+		}
+
+		lv, err := vg.GetLV(full_path.Components[1])
+		if err != nil {
+			return nil, err
+		}
+		return lvFileInfo(full_path, lv), nil
+	}
+
+	return nil, os.ErrNotExist
+}
+
+func (self LVMFileSystemAccessor) ReadDir(dir string) (
+	[]accessors.FileInfo, error) {
+	full_path, err := self.ParsePath(dir)
+	if err != nil {
+		return nil, err
+	}
+	return self.ReadDirWithOSPath(full_path)
+}
+
+func (self LVMFileSystemAccessor) ReadDirWithOSPath(
+	full_path *accessors.OSPath) ([]accessors.FileInfo, error) {
+	set, err := getCachedVolumeSet(full_path, self.scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []accessors.FileInfo
+	switch len(full_path.Components) {
+	case 0:
+		for _, vg := range set.VGs {
+			result = append(result, vgFileInfo(full_path.Append(vg.Name), vg))
+		}
+
+	case 1:
+		vg, err := set.GetVG(full_path.Components[0])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lv := range vg.LVs {
+			result = append(result, lvFileInfo(full_path.Append(lv.Name), lv))
+		}
+
+	default:
+		return nil, errors.New("lvm: Not a directory")
+	}
+
+	return result, nil
+}
+
+func (self LVMFileSystemAccessor) Open(filename string) (
+	accessors.ReadSeekCloser, error) {
+	full_path, err := self.ParsePath(filename)
+	if err != nil {
+		return nil, err
+	}
+	return self.OpenWithOSPath(full_path)
+}
+
+func (self LVMFileSystemAccessor) OpenWithOSPath(
+	full_path *accessors.OSPath) (accessors.ReadSeekCloser, error) {
+	set, err := getCachedVolumeSet(full_path, self.scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(full_path.Components) != 2 {
+		return nil, errors.New("lvm: Can only open logical volumes")
+	}
+
+	vg, err := set.GetVG(full_path.Components[0])
+	if err != nil {
+		return nil, err
+	}
+
+	lv, err := vg.GetLV(full_path.Components[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.NewReadSeekReaderAdapter(lv.Reader()), nil
+}
+
+func init() {
+	accessors.Register("lvm", &LVMFileSystemAccessor{},
+		`Access the logical volumes inside LVM2 physical volumes.
+
+The delegate is a physical volume (e.g. a partition of a disk image
+opened through the offset accessor). Each logical volume appears as a
+file /<vg>/<lv> which other accessors (e.g. raw_ext4 or raw_xfs) can
+parse. Linear and striped logical volumes are supported.
+
+When a volume group spans several physical volumes, leave the
+DelegateAccessor empty and set the DelegatePath to a JSON object
+listing all the physical volumes:
+
+{"PVs": [{"DelegateAccessor": "file", "DelegatePath": "/images/pv1.dd"},
+         {"DelegateAccessor": "file", "DelegatePath": "/images/pv2.dd"}]}
+
+## Example
+
+SELECT OSPath.Path AS OSPath, Size
+FROM glob(globs="/*", accessor="raw_ext4",
+  root=pathspec(
+    DelegateAccessor="lvm",
+    Delegate=pathspec(
+      DelegateAccessor="offset",
+      Delegate=pathspec(
+        DelegateAccessor="file",
+        DelegatePath="/images/disk.dd",
+        Path="/1048576"),
+      Path="/rhel/root"),
+    Path="/"))
+`)
+}
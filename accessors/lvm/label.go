@@ -0,0 +1,179 @@
+package lvm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The on disk format is described in lib/format_text/layout.h of the
+// LVM2 sources.
+const (
+	LVM_SECTOR_SIZE        = 512
+	LVM_LABEL_SCAN_SECTORS = 4
+	LVM_LABEL_ID           = "LABELONE"
+	LVM_LABEL_TYPE         = "LVM2 001"
+	LVM_ID_LEN             = 32
+
+	LVM_MDA_MAGIC       = " LVM2 x[5A%r0N*>"
+	LVM_MDA_HEADER_SIZE = 512
+	LVM_RAW_LOCN_SIZE   = 24
+	LVM_DISK_LOCN_SIZE  = 16
+
+	RAW_LOCN_IGNORED = 1
+
+	// Metadata is normally a few kb.
+	LVM_MAX_METADATA_SIZE = 16 * 1024 * 1024
+
+	// Guard against corrupted location lists.
+	LVM_MAX_LOCATIONS = 16
+)
+
+type diskLocation struct {
+	Offset uint64
+	Size   uint64
+}
+
+// A physical volume is a device with an LVM label.
+type PhysicalVolume struct {
+	// The UUID without dashes as stored in the label.
+	UUID       string
+	DeviceSize uint64
+
+	// The text metadata from each metadata area.
+	Metadata [][]byte
+
+	reader io.ReaderAt
+}
+
+// Returns true if the device has an LVM label.
+func IsPhysicalVolume(reader io.ReaderAt) bool {
+	_, _, err := findLabel(reader)
+	return err == nil
+}
+
+func findLabel(reader io.ReaderAt) ([]byte, int64, error) {
+	buf := make([]byte, LVM_LABEL_SCAN_SECTORS*LVM_SECTOR_SIZE)
+	n, err := reader.ReadAt(buf, 0)
+	if err != nil && n == 0 {
+		return nil, 0, err
+	}
+	buf = buf[:n]
+
+	for i := 0; i+LVM_SECTOR_SIZE <= len(buf); i += LVM_SECTOR_SIZE {
+		sector := buf[i : i+LVM_SECTOR_SIZE]
+		if string(sector[:8]) == LVM_LABEL_ID &&
+			string(sector[24:32]) == LVM_LABEL_TYPE {
+			return sector, int64(i), nil
+		}
+	}
+	return nil, 0, errors.New("lvm: No LVM2 label found")
+}
+
+func readLocations(buf []byte) ([]diskLocation, []byte) {
+	var result []diskLocation
+	for len(buf) >= LVM_DISK_LOCN_SIZE && len(result) < LVM_MAX_LOCATIONS {
+		loc := diskLocation{
+			Offset: binary.LittleEndian.Uint64(buf),
+			Size:   binary.LittleEndian.Uint64(buf[8:]),
+		}
+		buf = buf[LVM_DISK_LOCN_SIZE:]
+		if loc.Offset == 0 {
+			break
+		}
+		result = append(result, loc)
+	}
+	return result, buf
+}
+
+func NewPhysicalVolume(reader io.ReaderAt) (*PhysicalVolume, error) {
+	label, _, err := findLabel(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := int(binary.LittleEndian.Uint32(label[20:]))
+	if offset+LVM_ID_LEN+8 > len(label) {
+		return nil, errors.New("lvm: Invalid label header")
+	}
+
+	header := label[offset:]
+	result := &PhysicalVolume{
+		UUID:       string(header[:LVM_ID_LEN]),
+		DeviceSize: binary.LittleEndian.Uint64(header[LVM_ID_LEN:]),
+		reader:     reader,
+	}
+
+	// The data areas are followed by the metadata areas.
+	_, rest := readLocations(header[LVM_ID_LEN+8:])
+	metadata_areas, _ := readLocations(rest)
+
+	for _, mda := range metadata_areas {
+		text, err := readMetadataArea(reader, mda)
+		if err != nil {
+			continue
+		}
+		result.Metadata = append(result.Metadata, text)
+	}
+
+	if len(result.Metadata) == 0 {
+		return nil, fmt.Errorf("lvm: No metadata found on PV %v", result.UUID)
+	}
+
+	return result, nil
+}
+
+// The metadata area is a circular buffer following its header. The
+// raw location points at the current copy of the text metadata.
+func readMetadataArea(reader io.ReaderAt, mda diskLocation) ([]byte, error) {
+	header := make([]byte, LVM_MDA_HEADER_SIZE)
+	_, err := reader.ReadAt(header, int64(mda.Offset))
+	if err != nil {
+		return nil, err
+	}
+
+	if string(header[4:20]) != LVM_MDA_MAGIC {
+		return nil, errors.New("lvm: Invalid metadata area magic")
+	}
+
+	start := binary.LittleEndian.Uint64(header[24:])
+	size := binary.LittleEndian.Uint64(header[32:])
+
+	locn := header[40:]
+	offset := binary.LittleEndian.Uint64(locn)
+	length := binary.LittleEndian.Uint64(locn[8:])
+	flags := binary.LittleEndian.Uint32(locn[20:])
+
+	if offset == 0 || flags&RAW_LOCN_IGNORED != 0 {
+		return nil, errors.New("lvm: No metadata in area")
+	}
+
+	if length > LVM_MAX_METADATA_SIZE || offset >= size ||
+		size <= LVM_MDA_HEADER_SIZE {
+		return nil, errors.New("lvm: Invalid metadata location")
+	}
+
+	result := make([]byte, length)
+	first := length
+	if offset+length > size {
+		first = size - offset
+	}
+
+	_, err = reader.ReadAt(result[:first], int64(start+offset))
+	if err != nil {
+		return nil, err
+	}
+
+	// The text wraps around to the start of the buffer.
+	if first < length {
+		_, err = reader.ReadAt(result[first:],
+			int64(start+LVM_MDA_HEADER_SIZE))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bytes.TrimRight(result, "\x00"), nil
+}
@@ -0,0 +1,267 @@
+package lvm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+const (
+	testExtentSize = 8 // sectors
+	testExtent     = testExtentSize * LVM_SECTOR_SIZE
+	testPeStart    = 2048 // sectors
+	testMdaOffset  = 4096
+	testMdaSize    = 8192
+	testExtents    = 16
+)
+
+var (
+	testUUIDs = []string{
+		"aaaaaa-aaaa-aaaa-aaaa-aaaa-aaaa-aaaaaa",
+		"bbbbbb-bbbb-bbbb-bbbb-bbbb-bbbb-bbbbbb",
+	}
+
+	testMetadata = `# A comment
+testvg {
+id = "vvvvvv-vvvv-vvvv-vvvv-vvvv-vvvv-vvvvvv"
+seqno = 3
+format = "lvm2"
+status = ["RESIZEABLE", "READ", "WRITE"]
+flags = []
+extent_size = %d
+max_lv = 0
+
+physical_volumes {
+
+pv0 {
+id = "%s"
+device = "/dev/sda2"
+status = ["ALLOCATABLE"]
+pe_start = %d
+pe_count = %d
+}
+
+pv1 {
+id = "%s"
+device = "/dev/sdb"
+status = ["ALLOCATABLE"]
+pe_start = %d
+pe_count = %d
+}
+}
+
+logical_volumes {
+
+linear {
+id = "llllll-llll-llll-llll-llll-llll-llllll"
+status = ["READ", "WRITE", "VISIBLE"]
+creation_time = 1700000000	# 2023-11-14
+creation_host = "host \"one\""
+segment_count = 2
+
+segment1 {
+start_extent = 0
+extent_count = 2
+type = "striped"
+stripe_count = 1	# linear
+stripes = [
+"pv0", 0
+]
+}
+segment2 {
+start_extent = 2
+extent_count = 1
+type = "striped"
+stripe_count = 1
+stripes = [
+"pv1", 0
+]
+}
+}
+
+striped {
+id = "ssssss-ssss-ssss-ssss-ssss-ssss-ssssss"
+status = ["READ", "WRITE", "VISIBLE"]
+segment_count = 1
+
+segment1 {
+start_extent = 0
+extent_count = 4
+type = "striped"
+stripe_count = 2
+stripe_size = 2
+stripes = [
+"pv0", 4,
+"pv1", 4
+]
+}
+}
+}
+}
+# Generated by LVM2
+contents = "Text Format Volume Group"
+version = 1
+`
+)
+
+// Build a PV image. The metadata is placed so it wraps around the
+// end of the circular buffer when wrap is set.
+func buildPV(uuid, metadata string, wrap bool) []byte {
+	image := make([]byte, testPeStart*LVM_SECTOR_SIZE+testExtents*testExtent)
+
+	label := image[LVM_SECTOR_SIZE:]
+	copy(label, LVM_LABEL_ID)
+	binary.LittleEndian.PutUint64(label[8:], 1)
+	binary.LittleEndian.PutUint32(label[20:], 32)
+	copy(label[24:], LVM_LABEL_TYPE)
+
+	header := label[32:]
+	copy(header, normalizeUUID(uuid))
+	binary.LittleEndian.PutUint64(header[32:], uint64(len(image)))
+
+	// One data area followed by one metadata area.
+	binary.LittleEndian.PutUint64(header[40:], testPeStart*LVM_SECTOR_SIZE)
+	binary.LittleEndian.PutUint64(header[72:], testMdaOffset)
+	binary.LittleEndian.PutUint64(header[80:], testMdaSize)
+
+	mda := image[testMdaOffset:]
+	copy(mda[4:], LVM_MDA_MAGIC)
+	binary.LittleEndian.PutUint32(mda[20:], 1)
+	binary.LittleEndian.PutUint64(mda[24:], testMdaOffset)
+	binary.LittleEndian.PutUint64(mda[32:], testMdaSize)
+
+	offset := LVM_MDA_HEADER_SIZE
+	if wrap {
+		offset = testMdaSize - 100
+	}
+	binary.LittleEndian.PutUint64(mda[40:], uint64(offset))
+	binary.LittleEndian.PutUint64(mda[48:], uint64(len(metadata)))
+
+	first := copy(mda[offset:testMdaSize], metadata)
+	copy(mda[LVM_MDA_HEADER_SIZE:], metadata[first:])
+
+	return image
+}
+
+func pvOffset(extent, offset int) int {
+	return testPeStart*LVM_SECTOR_SIZE + extent*testExtent + offset
+}
+
+func TestLVM(t *testing.T) {
+	metadata := fmt.Sprintf(testMetadata, testExtentSize,
+		testUUIDs[0], testPeStart, testExtents,
+		testUUIDs[1], testPeStart, testExtents)
+
+	pvs := [][]byte{
+		buildPV(testUUIDs[0], metadata, false),
+		buildPV(testUUIDs[1], metadata, true),
+	}
+
+	// The linear volume uses two extents on pv0 then one on pv1.
+	linear := make([]byte, 3*testExtent)
+	for i := range linear {
+		linear[i] = byte(i % 251)
+	}
+	copy(pvs[0][pvOffset(0, 0):], linear[:2*testExtent])
+	copy(pvs[1][pvOffset(0, 0):], linear[2*testExtent:])
+
+	// The striped volume alternates 1kb chunks between the PVs.
+	striped := make([]byte, 4*testExtent)
+	for i := range striped {
+		striped[i] = byte(i % 241)
+	}
+	chunk := 2 * LVM_SECTOR_SIZE
+	for i := 0; i*chunk < len(striped); i++ {
+		copy(pvs[i%2][pvOffset(4, (i/2)*chunk):],
+			striped[i*chunk:(i+1)*chunk])
+	}
+
+	dir := t.TempDir()
+	var specs []*accessors.PathSpec
+	for i, pv := range pvs {
+		path := filepath.Join(dir, fmt.Sprintf("pv%d.dd", i))
+		assert.NoError(t, os.WriteFile(path, pv, 0600))
+		specs = append(specs, &accessors.PathSpec{
+			DelegateAccessor: "file",
+			DelegatePath:     path,
+		})
+	}
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	accessor, err := accessors.GetAccessor("lvm", scope)
+	assert.NoError(t, err)
+
+	root := NewLVMPath(&LVMSpec{PVs: specs})
+
+	list := func(path *accessors.OSPath) []string {
+		children, err := accessor.ReadDirWithOSPath(path)
+		assert.NoError(t, err)
+
+		result := []string{}
+		for _, c := range children {
+			result = append(result, c.Name())
+		}
+		return result
+	}
+
+	read := func(path *accessors.OSPath) []byte {
+		fd, err := accessor.OpenWithOSPath(path)
+		assert.NoError(t, err)
+		defer fd.Close()
+
+		data, err := ioutil.ReadAll(fd)
+		assert.NoError(t, err)
+		return data
+	}
+
+	assert.Equal(t, []string{"testvg"}, list(root))
+	assert.Equal(t, []string{"linear", "striped"}, list(root.Append("testvg")))
+
+	info, err := accessor.LstatWithOSPath(root.Append("testvg", "linear"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3*testExtent), info.Size())
+	assert.Equal(t, int64(1700000000), info.Btime().Unix())
+
+	host, _ := info.Data().Get("CreationHost")
+	assert.Equal(t, `host "one"`, host)
+
+	assert.Equal(t, linear, read(root.Append("testvg", "linear")))
+	assert.Equal(t, striped, read(root.Append("testvg", "striped")))
+
+	// With only the first PV the volume group is still visible but
+	// reading past it fails.
+	single := accessors.MustNewLinuxOSPath("/testvg/linear")
+	single.SetPathSpec(&accessors.PathSpec{
+		DelegateAccessor: "file",
+		DelegatePath:     specs[0].DelegatePath,
+		Path:             "/testvg/linear",
+	})
+
+	fd, err := accessor.OpenWithOSPath(single)
+	assert.NoError(t, err)
+	defer fd.Close()
+
+	buf := make([]byte, 100)
+	_, err = fd.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, linear[:100], buf)
+
+	_, err = fd.Seek(2*testExtent, 0)
+	assert.NoError(t, err)
+	_, err = fd.Read(buf)
+	assert.Error(t, err)
+}
@@ -0,0 +1,233 @@
+package lvm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+// The maximum nesting of sections we accept.
+const MAX_SECTION_DEPTH = 16
+
+// A parser for the LVM2 text metadata format. Sections become
+// ordereddicts, arrays become []interface{} and values are either
+// int64 or string.
+type metadataParser struct {
+	data []byte
+	pos  int
+}
+
+func parseMetadata(data []byte) (*ordereddict.Dict, error) {
+	parser := &metadataParser{data: data}
+	return parser.parseSection(0, true)
+}
+
+func (self *metadataParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("lvm: metadata offset %v: %v", self.pos,
+		fmt.Sprintf(format, args...))
+}
+
+func (self *metadataParser) skipSpace() {
+	for self.pos < len(self.data) {
+		c := self.data[self.pos]
+		switch {
+		case c == '#':
+			for self.pos < len(self.data) && self.data[self.pos] != '\n' {
+				self.pos++
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			self.pos++
+		default:
+			return
+		}
+	}
+}
+
+func isTokenChar(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '=', '{', '}', '[', ']', ',', '"', '#':
+		return false
+	}
+	return true
+}
+
+func (self *metadataParser) token() string {
+	start := self.pos
+	for self.pos < len(self.data) && isTokenChar(self.data[self.pos]) {
+		self.pos++
+	}
+	return string(self.data[start:self.pos])
+}
+
+func (self *metadataParser) peek() byte {
+	self.skipSpace()
+	if self.pos >= len(self.data) {
+		return 0
+	}
+	return self.data[self.pos]
+}
+
+func (self *metadataParser) parseSection(depth int, top bool) (
+	*ordereddict.Dict, error) {
+	if depth > MAX_SECTION_DEPTH {
+		return nil, self.errorf("sections nested too deeply")
+	}
+
+	result := ordereddict.NewDict()
+	for {
+		c := self.peek()
+		switch c {
+		case 0:
+			if top {
+				return result, nil
+			}
+			return nil, errors.New("lvm: Unexpected end of metadata")
+
+		case '}':
+			if top {
+				return nil, self.errorf("unexpected }")
+			}
+			self.pos++
+			return result, nil
+		}
+
+		name := self.token()
+		if name == "" {
+			return nil, self.errorf("expected a name")
+		}
+
+		switch self.peek() {
+		case '{':
+			self.pos++
+			section, err := self.parseSection(depth+1, false)
+			if err != nil {
+				return nil, err
+			}
+			result.Set(name, section)
+
+		case '=':
+			self.pos++
+			value, err := self.parseValue(true)
+			if err != nil {
+				return nil, err
+			}
+			result.Set(name, value)
+
+		default:
+			return nil, self.errorf("expected = or { after %v", name)
+		}
+	}
+}
+
+func (self *metadataParser) parseValue(allow_array bool) (interface{}, error) {
+	switch self.peek() {
+	case '"':
+		return self.parseString()
+
+	case '[':
+		if !allow_array {
+			return nil, self.errorf("nested arrays are not allowed")
+		}
+		self.pos++
+
+		result := []interface{}{}
+		for {
+			if self.peek() == ']' {
+				self.pos++
+				return result, nil
+			}
+
+			value, err := self.parseValue(false)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+
+			switch self.peek() {
+			case ',':
+				self.pos++
+			case ']':
+			default:
+				return nil, self.errorf("expected , or ]")
+			}
+		}
+	}
+
+	token := self.token()
+	if token == "" {
+		return nil, self.errorf("expected a value")
+	}
+
+	number, err := strconv.ParseInt(token, 0, 64)
+	if err == nil {
+		return number, nil
+	}
+	return token, nil
+}
+
+func (self *metadataParser) parseString() (string, error) {
+	// Skip the opening quote
+	self.pos++
+
+	var result strings.Builder
+	for self.pos < len(self.data) {
+		c := self.data[self.pos]
+		self.pos++
+
+		switch c {
+		case '"':
+			return result.String(), nil
+
+		case '\\':
+			if self.pos < len(self.data) {
+				result.WriteByte(self.data[self.pos])
+				self.pos++
+			}
+
+		default:
+			result.WriteByte(c)
+		}
+	}
+	return "", errors.New("lvm: Unterminated string in metadata")
+}
+
+func getInt(dict *ordereddict.Dict, name string) uint64 {
+	value, _ := dict.Get(name)
+	number, _ := value.(int64)
+	if number < 0 {
+		return 0
+	}
+	return uint64(number)
+}
+
+func getString(dict *ordereddict.Dict, name string) string {
+	value, _ := dict.Get(name)
+	str, _ := value.(string)
+	return str
+}
+
+func getStrings(dict *ordereddict.Dict, name string) []string {
+	value, _ := dict.Get(name)
+	array, _ := value.([]interface{})
+
+	result := []string{}
+	for _, item := range array {
+		str, ok := item.(string)
+		if ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
+
+func getSection(dict *ordereddict.Dict, name string) *ordereddict.Dict {
+	value, _ := dict.Get(name)
+	section, _ := value.(*ordereddict.Dict)
+	if section == nil {
+		return ordereddict.NewDict()
+	}
+	return section
+}
@@ -0,0 +1,426 @@
+package lvm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+// Limits to guard against corrupted metadata.
+const (
+	LVM_MAX_SEGMENTS = 65536
+	LVM_MAX_STRIPES  = 128
+)
+
+type Stripe struct {
+	// The name of the PV within the volume group (e.g. pv0)
+	PV     string
+	Extent uint64
+}
+
+type Segment struct {
+	StartExtent uint64
+	ExtentCount uint64
+	Type        string
+
+	// Stripe size in sectors.
+	StripeSize uint64
+	Stripes    []Stripe
+}
+
+type PhysicalVolumeInfo struct {
+	Name    string
+	ID      string
+	Device  string
+	PeStart uint64
+	PeCount uint64
+
+	// Nil if the PV is missing.
+	reader io.ReaderAt
+}
+
+type LogicalVolume struct {
+	Name         string
+	ID           string
+	Status       []string
+	CreationHost string
+	CreationTime time.Time
+	Segments     []*Segment
+
+	vg *VolumeGroup
+}
+
+type VolumeGroup struct {
+	Name       string
+	ID         string
+	Seqno      uint64
+	ExtentSize uint64
+	PVs        map[string]*PhysicalVolumeInfo
+	LVs        []*LogicalVolume
+}
+
+// LVM2 formats UUIDs with dashes but stores them without in the
+// label.
+func normalizeUUID(uuid string) string {
+	return strings.ReplaceAll(uuid, "-", "")
+}
+
+func (self *VolumeGroup) extentBytes() uint64 {
+	return self.ExtentSize * LVM_SECTOR_SIZE
+}
+
+func (self *VolumeGroup) GetLV(name string) (*LogicalVolume, error) {
+	for _, lv := range self.LVs {
+		if lv.Name == name {
+			return lv, nil
+		}
+	}
+	return nil, fmt.Errorf("lvm: No logical volume %v in %v", name, self.Name)
+}
+
+// Parse the volume groups in the text metadata. There is usually
+// exactly one.
+func parseVolumeGroups(text []byte) ([]*VolumeGroup, error) {
+	metadata, err := parseMetadata(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*VolumeGroup
+	for _, name := range metadata.Keys() {
+		// Top level assignments describe the metadata itself.
+		value, _ := metadata.Get(name)
+		section, ok := value.(*ordereddict.Dict)
+		if !ok {
+			continue
+		}
+
+		vg, err := parseVolumeGroup(name, section)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, vg)
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("lvm: No volume group in metadata")
+	}
+	return result, nil
+}
+
+func parseVolumeGroup(name string, section *ordereddict.Dict) (
+	*VolumeGroup, error) {
+	result := &VolumeGroup{
+		Name:       name,
+		ID:         getString(section, "id"),
+		Seqno:      getInt(section, "seqno"),
+		ExtentSize: getInt(section, "extent_size"),
+		PVs:        make(map[string]*PhysicalVolumeInfo),
+	}
+
+	if result.ExtentSize == 0 {
+		return nil, fmt.Errorf("lvm: Volume group %v has no extent size", name)
+	}
+
+	pvs := getSection(section, "physical_volumes")
+	for _, pv_name := range pvs.Keys() {
+		pv := getSection(pvs, pv_name)
+		result.PVs[pv_name] = &PhysicalVolumeInfo{
+			Name:    pv_name,
+			ID:      normalizeUUID(getString(pv, "id")),
+			Device:  getString(pv, "device"),
+			PeStart: getInt(pv, "pe_start"),
+			PeCount: getInt(pv, "pe_count"),
+		}
+	}
+
+	lvs := getSection(section, "logical_volumes")
+	for _, lv_name := range lvs.Keys() {
+		lv, err := parseLogicalVolume(lv_name, getSection(lvs, lv_name))
+		if err != nil {
+			return nil, err
+		}
+		lv.vg = result
+		result.LVs = append(result.LVs, lv)
+	}
+
+	return result, nil
+}
+
+func parseLogicalVolume(name string, section *ordereddict.Dict) (
+	*LogicalVolume, error) {
+	result := &LogicalVolume{
+		Name:         name,
+		ID:           getString(section, "id"),
+		Status:       getStrings(section, "status"),
+		CreationHost: getString(section, "creation_host"),
+	}
+
+	creation_time := getInt(section, "creation_time")
+	if creation_time > 0 {
+		result.CreationTime = time.Unix(int64(creation_time), 0).UTC()
+	}
+
+	segment_count := getInt(section, "segment_count")
+	if segment_count > LVM_MAX_SEGMENTS {
+		return nil, fmt.Errorf("lvm: Too many segments in %v", name)
+	}
+
+	for i := uint64(1); i <= segment_count; i++ {
+		seg := getSection(section, fmt.Sprintf("segment%d", i))
+		segment := &Segment{
+			StartExtent: getInt(seg, "start_extent"),
+			ExtentCount: getInt(seg, "extent_count"),
+			Type:        getString(seg, "type"),
+			StripeSize:  getInt(seg, "stripe_size"),
+		}
+
+		value, _ := seg.Get("stripes")
+		stripes, _ := value.([]interface{})
+		for j := 0; j+1 < len(stripes) && j < 2*LVM_MAX_STRIPES; j += 2 {
+			pv, _ := stripes[j].(string)
+			extent, _ := stripes[j+1].(int64)
+			segment.Stripes = append(segment.Stripes, Stripe{
+				PV: pv, Extent: uint64(extent),
+			})
+		}
+
+		result.Segments = append(result.Segments, segment)
+	}
+
+	sort.Slice(result.Segments, func(i, j int) bool {
+		return result.Segments[i].StartExtent < result.Segments[j].StartExtent
+	})
+
+	return result, nil
+}
+
+func (self *LogicalVolume) Size() int64 {
+	var extents uint64
+	for _, seg := range self.Segments {
+		extents += seg.ExtentCount
+	}
+	return int64(extents * self.vg.extentBytes())
+}
+
+func (self *LogicalVolume) IsVisible() bool {
+	for _, s := range self.Status {
+		if s == "VISIBLE" {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *LogicalVolume) Types() []string {
+	result := []string{}
+	for _, seg := range self.Segments {
+		if !stringIn(result, seg.Type) {
+			result = append(result, seg.Type)
+		}
+	}
+	return result
+}
+
+func stringIn(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *LogicalVolume) Reader() io.ReaderAt {
+	return &lvReader{lv: self}
+}
+
+// Reads the logical volume by mapping each range onto the physical
+// volumes.
+type lvReader struct {
+	lv *LogicalVolume
+}
+
+func (self *lvReader) ReadAt(buf []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, errors.New("lvm: Negative offset")
+	}
+
+	size := self.lv.Size()
+	if offset >= size {
+		return 0, io.EOF
+	}
+
+	to_read := int64(len(buf))
+	if offset+to_read > size {
+		to_read = size - offset
+	}
+
+	total := 0
+	for int64(total) < to_read {
+		n, err := self.readChunk(buf[total:to_read], uint64(offset)+uint64(total))
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	if total < len(buf) {
+		return total, io.EOF
+	}
+	return total, nil
+}
+
+// Read from a single contiguous range on one physical volume.
+func (self *lvReader) readChunk(buf []byte, offset uint64) (int, error) {
+	vg := self.lv.vg
+	extent_bytes := vg.extentBytes()
+
+	var segment *Segment
+	for _, seg := range self.lv.Segments {
+		start := seg.StartExtent * extent_bytes
+		if offset >= start && offset < start+seg.ExtentCount*extent_bytes {
+			segment = seg
+			break
+		}
+	}
+
+	if segment == nil {
+		return 0, fmt.Errorf("lvm: Offset %#x not mapped in %v", offset, self.lv.Name)
+	}
+
+	seg_offset := offset - segment.StartExtent*extent_bytes
+	available := segment.ExtentCount*extent_bytes - seg_offset
+
+	var stripe Stripe
+	var stripe_offset uint64
+
+	switch segment.Type {
+	case "zero", "error":
+		if uint64(len(buf)) > available {
+			buf = buf[:available]
+		}
+		if segment.Type == "error" {
+			return 0, fmt.Errorf("lvm: Read from error segment in %v", self.lv.Name)
+		}
+		for i := range buf {
+			buf[i] = 0
+		}
+		return len(buf), nil
+
+	case "striped":
+		switch {
+		case len(segment.Stripes) == 0:
+			return 0, fmt.Errorf("lvm: No stripes in %v", self.lv.Name)
+
+		case len(segment.Stripes) == 1:
+			stripe = segment.Stripes[0]
+			stripe_offset = seg_offset
+
+		default:
+			// Chunks of stripe_size sectors are laid out across the
+			// stripes in turn.
+			chunk := segment.StripeSize * LVM_SECTOR_SIZE
+			if chunk == 0 {
+				return 0, fmt.Errorf("lvm: Invalid stripe size in %v", self.lv.Name)
+			}
+			count := uint64(len(segment.Stripes))
+			chunk_nr := seg_offset / chunk
+			stripe = segment.Stripes[chunk_nr%count]
+			stripe_offset = (chunk_nr/count)*chunk + seg_offset%chunk
+			if chunk-seg_offset%chunk < available {
+				available = chunk - seg_offset%chunk
+			}
+		}
+
+	default:
+		return 0, fmt.Errorf("lvm: Unsupported segment type %v in %v",
+			segment.Type, self.lv.Name)
+	}
+
+	pv, pres := vg.PVs[stripe.PV]
+	if !pres {
+		return 0, fmt.Errorf("lvm: Unknown PV %v in %v", stripe.PV, self.lv.Name)
+	}
+
+	if pv.reader == nil {
+		return 0, fmt.Errorf("lvm: PV %v (%v) of %v is missing",
+			stripe.PV, pv.ID, vg.Name)
+	}
+
+	if uint64(len(buf)) > available {
+		buf = buf[:available]
+	}
+
+	physical := pv.PeStart*LVM_SECTOR_SIZE + stripe.Extent*extent_bytes +
+		stripe_offset
+	n, err := pv.reader.ReadAt(buf, int64(physical))
+	if n < len(buf) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return n, err
+	}
+	return n, nil
+}
+
+// All the volume groups found on a set of physical volumes.
+type VolumeSet struct {
+	VGs []*VolumeGroup
+}
+
+func NewVolumeSet(pvs []*PhysicalVolume) (*VolumeSet, error) {
+	// Keep the newest metadata for each volume group.
+	groups := make(map[string]*VolumeGroup)
+	for _, pv := range pvs {
+		for _, text := range pv.Metadata {
+			vgs, err := parseVolumeGroups(text)
+			if err != nil {
+				continue
+			}
+
+			for _, vg := range vgs {
+				existing, pres := groups[vg.Name]
+				if !pres || existing.Seqno < vg.Seqno {
+					groups[vg.Name] = vg
+				}
+			}
+		}
+	}
+
+	if len(groups) == 0 {
+		return nil, errors.New("lvm: No volume groups found")
+	}
+
+	result := &VolumeSet{}
+	for _, vg := range groups {
+		for _, info := range vg.PVs {
+			for _, pv := range pvs {
+				if pv.UUID == info.ID {
+					info.reader = pv.reader
+				}
+			}
+		}
+		result.VGs = append(result.VGs, vg)
+	}
+
+	sort.Slice(result.VGs, func(i, j int) bool {
+		return result.VGs[i].Name < result.VGs[j].Name
+	})
+
+	return result, nil
+}
+
+func (self *VolumeSet) GetVG(name string) (*VolumeGroup, error) {
+	for _, vg := range self.VGs {
+		if vg.Name == name {
+			return vg, nil
+		}
+	}
+	return nil, fmt.Errorf("lvm: No volume group %v", name)
+}
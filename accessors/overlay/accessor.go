@@ -0,0 +1,250 @@
+package overlay
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	OVERLAY_CACHE_TAG = "__OVERLAY_CACHE"
+)
+
+var (
+	overlayAccessorCurrentTmpLayers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "accessor_overlay_current_tmp_layers",
+		Help: "Number of compressed layers currently unpacked to tmp files",
+	})
+)
+
+type OverlayFileInfo struct {
+	accessors.FileInfo
+
+	path  *accessors.OSPath
+	layer int
+	link  string
+}
+
+func (self *OverlayFileInfo) OSPath() *accessors.OSPath {
+	return self.path
+}
+
+func (self *OverlayFileInfo) FullPath() string {
+	return self.path.String()
+}
+
+func (self *OverlayFileInfo) Data() *ordereddict.Dict {
+	result := ordereddict.NewDict()
+	data := self.FileInfo.Data()
+	if data != nil {
+		result.MergeFrom(data)
+	}
+	return result.Set("Layer", self.layer)
+}
+
+func (self *OverlayFileInfo) GetLink() (*accessors.OSPath, error) {
+	if self.link == "" {
+		return nil, errors.New("Not a symlink")
+	}
+	return resolveLink(self.path, self.link), nil
+}
+
+// Opening the layers may be expensive (compressed layers are
+// unpacked) so we keep them until the end of the query.
+type overlayCache struct {
+	mu sync.Mutex
+
+	stacks map[string]*overlayStack
+}
+
+func (self *overlayCache) Close() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	for _, stack := range self.stacks {
+		stack.Close()
+	}
+	self.stacks = make(map[string]*overlayStack)
+}
+
+func getCachedStack(
+	full_path *accessors.OSPath, scope vfilter.Scope) (*overlayStack, error) {
+	cache, pres := vql_subsystem.CacheGet(scope, OVERLAY_CACHE_TAG).(*overlayCache)
+	if !pres {
+		cache = &overlayCache{
+			stacks: make(map[string]*overlayStack),
+		}
+		// Cache will remain alive for the duration of the query.
+		vql_subsystem.GetRootScope(scope).AddDestructor(cache.Close)
+		vql_subsystem.CacheSet(scope, OVERLAY_CACHE_TAG, cache)
+	}
+
+	key := full_path.DelegatePath()
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	stack, pres := cache.stacks[key]
+	if pres {
+		return stack, nil
+	}
+
+	spec, err := parseOverlaySpec(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stack = &overlayStack{}
+	layers := spec.Lower
+	if spec.Upper != nil {
+		layers = append(layers, spec.Upper)
+	}
+
+	// Keep the top layer first.
+	for i := len(layers) - 1; i >= 0; i-- {
+		l, err := openLayer(scope, layers[i])
+		if err != nil {
+			stack.Close()
+			return nil, err
+		}
+		stack.layers = append(stack.layers, &overlayLayer{layer: l, index: i})
+	}
+
+	cache.stacks[key] = stack
+	return stack, nil
+}
+
+type OverlayFileSystemAccessor struct {
+	scope vfilter.Scope
+}
+
+func (self OverlayFileSystemAccessor) New(scope vfilter.Scope) (
+	accessors.FileSystemAccessor, error) {
+	return &OverlayFileSystemAccessor{scope: scope}, nil
+}
+
+func (self OverlayFileSystemAccessor) ParsePath(path string) (
+	*accessors.OSPath, error) {
+	return accessors.NewLinuxOSPath(path)
+}
+
+func (self OverlayFileSystemAccessor) makeFileInfo(
+	full_path *accessors.OSPath, info accessors.FileInfo,
+	l *overlayLayer) *OverlayFileInfo {
+	result := &OverlayFileInfo{
+		FileInfo: info,
+		path:     full_path,
+		layer:    l.index,
+	}
+	if info.IsLink() {
+		result.link = l.Readlink(info)
+	}
+	return result
+}
+
+func (self OverlayFileSystemAccessor) Lstat(filename string) (
+	accessors.FileInfo, error) {
+	full_path, err := self.ParsePath(filename)
+	if err != nil {
+		return nil, err
+	}
+	return self.LstatWithOSPath(full_path)
+}
+
+func (self OverlayFileSystemAccessor) LstatWithOSPath(
+	full_path *accessors.OSPath) (accessors.FileInfo, error) {
+	stack, err := getCachedStack(full_path, self.scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(full_path.Components) == 0 {
+		return &accessors.VirtualFileInfo{
+			IsDir_: true,
+			Path:   full_path,
+		}, nil
+	}
+
+	res, err := stack.lookup(full_path.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.makeFileInfo(full_path, res.info, res.layer), nil
+}
+
+func (self OverlayFileSystemAccessor) ReadDir(dir string) (
+	[]accessors.FileInfo, error) {
+	full_path, err := self.ParsePath(dir)
+	if err != nil {
+		return nil, err
+	}
+	return self.ReadDirWithOSPath(full_path)
+}
+
+func (self OverlayFileSystemAccessor) ReadDirWithOSPath(
+	full_path *accessors.OSPath) ([]accessors.FileInfo, error) {
+	stack, err := getCachedStack(full_path, self.scope)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := stack.readDir(full_path.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]accessors.FileInfo, 0, len(children))
+	for _, child := range children {
+		result = append(result, self.makeFileInfo(
+			full_path.Append(child.info.Name()), child.info, child.layer))
+	}
+	return result, nil
+}
+
+func (self OverlayFileSystemAccessor) Open(filename string) (
+	accessors.ReadSeekCloser, error) {
+	full_path, err := self.ParsePath(filename)
+	if err != nil {
+		return nil, err
+	}
+	return self.OpenWithOSPath(full_path)
+}
+
+func (self OverlayFileSystemAccessor) OpenWithOSPath(
+	full_path *accessors.OSPath) (accessors.ReadSeekCloser, error) {
+	stack, err := getCachedStack(full_path, self.scope)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := stack.lookup(full_path.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.layer == nil || res.info.IsDir() {
+		return nil, errors.New("overlay: Can not open a directory")
+	}
+
+	return res.layer.Open(full_path.Components)
+}
+
+func init() {
+	accessors.Register("overlay", &OverlayFileSystemAccessor{},
+		`Present the merged view of a stack of layers like overlayfs.
+
+The DelegatePath is a JSON object with the lower layers (bottom layer first) and an optional upper layer. Each layer is a pathspec to a directory or a (possibly compressed) tar file:
+
+{"Lower": [{"DelegateAccessor": "file", "DelegatePath": "/layers/1.tar.gz"}],
+ "Upper": {"DelegateAccessor": "file", "DelegatePath": "/upper"}}
+
+Whiteout files and opaque directories hide content in lower layers.
+`)
+}
@@ -0,0 +1,488 @@
+package overlay
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/klauspost/compress/zstd"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	// A file named .wh.<name> hides <name> in the lower layers.
+	WHITEOUT_PREFIX = ".wh."
+
+	// A directory containing this file hides the directory's
+	// content in the lower layers.
+	OPAQUE_WHITEOUT = ".wh..wh..opq"
+)
+
+var (
+	notFoundError = os.ErrNotExist
+)
+
+// A single layer of the overlay. Paths are given as components
+// relative to the layer's root.
+type layer interface {
+	Lstat(components []string) (accessors.FileInfo, error)
+	ReadDir(components []string) ([]accessors.FileInfo, error)
+	Open(components []string) (accessors.ReadSeekCloser, error)
+
+	// The symlink target as stored in the layer.
+	Readlink(info accessors.FileInfo) string
+
+	// Does the directory hide the content of lower layers?
+	IsOpaque(components []string) bool
+
+	Close()
+}
+
+// Overlayfs marks deleted files in the upper directory with a 0/0
+// character device.
+func isWhiteout(info accessors.FileInfo) bool {
+	return info.Mode()&os.ModeCharDevice != 0 && info.Size() == 0
+}
+
+// A layer backed by a directory in any accessor (e.g. an extracted
+// layer or the upper directory of a running container).
+type dirLayer struct {
+	accessor accessors.FileSystemAccessor
+	root     *accessors.OSPath
+}
+
+func (self *dirLayer) path(components []string) *accessors.OSPath {
+	return self.root.Append(components...)
+}
+
+func (self *dirLayer) Lstat(components []string) (accessors.FileInfo, error) {
+	return self.accessor.LstatWithOSPath(self.path(components))
+}
+
+func (self *dirLayer) ReadDir(components []string) ([]accessors.FileInfo, error) {
+	return self.accessor.ReadDirWithOSPath(self.path(components))
+}
+
+func (self *dirLayer) Open(components []string) (accessors.ReadSeekCloser, error) {
+	return self.accessor.OpenWithOSPath(self.path(components))
+}
+
+func (self *dirLayer) Readlink(info accessors.FileInfo) string {
+	data := info.Data()
+	if data == nil {
+		return ""
+	}
+	link, _ := data.GetString("Link")
+	return link
+}
+
+func (self *dirLayer) IsOpaque(components []string) bool {
+	_, err := self.Lstat(append(utils.CopySlice(components), OPAQUE_WHITEOUT))
+	if err == nil {
+		return true
+	}
+	return isOpaqueDirectory(self.accessor, self.path(components))
+}
+
+func (self *dirLayer) Close() {}
+
+type tarEntry struct {
+	header *tar.Header
+
+	// Offset of the data in the uncompressed tar.
+	offset int64
+
+	children map[string]*tarEntry
+}
+
+type tarFileInfo struct {
+	entry *tarEntry
+	name  string
+}
+
+func (self *tarFileInfo) Name() string {
+	return self.name
+}
+
+func (self *tarFileInfo) ModTime() time.Time {
+	return self.entry.header.ModTime
+}
+
+func (self *tarFileInfo) FullPath() string {
+	return self.entry.header.Name
+}
+
+func (self *tarFileInfo) OSPath() *accessors.OSPath {
+	return accessors.MustNewLinuxOSPath(self.entry.header.Name)
+}
+
+func (self *tarFileInfo) Btime() time.Time {
+	return time.Time{}
+}
+
+func (self *tarFileInfo) Mtime() time.Time {
+	return self.entry.header.ModTime
+}
+
+func (self *tarFileInfo) Ctime() time.Time {
+	return self.entry.header.ChangeTime
+}
+
+func (self *tarFileInfo) Atime() time.Time {
+	return self.entry.header.AccessTime
+}
+
+func (self *tarFileInfo) Data() *ordereddict.Dict {
+	result := ordereddict.NewDict().
+		Set("Uid", self.entry.header.Uid).
+		Set("Gid", self.entry.header.Gid)
+	if self.entry.header.Linkname != "" {
+		result.Set("Link", self.entry.header.Linkname)
+	}
+	return result
+}
+
+func (self *tarFileInfo) Size() int64 {
+	if self.IsDir() {
+		return 0
+	}
+	return self.entry.header.Size
+}
+
+func (self *tarFileInfo) IsDir() bool {
+	return self.entry.header.Typeflag == tar.TypeDir
+}
+
+func (self *tarFileInfo) IsLink() bool {
+	return self.entry.header.Typeflag == tar.TypeSymlink
+}
+
+func (self *tarFileInfo) GetLink() (*accessors.OSPath, error) {
+	return nil, errors.New("Not supported")
+}
+
+func (self *tarFileInfo) Mode() os.FileMode {
+	return self.entry.header.FileInfo().Mode()
+}
+
+// A layer stored as a (possibly compressed) tar file. The tar is
+// indexed once and members are read directly from the uncompressed
+// tar.
+type tarLayer struct {
+	root   *tarEntry
+	reader io.ReaderAt
+	closer func()
+}
+
+func (self *tarLayer) find(components []string) (*tarEntry, error) {
+	entry := self.root
+	for _, c := range components {
+		child, pres := entry.children[c]
+		if !pres {
+			return nil, notFoundError
+		}
+		entry = child
+	}
+	return entry, nil
+}
+
+func (self *tarLayer) Lstat(components []string) (accessors.FileInfo, error) {
+	entry, err := self.find(components)
+	if err != nil {
+		return nil, err
+	}
+
+	name := ""
+	if len(components) > 0 {
+		name = components[len(components)-1]
+	}
+	return &tarFileInfo{entry: entry, name: name}, nil
+}
+
+func (self *tarLayer) ReadDir(components []string) ([]accessors.FileInfo, error) {
+	entry, err := self.find(components)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.header.Typeflag != tar.TypeDir {
+		return nil, errors.New("Not a directory")
+	}
+
+	names := make([]string, 0, len(entry.children))
+	for name := range entry.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]accessors.FileInfo, 0, len(names))
+	for _, name := range names {
+		result = append(result, &tarFileInfo{
+			entry: entry.children[name],
+			name:  name,
+		})
+	}
+	return result, nil
+}
+
+func (self *tarLayer) Open(components []string) (accessors.ReadSeekCloser, error) {
+	entry, err := self.find(components)
+	if err != nil {
+		return nil, err
+	}
+
+	switch entry.header.Typeflag {
+	case tar.TypeReg, tar.TypeLink:
+		return utils.NopSeekCloser(
+			io.NewSectionReader(self.reader, entry.offset, entry.header.Size)), nil
+
+	default:
+		return nil, errors.New("Not a regular file")
+	}
+}
+
+func (self *tarLayer) Readlink(info accessors.FileInfo) string {
+	tar_info, ok := info.(*tarFileInfo)
+	if !ok || tar_info.entry.header.Typeflag != tar.TypeSymlink {
+		return ""
+	}
+	return tar_info.entry.header.Linkname
+}
+
+func (self *tarLayer) IsOpaque(components []string) bool {
+	entry, err := self.find(components)
+	if err != nil {
+		return false
+	}
+	_, pres := entry.children[OPAQUE_WHITEOUT]
+	return pres
+}
+
+func (self *tarLayer) Close() {
+	self.closer()
+}
+
+func newDirEntry() *tarEntry {
+	return &tarEntry{
+		header: &tar.Header{
+			Typeflag: tar.TypeDir,
+			Mode:     0755,
+		},
+		children: make(map[string]*tarEntry),
+	}
+}
+
+func splitTarPath(name string) []string {
+	name = path.Clean("/" + name)
+	if name == "/" {
+		return nil
+	}
+	return strings.Split(name[1:], "/")
+}
+
+// Index the members of an uncompressed tar file.
+func indexTar(reader io.ReaderAt, size int64) (*tarEntry, error) {
+	section := io.NewSectionReader(reader, 0, size)
+	tar_reader := tar.NewReader(section)
+	root := newDirEntry()
+	links := []*tarEntry{}
+
+	for {
+		header, err := tar_reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// The tar reader does not buffer so the section is
+		// positioned at the member's data.
+		offset, err := section.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+
+		components := splitTarPath(header.Name)
+		if len(components) == 0 {
+			continue
+		}
+
+		// Create missing parent directories.
+		parent := root
+		for _, c := range components[:len(components)-1] {
+			child, pres := parent.children[c]
+			if !pres || child.children == nil {
+				child = newDirEntry()
+				parent.children[c] = child
+			}
+			parent = child
+		}
+
+		name := components[len(components)-1]
+		entry := &tarEntry{
+			header: header,
+			offset: offset,
+		}
+
+		if header.Typeflag == tar.TypeDir {
+			// Keep the content of directories listed again.
+			existing, pres := parent.children[name]
+			if pres && existing.children != nil {
+				existing.header = header
+				continue
+			}
+			entry.children = make(map[string]*tarEntry)
+		}
+
+		if header.Typeflag == tar.TypeLink {
+			links = append(links, entry)
+		}
+
+		parent.children[name] = entry
+	}
+
+	// Hard links share the data of their target.
+	for _, link := range links {
+		target := root
+		for _, c := range splitTarPath(link.header.Linkname) {
+			target = target.children[c]
+			if target == nil {
+				break
+			}
+		}
+
+		if target != nil && target.header.Typeflag == tar.TypeReg {
+			link.offset = target.offset
+			link.header.Size = target.header.Size
+		}
+	}
+
+	return root, nil
+}
+
+// Layers are usually compressed. Since tar members need to be read
+// at random we decompress them to a temporary file first.
+func decompressLayer(
+	fd accessors.ReadSeekCloser) (io.ReaderAt, int64, func(), error) {
+	header := make([]byte, 4)
+	_, err := io.ReadFull(fd, header)
+	if err != nil {
+		fd.Close()
+		return nil, 0, nil, err
+	}
+
+	_, err = fd.Seek(0, io.SeekStart)
+	if err != nil {
+		fd.Close()
+		return nil, 0, nil, err
+	}
+
+	var decompressor io.Reader
+	switch {
+	case header[0] == 0x1f && header[1] == 0x8b:
+		gz, err := gzip.NewReader(fd)
+		if err != nil {
+			fd.Close()
+			return nil, 0, nil, err
+		}
+		defer gz.Close()
+		decompressor = gz
+
+	case string(header) == "\x28\xb5\x2f\xfd":
+		zr, err := zstd.NewReader(fd)
+		if err != nil {
+			fd.Close()
+			return nil, 0, nil, err
+		}
+		defer zr.Close()
+		decompressor = zr
+
+	default:
+		// Uncompressed tar files are read directly.
+		size, err := fd.Seek(0, io.SeekEnd)
+		if err != nil {
+			fd.Close()
+			return nil, 0, nil, err
+		}
+		return utils.MakeReaderAtter(fd), size, func() { fd.Close() }, nil
+	}
+	defer fd.Close()
+
+	tmpfile, err := ioutil.TempFile("", "overlay*.tar")
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	overlayAccessorCurrentTmpLayers.Inc()
+	closer := func() {
+		tmpfile.Close()
+		os.Remove(tmpfile.Name())
+		overlayAccessorCurrentTmpLayers.Dec()
+	}
+
+	size, err := io.Copy(tmpfile, decompressor)
+	if err != nil {
+		closer()
+		return nil, 0, nil, err
+	}
+
+	return tmpfile, size, closer, nil
+}
+
+// Open a layer. Directories are used as is and files are treated as
+// tar layers.
+func openLayer(scope vfilter.Scope,
+	pathspec *accessors.PathSpec) (layer, error) {
+	accessor_name := pathspec.DelegateAccessor
+	if accessor_name == "" {
+		accessor_name = "auto"
+	}
+
+	accessor, err := accessors.GetAccessor(accessor_name, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := accessor.ParsePath(pathspec.GetDelegatePath())
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := accessor.LstatWithOSPath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if stat.IsDir() {
+		return &dirLayer{accessor: accessor, root: root}, nil
+	}
+
+	fd, err := accessor.OpenWithOSPath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, size, closer, err := decompressLayer(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := indexTar(reader, size)
+	if err != nil {
+		closer()
+		return nil, err
+	}
+
+	return &tarLayer{root: index, reader: reader, closer: closer}, nil
+}
@@ -0,0 +1,34 @@
+//go:build linux
+// +build linux
+
+package overlay
+
+import (
+	"golang.org/x/sys/unix"
+	"www.velocidex.com/golang/velociraptor/accessors"
+)
+
+// Overlayfs marks opaque directories in the upper directory with an
+// extended attribute. This is only visible when the layer is on the
+// local filesystem.
+func isOpaqueDirectory(
+	accessor accessors.FileSystemAccessor, path *accessors.OSPath) bool {
+	raw_accessor, ok := accessor.(accessors.RawFileAPIAccessor)
+	if !ok {
+		return false
+	}
+
+	filename, err := raw_accessor.GetUnderlyingAPIFilename(path)
+	if err != nil {
+		return false
+	}
+
+	buf := make([]byte, 8)
+	for _, attr := range []string{"trusted.overlay.opaque", "user.overlay.opaque"} {
+		n, err := unix.Lgetxattr(filename, attr, buf)
+		if err == nil && n > 0 && buf[0] == 'y' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package overlay
+
+import "www.velocidex.com/golang/velociraptor/accessors"
+
+func isOpaqueDirectory(
+	accessor accessors.FileSystemAccessor, path *accessors.OSPath) bool {
+	return false
+}
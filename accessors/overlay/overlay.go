@@ -0,0 +1,222 @@
+package overlay
+
+import (
+	"errors"
+	"path"
+	"strings"
+
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/json"
+)
+
+// Describes the layers of the overlay. This is serialized into the
+// DelegatePath of the overlay accessor's pathspec.
+type OverlaySpec struct {
+	// Lower layers from the bottom layer up. This is the order of
+	// the layers in an OCI image manifest.
+	Lower []*accessors.PathSpec `json:"Lower"`
+
+	// The upper layer (e.g. the writable layer of a container) is
+	// applied last.
+	Upper *accessors.PathSpec `json:"Upper,omitempty"`
+}
+
+func (self *OverlaySpec) String() string {
+	return json.MustMarshalString(self)
+}
+
+// Build the root path for the overlay accessor.
+func NewOverlayPath(spec *OverlaySpec) *accessors.OSPath {
+	result := accessors.MustNewLinuxOSPath("")
+	result.SetPathSpec(&accessors.PathSpec{
+		DelegatePath: spec.String(),
+		Path:         "/",
+	})
+	return result
+}
+
+func parseOverlaySpec(data string) (*OverlaySpec, error) {
+	result := &OverlaySpec{}
+	err := json.Unmarshal([]byte(data), result)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Lower) == 0 && result.Upper == nil {
+		return nil, errors.New("overlay: No layers specified")
+	}
+	return result, nil
+}
+
+type overlayLayer struct {
+	layer
+
+	// Index of the layer from the bottom layer up.
+	index int
+}
+
+// The merged view of a stack of layers.
+type overlayStack struct {
+	// Top layer first.
+	layers []*overlayLayer
+}
+
+func (self *overlayStack) Close() {
+	for _, l := range self.layers {
+		l.Close()
+	}
+}
+
+type lookupResult struct {
+	info  accessors.FileInfo
+	layer *overlayLayer
+
+	// All the layers that contribute to a directory, top layer
+	// first.
+	dirs []*overlayLayer
+}
+
+// Walk down the path one component at a time, keeping track of the
+// layers that contribute to each directory.
+func (self *overlayStack) lookup(components []string) (*lookupResult, error) {
+	result := &lookupResult{dirs: self.layers}
+
+	for i, name := range components {
+		dir := components[:i]
+		path := components[:i+1]
+
+		if result.info != nil && !result.info.IsDir() {
+			return nil, notFoundError
+		}
+
+		next := &lookupResult{}
+		for _, l := range result.dirs {
+			// Files with a whiteout in this layer are hidden in
+			// the lower layers.
+			_, err := l.Lstat(append(copyComponents(dir), WHITEOUT_PREFIX+name))
+			if err == nil {
+				break
+			}
+
+			info, err := l.Lstat(path)
+			if err != nil {
+				continue
+			}
+
+			if isWhiteout(info) {
+				break
+			}
+
+			if !info.IsDir() {
+				// A file in an upper layer hides everything below
+				// it. A directory in an upper layer hides files
+				// below it.
+				if next.info == nil {
+					next.info = info
+					next.layer = l
+				}
+				break
+			}
+
+			if next.info == nil {
+				next.info = info
+				next.layer = l
+			}
+			next.dirs = append(next.dirs, l)
+
+			if l.IsOpaque(path) {
+				break
+			}
+		}
+
+		if next.info == nil {
+			return nil, notFoundError
+		}
+		result = next
+	}
+
+	return result, nil
+}
+
+type mergedEntry struct {
+	info  accessors.FileInfo
+	layer *overlayLayer
+}
+
+func (self *overlayStack) readDir(components []string) ([]*mergedEntry, error) {
+	dir, err := self.lookup(components)
+	if err != nil {
+		return nil, err
+	}
+
+	if dir.info != nil && !dir.info.IsDir() {
+		return nil, errors.New("overlay: Not a directory")
+	}
+
+	var result []*mergedEntry
+
+	// Names seen or deleted in upper layers.
+	seen := make(map[string]bool)
+	for _, l := range dir.dirs {
+		children, err := l.ReadDir(components)
+		if err != nil {
+			continue
+		}
+
+		hidden := []string{}
+		for _, child := range children {
+			name := child.Name()
+			if name == OPAQUE_WHITEOUT {
+				continue
+			}
+
+			if strings.HasPrefix(name, WHITEOUT_PREFIX) {
+				hidden = append(hidden, strings.TrimPrefix(name, WHITEOUT_PREFIX))
+				continue
+			}
+
+			if isWhiteout(child) {
+				hidden = append(hidden, name)
+				continue
+			}
+
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			result = append(result, &mergedEntry{info: child, layer: l})
+		}
+
+		// Whiteouts only apply to the lower layers.
+		for _, name := range hidden {
+			seen[name] = true
+		}
+	}
+
+	return result, nil
+}
+
+func copyComponents(components []string) []string {
+	return append([]string{}, components...)
+}
+
+// Resolve a symlink target relative to the root of the overlay.
+func resolveLink(full_path *accessors.OSPath, target string) *accessors.OSPath {
+	if !path.IsAbs(target) {
+		dir := full_path.Components
+		if len(dir) > 0 {
+			dir = dir[:len(dir)-1]
+		}
+		target = path.Join("/", strings.Join(dir, "/"), target)
+	}
+
+	result := full_path.Copy()
+	result.Components = nil
+	for _, c := range strings.Split(path.Clean("/"+target), "/") {
+		if c != "" {
+			result.Components = append(result.Components, c)
+		}
+	}
+	return result
+}
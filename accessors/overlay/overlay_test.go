@@ -0,0 +1,166 @@
+package overlay
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+type tarMember struct {
+	name     string
+	data     string
+	typeflag byte
+	link     string
+}
+
+func buildTar(t *testing.T, members []tarMember) []byte {
+	buf := &bytes.Buffer{}
+	w := tar.NewWriter(buf)
+	for _, m := range members {
+		header := &tar.Header{
+			Name:     m.name,
+			Typeflag: m.typeflag,
+			Linkname: m.link,
+			Mode:     0644,
+		}
+		if m.typeflag == tar.TypeReg {
+			header.Size = int64(len(m.data))
+		}
+		assert.NoError(t, w.WriteHeader(header))
+		if m.typeflag == tar.TypeReg {
+			_, err := w.Write([]byte(m.data))
+			assert.NoError(t, err)
+		}
+	}
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func gzipData(t *testing.T, data []byte) []byte {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	_, err := w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func readDir(t *testing.T, accessor accessors.FileSystemAccessor,
+	root *accessors.OSPath, components ...string) []string {
+	children, err := accessor.ReadDirWithOSPath(root.Append(components...))
+	assert.NoError(t, err)
+
+	result := []string{}
+	for _, child := range children {
+		result = append(result, child.Name())
+	}
+	sort.Strings(result)
+	return result
+}
+
+func readFile(t *testing.T, accessor accessors.FileSystemAccessor,
+	path *accessors.OSPath) string {
+	fd, err := accessor.OpenWithOSPath(path)
+	assert.NoError(t, err)
+	defer fd.Close()
+
+	data, err := ioutil.ReadAll(fd)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestOverlay(t *testing.T) {
+	dir := t.TempDir()
+
+	// The bottom layer is a compressed tar.
+	layer0 := filepath.Join(dir, "layer0.tar.gz")
+	assert.NoError(t, os.WriteFile(layer0, gzipData(t, buildTar(t, []tarMember{
+		{name: "./etc/", typeflag: tar.TypeDir},
+		{name: "./etc/passwd", data: "root", typeflag: tar.TypeReg},
+		{name: "./etc/hosts", data: "hosts0", typeflag: tar.TypeReg},
+		{name: "app/a", data: "a0", typeflag: tar.TypeReg},
+		{name: "app/b", data: "b0", typeflag: tar.TypeReg},
+		{name: "opt/x/y", data: "y", typeflag: tar.TypeReg},
+		{name: "link", typeflag: tar.TypeSymlink, link: "/etc/hosts"},
+		{name: "hard", typeflag: tar.TypeLink, link: "etc/passwd"},
+	})), 0600))
+
+	// The next layer deletes app/b and replaces the content of opt.
+	layer1 := filepath.Join(dir, "layer1.tar")
+	assert.NoError(t, os.WriteFile(layer1, buildTar(t, []tarMember{
+		{name: "etc/hosts", data: "hosts1", typeflag: tar.TypeReg},
+		{name: "app/.wh.b", typeflag: tar.TypeReg},
+		{name: "opt/.wh..wh..opq", typeflag: tar.TypeReg},
+		{name: "opt/z", data: "z", typeflag: tar.TypeReg},
+	}), 0600))
+
+	// The upper layer is a directory.
+	upper := filepath.Join(dir, "upper")
+	assert.NoError(t, os.MkdirAll(filepath.Join(upper, "etc"), 0700))
+	assert.NoError(t, os.MkdirAll(filepath.Join(upper, "app"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(upper, "etc", "new"), []byte("new"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(upper, "app", ".wh.a"), nil, 0600))
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	accessor, err := accessors.GetAccessor("overlay", scope)
+	assert.NoError(t, err)
+
+	root := NewOverlayPath(&OverlaySpec{
+		Lower: []*accessors.PathSpec{
+			{DelegateAccessor: "file", DelegatePath: layer0},
+			{DelegateAccessor: "file", DelegatePath: layer1},
+		},
+		Upper: &accessors.PathSpec{DelegateAccessor: "file", DelegatePath: upper},
+	})
+
+	assert.Equal(t, []string{"app", "etc", "hard", "link", "opt"},
+		readDir(t, accessor, root))
+	assert.Equal(t, []string{"hosts", "new", "passwd"},
+		readDir(t, accessor, root, "etc"))
+	assert.Equal(t, []string{}, readDir(t, accessor, root, "app"))
+	assert.Equal(t, []string{"z"}, readDir(t, accessor, root, "opt"))
+
+	// Upper layers replace files in lower layers.
+	assert.Equal(t, "hosts1", readFile(t, accessor, root.Append("etc", "hosts")))
+	assert.Equal(t, "root", readFile(t, accessor, root.Append("etc", "passwd")))
+	assert.Equal(t, "new", readFile(t, accessor, root.Append("etc", "new")))
+	assert.Equal(t, "root", readFile(t, accessor, root.Append("hard")))
+
+	info, err := accessor.LstatWithOSPath(root.Append("etc", "hosts"))
+	assert.NoError(t, err)
+	layer, _ := info.Data().Get("Layer")
+	assert.Equal(t, 1, layer)
+
+	// Deleted files are not visible.
+	for _, deleted := range [][]string{
+		{"app", "a"}, {"app", "b"}, {"opt", "x"}, {"opt", "x", "y"}} {
+		_, err = accessor.LstatWithOSPath(root.Append(deleted...))
+		assert.Error(t, err)
+	}
+
+	// Symlinks resolve inside the overlay.
+	info, err = accessor.LstatWithOSPath(root.Append("link"))
+	assert.NoError(t, err)
+	assert.True(t, info.IsLink())
+
+	target, err := info.GetLink()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"etc", "hosts"}, target.Components)
+	assert.Equal(t, "hosts1", readFile(t, accessor, target))
+}
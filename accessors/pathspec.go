@@ -64,6 +64,15 @@ var (
    },
    "Path": "zip_member.exe"}
 
+  Some accessors also accept options which change how the delegate
+  is interpreted. For example, the following asks the raw_reg
+  accessor to replay the hive's transaction logs:
+
+  {"DelegateAccessor": "file",
+   "DelegatePath": "C:/Windows/System32/config/SYSTEM",
+   "Options": {"replay_logs": "true"},
+   "Path": "/Select"}
+
   ## Note:
 
   In previous versions, the PathSpec abstraction was provided by
@@ -83,6 +92,10 @@ type PathSpec struct {
 	Delegate *PathSpec `json:"Delegate,omitempty"`
 	Path     string    `json:"Path,omitempty"`
 
+	// Accessor specific options which control how the delegate is
+	// interpreted (e.g. {"replay_logs": "true"} for raw_reg).
+	Options map[string]string `json:"Options,omitempty"`
+
 	// Keep track of if the pathspec came from a URL based for
 	// backwards compatibility.
 	url_based bool
@@ -94,6 +107,13 @@ func (self PathSpec) Copy() *PathSpec {
 		result.Delegate = result.Delegate.Copy()
 	}
 
+	if result.Options != nil {
+		result.Options = make(map[string]string)
+		for k, v := range self.Options {
+			result.Options[k] = v
+		}
+	}
+
 	return &result
 }
 
@@ -114,6 +134,10 @@ func (self PathSpec) GetPath() string {
 	return self.Path
 }
 
+func (self PathSpec) GetOption(name string) string {
+	return self.Options[name]
+}
+
 func (self PathSpec) String() string {
 	if self.url_based {
 		result := url.URL{
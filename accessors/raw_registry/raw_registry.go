@@ -31,6 +31,7 @@ package raw_registry
 import (
 	"bytes"
 	"errors"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -290,6 +291,7 @@ func getRegHive(scope vfilter.Scope,
 	base_pathspec := accessors.PathSpec{
 		DelegateAccessor: pathspec.DelegateAccessor,
 		DelegatePath:     pathspec.GetDelegatePath(),
+		Options:          pathspec.Options,
 	}
 	cache_key := base_pathspec.String()
 
@@ -323,7 +325,17 @@ func getRegHive(scope vfilter.Scope,
 		return nil, err
 	}
 
-	hive, err := regparser.NewRegistry(paged_reader)
+	var reader io.ReaderAt = paged_reader
+	if shouldReplayLogs(pathspec) {
+		reader, err = replayHive(scope, pathspec.DelegateAccessor,
+			delegate, paged_reader, int(lru_size))
+		if err != nil {
+			paged_reader.Close()
+			return nil, err
+		}
+	}
+
+	hive, err := regparser.NewRegistry(reader)
 	if err != nil {
 		paged_reader.Close()
 		return nil, err
@@ -334,6 +346,47 @@ func getRegHive(scope vfilter.Scope,
 	return hive, nil
 }
 
+// Replay the transaction logs which sit next to the hive.
+func replayHive(scope vfilter.Scope,
+	accessor string, delegate *accessors.OSPath,
+	hive_reader io.ReaderAt, lru_size int) (io.ReaderAt, error) {
+
+	var logs []io.ReaderAt
+	for _, ext := range []string{".LOG1", ".LOG2", ".LOG"} {
+		log_path := delegate.Dirname().Append(delegate.Basename() + ext)
+		log_reader, err := readers.NewAccessorReader(
+			scope, accessor, log_path, lru_size)
+		if err != nil {
+			continue
+		}
+
+		// Skip missing logs
+		header := make([]byte, 4)
+		_, err = log_reader.ReadAt(header, 0)
+		if err != nil {
+			log_reader.Close()
+			continue
+		}
+		defer log_reader.Close()
+
+		logs = append(logs, log_reader)
+	}
+
+	overlay, stats, err := replayTransactionLogs(hive_reader, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.entries > 0 {
+		scope.Log("raw_reg: Replayed %v transaction log entries from %v logs into %v (sequence number %v)",
+			stats.entries, len(logs), delegate, stats.seq)
+	} else if len(logs) == 0 {
+		scope.Log("raw_reg: No transaction logs found for %v", delegate)
+	}
+
+	return overlay, nil
+}
+
 const RawRegFileSystemTag = "_RawReg"
 
 func (self *RawRegFileSystemAccessor) New(scope vfilter.Scope) (
@@ -646,7 +699,7 @@ func init() {
 	accessors.Register("raw_reg", &RawRegFileSystemAccessor{
 		root: accessors.MustNewGenericOSPathWithBackslashSeparator(""),
 	},
-		`Access keys and values by parsing the raw registry hive. Path is a pathspec having delegate opening the raw registry hive.`)
+		`Access keys and values by parsing the raw registry hive. Path is a pathspec having delegate opening the raw registry hive. Set the replay_logs pathspec option to replay the hive's transaction logs (.LOG1/.LOG2) in memory.`)
 
 	json.RegisterCustomEncoder(&RawRegKeyInfo{}, accessors.MarshalGlobFileInfo)
 	json.RegisterCustomEncoder(&RawRegValueInfo{}, accessors.MarshalGlobFileInfo)
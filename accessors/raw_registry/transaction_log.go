@@ -0,0 +1,494 @@
+package raw_registry
+
+// Windows does not write registry changes to the primary hive file
+// immediately. Changes are first written to the transaction logs
+// (.LOG1/.LOG2 or .LOG on older systems) and only later flushed to
+// the primary file. A hive collected from a live or crashed system
+// may therefore be missing recent changes.
+//
+// This file implements replaying the transaction logs in memory on
+// top of the primary file. Both the new format logs (Windows 8.1 and
+// later - a sequence of HvLE log entries) and the legacy format logs
+// (a dirty vector followed by dirty sectors) are supported. The
+// format is described in
+// https://github.com/msuhanov/regf/blob/master/Windows%20registry%20file%20format%20specification.md
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"sort"
+	"strconv"
+
+	"www.velocidex.com/golang/regparser"
+	"www.velocidex.com/golang/velociraptor/accessors"
+)
+
+const (
+	// The primary file has a 4kb base block but the logs only
+	// store the first 512 bytes of it.
+	BASE_BLOCK_SIZE     = 0x1000
+	LOG_BASE_BLOCK_SIZE = 0x200
+	SECTOR_SIZE         = 0x200
+	HIVE_PAGE_SIZE      = 0x1000
+
+	// Offsets into the base block
+	BASE_BLOCK_PRIMARY_SEQ    = 4
+	BASE_BLOCK_SECONDARY_SEQ  = 8
+	BASE_BLOCK_FILE_TYPE      = 28
+	BASE_BLOCK_HIVE_BINS_SIZE = 40
+	BASE_BLOCK_CHECKSUM       = 508
+
+	FILE_TYPE_PRIMARY    = 0
+	FILE_TYPE_LOG_LEGACY = 1
+	FILE_TYPE_LOG_OLD    = 2
+	FILE_TYPE_LOG_NEW    = 6
+
+	LOG_ENTRY_HEADER_SIZE = 40
+
+	// Hives are limited to 2gb
+	MAX_HIVE_BINS_SIZE = 0x80000000
+
+	// Sanity check for log entry sizes.
+	MAX_LOG_ENTRY_SIZE = 0x10000000
+
+	// The seed used for the log entry hashes.
+	MARVIN32_SEED = 0x82EF4D887A4E55C5
+
+	REPLAY_LOGS_OPTION = "replay_logs"
+)
+
+var (
+	invalidBaseBlock = errors.New("Invalid base block")
+)
+
+// Returns true if the pathspec asks for the transaction logs to be
+// replayed.
+func shouldReplayLogs(pathspec *accessors.PathSpec) bool {
+	value, _ := strconv.ParseBool(pathspec.GetOption(REPLAY_LOGS_OPTION))
+	return value
+}
+
+type baseBlock struct {
+	data []byte
+
+	primary_seq    uint32
+	secondary_seq  uint32
+	file_type      uint32
+	hive_bins_size uint32
+}
+
+// A hive is dirty when a write to the primary file was started but
+// never completed.
+func (self *baseBlock) IsDirty() bool {
+	return self.primary_seq != self.secondary_seq
+}
+
+func readBaseBlock(reader io.ReaderAt) (*baseBlock, error) {
+	data := make([]byte, LOG_BASE_BLOCK_SIZE)
+	n, err := reader.ReadAt(data, 0)
+	if n < len(data) {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	if string(data[:4]) != "regf" ||
+		baseBlockChecksum(data) != binary.LittleEndian.Uint32(
+			data[BASE_BLOCK_CHECKSUM:]) {
+		return nil, invalidBaseBlock
+	}
+
+	return &baseBlock{
+		data:           data,
+		primary_seq:    binary.LittleEndian.Uint32(data[BASE_BLOCK_PRIMARY_SEQ:]),
+		secondary_seq:  binary.LittleEndian.Uint32(data[BASE_BLOCK_SECONDARY_SEQ:]),
+		file_type:      binary.LittleEndian.Uint32(data[BASE_BLOCK_FILE_TYPE:]),
+		hive_bins_size: binary.LittleEndian.Uint32(data[BASE_BLOCK_HIVE_BINS_SIZE:]),
+	}, nil
+}
+
+// The checksum is the XOR of the first 127 dwords.
+func baseBlockChecksum(data []byte) uint32 {
+	var result uint32
+	for i := 0; i < BASE_BLOCK_CHECKSUM; i += 4 {
+		result ^= binary.LittleEndian.Uint32(data[i:])
+	}
+
+	switch result {
+	case 0:
+		return 1
+	case 0xFFFFFFFF:
+		return 0xFFFFFFFE
+	}
+	return result
+}
+
+// Build the base block of the replayed hive from the base block in
+// the log.
+func replayedBaseBlock(log_block *baseBlock,
+	seq, hive_bins_size uint32) []byte {
+	result := make([]byte, BASE_BLOCK_SIZE)
+	copy(result, log_block.data)
+
+	binary.LittleEndian.PutUint32(result[BASE_BLOCK_PRIMARY_SEQ:], seq)
+	binary.LittleEndian.PutUint32(result[BASE_BLOCK_SECONDARY_SEQ:], seq)
+	binary.LittleEndian.PutUint32(result[BASE_BLOCK_FILE_TYPE:],
+		FILE_TYPE_PRIMARY)
+	binary.LittleEndian.PutUint32(result[BASE_BLOCK_HIVE_BINS_SIZE:],
+		hive_bins_size)
+	binary.LittleEndian.PutUint32(result[BASE_BLOCK_CHECKSUM:],
+		baseBlockChecksum(result))
+
+	return result
+}
+
+type dirtyPage struct {
+	// Offset relative to the start of the hive bins.
+	offset uint32
+	data   []byte
+}
+
+type logEntry struct {
+	seq            uint32
+	hive_bins_size uint32
+	pages          []dirtyPage
+
+	// The log this entry came from.
+	log *baseBlock
+}
+
+// Read the HvLE log entries from a new format log. Reading stops at
+// the first invalid entry since the rest of the log is stale.
+func readLogEntries(reader io.ReaderAt, log *baseBlock) []*logEntry {
+	var result []*logEntry
+
+	profile := regparser.NewRegistryProfile()
+	header := make([]byte, LOG_ENTRY_HEADER_SIZE)
+
+	for offset := int64(LOG_BASE_BLOCK_SIZE); ; {
+		n, _ := reader.ReadAt(header, offset)
+		if n < len(header) || string(header[:4]) != "HvLE" {
+			return result
+		}
+
+		size := binary.LittleEndian.Uint32(header[4:])
+		if size < LOG_ENTRY_HEADER_SIZE || size%SECTOR_SIZE != 0 ||
+			size > MAX_LOG_ENTRY_SIZE {
+			return result
+		}
+
+		data := make([]byte, size)
+		n, _ = reader.ReadAt(data, offset)
+		if n < len(data) {
+			return result
+		}
+
+		entry, err := parseLogEntry(profile, data)
+		if err != nil {
+			return result
+		}
+		entry.log = log
+
+		result = append(result, entry)
+		offset += int64(size)
+	}
+}
+
+func parseLogEntry(
+	profile *regparser.RegistryProfile, data []byte) (*logEntry, error) {
+	hash1 := binary.LittleEndian.Uint64(data[24:])
+	hash2 := binary.LittleEndian.Uint64(data[32:])
+
+	if marvin32(data[:32], MARVIN32_SEED) != hash2 ||
+		marvin32(data[LOG_ENTRY_HEADER_SIZE:], MARVIN32_SEED) != hash1 {
+		return nil, errors.New("Log entry hash mismatch")
+	}
+
+	log_entry := profile.HIVE_LOG_ENTRY(bytes.NewReader(data), 0)
+	result := &logEntry{
+		seq:            log_entry.SequenceNumber(),
+		hive_bins_size: log_entry.HiveBinsDataSize(),
+	}
+
+	if result.hive_bins_size%HIVE_PAGE_SIZE != 0 ||
+		result.hive_bins_size > MAX_HIVE_BINS_SIZE {
+		return nil, fmt.Errorf("Invalid hive bins data size %#x",
+			result.hive_bins_size)
+	}
+
+	// Make sure the dirty page references fit in the entry before
+	// we parse them.
+	count := int64(log_entry.DirtyPagesCount())
+	if LOG_ENTRY_HEADER_SIZE+count*8 > int64(len(data)) {
+		return nil, errors.New("Too many dirty pages")
+	}
+
+	for _, page := range log_entry.GetDirtyPages() {
+		if page.PageSize%HIVE_PAGE_SIZE != 0 ||
+			uint64(page.PageOffset)+uint64(page.PageSize) >
+				uint64(result.hive_bins_size) {
+			return nil, fmt.Errorf("Invalid dirty page at %#x",
+				page.PageOffset)
+		}
+
+		page_data, err := page.Data()
+		if err != nil {
+			return nil, err
+		}
+
+		result.pages = append(result.pages, dirtyPage{
+			offset: page.PageOffset,
+			data:   page_data,
+		})
+	}
+
+	return result, nil
+}
+
+// Legacy logs contain a single dirty vector: a bitmap with a bit for
+// each sector of the hive bins, followed by the dirty sectors.
+func readLegacyLog(reader io.ReaderAt, log *baseBlock) (*logEntry, error) {
+	// The log was not completely written.
+	if log.IsDirty() {
+		return nil, errors.New("Log is dirty")
+	}
+
+	if log.hive_bins_size%HIVE_PAGE_SIZE != 0 ||
+		log.hive_bins_size > MAX_HIVE_BINS_SIZE {
+		return nil, fmt.Errorf("Invalid hive bins data size %#x",
+			log.hive_bins_size)
+	}
+
+	bitmap := make([]byte, 4+log.hive_bins_size/SECTOR_SIZE/8)
+	n, _ := reader.ReadAt(bitmap, LOG_BASE_BLOCK_SIZE)
+	if n < len(bitmap) || string(bitmap[:4]) != "DIRT" {
+		return nil, errors.New("No dirty vector found")
+	}
+
+	result := &logEntry{
+		seq:            log.secondary_seq,
+		hive_bins_size: log.hive_bins_size,
+		log:            log,
+	}
+
+	// Dirty sectors start at the next sector boundary.
+	data_offset := (int64(LOG_BASE_BLOCK_SIZE+len(bitmap)) +
+		SECTOR_SIZE - 1) / SECTOR_SIZE * SECTOR_SIZE
+
+	for i, b := range bitmap[4:] {
+		for bit := 0; bit < 8; bit++ {
+			if b&(1<<bit) == 0 {
+				continue
+			}
+
+			data := make([]byte, SECTOR_SIZE)
+			n, _ := reader.ReadAt(data, data_offset)
+			if n < len(data) {
+				return nil, errors.New("Log is truncated")
+			}
+			data_offset += SECTOR_SIZE
+
+			result.pages = append(result.pages, dirtyPage{
+				offset: uint32(i*8+bit) * SECTOR_SIZE,
+				data:   data,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+type replayStats struct {
+	// The number of log entries (or legacy logs) applied.
+	entries int
+
+	// The sequence number of the replayed hive.
+	seq uint32
+}
+
+// Replay the transaction logs over the primary hive. Returns a reader
+// presenting the replayed hive.
+func replayTransactionLogs(reader io.ReaderAt, logs []io.ReaderAt) (
+	*hiveOverlay, *replayStats, error) {
+
+	hive, err := readBaseBlock(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	overlay := &hiveOverlay{
+		reader:  reader,
+		sectors: make(map[int64][]byte),
+	}
+	stats := &replayStats{seq: hive.secondary_seq}
+
+	var new_entries []*logEntry
+	var legacy_entry *logEntry
+
+	for _, log_reader := range logs {
+		log, err := readBaseBlock(log_reader)
+		if err != nil {
+			continue
+		}
+
+		switch log.file_type {
+		case FILE_TYPE_LOG_NEW:
+			new_entries = append(new_entries,
+				readLogEntries(log_reader, log)...)
+
+		case FILE_TYPE_LOG_LEGACY, FILE_TYPE_LOG_OLD:
+			entry, err := readLegacyLog(log_reader, log)
+			if err != nil {
+				continue
+			}
+
+			// Only the most recent legacy log is relevant.
+			if legacy_entry == nil || entry.seq > legacy_entry.seq {
+				legacy_entry = entry
+			}
+		}
+	}
+
+	// New format logs: Apply all entries the primary file may be
+	// missing in sequence number order. The entries must be
+	// consecutive - a gap means the rest are stale.
+	sort.SliceStable(new_entries, func(i, j int) bool {
+		return new_entries[i].seq < new_entries[j].seq
+	})
+
+	var last *logEntry
+	for _, entry := range new_entries {
+		if entry.seq < hive.secondary_seq {
+			continue
+		}
+
+		if last != nil {
+			// Same entry in both logs.
+			if entry.seq == last.seq {
+				continue
+			}
+
+			if entry.seq != last.seq+1 {
+				break
+			}
+		}
+
+		overlay.applyEntry(entry)
+		last = entry
+		stats.entries++
+	}
+
+	// Legacy logs are only used when the hive is dirty.
+	if last == nil && legacy_entry != nil && hive.IsDirty() &&
+		legacy_entry.seq >= hive.secondary_seq {
+		overlay.applyEntry(legacy_entry)
+		last = legacy_entry
+		stats.entries++
+	}
+
+	if last != nil {
+		stats.seq = last.seq + 1
+		overlay.set(0, replayedBaseBlock(
+			last.log, stats.seq, last.hive_bins_size))
+	}
+
+	return overlay, stats, nil
+}
+
+// Present the primary file with the replayed sectors on top.
+type hiveOverlay struct {
+	reader io.ReaderAt
+
+	// Replaced sectors keyed by sector number in the primary file.
+	sectors map[int64][]byte
+}
+
+func (self *hiveOverlay) applyEntry(entry *logEntry) {
+	for _, page := range entry.pages {
+		self.set(BASE_BLOCK_SIZE+int64(page.offset), page.data)
+	}
+}
+
+// Offset and data must be sector aligned.
+func (self *hiveOverlay) set(offset int64, data []byte) {
+	for i := 0; i+SECTOR_SIZE <= len(data); i += SECTOR_SIZE {
+		self.sectors[(offset+int64(i))/SECTOR_SIZE] = data[i : i+SECTOR_SIZE]
+	}
+}
+
+func (self *hiveOverlay) ReadAt(buf []byte, offset int64) (int, error) {
+	total := 0
+	for total < len(buf) {
+		current := offset + int64(total)
+		sector := current / SECTOR_SIZE
+		sector_offset := current % SECTOR_SIZE
+
+		data, pres := self.sectors[sector]
+		if pres {
+			total += copy(buf[total:], data[sector_offset:])
+			continue
+		}
+
+		// Read as much as we can from the primary file up to the
+		// next replaced sector.
+		end := len(buf)
+		for next := sector + 1; next*SECTOR_SIZE-offset < int64(end); next++ {
+			_, pres := self.sectors[next]
+			if pres {
+				end = int(next*SECTOR_SIZE - offset)
+				break
+			}
+		}
+
+		want := end - total
+		n, err := self.reader.ReadAt(buf[total:end], current)
+		total += n
+		if n < want {
+			if err == nil {
+				err = io.EOF
+			}
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// An implementation of the Marvin32 hash used to verify the log
+// entries.
+func marvin32(data []byte, seed uint64) uint64 {
+	lo := uint32(seed)
+	hi := uint32(seed >> 32)
+
+	block := func() {
+		hi ^= lo
+		lo = bits.RotateLeft32(lo, 20)
+		lo += hi
+		hi = bits.RotateLeft32(hi, 9)
+		hi ^= lo
+		lo = bits.RotateLeft32(lo, 27)
+		lo += hi
+		hi = bits.RotateLeft32(hi, 19)
+	}
+
+	for len(data) >= 4 {
+		lo += binary.LittleEndian.Uint32(data)
+		block()
+		data = data[4:]
+	}
+
+	final := uint32(0x80)
+	for i := len(data) - 1; i >= 0; i-- {
+		final = final<<8 | uint32(data[i])
+	}
+	lo += final
+	block()
+	block()
+
+	return uint64(hi)<<32 | uint64(lo)
+}
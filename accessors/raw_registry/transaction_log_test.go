@@ -0,0 +1,365 @@
+package raw_registry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/config"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+// Build a base block for a log file with the given sequence numbers.
+func makeLogBaseBlock(hive []byte, file_type,
+	primary, secondary, hive_bins_size uint32) []byte {
+	result := make([]byte, LOG_BASE_BLOCK_SIZE)
+	copy(result, hive)
+
+	binary.LittleEndian.PutUint32(result[BASE_BLOCK_PRIMARY_SEQ:], primary)
+	binary.LittleEndian.PutUint32(result[BASE_BLOCK_SECONDARY_SEQ:], secondary)
+	binary.LittleEndian.PutUint32(result[BASE_BLOCK_FILE_TYPE:], file_type)
+	binary.LittleEndian.PutUint32(result[BASE_BLOCK_HIVE_BINS_SIZE:],
+		hive_bins_size)
+	binary.LittleEndian.PutUint32(result[BASE_BLOCK_CHECKSUM:],
+		baseBlockChecksum(result))
+	return result
+}
+
+// Build a HvLE log entry which contains the pages of the new hive
+// at the specified hive bin offsets.
+func makeLogEntry(new_hive []byte, seq uint32, offsets ...uint32) []byte {
+	header := make([]byte, LOG_ENTRY_HEADER_SIZE)
+	copy(header, "HvLE")
+	binary.LittleEndian.PutUint32(header[12:], seq)
+	binary.LittleEndian.PutUint32(header[16:], uint32(len(new_hive)-BASE_BLOCK_SIZE))
+	binary.LittleEndian.PutUint32(header[20:], uint32(len(offsets)))
+
+	refs := &bytes.Buffer{}
+	data := &bytes.Buffer{}
+	for _, offset := range offsets {
+		binary.Write(refs, binary.LittleEndian, offset)
+		binary.Write(refs, binary.LittleEndian, uint32(HIVE_PAGE_SIZE))
+
+		start := BASE_BLOCK_SIZE + offset
+		data.Write(new_hive[start : start+HIVE_PAGE_SIZE])
+	}
+
+	result := append(header, refs.Bytes()...)
+	result = append(result, data.Bytes()...)
+	for len(result)%SECTOR_SIZE != 0 {
+		result = append(result, 0)
+	}
+	binary.LittleEndian.PutUint32(result[4:], uint32(len(result)))
+
+	binary.LittleEndian.PutUint64(result[24:],
+		marvin32(result[LOG_ENTRY_HEADER_SIZE:], MARVIN32_SEED))
+	binary.LittleEndian.PutUint64(result[32:],
+		marvin32(result[:32], MARVIN32_SEED))
+
+	return result
+}
+
+// Build a legacy log containing the sectors which differ between
+// the hives.
+func makeLegacyLog(hive, new_hive []byte, seq uint32) []byte {
+	hive_bins_size := uint32(len(new_hive) - BASE_BLOCK_SIZE)
+	result := makeLogBaseBlock(
+		hive, FILE_TYPE_LOG_LEGACY, seq, seq, hive_bins_size)
+
+	bitmap := make([]byte, hive_bins_size/SECTOR_SIZE/8)
+	data := &bytes.Buffer{}
+	for i := 0; i < int(hive_bins_size)/SECTOR_SIZE; i++ {
+		start := BASE_BLOCK_SIZE + i*SECTOR_SIZE
+		sector := new_hive[start : start+SECTOR_SIZE]
+		if !bytes.Equal(sector, hive[start:start+SECTOR_SIZE]) {
+			bitmap[i/8] |= 1 << (i % 8)
+			data.Write(sector)
+		}
+	}
+
+	result = append(result, "DIRT"...)
+	result = append(result, bitmap...)
+	for len(result)%SECTOR_SIZE != 0 {
+		result = append(result, 0)
+	}
+	return append(result, data.Bytes()...)
+}
+
+// Rename a key in the hive in place.
+func renameKey(t *testing.T, hive []byte, from, to string) []byte {
+	result := append([]byte{}, hive...)
+	for offset := 0; offset < len(result); {
+		idx := bytes.Index(result[offset:], []byte(from))
+		assert.True(t, idx >= 0)
+		offset += idx
+
+		// Key names are at offset 0x4c into the key node.
+		if offset > 0x4c && string(result[offset-0x4c:offset-0x4a]) == "nk" {
+			copy(result[offset:], to)
+			return result
+		}
+		offset++
+	}
+	return nil
+}
+
+// The hive bin offset of the page containing the key.
+func keyPage(hive []byte, name string) uint32 {
+	offset := bytes.Index(hive, []byte(name))
+	return uint32(offset-BASE_BLOCK_SIZE) / HIVE_PAGE_SIZE * HIVE_PAGE_SIZE
+}
+
+type transactionLogTest struct {
+	name         string
+	hive_seqs    [2]uint32
+	logs         map[string][]byte
+	replay, raw  []string
+	log_contains string
+}
+
+func TestTransactionLogReplay(t *testing.T) {
+	hive, err := os.ReadFile("../../artifacts/testdata/files/SAM")
+	assert.NoError(t, err)
+
+	base, err := readBaseBlock(bytes.NewReader(hive))
+	assert.NoError(t, err)
+	assert.False(t, base.IsDirty())
+
+	seq := base.secondary_seq
+	hive_bins_size := base.hive_bins_size
+
+	// Two consecutive changes to the same key.
+	first := renameKey(t, hive, "Builtin", "Changed")
+	second := renameKey(t, first, "Changed", "Twice!!")
+	page := keyPage(hive, "Builtin")
+
+	raw := []string{"Account", "Builtin"}
+
+	tests := []transactionLogTest{{
+		name:   "No logs",
+		logs:   map[string][]byte{},
+		replay: raw,
+		raw:    raw,
+	}, {
+		name: "New format log",
+		logs: map[string][]byte{
+			".LOG1": append(makeLogBaseBlock(hive, FILE_TYPE_LOG_NEW,
+				seq+1, seq+1, hive_bins_size),
+				makeLogEntry(first, seq, page)...),
+		},
+		replay:       []string{"Account", "Changed"},
+		raw:          raw,
+		log_contains: "Replayed 1 transaction log entries",
+	}, {
+		// Entries are split across both logs. Stale entries
+		// (before the hive's sequence number) and entries after a
+		// gap are ignored.
+		name: "Both new format logs",
+		logs: map[string][]byte{
+			".LOG1": bytes.Join([][]byte{
+				makeLogBaseBlock(hive, FILE_TYPE_LOG_NEW,
+					seq+2, seq+2, hive_bins_size),
+				makeLogEntry(second, seq-1, page),
+				makeLogEntry(first, seq, page),
+			}, nil),
+			".LOG2": bytes.Join([][]byte{
+				makeLogBaseBlock(hive, FILE_TYPE_LOG_NEW,
+					seq+2, seq+2, hive_bins_size),
+				makeLogEntry(second, seq+1, page),
+				makeLogEntry(hive, seq+3, page),
+			}, nil),
+		},
+		replay:       []string{"Account", "Twice!!"},
+		raw:          raw,
+		log_contains: "Replayed 2 transaction log entries from 2 logs",
+	}, {
+		name: "Corrupted log entry",
+		logs: map[string][]byte{
+			".LOG1": append(makeLogBaseBlock(hive, FILE_TYPE_LOG_NEW,
+				seq+1, seq+1, hive_bins_size),
+				corrupt(makeLogEntry(first, seq, page))...),
+		},
+		replay: raw,
+		raw:    raw,
+	}, {
+		// Legacy logs are only replayed into dirty hives.
+		name:      "Legacy log",
+		hive_seqs: [2]uint32{seq + 1, seq},
+		logs: map[string][]byte{
+			".LOG": makeLegacyLog(hive, first, seq),
+		},
+		replay:       []string{"Account", "Changed"},
+		raw:          raw,
+		log_contains: "Replayed 1 transaction log entries",
+	}, {
+		name: "Legacy log on clean hive",
+		logs: map[string][]byte{
+			".LOG": makeLegacyLog(hive, first, seq),
+		},
+		replay: raw,
+		raw:    raw,
+	}}
+
+	config_obj := config.GetDefaultConfig()
+
+	for _, test := range tests {
+		temp_dir := t.TempDir()
+
+		hive_data := append([]byte{}, hive...)
+		if test.hive_seqs[0] > 0 {
+			copy(hive_data, makeLogBaseBlock(hive, FILE_TYPE_PRIMARY,
+				test.hive_seqs[0], test.hive_seqs[1], hive_bins_size))
+		}
+
+		hive_path := filepath.Join(temp_dir, "SAM")
+		assert.NoError(t, os.WriteFile(hive_path, hive_data, 0600))
+
+		for ext, data := range test.logs {
+			assert.NoError(t, os.WriteFile(hive_path+ext, data, 0600))
+		}
+
+		logging.ClearMemoryLogs()
+		scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+			Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+		scope.SetLogger(logging.NewPlainLogger(
+			config_obj, &logging.FrontendComponent))
+
+		assert.Equal(t, test.replay, listDomains(t, scope, hive_path, true),
+			test.name)
+		assert.Equal(t, test.raw, listDomains(t, scope, hive_path, false),
+			test.name)
+
+		if test.log_contains != "" {
+			assert.Contains(t, strings.Join(logging.GetMemoryLogs(), ""),
+				test.log_contains, test.name)
+		}
+
+		scope.Close()
+	}
+}
+
+// The dirty_hive fixture is a SAM hive which was not flushed: The
+// hive's sequence numbers do not match and the last two transactions
+// are only in the LOG1 and LOG2 files next to it. It was made from
+// the SAM hive in the same directory by renaming two keys.
+func TestDirtyHiveFixture(t *testing.T) {
+	hive_path, err := filepath.Abs(
+		"../../artifacts/testdata/files/dirty_hive/SAM")
+	assert.NoError(t, err)
+
+	fd, err := os.Open(hive_path)
+	assert.NoError(t, err)
+	defer fd.Close()
+
+	base, err := readBaseBlock(fd)
+	assert.NoError(t, err)
+	assert.True(t, base.IsDirty())
+
+	config_obj := config.GetDefaultConfig()
+	logging.ClearMemoryLogs()
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	scope.SetLogger(logging.NewPlainLogger(
+		config_obj, &logging.FrontendComponent))
+	defer scope.Close()
+
+	raw := listDomains(t, scope, hive_path, false)
+	replayed := listDomains(t, scope, hive_path, true)
+
+	assert.Equal(t, []string{"Account", "Builtin"}, raw)
+	assert.Equal(t, []string{"Changed", "Updated"}, replayed)
+
+	// The keys written in the logs are only visible when replaying.
+	for _, key := range replayed {
+		assert.False(t, utils.InString(raw, key), key)
+	}
+
+	assert.Contains(t, strings.Join(logging.GetMemoryLogs(), ""),
+		"Replayed 2 transaction log entries from 2 logs")
+}
+
+func corrupt(data []byte) []byte {
+	data[len(data)-1] ^= 0xff
+	return data
+}
+
+func listDomains(t *testing.T, scope vfilter.Scope,
+	hive_path string, replay bool) []string {
+
+	reg_accessor, err := accessors.GetAccessor("raw_reg", scope)
+	assert.NoError(t, err)
+
+	pathspec := &accessors.PathSpec{
+		DelegateAccessor: "file",
+		DelegatePath:     hive_path,
+		Path:             "/SAM/Domains",
+	}
+	if replay {
+		pathspec.Options = map[string]string{REPLAY_LOGS_OPTION: "true"}
+	}
+
+	path, err := reg_accessor.ParsePath(pathspec.String())
+	assert.NoError(t, err)
+
+	children, err := reg_accessor.ReadDirWithOSPath(path)
+	assert.NoError(t, err)
+
+	result := []string{}
+	for _, child := range children {
+		if child.IsDir() {
+			result = append(result, child.Name())
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestHiveOverlay(t *testing.T) {
+	base := bytes.Repeat([]byte{'a'}, 4*SECTOR_SIZE)
+	overlay := &hiveOverlay{
+		reader:  bytes.NewReader(base),
+		sectors: make(map[int64][]byte),
+	}
+
+	// Replace a sector in the middle and one past the end of the
+	// file (the hive grew).
+	overlay.set(SECTOR_SIZE, bytes.Repeat([]byte{'b'}, SECTOR_SIZE))
+	overlay.set(4*SECTOR_SIZE, bytes.Repeat([]byte{'c'}, SECTOR_SIZE))
+
+	buf := make([]byte, 4)
+	n, err := overlay.ReadAt(buf, SECTOR_SIZE-2)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, "aabb", string(buf))
+
+	n, err = overlay.ReadAt(buf, 2*SECTOR_SIZE-2)
+	assert.NoError(t, err)
+	assert.Equal(t, "bbaa", string(buf[:n]))
+
+	n, err = overlay.ReadAt(buf, 4*SECTOR_SIZE-2)
+	assert.NoError(t, err)
+	assert.Equal(t, "aacc", string(buf[:n]))
+
+	// Reading past the end is still an EOF.
+	n, _ = overlay.ReadAt(buf, 5*SECTOR_SIZE-2)
+	assert.Equal(t, "cc", string(buf[:n]))
+}
+
+func TestMarvin32(t *testing.T) {
+	seed := uint64(0x004FB61A001BDBCC)
+	assert.Equal(t, uint64(0x30ED35C100CD3C7D), marvin32(nil, seed))
+	assert.Equal(t, uint64(0x48E73FC77D75DDC1), marvin32([]byte{0xaf}, seed))
+	assert.Equal(t, uint64(0xB5F6E1FC485DBFF8),
+		marvin32([]byte{0xe7, 0x0f}, seed))
+}
@@ -0,0 +1,396 @@
+package xfs
+
+// This is an accessor which parses an XFS filesystem
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/vfilter"
+)
+
+type XFSFileInfo struct {
+	inode      *Inode
+	name       string
+	_full_path *accessors.OSPath
+	deleted    bool
+	mode       os.FileMode
+	size       int64
+	link       string
+}
+
+func newXFSFileInfo(full_path *accessors.OSPath,
+	res *LookupResult) *XFSFileInfo {
+	result := &XFSFileInfo{
+		inode:      res.Inode,
+		name:       full_path.Basename(),
+		_full_path: full_path,
+		deleted:    res.Deleted,
+		mode:       res.Inode.FileMode(),
+		size:       res.Inode.Size,
+	}
+
+	// Freed inodes lose their mode so use the type from the
+	// directory entry.
+	if !res.Inode.IsAllocated() && res.Entry != nil {
+		switch res.Entry.Ftype {
+		case XFS_DIR3_FT_DIR:
+			result.mode = os.ModeDir | 0755
+		case XFS_DIR3_FT_SYMLINK:
+			result.mode = os.ModeSymlink | 0777
+		default:
+			result.mode = 0644
+		}
+	}
+
+	if result.deleted && result.mode.IsRegular() {
+		_, size, err := res.Inode.Reader(true)
+		if err == nil {
+			result.size = size
+		}
+	}
+
+	if result.mode&os.ModeSymlink != 0 {
+		result.link, _ = res.Inode.Readlink()
+	}
+
+	return result
+}
+
+func (self *XFSFileInfo) Name() string {
+	return self.name
+}
+
+func (self *XFSFileInfo) IsDir() bool {
+	return self.mode.IsDir()
+}
+
+func (self *XFSFileInfo) Size() int64 {
+	return self.size
+}
+
+func (self *XFSFileInfo) Mode() os.FileMode {
+	return self.mode
+}
+
+func (self *XFSFileInfo) ModTime() time.Time {
+	return self.inode.Mtime
+}
+
+func (self *XFSFileInfo) Mtime() time.Time {
+	return self.inode.Mtime
+}
+
+func (self *XFSFileInfo) Atime() time.Time {
+	return self.inode.Atime
+}
+
+func (self *XFSFileInfo) Ctime() time.Time {
+	return self.inode.Ctime
+}
+
+// Only v5 filesystems record the creation time.
+func (self *XFSFileInfo) Btime() time.Time {
+	return self.inode.Crtime
+}
+
+func (self *XFSFileInfo) Data() *ordereddict.Dict {
+	result := ordereddict.NewDict().
+		Set("Inode", self.inode.Ino).
+		Set("Uid", self.inode.Uid).
+		Set("Gid", self.inode.Gid).
+		Set("Nlink", self.inode.Nlink).
+		Set("Generation", self.inode.Generation)
+
+	if self.link != "" {
+		result.Set("Link", self.link)
+	}
+
+	if self.deleted {
+		result.Set("Deleted", true)
+	}
+	return result
+}
+
+// Deleted entries may share a name with a live entry.
+func (self *XFSFileInfo) UniqueName() string {
+	if self.deleted {
+		return fmt.Sprintf("%v:%v", self._full_path.String(), self.inode.Ino)
+	}
+	return self._full_path.String()
+}
+
+func (self *XFSFileInfo) FullPath() string {
+	return self._full_path.String()
+}
+
+func (self *XFSFileInfo) OSPath() *accessors.OSPath {
+	return self._full_path
+}
+
+func (self *XFSFileInfo) IsLink() bool {
+	return self.mode&os.ModeSymlink != 0
+}
+
+// Symlinks are resolved relative to the root of the filesystem.
+func (self *XFSFileInfo) GetLink() (*accessors.OSPath, error) {
+	if self.link == "" {
+		return nil, errors.New("Not a symlink")
+	}
+
+	target := self.link
+	if !path.IsAbs(target) {
+		dir := self._full_path.Dirname().Components
+		target = path.Join("/", strings.Join(dir, "/"), target)
+	}
+
+	result := self._full_path.Copy()
+	result.Components = nil
+	for _, c := range strings.Split(path.Clean("/"+target), "/") {
+		if c != "" {
+			result.Components = append(result.Components, c)
+		}
+	}
+	return result, nil
+}
+
+type XFSFileSystemAccessor struct {
+	scope vfilter.Scope
+
+	// The delegate accessor we use to open the underlying volume.
+	accessor string
+	device   *accessors.OSPath
+
+	root *accessors.OSPath
+}
+
+func NewXFSFileSystemAccessor(
+	scope vfilter.Scope,
+	root_path *accessors.OSPath,
+	device *accessors.OSPath, accessor string) *XFSFileSystemAccessor {
+	return &XFSFileSystemAccessor{
+		scope:    scope,
+		accessor: accessor,
+		device:   device,
+		root:     root_path,
+	}
+}
+
+func (self XFSFileSystemAccessor) New(scope vfilter.Scope) (
+	accessors.FileSystemAccessor, error) {
+	return &XFSFileSystemAccessor{
+		scope:    scope,
+		device:   self.device,
+		accessor: self.accessor,
+		root:     self.root,
+	}, nil
+}
+
+func (self XFSFileSystemAccessor) ParsePath(path string) (
+	*accessors.OSPath, error) {
+	return accessors.NewLinuxOSPath(path)
+}
+
+func (self *XFSFileSystemAccessor) ReadDir(path string) (
+	res []accessors.FileInfo, err error) {
+	fullpath, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.ReadDirWithOSPath(fullpath)
+}
+
+func (self *XFSFileSystemAccessor) ReadDirWithOSPath(
+	fullpath *accessors.OSPath) (res []accessors.FileInfo, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_xfs: %v", r)
+		}
+	}()
+
+	xfs_ctx, err := GetXFSContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := xfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := dir.Inode.ReadDir()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []accessors.FileInfo{}
+	for _, entry := range entries {
+		inode, err := xfs_ctx.OpenInode(entry.Inode)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, newXFSFileInfo(
+			fullpath.Append(entry.Name), &LookupResult{
+				Inode:   inode,
+				Entry:   entry,
+				Deleted: dir.Deleted || entry.Deleted,
+			}))
+	}
+	return result, nil
+}
+
+func (self *XFSFileSystemAccessor) Open(
+	path string) (res accessors.ReadSeekCloser, err error) {
+	full_path, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.OpenWithOSPath(full_path)
+}
+
+func (self *XFSFileSystemAccessor) OpenWithOSPath(
+	fullpath *accessors.OSPath) (res accessors.ReadSeekCloser, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_xfs: %v", r)
+		}
+	}()
+
+	xfs_ctx, err := GetXFSContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := xfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Inode.IsDir() {
+		return nil, errors.New("raw_xfs: Can not open a directory")
+	}
+
+	reader, size, err := file.Inode.Reader(file.Deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileReader{reader: reader, size: size}, nil
+}
+
+func (self *XFSFileSystemAccessor) Lstat(
+	path string) (res accessors.FileInfo, err error) {
+	fullpath, err := self.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.LstatWithOSPath(fullpath)
+}
+
+func (self *XFSFileSystemAccessor) LstatWithOSPath(
+	fullpath *accessors.OSPath) (res accessors.FileInfo, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("raw_xfs: %v", r)
+		}
+	}()
+
+	xfs_ctx, err := GetXFSContext(self.scope, self.device, fullpath, self.accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := xfs_ctx.Lookup(fullpath.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	return newXFSFileInfo(fullpath, file), nil
+}
+
+// A seekable reader over the file content.
+type fileReader struct {
+	reader io.ReaderAt
+	size   int64
+	offset int64
+}
+
+func (self *fileReader) Read(buf []byte) (int, error) {
+	n, err := self.reader.ReadAt(buf, self.offset)
+	self.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (self *fileReader) ReadAt(buf []byte, offset int64) (int, error) {
+	return self.reader.ReadAt(buf, offset)
+}
+
+func (self *fileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += self.offset
+	case io.SeekEnd:
+		offset += self.size
+	default:
+		return 0, errors.New("Invalid whence")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("Negative seek")
+	}
+	self.offset = offset
+	return offset, nil
+}
+
+func (self *fileReader) Close() error {
+	return nil
+}
+
+func init() {
+	accessors.Register("raw_xfs", &XFSFileSystemAccessor{},
+		`Access the XFS filesystem inside an image by parsing the image.
+
+This accessor is designed to operate on images directly. It requires a
+delegate accessor to get the raw image and will open files using the
+full path rooted at the top of the filesystem.
+
+Directory entries which were deleted but can still be found in the
+directory blocks are also listed, with Data.Deleted set. When the
+deleted inode still holds its extent list, the file content is
+recovered.
+
+## Example
+
+The following query will glob all the files under the directory 'a'
+inside an XFS image file
+
+SELECT *
+FROM glob(globs='/**',
+  accessor="raw_xfs",
+  root=pathspec(
+    Path="a",
+    DelegateAccessor="file",
+    DelegatePath='xfs.dd'))
+
+`)
+
+	json.RegisterCustomEncoder(&XFSFileInfo{}, accessors.MarshalGlobFileInfo)
+}
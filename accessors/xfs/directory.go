@@ -0,0 +1,291 @@
+package xfs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+const (
+	XFS_DIR2_BLOCK_MAGIC = 0x58443242 // XD2B
+	XFS_DIR2_DATA_MAGIC  = 0x58443244 // XD2D
+	XFS_DIR3_BLOCK_MAGIC = 0x58444233 // XDB3
+	XFS_DIR3_DATA_MAGIC  = 0x58444433 // XDD3
+
+	XFS_DIR2_DATA_HDR_SIZE = 16
+	XFS_DIR3_DATA_HDR_SIZE = 64
+
+	XFS_DIR2_DATA_FREE_TAG = 0xffff
+
+	XFS_DIR3_FT_UNKNOWN = 0
+	XFS_DIR3_FT_DIR     = 2
+	XFS_DIR3_FT_SYMLINK = 7
+)
+
+type DirEntry struct {
+	Name  string
+	Inode uint64
+	Ftype uint8
+
+	// Recovered from the free space of a directory block.
+	Deleted bool
+}
+
+// List the directory. Deleted entries which can still be found in
+// the directory blocks are included.
+func (self *Inode) ReadDir() ([]*DirEntry, error) {
+	if !self.IsDir() {
+		return nil, fmt.Errorf("xfs: Inode %v is not a directory", self.Ino)
+	}
+
+	switch self.Format {
+	case XFS_DINODE_FMT_LOCAL:
+		return self.readShortformDir()
+
+	case XFS_DINODE_FMT_EXTENTS, XFS_DINODE_FMT_BTREE:
+		return self.readBlockDir()
+	}
+	return nil, fmt.Errorf("xfs: Invalid directory format %v", self.Format)
+}
+
+// Small directories are stored in the inode.
+func (self *Inode) readShortformDir() ([]*DirEntry, error) {
+	data := self.dataFork
+	if self.Size < int64(len(data)) {
+		data = data[:self.Size]
+	}
+
+	if len(data) < 6 {
+		return nil, fmt.Errorf("xfs: Invalid short form directory in inode %v", self.Ino)
+	}
+
+	// If any inode number needs 64 bits they all use 64 bits.
+	count := int(data[0])
+	ino_size := 4
+	if data[1] > 0 {
+		ino_size = 8
+	}
+
+	has_ftype := self.fs.sb.HasFtype()
+	offset := 2 + ino_size
+
+	var result []*DirEntry
+	for i := 0; i < count; i++ {
+		if offset+3 > len(data) {
+			break
+		}
+
+		namelen := int(data[offset])
+		name_start := offset + 3
+		end := name_start + namelen
+		entry := &DirEntry{}
+		if has_ftype {
+			if end >= len(data) {
+				break
+			}
+			entry.Ftype = data[end]
+			end++
+		}
+
+		if end+ino_size > len(data) {
+			break
+		}
+
+		entry.Name = string(data[name_start : name_start+namelen])
+		entry.Inode = readIno(data[end:], ino_size)
+		result = append(result, entry)
+		offset = end + ino_size
+	}
+	return result, nil
+}
+
+func readIno(buf []byte, size int) uint64 {
+	if size == 8 {
+		return binary.BigEndian.Uint64(buf)
+	}
+	return uint64(binary.BigEndian.Uint32(buf))
+}
+
+func (self *Inode) readBlockDir() ([]*DirEntry, error) {
+	extents, err := self.Extents()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &extentReader{
+		fs:      self.fs,
+		extents: extents,
+		size:    self.Size,
+	}
+
+	// Directory blocks are mapped at offsets below the leaf
+	// offset. The directory size covers the data blocks only.
+	dir_block_size := self.fs.sb.DirBlockSize()
+	end := self.Size
+	if end > XFS_DIR2_LEAF_OFFSET {
+		end = XFS_DIR2_LEAF_OFFSET
+	}
+
+	var result []*DirEntry
+	seen := make(map[string]bool)
+
+	for _, e := range extents {
+		start := int64(e.Offset) << self.fs.sb.BlockLog
+		extent_end := int64(e.Offset+e.Count) << self.fs.sb.BlockLog
+		if extent_end > end {
+			extent_end = end
+		}
+
+		for offset := start; offset+dir_block_size <= extent_end; offset += dir_block_size {
+			block := make([]byte, dir_block_size)
+			_, err := reader.ReadAt(block, offset)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, entry := range self.fs.parseDirBlock(block) {
+				// Each name is listed once, preferring live
+				// entries.
+				key := entry.Name
+				if entry.Deleted {
+					key = fmt.Sprintf("%v:%v", entry.Name, entry.Inode)
+				}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				result = append(result, entry)
+			}
+		}
+	}
+
+	// Drop deleted entries which duplicate live entries.
+	filtered := result[:0]
+	for _, entry := range result {
+		if entry.Deleted && seen[entry.Name] {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered, nil
+}
+
+func roundUp8(size int) int {
+	return (size + 7) &^ 7
+}
+
+func (self *FileSystem) entrySize(namelen int) int {
+	size := 8 + 1 + namelen + 2
+	if self.sb.HasFtype() {
+		size++
+	}
+	return roundUp8(size)
+}
+
+func (self *FileSystem) parseDirBlock(block []byte) []*DirEntry {
+	header_len := XFS_DIR2_DATA_HDR_SIZE
+	end := len(block)
+
+	switch binary.BigEndian.Uint32(block) {
+	case XFS_DIR2_DATA_MAGIC:
+	case XFS_DIR3_DATA_MAGIC:
+		header_len = XFS_DIR3_DATA_HDR_SIZE
+
+	case XFS_DIR2_BLOCK_MAGIC, XFS_DIR3_BLOCK_MAGIC:
+		if binary.BigEndian.Uint32(block) == XFS_DIR3_BLOCK_MAGIC {
+			header_len = XFS_DIR3_DATA_HDR_SIZE
+		}
+
+		// Single block directories end with the hash leaf
+		// entries and a tail recording their count.
+		count := int(binary.BigEndian.Uint32(block[end-8:]))
+		end -= 8 + count*8
+		if end < header_len {
+			return nil
+		}
+
+	default:
+		return nil
+	}
+
+	var result []*DirEntry
+	offset := header_len
+	for offset+8 <= end {
+		if binary.BigEndian.Uint16(block[offset:]) == XFS_DIR2_DATA_FREE_TAG {
+			length := int(binary.BigEndian.Uint16(block[offset+2:]))
+			if length < 8 || length%8 != 0 || offset+length > end {
+				break
+			}
+			result = append(result, self.recoverDirEntries(
+				block[offset:offset+length])...)
+			offset += length
+			continue
+		}
+
+		entry, size := self.parseDirEntry(block[offset:end], 8)
+		if entry == nil {
+			break
+		}
+		if entry.Name != "." && entry.Name != ".." {
+			result = append(result, entry)
+		}
+		offset += size
+	}
+	return result
+}
+
+// Parse a directory data entry. For deleted entries the start of
+// the inode number is overwritten.
+func (self *FileSystem) parseDirEntry(buf []byte, ino_size int) (*DirEntry, int) {
+	if len(buf) < 11 {
+		return nil, 0
+	}
+
+	namelen := int(buf[8])
+	size := self.entrySize(namelen)
+	if namelen == 0 || size > len(buf) {
+		return nil, 0
+	}
+
+	result := &DirEntry{
+		Inode: readIno(buf[8-ino_size:], ino_size),
+		Name:  string(buf[9 : 9+namelen]),
+	}
+	if self.sb.HasFtype() {
+		result.Ftype = buf[9+namelen]
+	}
+	return result, size
+}
+
+// When an entry is removed its space is marked free by overwriting
+// the first 4 bytes with the free tag and length. Adjacent free
+// space is merged so a free region may hold several old entries.
+func (self *FileSystem) recoverDirEntries(buf []byte) []*DirEntry {
+	var result []*DirEntry
+
+	// Only the low 32 bits of the first inode number survive.
+	ino_size := 4
+	offset := 0
+	for offset < len(buf) {
+		entry, size := self.parseDirEntry(buf[offset:], ino_size)
+		if entry == nil || !self.plausibleEntry(entry) {
+			break
+		}
+		entry.Deleted = true
+		result = append(result, entry)
+		offset += size
+		ino_size = 8
+	}
+	return result
+}
+
+func (self *FileSystem) plausibleEntry(entry *DirEntry) bool {
+	if entry.Inode == 0 || strings.ContainsAny(entry.Name, "/\x00") ||
+		entry.Name == "." || entry.Name == ".." ||
+		entry.Ftype > XFS_DIR3_FT_SYMLINK {
+		return false
+	}
+
+	_, err := self.inodeOffset(entry.Inode)
+	return err == nil
+}
@@ -0,0 +1,241 @@
+package xfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+const (
+	XFS_BMAP_MAGIC     = 0x424d4150 // BMAP
+	XFS_BMAP_CRC_MAGIC = 0x424d4133 // BMA3
+
+	XFS_BTREE_LBLOCK_LEN     = 24
+	XFS_BTREE_LBLOCK_CRC_LEN = 72
+
+	XFS_BMBT_REC_SIZE = 16
+
+	// A bmap btree is never this deep.
+	XFS_BMAP_MAX_LEVELS = 10
+)
+
+// A mapping of a run of file blocks to filesystem blocks.
+type Extent struct {
+	// In file blocks
+	Offset uint64
+
+	// The filesystem block
+	Block uint64
+	Count uint64
+
+	// Preallocated blocks which read as zeros.
+	Unwritten bool
+}
+
+// Extent records are packed into 128 bits.
+func parseExtent(buf []byte) Extent {
+	l0 := binary.BigEndian.Uint64(buf)
+	l1 := binary.BigEndian.Uint64(buf[8:])
+	return Extent{
+		Unwritten: l0>>63 != 0,
+		Offset:    (l0 & (1<<63 - 1)) >> 9,
+		Block:     (l0&0x1ff)<<43 | l1>>21,
+		Count:     l1 & (1<<21 - 1),
+	}
+}
+
+func (self *Inode) Extents() ([]Extent, error) {
+	var result []Extent
+	var err error
+
+	switch self.Format {
+	case XFS_DINODE_FMT_EXTENTS:
+		if uint64(len(self.dataFork))/XFS_BMBT_REC_SIZE < self.NExtents {
+			return nil, fmt.Errorf("xfs: Inode %v has too many extents", self.Ino)
+		}
+
+		for i := uint64(0); i < self.NExtents; i++ {
+			result = append(result, parseExtent(self.dataFork[i*XFS_BMBT_REC_SIZE:]))
+		}
+
+	case XFS_DINODE_FMT_BTREE:
+		result, err = self.btreeExtents()
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("xfs: Inode %v has no extents (format %v)",
+			self.Ino, self.Format)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Offset < result[j].Offset
+	})
+	return result, nil
+}
+
+// When an inode is freed the extent count is cleared but the extent
+// records often remain in the inode's literal area.
+func (self *Inode) RecoverExtents() []Extent {
+	var result []Extent
+	if self.Format != XFS_DINODE_FMT_EXTENTS {
+		return nil
+	}
+
+	for i := 0; i+XFS_BMBT_REC_SIZE <= len(self.dataFork); i += XFS_BMBT_REC_SIZE {
+		extent := parseExtent(self.dataFork[i:])
+		if extent.Count == 0 || !self.fs.validBlock(extent.Block) ||
+			!self.fs.validBlock(extent.Block+extent.Count-1) {
+			break
+		}
+		result = append(result, extent)
+	}
+	return result
+}
+
+// The root of the bmap btree is stored in the inode's data fork.
+func (self *Inode) btreeExtents() ([]Extent, error) {
+	fork := self.dataFork
+	if len(fork) < 4 {
+		return nil, errors.New("xfs: Invalid bmap btree root")
+	}
+
+	level := binary.BigEndian.Uint16(fork)
+	numrecs := int(binary.BigEndian.Uint16(fork[2:]))
+	maxrecs := (len(fork) - 4) / 16
+	if numrecs > maxrecs || level == 0 || level > XFS_BMAP_MAX_LEVELS {
+		return nil, fmt.Errorf("xfs: Invalid bmap btree root in inode %v", self.Ino)
+	}
+
+	var result []Extent
+	ptrs := fork[4+maxrecs*8:]
+	for i := 0; i < numrecs; i++ {
+		err := self.walkBmapBlock(binary.BigEndian.Uint64(ptrs[i*8:]),
+			int(level)-1, &result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (self *Inode) walkBmapBlock(fsblock uint64, level int, result *[]Extent) error {
+	block, err := self.fs.readBlock(fsblock, 1)
+	if err != nil {
+		return err
+	}
+
+	header_len := XFS_BTREE_LBLOCK_LEN
+	switch binary.BigEndian.Uint32(block) {
+	case XFS_BMAP_MAGIC:
+	case XFS_BMAP_CRC_MAGIC:
+		header_len = XFS_BTREE_LBLOCK_CRC_LEN
+	default:
+		return fmt.Errorf("xfs: Invalid bmap btree block %v", fsblock)
+	}
+
+	block_level := int(binary.BigEndian.Uint16(block[4:]))
+	numrecs := int(binary.BigEndian.Uint16(block[6:]))
+	if block_level != level {
+		return fmt.Errorf("xfs: Unexpected bmap btree level in block %v", fsblock)
+	}
+
+	maxrecs := (len(block) - header_len) / 16
+	if numrecs > maxrecs {
+		return fmt.Errorf("xfs: Invalid bmap btree block %v", fsblock)
+	}
+
+	if level == 0 {
+		for i := 0; i < numrecs; i++ {
+			*result = append(*result, parseExtent(block[header_len+i*XFS_BMBT_REC_SIZE:]))
+		}
+		return nil
+	}
+
+	ptrs := block[header_len+maxrecs*8:]
+	for i := 0; i < numrecs; i++ {
+		err := self.walkBmapBlock(binary.BigEndian.Uint64(ptrs[i*8:]), level-1, result)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reads the file content through its extents. Holes and unwritten
+// extents read as zeros.
+type extentReader struct {
+	fs      *FileSystem
+	extents []Extent
+	size    int64
+}
+
+func (self *extentReader) ReadAt(buf []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, errors.New("xfs: Negative offset")
+	}
+
+	if offset >= self.size {
+		return 0, io.EOF
+	}
+
+	to_read := int64(len(buf))
+	if offset+to_read > self.size {
+		to_read = self.size - offset
+	}
+
+	block_log := self.fs.sb.BlockLog
+	n := int64(0)
+	for n < to_read {
+		pos := offset + n
+		file_block := uint64(pos >> block_log)
+
+		// Find the first extent that ends after this block.
+		idx := sort.Search(len(self.extents), func(i int) bool {
+			e := self.extents[i]
+			return e.Offset+e.Count > file_block
+		})
+
+		// By default read zeros up to the next extent.
+		run_end := to_read
+		var extent *Extent
+		if idx < len(self.extents) {
+			e := &self.extents[idx]
+			start := int64(e.Offset) << block_log
+			if start > pos {
+				if start-offset < run_end {
+					run_end = start - offset
+				}
+			} else {
+				extent = e
+				end := int64(e.Offset+e.Count)<<block_log - offset
+				if end < run_end {
+					run_end = end
+				}
+			}
+		}
+
+		chunk := buf[n:run_end]
+		if extent == nil || extent.Unwritten {
+			for i := range chunk {
+				chunk[i] = 0
+			}
+		} else {
+			delta := pos - int64(extent.Offset)<<block_log
+			err := readAt(self.fs.reader, chunk,
+				self.fs.blockOffset(extent.Block)+delta)
+			if err != nil {
+				return int(n), err
+			}
+		}
+		n = run_end
+	}
+
+	if n < int64(len(buf)) {
+		return int(n), io.EOF
+	}
+	return int(n), nil
+}
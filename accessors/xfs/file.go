@@ -0,0 +1,122 @@
+package xfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	XFS_SYMLINK_MAGIC   = 0x58534c4d // XSLM
+	XFS_SYMLINK_HDR_LEN = 56
+
+	// Symlink targets are limited to this length.
+	XFS_SYMLINK_MAXLEN = 1024
+)
+
+// Get a reader over the file's content. For deleted inodes we try to
+// recover the extents which are still in the inode.
+func (self *Inode) Reader(deleted bool) (io.ReaderAt, int64, error) {
+	switch self.Format {
+	case XFS_DINODE_FMT_LOCAL:
+		data := self.dataFork
+		if self.Size < int64(len(data)) {
+			data = data[:self.Size]
+		}
+		return bytesReader(data), int64(len(data)), nil
+
+	case XFS_DINODE_FMT_EXTENTS, XFS_DINODE_FMT_BTREE:
+		extents, err := self.Extents()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		size := self.Size
+		if deleted && len(extents) == 0 {
+			// The size is cleared when the file is truncated
+			// so present all the recovered blocks.
+			extents = self.RecoverExtents()
+			size = 0
+			for _, e := range extents {
+				end := int64(e.Offset+e.Count) << self.fs.sb.BlockLog
+				if end > size {
+					size = end
+				}
+			}
+		}
+
+		return &extentReader{
+			fs:      self.fs,
+			extents: extents,
+			size:    size,
+		}, size, nil
+	}
+
+	return nil, 0, fmt.Errorf("xfs: Unable to read inode %v with format %v",
+		self.Ino, self.Format)
+}
+
+func (self *Inode) Readlink() (string, error) {
+	if !self.IsLink() {
+		return "", errors.New("xfs: Not a symlink")
+	}
+
+	if self.Size > XFS_SYMLINK_MAXLEN {
+		return "", errors.New("xfs: Symlink target too long")
+	}
+
+	if self.Format == XFS_DINODE_FMT_LOCAL {
+		data := self.dataFork
+		if self.Size < int64(len(data)) {
+			data = data[:self.Size]
+		}
+		return string(data), nil
+	}
+
+	extents, err := self.Extents()
+	if err != nil {
+		return "", err
+	}
+
+	// Each remote symlink block has a header on v5 filesystems.
+	result := []byte{}
+	for _, e := range extents {
+		for i := uint64(0); i < e.Count && int64(len(result)) < self.Size; i++ {
+			block, err := self.fs.readBlock(e.Block+i, 1)
+			if err != nil {
+				return "", err
+			}
+
+			if self.fs.sb.IsV5() {
+				if binary.BigEndian.Uint32(block) != XFS_SYMLINK_MAGIC {
+					return "", errors.New("xfs: Invalid remote symlink block")
+				}
+				length := int(binary.BigEndian.Uint32(block[8:]))
+				if XFS_SYMLINK_HDR_LEN+length > len(block) {
+					return "", errors.New("xfs: Invalid remote symlink block")
+				}
+				block = block[XFS_SYMLINK_HDR_LEN : XFS_SYMLINK_HDR_LEN+length]
+			}
+			result = append(result, block...)
+		}
+	}
+
+	if int64(len(result)) > self.Size {
+		result = result[:self.Size]
+	}
+	return string(result), nil
+}
+
+type bytesReader []byte
+
+func (self bytesReader) ReadAt(buf []byte, offset int64) (int, error) {
+	if offset < 0 || offset >= int64(len(self)) {
+		return 0, io.EOF
+	}
+	n := copy(buf, self[offset:])
+	if n < len(buf) {
+		return n, io.EOF
+	}
+	return n, nil
+}
@@ -0,0 +1,160 @@
+package xfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	XFS_DINODE_MAGIC = 0x494e // IN
+
+	XFS_DINODE_FMT_DEV     = 0
+	XFS_DINODE_FMT_LOCAL   = 1
+	XFS_DINODE_FMT_EXTENTS = 2
+	XFS_DINODE_FMT_BTREE   = 3
+
+	XFS_DIFLAG2_BIGTIME = 1 << 3
+	XFS_DIFLAG2_NREXT64 = 1 << 4
+
+	// Size of the inode core before the data fork.
+	XFS_DINODE_CORE_SIZE_V2 = 100
+	XFS_DINODE_CORE_SIZE_V3 = 176
+
+	// Bigtime timestamps count nanoseconds from the smallest
+	// legacy timestamp (-2^31 seconds).
+	XFS_BIGTIME_EPOCH_OFFSET = 1 << 31
+)
+
+type Inode struct {
+	fs *FileSystem
+
+	Ino        uint64
+	Mode       uint16
+	Version    uint8
+	Format     uint8
+	Uid        uint32
+	Gid        uint32
+	Nlink      uint32
+	Atime      time.Time
+	Mtime      time.Time
+	Ctime      time.Time
+	Crtime     time.Time
+	Size       int64
+	NBlocks    uint64
+	NExtents   uint64
+	ForkOffset uint8
+	Flags      uint16
+	Flags2     uint64
+	Generation uint32
+
+	// The raw data fork.
+	dataFork []byte
+}
+
+func (self *FileSystem) OpenInode(ino uint64) (*Inode, error) {
+	offset, err := self.inodeOffset(ino)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, self.sb.InodeSize)
+	err = readAt(self.reader, buf, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.parseInode(ino, buf)
+}
+
+func (self *FileSystem) parseInode(ino uint64, buf []byte) (*Inode, error) {
+	if binary.BigEndian.Uint16(buf) != XFS_DINODE_MAGIC {
+		return nil, fmt.Errorf("xfs: Inode %v has invalid magic", ino)
+	}
+
+	result := &Inode{
+		fs:         self,
+		Ino:        ino,
+		Mode:       binary.BigEndian.Uint16(buf[2:]),
+		Version:    buf[4],
+		Format:     buf[5],
+		Uid:        binary.BigEndian.Uint32(buf[8:]),
+		Gid:        binary.BigEndian.Uint32(buf[12:]),
+		Nlink:      binary.BigEndian.Uint32(buf[16:]),
+		Size:       int64(binary.BigEndian.Uint64(buf[56:])),
+		NBlocks:    binary.BigEndian.Uint64(buf[64:]),
+		NExtents:   uint64(binary.BigEndian.Uint32(buf[76:])),
+		ForkOffset: buf[82],
+		Flags:      binary.BigEndian.Uint16(buf[90:]),
+		Generation: binary.BigEndian.Uint32(buf[92:]),
+	}
+
+	// Version 1 inodes keep a 16 bit link count.
+	if result.Version == 1 {
+		result.Nlink = uint32(binary.BigEndian.Uint16(buf[6:]))
+	}
+
+	core_size := XFS_DINODE_CORE_SIZE_V2
+	if result.Version >= 3 {
+		core_size = XFS_DINODE_CORE_SIZE_V3
+		result.Flags2 = binary.BigEndian.Uint64(buf[120:])
+		if result.Flags2&XFS_DIFLAG2_NREXT64 != 0 {
+			result.NExtents = binary.BigEndian.Uint64(buf[24:])
+		}
+	}
+
+	bigtime := result.Flags2&XFS_DIFLAG2_BIGTIME != 0
+	result.Atime = parseTimestamp(buf[32:], bigtime)
+	result.Mtime = parseTimestamp(buf[40:], bigtime)
+	result.Ctime = parseTimestamp(buf[48:], bigtime)
+	if result.Version >= 3 {
+		result.Crtime = parseTimestamp(buf[144:], bigtime)
+	}
+
+	if core_size > len(buf) {
+		return nil, errors.New("xfs: Inode too short")
+	}
+
+	// The data fork takes the rest of the literal area unless
+	// there is an attribute fork.
+	fork_end := len(buf)
+	if result.ForkOffset != 0 && core_size+int(result.ForkOffset)*8 < fork_end {
+		fork_end = core_size + int(result.ForkOffset)*8
+	}
+	result.dataFork = buf[core_size:fork_end]
+
+	return result, nil
+}
+
+func parseTimestamp(buf []byte, bigtime bool) time.Time {
+	if bigtime {
+		ns := binary.BigEndian.Uint64(buf)
+		sec := int64(ns/1000000000) - XFS_BIGTIME_EPOCH_OFFSET
+		return time.Unix(sec, int64(ns%1000000000)).UTC()
+	}
+
+	sec := int32(binary.BigEndian.Uint32(buf))
+	nsec := binary.BigEndian.Uint32(buf[4:])
+	return time.Unix(int64(sec), int64(nsec)).UTC()
+}
+
+func (self *Inode) FileMode() os.FileMode {
+	return utils.UnixFileMode(uint32(self.Mode))
+}
+
+func (self *Inode) IsDir() bool {
+	return self.Mode&0170000 == 0040000
+}
+
+func (self *Inode) IsLink() bool {
+	return self.Mode&0170000 == 0120000
+}
+
+// Freed inodes have their mode cleared.
+func (self *Inode) IsAllocated() bool {
+	return self.Mode != 0
+}
@@ -0,0 +1,70 @@
+package xfs
+
+import (
+	"fmt"
+	"os"
+)
+
+// The maximum number of path components we walk.
+const MAX_PATH_DEPTH = 256
+
+type LookupResult struct {
+	Inode *Inode
+
+	// The directory entry which led to the inode. This is nil for
+	// the root directory.
+	Entry *DirEntry
+
+	// The file or one of its parent directories was deleted.
+	Deleted bool
+}
+
+// Walk the path from the root directory. Deleted entries are only
+// used if there is no live entry with the same name.
+func (self *FileSystem) Lookup(components []string) (*LookupResult, error) {
+	if len(components) > MAX_PATH_DEPTH {
+		return nil, fmt.Errorf("xfs: Path too deep")
+	}
+
+	inode, err := self.OpenInode(self.sb.RootIno)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LookupResult{Inode: inode}
+	for _, name := range components {
+		if !result.Inode.IsDir() {
+			return nil, os.ErrNotExist
+		}
+
+		entries, err := result.Inode.ReadDir()
+		if err != nil {
+			return nil, err
+		}
+
+		var found *DirEntry
+		for _, entry := range entries {
+			if entry.Name == name {
+				found = entry
+				break
+			}
+		}
+
+		if found == nil {
+			return nil, os.ErrNotExist
+		}
+
+		inode, err := self.OpenInode(found.Inode)
+		if err != nil {
+			return nil, err
+		}
+
+		result = &LookupResult{
+			Inode:   inode,
+			Entry:   found,
+			Deleted: result.Deleted || found.Deleted,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,192 @@
+package xfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// On disk structures are described in "XFS Algorithms & Data
+// Structures"
+// https://www.kernel.org/pub/linux/utils/fs/xfs/docs/xfs_filesystem_structure.pdf
+const (
+	XFS_SB_MAGIC = 0x58465342 // XFSB
+
+	XFS_SB_VERSION_NUMBITS = 0x000f
+	XFS_SB_VERSION_5       = 5
+
+	XFS_SB_VERSION2_FTYPE      = 0x00000200
+	XFS_SB_FEAT_INCOMPAT_FTYPE = 0x00000001
+
+	// Directory data lives below this offset in the directory's
+	// address space. The leaf and free index blocks live above it.
+	XFS_DIR2_LEAF_OFFSET = 1 << 35
+)
+
+type Superblock struct {
+	BlockSize     uint32
+	DataBlocks    uint64
+	RootIno       uint64
+	AGBlocks      uint32
+	AGCount       uint32
+	Version       uint16
+	InodeSize     uint16
+	BlockLog      uint8
+	InodeLog      uint8
+	InopBlockLog  uint8
+	AGBlockLog    uint8
+	DirBlockLog   uint8
+	Features2     uint32
+	FeatIncompat  uint32
+	Name          string
+	UUID          [16]byte
+	InodeCount    uint64
+	FreeInodes    uint64
+	FreeDataBlock uint64
+}
+
+func (self *Superblock) IsV5() bool {
+	return self.Version&XFS_SB_VERSION_NUMBITS == XFS_SB_VERSION_5
+}
+
+// Directory entries record the file type.
+func (self *Superblock) HasFtype() bool {
+	if self.IsV5() {
+		return self.FeatIncompat&XFS_SB_FEAT_INCOMPAT_FTYPE != 0
+	}
+	return self.Features2&XFS_SB_VERSION2_FTYPE != 0
+}
+
+func (self *Superblock) DirBlockSize() int64 {
+	return int64(self.BlockSize) << self.DirBlockLog
+}
+
+func parseSuperblock(buf []byte) (*Superblock, error) {
+	if len(buf) < 256 {
+		return nil, errors.New("xfs: Superblock too short")
+	}
+
+	if binary.BigEndian.Uint32(buf) != XFS_SB_MAGIC {
+		return nil, errors.New("xfs: Invalid superblock magic")
+	}
+
+	result := &Superblock{
+		BlockSize:     binary.BigEndian.Uint32(buf[4:]),
+		DataBlocks:    binary.BigEndian.Uint64(buf[8:]),
+		RootIno:       binary.BigEndian.Uint64(buf[56:]),
+		AGBlocks:      binary.BigEndian.Uint32(buf[84:]),
+		AGCount:       binary.BigEndian.Uint32(buf[88:]),
+		Version:       binary.BigEndian.Uint16(buf[100:]),
+		InodeSize:     binary.BigEndian.Uint16(buf[104:]),
+		BlockLog:      buf[120],
+		InodeLog:      buf[122],
+		InopBlockLog:  buf[123],
+		AGBlockLog:    buf[124],
+		InodeCount:    binary.BigEndian.Uint64(buf[128:]),
+		FreeInodes:    binary.BigEndian.Uint64(buf[136:]),
+		FreeDataBlock: binary.BigEndian.Uint64(buf[144:]),
+		DirBlockLog:   buf[192],
+		Features2:     binary.BigEndian.Uint32(buf[200:]),
+		FeatIncompat:  binary.BigEndian.Uint32(buf[216:]),
+		Name:          cString(buf[108:120]),
+	}
+	copy(result.UUID[:], buf[32:48])
+
+	// Sanity check the geometry so corrupted images do not cause
+	// huge allocations.
+	if result.BlockLog < 9 || result.BlockLog > 16 ||
+		result.BlockSize != 1<<result.BlockLog {
+		return nil, fmt.Errorf("xfs: Invalid block size %v", result.BlockSize)
+	}
+
+	if result.InodeLog < 8 || result.InodeLog > 11 ||
+		result.InodeSize != 1<<result.InodeLog ||
+		result.InopBlockLog != result.BlockLog-result.InodeLog {
+		return nil, fmt.Errorf("xfs: Invalid inode size %v", result.InodeSize)
+	}
+
+	if result.AGBlocks == 0 || result.AGCount == 0 ||
+		result.AGBlockLog > 31 || uint64(result.AGBlocks) > 1<<result.AGBlockLog {
+		return nil, errors.New("xfs: Invalid allocation group geometry")
+	}
+
+	if result.DirBlockLog > 6 {
+		return nil, fmt.Errorf("xfs: Invalid directory block size")
+	}
+
+	return result, nil
+}
+
+type FileSystem struct {
+	reader io.ReaderAt
+	sb     *Superblock
+}
+
+func NewFileSystem(reader io.ReaderAt) (*FileSystem, error) {
+	buf := make([]byte, 512)
+	err := readAt(reader, buf, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	sb, err := parseSuperblock(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSystem{reader: reader, sb: sb}, nil
+}
+
+func (self *FileSystem) Superblock() *Superblock {
+	return self.sb
+}
+
+// Convert a filesystem block number (AG number and AG relative block)
+// to a byte offset in the device.
+func (self *FileSystem) blockOffset(fsblock uint64) int64 {
+	agno := fsblock >> self.sb.AGBlockLog
+	agbno := fsblock & (1<<self.sb.AGBlockLog - 1)
+	return int64(agno*uint64(self.sb.AGBlocks)+agbno) << self.sb.BlockLog
+}
+
+func (self *FileSystem) validBlock(fsblock uint64) bool {
+	agno := fsblock >> self.sb.AGBlockLog
+	agbno := fsblock & (1<<self.sb.AGBlockLog - 1)
+	return agno < uint64(self.sb.AGCount) && agbno < uint64(self.sb.AGBlocks)
+}
+
+// Inode numbers encode the AG, the AG relative block and the index
+// of the inode in the block.
+func (self *FileSystem) inodeOffset(ino uint64) (int64, error) {
+	index := ino & (1<<self.sb.InopBlockLog - 1)
+	fsblock := ino >> self.sb.InopBlockLog
+	if !self.validBlock(fsblock) {
+		return 0, fmt.Errorf("xfs: Invalid inode number %v", ino)
+	}
+	return self.blockOffset(fsblock) + int64(index)<<self.sb.InodeLog, nil
+}
+
+func (self *FileSystem) readBlock(fsblock uint64, count int64) ([]byte, error) {
+	if !self.validBlock(fsblock) {
+		return nil, fmt.Errorf("xfs: Invalid block %v", fsblock)
+	}
+
+	buf := make([]byte, count<<self.sb.BlockLog)
+	err := readAt(self.reader, buf, self.blockOffset(fsblock))
+	return buf, err
+}
+
+func readAt(reader io.ReaderAt, buf []byte, offset int64) error {
+	_, err := io.ReadFull(io.NewSectionReader(reader, offset, int64(len(buf))), buf)
+	return err
+}
+
+func cString(buf []byte) string {
+	for i, c := range buf {
+		if c == 0 {
+			return string(buf[:i])
+		}
+	}
+	return string(buf)
+}
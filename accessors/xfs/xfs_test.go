@@ -0,0 +1,266 @@
+package xfs
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+const (
+	testBlockSize = 4096
+	testInodeSize = 512
+)
+
+var (
+	testMtime  = time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+	testCrtime = time.Date(2023, 6, 7, 8, 9, 10, 0, time.UTC)
+)
+
+type testImage struct {
+	data []byte
+}
+
+func (self *testImage) block(n int) []byte {
+	return self.data[n*testBlockSize : (n+1)*testBlockSize]
+}
+
+func (self *testImage) inode(ino int) []byte {
+	return self.data[ino*testInodeSize : (ino+1)*testInodeSize]
+}
+
+func packExtent(offset, block, count uint64) []byte {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf, offset<<9|block>>43)
+	binary.BigEndian.PutUint64(buf[8:], block<<21|count)
+	return buf
+}
+
+func putTimestamp(buf []byte, t time.Time, bigtime bool) {
+	if bigtime {
+		binary.BigEndian.PutUint64(buf, uint64(t.Unix()+XFS_BIGTIME_EPOCH_OFFSET)*
+			1000000000+uint64(t.Nanosecond()))
+		return
+	}
+	binary.BigEndian.PutUint32(buf, uint32(t.Unix()))
+	binary.BigEndian.PutUint32(buf[4:], uint32(t.Nanosecond()))
+}
+
+func (self *testImage) putInode(ino int, mode uint16, format uint8,
+	size int64, nextents uint32, bigtime bool, fork []byte) {
+	buf := self.inode(ino)
+	binary.BigEndian.PutUint16(buf, XFS_DINODE_MAGIC)
+	binary.BigEndian.PutUint16(buf[2:], mode)
+	buf[4] = 3
+	buf[5] = format
+	binary.BigEndian.PutUint32(buf[8:], 1000)
+	binary.BigEndian.PutUint32(buf[12:], 1000)
+	binary.BigEndian.PutUint32(buf[16:], 1)
+	binary.BigEndian.PutUint64(buf[56:], uint64(size))
+	binary.BigEndian.PutUint32(buf[76:], nextents)
+
+	var flags2 uint64
+	if bigtime {
+		flags2 = XFS_DIFLAG2_BIGTIME
+	}
+	binary.BigEndian.PutUint64(buf[120:], flags2)
+	for _, offset := range []int{32, 40, 48} {
+		putTimestamp(buf[offset:], testMtime, bigtime)
+	}
+	putTimestamp(buf[144:], testCrtime, bigtime)
+	binary.BigEndian.PutUint64(buf[152:], uint64(ino))
+	copy(buf[XFS_DINODE_CORE_SIZE_V3:], fork)
+}
+
+func dirEntry(ino uint64, name string, ftype uint8, offset int) []byte {
+	size := roundUp8(8 + 1 + len(name) + 1 + 2)
+	buf := make([]byte, size)
+	binary.BigEndian.PutUint64(buf, ino)
+	buf[8] = uint8(len(name))
+	copy(buf[9:], name)
+	buf[9+len(name)] = ftype
+	binary.BigEndian.PutUint16(buf[size-2:], uint16(offset))
+	return buf
+}
+
+// Build a small v5 filesystem with a single allocation group.
+func buildImage() []byte {
+	img := &testImage{data: make([]byte, 64*testBlockSize)}
+
+	sb := img.block(0)
+	binary.BigEndian.PutUint32(sb, XFS_SB_MAGIC)
+	binary.BigEndian.PutUint32(sb[4:], testBlockSize)
+	binary.BigEndian.PutUint64(sb[8:], 64)
+	binary.BigEndian.PutUint64(sb[56:], 16)
+	binary.BigEndian.PutUint32(sb[84:], 64)
+	binary.BigEndian.PutUint32(sb[88:], 1)
+	binary.BigEndian.PutUint16(sb[100:], 0xb4a5)
+	binary.BigEndian.PutUint16(sb[104:], testInodeSize)
+	binary.BigEndian.PutUint16(sb[106:], 8)
+	copy(sb[108:], "testfs")
+	sb[120] = 12
+	sb[122] = 9
+	sb[123] = 3
+	sb[124] = 6
+	binary.BigEndian.PutUint32(sb[216:], XFS_SB_FEAT_INCOMPAT_FTYPE)
+
+	// Inodes are in block 2 so the first inode is 16.
+	root := []byte{4, 0, 0, 0, 0, 16}
+	for _, e := range []struct {
+		name  string
+		ftype uint8
+		ino   byte
+	}{{"file.txt", 1, 17}, {"sub", 2, 18}, {"link", 7, 19}, {"big", 1, 20}} {
+		root = append(root, uint8(len(e.name)), 0, 0)
+		root = append(root, e.name...)
+		root = append(root, e.ftype, 0, 0, 0, e.ino)
+	}
+	img.putInode(16, 040755, XFS_DINODE_FMT_LOCAL, int64(len(root)), 0, false, root)
+
+	// A two block file.
+	img.putInode(17, 0100644, XFS_DINODE_FMT_EXTENTS, 5000, 1, true,
+		packExtent(0, 10, 2))
+	copy(img.block(10), "hello")
+	copy(img.block(11), "world")
+
+	// A block directory with a deleted entry.
+	img.putInode(18, 040755, XFS_DINODE_FMT_EXTENTS, testBlockSize, 1, false,
+		packExtent(0, 12, 1))
+	dir := img.block(12)
+	binary.BigEndian.PutUint32(dir, XFS_DIR3_BLOCK_MAGIC)
+	offset := XFS_DIR3_DATA_HDR_SIZE
+	for _, e := range [][]byte{
+		dirEntry(18, ".", 2, offset), dirEntry(16, "..", 2, offset+16),
+		dirEntry(22, "a", 1, offset+32)} {
+		copy(dir[offset:], e)
+		offset += len(e)
+	}
+
+	deleted := dirEntry(21, "gone.txt", 1, offset)
+	copy(dir[offset:], deleted)
+	end := testBlockSize - 8 - 3*8
+	binary.BigEndian.PutUint16(dir[offset:], XFS_DIR2_DATA_FREE_TAG)
+	binary.BigEndian.PutUint16(dir[offset+2:], uint16(end-offset))
+	binary.BigEndian.PutUint32(dir[testBlockSize-8:], 3)
+
+	img.putInode(22, 0100600, XFS_DINODE_FMT_EXTENTS, 3, 1, false,
+		packExtent(0, 13, 1))
+	copy(img.block(13), "aaa")
+
+	// The deleted file's inode was freed but the extent remains.
+	img.putInode(21, 0, XFS_DINODE_FMT_EXTENTS, 0, 0, false,
+		packExtent(0, 14, 1))
+	copy(img.block(14), "deleted data")
+
+	img.putInode(19, 0120777, XFS_DINODE_FMT_LOCAL, 8, 0, false,
+		[]byte("file.txt"))
+
+	// A sparse file mapped through a bmap btree.
+	maxrecs := (testInodeSize - XFS_DINODE_CORE_SIZE_V3 - 4) / 16
+	bmdr := make([]byte, 4+maxrecs*16)
+	binary.BigEndian.PutUint16(bmdr, 1)
+	binary.BigEndian.PutUint16(bmdr[2:], 1)
+	binary.BigEndian.PutUint64(bmdr[4+maxrecs*8:], 15)
+	img.putInode(20, 0100644, XFS_DINODE_FMT_BTREE, 3*testBlockSize, 2, false, bmdr)
+
+	leaf := img.block(15)
+	binary.BigEndian.PutUint32(leaf, XFS_BMAP_CRC_MAGIC)
+	binary.BigEndian.PutUint16(leaf[6:], 2)
+	copy(leaf[XFS_BTREE_LBLOCK_CRC_LEN:], packExtent(0, 16, 1))
+	copy(leaf[XFS_BTREE_LBLOCK_CRC_LEN+16:], packExtent(2, 17, 1))
+	copy(img.block(16), "first")
+	copy(img.block(17), "third")
+
+	return img.data
+}
+
+func TestXFS(t *testing.T) {
+	dir := t.TempDir()
+	image := filepath.Join(dir, "xfs.dd")
+	assert.NoError(t, os.WriteFile(image, buildImage(), 0600))
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	accessor, err := accessors.GetAccessor("raw_xfs", scope)
+	assert.NoError(t, err)
+
+	root := accessors.MustNewLinuxOSPath("")
+	root.SetPathSpec(&accessors.PathSpec{
+		DelegateAccessor: "file",
+		DelegatePath:     image,
+	})
+
+	list := func(path *accessors.OSPath) []string {
+		children, err := accessor.ReadDirWithOSPath(path)
+		assert.NoError(t, err)
+
+		result := []string{}
+		for _, c := range children {
+			result = append(result, c.Name())
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	read := func(path *accessors.OSPath) string {
+		fd, err := accessor.OpenWithOSPath(path)
+		assert.NoError(t, err)
+		defer fd.Close()
+
+		data, err := ioutil.ReadAll(fd)
+		assert.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Equal(t, []string{"big", "file.txt", "link", "sub"}, list(root))
+	assert.Equal(t, []string{"a", "gone.txt"}, list(root.Append("sub")))
+
+	info, err := accessor.LstatWithOSPath(root.Append("file.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5000), info.Size())
+	assert.Equal(t, testMtime, info.Mtime())
+	assert.Equal(t, testCrtime, info.Btime())
+	assert.Equal(t, "-rw-r--r--", info.Mode().String())
+
+	data := read(root.Append("file.txt"))
+	assert.Equal(t, 5000, len(data))
+	assert.Equal(t, "hello", data[:5])
+	assert.Equal(t, "world", data[testBlockSize:testBlockSize+5])
+
+	// Holes in sparse files read as zeros.
+	data = read(root.Append("big"))
+	assert.Equal(t, 3*testBlockSize, len(data))
+	assert.Equal(t, "first", data[:5])
+	assert.Equal(t, string(make([]byte, 5)), data[testBlockSize:testBlockSize+5])
+	assert.Equal(t, "third", data[2*testBlockSize:2*testBlockSize+5])
+
+	assert.Equal(t, "aaa", read(root.Append("sub", "a")))
+
+	// Deleted entries are recovered from the directory block.
+	info, err = accessor.LstatWithOSPath(root.Append("sub", "gone.txt"))
+	assert.NoError(t, err)
+	deleted, _ := info.Data().Get("Deleted")
+	assert.Equal(t, true, deleted)
+	assert.Equal(t, int64(testBlockSize), info.Size())
+	assert.Equal(t, "deleted data", read(root.Append("sub", "gone.txt"))[:12])
+
+	info, err = accessor.LstatWithOSPath(root.Append("link"))
+	assert.NoError(t, err)
+	assert.True(t, info.IsLink())
+	target, err := info.GetLink()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"file.txt"}, target.Components)
+}
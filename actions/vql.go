@@ -143,6 +143,7 @@ func (self VQLClientAction) StartQuery(
 
 	uploader := &uploads.VelociraptorUploader{
 		Responder: responder,
+		ConfigObj: config_obj,
 	}
 
 	builder := services.ScopeBuilder{
@@ -301,6 +301,22 @@ func doDeadDisk() error {
 		}
 	}
 
+	if len(*deaddisk_command_add_linux_disk) > 0 {
+		images := []string{}
+		for _, image := range *deaddisk_command_add_linux_disk {
+			abs_path, err := filepath.Abs(image)
+			if err != nil {
+				return err
+			}
+			images = append(images, abs_path)
+		}
+
+		err = addLinuxHardDisk(images, config_obj)
+		if err != nil {
+			return err
+		}
+	}
+
 	res, err := yaml.Marshal(config_obj)
 	if err != nil {
 		return err
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/accessors/lvm"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/services"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+var (
+	deaddisk_command_add_linux_disk = deaddisk_command.Flag(
+		"add_linux_disk", "Add a Linux Hard Disk Image (may be repeated "+
+			"when LVM volume groups span several disks)").Strings()
+)
+
+// A volume which may contain a filesystem, described by the accessor
+// and pathspec used to read it.
+type linuxVolume struct {
+	description string
+	accessor    string
+	pathspec    *accessors.PathSpec
+}
+
+// Read the start of the volume to identify it.
+func (self *linuxVolume) readHeader(scope vfilter.Scope) ([]byte, error) {
+	accessor, err := accessors.GetAccessor(self.accessor, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := accessor.ParsePath(self.pathspec.String())
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := accessor.OpenWithOSPath(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	buf := make([]byte, 0x10000+0x1000)
+	total := 0
+	for total < len(buf) {
+		n, err := fd.Read(buf[total:])
+		if n == 0 || err != nil {
+			break
+		}
+		total += n
+	}
+	return buf[:total], nil
+}
+
+// Identify the raw filesystem accessor which can parse the volume
+// from its magic. LVM physical volumes are reported as "lvm".
+func detectLinuxVolume(header []byte) string {
+	for i := 0; i < 4 && (i+1)*512 <= len(header); i++ {
+		sector := header[i*512:]
+		if string(sector[:8]) == lvm.LVM_LABEL_ID &&
+			string(sector[24:32]) == lvm.LVM_LABEL_TYPE {
+			return "lvm"
+		}
+	}
+
+	switch {
+	case len(header) >= 4 && string(header[:4]) == "XFSB":
+		return "raw_xfs"
+
+	case len(header) >= 1082 &&
+		binary.LittleEndian.Uint16(header[1080:]) == 0xEF53:
+		return "raw_ext4"
+
+	case len(header) >= 0x10048 &&
+		bytes.Equal(header[0x10040:0x10048], []byte("_BHRfS_M")):
+		return "raw_btrfs"
+	}
+	return ""
+}
+
+func addLinuxHardDisk(
+	images []string,
+	config_obj *config_proto.Config) error {
+
+	logger := &LogWriter{config_obj: config_obj}
+	builder := services.ScopeBuilder{
+		Config:     config_obj,
+		ACLManager: acl_managers.NullACLManager{},
+		Logger:     log.New(logger, "", 0),
+		Env: ordereddict.NewDict().
+			Set(vql_subsystem.ACL_MANAGER_VAR,
+				acl_managers.NewRoleACLManager(config_obj, "administrator")),
+	}
+
+	manager, err := services.GetRepositoryManager(config_obj)
+	if err != nil {
+		return err
+	}
+	scope := manager.BuildScope(builder)
+	defer scope.Close()
+
+	// Find all the partitions on all the disks. Disks without a
+	// partition table are treated as a single volume.
+	var volumes []*linuxVolume
+	for _, image := range images {
+		scope.Log("Enumerating partitions of %v", image)
+		offsets := []uint64{}
+
+		accessor := "file"
+		if strings.HasSuffix(strings.ToLower(image), ".e01") {
+			accessor = "ewf"
+		}
+
+		subscope := scope.Copy()
+		subscope.AppendVars(ordereddict.NewDict().
+			Set("Accessor", accessor).
+			Set("ImagePath", image))
+		rows, err := getPartitionOffsets(subscope, image, config_obj)
+		subscope.Close()
+		if err == nil {
+			for _, row := range rows {
+				offsets = append(offsets, vql_subsystem.GetIntFromRow(
+					scope, row, "StartOffset"))
+			}
+		}
+
+		if len(offsets) == 0 {
+			offsets = append(offsets, 0)
+		}
+
+		for _, offset := range offsets {
+			volumes = append(volumes, &linuxVolume{
+				description: fmt.Sprintf("%v (offset %v)", image, offset),
+				accessor:    "offset",
+				pathspec: &accessors.PathSpec{
+					DelegateAccessor: accessor,
+					DelegatePath:     image,
+					Path:             fmt.Sprintf("%d", offset),
+				},
+			})
+		}
+	}
+
+	// Classify the volumes, collecting the LVM physical volumes.
+	var filesystems []*linuxVolume
+	var fs_accessors []string
+	pvs := &lvm.LVMSpec{}
+
+	for _, volume := range volumes {
+		header, err := volume.readHeader(scope)
+		if err != nil {
+			continue
+		}
+
+		switch fs_type := detectLinuxVolume(header); fs_type {
+		case "":
+		case "lvm":
+			scope.Log("Found LVM physical volume at %v", volume.description)
+			pvs.PVs = append(pvs.PVs, &accessors.PathSpec{
+				DelegateAccessor: volume.accessor,
+				Delegate:         volume.pathspec,
+			})
+
+		default:
+			scope.Log("Found %v filesystem at %v", fs_type, volume.description)
+			filesystems = append(filesystems, volume)
+			fs_accessors = append(fs_accessors, fs_type)
+		}
+	}
+
+	// Assemble the volume groups from all the physical volumes and
+	// check each logical volume.
+	if len(pvs.PVs) > 0 {
+		lvm_root := lvm.NewLVMPath(pvs)
+		lvm_accessor, err := accessors.GetAccessor("lvm", scope)
+		if err != nil {
+			return err
+		}
+
+		vgs, err := lvm_accessor.ReadDirWithOSPath(lvm_root)
+		if err != nil {
+			scope.Log("Unable to assemble volume groups: %v", err)
+		}
+
+		for _, vg := range vgs {
+			lvs, err := lvm_accessor.ReadDirWithOSPath(vg.OSPath())
+			if err != nil {
+				continue
+			}
+
+			for _, lv := range lvs {
+				volume := &linuxVolume{
+					description: fmt.Sprintf("LVM volume /%v/%v",
+						vg.Name(), lv.Name()),
+					accessor: "lvm",
+					pathspec: &accessors.PathSpec{
+						DelegatePath: pvs.String(),
+						Path:         fmt.Sprintf("/%v/%v", vg.Name(), lv.Name()),
+					},
+				}
+
+				header, err := volume.readHeader(scope)
+				if err != nil {
+					continue
+				}
+
+				fs_type := detectLinuxVolume(header)
+				if fs_type == "" || fs_type == "lvm" {
+					continue
+				}
+
+				scope.Log("Found %v filesystem at %v", fs_type, volume.description)
+				filesystems = append(filesystems, volume)
+				fs_accessors = append(fs_accessors, fs_type)
+			}
+		}
+	}
+
+	// The root filesystem is the one with an /etc directory.
+	for idx, volume := range filesystems {
+		fs_accessor := fs_accessors[idx]
+		mount_point := &accessors.PathSpec{
+			DelegateAccessor: volume.accessor,
+			Delegate:         volume.pathspec,
+			Path:             "/",
+		}
+
+		if !checkForLinuxRoot(scope, fs_accessor, mount_point) {
+			continue
+		}
+
+		addLinuxRootFilesystem(config_obj, scope,
+			fs_accessor, mount_point, volume.description)
+		addCommonShadowAccessors(config_obj)
+		return logger.Error
+	}
+
+	scope.Log("No Linux root filesystem found")
+	return logger.Error
+}
+
+func checkForLinuxRoot(scope vfilter.Scope,
+	fs_accessor string, mount_point *accessors.PathSpec) bool {
+	accessor, err := accessors.GetAccessor(fs_accessor, scope)
+	if err != nil {
+		return false
+	}
+
+	etc := mount_point.Copy()
+	etc.Path = "/etc"
+
+	path, err := accessor.ParsePath(etc.String())
+	if err != nil {
+		return false
+	}
+
+	stat, err := accessor.LstatWithOSPath(path)
+	return err == nil && stat.IsDir()
+}
+
+func addLinuxRootFilesystem(
+	config_obj *config_proto.Config,
+	scope vfilter.Scope,
+	fs_accessor string,
+	mount_point *accessors.PathSpec,
+	description string) {
+	addCommonPermissions(config_obj)
+
+	scope.Log("Adding Linux root filesystem at %v", description)
+
+	impersonationClause(config_obj, "linux", *deaddisk_command_hostname)
+
+	from := &config_proto.MountPoint{
+		Accessor: fs_accessor,
+		Prefix:   mount_point.String(),
+	}
+
+	// Operations of the file and auto accessors transparently use the
+	// raw filesystem accessor.
+	for _, on_accessor := range []string{"file", "auto"} {
+		config_obj.Remappings = append(config_obj.Remappings,
+			&config_proto.RemappingConfig{
+				Type: "mount",
+				Description: fmt.Sprintf(
+					"Mount the %v root filesystem %v on / (%v accessor)",
+					fs_accessor, description, on_accessor),
+				From: from,
+				On: &config_proto.MountPoint{
+					Accessor: on_accessor,
+					Prefix:   "/",
+					PathType: "linux",
+				},
+			})
+	}
+}
@@ -100,6 +100,13 @@ const (
 	// Used by the S3 accessor to configure credentials.
 	S3_CREDENTIALS = "S3_CREDENTIALS"
 
+	// Used by the luks accessor to select the secret holding the key.
+	LUKS_CONFIG = "LUKS_CONFIG"
+
+	// Used by the bitlocker accessor to select the secret holding the
+	// key.
+	BITLOCKER_CONFIG = "BITLOCKER_CONFIG"
+
 	// VQL tries to balance memory/cpu tradeoffs and also place limits
 	// on memory use. These parameters control this behavior. You can
 	// set them in the VQL environment to influence how the engine
@@ -160,6 +167,8 @@ const (
 	HTTP_SECRETS    = "HTTP Secrets"
 	SPLUNK_CREDS    = "Splunk Creds"
 	ELASTIC_CREDS   = "Elastic Creds"
+	LUKS_KEY        = "LUKS Key"
+	BITLOCKER_KEY   = "BitLocker Key"
 )
 
 type key int
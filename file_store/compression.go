@@ -0,0 +1,36 @@
+package file_store
+
+import (
+	"context"
+	"sync"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store/directory"
+)
+
+// Start the background converter which compresses existing files
+// for orgs with file store compression enabled. Only the master
+// converts files since the file store is shared with the minions.
+func StartCompressionConverter(
+	ctx context.Context, wg *sync.WaitGroup,
+	config_obj *config_proto.Config) error {
+
+	if config_obj.Datastore == nil ||
+		!directory.IsCompressionEnabled(config_obj) ||
+		(config_obj.Frontend != nil && config_obj.Frontend.IsMinion) {
+		return nil
+	}
+
+	implementation, err := datastore.GetImplementationName(config_obj)
+	if err != nil {
+		return err
+	}
+
+	switch implementation {
+	case "FileBaseDataStore", "MemcacheFileDataStore":
+		directory.NewCompressionConverter(config_obj).Start(ctx, wg)
+	}
+
+	return nil
+}
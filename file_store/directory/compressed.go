@@ -0,0 +1,956 @@
+package directory
+
+/*
+  Transparent compression for the directory file store.
+
+  Compressed files are stored as a sequence of independent zstd
+  frames, each holding up to compressedFrameSize bytes of the original
+  data. Since each frame is a complete zstd frame, the data file can
+  still be decompressed with the standard zstd tool.
+
+  To allow seeking into the file, a frame index is kept in a sidecar
+  file next to the data file (with the .zidx extension). The presence
+  of the sidecar marks the data file as compressed. All offsets and
+  sizes reported through the FileStore API are in the uncompressed
+  space so callers (e.g. the result set index) do not need to know
+  the file is compressed.
+
+  The frame index consists of a 16 byte header followed by fixed size
+  records, one per frame:
+
+  Header: Magic "VZIX" | Version uint32 | Reserved uint64
+  Record: CompressedOffset uint64 | UncompressedOffset uint64 |
+          CompressedLength uint32 | UncompressedLength uint32
+*/
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	COMPRESSED_INDEX_EXTENSION = ".zidx"
+
+	// Temporary files used while converting existing files.
+	COMPRESSED_TMP_EXTENSION = ".ztmp"
+
+	compressedIndexMagic      = "VZIX"
+	compressedIndexVersion    = 1
+	compressedIndexHeaderSize = 16
+	frameRecordSize           = 24
+
+	// Seeking requires decompressing a whole frame so keep them
+	// reasonably small.
+	compressedFrameSize = 256 * 1024
+)
+
+var (
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+	// Data in a partial frame is written this long after the last
+	// write so readers can follow the file while it is written.
+	compressedFlushDelay = time.Second
+
+	codec_once sync.Once
+	encoder    *zstd.Encoder
+	decoder    *zstd.Decoder
+
+	metricCompressedBytesIn = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "filestore_compressed_bytes_in",
+			Help: "Total number of uncompressed bytes written to compressed files",
+		})
+
+	metricCompressedBytesOut = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "filestore_compressed_bytes_out",
+			Help: "Total number of compressed bytes stored on disk",
+		})
+
+	// Very small frames may be larger than the data they hold so
+	// this can go down.
+	metricCompressionSavedBytes = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "filestore_compression_saved_bytes",
+			Help: "Total number of bytes saved by file store compression",
+		})
+)
+
+func getCodecs() (*zstd.Encoder, *zstd.Decoder) {
+	codec_once.Do(func() {
+		// EncodeAll and DecodeAll are safe for concurrent use so we
+		// share a single encoder and decoder.
+		encoder, _ = zstd.NewWriter(nil)
+		decoder, _ = zstd.NewReader(nil)
+	})
+	return encoder, decoder
+}
+
+// Is file store compression enabled for this org?
+func IsCompressionEnabled(config_obj *config_proto.Config) bool {
+	if config_obj.Datastore == nil {
+		return false
+	}
+
+	for _, org_id := range config_obj.Datastore.CompressedOrgs {
+		if org_id == "*" {
+			return true
+		}
+	}
+	return utils.OrgIdInList(utils.GetOrgId(config_obj),
+		config_obj.Datastore.CompressedOrgs)
+}
+
+// Only bulk data is compressed. Index files need to be randomly
+// accessed and are small anyway.
+func shouldCompress(
+	config_obj *config_proto.Config, filename api.FSPathSpec) bool {
+	switch filename.Type() {
+	case api.PATH_TYPE_FILESTORE_JSON, api.PATH_TYPE_FILESTORE_ANY:
+		return IsCompressionEnabled(config_obj)
+	}
+	return false
+}
+
+// A data file is compressed if it has a frame index. If a conversion
+// was interrupted the data file may still be raw so we also check
+// the magic.
+func isCompressedFile(file_path string) bool {
+	_, err := os.Lstat(file_path + COMPRESSED_INDEX_EXTENSION)
+	if err != nil {
+		return false
+	}
+
+	fd, err := os.Open(file_path)
+	if err != nil {
+		// The sidecar exists but not the data file - this can only
+		// happen when the file is about to be created.
+		return errors.Is(err, os.ErrNotExist)
+	}
+	defer fd.Close()
+
+	magic := make([]byte, len(zstdMagic))
+	n, _ := io.ReadFull(fd, magic)
+	return n == 0 || bytes.Equal(magic[:n], zstdMagic)
+}
+
+type frameRecord struct {
+	CompressedOffset   uint64
+	UncompressedOffset uint64
+	CompressedLength   uint32
+	UncompressedLength uint32
+}
+
+func (self frameRecord) End() int64 {
+	return int64(self.UncompressedOffset) + int64(self.UncompressedLength)
+}
+
+func (self frameRecord) CompressedEnd() int64 {
+	return int64(self.CompressedOffset) + int64(self.CompressedLength)
+}
+
+func (self frameRecord) MarshalBinary() []byte {
+	data := make([]byte, frameRecordSize)
+	binary.LittleEndian.PutUint64(data[0:8], self.CompressedOffset)
+	binary.LittleEndian.PutUint64(data[8:16], self.UncompressedOffset)
+	binary.LittleEndian.PutUint32(data[16:20], self.CompressedLength)
+	binary.LittleEndian.PutUint32(data[20:24], self.UncompressedLength)
+	return data
+}
+
+func parseFrameRecord(data []byte) frameRecord {
+	return frameRecord{
+		CompressedOffset:   binary.LittleEndian.Uint64(data[0:8]),
+		UncompressedOffset: binary.LittleEndian.Uint64(data[8:16]),
+		CompressedLength:   binary.LittleEndian.Uint32(data[16:20]),
+		UncompressedLength: binary.LittleEndian.Uint32(data[20:24]),
+	}
+}
+
+func writeIndexHeader(fd *os.File) error {
+	header := make([]byte, compressedIndexHeaderSize)
+	copy(header, compressedIndexMagic)
+	binary.LittleEndian.PutUint32(header[4:8], compressedIndexVersion)
+	_, err := fd.WriteAt(header, 0)
+	return err
+}
+
+func checkIndexHeader(fd *os.File) error {
+	header := make([]byte, compressedIndexHeaderSize)
+	_, err := fd.ReadAt(header, 0)
+	if err != nil {
+		return err
+	}
+
+	if string(header[:4]) != compressedIndexMagic {
+		return errors.New("Invalid frame index magic")
+	}
+
+	if binary.LittleEndian.Uint32(header[4:8]) != compressedIndexVersion {
+		return errors.New("Unsupported frame index version")
+	}
+	return nil
+}
+
+// Read all the frame records in the index starting at record number
+// first. A partially written trailing record is ignored.
+func readFrameRecords(fd *os.File, first int64) ([]frameRecord, error) {
+	stat, err := fd.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	count := (stat.Size() - compressedIndexHeaderSize) / frameRecordSize
+	if count <= first {
+		return nil, nil
+	}
+
+	data := make([]byte, (count-first)*frameRecordSize)
+	_, err = fd.ReadAt(data,
+		compressedIndexHeaderSize+first*frameRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]frameRecord, 0, count-first)
+	for i := 0; i < len(data); i += frameRecordSize {
+		result = append(result, parseFrameRecord(data[i:i+frameRecordSize]))
+	}
+	return result, nil
+}
+
+// Returns the number of frames and the last frame record.
+func readLastFrameRecord(fd *os.File) (int64, frameRecord, error) {
+	stat, err := fd.Stat()
+	if err != nil {
+		return 0, frameRecord{}, err
+	}
+
+	count := (stat.Size() - compressedIndexHeaderSize) / frameRecordSize
+	if count <= 0 {
+		return 0, frameRecord{}, nil
+	}
+
+	data := make([]byte, frameRecordSize)
+	_, err = fd.ReadAt(data,
+		compressedIndexHeaderSize+(count-1)*frameRecordSize)
+	if err != nil {
+		return 0, frameRecord{}, err
+	}
+	return count, parseFrameRecord(data), nil
+}
+
+// Get the uncompressed size of a compressed file.
+func getUncompressedSize(file_path string) (int64, error) {
+	fd, err := os.Open(file_path + COMPRESSED_INDEX_EXTENSION)
+	if err != nil {
+		return 0, err
+	}
+	defer fd.Close()
+
+	_, last, err := readLastFrameRecord(fd)
+	return last.End(), err
+}
+
+func getCompressedFileInfo(file_path string, info os.FileInfo) os.FileInfo {
+	size, err := getUncompressedSize(file_path)
+	if err != nil {
+		return info
+	}
+	return compressedFileInfo{FileInfo: info, size: size}
+}
+
+func readFrame(fd *os.File, record frameRecord) ([]byte, error) {
+	compressed := make([]byte, record.CompressedLength)
+	_, err := fd.ReadAt(compressed, int64(record.CompressedOffset))
+	if err != nil {
+		return nil, err
+	}
+
+	_, decoder := getCodecs()
+	data, err := decoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != int(record.UncompressedLength) {
+		return nil, errors.New("Compressed frame has incorrect length")
+	}
+	return data, nil
+}
+
+// Writers to the same file are serialized with a lock. This also
+// allows the converter to avoid files which are currently open for
+// writing.
+type fileLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+var (
+	file_locks_mu sync.Mutex
+	file_locks    = make(map[string]*fileLock)
+)
+
+func acquireFileLock(file_path string) *fileLock {
+	file_locks_mu.Lock()
+	defer file_locks_mu.Unlock()
+
+	lock, pres := file_locks[file_path]
+	if !pres {
+		lock = &fileLock{}
+		file_locks[file_path] = lock
+	}
+	lock.refs++
+	return lock
+}
+
+// Only acquire the lock if no one else holds it.
+func acquireIdleFileLock(file_path string) (*fileLock, bool) {
+	file_locks_mu.Lock()
+	defer file_locks_mu.Unlock()
+
+	_, pres := file_locks[file_path]
+	if pres {
+		return nil, false
+	}
+
+	lock := &fileLock{refs: 1}
+	file_locks[file_path] = lock
+	return lock, true
+}
+
+func releaseFileLock(file_path string) {
+	file_locks_mu.Lock()
+	defer file_locks_mu.Unlock()
+
+	lock, pres := file_locks[file_path]
+	if !pres {
+		return
+	}
+
+	lock.refs--
+	if lock.refs <= 0 {
+		delete(file_locks, file_path)
+	}
+}
+
+type CompressedFileWriter struct {
+	mu sync.Mutex
+
+	fd     *os.File
+	idx_fd *os.File
+
+	// Data after the last full frame. Only full frames are written
+	// by Write(), the rest is written as a partial frame when the
+	// writer is flushed and rewritten on the next flush until the
+	// frame is full.
+	buffer []byte
+
+	// The partial frame holding the start of the buffer or nil if
+	// the buffer is not written yet.
+	tail       *frameRecord
+	tail_frame int64
+
+	flush_timer *time.Timer
+	closed      bool
+
+	// Keep track of the end of the last frame.
+	frames          int64
+	size            int64
+	compressed_size int64
+
+	// The lock is shared with all writers of the same file.
+	lock      *fileLock
+	lock_path string
+
+	completion func()
+}
+
+// Open a compressed file for writing. The caller must hold the file
+// lock for lock_path.
+func openCompressedFileWriter(
+	data_path, index_path, lock_path string, lock *fileLock,
+	completion func()) (*CompressedFileWriter, error) {
+
+	idx_fd, err := os.OpenFile(index_path, os.O_RDWR|os.O_CREATE, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := os.OpenFile(data_path, os.O_RDWR|os.O_CREATE, 0700)
+	if err != nil {
+		idx_fd.Close()
+		return nil, err
+	}
+
+	result := &CompressedFileWriter{
+		fd:         fd,
+		idx_fd:     idx_fd,
+		lock:       lock,
+		lock_path:  lock_path,
+		completion: completion,
+	}
+
+	stat, err := idx_fd.Stat()
+	if err == nil && stat.Size() == 0 {
+		err = writeIndexHeader(idx_fd)
+	} else if err == nil {
+		err = checkIndexHeader(idx_fd)
+	}
+
+	if err == nil {
+		var last frameRecord
+		last, err = result.loadTail()
+		if err == nil {
+			err = result.loadPartialFrame(last)
+		}
+	}
+
+	if err != nil {
+		fd.Close()
+		idx_fd.Close()
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Refresh the end of file from the index in case another writer
+// appended to it. Returns the last frame record.
+func (self *CompressedFileWriter) loadTail() (frameRecord, error) {
+	frames, last, err := readLastFrameRecord(self.idx_fd)
+	if err != nil {
+		return last, err
+	}
+
+	self.frames = frames
+	self.size = last.End()
+	self.compressed_size = last.CompressedEnd()
+	return last, nil
+}
+
+// Files are often reopened to append a little data (e.g. logs). To
+// avoid starting a new small frame each time, a partial last frame
+// is loaded into the buffer and replaced on the next flush. Must be
+// called with the file lock held.
+func (self *CompressedFileWriter) loadPartialFrame(last frameRecord) error {
+	if self.frames == 0 || last.UncompressedLength >= compressedFrameSize {
+		return nil
+	}
+
+	data, err := readFrame(self.fd, last)
+	if err != nil {
+		return err
+	}
+
+	self.buffer = data
+	self.tail = &last
+	self.tail_frame = self.frames - 1
+	return nil
+}
+
+// Compress data into a new frame. Must be called with the file lock
+// held.
+func (self *CompressedFileWriter) writeFrame(data []byte) (frameRecord, error) {
+	encoder, _ := getCodecs()
+	compressed := encoder.EncodeAll(data, nil)
+
+	record := frameRecord{
+		CompressedOffset:   uint64(self.compressed_size),
+		UncompressedOffset: uint64(self.size),
+		CompressedLength:   uint32(len(compressed)),
+		UncompressedLength: uint32(len(data)),
+	}
+
+	_, err := self.fd.WriteAt(compressed, self.compressed_size)
+	if err != nil {
+		return record, err
+	}
+
+	// Remove any junk left over from a previously interrupted write.
+	err = self.fd.Truncate(record.CompressedEnd())
+	if err != nil {
+		return record, err
+	}
+
+	_, err = self.idx_fd.WriteAt(record.MarshalBinary(),
+		compressedIndexHeaderSize+self.frames*frameRecordSize)
+	if err != nil {
+		return record, err
+	}
+
+	self.frames++
+	self.size = record.End()
+	self.compressed_size = record.CompressedEnd()
+
+	metricCompressedBytesIn.Add(float64(len(data)))
+	metricCompressedBytesOut.Add(float64(len(compressed)))
+	metricCompressionSavedBytes.Add(float64(len(data) - len(compressed)))
+
+	return record, nil
+}
+
+func (self *CompressedFileWriter) writeFrames(data []byte) error {
+	for len(data) > 0 {
+		length := len(data)
+		if length > compressedFrameSize {
+			length = compressedFrameSize
+		}
+
+		_, err := self.writeFrame(data[:length])
+		if err != nil {
+			return err
+		}
+		data = data[length:]
+	}
+	return nil
+}
+
+// Compress the full frames in the buffer. When partial is set the
+// trailing partial frame is written as well so readers see all the
+// data written so far. It is kept in the buffer and replaced by the
+// next flush so small writes do not produce many small frames.
+func (self *CompressedFileWriter) flushBuffer(partial bool) error {
+	tail_length := 0
+	if self.tail != nil {
+		tail_length = int(self.tail.UncompressedLength)
+	}
+
+	// Nothing was added since the last flush.
+	if len(self.buffer) == tail_length ||
+		(!partial && len(self.buffer) < compressedFrameSize) {
+		return nil
+	}
+
+	self.lock.mu.Lock()
+	defer self.lock.mu.Unlock()
+
+	last, err := self.loadTail()
+	if err != nil {
+		return err
+	}
+
+	if self.tail != nil {
+		if self.tail_frame == self.frames-1 && last == *self.tail {
+			// Drop the partial frame so it can be rewritten.
+			self.frames = self.tail_frame
+			self.size = int64(self.tail.UncompressedOffset)
+			self.compressed_size = int64(self.tail.CompressedOffset)
+
+		} else {
+			// Another writer changed the file after our partial
+			// frame so it has to stay as it is.
+			self.buffer = append(self.buffer[:0],
+				self.buffer[tail_length:]...)
+		}
+		self.tail = nil
+	}
+
+	full := len(self.buffer) - len(self.buffer)%compressedFrameSize
+	err = self.writeFrames(self.buffer[:full])
+	if err != nil {
+		return err
+	}
+
+	self.buffer = append(self.buffer[:0], self.buffer[full:]...)
+	if len(self.buffer) == 0 || !partial {
+		return nil
+	}
+
+	tail, err := self.writeFrame(self.buffer)
+	if err != nil {
+		return err
+	}
+
+	self.tail = &tail
+	self.tail_frame = self.frames - 1
+	return nil
+}
+
+// Write the partial frame some time after the last write.
+func (self *CompressedFileWriter) scheduleFlush() {
+	if self.flush_timer != nil {
+		return
+	}
+
+	self.flush_timer = time.AfterFunc(compressedFlushDelay, func() {
+		self.mu.Lock()
+		defer self.mu.Unlock()
+
+		self.flush_timer = nil
+		if !self.closed {
+			_ = self.flushBuffer(true)
+		}
+	})
+}
+
+func (self *CompressedFileWriter) stopFlush() {
+	if self.flush_timer != nil {
+		self.flush_timer.Stop()
+		self.flush_timer = nil
+	}
+}
+
+func (self *CompressedFileWriter) Size() (int64, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.lock.mu.Lock()
+	_, err := self.loadTail()
+	self.lock.mu.Unlock()
+
+	// The part of the buffer which is not written yet.
+	pending := int64(len(self.buffer))
+	if self.tail != nil {
+		pending -= int64(self.tail.UncompressedLength)
+	}
+	return self.size + pending, err
+}
+
+func (self *CompressedFileWriter) Write(data []byte) (int, error) {
+	defer api.InstrumentWithDelay("write", "CompressedFileWriter", nil)()
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.buffer = append(self.buffer, data...)
+	err := self.flushBuffer(false)
+	if err != nil {
+		return 0, err
+	}
+
+	self.scheduleFlush()
+	return len(data), nil
+}
+
+// Updating compressed data in place requires rewriting all the
+// frames from the update offset to the end of the file.
+func (self *CompressedFileWriter) Update(data []byte, offset int64) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	err := self.flushBuffer(true)
+	if err != nil {
+		return err
+	}
+
+	self.lock.mu.Lock()
+	defer self.lock.mu.Unlock()
+
+	// The frames are rewritten below so the buffer is no longer
+	// needed.
+	self.buffer = self.buffer[:0]
+	self.tail = nil
+
+	records, err := readFrameRecords(self.idx_fd, 0)
+	if err != nil {
+		return err
+	}
+
+	// Find the first frame affected by the update.
+	first := sort.Search(len(records), func(i int) bool {
+		return records[i].End() > offset
+	})
+
+	var tail []byte
+	base := int64(0)
+	compressed_base := int64(0)
+	if first < len(records) {
+		base = int64(records[first].UncompressedOffset)
+		compressed_base = int64(records[first].CompressedOffset)
+	} else if len(records) > 0 {
+		base = records[len(records)-1].End()
+		compressed_base = records[len(records)-1].CompressedEnd()
+	}
+
+	for _, record := range records[first:] {
+		frame, err := readFrame(self.fd, record)
+		if err != nil {
+			return err
+		}
+		tail = append(tail, frame...)
+	}
+
+	// Updating past the end of the file pads with zeros just like a
+	// sparse file.
+	relative := int(offset - base)
+	if relative+len(data) > len(tail) {
+		tail = append(tail, make([]byte, relative+len(data)-len(tail))...)
+	}
+	copy(tail[relative:], data)
+
+	// Drop the old frames and rewrite the tail.
+	err = self.idx_fd.Truncate(
+		compressedIndexHeaderSize + int64(first)*frameRecordSize)
+	if err != nil {
+		return err
+	}
+
+	self.frames = int64(first)
+	self.size = base
+	self.compressed_size = compressed_base
+
+	return self.writeFrames(tail)
+}
+
+func (self *CompressedFileWriter) Truncate() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.lock.mu.Lock()
+	defer self.lock.mu.Unlock()
+
+	self.buffer = self.buffer[:0]
+	self.tail = nil
+	self.frames = 0
+	self.size = 0
+	self.compressed_size = 0
+
+	err := self.fd.Truncate(0)
+	if err != nil {
+		return err
+	}
+	return self.idx_fd.Truncate(compressedIndexHeaderSize)
+}
+
+func (self *CompressedFileWriter) Flush() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self.flushBuffer(true)
+}
+
+func (self *CompressedFileWriter) Close() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.stopFlush()
+	self.closed = true
+
+	err := self.flushBuffer(true)
+	self.fd.Close()
+	self.idx_fd.Close()
+
+	if self.lock_path != "" {
+		releaseFileLock(self.lock_path)
+	}
+
+	// Writing is synchronous... complete on Close()
+	if self.completion != nil &&
+		!utils.CompareFuncs(self.completion, utils.SyncCompleter) {
+		self.completion()
+	}
+	return err
+}
+
+// Report the uncompressed size of the file.
+type compressedFileInfo struct {
+	os.FileInfo
+	size int64
+}
+
+func (self compressedFileInfo) Size() int64 {
+	return self.size
+}
+
+// A reader which presents the uncompressed data of a compressed
+// file.
+type CompressedFileReader struct {
+	fd     *os.File
+	idx_fd *os.File
+
+	path_spec api.FSPathSpec
+	records   []frameRecord
+
+	// The current uncompressed offset.
+	offset int64
+
+	// The last decompressed frame is cached to make small reads
+	// efficient.
+	current      int
+	current_data []byte
+}
+
+func openCompressedFileReader(
+	file_path string, path_spec api.FSPathSpec) (*CompressedFileReader, error) {
+	idx_fd, err := os.Open(file_path + COMPRESSED_INDEX_EXTENSION)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkIndexHeader(idx_fd)
+	if err != nil {
+		idx_fd.Close()
+		return nil, err
+	}
+
+	fd, err := os.Open(file_path)
+	if err != nil {
+		idx_fd.Close()
+		return nil, err
+	}
+
+	result := &CompressedFileReader{
+		fd:        fd,
+		idx_fd:    idx_fd,
+		path_spec: path_spec,
+		current:   -1,
+	}
+
+	return result, result.refresh()
+}
+
+// Pick up any frames written since we last looked - this allows
+// readers to follow a file while it is being written. The last frame
+// may be a partial frame which the writer replaces as more data
+// arrives so it is read again.
+func (self *CompressedFileReader) refresh() error {
+	first := len(self.records) - 1
+	if first < 0 {
+		first = 0
+	}
+
+	records, err := readFrameRecords(self.idx_fd, int64(first))
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	if first < len(self.records) && self.records[first] != records[0] &&
+		self.current == first {
+		self.current = -1
+	}
+	self.records = append(self.records[:first], records...)
+	return nil
+}
+
+func (self *CompressedFileReader) size() int64 {
+	if len(self.records) == 0 {
+		return 0
+	}
+	return self.records[len(self.records)-1].End()
+}
+
+// Find the frame containing the offset or -1 if the offset is past
+// the end of the file.
+func (self *CompressedFileReader) findFrame(offset int64) (int, error) {
+	if offset >= self.size() {
+		err := self.refresh()
+		if err != nil {
+			return -1, err
+		}
+
+		if offset >= self.size() {
+			return -1, nil
+		}
+	}
+
+	return sort.Search(len(self.records), func(i int) bool {
+		return self.records[i].End() > offset
+	}), nil
+}
+
+func (self *CompressedFileReader) Read(buff []byte) (int, error) {
+	total := 0
+	for total < len(buff) {
+		idx, err := self.findFrame(self.offset)
+		if err != nil {
+			return total, err
+		}
+
+		if idx < 0 {
+			if total > 0 {
+				return total, nil
+			}
+			return 0, io.EOF
+		}
+
+		if idx != self.current {
+			data, err := readFrame(self.fd, self.records[idx])
+			if err != nil && idx == len(self.records)-1 {
+				// The partial frame may have been replaced while we
+				// read it.
+				err = self.refresh()
+				if err == nil {
+					data, err = readFrame(self.fd, self.records[idx])
+				}
+			}
+			if err != nil {
+				return total, err
+			}
+			self.current = idx
+			self.current_data = data
+		}
+
+		frame_offset := self.offset - int64(self.records[idx].UncompressedOffset)
+		n := copy(buff[total:], self.current_data[frame_offset:])
+		total += n
+		self.offset += int64(n)
+	}
+
+	return total, nil
+}
+
+func (self *CompressedFileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += self.offset
+
+	case io.SeekEnd:
+		err := self.refresh()
+		if err != nil {
+			return 0, err
+		}
+		offset += self.size()
+
+	default:
+		return 0, errors.New("Invalid whence")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("Invalid offset")
+	}
+
+	self.offset = offset
+	return offset, nil
+}
+
+func (self *CompressedFileReader) Stat() (api.FileInfo, error) {
+	stat, err := self.fd.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	err = self.refresh()
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewFileInfoAdapter(compressedFileInfo{
+		FileInfo: stat,
+		size:     self.size(),
+	}, self.path_spec, nil), nil
+}
+
+func (self *CompressedFileReader) Close() error {
+	self.idx_fd.Close()
+	return self.fd.Close()
+}
@@ -0,0 +1,345 @@
+package directory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+	"www.velocidex.com/golang/velociraptor/file_store/tests"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/result_sets/simple"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+)
+
+type CompressedDirectoryTestSuite struct {
+	*tests.FileStoreTestSuite
+
+	config_obj *config_proto.Config
+	file_store *DirectoryFileStore
+}
+
+func (self *CompressedDirectoryTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "file_store_test")
+	assert.NoError(self.T(), err)
+
+	self.config_obj.Datastore.FilestoreDirectory = dir
+	self.config_obj.Datastore.Location = dir
+}
+
+func (self *CompressedDirectoryTestSuite) TearDownTest() {
+	os.RemoveAll(self.config_obj.Datastore.FilestoreDirectory)
+}
+
+// Data spanning many frames can be read back and seeked into.
+func (self *CompressedDirectoryTestSuite) TestMultipleFrames() {
+	filename := path_specs.NewSafeFilestorePath("test", "large")
+	fd, err := self.file_store.WriteFile(filename)
+	assert.NoError(self.T(), err)
+
+	expected := &bytes.Buffer{}
+	for i := 0; expected.Len() < 3*compressedFrameSize; i++ {
+		line := fmt.Sprintf("This is line %d\n", i)
+		expected.WriteString(line)
+		_, err = fd.Write([]byte(line))
+		assert.NoError(self.T(), err)
+	}
+
+	size, err := fd.Size()
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(expected.Len()), size)
+	fd.Close()
+
+	// The data file is compressed and has a frame index.
+	file_path := filename.AsFilestoreFilename(self.config_obj)
+	assert.True(self.T(), isCompressedFile(file_path))
+
+	raw_stat, err := os.Stat(file_path)
+	assert.NoError(self.T(), err)
+	assert.True(self.T(), raw_stat.Size() < int64(expected.Len())/4)
+
+	// Stat reports the uncompressed size.
+	stat, err := self.file_store.StatFile(filename)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(expected.Len()), stat.Size())
+
+	// Listing does not show the sidecar.
+	infos, err := self.file_store.ListDirectory(
+		path_specs.NewSafeFilestorePath("test"))
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(infos))
+	assert.Equal(self.T(), int64(expected.Len()), infos[0].Size())
+
+	reader, err := self.file_store.ReadFile(filename)
+	assert.NoError(self.T(), err)
+	defer reader.Close()
+
+	data, err := ioutil.ReadAll(reader)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), expected.Bytes(), data)
+
+	// Seek across a frame boundary.
+	offset := int64(compressedFrameSize - 10)
+	_, err = reader.Seek(offset, io.SeekStart)
+	assert.NoError(self.T(), err)
+
+	buff := make([]byte, 20)
+	_, err = io.ReadFull(reader, buff)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), expected.Bytes()[offset:offset+20], buff)
+}
+
+// Paging through a result set works on compressed files.
+func (self *CompressedDirectoryTestSuite) TestResultSetSeekToRow() {
+	filename := path_specs.NewSafeFilestorePath("test", "result_set")
+	writer, err := simple.ResultSetFactory{}.NewResultSetWriter(
+		self.file_store, filename, json.DefaultEncOpts(),
+		utils.SyncCompleter, true)
+	assert.NoError(self.T(), err)
+
+	for i := 0; i < 50000; i++ {
+		writer.Write(ordereddict.NewDict().Set("Row", i))
+	}
+	writer.Close()
+
+	reader, err := simple.ResultSetFactory{}.NewResultSetReader(
+		self.file_store, filename)
+	assert.NoError(self.T(), err)
+	defer reader.Close()
+
+	assert.Equal(self.T(), int64(50000), reader.TotalRows())
+
+	err = reader.SeekToRow(40000)
+	assert.NoError(self.T(), err)
+
+	for row := range reader.Rows(context.Background()) {
+		value, _ := row.Get("Row")
+		assert.Equal(self.T(), uint64(40000), value)
+		break
+	}
+}
+
+// Result sets can be read while they are still being written.
+func (self *CompressedDirectoryTestSuite) TestResultSetReadBeforeClose() {
+	old_delay := compressedFlushDelay
+	compressedFlushDelay = 10 * time.Millisecond
+	defer func() { compressedFlushDelay = old_delay }()
+
+	filename := path_specs.NewSafeFilestorePath("test", "running")
+	writer, err := simple.ResultSetFactory{}.NewResultSetWriter(
+		self.file_store, filename, json.DefaultEncOpts(),
+		utils.SyncCompleter, true)
+	assert.NoError(self.T(), err)
+	defer writer.Close()
+
+	row := 0
+	for batch := 1; batch <= 3; batch++ {
+		for ; row < batch*100; row++ {
+			writer.Write(ordereddict.NewDict().Set("Row", row))
+		}
+		writer.Flush()
+
+		// The last row is readable through the index once the
+		// partial frame is written.
+		vtesting.WaitUntil(2*time.Second, self.T(), func() bool {
+			reader, err := simple.ResultSetFactory{}.NewResultSetReader(
+				self.file_store, filename)
+			if err != nil {
+				return false
+			}
+			defer reader.Close()
+
+			if reader.TotalRows() != int64(row) ||
+				reader.SeekToRow(int64(row-1)) != nil {
+				return false
+			}
+
+			var last interface{}
+			for r := range reader.Rows(context.Background()) {
+				last, _ = r.Get("Row")
+			}
+			return last == uint64(row-1)
+		})
+	}
+
+	// The partial frame is rewritten on each flush rather than
+	// adding small frames.
+	self.assertFrames(filename, 1)
+}
+
+func (self *CompressedDirectoryTestSuite) assertFrames(
+	filename api.FSPathSpec, expected int64) {
+	idx_fd, err := os.Open(filename.AsFilestoreFilename(self.config_obj) +
+		COMPRESSED_INDEX_EXTENSION)
+	assert.NoError(self.T(), err)
+	defer idx_fd.Close()
+
+	frames, _, err := readLastFrameRecord(idx_fd)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), expected, frames)
+}
+
+func (self *CompressedDirectoryTestSuite) readAll(filename api.FSPathSpec) string {
+	reader, err := self.file_store.ReadFile(filename)
+	assert.NoError(self.T(), err)
+	defer reader.Close()
+
+	data, err := ioutil.ReadAll(reader)
+	assert.NoError(self.T(), err)
+	return string(data)
+}
+
+// Small writes are buffered until a frame is full or the writer is
+// flushed.
+func (self *CompressedDirectoryTestSuite) TestSmallWrites() {
+	filename := path_specs.NewSafeFilestorePath("test", "small")
+	fd, err := self.file_store.WriteFile(filename)
+	assert.NoError(self.T(), err)
+
+	expected := &bytes.Buffer{}
+	for expected.Len() < compressedFrameSize+100 {
+		line := fmt.Sprintf("This is line %d\n", expected.Len())
+		expected.WriteString(line)
+		_, err = fd.Write([]byte(line))
+		assert.NoError(self.T(), err)
+	}
+
+	// Only the full frame is written so far.
+	self.assertFrames(filename, 1)
+
+	size, err := fd.Size()
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(expected.Len()), size)
+
+	assert.NoError(self.T(), fd.Flush())
+	self.assertFrames(filename, 2)
+	fd.Close()
+
+	data := self.readAll(filename)
+	assert.Equal(self.T(), expected.String(), data)
+}
+
+// Reopening a file to append continues the partial last frame.
+func (self *CompressedDirectoryTestSuite) TestReopenAppend() {
+	filename := path_specs.NewSafeFilestorePath("test", "appended")
+
+	expected := &bytes.Buffer{}
+	for i := 0; i < 200; i++ {
+		line := fmt.Sprintf("This is log line %d\n", i)
+		expected.WriteString(line)
+
+		fd, err := self.file_store.WriteFile(filename)
+		assert.NoError(self.T(), err)
+		_, err = fd.Write([]byte(line))
+		assert.NoError(self.T(), err)
+		fd.Close()
+	}
+
+	self.assertFrames(filename, 1)
+
+	stat, err := os.Stat(filename.AsFilestoreFilename(self.config_obj))
+	assert.NoError(self.T(), err)
+	assert.True(self.T(), stat.Size() < int64(expected.Len())/2)
+
+	data := self.readAll(filename)
+	assert.Equal(self.T(), expected.String(), data)
+}
+
+// Existing raw files are converted in place.
+func (self *CompressedDirectoryTestSuite) TestConverter() {
+	filename := path_specs.NewSafeFilestorePath("test", "raw")
+	file_path := filename.AsFilestoreFilename(self.config_obj)
+
+	// Write a raw file directly.
+	data := bytes.Repeat([]byte("hello world\n"), 10000)
+	err := os.MkdirAll(self.config_obj.Datastore.FilestoreDirectory+"/test", 0700)
+	assert.NoError(self.T(), err)
+
+	err = ioutil.WriteFile(file_path, data, 0600)
+	assert.NoError(self.T(), err)
+
+	// Appending to a raw file keeps it raw.
+	fd, err := self.file_store.WriteFile(filename)
+	assert.NoError(self.T(), err)
+	_, err = fd.Write([]byte("more\n"))
+	assert.NoError(self.T(), err)
+
+	// Can not convert while the file is open for writing.
+	_, err = CompressFile(file_path)
+	assert.ErrorIs(self.T(), err, fileBusyError)
+	fd.Close()
+	data = append(data, []byte("more\n")...)
+	assert.False(self.T(), isCompressedFile(file_path))
+
+	// Make the file old enough to convert
+	old := time.Now().Add(-time.Hour)
+	assert.NoError(self.T(), os.Chtimes(file_path, old, old))
+
+	converter := NewCompressionConverter(self.config_obj)
+	err = converter.ConvertAll(context.Background())
+	assert.NoError(self.T(), err)
+	assert.True(self.T(), isCompressedFile(file_path))
+
+	reader, err := self.file_store.ReadFile(filename)
+	assert.NoError(self.T(), err)
+	read_data, err := ioutil.ReadAll(reader)
+	assert.NoError(self.T(), err)
+	reader.Close()
+	assert.Equal(self.T(), data, read_data)
+
+	// Further writes append compressed frames.
+	fd, err = self.file_store.WriteFile(filename)
+	assert.NoError(self.T(), err)
+	_, err = fd.Write([]byte("last\n"))
+	assert.NoError(self.T(), err)
+	fd.Close()
+
+	stat, err := self.file_store.StatFile(filename)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(len(data)+5), stat.Size())
+
+	// Deleting removes the sidecar.
+	err = self.file_store.Delete(filename)
+	assert.NoError(self.T(), err)
+
+	_, err = os.Stat(file_path + COMPRESSED_INDEX_EXTENSION)
+	assert.True(self.T(), os.IsNotExist(err))
+}
+
+// Index files are never compressed.
+func (self *CompressedDirectoryTestSuite) TestIndexNotCompressed() {
+	filename := path_specs.NewSafeFilestorePath("test", "foo").
+		SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX)
+	fd, err := self.file_store.WriteFile(filename)
+	assert.NoError(self.T(), err)
+	_, err = fd.Write([]byte("12345678"))
+	assert.NoError(self.T(), err)
+	fd.Close()
+
+	assert.False(self.T(), isCompressedFile(
+		filename.AsFilestoreFilename(self.config_obj)))
+}
+
+func TestCompressedDirectoryFileStore(t *testing.T) {
+	config_obj := config.GetDefaultConfig()
+	config_obj.Datastore.CompressedOrgs = []string{"*"}
+
+	file_store := NewDirectoryFileStore(config_obj)
+	suite.Run(t, &CompressedDirectoryTestSuite{
+		FileStoreTestSuite: tests.NewFileStoreTestSuite(config_obj, file_store),
+		file_store:         file_store,
+		config_obj:         config_obj,
+	})
+}
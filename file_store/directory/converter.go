@@ -0,0 +1,227 @@
+package directory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+var (
+	metricCompressionConvertedFiles = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "filestore_compression_converted_files",
+			Help: "Total number of existing files converted to compressed storage",
+		})
+
+	// The file is currently open for writing.
+	fileBusyError = errors.New("File is busy")
+)
+
+const (
+	// Leave recently modified files alone - they are likely to be
+	// written again soon.
+	converterMinAge = 10 * time.Minute
+)
+
+// Converts a raw file to compressed storage in place. Returns the
+// number of bytes saved.
+func CompressFile(file_path string) (int64, error) {
+	lock, ok := acquireIdleFileLock(file_path)
+	if !ok {
+		return 0, fileBusyError
+	}
+	defer releaseFileLock(file_path)
+
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+
+	if isCompressedFile(file_path) {
+		return 0, nil
+	}
+
+	stat, err := os.Stat(file_path)
+	if err != nil {
+		return 0, err
+	}
+
+	in_fd, err := os.Open(file_path)
+	if err != nil {
+		return 0, err
+	}
+	defer in_fd.Close()
+
+	tmp_data_path := file_path + COMPRESSED_TMP_EXTENSION
+	tmp_index_path := file_path + COMPRESSED_INDEX_EXTENSION +
+		COMPRESSED_TMP_EXTENSION
+
+	// Remove any left over temp files from an interrupted
+	// conversion.
+	os.Remove(tmp_data_path)
+	os.Remove(tmp_index_path)
+
+	// The temporary writer does not need a lock because no one else
+	// knows about it.
+	writer, err := openCompressedFileWriter(
+		tmp_data_path, tmp_index_path, "", &fileLock{}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// Copy whole frames at a time.
+	_, err = utils.CopyWithBuffer(context.Background(), writer, in_fd,
+		make([]byte, compressedFrameSize))
+	if err == nil {
+		err = writer.Close()
+	} else {
+		writer.Close()
+	}
+
+	if err == nil {
+		err = os.Chtimes(tmp_data_path, stat.ModTime(), stat.ModTime())
+	}
+
+	if err != nil {
+		os.Remove(tmp_data_path)
+		os.Remove(tmp_index_path)
+		return 0, err
+	}
+
+	// Readers check the data file magic so there is no window where
+	// a raw file is read as compressed.
+	err = os.Rename(tmp_index_path, file_path+COMPRESSED_INDEX_EXTENSION)
+	if err != nil {
+		return 0, err
+	}
+
+	err = os.Rename(tmp_data_path, file_path)
+	if err != nil {
+		return 0, err
+	}
+
+	metricCompressionConvertedFiles.Inc()
+
+	compressed_stat, err := os.Stat(file_path)
+	if err != nil {
+		return 0, err
+	}
+	return stat.Size() - compressed_stat.Size(), nil
+}
+
+// Periodically scan the org's file store for raw files and convert
+// them to compressed storage.
+type CompressionConverter struct {
+	config_obj *config_proto.Config
+	file_store *DirectoryFileStore
+}
+
+func NewCompressionConverter(
+	config_obj *config_proto.Config) *CompressionConverter {
+	return &CompressionConverter{
+		config_obj: config_obj,
+		file_store: NewDirectoryFileStore(config_obj),
+	}
+}
+
+func (self *CompressionConverter) Start(
+	ctx context.Context, wg *sync.WaitGroup) {
+
+	frequency := self.config_obj.Datastore.CompressionConverterFrequencySec
+	if frequency < 0 || !IsCompressionEnabled(self.config_obj) {
+		return
+	}
+
+	if frequency == 0 {
+		frequency = 3600
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	logger.Info("<green>Starting</> file store compression converter for %v",
+		utils.GetOrgId(self.config_obj))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			err := self.ConvertAll(ctx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error("CompressionConverter: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Duration(frequency) * time.Second):
+			}
+		}
+	}()
+}
+
+// Convert all eligible files in the org's file store.
+func (self *CompressionConverter) ConvertAll(ctx context.Context) error {
+	logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+	now := utils.GetTime().Now()
+
+	var total_files, total_saved int64
+
+	err := api.Walk(self.file_store, path_specs.NewSafeFilestorePath(),
+		func(path api.FSPathSpec, info os.FileInfo) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			if !self.shouldConvert(path, info, now) {
+				return nil
+			}
+
+			file_path := path.AsFilestoreFilename(self.config_obj)
+			saved, err := CompressFile(file_path)
+			if err != nil {
+				if !errors.Is(err, fileBusyError) {
+					logger.Debug("CompressionConverter: %v: %v", file_path, err)
+				}
+				return nil
+			}
+
+			total_files++
+			total_saved += saved
+			return nil
+		})
+
+	if total_files > 0 {
+		logger.Info("CompressionConverter: Compressed %v files in org %v saving %v bytes",
+			total_files, utils.GetOrgId(self.config_obj), total_saved)
+	}
+
+	return err
+}
+
+func (self *CompressionConverter) shouldConvert(
+	path api.FSPathSpec, info os.FileInfo, now time.Time) bool {
+	if info.Size() == 0 || now.Sub(info.ModTime()) < converterMinAge {
+		return false
+	}
+
+	// The root org's file store contains the other orgs. They are
+	// converted by their own converters.
+	components := path.Components()
+	if utils.IsRootOrg(self.config_obj.OrgId) &&
+		len(components) > 0 && components[0] == "orgs" {
+		return false
+	}
+
+	return shouldCompress(self.config_obj, path) &&
+		!isCompressedFile(path.AsFilestoreFilename(self.config_obj))
+}
@@ -49,6 +49,9 @@ const (
 type DirectoryFileWriter struct {
 	Fd         *os.File
 	completion func()
+
+	// Release the file lock on close.
+	lock_path string
 }
 
 func (self *DirectoryFileWriter) Size() (int64, error) {
@@ -86,6 +89,10 @@ func (self *DirectoryFileWriter) Flush() error { return nil }
 func (self *DirectoryFileWriter) Close() error {
 	err := self.Fd.Close()
 
+	if self.lock_path != "" {
+		releaseFileLock(self.lock_path)
+	}
+
 	// DirectoryFileWriter is synchronous... complete on Close()
 	if self.completion != nil &&
 		!utils.CompareFuncs(self.completion, utils.SyncCompleter) {
@@ -106,6 +113,17 @@ func (self *DirectoryFileStore) Move(src, dest api.FSPathSpec) error {
 	src_path := src.AsFilestoreFilename(self.config_obj)
 	dest_path := dest.AsFilestoreFilename(self.config_obj)
 
+	// Move the frame index along with compressed files.
+	if isCompressedFile(src_path) {
+		err := os.Rename(src_path+COMPRESSED_INDEX_EXTENSION,
+			dest_path+COMPRESSED_INDEX_EXTENSION)
+		if err != nil {
+			return err
+		}
+	} else {
+		os.Remove(dest_path + COMPRESSED_INDEX_EXTENSION)
+	}
+
 	return os.Rename(src_path, dest_path)
 }
 
@@ -124,6 +142,12 @@ func (self *DirectoryFileStore) ListDirectory(dirname api.FSPathSpec) (
 		return nil, err
 	}
 
+	// Compressed files have a frame index sidecar.
+	names := make(map[string]bool)
+	for _, fileinfo := range files {
+		names[fileinfo.Name()] = true
+	}
+
 	var result []api.FileInfo
 	for _, fileinfo := range files {
 		// Each file from the filesystem will be potentially
@@ -135,6 +159,24 @@ func (self *DirectoryFileStore) ListDirectory(dirname api.FSPathSpec) (
 			continue
 		}
 
+		// Eliminate the compression sidecars and temporary files
+		if strings.HasSuffix(name, COMPRESSED_TMP_EXTENSION) {
+			continue
+		}
+
+		if strings.HasSuffix(name, COMPRESSED_INDEX_EXTENSION) &&
+			names[strings.TrimSuffix(name, COMPRESSED_INDEX_EXTENSION)] {
+			continue
+		}
+
+		// Report the uncompressed size for compressed files.
+		if !fileinfo.IsDir() && names[name+COMPRESSED_INDEX_EXTENSION] {
+			full_path := filepath.Join(file_path, name)
+			if isCompressedFile(full_path) {
+				fileinfo = getCompressedFileInfo(full_path, fileinfo)
+			}
+		}
+
 		name_type, name := api.GetFileStorePathTypeFromExtension(name)
 		result = append(result, file_store_file_info.NewFileStoreFileInfo(
 			self.config_obj,
@@ -153,6 +195,14 @@ func (self *DirectoryFileStore) ReadFile(
 
 	defer api.InstrumentWithDelay("open_read", "DirectoryFileStore", filename)()
 
+	if isCompressedFile(file_path) {
+		reader, err := openCompressedFileReader(file_path, filename)
+		if err != nil {
+			return nil, errors.Wrap(err, 0)
+		}
+		return reader, nil
+	}
+
 	file, err := os.Open(file_path)
 	if err != nil {
 		return nil, errors.Wrap(err, 0)
@@ -174,6 +224,10 @@ func (self *DirectoryFileStore) StatFile(
 		return nil, err
 	}
 
+	if isCompressedFile(file_path) {
+		file = getCompressedFileInfo(file_path, file)
+	}
+
 	return file_store_file_info.NewFileStoreFileInfo(
 		self.config_obj, filename, file), nil
 }
@@ -196,8 +250,30 @@ func (self *DirectoryFileStore) WriteFileWithCompletion(
 		return nil, err
 	}
 
+	// Hold the file lock while we decide if the file should be
+	// compressed so a concurrent conversion can not change it under
+	// us.
+	lock := acquireFileLock(file_path)
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+
+	if self.useCompression(filename, file_path) {
+		writer, err := openCompressedFileWriter(file_path,
+			file_path+COMPRESSED_INDEX_EXTENSION, file_path, lock, completion)
+		if err != nil {
+			releaseFileLock(file_path)
+
+			logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+			logger.Error("Unable to open file %v: %v", file_path, err)
+			return nil, errors.Wrap(err, 0)
+		}
+		return writer, nil
+	}
+
 	file, err := os.OpenFile(file_path, os.O_RDWR|os.O_CREATE, 0700)
 	if err != nil {
+		releaseFileLock(file_path)
+
 		logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
 		logger.Error("Unable to open file %v: %v", file_path, err)
 
@@ -207,9 +283,33 @@ func (self *DirectoryFileStore) WriteFileWithCompletion(
 	return &DirectoryFileWriter{
 		Fd:         file,
 		completion: completion,
+		lock_path:  file_path,
 	}, nil
 }
 
+// Existing compressed files are always written compressed. New files
+// are compressed if compression is enabled for the org, but existing
+// raw files are left raw until converted.
+func (self *DirectoryFileStore) useCompression(
+	filename api.FSPathSpec, file_path string) bool {
+	if isCompressedFile(file_path) {
+		return true
+	}
+
+	if !shouldCompress(self.config_obj, filename) {
+		return false
+	}
+
+	stat, err := os.Stat(file_path)
+	if err == nil && stat.Size() > 0 {
+		return false
+	}
+
+	// Remove any stale frame index from an interrupted conversion.
+	os.Remove(file_path + COMPRESSED_INDEX_EXTENSION)
+	return true
+}
+
 func (self *DirectoryFileStore) Delete(filename api.FSPathSpec) error {
 
 	defer api.InstrumentWithDelay("delete", "DirectoryFileStore", filename)()
@@ -220,6 +320,9 @@ func (self *DirectoryFileStore) Delete(filename api.FSPathSpec) error {
 		return err
 	}
 
+	// Remove the frame index of compressed files.
+	os.Remove(file_path + COMPRESSED_INDEX_EXTENSION)
+
 	dir_name := filepath.Dir(file_path)
 
 	// Exit as soon as directory is not empty
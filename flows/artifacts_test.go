@@ -16,6 +16,8 @@ import (
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
 	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
 	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
 	flows_proto "www.velocidex.com/golang/velociraptor/flows/proto"
 	"www.velocidex.com/golang/velociraptor/paths"
@@ -24,6 +26,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/result_sets"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/journal"
+	"www.velocidex.com/golang/velociraptor/services/launcher"
 	"www.velocidex.com/golang/velociraptor/uploads"
 	utils "www.velocidex.com/golang/velociraptor/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
@@ -882,6 +885,156 @@ func (self *TestSuite) TestClientUploaderStoreSparseFileNTFS() {
 	assert.Equal(self.T(), uploaded_size, int64(0))
 }
 
+// A partially received upload can be resumed in a later flow.
+func (self *TestSuite) TestResumeUpload() {
+	old_size := uploads.UPLOAD_CHECKPOINT_SIZE
+	uploads.UPLOAD_CHECKPOINT_SIZE = 10
+	defer func() { uploads.UPLOAD_CHECKPOINT_SIZE = old_size }()
+
+	pathspec := &actions_proto.PathSpec{
+		Path:       "foo",
+		Components: []string{"foo"},
+		Accessor:   "ntfs",
+	}
+
+	// The first flow is interrupted after the first buffer.
+	runner := NewFlowRunner(self.Ctx, self.ConfigObj)
+	err := runner.FileBuffer(self.Ctx, self.client_id, "F.Partial",
+		&actions_proto.FileBuffer{
+			Pathspec: pathspec,
+			Size:     20,
+			Data:     []byte("0123456789"),
+		})
+	assert.NoError(self.T(), err)
+	runner.Close(self.Ctx)
+
+	// The next collection offers the partial upload to the client.
+	resumable, err := launcher.GetResumableUploads(
+		self.Ctx, self.ConfigObj, self.client_id)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(resumable))
+	assert.Equal(self.T(), "F.Partial", resumable[0].FlowId)
+	assert.Equal(self.T(), uint64(10), resumable[0].Offset)
+
+	// Only partial uploads recorded for the client can be resumed.
+	runner = NewFlowRunner(self.Ctx, self.ConfigObj)
+	err = runner.FileBuffer(self.Ctx, self.client_id, "F.Resumed",
+		&actions_proto.FileBuffer{
+			Pathspec:     pathspec,
+			Size:         20,
+			Offset:       8,
+			ResumeFlowId: "F.Unknown",
+			Data:         []byte("89abcdefghij"),
+		})
+	assert.NoError(self.T(), err)
+	runner.Close(self.Ctx)
+
+	flow_path_manager := paths.NewFlowPathManager(self.client_id, "F.Resumed")
+	upload_path := flow_path_manager.GetUploadsFile(
+		"ntfs", "foo", []string{"foo"}).Path()
+
+	file_store_factory := file_store.GetFileStore(self.ConfigObj)
+	_, err = file_store_factory.StatFile(upload_path)
+	assert.Error(self.T(), err)
+
+	// The client resumes from the offset it checkpointed.
+	runner = NewFlowRunner(self.Ctx, self.ConfigObj)
+	err = runner.FileBuffer(self.Ctx, self.client_id, "F.Resumed",
+		&actions_proto.FileBuffer{
+			Pathspec:     pathspec,
+			Size:         20,
+			Offset:       8,
+			ResumeFlowId: "F.Partial",
+			Data:         []byte("89abcdefghij"),
+		})
+	assert.NoError(self.T(), err)
+
+	err = runner.FileBuffer(self.Ctx, self.client_id, "F.Resumed",
+		&actions_proto.FileBuffer{
+			Pathspec:   pathspec,
+			Size:       20,
+			Offset:     20,
+			StoredSize: 20,
+			Eof:        true,
+		})
+	assert.NoError(self.T(), err)
+	runner.Close(self.Ctx)
+
+	// The earlier data is copied in the background.
+	vtesting.WaitUntil(time.Second, self.T(), func() bool {
+		return getResumedUpload(self.ConfigObj, upload_path) == nil
+	})
+
+	assert.Equal(self.T(), "0123456789abcdefghij",
+		test_utils.FileReadAll(self.T(), self.ConfigObj, upload_path))
+
+	// Only the original partial upload remains resumable.
+	resumable, err = launcher.GetResumableUploads(
+		self.Ctx, self.ConfigObj, self.client_id)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 1, len(resumable))
+	assert.Equal(self.T(), "F.Partial", resumable[0].FlowId)
+}
+
+// Expired partial uploads are not offered but only removed when the
+// record is next updated.
+func (self *TestSuite) TestResumeUploadExpiry() {
+	old_size := uploads.UPLOAD_CHECKPOINT_SIZE
+	uploads.UPLOAD_CHECKPOINT_SIZE = 10
+	defer func() { uploads.UPLOAD_CHECKPOINT_SIZE = old_size }()
+
+	closer := utils.MockTime(utils.NewMockClock(time.Unix(1000, 0)))
+	defer closer()
+
+	upload := func(flow_id, name string) {
+		runner := NewFlowRunner(self.Ctx, self.ConfigObj)
+		err := runner.FileBuffer(self.Ctx, self.client_id, flow_id,
+			&actions_proto.FileBuffer{
+				Pathspec: &actions_proto.PathSpec{
+					Path:       name,
+					Components: []string{name},
+					Accessor:   "ntfs",
+				},
+				Size: 20,
+				Data: []byte("0123456789"),
+			})
+		assert.NoError(self.T(), err)
+		runner.Close(self.Ctx)
+	}
+
+	get_record := func() *crypto_proto.ResumableUploads {
+		db, err := datastore.GetDB(self.ConfigObj)
+		assert.NoError(self.T(), err)
+
+		record := &crypto_proto.ResumableUploads{}
+		err = db.GetSubject(self.ConfigObj,
+			paths.NewClientPathManager(self.client_id).PartialUploads(),
+			record)
+		assert.NoError(self.T(), err)
+		return record
+	}
+
+	upload("F.Old", "old")
+
+	// Once expired, the upload is not offered to the client but
+	// getting the list does not modify the record.
+	utils.MockTime(utils.NewMockClock(time.Unix(1000, 0).Add(
+		launcher.PARTIAL_UPLOAD_EXPIRY + time.Hour)))
+
+	resumable, err := launcher.GetResumableUploads(
+		self.Ctx, self.ConfigObj, self.client_id)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, len(resumable))
+	assert.Equal(self.T(), 1, len(get_record().Uploads))
+
+	// Recording a new partial upload prunes the expired one.
+	upload("F.New", "new")
+
+	record := get_record()
+	assert.Equal(self.T(), 1, len(record.Uploads))
+	assert.Equal(self.T(), "F.New", record.Uploads[0].FlowId)
+}
+
 func TestArtifactCollection(t *testing.T) {
 	suite.Run(t, &TestSuite{
 		client_id: "C.12312",
@@ -24,6 +24,7 @@ import (
 	"www.velocidex.com/golang/velociraptor/result_sets"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/launcher"
+	"www.velocidex.com/golang/velociraptor/uploads"
 	utils "www.velocidex.com/golang/velociraptor/utils"
 )
 
@@ -314,7 +315,38 @@ func (self *ClientFlowRunner) FileBuffer(
 		file_buffer.Pathspec.Accessor, file_buffer.Pathspec.Path,
 		file_buffer.Pathspec.Components)
 
-	fd, err := file_store_factory.WriteFile(file_path_manager.Path())
+	if file_buffer.ResumeFlowId != "" {
+		// The client is resuming an upload interrupted in an earlier
+		// flow. The data we already have is copied in the background.
+		err := self.resumeUpload(client_id, flow_id, file_buffer,
+			file_path_manager.Path())
+		if err != nil {
+			logger.Error("While resuming upload to %v: %v",
+				file_path_manager.Path().AsClientPath(), err)
+			return nil
+		}
+	}
+
+	// While an upload is being resumed, new data goes elsewhere.
+	write_path := file_path_manager.Path()
+	resumed := getResumedUpload(self.config_obj, write_path)
+	if resumed != nil {
+		resumed.mu.Lock()
+		defer resumed.mu.Unlock()
+
+		// The rest of a failed upload is dropped.
+		if file_buffer.Eof && resumed.err != nil {
+			resumed.remove()
+		}
+
+		var err error
+		write_path, err = resumed.writePath()
+		if err != nil {
+			return nil
+		}
+	}
+
+	fd, err := file_store_factory.WriteFile(write_path)
 	if err != nil {
 		// If we fail to write this one file we keep going -
 		// otherwise the flow will be terminated.
@@ -324,16 +356,27 @@ func (self *ClientFlowRunner) FileBuffer(
 	}
 	defer fd.Close()
 
-	// Keep track of all the files we uploaded.
-	if file_buffer.Offset == 0 {
-
-		// Truncate the file on first buffer received.
+	// Truncate the file on first buffer received.
+	if file_buffer.Offset == 0 && file_buffer.ResumeFlowId == "" {
 		err = fd.Truncate()
 		if err != nil {
 			return err
 		}
 	}
 
+	// Remember large uploads so they can be resumed if they are
+	// interrupted.
+	is_resumable := int64(file_buffer.Size) >= uploads.UPLOAD_CHECKPOINT_SIZE
+	if is_resumable && !file_buffer.Eof &&
+		(file_buffer.Offset == 0 || file_buffer.ResumeFlowId != "") {
+		err = launcher.RecordPartialUpload(self.config_obj, client_id, flow_id,
+			file_buffer.Pathspec.Accessor, file_buffer.Pathspec.Path,
+			file_buffer.Pathspec.Components)
+		if err != nil {
+			logger.Error("RecordPartialUpload: %v", err)
+		}
+	}
+
 	// Write the actual data to the file.
 	_, err = fd.Write(file_buffer.Data)
 	if err != nil {
@@ -344,9 +387,24 @@ func (self *ClientFlowRunner) FileBuffer(
 
 	// The EOF status represents the file is complete - do some housework
 	if file_buffer.Eof {
+		// The flow is not complete until the resumed data is copied.
+		if resumed != nil && !resumed.done {
+			resumed.waiters = append(resumed.waiters,
+				self.completer.GetCompletionFunc())
+		}
+
 		uploadCounter.Inc()
 		uploadBytes.Add(float64(file_buffer.StoredSize))
 
+		if is_resumable {
+			err = launcher.CompletePartialUpload(self.config_obj,
+				client_id, flow_id, file_buffer.Pathspec.Accessor,
+				file_buffer.Pathspec.Components)
+			if err != nil {
+				logger.Error("CompletePartialUpload: %v", err)
+			}
+		}
+
 		// When the upload completes, we emit an event.
 		self.upload_completion_messages = append(
 			self.upload_completion_messages,
@@ -0,0 +1,287 @@
+package flows
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/services/launcher"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+/*
+  When a client resumes an upload interrupted in an earlier flow, the
+  data the server already holds must be copied into the new flow's
+  upload. This may be a lot of data so it is copied in the background
+  rather than holding up the client's messages.
+
+  While the copy is in progress, new data for the upload is spooled
+  into a temporary file. Once the copy catches up with the spool, the
+  upload is written directly again. The upload file therefore always
+  holds a valid prefix of the file, so it can be resumed again if the
+  server restarts in the mean time.
+*/
+
+var (
+	resumed_mu      sync.Mutex
+	resumed_uploads = make(map[string]*resumedUpload)
+
+	// Once the spool is smaller than this we finish copying it while
+	// holding the lock.
+	resumeSpoolCatchUp = int64(1024 * 1024)
+)
+
+type resumedUpload struct {
+	mu sync.Mutex
+
+	key        string
+	config_obj *config_proto.Config
+	path       api.FSPathSpec
+	spool      api.FSPathSpec
+
+	// Set when the copy is finished and new data can be written to
+	// the upload directly.
+	done bool
+
+	// Set if the copy failed. The rest of the upload is dropped.
+	err error
+
+	// Called when the copy is finished. The flow is not complete
+	// until the upload data is in place.
+	waiters []func()
+}
+
+func resumedUploadKey(
+	config_obj *config_proto.Config, path api.FSPathSpec) string {
+	return utils.GetOrgId(config_obj) + ":" + path.AsClientPath()
+}
+
+// Returns the upload being resumed or nil if the upload is not
+// being resumed.
+func getResumedUpload(
+	config_obj *config_proto.Config, path api.FSPathSpec) *resumedUpload {
+	resumed_mu.Lock()
+	defer resumed_mu.Unlock()
+
+	return resumed_uploads[resumedUploadKey(config_obj, path)]
+}
+
+func (self *resumedUpload) remove() {
+	resumed_mu.Lock()
+	defer resumed_mu.Unlock()
+
+	if resumed_uploads[self.key] == self {
+		delete(resumed_uploads, self.key)
+	}
+}
+
+// Where new data for the upload should be written. Must be called
+// with the lock held.
+func (self *resumedUpload) writePath() (api.FSPathSpec, error) {
+	if self.err != nil {
+		return nil, self.err
+	}
+
+	if self.done {
+		return self.path, nil
+	}
+	return self.spool, nil
+}
+
+// Start resuming an upload from an earlier flow. The data is copied
+// in the background.
+func (self *ClientFlowRunner) resumeUpload(
+	client_id, flow_id string,
+	file_buffer *actions_proto.FileBuffer, path api.FSPathSpec) error {
+
+	if file_buffer.ResumeFlowId == flow_id {
+		return fmt.Errorf("Can not resume upload from the same flow %v",
+			flow_id)
+	}
+
+	// Only resume uploads we recorded for this client.
+	size, err := launcher.GetPartialUploadSize(self.config_obj, client_id,
+		file_buffer.ResumeFlowId, file_buffer.Pathspec.Accessor,
+		file_buffer.Pathspec.Components)
+	if err != nil {
+		return err
+	}
+
+	if uint64(size) < file_buffer.Offset {
+		return fmt.Errorf("Partial upload in %v is too short: %v < %v",
+			file_buffer.ResumeFlowId, size, file_buffer.Offset)
+	}
+
+	old_path := paths.NewFlowPathManager(client_id, file_buffer.ResumeFlowId).
+		GetUploadsFile(file_buffer.Pathspec.Accessor, file_buffer.Pathspec.Path,
+			file_buffer.Pathspec.Components).Path()
+
+	resumed := &resumedUpload{
+		key:        resumedUploadKey(self.config_obj, path),
+		config_obj: self.config_obj,
+		path:       path,
+		spool:      path.SetType(api.PATH_TYPE_FILESTORE_TMP),
+	}
+
+	resumed_mu.Lock()
+	_, pres := resumed_uploads[resumed.key]
+	if !pres {
+		resumed_uploads[resumed.key] = resumed
+	}
+	resumed_mu.Unlock()
+
+	if pres {
+		return fmt.Errorf("Upload is already being resumed")
+	}
+
+	// Start with empty files.
+	file_store_factory := file_store.GetFileStore(self.config_obj)
+	for _, p := range []api.FSPathSpec{resumed.path, resumed.spool} {
+		err = truncateFile(file_store_factory, p)
+		if err != nil {
+			resumed.remove()
+			return err
+		}
+	}
+
+	go resumed.copy(old_path, int64(file_buffer.Offset))
+
+	return nil
+}
+
+func truncateFile(file_store_factory api.FileStore, path api.FSPathSpec) error {
+	fd, err := file_store_factory.WriteFile(path)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	return fd.Truncate()
+}
+
+func (self *resumedUpload) copy(old_path api.FSPathSpec, offset int64) {
+	file_store_factory := file_store.GetFileStore(self.config_obj)
+
+	var copied int64
+	fd, err := file_store_factory.WriteFile(self.path)
+	if err == nil {
+		copied, err = self.copyPartial(file_store_factory, fd, old_path, offset)
+	}
+
+	self.mu.Lock()
+
+	// Copy the rest of the spool while no new data can be added to
+	// it.
+	if err == nil {
+		err = self.copySpool(file_store_factory, fd, copied)
+	}
+
+	if fd != nil {
+		close_err := fd.Close()
+		if err == nil {
+			err = close_err
+		}
+	}
+
+	if err != nil {
+		logger := logging.GetLogger(self.config_obj, &logging.FrontendComponent)
+		logger.Error("While resuming upload to %v: %v",
+			self.path.AsClientPath(), err)
+		self.err = err
+	}
+	self.done = true
+	waiters := self.waiters
+	self.waiters = nil
+	self.mu.Unlock()
+
+	// Failed uploads are removed when the client finishes sending
+	// them so the rest of the data is dropped until then.
+	if err == nil {
+		self.remove()
+	}
+	file_store_factory.Delete(self.spool)
+
+	for _, w := range waiters {
+		w()
+	}
+}
+
+// Copy the data from the earlier flow and most of the data spooled
+// in the mean time. Returns how much of the spool was copied.
+func (self *resumedUpload) copyPartial(
+	file_store_factory api.FileStore, fd api.FileWriter,
+	old_path api.FSPathSpec, offset int64) (int64, error) {
+
+	err := copyRange(file_store_factory, fd, old_path, 0, offset)
+	if err != nil {
+		return 0, fmt.Errorf("Copying partial upload: %w", err)
+	}
+
+	copied := int64(0)
+	for {
+		stat, err := file_store_factory.StatFile(self.spool)
+		if err != nil {
+			return 0, err
+		}
+
+		size := stat.Size()
+		if size-copied < resumeSpoolCatchUp {
+			return copied, nil
+		}
+
+		err = copyRange(file_store_factory, fd, self.spool,
+			copied, size-copied)
+		if err != nil {
+			return 0, err
+		}
+		copied = size
+	}
+}
+
+func (self *resumedUpload) copySpool(
+	file_store_factory api.FileStore, fd api.FileWriter, copied int64) error {
+	stat, err := file_store_factory.StatFile(self.spool)
+	if err != nil {
+		return err
+	}
+
+	return copyRange(file_store_factory, fd, self.spool,
+		copied, stat.Size()-copied)
+}
+
+// Copy length bytes from offset in the file to the writer.
+func copyRange(file_store_factory api.FileStore,
+	fd api.FileWriter, path api.FSPathSpec, offset, length int64) error {
+	if length == 0 {
+		return nil
+	}
+
+	reader, err := file_store_factory.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = reader.Seek(offset, 0)
+	if err != nil {
+		return err
+	}
+
+	n, err := utils.CopyN(context.Background(), fd, reader, length)
+	if err != nil {
+		return err
+	}
+
+	if int64(n) != length {
+		return fmt.Errorf("%v is too short: %v < %v",
+			path.AsClientPath(), offset+int64(n), offset+length)
+	}
+
+	return nil
+}
@@ -341,7 +341,7 @@ export default class NotebookCellRenderer extends React.Component {
         return "yaml";
     }
 
-    recalculate = (cell) => {
+    recalculate = (cell, invalidate_cache) => {
         cell.output = T("Loading");
         cell.timestamp = 0;
         cell.calculating = true;
@@ -367,6 +367,7 @@ export default class NotebookCellRenderer extends React.Component {
             env: this.state.cell.env,
             currently_editing: false,
             input: this.state.cell.input,
+            invalidate_cache: invalidate_cache,
         }, this.update_source.token).then( (response) => {
             this.props.incNotebookLocked(-1);
 
@@ -713,6 +714,15 @@ export default class NotebookCellRenderer extends React.Component {
                 <FontAwesomeIcon icon="sync"/>
               </Button>
 
+              <Button data-tooltip={T("Clear Cache and Recalculate")}
+                      data-position="right"
+                      className="btn-tooltip"
+                      disabled={this.state.cell.calculating}
+                      onClick={()=>this.recalculate(this.state.cell, true)}
+                      variant="default">
+                <FontAwesomeIcon icon="broom"/>
+              </Button>
+
               <Button data-tooltip={T("Stop Calculating")}
                       data-position="right"
                       className="btn-tooltip"
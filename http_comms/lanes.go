@@ -0,0 +1,86 @@
+package http_comms
+
+import (
+	"www.velocidex.com/golang/velociraptor/constants"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+)
+
+// The ring buffers hold several prioritized queues (lanes). This
+// prevents high value messages such as flow status or monitoring
+// events from sitting behind large uploads. Each lane has its own
+// share of the buffer size so a busy bulk lane can not fill the
+// buffer for the others.
+type Lane int
+
+const (
+	// Flow status and log messages.
+	LANE_STATUS Lane = iota
+
+	// Rows from client event queries.
+	LANE_EVENTS
+
+	// Collection results and uploads.
+	LANE_BULK
+
+	NUM_LANES
+)
+
+type laneSpec struct {
+	name string
+
+	// The fraction of the buffer size this lane may use before
+	// Enqueue blocks.
+	budget float64
+
+	// The relative share of each packet this lane receives when
+	// leasing.
+	weight uint64
+}
+
+var (
+	laneSpecs = [NUM_LANES]laneSpec{
+		LANE_STATUS: {name: "status", budget: 0.1, weight: 4},
+		LANE_EVENTS: {name: "events", budget: 0.3, weight: 2},
+		LANE_BULK:   {name: "bulk", budget: 0.6, weight: 1},
+	}
+)
+
+func (self Lane) String() string {
+	if self >= 0 && self < NUM_LANES {
+		return laneSpecs[self].name
+	}
+	return "unknown"
+}
+
+// The maximum number of bytes the lane may hold out of a buffer of
+// the specified size.
+func laneBudget(lane Lane, size uint64) uint64 {
+	return uint64(float64(size) * laneSpecs[lane].budget)
+}
+
+// The share of a packet of the specified size the lane receives.
+func laneShare(lane Lane, size uint64) uint64 {
+	total := uint64(0)
+	for _, spec := range laneSpecs {
+		total += spec.weight
+	}
+	return size * laneSpecs[lane].weight / total
+}
+
+// Decide which lane the message should be queued in.
+func GetLane(msg *crypto_proto.VeloMessage) Lane {
+	// The final FlowStats message must not overtake the flow's data
+	// or the server will consider the flow complete before all its
+	// results and uploads arrive. It is queued with the bulk data
+	// instead.
+	if msg.LogMessage != nil ||
+		(msg.FlowStats != nil && !msg.FlowStats.FlowComplete) {
+		return LANE_STATUS
+	}
+
+	if msg.SessionId == constants.MONITORING_WELL_KNOWN_FLOW {
+		return LANE_EVENTS
+	}
+
+	return LANE_BULK
+}
@@ -2,6 +2,7 @@ package http_comms
 
 import (
 	"context"
+	"crypto/cipher"
 	"encoding/binary"
 	"io"
 	"os"
@@ -23,6 +24,10 @@ import (
 const (
 	FileMagic         = "VRB\x5e"
 	FirstRecordOffset = 50
+
+	// Version 0 files store items in the clear. Version 1 files
+	// store encrypted records.
+	RING_BUFFER_VERSION = 1
 )
 
 var (
@@ -34,15 +39,23 @@ var (
 )
 
 type IRingBuffer interface {
+	// Enqueue into the bulk lane.
 	Enqueue(item []byte)
 
+	// Enqueue into a specific lane.
+	EnqueueLane(lane Lane, item []byte)
+
 	// How many bytes are currently available to be sent.
 	AvailableBytes() uint64
 
 	// Lease this much data from the buffer - the data is not deleted,
-	// but it is kept in the file until it is committed.
+	// but it is kept in the file until it is committed. Lanes are
+	// leased in strict priority order.
 	Lease(size uint64) []byte
 
+	// Lease this much data from a single lane.
+	LeaseLane(lane Lane, size uint64) []byte
+
 	// The total size of data in the ring buffer - sum of
 	// AvailableBytes and LeasedBytes
 	TotalSize() uint64
@@ -62,6 +75,9 @@ type Header struct {
 	// the leased data again. This should be 0 when we open a
 	// file.
 	LeasedBytes int64
+
+	// The format of the records in the file.
+	Version uint32
 }
 
 func (self *Header) MarshalBinary() ([]byte, error) {
@@ -73,6 +89,7 @@ func (self *Header) MarshalBinary() ([]byte, error) {
 	binary.LittleEndian.PutUint64(data[20:28], uint64(self.MaxSize))
 	binary.LittleEndian.PutUint64(data[28:36], uint64(self.AvailableBytes))
 	binary.LittleEndian.PutUint64(data[36:44], uint64(self.LeasedBytes))
+	binary.LittleEndian.PutUint32(data[44:48], self.Version)
 
 	return data, nil
 }
@@ -91,6 +108,11 @@ func (self *Header) UnmarshalBinary(data []byte) error {
 	self.MaxSize = int64(binary.LittleEndian.Uint64(data[20:28]))
 	self.AvailableBytes = int64(binary.LittleEndian.Uint64(data[28:36]))
 	self.LeasedBytes = int64(binary.LittleEndian.Uint64(data[36:44]))
+	self.Version = binary.LittleEndian.Uint32(data[44:48])
+
+	if self.Version > RING_BUFFER_VERSION {
+		return errors.New("Unsupported version")
+	}
 
 	return nil
 }
@@ -101,21 +123,45 @@ type ReadWriterAt interface {
 	Truncate(size int64) error
 }
 
-type FileBasedRingBuffer struct {
-	config_obj *config_proto.Config
-
-	mu sync.Mutex
-	c  *sync.Cond
-
+// Each lane of the FileBasedRingBuffer is stored in its own file
+// with the same layout. The bulk lane uses the configured filename
+// so buffers written by older clients are still replayed.
+type fileLane struct {
+	lane   Lane
 	fd     *os.File
 	header *Header
-	closed bool
+	aead   cipher.AEAD
 
 	read_buf  []byte
 	write_buf []byte
 
 	// The file offset where leases come from.
 	leased_pointer int64
+}
+
+// _Truncate returns the lane file to a virgin state. Assumes
+// FileBasedRingBuffer is already under lock.
+func (self *fileLane) _Truncate() {
+	_ = self.fd.Truncate(0)
+	self.header.ReadPointer = FirstRecordOffset
+	self.header.WritePointer = FirstRecordOffset
+	self.header.AvailableBytes = 0
+	self.header.LeasedBytes = 0
+	self.header.Version = RING_BUFFER_VERSION
+
+	self.leased_pointer = FirstRecordOffset
+	serialized, _ := self.header.MarshalBinary()
+	_, _ = self.fd.WriteAt(serialized, 0)
+}
+
+type FileBasedRingBuffer struct {
+	config_obj *config_proto.Config
+
+	mu sync.Mutex
+	c  *sync.Cond
+
+	lanes  [NUM_LANES]*fileLane
+	closed bool
 
 	log_ctx *logging.LogContext
 
@@ -123,6 +169,10 @@ type FileBasedRingBuffer struct {
 }
 
 func (self *FileBasedRingBuffer) Enqueue(item []byte) {
+	self.EnqueueLane(LANE_BULK, item)
+}
+
+func (self *FileBasedRingBuffer) EnqueueLane(lane Lane, item []byte) {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
@@ -130,41 +180,49 @@ func (self *FileBasedRingBuffer) Enqueue(item []byte) {
 		return
 	}
 
-	binary.LittleEndian.PutUint64(self.write_buf, uint64(len(item)))
-	_, err := self.fd.WriteAt(self.write_buf, int64(self.header.WritePointer))
+	l := self.lanes[lane]
+	record, err := encryptRecord(l.aead, item, l.header.WritePointer)
 	if err != nil {
-		self.Reset()
+		self._TruncateLane(l)
 		return
 	}
-	n, err := self.fd.WriteAt(item, int64(self.header.WritePointer+8))
+
+	binary.LittleEndian.PutUint64(l.write_buf, uint64(len(record)))
+	_, err = l.fd.WriteAt(l.write_buf, int64(l.header.WritePointer))
 	if err != nil {
-		self.Reset()
+		self._TruncateLane(l)
+		return
+	}
+	n, err := l.fd.WriteAt(record, int64(l.header.WritePointer+8))
+	if err != nil {
+		self._TruncateLane(l)
 		return
 	}
 
-	self.header.WritePointer += 8 + int64(n)
-	self.header.AvailableBytes += int64(n)
+	l.header.WritePointer += 8 + int64(n)
+	l.header.AvailableBytes += int64(len(item))
 
-	serialized, _ := self.header.MarshalBinary()
-	_, err = self.fd.WriteAt(serialized, 0)
+	serialized, _ := l.header.MarshalBinary()
+	_, err = l.fd.WriteAt(serialized, 0)
 	if err != nil {
-		self.Reset()
+		self._TruncateLane(l)
 		return
 	}
 
 	logger := logging.GetLogger(self.config_obj, &logging.ClientComponent)
 	logger.WithFields(logrus.Fields{
-		"header":         json.MustMarshalString(self.header),
-		"leased_pointer": self.leased_pointer,
+		"lane":           lane.String(),
+		"header":         json.MustMarshalString(l.header),
+		"leased_pointer": l.leased_pointer,
 	}).Info("File Ring Buffer: Enqueue")
 
-	// We need to block here until there is room in the message
-	// queue. If the message queue is full, the mutex will be
-	// locked and we wait here until the data is pushed through to
-	// the server, and enough room is available. This has the
-	// effect of blocking the executor and stopping the query
-	// until we return.
-	for self.header.WritePointer > self.header.MaxSize && !self.closed {
+	// We need to block here until there is room in the lane. If the
+	// lane is full, the mutex will be locked and we wait here until
+	// the data is pushed through to the server, and enough room is
+	// available. This has the effect of blocking the executor and
+	// stopping the query until we return. Other lanes are not
+	// affected.
+	for l.header.WritePointer > l.header.MaxSize && !self.closed {
 		self.c.Wait()
 	}
 }
@@ -173,47 +231,81 @@ func (self *FileBasedRingBuffer) TotalSize() uint64 {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
-	return uint64(self.header.AvailableBytes + self.header.LeasedBytes)
+	result := uint64(0)
+	for _, l := range self.lanes {
+		result += uint64(l.header.AvailableBytes + l.header.LeasedBytes)
+	}
+	return result
 }
 
 func (self *FileBasedRingBuffer) AvailableBytes() uint64 {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
-	return uint64(self.header.AvailableBytes)
+	result := uint64(0)
+	for _, l := range self.lanes {
+		result += uint64(l.header.AvailableBytes)
+	}
+	return result
 }
 
-// Call Lease() repeatadly and compress each result until we get
-// closer to the required size.
+// Lease messages from all the lanes and compress each result until
+// we get closer to the required size. Each lane first receives its
+// weighted share of the packet, then any remaining space is filled
+// in priority order. This ensures that bulk data can not starve the
+// higher priority lanes, but it still makes progress when they are
+// busy.
 func LeaseAndCompress(self IRingBuffer, size uint64,
 	compression crypto_proto.PackedMessageList_CompressionType) [][]byte {
 	result := [][]byte{}
 	total_len := uint64(0)
 	step := size / 4
 
-	for total_len < size {
-		next_message_list := self.Lease(step)
+	// Lease up to budget bytes from the lane. Returns false if the
+	// buffer was reset.
+	lease_lane := func(lane Lane, budget uint64) bool {
+		lane_len := uint64(0)
+		for lane_len < budget && total_len < size {
+			to_lease := budget - lane_len
+			if to_lease > step {
+				to_lease = step
+			}
 
-		// No more messages.
-		if len(next_message_list) == 0 {
-			break
-		}
+			next_message_list := self.LeaseLane(lane, to_lease)
 
-		if compression == crypto_proto.PackedMessageList_ZCOMPRESSION {
-			compressed_message_list, err := utils.Compress(next_message_list)
-			if err != nil || len(compressed_message_list) == 0 {
-				// Something terrible happened! The file is
-				// corrupted and it is better to start again.
-				self.Reset()
+			// No more messages.
+			if len(next_message_list) == 0 {
 				break
 			}
-			result = append(result, compressed_message_list)
-			total_len += uint64(len(compressed_message_list))
 
-		} else {
+			if compression == crypto_proto.PackedMessageList_ZCOMPRESSION {
+				compressed_message_list, err := utils.Compress(next_message_list)
+				if err != nil || len(compressed_message_list) == 0 {
+					// Something terrible happened! The file is
+					// corrupted and it is better to start again.
+					self.Reset()
+					return false
+				}
+				next_message_list = compressed_message_list
+			}
+
 			result = append(result, next_message_list)
+			lane_len += uint64(len(next_message_list))
 			total_len += uint64(len(next_message_list))
 		}
+		return true
+	}
+
+	for lane := Lane(0); lane < NUM_LANES; lane++ {
+		if !lease_lane(lane, laneShare(lane, size)) {
+			return result
+		}
+	}
+
+	for lane := Lane(0); lane < NUM_LANES && total_len < size; lane++ {
+		if !lease_lane(lane, size-total_len) {
+			return result
+		}
 	}
 
 	return result
@@ -223,39 +315,70 @@ func (self *FileBasedRingBuffer) Lease(size uint64) []byte {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
+	var result []byte
+	for _, l := range self.lanes {
+		if uint64(len(result)) > size {
+			break
+		}
+		result = append(result, self._LeaseLane(l, size-uint64(len(result)))...)
+	}
+
+	return result
+}
+
+func (self *FileBasedRingBuffer) LeaseLane(lane Lane, size uint64) []byte {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self._LeaseLane(self.lanes[lane], size)
+}
+
+// Assumes FileBasedRingBuffer is already under lock.
+func (self *FileBasedRingBuffer) _LeaseLane(l *fileLane, size uint64) []byte {
 	result := []byte{}
 
-	for self.header.WritePointer > self.leased_pointer {
-		n, err := self.fd.ReadAt(self.read_buf, self.leased_pointer)
-		if err == nil && n == len(self.read_buf) {
-			length := int64(binary.LittleEndian.Uint64(self.read_buf))
+	for l.header.WritePointer > l.leased_pointer {
+		n, err := l.fd.ReadAt(l.read_buf, l.leased_pointer)
+		if err == nil && n == len(l.read_buf) {
+			length := int64(binary.LittleEndian.Uint64(l.read_buf))
 
-			// File might be corrupt - just reset the entire file.
-			if length > constants.MAX_MEMORY*2 || length <= 0 {
+			// File might be corrupt - just reset the entire lane.
+			if length > constants.MAX_MEMORY*2 || length < RecordOverhead {
 				self.log_ctx.Error("Possible corruption detected - item length is too large.")
-				self._Truncate()
+				self._TruncateLane(l)
 				return nil
 			}
-			item := make([]byte, length)
-			n, err := self.fd.ReadAt(item, self.leased_pointer+8)
+			record := make([]byte, length)
+			n, err := l.fd.ReadAt(record, l.leased_pointer+8)
 			if err != nil || int64(n) != length {
 				self.log_ctx.Errorf(
 					"Possible corruption detected - expected item of length %v received %v.",
 					length, n)
-				self._Truncate()
+				self._TruncateLane(l)
 				return nil
 			}
 
-			// Filter the item from any blacklisted flow ids
-			filtered_item := FilterBlackListedItems(
-				context.Background(), self.flow_manager, self.config_obj, item)
-			result = append(result, filtered_item...)
+			// A record which fails authentication is skipped - the
+			// length is still good so the following records can be
+			// read.
+			item, err := decryptRecord(l.aead, record, l.leased_pointer)
+			if err != nil {
+				self.log_ctx.Errorf(
+					"Possible corruption detected - unable to decrypt item at offset %v.",
+					l.leased_pointer)
+
+			} else {
+				// Filter the item from any blacklisted flow ids
+				filtered_item := FilterBlackListedItems(
+					context.Background(), self.flow_manager, self.config_obj, item)
+				result = append(result, filtered_item...)
+			}
 
 			// Skip the full length of the unfiltered item to maintain
 			// alignment.
-			self.leased_pointer += 8 + int64(n)
-			self.header.LeasedBytes += int64(n)
-			self.header.AvailableBytes -= int64(n)
+			l.leased_pointer += 8 + int64(n)
+			l.header.LeasedBytes += int64(n) - RecordOverhead
+			l.header.AvailableBytes -= int64(n) - RecordOverhead
 
 			if uint64(len(result)) > size {
 				break
@@ -263,25 +386,17 @@ func (self *FileBasedRingBuffer) Lease(size uint64) []byte {
 
 		} else {
 			self.log_ctx.Error("Possible corruption detected: file too short.")
-			self._Truncate()
+			self._TruncateLane(l)
 		}
 	}
 
 	return result
 }
 
-// _Truncate returns the file to a virgin state. Assumes
+// _TruncateLane returns the lane to a virgin state. Assumes
 // FileBasedRingBuffer is already under lock.
-func (self *FileBasedRingBuffer) _Truncate() {
-	_ = self.fd.Truncate(0)
-	self.header.ReadPointer = FirstRecordOffset
-	self.header.WritePointer = FirstRecordOffset
-	self.header.AvailableBytes = 0
-	self.header.LeasedBytes = 0
-
-	self.leased_pointer = FirstRecordOffset
-	serialized, _ := self.header.MarshalBinary()
-	_, _ = self.fd.WriteAt(serialized, 0)
+func (self *FileBasedRingBuffer) _TruncateLane(l *fileLane) {
+	l._Truncate()
 
 	// Unblock any blocked writers to let them know there is now room
 	// in the file.
@@ -292,7 +407,9 @@ func (self *FileBasedRingBuffer) Reset() {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
-	self._Truncate()
+	for _, l := range self.lanes {
+		self._TruncateLane(l)
+	}
 }
 
 func (self *FileBasedRingBuffer) Close() {
@@ -300,8 +417,10 @@ func (self *FileBasedRingBuffer) Close() {
 	defer self.mu.Unlock()
 
 	self.closed = true
-	self.fd.Close()
-	os.Remove(self.fd.Name())
+	for _, l := range self.lanes {
+		l.fd.Close()
+		os.Remove(l.fd.Name())
+	}
 
 	// Unblock any blocked writers to let them know this file is now
 	// closed.
@@ -314,21 +433,37 @@ func (self *FileBasedRingBuffer) Commit() {
 
 	logger := logging.GetLogger(self.config_obj, &logging.ClientComponent)
 
-	// We read up to the write pointer, we may truncate the file now.
-	if self.leased_pointer == self.header.WritePointer {
-		self._Truncate()
-		return
-	}
+	for _, l := range self.lanes {
+		// Nothing was leased from this lane.
+		if l.leased_pointer == l.header.ReadPointer {
+			continue
+		}
 
-	self.header.ReadPointer = self.leased_pointer
-	self.header.LeasedBytes = 0
+		// We read up to the write pointer, we may truncate the file now.
+		if l.leased_pointer == l.header.WritePointer {
+			self._TruncateLane(l)
+			continue
+		}
 
-	serialized, _ := self.header.MarshalBinary()
-	_, _ = self.fd.WriteAt(serialized, 0)
+		l.header.ReadPointer = l.leased_pointer
+		l.header.LeasedBytes = 0
 
-	logger.WithFields(logrus.Fields{
-		"header": json.MustMarshalString(self.header),
-	}).Info("File Ring Buffer: Commit")
+		serialized, _ := l.header.MarshalBinary()
+		_, _ = l.fd.WriteAt(serialized, 0)
+
+		logger.WithFields(logrus.Fields{
+			"lane":   l.lane.String(),
+			"header": json.MustMarshalString(l.header),
+		}).Info("File Ring Buffer: Commit")
+	}
+}
+
+// The file used to store the lane.
+func laneFilename(filename string, lane Lane) string {
+	if lane == LANE_BULK {
+		return filename
+	}
+	return filename + "." + lane.String()
 }
 
 // Open an existing ring buffer file.
@@ -343,12 +478,18 @@ func OpenFileBasedRingBuffer(
 		return nil, errors.New("Unsupport platform")
 	}
 
-	fd, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0700)
-	if err != nil {
-		return nil, err
+	var fds [NUM_LANES]*os.File
+	for lane := Lane(0); lane < NUM_LANES; lane++ {
+		fd, err := os.OpenFile(laneFilename(filename, lane),
+			os.O_RDWR|os.O_CREATE, 0700)
+		if err != nil {
+			closeFiles(fds)
+			return nil, err
+		}
+		fds[lane] = fd
 	}
 
-	return newFileBasedRingBuffer(fd, config_obj,
+	return newFileBasedRingBuffer(fds, config_obj,
 		filename, flow_manager, log_ctx)
 }
 
@@ -363,30 +504,83 @@ func NewFileBasedRingBuffer(
 		return nil, errors.New("Unsupport platform")
 	}
 
-	fd, err := createFile(filename)
-	if err != nil {
-		return nil, err
+	var fds [NUM_LANES]*os.File
+	for lane := Lane(0); lane < NUM_LANES; lane++ {
+		fd, err := createFile(laneFilename(filename, lane))
+		if err != nil {
+			closeFiles(fds)
+			return nil, err
+		}
+		fds[lane] = fd
 	}
 
-	return newFileBasedRingBuffer(fd, config_obj,
+	return newFileBasedRingBuffer(fds, config_obj,
 		filename, flow_manager, log_ctx)
 }
 
+func closeFiles(fds [NUM_LANES]*os.File) {
+	for _, fd := range fds {
+		if fd != nil {
+			fd.Close()
+		}
+	}
+}
+
 func newFileBasedRingBuffer(
-	fd *os.File,
+	fds [NUM_LANES]*os.File,
 	config_obj *config_proto.Config,
 	filename string,
 	flow_manager *responder.FlowManager,
 	log_ctx *logging.LogContext) (*FileBasedRingBuffer, error) {
 
+	aead, err := getRingBufferCipher(config_obj)
+	if err != nil {
+		closeFiles(fds)
+		return nil, err
+	}
+
+	result := &FileBasedRingBuffer{
+		config_obj:   config_obj,
+		log_ctx:      log_ctx,
+		flow_manager: flow_manager,
+	}
+
+	for lane, fd := range fds {
+		l, err := openFileLane(Lane(lane), fd, aead,
+			laneBudget(Lane(lane), config_obj.Client.LocalBuffer.DiskSize),
+			log_ctx)
+		if err != nil {
+			closeFiles(fds)
+			return nil, err
+		}
+		result.lanes[lane] = l
+
+		// Upgrading the lane may replace the file.
+		fds[lane] = l.fd
+	}
+
+	result.c = sync.NewCond(&result.mu)
+
+	log_ctx.WithFields(logrus.Fields{
+		"filename": filename,
+		"max_size": config_obj.Client.LocalBuffer.DiskSize,
+	}).Info("FileBasedRingBuffer: Creation")
+
+	return result, nil
+}
+
+func openFileLane(
+	lane Lane, fd *os.File, aead cipher.AEAD, max_size uint64,
+	log_ctx *logging.LogContext) (*fileLane, error) {
+
 	header := &Header{
 		// Pad the header a bit to allow for extensions.
 		WritePointer:   FirstRecordOffset,
 		AvailableBytes: 0,
 		LeasedBytes:    0,
 		ReadPointer:    FirstRecordOffset,
-		MaxSize: int64(config_obj.Client.LocalBuffer.DiskSize) +
-			FirstRecordOffset,
+		MaxSize:        int64(max_size) + FirstRecordOffset,
+		Version:        RING_BUFFER_VERSION,
 	}
 	data := make([]byte, FirstRecordOffset)
 	n, err := fd.ReadAt(data, 0)
@@ -411,6 +605,11 @@ func newFileBasedRingBuffer(
 		}
 	}
 
+	// The file may have been written with a different buffer size or
+	// before the buffer was split into lanes. Always enforce the
+	// current budget.
+	header.MaxSize = int64(max_size) + FirstRecordOffset
+
 	// If we opened a file which is not yet fully committed adjust
 	// the available bytes again so we can replay the lost
 	// messages.
@@ -419,35 +618,142 @@ func newFileBasedRingBuffer(
 		header.LeasedBytes = 0
 	}
 
-	result := &FileBasedRingBuffer{
-		config_obj:     config_obj,
+	result := &fileLane{
+		lane:           lane,
 		fd:             fd,
 		header:         header,
+		aead:           aead,
 		read_buf:       make([]byte, 8),
 		write_buf:      make([]byte, 8),
 		leased_pointer: header.ReadPointer,
-		log_ctx:        log_ctx,
-		flow_manager:   flow_manager,
 	}
 
-	result.c = sync.NewCond(&result.mu)
-
-	log_ctx.WithFields(logrus.Fields{
-		"filename": fd.Name(),
-		"max_size": result.header.MaxSize,
-	}).Info("FileBasedRingBuffer: Creation")
+	if header.Version < RING_BUFFER_VERSION {
+		err := result.upgradeLegacyRecords(log_ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return result, nil
 }
 
-type RingBuffer struct {
-	config_obj *config_proto.Config
+// Older clients stored items in the clear. Encrypt any pending items
+// into a new file which then replaces the old one so no plaintext is
+// left on disk. If we crash half way the old file is still there to
+// upgrade next time.
+func (self *fileLane) upgradeLegacyRecords(log_ctx *logging.LogContext) error {
+	filename := self.fd.Name()
+	tmp_filename := filename + ".tmp"
+
+	out_fd, err := os.OpenFile(tmp_filename,
+		os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0700)
+	if err != nil {
+		return err
+	}
+
+	header := *self.header
+	header.Version = RING_BUFFER_VERSION
+	header.ReadPointer = FirstRecordOffset
+	header.WritePointer = FirstRecordOffset
+	header.AvailableBytes = 0
+	header.LeasedBytes = 0
+
+	err = self.encryptLegacyRecords(log_ctx, out_fd, &header)
+	if err == nil {
+		serialized, _ := header.MarshalBinary()
+		_, err = out_fd.WriteAt(serialized, 0)
+	}
+	if err == nil {
+		err = out_fd.Sync()
+	}
+	out_fd.Close()
+
+	if err != nil {
+		os.Remove(tmp_filename)
+		return err
+	}
+
+	if header.AvailableBytes > 0 {
+		log_ctx.Info("FileBasedRingBuffer: Encrypted %v bytes of legacy items",
+			header.AvailableBytes)
+	}
+
+	// Windows can not replace open files.
+	self.fd.Close()
+	err = os.Rename(tmp_filename, filename)
+	if err != nil {
+		os.Remove(tmp_filename)
+	}
+
+	fd, open_err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0700)
+	if open_err != nil {
+		return open_err
+	}
 
-	// We serialize messages into the messages queue as they
-	// arrive.
-	mu       sync.Mutex
+	self.fd = fd
+	if err != nil {
+		return err
+	}
+
+	self.header = &header
+	self.leased_pointer = header.ReadPointer
+	return nil
+}
+
+// Copy the pending legacy items into the file as encrypted records.
+func (self *fileLane) encryptLegacyRecords(log_ctx *logging.LogContext,
+	out_fd *os.File, header *Header) error {
+	read_pointer := self.header.ReadPointer
+
+	for read_pointer < self.header.WritePointer {
+		n, err := self.fd.ReadAt(self.read_buf, read_pointer)
+		if err != nil || n != len(self.read_buf) {
+			log_ctx.Error("Possible corruption detected: file too short.")
+			break
+		}
+
+		length := int64(binary.LittleEndian.Uint64(self.read_buf))
+		if length > constants.MAX_MEMORY*2 || length <= 0 {
+			log_ctx.Error("Possible corruption detected - item length is too large.")
+			break
+		}
+
+		item := make([]byte, length)
+		n, err = self.fd.ReadAt(item, read_pointer+8)
+		if err != nil || int64(n) != length {
+			log_ctx.Errorf(
+				"Possible corruption detected - expected item of length %v received %v.",
+				length, n)
+			break
+		}
+		read_pointer += 8 + length
+
+		record, err := encryptRecord(self.aead, item, header.WritePointer)
+		if err != nil {
+			return err
+		}
+
+		binary.LittleEndian.PutUint64(self.write_buf, uint64(len(record)))
+		_, err = out_fd.WriteAt(self.write_buf, header.WritePointer)
+		if err != nil {
+			return err
+		}
+
+		_, err = out_fd.WriteAt(record, header.WritePointer+8)
+		if err != nil {
+			return err
+		}
+		header.WritePointer += 8 + int64(len(record))
+		header.AvailableBytes += length
+	}
+
+	return nil
+}
+
+// A single lane of the in memory ring buffer.
+type memoryLane struct {
 	messages [][]byte
-	closed   bool
 
 	// The index in the messages array where messages before it
 	// are leased.
@@ -458,10 +764,23 @@ type RingBuffer struct {
 	// leased).
 	leased_length uint64
 
-	// Protects total_length
-	c            *sync.Cond
 	total_length uint64
 
+	// Enqueue blocks when the lane grows beyond this size.
+	max_size uint64
+}
+
+type RingBuffer struct {
+	config_obj *config_proto.Config
+
+	// We serialize messages into the lanes as they arrive.
+	mu     sync.Mutex
+	lanes  [NUM_LANES]*memoryLane
+	closed bool
+
+	// Signalled when room is made in the lanes.
+	c *sync.Cond
+
 	// The maximum size of the ring buffer
 	Size uint64
 
@@ -472,12 +791,20 @@ func (self *RingBuffer) Reset() {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
-	self.total_length = 0
-	self.messages = nil
+	for _, l := range self.lanes {
+		l.total_length = 0
+		l.leased_length = 0
+		l.leased_idx = 0
+		l.messages = nil
+	}
 	self.c.Broadcast()
 }
 
 func (self *RingBuffer) Enqueue(item []byte) {
+	self.EnqueueLane(LANE_BULK, item)
+}
+
+func (self *RingBuffer) EnqueueLane(lane Lane, item []byte) {
 	self.c.L.Lock()
 	defer self.c.L.Unlock()
 
@@ -488,22 +815,23 @@ func (self *RingBuffer) Enqueue(item []byte) {
 	// Write the message immediately into the ring buffer. If we
 	// crash, the message will be written to disk and
 	// retransmitted on restart.
-	self.messages = append(self.messages, item)
-	self.total_length += uint64(len(item))
+	l := self.lanes[lane]
+	l.messages = append(l.messages, item)
+	l.total_length += uint64(len(item))
 
 	logger := logging.GetLogger(self.config_obj, &logging.ClientComponent)
 	logger.WithFields(logrus.Fields{
+		"lane":         lane.String(),
 		"item_len":     len(item),
-		"total_length": self.total_length,
+		"total_length": l.total_length,
 	}).Info("Ring Buffer: Enqueue")
 
-	// We need to block here until there is room in the message
-	// queue. If the message queue is full, the mutex will be
-	// locked and we wait here until the data is pushed through to
-	// the server, and enough room is available. This has the
-	// effect of blocking the executor and stopping the query
-	// until we return.
-	for self.total_length > self.Size && !self.closed {
+	// We need to block here until there is room in the lane. If the
+	// lane is full, the mutex will be locked and we wait here until
+	// the data is pushed through to the server, and enough room is
+	// available. This has the effect of blocking the executor and
+	// stopping the query until we return.
+	for l.total_length > l.max_size && !self.closed {
 		self.c.Wait()
 	}
 }
@@ -512,14 +840,22 @@ func (self *RingBuffer) TotalSize() uint64 {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
-	return self.total_length
+	result := uint64(0)
+	for _, l := range self.lanes {
+		result += l.total_length
+	}
+	return result
 }
 
 func (self *RingBuffer) AvailableBytes() uint64 {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
-	return self.total_length - self.leased_length
+	result := uint64(0)
+	for _, l := range self.lanes {
+		result += l.total_length - l.leased_length
+	}
+	return result
 }
 
 // Determine if the item is blacklisted. Items are blacklisted when
@@ -576,14 +912,37 @@ func (self *RingBuffer) Lease(size uint64) []byte {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
+	var leased []byte
+	for lane := Lane(0); lane < NUM_LANES; lane++ {
+		if uint64(len(leased)) > size {
+			break
+		}
+		leased = append(leased,
+			self._LeaseLane(lane, size-uint64(len(leased)))...)
+	}
+
+	return leased
+}
+
+func (self *RingBuffer) LeaseLane(lane Lane, size uint64) []byte {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return self._LeaseLane(lane, size)
+}
+
+// Assumes RingBuffer is already under lock.
+func (self *RingBuffer) _LeaseLane(lane Lane, size uint64) []byte {
+	l := self.lanes[lane]
+
 	// No more to lease.
-	if self.leased_idx >= uint64(len(self.messages)) {
+	if l.leased_idx >= uint64(len(l.messages)) {
 		return nil
 	}
 
 	leased := make([]byte, 0)
 
-	for _, item := range self.messages[self.leased_idx:] {
+	for _, item := range l.messages[l.leased_idx:] {
 		filtered := FilterBlackListedItems(
 			context.Background(), self.flow_manager, self.config_obj, item)
 
@@ -591,8 +950,8 @@ func (self *RingBuffer) Lease(size uint64) []byte {
 
 		// Skip the full length of the unfiltered message - the
 		// filtered message may be shorter.
-		self.leased_length += uint64(len(item))
-		self.leased_idx += 1
+		l.leased_length += uint64(len(item))
+		l.leased_idx += 1
 		if uint64(len(leased)) > size {
 			break
 		}
@@ -600,8 +959,9 @@ func (self *RingBuffer) Lease(size uint64) []byte {
 
 	logger := logging.GetLogger(self.config_obj, &logging.ClientComponent)
 	logger.WithFields(logrus.Fields{
+		"lane":          lane.String(),
 		"total_length":  len(leased),
-		"leased_length": self.leased_length,
+		"leased_length": l.leased_length,
 	}).Info("Ring Buffer: Leased")
 
 	return leased
@@ -611,24 +971,34 @@ func (self *RingBuffer) Rollback() {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
-	self.total_length += self.leased_length
-	self.leased_length = 0
-	self.leased_idx = 0
+	for _, l := range self.lanes {
+		l.leased_length = 0
+		l.leased_idx = 0
+	}
 
 	logger := logging.GetLogger(self.config_obj, &logging.ClientComponent)
 	logger.WithFields(logrus.Fields{
-		"total_length":  self.total_length,
-		"leased_length": self.leased_length,
+		"total_length": self._TotalLength(),
 	}).Info("Ring Buffer: Rollback")
 }
 
+func (self *RingBuffer) _TotalLength() uint64 {
+	result := uint64(0)
+	for _, l := range self.lanes {
+		result += l.total_length
+	}
+	return result
+}
+
 func (self *RingBuffer) Close() {
 	self.mu.Lock()
 	defer self.mu.Unlock()
 
 	self.closed = true
-	self.total_length = 0
-	self.messages = nil
+	for _, l := range self.lanes {
+		l.total_length = 0
+		l.messages = nil
+	}
 	self.c.Broadcast()
 }
 
@@ -638,21 +1008,29 @@ func (self *RingBuffer) Commit() {
 	defer self.mu.Unlock()
 
 	logger := logging.GetLogger(self.config_obj, &logging.ClientComponent)
-	logger.WithFields(logrus.Fields{
-		"total_length":  self.total_length,
-		"leased_length": self.leased_length,
-	}).Info("Ring Buffer: Commit")
 
-	if uint64(len(self.messages)) >= self.leased_idx {
-		self.messages = self.messages[self.leased_idx:]
-	}
+	for lane, l := range self.lanes {
+		if l.leased_idx == 0 {
+			continue
+		}
 
-	self.total_length -= self.leased_length
-	self.leased_length = 0
-	self.leased_idx = 0
+		logger.WithFields(logrus.Fields{
+			"lane":          Lane(lane).String(),
+			"total_length":  l.total_length,
+			"leased_length": l.leased_length,
+		}).Info("Ring Buffer: Commit")
+
+		if uint64(len(l.messages)) >= l.leased_idx {
+			l.messages = l.messages[l.leased_idx:]
+		}
+
+		l.total_length -= l.leased_length
+		l.leased_length = 0
+		l.leased_idx = 0
+	}
 
 	logger.WithFields(logrus.Fields{
-		"total_length": self.total_length,
+		"total_length": self._TotalLength(),
 	}).Info("Ring Buffer: Truncate")
 
 	self.c.Broadcast()
@@ -661,11 +1039,17 @@ func (self *RingBuffer) Commit() {
 func NewRingBuffer(config_obj *config_proto.Config,
 	flow_manager *responder.FlowManager, size uint64) *RingBuffer {
 	result := &RingBuffer{
-		messages:     make([][]byte, 0),
 		Size:         size,
 		config_obj:   config_obj,
 		flow_manager: flow_manager,
 	}
+
+	for lane := Lane(0); lane < NUM_LANES; lane++ {
+		result.lanes[lane] = &memoryLane{
+			messages: make([][]byte, 0),
+			max_size: laneBudget(lane, size),
+		}
+	}
 	result.c = sync.NewCond(&result.mu)
 
 	return result
@@ -0,0 +1,112 @@
+package http_comms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"golang.org/x/crypto/hkdf"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/services/writeback"
+)
+
+// Items in the file based ring buffer are encrypted with AES-GCM so
+// queued results are not stored in the clear while the client is
+// offline. The key is derived from the client's private key so a
+// restarted client can still replay its buffer.
+//
+// Each record is stored as:
+//   length (8 bytes) | nonce (12 bytes) | ciphertext | tag (16 bytes)
+//
+// The record's file offset is authenticated as additional data so
+// records can not be moved around in the file.
+
+const (
+	RING_BUFFER_KEY_INFO = "Velociraptor Ring Buffer"
+	nonceSize            = 12
+	tagSize              = 16
+
+	// The number of bytes each record adds to the item.
+	RecordOverhead = nonceSize + tagSize
+)
+
+var (
+	// If the client has no private key yet we use a random key for
+	// the life of the process.
+	ephemeral_mu  sync.Mutex
+	ephemeral_key []byte
+)
+
+func getEphemeralKey() ([]byte, error) {
+	ephemeral_mu.Lock()
+	defer ephemeral_mu.Unlock()
+
+	if ephemeral_key == nil {
+		key := make([]byte, 32)
+		_, err := rand.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		ephemeral_key = key
+	}
+	return ephemeral_key, nil
+}
+
+func getRingBufferCipher(config_obj *config_proto.Config) (cipher.AEAD, error) {
+	var key []byte
+
+	wb, err := writeback.GetWritebackService().GetWriteback(config_obj)
+	if err == nil && wb.PrivateKey != "" {
+		key = make([]byte, 32)
+		_, err = io.ReadFull(hkdf.New(sha256.New, []byte(wb.PrivateKey),
+			nil, []byte(RING_BUFFER_KEY_INFO)), key)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		key, err = getEphemeralKey()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func recordAdditionalData(offset int64) []byte {
+	result := make([]byte, 8)
+	binary.LittleEndian.PutUint64(result, uint64(offset))
+	return result
+}
+
+// Encrypt the item into a record to be stored at offset.
+func encryptRecord(aead cipher.AEAD, item []byte, offset int64) ([]byte, error) {
+	record := make([]byte, nonceSize, nonceSize+len(item)+tagSize)
+	_, err := rand.Read(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(record, record[:nonceSize], item,
+		recordAdditionalData(offset)), nil
+}
+
+// Decrypt and authenticate a record read from offset.
+func decryptRecord(aead cipher.AEAD, record []byte, offset int64) ([]byte, error) {
+	if len(record) < RecordOverhead {
+		return nil, errors.New("Record too short")
+	}
+
+	return aead.Open(nil, record[:nonceSize], record[nonceSize:],
+		recordAdditionalData(offset))
+}
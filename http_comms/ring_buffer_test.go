@@ -1,19 +1,26 @@
 package http_comms
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
 	"www.velocidex.com/golang/velociraptor/config"
+	"www.velocidex.com/golang/velociraptor/constants"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/logging"
 	"www.velocidex.com/golang/velociraptor/responder"
+	"www.velocidex.com/golang/velociraptor/vtesting"
 )
 
 var (
@@ -29,6 +36,13 @@ func getTempFile(t *testing.T) string {
 	return fd.Name()
 }
 
+// Remove the files of all the lanes.
+func removeRB(filename string) {
+	for lane := Lane(0); lane < NUM_LANES; lane++ {
+		os.Remove(laneFilename(filename, lane))
+	}
+}
+
 func createRB(t *testing.T, filename string) (*FileBasedRingBuffer, *responder.FlowManager) {
 	config_obj := config.GetDefaultConfig()
 	config_obj.Client.LocalBuffer.FilenameLinux = filename
@@ -79,7 +93,7 @@ func TestRingBuffer(t *testing.T) {
 	test_string := "Hello"    // 5 bytes
 	test_string2 := "Goodbye" // 7 bytes
 
-	defer os.Remove(filename)
+	defer removeRB(filename)
 
 	ring_buffer, flow_manager := createRB(t, filename)
 	ring_buffer.Enqueue([]byte(test_string))
@@ -91,6 +105,7 @@ func TestRingBuffer(t *testing.T) {
 	assert.Equal(t,
 		FirstRecordOffset+
 			8+ // Length of item
+			RecordOverhead+
 			int64(len(test_string)),
 		st.Size())
 
@@ -98,7 +113,7 @@ func TestRingBuffer(t *testing.T) {
 	ring_buffer = openRB(t, filename, flow_manager)
 
 	// First message available.
-	assert.Equal(t, ring_buffer.header.AvailableBytes,
+	assert.Equal(t, ring_buffer.lanes[LANE_BULK].header.AvailableBytes,
 		int64(len(test_string)))
 
 	// Enqueue another message.
@@ -110,8 +125,9 @@ func TestRingBuffer(t *testing.T) {
 	assert.Equal(t,
 		FirstRecordOffset+
 			8+ // Length of item
+			RecordOverhead+
 			int64(len(test_string))+
-			8+
+			8+RecordOverhead+
 			int64(len(test_string2)),
 		st.Size())
 
@@ -119,7 +135,7 @@ func TestRingBuffer(t *testing.T) {
 	ring_buffer = openRB(t, filename, flow_manager)
 
 	// Two messages available.
-	assert.Equal(t, ring_buffer.header.AvailableBytes,
+	assert.Equal(t, ring_buffer.lanes[LANE_BULK].header.AvailableBytes,
 		int64(len(test_string))+int64(len(test_string2)))
 
 	// Lease a message
@@ -128,11 +144,11 @@ func TestRingBuffer(t *testing.T) {
 	assert.Equal(t, lease, []byte(test_string))
 
 	// Second message available still.
-	assert.Equal(t, ring_buffer.header.AvailableBytes,
+	assert.Equal(t, ring_buffer.lanes[LANE_BULK].header.AvailableBytes,
 		int64(len(test_string2)))
 
 	// First message leased.
-	assert.Equal(t, ring_buffer.header.LeasedBytes,
+	assert.Equal(t, ring_buffer.lanes[LANE_BULK].header.LeasedBytes,
 		int64(len(test_string)))
 
 	// Since we did not commit the last message - opening again
@@ -140,7 +156,7 @@ func TestRingBuffer(t *testing.T) {
 	ring_buffer = openRB(t, filename, flow_manager)
 
 	// Two messages available.
-	assert.Equal(t, ring_buffer.header.AvailableBytes,
+	assert.Equal(t, ring_buffer.lanes[LANE_BULK].header.AvailableBytes,
 		int64(len(test_string))+int64(len(test_string2)))
 
 	// Lease a message
@@ -153,7 +169,7 @@ func TestRingBuffer(t *testing.T) {
 	ring_buffer = openRB(t, filename, flow_manager)
 
 	// Now only the second message is available.
-	assert.Equal(t, ring_buffer.header.AvailableBytes,
+	assert.Equal(t, ring_buffer.lanes[LANE_BULK].header.AvailableBytes,
 		int64(len(test_string2)))
 
 	// But the file contains both messages still.
@@ -162,8 +178,9 @@ func TestRingBuffer(t *testing.T) {
 	assert.Equal(t,
 		FirstRecordOffset+
 			8+ // Length of item
+			RecordOverhead+
 			int64(len(test_string))+
-			8+
+			8+RecordOverhead+
 			int64(len(test_string2)),
 		st.Size())
 
@@ -174,11 +191,11 @@ func TestRingBuffer(t *testing.T) {
 	assert.Equal(t, lease, []byte(test_string2))
 
 	// No messages are available now.
-	assert.Equal(t, ring_buffer.header.AvailableBytes, int64(0))
+	assert.Equal(t, ring_buffer.lanes[LANE_BULK].header.AvailableBytes, int64(0))
 
 	// But second message is currently leased - if we crash it
 	// will be replayed.
-	assert.Equal(t, ring_buffer.header.LeasedBytes,
+	assert.Equal(t, ring_buffer.lanes[LANE_BULK].header.LeasedBytes,
 		int64(len(test_string2)))
 
 	// But the file contains both messages still.
@@ -187,8 +204,9 @@ func TestRingBuffer(t *testing.T) {
 	assert.Equal(t,
 		FirstRecordOffset+
 			8+ // Length of item
+			RecordOverhead+
 			int64(len(test_string))+
-			8+
+			8+RecordOverhead+
 			int64(len(test_string2)),
 		st.Size())
 
@@ -198,8 +216,8 @@ func TestRingBuffer(t *testing.T) {
 	// This should now truncate the file since there are no more
 	// AvailableBytes and we committed the last outstanding
 	// message.
-	assert.Equal(t, ring_buffer.header.AvailableBytes, int64(0))
-	assert.Equal(t, ring_buffer.header.LeasedBytes, int64(0))
+	assert.Equal(t, ring_buffer.lanes[LANE_BULK].header.AvailableBytes, int64(0))
+	assert.Equal(t, ring_buffer.lanes[LANE_BULK].header.LeasedBytes, int64(0))
 
 	st, err = os.Stat(filename)
 	assert.NoError(t, err)
@@ -214,7 +232,7 @@ func TestRingBufferCorruption(t *testing.T) {
 	filename := getTempFile(t)
 	test_string := "Hello"
 
-	defer os.Remove(filename)
+	defer removeRB(filename)
 
 	ring_buffer, flow_manager := createRB(t, filename)
 	ring_buffer.Enqueue([]byte(test_string))
@@ -224,19 +242,19 @@ func TestRingBufferCorruption(t *testing.T) {
 	assert.NoError(t, err)
 
 	fd.Seek(FirstRecordOffset, os.SEEK_SET)
-	n, err := fd.Write([]byte{20, 0, 0, 0, 0, 0, 0, 0})
+	n, err := fd.Write([]byte{40, 0, 0, 0, 0, 0, 0, 0})
 	assert.NoError(t, err)
 	assert.Equal(t, n, 8)
 	fd.Close()
 
 	ring_buffer = openRB(t, filename, flow_manager)
 
-	// Possible corruption detected - expected item of length 20 received 5.
+	// Possible corruption detected - expected item of length 40 received 33.
 	lease := ring_buffer.Lease(1)
 	assert.Nil(t, lease)
 
 	assert.Equal(t, checkLogMessage(hook,
-		"Possible corruption detected - expected item of length 20 received 5."), true)
+		"Possible corruption detected - expected item of length 40 received 33."), true)
 
 	st, err := os.Stat(filename)
 	assert.NoError(t, err)
@@ -257,7 +275,7 @@ func TestRingBufferCorruption(t *testing.T) {
 	assert.Equal(t, true, checkLogMessage(hook,
 		"Possible corruption detected: file too short."))
 
-	assert.Equal(t, int64(FirstRecordOffset), ring_buffer.header.WritePointer)
+	assert.Equal(t, int64(FirstRecordOffset), ring_buffer.lanes[LANE_BULK].header.WritePointer)
 
 	// Invalid header.
 	fd, err = os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0700)
@@ -273,7 +291,7 @@ func TestRingBufferCorruption(t *testing.T) {
 	assert.Equal(t, checkLogMessage(hook,
 		"Possible corruption detected: Invalid header length."), true)
 
-	assert.Equal(t, int64(FirstRecordOffset), ring_buffer.header.WritePointer)
+	assert.Equal(t, int64(FirstRecordOffset), ring_buffer.lanes[LANE_BULK].header.WritePointer)
 	ring_buffer.Enqueue([]byte(test_string))
 
 	// Create a very large items length.
@@ -294,7 +312,7 @@ func TestRingBufferCorruption(t *testing.T) {
 	assert.Equal(t, checkLogMessage(hook,
 		"Possible corruption detected - item length is too large."), true)
 
-	assert.Equal(t, int64(FirstRecordOffset), ring_buffer.header.WritePointer)
+	assert.Equal(t, int64(FirstRecordOffset), ring_buffer.lanes[LANE_BULK].header.WritePointer)
 }
 
 func checkLogMessage(hook *test.Hook, msg string) bool {
@@ -311,7 +329,7 @@ func checkLogMessage(hook *test.Hook, msg string) bool {
 
 func TestRingBufferCancellation(t *testing.T) {
 	filename := getTempFile(t)
-	defer os.Remove(filename)
+	defer removeRB(filename)
 
 	// Make SessionId unique for each test run
 	message_list := &crypto_proto.MessageList{
@@ -395,3 +413,271 @@ func TestRingBufferCancellation(t *testing.T) {
 	// Make sure all messages are delivered
 	assert.Equal(t, serialized_message_list, lease)
 }
+
+func serializeMessage(t *testing.T, msg *crypto_proto.VeloMessage) []byte {
+	serialized, err := proto.Marshal(&crypto_proto.MessageList{
+		Job: []*crypto_proto.VeloMessage{msg}})
+	assert.NoError(t, err)
+	return serialized
+}
+
+func TestRingBufferLanes(t *testing.T) {
+	status := &crypto_proto.VeloMessage{
+		SessionId: "F.1234",
+		FlowStats: &crypto_proto.FlowStats{TotalCollectedRows: 1},
+	}
+	final_status := &crypto_proto.VeloMessage{
+		SessionId: "F.1234",
+		FlowStats: &crypto_proto.FlowStats{FlowComplete: true},
+	}
+	event := &crypto_proto.VeloMessage{
+		SessionId: constants.MONITORING_WELL_KNOWN_FLOW,
+		VQLResponse: &actions_proto.VQLResponse{
+			JSONLResponse: "Event",
+		},
+	}
+	upload := &crypto_proto.VeloMessage{
+		SessionId: "F.1234",
+		FileBuffer: &actions_proto.FileBuffer{
+			Data: []byte("FileBuffer"),
+		},
+	}
+
+	assert.Equal(t, LANE_STATUS, GetLane(status))
+	assert.Equal(t, LANE_EVENTS, GetLane(event))
+	assert.Equal(t, LANE_BULK, GetLane(upload))
+
+	// The final status must follow the flow's data.
+	assert.Equal(t, LANE_BULK, GetLane(final_status))
+
+	filename := getTempFile(t)
+	defer removeRB(filename)
+
+	ring_buffer, flow_manager := createRB(t, filename)
+
+	// Queue the upload first, then the higher priority messages.
+	ring_buffer.EnqueueLane(GetLane(upload), serializeMessage(t, upload))
+	ring_buffer.EnqueueLane(GetLane(event), serializeMessage(t, event))
+	ring_buffer.EnqueueLane(GetLane(status), serializeMessage(t, status))
+
+	// Each lane is stored in its own file.
+	for lane := Lane(0); lane < NUM_LANES; lane++ {
+		st, err := os.Stat(laneFilename(filename, lane))
+		assert.NoError(t, err)
+		assert.True(t, st.Size() > FirstRecordOffset)
+	}
+
+	// Leasing drains the lanes in priority order.
+	lease := ring_buffer.Lease(1)
+	assert.Equal(t, serializeMessage(t, status), lease)
+
+	// Reopening the buffer replays the uncommitted lease.
+	ring_buffer = openRB(t, filename, flow_manager)
+	assert.Equal(t, uint64(len(serializeMessage(t, status))+
+		len(serializeMessage(t, event))+
+		len(serializeMessage(t, upload))), ring_buffer.AvailableBytes())
+
+	leases := LeaseAndCompress(ring_buffer, 1000,
+		crypto_proto.PackedMessageList_UNCOMPRESSED)
+	assert.Equal(t, [][]byte{
+		serializeMessage(t, status),
+		serializeMessage(t, event),
+		serializeMessage(t, upload),
+	}, leases)
+
+	ring_buffer.Commit()
+	assert.Equal(t, uint64(0), ring_buffer.TotalSize())
+}
+
+// Reopening with a smaller disk size enforces the new lane budgets.
+func TestRingBufferReopenSmaller(t *testing.T) {
+	filename := getTempFile(t)
+	defer removeRB(filename)
+
+	ring_buffer, flow_manager := createRB(t, filename)
+	ring_buffer.Enqueue([]byte("Hello"))
+
+	config_obj := config.GetDefaultConfig()
+	config_obj.Client.LocalBuffer.FilenameLinux = filename
+	config_obj.Client.LocalBuffer.FilenameWindows = filename
+	config_obj.Client.LocalBuffer.FilenameDarwin = filename
+	config_obj.Client.LocalBuffer.DiskSize = 1000
+
+	null_logger, _ := test.NewNullLogger()
+	logger := &logging.LogContext{Logger: null_logger}
+
+	ring_buffer, err := OpenFileBasedRingBuffer(context.Background(),
+		config_obj, flow_manager, logger)
+	assert.NoError(t, err)
+
+	total := int64(0)
+	for lane, l := range ring_buffer.lanes {
+		assert.Equal(t, int64(laneBudget(Lane(lane), 1000))+FirstRecordOffset,
+			l.header.MaxSize)
+		total += l.header.MaxSize - FirstRecordOffset
+	}
+	assert.True(t, total <= 1000)
+
+	// The pending data is still there.
+	assert.Equal(t, []byte("Hello"), ring_buffer.Lease(100))
+}
+
+// A full bulk lane does not prevent other lanes from being sent.
+func TestRingBufferLaneBudget(t *testing.T) {
+	config_obj := config.GetDefaultConfig()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	flow_manager := responder.NewFlowManager(ctx, config_obj)
+	ring_buffer := NewRingBuffer(config_obj, flow_manager, 100)
+
+	bulk := bytes.Repeat([]byte("B"), 10)
+	event := []byte("Event")
+
+	// Fill the bulk lane with more than its budget in the
+	// background. This will block until the lane is drained.
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			ring_buffer.EnqueueLane(LANE_BULK, bulk)
+		}
+	}()
+
+	vtesting.WaitUntil(5*time.Second, t, func() bool {
+		return ring_buffer.TotalSize() > laneBudget(LANE_BULK, 100)
+	})
+
+	// The events lane still has room.
+	ring_buffer.EnqueueLane(LANE_EVENTS, event)
+
+	// Even when leasing a small packet, the events are sent before
+	// the bulk data.
+	leases := LeaseAndCompress(ring_buffer, 20,
+		crypto_proto.PackedMessageList_UNCOMPRESSED)
+	assert.True(t, len(leases) > 1)
+	assert.Equal(t, event, leases[0])
+	ring_buffer.Commit()
+
+	// Drain the rest of the bulk data - this unblocks the writer.
+	total := 0
+	for _, lease := range leases[1:] {
+		total += len(lease)
+	}
+	vtesting.WaitUntil(5*time.Second, t, func() bool {
+		for _, lease := range LeaseAndCompress(ring_buffer, 100,
+			crypto_proto.PackedMessageList_UNCOMPRESSED) {
+			total += len(lease)
+		}
+		ring_buffer.Commit()
+		return total == 10*len(bulk)
+	})
+	wg.Wait()
+
+	assert.Equal(t, uint64(0), ring_buffer.TotalSize())
+}
+
+func TestRingBufferEncryption(t *testing.T) {
+	filename := getTempFile(t)
+	defer removeRB(filename)
+
+	secret := "Secret Credentials"
+	ring_buffer, flow_manager := createRB(t, filename)
+	ring_buffer.Enqueue([]byte(secret))
+	ring_buffer.Enqueue([]byte("Goodbye"))
+
+	// The items are not stored in the clear.
+	data, err := ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), secret)
+
+	// Corrupt the first record's ciphertext.
+	fd, err := os.OpenFile(filename, os.O_RDWR, 0700)
+	assert.NoError(t, err)
+	_, err = fd.WriteAt([]byte{0xff}, FirstRecordOffset+8+nonceSize+1)
+	assert.NoError(t, err)
+	fd.Close()
+
+	// The corrupted record is skipped but the next one is fine.
+	ring_buffer = openRB(t, filename, flow_manager)
+	lease := ring_buffer.Lease(100)
+	assert.Equal(t, []byte("Goodbye"), lease)
+
+	assert.True(t, checkLogMessage(hook, fmt.Sprintf(
+		"Possible corruption detected - unable to decrypt item at offset %v.",
+		FirstRecordOffset)))
+
+	ring_buffer.Commit()
+	assert.Equal(t, uint64(0), ring_buffer.TotalSize())
+}
+
+// Unencrypted buffers written by older clients are upgraded when
+// opened.
+func TestRingBufferLegacyUpgrade(t *testing.T) {
+	filename := getTempFile(t)
+	defer removeRB(filename)
+
+	items := []string{"Hello", "Goodbye"}
+
+	header := &Header{
+		ReadPointer:  FirstRecordOffset,
+		WritePointer: FirstRecordOffset,
+		MaxSize:      1000,
+	}
+
+	fd, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0700)
+	assert.NoError(t, err)
+	for _, item := range items {
+		length := make([]byte, 8)
+		binary.LittleEndian.PutUint64(length, uint64(len(item)))
+		_, err = fd.WriteAt(length, header.WritePointer)
+		assert.NoError(t, err)
+		_, err = fd.WriteAt([]byte(item), header.WritePointer+8)
+		assert.NoError(t, err)
+		header.WritePointer += 8 + int64(len(item))
+		header.AvailableBytes += int64(len(item))
+	}
+	serialized, _ := header.MarshalBinary()
+	_, err = fd.WriteAt(serialized, 0)
+	assert.NoError(t, err)
+	fd.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config_obj := config.GetDefaultConfig()
+	flow_manager := responder.NewFlowManager(ctx, config_obj)
+
+	ring_buffer := openRB(t, filename, flow_manager)
+	l := ring_buffer.lanes[LANE_BULK]
+	assert.Equal(t, uint32(RING_BUFFER_VERSION), l.header.Version)
+	assert.Equal(t, int64(len("HelloGoodbye")), l.header.AvailableBytes)
+
+	// The single legacy file now only gets the bulk lane's budget.
+	assert.Equal(t, int64(laneBudget(LANE_BULK,
+		config.GetDefaultConfig().Client.LocalBuffer.DiskSize))+FirstRecordOffset,
+		l.header.MaxSize)
+
+	// The plaintext is no longer on disk.
+	data, err := ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	for _, item := range items {
+		assert.NotContains(t, string(data), item)
+	}
+
+	_, err = os.Stat(filename + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+
+	// Reopening the upgraded file still replays the items.
+	ring_buffer = openRB(t, filename, flow_manager)
+	lease := ring_buffer.Lease(100)
+	assert.Equal(t, []byte("HelloGoodbye"), lease)
+
+	// Once drained the old records are gone.
+	ring_buffer.Commit()
+	st, err := os.Stat(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(FirstRecordOffset), st.Size())
+}
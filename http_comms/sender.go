@@ -142,10 +142,10 @@ func (self *Sender) PumpExecutorToRingBuffer(ctx context.Context) {
 					continue
 				}
 
-				// RingBuffer.Enqueue may block if there is
-				// no room in the ring buffer. While waiting
-				// here we block the executor channel.
-				self.ring_buffer.Enqueue(serialized_msg)
+				// RingBuffer.EnqueueLane may block if there
+				// is no room in the message's lane. While
+				// waiting here we block the executor channel.
+				self.ring_buffer.EnqueueLane(GetLane(msg), serialized_msg)
 			}
 
 			// We have just filled the message queue with
@@ -52,6 +52,14 @@ func (self ClientPathManager) Metadata() api.DSPathSpec {
 		SetType(api.PATH_TYPE_DATASTORE_JSON)
 }
 
+// Uploads from this client which the server only partially
+// received. The client may resume them in later collections.
+func (self ClientPathManager) PartialUploads() api.DSPathSpec {
+	return self.root.AddChild("partial_uploads").
+		SetType(api.PATH_TYPE_DATASTORE_JSON).
+		SetTag("PartialUploads")
+}
+
 // Store each client's public key so we can communicate with it.
 func (self ClientPathManager) Key() api.DSPathSpec {
 	return self.root.AddChild("key").
@@ -92,6 +92,26 @@ func (self *NotebookPathManager) ZipExport() api.FSPathSpec {
 		SetType(api.PATH_TYPE_FILESTORE_DOWNLOAD_ZIP)
 }
 
+// Query results cached by the notebook_cache() plugin are stored in
+// the notebook so they are removed with it.
+func (self *NotebookPathManager) QueryCacheDirectory() api.FSPathSpec {
+	return self.root.AddChild(self.notebook_id, "cache").AsFilestorePath()
+}
+
+func (self *NotebookPathManager) QueryCacheDSDirectory() api.DSPathSpec {
+	return self.root.AddChild(self.notebook_id, "cache")
+}
+
+func (self *NotebookPathManager) QueryCache(name string) api.FSPathSpec {
+	return self.root.AddUnsafeChild(self.notebook_id, "cache", name).
+		AsFilestorePath().SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
+
+func (self *NotebookPathManager) QueryCacheMetadata(name string) api.DSPathSpec {
+	return self.root.AddUnsafeChild(self.notebook_id, "cache", name).
+		SetTag("NotebookQueryCache")
+}
+
 // Where we store all our super timelines
 func (self *NotebookPathManager) SuperTimelineDir() api.DSPathSpec {
 	return self.root.AddChild(self.notebook_id, "timelines")
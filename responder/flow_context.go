@@ -311,6 +311,19 @@ func (self *FlowContext) SessionId() string {
 	return self.flow_id
 }
 
+// Find an upload interrupted in an earlier flow which the server
+// offered to resume. Returns nil if there is none.
+func (self *FlowContext) GetResumableUpload(flow_id, accessor string,
+	components []string) *crypto_proto.ResumableUpload {
+	for _, upload := range self.req.ResumableUploads {
+		if upload.FlowId == flow_id && upload.Accessor == accessor &&
+			utils.StringSliceEq(upload.Components, components) {
+			return upload
+		}
+	}
+	return nil
+}
+
 // Drains the error message buffer for transmission
 func (self *FlowContext) getLogMessages() (
 	buf []byte, start_id uint64, message_count uint64, error_message string) {
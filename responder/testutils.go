@@ -28,6 +28,12 @@ func (self *TestResponderType) Output() chan *crypto_proto.VeloMessage {
 
 func TestResponderWithFlowId(
 	config_obj *config_proto.Config, flow_id string) *TestResponderType {
+	return TestResponderWithFlowRequest(config_obj, flow_id, nil)
+}
+
+func TestResponderWithFlowRequest(
+	config_obj *config_proto.Config, flow_id string,
+	flow_request *crypto_proto.FlowRequest) *TestResponderType {
 	ctx := context.Background()
 
 	output_chan, drain := NewMessageDrain(ctx)
@@ -48,7 +54,10 @@ func TestResponderWithFlowId(
 	result.status.Status = crypto_proto.VeloStatus_PROGRESS
 	result.wg.Add(1)
 	flow_context := flow_manager.FlowContext(
-		result.output, &crypto_proto.VeloMessage{SessionId: flow_id})
+		result.output, &crypto_proto.VeloMessage{
+			SessionId:   flow_id,
+			FlowRequest: flow_request,
+		})
 	result.FlowResponder.flow_context = flow_context
 	result.FlowResponder.flow_context.mu.Lock()
 	result.FlowResponder.flow_context.responders = append(
@@ -0,0 +1,60 @@
+package result_sets
+
+import (
+	"sync"
+
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/vfilter"
+)
+
+const (
+	// The scope variable holding the tracker.
+	RESULT_SET_TRACKER = "$result_set_tracker"
+)
+
+// Records the stored result sets a query reads. Plugins which read
+// result sets register them with the tracker in the scope (if any)
+// so callers can tell which result sets a query depends on.
+type ResultSetTracker struct {
+	mu    sync.Mutex
+	paths []api.FSPathSpec
+	seen  map[string]bool
+}
+
+func (self *ResultSetTracker) Add(path api.FSPathSpec) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	key := path.AsClientPath()
+	if self.seen[key] {
+		return
+	}
+	self.seen[key] = true
+	self.paths = append(self.paths, path)
+}
+
+func (self *ResultSetTracker) Paths() []api.FSPathSpec {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return append([]api.FSPathSpec{}, self.paths...)
+}
+
+func NewResultSetTracker() *ResultSetTracker {
+	return &ResultSetTracker{
+		seen: make(map[string]bool),
+	}
+}
+
+// Register the result set with the scope's tracker.
+func TrackResultSet(scope vfilter.Scope, path api.FSPathSpec) {
+	tracker_any, pres := scope.Resolve(RESULT_SET_TRACKER)
+	if !pres {
+		return
+	}
+
+	tracker, ok := tracker_any.(*ResultSetTracker)
+	if ok {
+		tracker.Add(path)
+	}
+}
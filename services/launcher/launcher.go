@@ -633,6 +633,19 @@ func (self *Launcher) WriteArtifactCollectionRecord(
 		},
 	}
 
+	// Offer the client to resume any uploads interrupted in earlier
+	// collections.
+	if client_id != "server" {
+		resumable, err := GetResumableUploads(ctx, config_obj, client_id)
+		if err != nil {
+			// The collection can still run without resuming.
+			logger := logging.GetLogger(config_obj, &logging.FrontendComponent)
+			logger.Error("WriteArtifactCollectionRecord: GetResumableUploads: %v", err)
+		} else {
+			task.FlowRequest.ResumableUploads = resumable
+		}
+	}
+
 	if collector_request.TraceFreqSec > 0 {
 		task.FlowRequest.Trace, err = self.calculateTraceQuery(ctx, config_obj,
 			collector_request.TraceFreqSec)
@@ -0,0 +1,258 @@
+package launcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+/*
+  Large uploads may be interrupted (e.g. the client restarts or loses
+  its connection). The server keeps track of uploads which were
+  started but never completed in each client's partial uploads
+  record. When a new collection is scheduled for the client, we send
+  the list of partial uploads with it so the client can resume them
+  instead of starting from scratch.
+
+  The number of bytes the server holds for each partial upload is
+  simply the size of the upload file in the flow.
+*/
+
+var (
+	partial_mu sync.Mutex
+
+	// Forget partial uploads older than this.
+	PARTIAL_UPLOAD_EXPIRY = 7 * 24 * time.Hour
+
+	// Only this many partial uploads are remembered for each client.
+	MAX_PARTIAL_UPLOADS = 20
+)
+
+func isSameUpload(a *crypto_proto.ResumableUpload,
+	flow_id, accessor string, components []string) bool {
+	return a.FlowId == flow_id && a.Accessor == accessor &&
+		utils.StringSliceEq(a.Components, components)
+}
+
+func modifyPartialUploads(
+	config_obj *config_proto.Config, client_id string,
+	cb func(record *crypto_proto.ResumableUploads) bool) error {
+
+	partial_mu.Lock()
+	defer partial_mu.Unlock()
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	path := paths.NewClientPathManager(client_id).PartialUploads()
+	record := &crypto_proto.ResumableUploads{}
+
+	// Missing record is not an error - start with an empty one.
+	_ = db.GetSubject(config_obj, path, record)
+
+	if !cb(record) {
+		return nil
+	}
+
+	if len(record.Uploads) == 0 {
+		return db.DeleteSubject(config_obj, path)
+	}
+
+	return db.SetSubject(config_obj, path, record)
+}
+
+// Record that an upload was started in a flow. It will be removed
+// when the upload completes.
+func RecordPartialUpload(
+	config_obj *config_proto.Config,
+	client_id, flow_id, accessor, path string,
+	components []string) error {
+
+	return modifyPartialUploads(config_obj, client_id,
+		func(record *crypto_proto.ResumableUploads) bool {
+			// Replace any existing record of the same upload.
+			uploads := make([]*crypto_proto.ResumableUpload, 0,
+				len(record.Uploads)+1)
+			for _, u := range prunePartialUploads(
+				config_obj, client_id, record.Uploads) {
+				if !isSameUpload(u, flow_id, accessor, components) {
+					uploads = append(uploads, u)
+				}
+			}
+
+			uploads = append(uploads, &crypto_proto.ResumableUpload{
+				FlowId:     flow_id,
+				Accessor:   accessor,
+				Path:       path,
+				Components: components,
+				Timestamp:  utils.GetTime().Now().Unix(),
+			})
+
+			// Drop the oldest records.
+			if len(uploads) > MAX_PARTIAL_UPLOADS {
+				uploads = uploads[len(uploads)-MAX_PARTIAL_UPLOADS:]
+			}
+			record.Uploads = uploads
+			return true
+		})
+}
+
+// The upload completed - it does not need to be resumed any more.
+func CompletePartialUpload(
+	config_obj *config_proto.Config,
+	client_id, flow_id, accessor string, components []string) error {
+
+	return modifyPartialUploads(config_obj, client_id,
+		func(record *crypto_proto.ResumableUploads) bool {
+			uploads := make([]*crypto_proto.ResumableUpload, 0,
+				len(record.Uploads))
+			for _, u := range prunePartialUploads(
+				config_obj, client_id, record.Uploads) {
+				if !isSameUpload(u, flow_id, accessor, components) {
+					uploads = append(uploads, u)
+				}
+			}
+
+			if len(uploads) == len(record.Uploads) {
+				return false
+			}
+			record.Uploads = uploads
+			return true
+		})
+}
+
+// Returns how many bytes the server holds for the partial upload, or
+// false if it can not be resumed any more because it expired or its
+// data is gone.
+func getPartialUploadSize(
+	file_store_factory api.FileStore, now time.Time,
+	client_id string, upload *crypto_proto.ResumableUpload) (int64, bool) {
+	if now.Sub(time.Unix(upload.Timestamp, 0)) > PARTIAL_UPLOAD_EXPIRY {
+		return 0, false
+	}
+
+	flow_path_manager := paths.NewFlowPathManager(client_id, upload.FlowId)
+	upload_path := flow_path_manager.GetUploadsFile(
+		upload.Accessor, upload.Path, upload.Components).Path()
+	stat, err := file_store_factory.StatFile(upload_path)
+	if err != nil {
+		return 0, false
+	}
+
+	return stat.Size(), true
+}
+
+// Returns how many bytes the server holds for a partial upload the
+// client wants to resume. Clients may only resume uploads which were
+// recorded for them.
+func GetPartialUploadSize(
+	config_obj *config_proto.Config,
+	client_id, flow_id, accessor string,
+	components []string) (int64, error) {
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return 0, err
+	}
+
+	record := &crypto_proto.ResumableUploads{}
+	err = db.GetSubject(config_obj,
+		paths.NewClientPathManager(client_id).PartialUploads(), record)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+
+	for _, u := range record.Uploads {
+		if !isSameUpload(u, flow_id, accessor, components) {
+			continue
+		}
+
+		size, ok := getPartialUploadSize(file_store.GetFileStore(config_obj),
+			utils.GetTime().Now(), client_id, u)
+		if !ok {
+			return 0, fmt.Errorf("Partial upload in %v has expired", flow_id)
+		}
+		return size, nil
+	}
+
+	return 0, fmt.Errorf("No partial upload recorded in %v", flow_id)
+}
+
+// Remove the partial uploads which can not be resumed any more. This
+// is done when the record is updated anyway so launching collections
+// only needs to read it.
+func prunePartialUploads(
+	config_obj *config_proto.Config, client_id string,
+	uploads []*crypto_proto.ResumableUpload) []*crypto_proto.ResumableUpload {
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	now := utils.GetTime().Now()
+
+	result := make([]*crypto_proto.ResumableUpload, 0, len(uploads))
+	for _, u := range uploads {
+		_, ok := getPartialUploadSize(file_store_factory, now, client_id, u)
+		if ok {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
+// Get the list of partial uploads we can offer the client to
+// resume. This only reads the record: Stale uploads are skipped here
+// and removed when the record is next updated.
+func GetResumableUploads(
+	ctx context.Context,
+	config_obj *config_proto.Config,
+	client_id string) ([]*crypto_proto.ResumableUpload, error) {
+
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &crypto_proto.ResumableUploads{}
+	err = db.GetSubject(config_obj,
+		paths.NewClientPathManager(client_id).PartialUploads(), record)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	now := utils.GetTime().Now()
+	var result []*crypto_proto.ResumableUpload
+
+	for _, u := range record.Uploads {
+		size, ok := getPartialUploadSize(file_store_factory, now, client_id, u)
+		if !ok || size == 0 {
+			continue
+		}
+
+		result = append(result, &crypto_proto.ResumableUpload{
+			FlowId:     u.FlowId,
+			Accessor:   u.Accessor,
+			Path:       u.Path,
+			Components: u.Components,
+			Timestamp:  u.Timestamp,
+			Offset:     uint64(size),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,37 @@
+package notebook
+
+import (
+	"os"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/paths"
+)
+
+// Remove all the query caches created by notebook_cache() in the
+// notebook so the next calculation runs the queries again.
+func clearQueryCache(
+	config_obj *config_proto.Config, notebook_id string) error {
+	db, err := datastore.GetDB(config_obj)
+	if err != nil {
+		return err
+	}
+
+	path_manager := paths.NewNotebookPathManager(notebook_id)
+	err = datastore.Walk(config_obj, db, path_manager.QueryCacheDSDirectory(),
+		datastore.WalkWithoutDirectories,
+		func(filename api.DSPathSpec) error {
+			return db.DeleteSubject(config_obj, filename)
+		})
+	if err != nil {
+		return err
+	}
+
+	file_store_factory := file_store.GetFileStore(config_obj)
+	return api.Walk(file_store_factory, path_manager.QueryCacheDirectory(),
+		func(filename api.FSPathSpec, info os.FileInfo) error {
+			return file_store_factory.Delete(filename)
+		})
+}
@@ -12,6 +12,7 @@ import (
 	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
 	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
 	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/services/notebook"
 	"www.velocidex.com/golang/velociraptor/utils"
@@ -166,6 +167,71 @@ func (self *NotebookManagerTestSuite) TestNotebookManagerAlert() {
 	})
 }
 
+func (self *NotebookManagerTestSuite) TestNotebookManagerInvalidateCache() {
+	notebook_manager, err := services.GetNotebookManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	var notebook *api_proto.NotebookMetadata
+	vtesting.WaitUntil(2*time.Second, self.T(), func() bool {
+		notebook, err = notebook_manager.NewNotebook(self.Ctx, "admin", &api_proto.NotebookMetadata{
+			Name:        "Test Notebook",
+			Description: "This is a test",
+		})
+		return err == nil
+	})
+
+	// Simulate a query cache left by notebook_cache()
+	db := test_utils.GetMemoryDataStore(self.T(), self.ConfigObj)
+	mem_file_store := test_utils.GetMemoryFileStore(self.T(), self.ConfigObj)
+
+	path_manager := paths.NewNotebookPathManager(notebook.NotebookId)
+	metadata_path := path_manager.QueryCacheMetadata("Test")
+	cache_path := path_manager.QueryCache("Test")
+
+	err = db.SetSubject(self.ConfigObj, metadata_path,
+		&api_proto.NotebookQueryCache{Name: "Test"})
+	assert.NoError(self.T(), err)
+
+	fd, err := mem_file_store.WriteFile(cache_path)
+	assert.NoError(self.T(), err)
+	_, err = fd.Write([]byte("{\"Foo\":1}\n"))
+	assert.NoError(self.T(), err)
+	fd.Close()
+
+	// A normal recalculation leaves the cache alone.
+	_, err = notebook_manager.UpdateNotebookCell(self.Ctx, notebook,
+		"admin", &api_proto.NotebookCellRequest{
+			NotebookId: notebook.NotebookId,
+			CellId:     notebook.CellMetadata[0].CellId,
+			Input:      "SELECT * FROM scope()",
+			Type:       "vql",
+		})
+	assert.NoError(self.T(), err)
+
+	cache := &api_proto.NotebookQueryCache{}
+	err = db.GetSubject(self.ConfigObj, metadata_path, cache)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "Test", cache.Name)
+
+	// Invalidating the cache removes it before calculating.
+	_, err = notebook_manager.UpdateNotebookCell(self.Ctx, notebook,
+		"admin", &api_proto.NotebookCellRequest{
+			NotebookId:      notebook.NotebookId,
+			CellId:          notebook.CellMetadata[0].CellId,
+			Input:           "SELECT * FROM scope()",
+			Type:            "vql",
+			InvalidateCache: true,
+		})
+	assert.NoError(self.T(), err)
+
+	cache = &api_proto.NotebookQueryCache{}
+	err = db.GetSubject(self.ConfigObj, metadata_path, cache)
+	assert.Equal(self.T(), "", cache.Name)
+
+	_, err = mem_file_store.StatFile(cache_path)
+	assert.Error(self.T(), err)
+}
+
 func TestNotebookManager(t *testing.T) {
 	suite.Run(t, &NotebookManagerTestSuite{})
 }
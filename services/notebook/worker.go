@@ -71,6 +71,13 @@ func (self *NotebookWorker) ProcessUpdateRequest(
 	notebook_path_manager := paths.NewNotebookPathManager(
 		notebook_metadata.NotebookId)
 
+	if in.InvalidateCache {
+		err := clearQueryCache(config_obj, notebook_metadata.NotebookId)
+		if err != nil {
+			logger.Error("NotebookWorker: Clearing query cache: %v", err)
+		}
+	}
+
 	// The query will run in a sub context of the main context to
 	// allow our notification to cancel it.  NOTE: The
 	// updateCellContents() function itself must run as the parent
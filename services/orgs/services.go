@@ -701,6 +701,13 @@ func (self *OrgManager) startOrgFromContext(org_ctx *OrgContext) (err error) {
 		}
 	}
 
+	if spec.FrontendServer {
+		err = file_store.StartCompressionConverter(ctx, wg, org_config)
+		if err != nil {
+			return err
+		}
+	}
+
 	return maybeFlushFilesOnClose(ctx, wg, org_config)
 }
 
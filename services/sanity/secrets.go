@@ -78,6 +78,25 @@ var built_in_definitions = []string{`{
      "skip_verify": "FALSE"
   },
   "verifier": "x=>x.addresses"
+}`, `{
+  "typeName":"LUKS Key",
+  "description": "A passphrase or key file used by the luks accessor to unlock encrypted volumes.",
+  "template": {
+     "passphrase": "",
+     "keyfile": "",
+     "keyfile_accessor": ""
+  },
+  "verifier": "x=>x.passphrase OR x.keyfile"
+}`, `{
+  "typeName":"BitLocker Key",
+  "description": "A recovery password, password or startup key (.BEK file) used by the bitlocker accessor to unlock encrypted volumes.",
+  "template": {
+     "recovery_password": "",
+     "password": "",
+     "bek": "",
+     "bek_accessor": ""
+  },
+  "verifier": "x=>x.recovery_password =~ '^([0-9]{6}-){7}[0-9]{6}$' OR x.password OR x.bek"
 }`,
 }
 
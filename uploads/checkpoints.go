@@ -0,0 +1,319 @@
+package uploads
+
+import (
+	"encoding/hex"
+	"hash"
+	"io"
+	"time"
+
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/responder"
+	"www.velocidex.com/golang/velociraptor/services/writeback"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+/*
+  Large uploads are checkpointed in the client's writeback file as
+  they progress. The checkpoint records the flow the data was sent in,
+  the offset and the sha256 of the data sent so far.
+
+  If the upload is interrupted (e.g. the client restarts or the
+  connection drops), the server remembers the partial upload. When a
+  new collection is scheduled the server offers the partial uploads
+  it holds. If a new collection uploads the same file again, the
+  client resumes from the last point both sides agree on:
+
+  - The client reads the file up to the resume offset to rebuild the
+    hashes, but does not send this data.
+  - The first packet sent carries the flow id of the partial
+    upload. The server checks it recorded that partial upload for the
+    client and copies the data it already has from that flow in the
+    background, ahead of the new data.
+*/
+
+var (
+	// Uploads at least this large are checkpointed every
+	// UPLOAD_CHECKPOINT_SIZE bytes.
+	UPLOAD_CHECKPOINT_SIZE = int64(100 * 1024 * 1024)
+
+	// Checkpoints older than this are not resumed.
+	UPLOAD_CHECKPOINT_EXPIRY = 7 * 24 * time.Hour
+
+	// Only keep this many upload checkpoints in the writeback.
+	MAX_UPLOAD_CHECKPOINTS = 10
+)
+
+type uploadCheckpointer struct {
+	config_obj *config_proto.Config
+	flow_id    string
+	accessor   string
+	path       string
+	components []string
+	size       uint64
+	mtime      int64
+
+	// The offset of the last checkpoint we wrote.
+	last_offset int64
+
+	// Set when resuming an earlier upload.
+	resume_flow_id string
+	resume_offset  int64
+
+	// The expected sha256 of the data before resume_offset. This may
+	// be empty if the server holds less data than the client
+	// checkpointed - then we only rely on the file size and mtime.
+	resume_sha256 string
+}
+
+// Returns nil if the upload should not be checkpointed.
+func newUploadCheckpointer(
+	config_obj *config_proto.Config,
+	responder responder.Responder,
+	accessor, path string, components []string,
+	expected_size int64, mtime time.Time) *uploadCheckpointer {
+
+	if config_obj == nil || config_obj.Client == nil ||
+		config_obj.Client.DisableCheckpoints ||
+		expected_size < UPLOAD_CHECKPOINT_SIZE {
+		return nil
+	}
+
+	flow_context := responder.FlowContext()
+	if flow_context == nil {
+		return nil
+	}
+
+	self := &uploadCheckpointer{
+		config_obj: config_obj,
+		flow_id:    flow_context.SessionId(),
+		accessor:   accessor,
+		path:       path,
+		components: components,
+		size:       uint64(expected_size),
+		mtime:      mtime.UnixNano(),
+	}
+
+	// Can we resume? We need both a checkpoint on our side and the
+	// server to still have the data.
+	checkpoint := self.findCheckpoint()
+	if checkpoint == nil {
+		return self
+	}
+
+	server_upload := flow_context.GetResumableUpload(
+		checkpoint.FlowId, accessor, components)
+	if server_upload == nil {
+		return self
+	}
+
+	self.resume_flow_id = checkpoint.FlowId
+	self.resume_offset = int64(checkpoint.Offset)
+	self.resume_sha256 = checkpoint.Sha256
+	if server_upload.Offset < checkpoint.Offset {
+		self.resume_offset = int64(server_upload.Offset)
+		self.resume_sha256 = ""
+	}
+
+	return self
+}
+
+func (self *uploadCheckpointer) isSameUpload(
+	cp *config_proto.UploadCheckPoint) bool {
+	return cp.Accessor == self.accessor &&
+		utils.StringSliceEq(cp.Components, self.components)
+}
+
+func (self *uploadCheckpointer) findCheckpoint() *config_proto.UploadCheckPoint {
+	writeback_service := writeback.GetWritebackService()
+	wb, err := writeback_service.GetWriteback(self.config_obj)
+	if err != nil {
+		return nil
+	}
+
+	now := utils.GetTime().Now()
+	for _, cp := range wb.UploadCheckpoints {
+		if self.isSameUpload(cp) &&
+			cp.Size == self.size && cp.Mtime == self.mtime &&
+			cp.FlowId != self.flow_id &&
+			now.Sub(time.Unix(cp.Timestamp, 0)) < UPLOAD_CHECKPOINT_EXPIRY {
+			return cp
+		}
+	}
+	return nil
+}
+
+// The offset to resume the upload from, or 0 to start from the
+// beginning.
+func (self *uploadCheckpointer) ResumeOffset() int64 {
+	if self == nil {
+		return 0
+	}
+	return self.resume_offset
+}
+
+// The flow holding the data before the resume offset.
+func (self *uploadCheckpointer) ResumeFlowId() string {
+	if self == nil || self.resume_offset == 0 {
+		return ""
+	}
+	return self.resume_flow_id
+}
+
+// Read the data before the resume offset into the hashes. If the
+// data does not match the checkpoint we start again from the
+// beginning of the file.
+func (self *uploadCheckpointer) SkipPrefix(
+	reader io.Reader, md5_sum, sha_sum hash.Hash) error {
+	if self.ResumeOffset() == 0 {
+		return nil
+	}
+
+	// We need to be able to rewind the file if it changed.
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		self.abandon()
+		return nil
+	}
+
+	n, err := io.CopyN(io.MultiWriter(md5_sum, sha_sum), reader,
+		self.resume_offset)
+	if self.checkPrefix(n, err, md5_sum, sha_sum) {
+		return nil
+	}
+
+	_, err = seeker.Seek(0, io.SeekStart)
+	return err
+}
+
+// Same as SkipPrefix but the resume offset refers to the data in the
+// non sparse ranges.
+func (self *uploadCheckpointer) SkipSparsePrefix(
+	reader RangeReader, ranges []Range, md5_sum, sha_sum hash.Hash) {
+	if self.ResumeOffset() == 0 {
+		return
+	}
+
+	hashes := io.MultiWriter(md5_sum, sha_sum)
+	total := int64(0)
+	var err error
+
+	for _, rng := range ranges {
+		if rng.IsSparse {
+			continue
+		}
+
+		to_read := self.resume_offset - total
+		if to_read <= 0 {
+			break
+		}
+		if to_read > rng.Length {
+			to_read = rng.Length
+		}
+
+		_, err = reader.Seek(rng.Offset, io.SeekStart)
+		if err != nil {
+			break
+		}
+
+		var n int64
+		n, err = io.CopyN(hashes, reader, to_read)
+		total += n
+		if err != nil {
+			break
+		}
+	}
+
+	self.checkPrefix(total, err, md5_sum, sha_sum)
+}
+
+// Check the prefix we read matches the checkpoint. If it does not,
+// the hashes are reset and the upload starts from scratch.
+func (self *uploadCheckpointer) checkPrefix(
+	n int64, err error, md5_sum, sha_sum hash.Hash) bool {
+	if err == nil && n == self.resume_offset &&
+		(self.resume_sha256 == "" ||
+			self.resume_sha256 == hex.EncodeToString(sha_sum.Sum(nil))) {
+		logger := logging.GetLogger(self.config_obj, &logging.ClientComponent)
+		logger.Info("Resuming upload of %v from flow %v at offset %v",
+			self.path, self.resume_flow_id, self.resume_offset)
+		self.last_offset = self.resume_offset
+		return true
+	}
+
+	self.abandon()
+	md5_sum.Reset()
+	sha_sum.Reset()
+	return false
+}
+
+func (self *uploadCheckpointer) abandon() {
+	self.resume_offset = 0
+	self.resume_flow_id = ""
+	self.resume_sha256 = ""
+}
+
+// Record the progress of the upload if enough data was sent since
+// the last checkpoint.
+func (self *uploadCheckpointer) Update(offset int64, sha_sum hash.Hash) {
+	if self == nil || offset-self.last_offset < UPLOAD_CHECKPOINT_SIZE {
+		return
+	}
+	self.last_offset = offset
+
+	checkpoint := &config_proto.UploadCheckPoint{
+		FlowId:     self.flow_id,
+		Accessor:   self.accessor,
+		Path:       self.path,
+		Components: self.components,
+		Offset:     uint64(offset),
+		Sha256:     hex.EncodeToString(sha_sum.Sum(nil)),
+		Size:       self.size,
+		Mtime:      self.mtime,
+		Timestamp:  utils.GetTime().Now().Unix(),
+	}
+
+	writeback_service := writeback.GetWritebackService()
+	err := writeback_service.MutateWriteback(self.config_obj,
+		func(wb *config_proto.Writeback) error {
+			checkpoints := []*config_proto.UploadCheckPoint{}
+			for _, cp := range wb.UploadCheckpoints {
+				if !self.isSameUpload(cp) {
+					checkpoints = append(checkpoints, cp)
+				}
+			}
+			checkpoints = append(checkpoints, checkpoint)
+			if len(checkpoints) > MAX_UPLOAD_CHECKPOINTS {
+				checkpoints = checkpoints[len(checkpoints)-MAX_UPLOAD_CHECKPOINTS:]
+			}
+			wb.UploadCheckpoints = checkpoints
+			return writeback.WritebackUpdateLevel2
+		})
+	if err != nil {
+		logger := logging.GetLogger(self.config_obj, &logging.ClientComponent)
+		logger.Error("Unable to checkpoint upload of %v: %v", self.path, err)
+	}
+}
+
+// The upload is complete - remove its checkpoint.
+func (self *uploadCheckpointer) Done() {
+	if self == nil {
+		return
+	}
+
+	writeback_service := writeback.GetWritebackService()
+	_ = writeback_service.MutateWriteback(self.config_obj,
+		func(wb *config_proto.Writeback) error {
+			checkpoints := []*config_proto.UploadCheckPoint{}
+			for _, cp := range wb.UploadCheckpoints {
+				if !self.isSameUpload(cp) {
+					checkpoints = append(checkpoints, cp)
+				}
+			}
+			if len(checkpoints) == len(wb.UploadCheckpoints) {
+				return writeback.WritebackNoUpdate
+			}
+			wb.UploadCheckpoints = checkpoints
+			return writeback.WritebackUpdateLevel2
+		})
+}
@@ -0,0 +1,208 @@
+package uploads
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/config"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
+	"www.velocidex.com/golang/velociraptor/responder"
+	"www.velocidex.com/golang/velociraptor/services/writeback"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+)
+
+var resumeTestData = []byte("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMN")
+
+// A reader which fails after some data simulating a network drop or
+// client restart.
+type interruptedReader struct {
+	io.Reader
+}
+
+func (self *interruptedReader) Read(buf []byte) (int, error) {
+	n, err := self.Reader.Read(buf)
+	if err == io.EOF {
+		return 0, errors.New("Interrupted")
+	}
+	return n, err
+}
+
+func getFileBuffers(t *testing.T,
+	resp *responder.TestResponderType) []*crypto_proto.VeloMessage {
+	resp.Close()
+
+	var result []*crypto_proto.VeloMessage
+	vtesting.WaitUntil(time.Second*5, t, func() bool {
+		result = nil
+		stats := false
+		for _, m := range resp.Drain.Messages() {
+			if m.FileBuffer != nil {
+				result = append(result, m)
+			}
+			if m.FlowStats != nil {
+				stats = true
+			}
+		}
+		return stats
+	})
+	return result
+}
+
+func getUploadCheckpoints(
+	t *testing.T, config_obj *config_proto.Config) []*config_proto.UploadCheckPoint {
+	wb, err := writeback.GetWritebackService().GetWriteback(config_obj)
+	assert.NoError(t, err)
+	return wb.UploadCheckpoints
+}
+
+func makeResumeConfig(t *testing.T) (*config_proto.Config, func()) {
+	tmpfile, err := ioutil.TempFile("", "")
+	assert.NoError(t, err)
+	tmpfile.Close()
+
+	config_obj := config.GetDefaultConfig()
+	config_obj.Client.WritebackLinux = tmpfile.Name()
+	config_obj.Client.WritebackWindows = tmpfile.Name()
+	config_obj.Client.WritebackDarwin = tmpfile.Name()
+	config_obj.Client.Level2WritebackSuffix = ".l2"
+
+	writeback.GetWritebackService().LoadWriteback(config_obj)
+
+	old_size := UPLOAD_CHECKPOINT_SIZE
+	UPLOAD_CHECKPOINT_SIZE = 10
+	BUFF_SIZE = 10
+
+	return config_obj, func() {
+		UPLOAD_CHECKPOINT_SIZE = old_size
+		os.Remove(tmpfile.Name())
+		os.Remove(tmpfile.Name() + ".l2")
+	}
+}
+
+func TestClientUploaderResume(t *testing.T) {
+	config_obj, closer := makeResumeConfig(t)
+	defer closer()
+
+	ctx := context.Background()
+	filename := accessors.MustNewLinuxOSPath("resume.bin")
+
+	// First upload is interrupted after 35 bytes.
+	resp := responder.TestResponderWithFlowId(config_obj, "F.Resume1")
+	uploader := &VelociraptorUploader{Responder: resp, ConfigObj: config_obj}
+
+	_, err := uploader.Upload(ctx, vql_subsystem.MakeScope(), filename,
+		"file", nil, int64(len(resumeTestData)),
+		nilTime, nilTime, nilTime, nilTime, 0,
+		&interruptedReader{bytes.NewReader(resumeTestData[:35])})
+	assert.Error(t, err)
+	getFileBuffers(t, resp)
+
+	// The writeback records the progress up to the last checkpoint.
+	checkpoints := getUploadCheckpoints(t, config_obj)
+	assert.Equal(t, 1, len(checkpoints))
+	assert.Equal(t, "F.Resume1", checkpoints[0].FlowId)
+	assert.Equal(t, uint64(30), checkpoints[0].Offset)
+
+	prefix_hash := sha256.Sum256(resumeTestData[:30])
+	assert.Equal(t, hex.EncodeToString(prefix_hash[:]), checkpoints[0].Sha256)
+
+	// The next collection is offered the partial upload by the
+	// server which received all 35 bytes.
+	resp = responder.TestResponderWithFlowRequest(config_obj, "F.Resume2",
+		&crypto_proto.FlowRequest{
+			ResumableUploads: []*crypto_proto.ResumableUpload{{
+				FlowId:     "F.Resume1",
+				Accessor:   "file",
+				Path:       filename.String(),
+				Components: filename.Components,
+				Offset:     35,
+			}},
+		})
+	uploader = &VelociraptorUploader{Responder: resp, ConfigObj: config_obj}
+
+	result, err := uploader.Upload(ctx, vql_subsystem.MakeScope(), filename,
+		"file", nil, int64(len(resumeTestData)),
+		nilTime, nilTime, nilTime, nilTime, 0,
+		bytes.NewReader(resumeTestData))
+	assert.NoError(t, err)
+
+	// The hash still covers the entire file.
+	full_hash := sha256.Sum256(resumeTestData)
+	assert.Equal(t, hex.EncodeToString(full_hash[:]), result.Sha256)
+	assert.Equal(t, uint64(len(resumeTestData)), result.Size)
+
+	// Only the data after the last checkpoint is sent.
+	buffers := getFileBuffers(t, resp)
+	assert.True(t, len(buffers) > 0)
+	assert.Equal(t, "F.Resume1", buffers[0].FileBuffer.ResumeFlowId)
+	assert.Equal(t, uint64(30), buffers[0].FileBuffer.Offset)
+	assert.Equal(t, "", buffers[1].FileBuffer.ResumeFlowId)
+
+	sent := []byte{}
+	for _, b := range buffers {
+		sent = append(sent, b.FileBuffer.Data...)
+	}
+	assert.Equal(t, resumeTestData[30:], sent)
+
+	// The completed upload is no longer checkpointed.
+	assert.Equal(t, 0, len(getUploadCheckpoints(t, config_obj)))
+}
+
+// If the file changed since the checkpoint the upload starts again.
+func TestClientUploaderResumeChangedFile(t *testing.T) {
+	config_obj, closer := makeResumeConfig(t)
+	defer closer()
+
+	ctx := context.Background()
+	filename := accessors.MustNewLinuxOSPath("changed.bin")
+
+	resp := responder.TestResponderWithFlowId(config_obj, "F.Changed1")
+	uploader := &VelociraptorUploader{Responder: resp, ConfigObj: config_obj}
+
+	_, err := uploader.Upload(ctx, vql_subsystem.MakeScope(), filename,
+		"file", nil, int64(len(resumeTestData)),
+		nilTime, nilTime, nilTime, nilTime, 0,
+		&interruptedReader{bytes.NewReader(resumeTestData[:25])})
+	assert.Error(t, err)
+	getFileBuffers(t, resp)
+
+	resp = responder.TestResponderWithFlowRequest(config_obj, "F.Changed2",
+		&crypto_proto.FlowRequest{
+			ResumableUploads: []*crypto_proto.ResumableUpload{{
+				FlowId:     "F.Changed1",
+				Accessor:   "file",
+				Components: filename.Components,
+				Offset:     25,
+			}},
+		})
+	uploader = &VelociraptorUploader{Responder: resp, ConfigObj: config_obj}
+
+	changed := bytes.ToUpper(resumeTestData)
+	_, err = uploader.Upload(ctx, vql_subsystem.MakeScope(), filename,
+		"file", nil, int64(len(changed)),
+		nilTime, nilTime, nilTime, nilTime, 0,
+		bytes.NewReader(changed))
+	assert.NoError(t, err)
+
+	buffers := getFileBuffers(t, resp)
+	assert.Equal(t, "", buffers[0].FileBuffer.ResumeFlowId)
+	assert.Equal(t, uint64(0), buffers[0].FileBuffer.Offset)
+
+	sent := []byte{}
+	for _, b := range buffers {
+		sent = append(sent, b.FileBuffer.Data...)
+	}
+	assert.Equal(t, changed, sent)
+}
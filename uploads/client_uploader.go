@@ -14,6 +14,7 @@ import (
 
 	"www.velocidex.com/golang/velociraptor/accessors"
 	actions_proto "www.velocidex.com/golang/velociraptor/actions/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
 	"www.velocidex.com/golang/velociraptor/constants"
 	crypto_proto "www.velocidex.com/golang/velociraptor/crypto/proto"
 	"www.velocidex.com/golang/velociraptor/responder"
@@ -29,6 +30,10 @@ var (
 type VelociraptorUploader struct {
 	Responder responder.Responder
 	Count     int
+
+	// Used to checkpoint large uploads in the writeback so they may
+	// be resumed. If not set, uploads are not checkpointed.
+	ConfigObj *config_proto.Config
 }
 
 func (self *VelociraptorUploader) Upload(
@@ -67,10 +72,14 @@ func (self *VelociraptorUploader) Upload(
 
 	upload_id := self.Responder.NextUploadId()
 
+	checkpointer := newUploadCheckpointer(self.ConfigObj, self.Responder,
+		accessor, store_as_name.String(), store_as_name.Components,
+		expected_size, mtime)
+
 	// Try to collect sparse files if possible
 	result, err := self.maybeUploadSparse(
 		ctx, scope, filename, accessor, store_as_name,
-		expected_size, mtime, upload_id, reader)
+		expected_size, mtime, upload_id, reader, checkpointer)
 	if err == nil {
 		CacheUploadResult(scope, store_as_name, result)
 		return result, nil
@@ -85,12 +94,20 @@ func (self *VelociraptorUploader) Upload(
 		result.Path = filename.String()
 	}
 
-	offset := uint64(0)
 	self.Count += 1
 
 	md5_sum := md5.New()
 	sha_sum := sha256.New()
 
+	// If we resume an earlier upload, skip the data the server
+	// already has.
+	err = checkpointer.SkipPrefix(reader, md5_sum, sha_sum)
+	if err != nil {
+		return nil, err
+	}
+	offset := uint64(checkpointer.ResumeOffset())
+	resume_flow_id := checkpointer.ResumeFlowId()
+
 	for {
 		// Ensure there is a fresh allocation for every
 		// iteration to prevent overwriting in flight buffers.
@@ -130,6 +147,7 @@ func (self *VelociraptorUploader) Upload(
 			// The number of the upload within the flow.
 			UploadNumber: upload_id,
 			Eof:          read_bytes == 0,
+			ResumeFlowId: resume_flow_id,
 		}
 
 		select {
@@ -143,13 +161,18 @@ func (self *VelociraptorUploader) Upload(
 				FileBuffer: packet})
 		}
 
+		resume_flow_id = ""
 		offset += uint64(read_bytes)
 		if err != nil && err != io.EOF {
 			return nil, err
 		}
 
+		checkpointer.Update(int64(offset), sha_sum)
+
 		// On the last packet send back the hashes into the query.
 		if read_bytes == 0 {
+			checkpointer.Done()
+
 			result.Size = offset
 			result.StoredSize = offset
 			result.Sha256 = hex.EncodeToString(sha_sum.Sum(nil))
@@ -170,7 +193,8 @@ func (self *VelociraptorUploader) maybeUploadSparse(
 	ignored_expected_size int64,
 	mtime time.Time,
 	upload_id int64,
-	reader io.Reader) (
+	reader io.Reader,
+	checkpointer *uploadCheckpointer) (
 	*UploadResponse, error) {
 
 	// Can the reader produce ranges?
@@ -243,6 +267,13 @@ func (self *VelociraptorUploader) maybeUploadSparse(
 		}
 	}
 
+	// If we resume an earlier upload, skip the data the server
+	// already has. The offset is within the non sparse data.
+	checkpointer.SkipSparsePrefix(range_reader, ranges, md5_sum, sha_sum)
+	resume_offset := checkpointer.ResumeOffset()
+	resume_flow_id := checkpointer.ResumeFlowId()
+	write_offset = resume_offset
+
 	// No ranges - just send a placeholder.
 	if expected_size == 0 {
 		if !is_sparse {
@@ -274,6 +305,7 @@ func (self *VelociraptorUploader) maybeUploadSparse(
 	}
 
 	// Send each range separately
+	stored_offset := int64(0)
 	for _, rng := range ranges {
 		// Ignore sparse ranges
 		if rng.IsSparse {
@@ -283,6 +315,22 @@ func (self *VelociraptorUploader) maybeUploadSparse(
 		// Range is not sparse - send it one buffer at the time.
 		to_read := rng.Length
 		read_offset = rng.Offset
+
+		// Skip the part of the range the server already has.
+		if stored_offset < resume_offset {
+			skip := resume_offset - stored_offset
+			if skip > to_read {
+				skip = to_read
+			}
+			to_read -= skip
+			read_offset += skip
+		}
+		stored_offset += rng.Length
+
+		if to_read == 0 {
+			continue
+		}
+
 		_, err := range_reader.Seek(read_offset, io.SeekStart)
 		if err != nil {
 			return nil, err
@@ -335,6 +383,7 @@ func (self *VelociraptorUploader) maybeUploadSparse(
 				Data:         data,
 				DataLength:   uint64(len(data)),
 				UploadNumber: upload_id,
+				ResumeFlowId: resume_flow_id,
 			}
 
 			select {
@@ -348,9 +397,12 @@ func (self *VelociraptorUploader) maybeUploadSparse(
 					FileBuffer: packet})
 			}
 
+			resume_flow_id = ""
 			to_read -= int64(read_bytes)
 			write_offset += int64(read_bytes)
 			read_offset += int64(read_bytes)
+
+			checkpointer.Update(write_offset, sha_sum)
 		}
 	}
 
@@ -377,8 +429,10 @@ func (self *VelociraptorUploader) maybeUploadSparse(
 			Index:        index,
 			Eof:          true,
 			UploadNumber: upload_id,
+			ResumeFlowId: resume_flow_id,
 		},
 	})
+	checkpointer.Done()
 
 	result.Size = uint64(real_size)
 	result.StoredSize = uint64(write_offset)
@@ -77,7 +77,7 @@ func TestClientUploaderSparse(t *testing.T) {
 	uploader.maybeUploadSparse(ctx, scope,
 		filename, "ntfs", nil, 1000, nilTime,
 		resp.NextUploadId(),
-		range_reader)
+		range_reader, nil)
 
 	responses := resp.Drain.WaitForMessage(t, 3)
 
@@ -121,7 +121,7 @@ func TestClientUploaderSparseWithEOF(t *testing.T) {
 	uploader.maybeUploadSparse(ctx, scope,
 		filename, "ntfs", nil, 1000, nilTime,
 		resp.NextUploadId(),
-		range_reader)
+		range_reader, nil)
 
 	var responses []*crypto_proto.VeloMessage
 	vtesting.WaitUntil(time.Second*5, t, func() bool {
@@ -255,7 +255,7 @@ func TestClientUploaderCompletelySparse(t *testing.T) {
 	uploader.maybeUploadSparse(ctx, scope,
 		filename, "ntfs", nil, 1000, nilTime,
 		resp.NextUploadId(),
-		range_reader)
+		range_reader, nil)
 
 	responses := resp.Drain.WaitForMessage(t, 1)
 
@@ -298,7 +298,7 @@ func TestClientUploaderSparseMultiBuffer(t *testing.T) {
 	upload_resp, err := uploader.maybeUploadSparse(ctx, scope,
 		filename, "ntfs", nil, 1000, nilTime,
 		resp.NextUploadId(),
-		range_reader)
+		range_reader, nil)
 	assert.NoError(t, err)
 
 	resp.Close()
@@ -440,7 +440,7 @@ func TestClientUploaderNoIndexIfNotSparse(t *testing.T) {
 	uploader.maybeUploadSparse(ctx, scope,
 		filename, "ntfs", nil, 1000, nilTime,
 		resp.NextUploadId(),
-		range_reader)
+		range_reader, nil)
 
 	responses := resp.Drain.WaitForMessage(t, 7)
 	assert.Equal(t, CombineOutput("/foo", responses), "Hello hello ")
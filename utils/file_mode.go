@@ -0,0 +1,33 @@
+package utils
+
+import "os"
+
+// Convert a unix st_mode as stored by filesystems and package
+// databases into a Go file mode.
+func UnixFileMode(mode uint32) os.FileMode {
+	result := os.FileMode(mode & 0777)
+	switch mode & 0170000 {
+	case 0040000:
+		result |= os.ModeDir
+	case 0120000:
+		result |= os.ModeSymlink
+	case 0020000:
+		result |= os.ModeDevice | os.ModeCharDevice
+	case 0060000:
+		result |= os.ModeDevice
+	case 0010000:
+		result |= os.ModeNamedPipe
+	case 0140000:
+		result |= os.ModeSocket
+	}
+	if mode&04000 != 0 {
+		result |= os.ModeSetuid
+	}
+	if mode&02000 != 0 {
+		result |= os.ModeSetgid
+	}
+	if mode&01000 != 0 {
+		result |= os.ModeSticky
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package utils
+
+import "errors"
+
+var (
+	invalidLZ4Error = errors.New("Invalid LZ4 data")
+)
+
+// Decode a raw LZ4 block into dst. Returns the number of bytes
+// written.
+func DecodeLZ4Block(src, dst []byte) (int, error) {
+	si, di := 0, 0
+
+	for si < len(src) {
+		token := src[si]
+		si++
+
+		// Copy the literals
+		literal_length, err := readLZ4Length(src, &si, int(token>>4))
+		if err != nil {
+			return 0, err
+		}
+
+		if si+literal_length > len(src) || di+literal_length > len(dst) {
+			return 0, invalidLZ4Error
+		}
+		copy(dst[di:], src[si:si+literal_length])
+		si += literal_length
+		di += literal_length
+
+		// The last sequence only contains literals.
+		if si == len(src) {
+			break
+		}
+
+		if si+2 > len(src) {
+			return 0, invalidLZ4Error
+		}
+		offset := int(src[si]) | int(src[si+1])<<8
+		si += 2
+
+		if offset == 0 || offset > di {
+			return 0, invalidLZ4Error
+		}
+
+		match_length, err := readLZ4Length(src, &si, int(token&0x0f))
+		if err != nil {
+			return 0, err
+		}
+		match_length += 4
+
+		if di+match_length > len(dst) {
+			return 0, invalidLZ4Error
+		}
+
+		// The match may overlap the output so copy byte by byte.
+		start := di - offset
+		for i := 0; i < match_length; i++ {
+			dst[di] = dst[start+i]
+			di++
+		}
+	}
+
+	return di, nil
+}
+
+// Lengths of 15 are extended by the following bytes until a byte
+// which is not 255.
+func readLZ4Length(src []byte, si *int, length int) (int, error) {
+	if length != 15 {
+		return length, nil
+	}
+
+	for {
+		if *si >= len(src) {
+			return 0, invalidLZ4Error
+		}
+		b := src[*si]
+		*si++
+		length += int(b)
+		if b != 255 {
+			return length, nil
+		}
+	}
+}
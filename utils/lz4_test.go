@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestLZ4(t *testing.T) {
+	// "abc" followed by a match of 9 bytes at offset 3 and a literal.
+	block := []byte{0x35, 'a', 'b', 'c', 0x03, 0x00, 0x10, 'x'}
+	dst := make([]byte, 13)
+	n, err := DecodeLZ4Block(block, dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "abcabcabcabcx", string(dst[:n]))
+
+	// Offset before the start of the output.
+	_, err = DecodeLZ4Block([]byte{0x35, 'a', 'b', 'c', 0x09, 0x00}, dst)
+	assert.Error(t, err)
+
+	// Output too small.
+	_, err = DecodeLZ4Block(block, make([]byte, 5))
+	assert.Error(t, err)
+}
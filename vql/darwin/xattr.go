@@ -44,6 +44,23 @@ func (self XAttrFunction) Call(
 		return nil
 	}
 
+	// Accessors which parse the filesystem directly (e.g. raw_apfs)
+	// provide the attributes themselves.
+	xattrs, ok := getExtendedAttributes(scope, arg.Accessor, arg.Filename)
+	if ok {
+		if len(arg.Attributes) > 0 {
+			return self.getExtendedAttributeValues(arg.Attributes, xattrs)
+		}
+
+		attributes, err := xattrs.ListXAttr()
+		if err != nil {
+			scope.Log("xattr: Failed to list attributes for filename %s: %s",
+				arg.Filename.String(), err)
+			return vfilter.Null{}
+		}
+		return self.getExtendedAttributeValues(attributes, xattrs)
+	}
+
 	filename, err := accessors.GetUnderlyingAPIFilename(
 		arg.Accessor, scope, arg.Filename)
 	if err != nil {
@@ -79,6 +96,36 @@ func (self *XAttrFunction) getAttributeValues(
 	return ret
 }
 
+func (self *XAttrFunction) getExtendedAttributeValues(
+	Attributes []string, xattrs accessors.ExtendedAttributes) *ordereddict.Dict {
+	ret := ordereddict.NewDict()
+	for _, attr := range Attributes {
+		value, err := xattrs.GetXAttr(attr)
+		if err != nil {
+			continue
+		}
+		ret.Set(attr, string(value))
+	}
+	return ret
+}
+
+func getExtendedAttributes(scope vfilter.Scope,
+	accessor_name string, filename *accessors.OSPath) (
+	accessors.ExtendedAttributes, bool) {
+	accessor, err := accessors.GetAccessor(accessor_name, scope)
+	if err != nil {
+		return nil, false
+	}
+
+	stat, err := accessor.LstatWithOSPath(filename)
+	if err != nil {
+		return nil, false
+	}
+
+	xattrs, ok := stat.(accessors.ExtendedAttributes)
+	return xattrs, ok
+}
+
 func (self XAttrFunction) Info(
 	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.FunctionInfo {
 	return &vfilter.FunctionInfo{
@@ -13,12 +13,13 @@ import (
 )
 
 type PathSpecArgs struct {
-	DelegateAccessor string      `vfilter:"optional,field=DelegateAccessor,doc=An accessor to use."`
-	DelegatePath     string      `vfilter:"optional,field=DelegatePath,doc=A delegate to pass to the accessor."`
-	Path             vfilter.Any `vfilter:"optional,field=Path,doc=A path to open."`
-	Parse            string      `vfilter:"optional,field=parse,doc=Alternatively parse the pathspec from this string."`
-	Type             string      `vfilter:"optional,field=path_type,doc=Type of path this is (windows,linux,registry,ntfs)."`
-	Accessor         string      `vfilter:"optional,field=accessor,doc=The accessor to use to parse the path with"`
+	DelegateAccessor string            `vfilter:"optional,field=DelegateAccessor,doc=An accessor to use."`
+	DelegatePath     string            `vfilter:"optional,field=DelegatePath,doc=A delegate to pass to the accessor."`
+	Path             vfilter.Any       `vfilter:"optional,field=Path,doc=A path to open."`
+	Parse            string            `vfilter:"optional,field=parse,doc=Alternatively parse the pathspec from this string."`
+	Type             string            `vfilter:"optional,field=path_type,doc=Type of path this is (windows,linux,registry,ntfs)."`
+	Accessor         string            `vfilter:"optional,field=accessor,doc=The accessor to use to parse the path with"`
+	Options          *ordereddict.Dict `vfilter:"optional,field=options,doc=Accessor specific options (e.g. dict(replay_logs=TRUE) for raw_reg)."`
 }
 
 type PathSpecFunction struct{}
@@ -82,6 +83,7 @@ func (self PathSpecFunction) Call(ctx context.Context,
 				DelegateAccessor: arg.DelegateAccessor,
 				DelegatePath:     arg.DelegatePath,
 				Path:             path_str,
+				Options:          getOptions(arg.Options),
 			}
 
 			result := accessors.MustNewPathspecOSPath(p.String())
@@ -112,11 +114,25 @@ func (self PathSpecFunction) Call(ctx context.Context,
 			DelegateAccessor: arg.DelegateAccessor,
 			DelegatePath:     arg.DelegatePath,
 			Path:             path_str,
+			Options:          getOptions(arg.Options),
 		})
 
 	return result
 }
 
+func getOptions(options *ordereddict.Dict) map[string]string {
+	if options == nil || options.Len() == 0 {
+		return nil
+	}
+
+	result := make(map[string]string)
+	for _, k := range options.Keys() {
+		v, _ := options.Get(k)
+		result[k] = utils.ToString(v)
+	}
+	return result
+}
+
 func parseOSPath(path *accessors.OSPath) *ordereddict.Dict {
 	pathspec := path.PathSpec()
 	return ordereddict.NewDict().
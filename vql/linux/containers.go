@@ -0,0 +1,78 @@
+//go:build linux
+// +build linux
+
+package linux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/psutils"
+	"www.velocidex.com/golang/vfilter"
+)
+
+func makeContainerDict(c *psutils.Container) *ordereddict.Dict {
+	result := ordereddict.NewDict().
+		Set("Id", c.Id).
+		Set("Name", c.Name).
+		Set("Runtime", c.Runtime).
+		Set("Image", c.Image).
+		Set("PodName", c.PodName).
+		Set("PodNamespace", c.PodNamespace).
+		Set("PodUid", c.PodUid).
+		Set("Running", c.Pid != 0).
+		Set("Pid", c.Pid).
+		Set("Pids", c.Pids)
+
+	// The container's filesystem as seen by its init process.
+	if c.Pid != 0 {
+		result.Set("Root", fmt.Sprintf("/proc/%d/root", c.Pid)).
+			Set("Namespaces", psutils.GetNamespaces(c.Pid))
+	} else {
+		result.Set("Root", "").
+			Set("Namespaces", ordereddict.NewDict())
+	}
+
+	return result.
+		Set("Cgroup", c.Cgroup).
+		Set("Created", c.Created).
+		Set("Labels", c.Labels).
+		Set("StateFile", c.StateFile)
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(
+		&vfilter.GenericListPlugin{
+			PluginName: "containers",
+			Metadata:   vql.VQLMetadata().Permissions(acls.MACHINE_STATE).Build(),
+			Function: func(
+				ctx context.Context,
+				scope vfilter.Scope,
+				args *ordereddict.Dict) []vfilter.Row {
+				var result []vfilter.Row
+
+				err := vql_subsystem.CheckAccess(scope, acls.MACHINE_STATE)
+				if err != nil {
+					scope.Log("containers: %s", err)
+					return result
+				}
+
+				containers, err := psutils.ListContainers(ctx)
+				if err != nil {
+					scope.Log("containers: %v", err)
+					return result
+				}
+
+				for _, c := range containers {
+					result = append(result, makeContainerDict(c))
+				}
+				return result
+			},
+			Doc: "List containers found in the cgroup hierarchy and the " +
+				"state directories of Docker, containerd, CRI-O and Podman.",
+		})
+}
@@ -0,0 +1,228 @@
+//go:build linux
+// +build linux
+
+package linux
+
+import (
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	FANOTIFY_METADATA_SIZE = 24
+
+	// Directory entry events need the directory file handle and the
+	// name of the entry reported (Linux 5.9+).
+	FANOTIFY_INIT_FLAGS = unix.FAN_CLASS_NOTIF | unix.FAN_CLOEXEC |
+		unix.FAN_NONBLOCK | unix.FAN_REPORT_DFID_NAME
+
+	FANOTIFY_MARK_MASK = unix.FAN_CREATE | unix.FAN_DELETE |
+		unix.FAN_MOVED_FROM | unix.FAN_MOVED_TO | unix.FAN_MODIFY |
+		unix.FAN_ATTRIB | unix.FAN_CLOSE_WRITE |
+		unix.FAN_EVENT_ON_CHILD | unix.FAN_ONDIR
+
+	// fsid followed by the file_handle header.
+	FANOTIFY_FID_HEADER_SIZE = 4 + 8 + 8
+)
+
+var fanotifyActions = []struct {
+	mask   uint64
+	action string
+}{
+	{unix.FAN_CREATE, WATCH_ACTION_CREATE},
+	{unix.FAN_MODIFY, WATCH_ACTION_MODIFY},
+	{unix.FAN_CLOSE_WRITE, WATCH_ACTION_CLOSE_WRITE},
+	{unix.FAN_ATTRIB, WATCH_ACTION_ATTRIB},
+	{unix.FAN_MOVED_FROM, WATCH_ACTION_MOVED_FROM},
+	{unix.FAN_MOVED_TO, WATCH_ACTION_MOVED_TO},
+	{unix.FAN_DELETE, WATCH_ACTION_DELETE},
+}
+
+// The fanotify backend reports the pid responsible for each
+// event. Events identify the directory by its file handle so we keep
+// track of the handles of the directories we mark.
+type fanotifyBackend struct {
+	fd int
+
+	paths   map[string]string
+	handles map[string]string
+
+	buf []byte
+}
+
+func handleKey(handle_type int32, handle []byte) string {
+	return string(binary.LittleEndian.AppendUint32(nil, uint32(handle_type))) +
+		string(handle)
+}
+
+func (self *fanotifyBackend) Name() string {
+	return "fanotify"
+}
+
+func (self *fanotifyBackend) Add(path string) error {
+	handle, _, err := unix.NameToHandleAt(unix.AT_FDCWD, path, 0)
+	if err != nil {
+		return err
+	}
+
+	err = unix.FanotifyMark(self.fd,
+		unix.FAN_MARK_ADD|unix.FAN_MARK_ONLYDIR|unix.FAN_MARK_DONT_FOLLOW,
+		FANOTIFY_MARK_MASK, unix.AT_FDCWD, path)
+	if err != nil {
+		return err
+	}
+
+	key := handleKey(handle.Type(), handle.Bytes())
+	self.paths[key] = path
+	self.handles[path] = key
+	return nil
+}
+
+// Remove the marks on the directory and all its subdirectories.
+func (self *fanotifyBackend) Remove(path string) int {
+	removed := 0
+	prefix := path + "/"
+	for watched, key := range self.handles {
+		if watched == path || strings.HasPrefix(watched, prefix) {
+			// Marks on deleted directories are already gone.
+			unix.FanotifyMark(self.fd,
+				unix.FAN_MARK_REMOVE|unix.FAN_MARK_ONLYDIR|unix.FAN_MARK_DONT_FOLLOW,
+				FANOTIFY_MARK_MASK, unix.AT_FDCWD, watched)
+			delete(self.handles, watched)
+			removed++
+			delete(self.paths, key)
+		}
+	}
+	return removed
+}
+
+func (self *fanotifyBackend) Read() ([]*watchEvent, error) {
+	ready, err := pollReadable(self.fd)
+	if err != nil || !ready {
+		return nil, err
+	}
+
+	n, err := unix.Read(self.fd, self.buf)
+	if err != nil {
+		if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return self.parse(self.buf[:n]), nil
+}
+
+func (self *fanotifyBackend) parse(data []byte) []*watchEvent {
+	result := []*watchEvent{}
+	for len(data) >= FANOTIFY_METADATA_SIZE {
+		event_len := int(binary.LittleEndian.Uint32(data))
+		metadata_len := int(binary.LittleEndian.Uint16(data[6:]))
+		if event_len < FANOTIFY_METADATA_SIZE || event_len > len(data) ||
+			metadata_len > event_len {
+			break
+		}
+
+		mask := binary.LittleEndian.Uint64(data[8:])
+		fd := int32(binary.LittleEndian.Uint32(data[16:]))
+		pid := int32(binary.LittleEndian.Uint32(data[20:]))
+		info := data[metadata_len:event_len]
+		data = data[event_len:]
+
+		if fd >= 0 {
+			unix.Close(int(fd))
+		}
+
+		if mask&unix.FAN_Q_OVERFLOW != 0 {
+			result = append(result, &watchEvent{Action: WATCH_ACTION_OVERFLOW})
+			continue
+		}
+
+		path, ok := self.resolvePath(info)
+		if !ok {
+			continue
+		}
+
+		for _, a := range fanotifyActions {
+			if mask&a.mask != 0 {
+				result = append(result, &watchEvent{
+					Path:   path,
+					Action: a.action,
+					IsDir:  mask&unix.FAN_ONDIR != 0,
+					Pid:    pid,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// Find the directory handle and entry name in the info records.
+func (self *fanotifyBackend) resolvePath(info []byte) (string, bool) {
+	for len(info) >= 4 {
+		info_type := info[0]
+		length := int(binary.LittleEndian.Uint16(info[2:]))
+		if length < 4 || length > len(info) {
+			return "", false
+		}
+		record := info[:length]
+		info = info[length:]
+
+		if info_type != unix.FAN_EVENT_INFO_TYPE_DFID_NAME ||
+			len(record) < FANOTIFY_FID_HEADER_SIZE {
+			continue
+		}
+
+		handle_bytes := int(binary.LittleEndian.Uint32(record[12:]))
+		handle_type := int32(binary.LittleEndian.Uint32(record[16:]))
+		end := FANOTIFY_FID_HEADER_SIZE + handle_bytes
+		if end > len(record) {
+			return "", false
+		}
+
+		dir, pres := self.paths[handleKey(handle_type,
+			record[FANOTIFY_FID_HEADER_SIZE:end])]
+		if !pres {
+			return "", false
+		}
+
+		name := record[end:]
+		nul := strings.IndexByte(string(name), 0)
+		if nul >= 0 {
+			name = name[:nul]
+		}
+
+		// Events about the marked directory itself are also
+		// reported by the parent directory.
+		if len(name) == 0 || string(name) == "." {
+			return "", false
+		}
+
+		return filepath.Join(dir, string(name)), true
+	}
+
+	return "", false
+}
+
+func (self *fanotifyBackend) Close() {
+	unix.Close(self.fd)
+}
+
+func newFanotifyBackend() (*fanotifyBackend, error) {
+	fd, err := unix.FanotifyInit(FANOTIFY_INIT_FLAGS,
+		unix.O_RDONLY|unix.O_LARGEFILE|unix.O_CLOEXEC)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fanotifyBackend{
+		fd:      fd,
+		paths:   make(map[string]string),
+		handles: make(map[string]string),
+		buf:     make([]byte, 64*1024),
+	}, nil
+}
@@ -0,0 +1,176 @@
+//go:build linux
+// +build linux
+
+package linux
+
+import (
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	INOTIFY_EVENT_SIZE = 16
+
+	INOTIFY_WATCH_MASK = unix.IN_CREATE | unix.IN_MODIFY | unix.IN_CLOSE_WRITE |
+		unix.IN_DELETE | unix.IN_MOVED_FROM | unix.IN_MOVED_TO |
+		unix.IN_ATTRIB | unix.IN_ONLYDIR | unix.IN_DONT_FOLLOW |
+		unix.IN_EXCL_UNLINK
+)
+
+var inotifyActions = []struct {
+	mask   uint32
+	action string
+}{
+	{unix.IN_CREATE, WATCH_ACTION_CREATE},
+	{unix.IN_MODIFY, WATCH_ACTION_MODIFY},
+	{unix.IN_CLOSE_WRITE, WATCH_ACTION_CLOSE_WRITE},
+	{unix.IN_ATTRIB, WATCH_ACTION_ATTRIB},
+	{unix.IN_MOVED_FROM, WATCH_ACTION_MOVED_FROM},
+	{unix.IN_MOVED_TO, WATCH_ACTION_MOVED_TO},
+	{unix.IN_DELETE, WATCH_ACTION_DELETE},
+}
+
+type inotifyBackend struct {
+	fd int
+
+	// Map watch descriptors to directories and back.
+	paths map[int32]string
+	wds   map[string]int32
+
+	buf []byte
+}
+
+func (self *inotifyBackend) Name() string {
+	return "inotify"
+}
+
+func (self *inotifyBackend) Add(path string) error {
+	wd, err := unix.InotifyAddWatch(self.fd, path, INOTIFY_WATCH_MASK)
+	if err != nil {
+		return err
+	}
+
+	self.paths[int32(wd)] = path
+	self.wds[path] = int32(wd)
+	return nil
+}
+
+// Remove the watches on the directory and all its subdirectories.
+func (self *inotifyBackend) Remove(path string) int {
+	removed := 0
+	prefix := path + "/"
+	for watched, wd := range self.wds {
+		if watched == path || strings.HasPrefix(watched, prefix) {
+			unix.InotifyRmWatch(self.fd, uint32(wd))
+			delete(self.wds, watched)
+			removed++
+			delete(self.paths, wd)
+		}
+	}
+	return removed
+}
+
+func (self *inotifyBackend) Read() ([]*watchEvent, error) {
+	ready, err := pollReadable(self.fd)
+	if err != nil || !ready {
+		return nil, err
+	}
+
+	n, err := unix.Read(self.fd, self.buf)
+	if err != nil {
+		if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return self.parse(self.buf[:n]), nil
+}
+
+func (self *inotifyBackend) parse(data []byte) []*watchEvent {
+	result := []*watchEvent{}
+	for len(data) >= INOTIFY_EVENT_SIZE {
+		wd := int32(binary.LittleEndian.Uint32(data))
+		mask := binary.LittleEndian.Uint32(data[4:])
+		cookie := binary.LittleEndian.Uint32(data[8:])
+		length := int(binary.LittleEndian.Uint32(data[12:]))
+		if INOTIFY_EVENT_SIZE+length > len(data) {
+			break
+		}
+		name := strings.TrimRight(
+			string(data[INOTIFY_EVENT_SIZE:INOTIFY_EVENT_SIZE+length]), "\x00")
+		data = data[INOTIFY_EVENT_SIZE+length:]
+
+		if mask&unix.IN_Q_OVERFLOW != 0 {
+			result = append(result, &watchEvent{Action: WATCH_ACTION_OVERFLOW})
+			continue
+		}
+
+		// The directory is gone.
+		if mask&unix.IN_IGNORED != 0 {
+			path, pres := self.paths[wd]
+			if pres {
+				delete(self.paths, wd)
+				if self.wds[path] == wd {
+					delete(self.wds, path)
+				}
+			}
+			continue
+		}
+
+		// Events about the watched directory itself are also
+		// reported by the parent directory.
+		dir, pres := self.paths[wd]
+		if !pres || name == "" {
+			continue
+		}
+
+		for _, a := range inotifyActions {
+			if mask&a.mask != 0 {
+				result = append(result, &watchEvent{
+					Path:   filepath.Join(dir, name),
+					Action: a.action,
+					IsDir:  mask&unix.IN_ISDIR != 0,
+					Cookie: cookie,
+				})
+			}
+		}
+	}
+	return result
+}
+
+func (self *inotifyBackend) Close() {
+	unix.Close(self.fd)
+}
+
+func newInotifyBackend() (*inotifyBackend, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inotifyBackend{
+		fd:    fd,
+		paths: make(map[int32]string),
+		wds:   make(map[string]int32),
+		buf:   make([]byte, 64*1024),
+	}, nil
+}
+
+// Wait up to a second for the fd to become readable so callers can
+// check for cancellation.
+func pollReadable(fd int) (bool, error) {
+	fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+	n, err := unix.Poll(fds, 1000)
+	if err != nil {
+		if errors.Is(err, unix.EINTR) {
+			return false, nil
+		}
+		return false, err
+	}
+	return n > 0, nil
+}
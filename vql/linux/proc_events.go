@@ -0,0 +1,481 @@
+//go:build linux
+// +build linux
+
+package linux
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/shirou/gopsutil/v3/process"
+	"golang.org/x/sys/unix"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+// The kernel's process events connector (include/uapi/linux/cn_proc.h)
+const (
+	CN_IDX_PROC = 1
+	CN_VAL_PROC = 1
+
+	PROC_CN_MCAST_LISTEN = 1
+	PROC_CN_MCAST_IGNORE = 2
+
+	NLMSG_HDR_SIZE   = 16
+	CN_MSG_SIZE      = 20
+	PROC_EVENT_SIZE  = 16
+	PROC_COMM_LENGTH = 16
+
+	PROC_EVENT_NONE     = 0x00000000
+	PROC_EVENT_FORK     = 0x00000001
+	PROC_EVENT_EXEC     = 0x00000002
+	PROC_EVENT_UID      = 0x00000004
+	PROC_EVENT_GID      = 0x00000040
+	PROC_EVENT_SID      = 0x00000080
+	PROC_EVENT_PTRACE   = 0x00000100
+	PROC_EVENT_COMM     = 0x00000200
+	PROC_EVENT_COREDUMP = 0x40000000
+	PROC_EVENT_EXIT     = 0x80000000
+)
+
+var (
+	procEventNames = map[uint32]string{
+		PROC_EVENT_FORK:     "fork",
+		PROC_EVENT_EXEC:     "exec",
+		PROC_EVENT_UID:      "uid",
+		PROC_EVENT_GID:      "gid",
+		PROC_EVENT_SID:      "sid",
+		PROC_EVENT_PTRACE:   "ptrace",
+		PROC_EVENT_COMM:     "comm",
+		PROC_EVENT_COREDUMP: "coredump",
+		PROC_EVENT_EXIT:     "exit",
+	}
+
+	defaultProcEvents = []string{"fork", "exec", "exit"}
+)
+
+type procEvent struct {
+	Type string
+
+	// Nanoseconds since boot
+	Timestamp uint64
+
+	// The process the event is about. For fork events this is the
+	// child.
+	Pid  int32
+	Tgid int32
+
+	// The parent for fork, exit and coredump events, or the tracer
+	// for ptrace events.
+	ParentPid  int32
+	ParentTgid int32
+
+	// Real and effective ids for uid and gid events.
+	RealId      uint32
+	EffectiveId uint32
+
+	ExitCode   uint32
+	ExitSignal uint32
+
+	Comm string
+}
+
+// Parse a single proc_event following the connector message header.
+func parseProcEvent(data []byte) (*procEvent, error) {
+	if len(data) < PROC_EVENT_SIZE {
+		return nil, errors.New("proc_event too short")
+	}
+
+	what := binary.LittleEndian.Uint32(data)
+	result := &procEvent{
+		Type:      procEventNames[what],
+		Timestamp: binary.LittleEndian.Uint64(data[8:]),
+	}
+	data = data[PROC_EVENT_SIZE:]
+
+	// All the event payloads are a sequence of 32 bit fields.
+	field := func(i int) uint32 {
+		if len(data) < (i+1)*4 {
+			return 0
+		}
+		return binary.LittleEndian.Uint32(data[i*4:])
+	}
+
+	switch what {
+	case PROC_EVENT_NONE:
+		return nil, nil
+
+	case PROC_EVENT_FORK:
+		result.ParentPid = int32(field(0))
+		result.ParentTgid = int32(field(1))
+		result.Pid = int32(field(2))
+		result.Tgid = int32(field(3))
+		return result, nil
+
+	case PROC_EVENT_EXEC, PROC_EVENT_SID:
+		result.Pid = int32(field(0))
+		result.Tgid = int32(field(1))
+		return result, nil
+
+	case PROC_EVENT_UID, PROC_EVENT_GID:
+		result.Pid = int32(field(0))
+		result.Tgid = int32(field(1))
+		result.RealId = field(2)
+		result.EffectiveId = field(3)
+		return result, nil
+
+	case PROC_EVENT_PTRACE, PROC_EVENT_COREDUMP:
+		result.Pid = int32(field(0))
+		result.Tgid = int32(field(1))
+		result.ParentPid = int32(field(2))
+		result.ParentTgid = int32(field(3))
+		return result, nil
+
+	case PROC_EVENT_COMM:
+		result.Pid = int32(field(0))
+		result.Tgid = int32(field(1))
+		if len(data) >= 8+PROC_COMM_LENGTH {
+			result.Comm = strings.TrimRight(
+				string(data[8:8+PROC_COMM_LENGTH]), "\x00")
+		}
+		return result, nil
+
+	case PROC_EVENT_EXIT:
+		result.Pid = int32(field(0))
+		result.Tgid = int32(field(1))
+		result.ExitCode = field(2)
+		result.ExitSignal = field(3)
+		result.ParentPid = int32(field(4))
+		result.ParentTgid = int32(field(5))
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("Unknown proc_event %#x", what)
+}
+
+// Parse all the proc events in a netlink datagram.
+func parseNetlinkMessages(data []byte) ([]*procEvent, error) {
+	result := []*procEvent{}
+	for len(data) >= NLMSG_HDR_SIZE {
+		length := int(binary.LittleEndian.Uint32(data))
+		if length < NLMSG_HDR_SIZE || length > len(data) {
+			return result, errors.New("Invalid netlink message length")
+		}
+
+		msg := data[NLMSG_HDR_SIZE:length]
+		if len(msg) >= CN_MSG_SIZE &&
+			binary.LittleEndian.Uint32(msg) == CN_IDX_PROC &&
+			binary.LittleEndian.Uint32(msg[4:]) == CN_VAL_PROC {
+			event, err := parseProcEvent(msg[CN_MSG_SIZE:])
+			if err != nil {
+				return result, err
+			}
+			if event != nil {
+				result = append(result, event)
+			}
+		}
+
+		// Messages are 4 byte aligned.
+		aligned := (length + 3) &^ 3
+		if aligned >= len(data) {
+			break
+		}
+		data = data[aligned:]
+	}
+	return result, nil
+}
+
+// Build a connector message to subscribe or unsubscribe from the
+// process events multicast group.
+func buildProcConnectorMessage(op uint32) []byte {
+	length := NLMSG_HDR_SIZE + CN_MSG_SIZE + 4
+
+	// nlmsghdr
+	result := binary.LittleEndian.AppendUint32(nil, uint32(length))
+	result = binary.LittleEndian.AppendUint16(result, unix.NLMSG_DONE)
+	result = binary.LittleEndian.AppendUint16(result, 0)
+	result = binary.LittleEndian.AppendUint32(result, 0)
+	result = binary.LittleEndian.AppendUint32(result, uint32(os.Getpid()))
+
+	// cn_msg
+	result = binary.LittleEndian.AppendUint32(result, CN_IDX_PROC)
+	result = binary.LittleEndian.AppendUint32(result, CN_VAL_PROC)
+	result = binary.LittleEndian.AppendUint32(result, 0)
+	result = binary.LittleEndian.AppendUint32(result, 0)
+	result = binary.LittleEndian.AppendUint16(result, 4)
+	result = binary.LittleEndian.AppendUint16(result, 0)
+
+	return binary.LittleEndian.AppendUint32(result, op)
+}
+
+type procConnector struct {
+	fd int
+
+	// The wall clock time of boot, to convert event timestamps.
+	boot_time time.Time
+}
+
+func (self *procConnector) Close() {
+	unix.Sendto(self.fd, buildProcConnectorMessage(PROC_CN_MCAST_IGNORE),
+		0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK})
+	unix.Close(self.fd)
+}
+
+// Read the next batch of events. Returns no events on timeout so the
+// caller can check for cancellation.
+func (self *procConnector) Read(buf []byte) ([]*procEvent, error) {
+	n, _, err := unix.Recvfrom(self.fd, buf, 0)
+	if err != nil {
+		if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+			return nil, nil
+		}
+		// We were too slow and the kernel dropped some events.
+		if errors.Is(err, unix.ENOBUFS) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return parseNetlinkMessages(buf[:n])
+}
+
+func (self *procConnector) Time(timestamp uint64) time.Time {
+	return self.boot_time.Add(time.Duration(timestamp)).UTC()
+}
+
+func newProcConnector() (*procConnector, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK,
+		unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_CONNECTOR)
+	if err != nil {
+		return nil, err
+	}
+
+	err = unix.Bind(fd, &unix.SockaddrNetlink{
+		Family: unix.AF_NETLINK,
+		Groups: CN_IDX_PROC,
+	})
+	if err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	// Wake up periodically to check for cancellation.
+	err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO,
+		&unix.Timeval{Sec: 1})
+	if err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	err = unix.Sendto(fd, buildProcConnectorMessage(PROC_CN_MCAST_LISTEN),
+		0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK})
+	if err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	// Event timestamps are from the monotonic clock.
+	var now unix.Timespec
+	err = unix.ClockGettime(unix.CLOCK_MONOTONIC, &now)
+	if err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	return &procConnector{
+		fd:        fd,
+		boot_time: time.Now().Add(-time.Duration(now.Nano())),
+	}, nil
+}
+
+// Add details about the process from /proc. The process may have
+// already exited so this is best effort.
+func enrichProcEvent(ctx context.Context, row *ordereddict.Dict, pid int32) {
+	proc, err := process.NewProcessWithContext(ctx, pid)
+	if err != nil {
+		return
+	}
+
+	create_time, err := proc.CreateTimeWithContext(ctx)
+	if err == nil {
+		row.Set("StartTime", time.UnixMilli(create_time).UTC())
+	}
+
+	name, _ := proc.NameWithContext(ctx)
+	row.Set("Name", name)
+
+	exe, _ := proc.ExeWithContext(ctx)
+	row.Set("Exe", exe)
+
+	cmdline, _ := proc.CmdlineSliceWithContext(ctx)
+	row.Set("CommandLine", strings.Join(cmdline, " "))
+	row.Set("Argv", cmdline)
+
+	cwd, _ := proc.CwdWithContext(ctx)
+	row.Set("Cwd", cwd)
+
+	username, _ := proc.UsernameWithContext(ctx)
+	row.Set("Username", username)
+
+	uids, _ := proc.UidsWithContext(ctx)
+	if len(uids) > 1 {
+		row.Set("Uid", uids[0]).Set("EUid", uids[1])
+	}
+
+	gids, _ := proc.GidsWithContext(ctx)
+	if len(gids) > 1 {
+		row.Set("Gid", gids[0]).Set("EGid", gids[1])
+	}
+
+	cgroup, _ := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	row.Set("Cgroup", strings.TrimSpace(string(cgroup)))
+}
+
+func (self *procConnector) makeRow(
+	ctx context.Context, event *procEvent) *ordereddict.Dict {
+	row := ordereddict.NewDict().
+		Set("Time", self.Time(event.Timestamp)).
+		Set("Type", event.Type).
+		Set("Pid", event.Tgid)
+
+	switch event.Type {
+	case "fork":
+		row.Set("Ppid", event.ParentTgid)
+		if event.Pid != event.Tgid {
+			row.Set("Tid", event.Pid)
+		}
+		enrichProcEvent(ctx, row, event.Tgid)
+
+	case "exec":
+		proc, err := process.NewProcessWithContext(ctx, event.Tgid)
+		if err == nil {
+			ppid, _ := proc.PpidWithContext(ctx)
+			row.Set("Ppid", ppid)
+		}
+		enrichProcEvent(ctx, row, event.Tgid)
+
+	case "uid", "gid":
+		row.Set("RealId", event.RealId).
+			Set("EffectiveId", event.EffectiveId)
+
+	case "ptrace":
+		row.Set("TracerPid", event.ParentTgid)
+
+	case "comm":
+		row.Set("Name", event.Comm)
+
+	case "coredump":
+		row.Set("Ppid", event.ParentTgid)
+
+	case "exit":
+		row.Set("Ppid", event.ParentTgid).
+			Set("ExitCode", event.ExitCode>>8).
+			Set("ExitSignal", event.ExitCode&0x7f)
+		if event.Pid != event.Tgid {
+			row.Set("Tid", event.Pid)
+		}
+	}
+
+	return row
+}
+
+type WatchProcEventsArgs struct {
+	Events  []string `vfilter:"optional,field=events,doc=The event types to watch (default fork, exec, exit). Can include fork, exec, exit, uid, gid, sid, ptrace, comm and coredump."`
+	Threads bool     `vfilter:"optional,field=threads,doc=Also report threads starting and exiting."`
+}
+
+type WatchProcEventsPlugin struct{}
+
+func (self WatchProcEventsPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "watch_proc_events",
+		Doc:      "Watch process events using the kernel's netlink process connector.",
+		ArgType:  type_map.AddType(scope, &WatchProcEventsArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.MACHINE_STATE).Build(),
+	}
+}
+
+func (self WatchProcEventsPlugin) Call(
+	ctx context.Context, scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("watch_proc_events", args)()
+
+		err := vql_subsystem.CheckAccess(scope, acls.MACHINE_STATE)
+		if err != nil {
+			scope.Log("watch_proc_events: %s", err)
+			return
+		}
+
+		arg := &WatchProcEventsArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("watch_proc_events: %v", err)
+			return
+		}
+
+		if len(arg.Events) == 0 {
+			arg.Events = defaultProcEvents
+		}
+
+		connector, err := newProcConnector()
+		if err != nil {
+			scope.Log("watch_proc_events: %v (Requires root)", err)
+			return
+		}
+		defer connector.Close()
+
+		buf := make([]byte, os.Getpagesize())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			events, err := connector.Read(buf)
+			if err != nil {
+				scope.Log("watch_proc_events: %v", err)
+				return
+			}
+
+			for _, event := range events {
+				if !utils.InString(arg.Events, event.Type) {
+					continue
+				}
+
+				// Skip threads of the same process
+				if !arg.Threads && event.Pid != event.Tgid &&
+					(event.Type == "fork" || event.Type == "exit") {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case output_chan <- connector.makeRow(ctx, event):
+				}
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&WatchProcEventsPlugin{})
+}
@@ -0,0 +1,62 @@
+//go:build linux
+// +build linux
+
+package linux
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+func buildProcEventMessage(what uint32, fields ...uint32) []byte {
+	event := binary.LittleEndian.AppendUint32(nil, what)
+	event = binary.LittleEndian.AppendUint32(event, 0)
+	event = binary.LittleEndian.AppendUint64(event, 1000)
+	for _, f := range fields {
+		event = binary.LittleEndian.AppendUint32(event, f)
+	}
+
+	msg := buildProcConnectorMessage(0)[:NLMSG_HDR_SIZE+CN_MSG_SIZE]
+	binary.LittleEndian.PutUint32(msg, uint32(len(msg)+len(event)))
+	binary.LittleEndian.PutUint16(msg[NLMSG_HDR_SIZE+16:], uint16(len(event)))
+	return append(msg, event...)
+}
+
+func TestParseProcEvents(t *testing.T) {
+	data := buildProcEventMessage(PROC_EVENT_FORK, 10, 10, 11, 11)
+
+	// Messages are padded to 4 bytes.
+	comm := buildProcEventMessage(PROC_EVENT_COMM, 11, 11)
+	comm = append(comm, "bash\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00xx"...)
+	binary.LittleEndian.PutUint32(comm, uint32(len(comm)))
+	data = append(data, comm...)
+	data = append(data, 0, 0)
+
+	data = append(data, buildProcEventMessage(PROC_EVENT_EXIT, 11, 11, 1<<8, 17, 10, 10)...)
+
+	events, err := parseNetlinkMessages(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(events))
+
+	assert.Equal(t, &procEvent{
+		Type:       "fork",
+		Timestamp:  1000,
+		Pid:        11,
+		Tgid:       11,
+		ParentPid:  10,
+		ParentTgid: 10,
+	}, events[0])
+
+	assert.Equal(t, "bash", events[1].Comm)
+
+	assert.Equal(t, "exit", events[2].Type)
+	assert.Equal(t, uint32(1<<8), events[2].ExitCode)
+	assert.Equal(t, int32(10), events[2].ParentTgid)
+
+	// The acknowledgement of our subscription is ignored.
+	events, err = parseNetlinkMessages(buildProcEventMessage(PROC_EVENT_NONE, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(events))
+}
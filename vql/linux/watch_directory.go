@@ -0,0 +1,338 @@
+//go:build linux
+// +build linux
+
+package linux
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sys/unix"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+const (
+	WATCH_ACTION_CREATE      = "create"
+	WATCH_ACTION_MODIFY      = "modify"
+	WATCH_ACTION_CLOSE_WRITE = "close_write"
+	WATCH_ACTION_ATTRIB      = "attrib"
+	WATCH_ACTION_MOVED_FROM  = "moved_from"
+	WATCH_ACTION_MOVED_TO    = "moved_to"
+	WATCH_ACTION_DELETE      = "delete"
+
+	// The kernel queue overflowed and events were lost.
+	WATCH_ACTION_OVERFLOW = "overflow"
+
+	// Events waiting to be consumed by the query.
+	WATCH_QUEUE_SIZE = 1000
+)
+
+var (
+	metricWatchDirectoryWatches = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "watch_directory_watches",
+		Help: "Number of directories currently watched by watch_directory().",
+	})
+
+	metricWatchDirectoryOverflow = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "watch_directory_overflow",
+		Help: "Number of times the kernel event queue overflowed.",
+	})
+
+	metricWatchDirectoryDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "watch_directory_dropped_events",
+		Help: "Number of events dropped because the query could not keep up.",
+	})
+
+	metricWatchDirectoryLimit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "watch_directory_limit_reached",
+		Help: "Number of directories not watched due to the kernel watch limit.",
+	})
+)
+
+type watchEvent struct {
+	Path   string
+	Action string
+	IsDir  bool
+
+	// Only available with fanotify
+	Pid int32
+
+	// Links moved_from and moved_to events (inotify only)
+	Cookie uint32
+}
+
+type watchBackend interface {
+	Name() string
+	Add(path string) error
+
+	// Remove the directory and all its subdirectories. Returns the
+	// number of directories removed.
+	Remove(path string) int
+
+	// Blocks for up to a second waiting for events.
+	Read() ([]*watchEvent, error)
+	Close()
+}
+
+// Manages the watched directories, following new directories when
+// watching recursively.
+type directoryWatcher struct {
+	backend   watchBackend
+	recursive bool
+	scope     vfilter.Scope
+
+	watches       int
+	limit_reached bool
+}
+
+func (self *directoryWatcher) addDirectory(path string) {
+	if self.limit_reached {
+		metricWatchDirectoryLimit.Inc()
+		return
+	}
+
+	err := self.backend.Add(path)
+	if err != nil {
+		if errors.Is(err, unix.ENOSPC) {
+			self.limit_reached = true
+			metricWatchDirectoryLimit.Inc()
+			self.scope.Log("watch_directory: %v watch limit reached after %v "+
+				"directories: %v is not watched. Increase "+
+				"fs.inotify.max_user_watches or narrow the watched paths.",
+				self.backend.Name(), self.watches, path)
+			return
+		}
+		self.scope.Log("watch_directory: %v: %v", path, err)
+		return
+	}
+
+	self.watches++
+	metricWatchDirectoryWatches.Inc()
+}
+
+// Watch a directory tree. Returns the entries found in it so events
+// can be synthesized for files created before the watch was added.
+func (self *directoryWatcher) addTree(root string) []*watchEvent {
+	result := []*watchEvent{}
+
+	if !self.recursive {
+		self.addDirectory(root)
+		return result
+	}
+
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if d.IsDir() {
+			self.addDirectory(path)
+		}
+
+		if path != root {
+			result = append(result, &watchEvent{
+				Path:   path,
+				Action: WATCH_ACTION_CREATE,
+				IsDir:  d.IsDir(),
+			})
+		}
+		return nil
+	})
+	return result
+}
+
+func (self *directoryWatcher) removeTree(path string) {
+	removed := self.backend.Remove(path)
+	self.watches -= removed
+	metricWatchDirectoryWatches.Sub(float64(removed))
+}
+
+// Update the watches for directories that come and go.
+func (self *directoryWatcher) handle(event *watchEvent) []*watchEvent {
+	result := []*watchEvent{event}
+	if !self.recursive || !event.IsDir {
+		return result
+	}
+
+	switch event.Action {
+	case WATCH_ACTION_CREATE, WATCH_ACTION_MOVED_TO:
+		result = append(result, self.addTree(event.Path)...)
+
+	case WATCH_ACTION_DELETE, WATCH_ACTION_MOVED_FROM:
+		self.removeTree(event.Path)
+	}
+	return result
+}
+
+func (self *directoryWatcher) Close() {
+	metricWatchDirectoryWatches.Sub(float64(self.watches))
+	self.backend.Close()
+}
+
+type WatchDirectoryArgs struct {
+	Paths           []string `vfilter:"required,field=path,doc=The directories to watch."`
+	Recursive       bool     `vfilter:"optional,field=recursive,doc=Also watch all subdirectories."`
+	Events          []string `vfilter:"optional,field=events,doc=The events to report (default all). Can be create, modify, close_write, attrib, moved_from, moved_to and delete."`
+	DisableFanotify bool     `vfilter:"optional,field=disable_fanotify,doc=Only use inotify even if fanotify is available."`
+}
+
+type WatchDirectoryPlugin struct{}
+
+func (self WatchDirectoryPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "watch_directory",
+		Doc:      "Watch directories for changes using inotify or fanotify.",
+		ArgType:  type_map.AddType(scope, &WatchDirectoryArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func (self WatchDirectoryPlugin) Call(
+	ctx context.Context, scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("watch_directory", args)()
+
+		err := vql_subsystem.CheckAccess(scope, acls.FILESYSTEM_READ)
+		if err != nil {
+			scope.Log("watch_directory: %s", err)
+			return
+		}
+
+		arg := &WatchDirectoryArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("watch_directory: %v", err)
+			return
+		}
+
+		var backend watchBackend
+		if !arg.DisableFanotify {
+			backend, err = newFanotifyBackend()
+			if err != nil {
+				scope.Log("DEBUG:watch_directory: fanotify not available (%v), "+
+					"falling back to inotify", err)
+				backend = nil
+			}
+		}
+
+		if backend == nil {
+			backend, err = newInotifyBackend()
+			if err != nil {
+				scope.Log("watch_directory: %v", err)
+				return
+			}
+		}
+
+		watcher := &directoryWatcher{
+			backend:   backend,
+			recursive: arg.Recursive,
+			scope:     scope,
+		}
+
+		for _, path := range arg.Paths {
+			watcher.addTree(filepath.Clean(path))
+		}
+
+		scope.Log("watch_directory: Watching %v directories using %v",
+			watcher.watches, backend.Name())
+
+		queue := make(chan *watchEvent, WATCH_QUEUE_SIZE)
+		sub_ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// Read events as fast as possible so the kernel queue does
+		// not overflow. If the query can not keep up we drop events.
+		go func() {
+			defer close(queue)
+			defer watcher.Close()
+
+			dropped := 0
+			last_report := time.Now()
+
+			for {
+				select {
+				case <-sub_ctx.Done():
+					return
+				default:
+				}
+
+				events, err := backend.Read()
+				if err != nil {
+					scope.Log("watch_directory: %v", err)
+					return
+				}
+
+				for _, event := range events {
+					if event.Action == WATCH_ACTION_OVERFLOW {
+						metricWatchDirectoryOverflow.Inc()
+						scope.Log("watch_directory: %v event queue overflowed, "+
+							"events were lost", backend.Name())
+					}
+
+					for _, e := range watcher.handle(event) {
+						select {
+						case queue <- e:
+						default:
+							dropped++
+							metricWatchDirectoryDropped.Inc()
+						}
+					}
+				}
+
+				if dropped > 0 && time.Since(last_report) > 10*time.Second {
+					scope.Log("watch_directory: Dropped %v events because "+
+						"the query is too slow", dropped)
+					dropped = 0
+					last_report = time.Now()
+				}
+			}
+		}()
+
+		for event := range queue {
+			if len(arg.Events) > 0 && event.Action != WATCH_ACTION_OVERFLOW &&
+				!utils.InString(arg.Events, event.Action) {
+				continue
+			}
+
+			row := ordereddict.NewDict().
+				Set("Time", utils.GetTime().Now().UTC()).
+				Set("Action", event.Action).
+				Set("OSPath", event.Path).
+				Set("IsDir", event.IsDir)
+
+			if backend.Name() == "fanotify" {
+				row.Set("Pid", event.Pid)
+			} else {
+				row.Set("Cookie", event.Cookie)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&WatchDirectoryPlugin{})
+}
@@ -0,0 +1,77 @@
+//go:build linux
+// +build linux
+
+package linux
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/utils"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+func TestWatchDirectoryInotify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch_directory")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows := WatchDirectoryPlugin{}.Call(ctx, scope, ordereddict.NewDict().
+		Set("path", dir).
+		Set("recursive", true).
+		Set("events", []string{"create", "moved_from", "moved_to", "delete"}).
+		Set("disable_fanotify", true))
+
+	// Wait for the watches to be added.
+	time.Sleep(500 * time.Millisecond)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "f1"), nil, 0600))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0700))
+
+	// Files in new directories are also watched.
+	time.Sleep(200 * time.Millisecond)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "sub", "f2"), nil, 0600))
+	assert.NoError(t, os.Rename(filepath.Join(dir, "f1"),
+		filepath.Join(dir, "sub", "f3")))
+	assert.NoError(t, os.Remove(filepath.Join(dir, "sub", "f2")))
+
+	expected := []string{
+		"create f1",
+		"create sub",
+		"create sub/f2",
+		"moved_from f1",
+		"moved_to sub/f3",
+		"delete sub/f2",
+	}
+
+	seen := []string{}
+	for row := range rows {
+		action, _ := row.(*ordereddict.Dict).GetString("Action")
+		path, _ := row.(*ordereddict.Dict).GetString("OSPath")
+		event := action + " " + strings.TrimPrefix(path, dir+"/")
+		if !utils.InString(seen, event) {
+			seen = append(seen, event)
+		}
+
+		if len(seen) == len(expected) {
+			break
+		}
+	}
+
+	assert.Equal(t, expected, seen)
+}
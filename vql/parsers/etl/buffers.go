@@ -0,0 +1,178 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	ntfs "www.velocidex.com/golang/go-ntfs/parser"
+	prefetch "www.velocidex.com/golang/go-prefetch"
+)
+
+const (
+	// The size of the WMI_BUFFER_HEADER at the start of each buffer.
+	BUFFER_HEADER_SIZE = 0x48
+
+	// ETW buffers are at most 16mb but leave some room.
+	MAX_BUFFER_SIZE = 0x2000000
+
+	ETW_BUFFER_FLAG_FLUSH_MARKER    = 0x0001
+	ETW_BUFFER_FLAG_EVENTS_LOST     = 0x0002
+	ETW_BUFFER_FLAG_BUFFER_LOST     = 0x0004
+	ETW_BUFFER_FLAG_PROCESSOR_INDEX = 0x0020
+	ETW_BUFFER_FLAG_COMPRESSED      = 0x0040
+
+	// LZNT1 chunk headers carry a signature in bits 12-14.
+	LZNT1_SIGNATURE_MASK = 0x7000
+	LZNT1_SIGNATURE      = 0x3000
+)
+
+var (
+	bufferTypes = map[uint16]string{
+		0: "Generic",
+		1: "Rundown",
+		2: "CtxSwap",
+		3: "RefTime",
+		4: "Header",
+		5: "Batched",
+		6: "EmptyMarker",
+		7: "DbgInfo",
+	}
+
+	invalidBufferError = errors.New("Invalid buffer header")
+)
+
+// A buffer as written to the ETL file. Each buffer starts with a
+// WMI_BUFFER_HEADER followed by 8 byte aligned events.
+type Buffer struct {
+	Offset int64
+
+	// The on disk size of the buffer.
+	Size int
+
+	BufferSize    uint32
+	SavedOffset   uint32
+	CurrentOffset uint32
+	Timestamp     int64
+	Sequence      int64
+	Processor     uint16
+	LoggerId      uint16
+	Filled        uint32
+	Flags         uint16
+	Type          uint16
+
+	// The reference clock of the buffer (FILETIME and the raw clock
+	// at the same instant). May be zero.
+	ReferenceTime  int64
+	ReferenceClock int64
+
+	// The events in this buffer (following the header) after
+	// decompression.
+	Data buffer
+}
+
+func (self *Buffer) TypeName() string {
+	name, pres := bufferTypes[self.Type]
+	if !pres {
+		return fmt.Sprintf("%#x", self.Type)
+	}
+	return name
+}
+
+// Read the buffer at the offset. Returns io.EOF at the end of the
+// file.
+func ReadBuffer(reader io.ReaderAt, offset int64) (*Buffer, error) {
+	header := make(buffer, BUFFER_HEADER_SIZE)
+	n, err := reader.ReadAt(header, offset)
+	if n < BUFFER_HEADER_SIZE {
+		if err == nil || errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+
+	// Files are sometimes padded with zeros after the last buffer.
+	buffer_size := header.u32(0)
+	if buffer_size == 0 {
+		return nil, io.EOF
+	}
+
+	if buffer_size < BUFFER_HEADER_SIZE || buffer_size > MAX_BUFFER_SIZE {
+		return nil, fmt.Errorf("%w at %#x: size %#x",
+			invalidBufferError, offset, buffer_size)
+	}
+
+	self := &Buffer{
+		Offset:         offset,
+		Size:           int(buffer_size),
+		BufferSize:     buffer_size,
+		SavedOffset:    header.u32(0x04),
+		CurrentOffset:  header.u32(0x08),
+		Timestamp:      int64(header.u64(0x10)),
+		Sequence:       int64(header.u64(0x18)),
+		LoggerId:       header.u16(0x2a),
+		Filled:         header.u32(0x30),
+		Flags:          header.u16(0x34),
+		Type:           header.u16(0x36),
+		ReferenceTime:  int64(header.u64(0x38)),
+		ReferenceClock: int64(header.u64(0x40)),
+	}
+
+	if self.Flags&ETW_BUFFER_FLAG_PROCESSOR_INDEX != 0 {
+		self.Processor = header.u16(0x28)
+	} else {
+		self.Processor = uint16(header.u8(0x28))
+	}
+
+	// Compressed buffers only occupy SavedOffset bytes in the file.
+	compressed := self.Flags&ETW_BUFFER_FLAG_COMPRESSED != 0
+	if compressed && self.SavedOffset > BUFFER_HEADER_SIZE &&
+		self.SavedOffset < buffer_size {
+		self.Size = int(self.SavedOffset)
+	}
+
+	data := make([]byte, self.Size-BUFFER_HEADER_SIZE)
+	n, err = reader.ReadAt(data, offset+BUFFER_HEADER_SIZE)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	data = data[:n]
+
+	if compressed {
+		data, err = decompressBuffer(data,
+			int(buffer_size)-BUFFER_HEADER_SIZE)
+		if err != nil {
+			return nil, fmt.Errorf("Buffer at %#x: %w", offset, err)
+		}
+	}
+
+	// Only the filled part of the buffer contains events.
+	end := int(self.Filled) - BUFFER_HEADER_SIZE
+	if self.Filled == 0 {
+		end = int(self.SavedOffset) - BUFFER_HEADER_SIZE
+	}
+	if end > 0 && end < len(data) {
+		data = data[:end]
+	}
+	self.Data = data
+
+	return self, nil
+}
+
+// Buffers in compressed mode logs (Windows 8+) are compressed with
+// one of the RtlCompressBuffer formats. LZNT1 chunks are easily
+// recognised by their header signature, otherwise the buffer is
+// XPRESS Huffman compressed.
+func decompressBuffer(data []byte, size int) ([]byte, error) {
+	if len(data) >= 2 {
+		chunk_header := buffer(data).u16(0)
+		if chunk_header&LZNT1_SIGNATURE_MASK == LZNT1_SIGNATURE {
+			result, err := ntfs.LZNT1Decompress(data)
+			if err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return prefetch.LZXpressHuffmanDecompressWithFallback(data, size)
+}
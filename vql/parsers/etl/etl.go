@@ -0,0 +1,179 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+var (
+	stopIteration = errors.New("Stop")
+)
+
+type etlParser struct {
+	manifests *Manifests
+	header    *LogfileHeader
+	clock     *Clock
+
+	// The default pointer size of the traced system.
+	pointer_size int
+
+	// TraceLogging providers name themselves in the provider traits.
+	provider_names map[string]string
+
+	log func(format string, args ...interface{})
+}
+
+// Parse all the events in the ETL file. Events which can not be
+// decoded are still emitted with their raw payload.
+func ParseETL(ctx context.Context, reader io.ReaderAt,
+	manifests *Manifests,
+	log func(format string, args ...interface{}),
+	cb func(row *ordereddict.Dict) error) error {
+
+	self := &etlParser{
+		manifests:      manifests,
+		pointer_size:   8,
+		provider_names: make(map[string]string),
+		log:            log,
+	}
+
+	for offset := int64(0); ; {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		buf, err := ReadBuffer(reader, offset)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		offset += int64(buf.Size)
+
+		if self.clock != nil {
+			self.clock.SetReference(buf.ReferenceTime, buf.ReferenceClock)
+		}
+
+		err = self.parseBuffer(buf, cb)
+		if err == stopIteration {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (self *etlParser) parseBuffer(buf *Buffer,
+	cb func(row *ordereddict.Dict) error) error {
+	data := buf.Data
+	for offset := 0; offset < len(data); {
+		event, size, err := ParseEvent(data.from(offset), self.pointer_size)
+		if err != nil {
+			// The rest of the buffer can not be parsed without a
+			// valid event size.
+			self.log("parse_etl: Buffer at %#x, event at %#x: %v",
+				buf.Offset, offset+BUFFER_HEADER_SIZE, err)
+			return nil
+		}
+
+		if size == 0 {
+			return nil
+		}
+		offset += size
+
+		if event.IsLogfileHeader() && self.header == nil {
+			self.header = ParseLogfileHeader(event.UserData)
+			self.pointer_size = self.header.PointerSize
+			self.clock = NewClock(self.header, event.Timestamp)
+		}
+
+		err = cb(self.eventToRow(event, buf))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (self *etlParser) eventToRow(event *Event,
+	buf *Buffer) *ordereddict.Dict {
+	provider_name := self.manifests.ProviderName(event.ProviderId)
+	event_name := ""
+	message := ""
+	var event_data interface{}
+
+	// TraceLogging events describe themselves.
+	traits := event.ExtendedItem(EXT_TYPE_PROV_TRAITS)
+	if traits != nil {
+		self.provider_names[event.ProviderId] = parseProviderTraits(traits)
+	}
+	if provider_name == "" {
+		provider_name = self.provider_names[event.ProviderId]
+	}
+
+	if event.IsKernelEvent() {
+		provider_name = "Kernel"
+		event_name = event.GroupName()
+	}
+
+	decoded := false
+	schema := event.ExtendedItem(EXT_TYPE_EVENT_SCHEMA_TL)
+	if event.IsLogfileHeader() && self.header != nil {
+		event_data = self.header.ToDict()
+		decoded = true
+
+	} else if schema != nil {
+		tlg, err := parseTraceLoggingSchema(schema)
+		if err == nil {
+			event_name = tlg.Name
+			data, err := tlg.Decode(event.UserData, event.PointerSize)
+			if err == nil {
+				event_data = data
+				decoded = true
+			}
+		}
+
+	} else if manifest := self.manifests.Lookup(event); manifest != nil {
+		event_name = manifest.Symbol
+		data, values, err := manifest.Decode(event.UserData, event.PointerSize)
+		if err == nil {
+			event_data = data
+			message = formatMessage(manifest.Message, values)
+			decoded = true
+		}
+	}
+
+	// Fall back to the raw payload for unknown providers.
+	var user_data interface{}
+	if !decoded && len(event.UserData) > 0 {
+		user_data = fmt.Sprintf("%x", []byte(event.UserData))
+	}
+
+	return ordereddict.NewDict().
+		Set("Time", self.clock.Time(event.Timestamp)).
+		Set("Provider", event.ProviderId).
+		Set("ProviderName", provider_name).
+		Set("EventId", event.EventId).
+		Set("EventName", event_name).
+		Set("Version", event.Version).
+		Set("Level", event.Level).
+		Set("Opcode", event.Opcode).
+		Set("Task", event.Task).
+		Set("Keyword", fmt.Sprintf("%#x", event.Keyword)).
+		Set("ProcessId", event.ProcessId).
+		Set("ThreadId", event.ThreadId).
+		Set("Processor", buf.Processor).
+		Set("ActivityId", event.ActivityId).
+		Set("Message", message).
+		Set("EventData", event_data).
+		Set("UserData", user_data).
+		Set("HeaderType", event.HeaderTypeName())
+}
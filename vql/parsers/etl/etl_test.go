@@ -0,0 +1,385 @@
+package etl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"log"
+	"os"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/suite"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/data"
+)
+
+const (
+	testBufferSize = 0x1000
+
+	// The QPC value when the trace started and its frequency.
+	testStartClock = 5000000
+	testFrequency  = 10000000
+
+	testManifestProvider = "{3A1D9BB1-0000-4C2D-A1B2-000000000001}"
+	testTLGProvider      = "{3A1D9BB1-0000-4C2D-A1B2-000000000002}"
+	testUnknownProvider  = "{3A1D9BB1-0000-4C2D-A1B2-000000000003}"
+)
+
+var (
+	testStartTime = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<instrumentationManifest xmlns="http://schemas.microsoft.com/win/2004/08/events">
+ <instrumentation>
+  <events>
+   <provider name="Test-Provider" guid="{3a1d9bb1-0000-4c2d-a1b2-000000000001}">
+    <events>
+     <event value="7" version="1" symbol="FileOpened" template="T_Open"
+            message="$(string.Event.7)"/>
+    </events>
+    <templates>
+     <template tid="T_Open">
+      <data name="Path" inType="win:UnicodeString"/>
+      <data name="Count" inType="win:UInt16"/>
+      <data name="Ports" inType="win:UInt16" count="Count"/>
+      <struct name="Owner">
+       <data name="Sid" inType="win:SID"/>
+       <data name="Pid" inType="win:UInt32"/>
+      </struct>
+     </template>
+    </templates>
+   </provider>
+  </events>
+ </instrumentation>
+ <localization>
+  <resources culture="en-US">
+   <stringTable>
+    <string id="Event.7" value="Opened %1 (%2!u! ports)%n"/>
+   </stringTable>
+  </resources>
+ </localization>
+</instrumentationManifest>`
+)
+
+func utf16z(value string) []byte {
+	b := &bytes.Buffer{}
+	binary.Write(b, binary.LittleEndian, utf16.Encode([]rune(value)))
+	return append(b.Bytes(), 0, 0)
+}
+
+func guidBytes(guid string) []byte {
+	result := make([]byte, 16)
+	var d1 uint32
+	var d2, d3 uint16
+	var d4 [8]byte
+	n := 0
+	for i := 1; i < len(guid)-1; i++ {
+		c := guid[i]
+		if c == '-' {
+			continue
+		}
+		v := byte(0)
+		switch {
+		case c >= '0' && c <= '9':
+			v = c - '0'
+		default:
+			v = c - 'A' + 10
+		}
+		switch {
+		case n < 8:
+			d1 = d1<<4 | uint32(v)
+		case n < 12:
+			d2 = d2<<4 | uint16(v)
+		case n < 16:
+			d3 = d3<<4 | uint16(v)
+		default:
+			d4[(n-16)/2] = d4[(n-16)/2]<<4 | v
+		}
+		n++
+	}
+	binary.LittleEndian.PutUint32(result, d1)
+	binary.LittleEndian.PutUint16(result[4:], d2)
+	binary.LittleEndian.PutUint16(result[6:], d3)
+	copy(result[8:], d4[:])
+	return result
+}
+
+func toFiletime(t time.Time) uint64 {
+	return uint64(t.Unix()+11644473600)*10000000 + uint64(t.Nanosecond()/100)
+}
+
+func align(data []byte) []byte {
+	for len(data)%8 != 0 {
+		data = append(data, 0)
+	}
+	return data
+}
+
+func buildLogfileHeader() []byte {
+	header := make([]byte, 280)
+	binary.LittleEndian.PutUint32(header[0:], testBufferSize)
+	copy(header[4:], []byte{10, 0, 0, 1})
+	binary.LittleEndian.PutUint32(header[12:], 4)
+	binary.LittleEndian.PutUint32(header[44:], 8)
+	binary.LittleEndian.PutUint32(header[52:], 2400)
+	binary.LittleEndian.PutUint64(header[256:], testFrequency)
+	binary.LittleEndian.PutUint64(header[264:], toFiletime(testStartTime))
+	binary.LittleEndian.PutUint32(header[272:], EVENT_TRACE_CLOCK_PERFCOUNTER)
+	header = append(header, utf16z("Test Logger")...)
+	return append(header, utf16z(`C:\test.etl`)...)
+}
+
+func buildSystemEvent(hook_id uint16, payload []byte) []byte {
+	event := make([]byte, SYSTEM_HEADER_SIZE)
+	binary.LittleEndian.PutUint16(event[0:], 2)
+	event[2] = TRACE_HEADER_TYPE_SYSTEM64
+	event[3] = 0xc0
+	binary.LittleEndian.PutUint16(event[4:], uint16(SYSTEM_HEADER_SIZE+len(payload)))
+	binary.LittleEndian.PutUint16(event[6:], hook_id)
+	binary.LittleEndian.PutUint32(event[8:], 4)
+	binary.LittleEndian.PutUint32(event[12:], 8)
+	binary.LittleEndian.PutUint64(event[16:], testStartClock)
+	return align(append(event, payload...))
+}
+
+type extendedItem struct {
+	ext_type uint16
+	data     []byte
+}
+
+func buildEventHeader(provider string, id uint16, version uint8,
+	seconds int64, extended []extendedItem, payload []byte) []byte {
+	event := make([]byte, EVENT_HEADER_SIZE)
+	event[2] = TRACE_HEADER_TYPE_EVENT_HEADER64
+	event[3] = 0xc0
+	binary.LittleEndian.PutUint16(event[4:], EVENT_HEADER_FLAG_64_BIT)
+	binary.LittleEndian.PutUint32(event[8:], 1234)
+	binary.LittleEndian.PutUint32(event[12:], 5678)
+	binary.LittleEndian.PutUint64(event[16:],
+		uint64(testStartClock+seconds*testFrequency))
+	copy(event[24:], guidBytes(provider))
+	binary.LittleEndian.PutUint16(event[40:], id)
+	event[42] = version
+	event[44] = 4
+	binary.LittleEndian.PutUint64(event[48:], 0x8000000000000010)
+
+	if len(extended) > 0 {
+		binary.LittleEndian.PutUint16(event[4:],
+			EVENT_HEADER_FLAG_64_BIT|EVENT_HEADER_FLAG_EXT)
+	}
+
+	for i, item := range extended {
+		header := make([]byte, EXTENDED_ITEM_SIZE)
+		binary.LittleEndian.PutUint16(header[2:], item.ext_type)
+		if i < len(extended)-1 {
+			binary.LittleEndian.PutUint16(header[4:], 1)
+		}
+		binary.LittleEndian.PutUint16(header[6:], uint16(len(item.data)))
+		event = align(append(append(event, header...), item.data...))
+	}
+
+	event = append(event, payload...)
+	binary.LittleEndian.PutUint16(event[0:], uint16(len(event)))
+	return align(event)
+}
+
+func buildBuffer(events []byte, flags uint16, compress bool) []byte {
+	filled := BUFFER_HEADER_SIZE + len(events)
+	data := events
+
+	if compress {
+		// Uncompressed LZNT1 chunks followed by the end marker.
+		chunk := make([]byte, 2)
+		binary.LittleEndian.PutUint16(chunk, uint16(len(events)-1)|LZNT1_SIGNATURE)
+		data = append(append(chunk, events...), 0, 0)
+	}
+
+	header := make([]byte, BUFFER_HEADER_SIZE)
+	binary.LittleEndian.PutUint32(header[0x00:], testBufferSize)
+	binary.LittleEndian.PutUint32(header[0x04:], uint32(BUFFER_HEADER_SIZE+len(data)))
+	header[0x28] = 1
+	binary.LittleEndian.PutUint32(header[0x30:], uint32(filled))
+	binary.LittleEndian.PutUint16(header[0x34:], flags)
+
+	result := append(header, data...)
+	if compress {
+		return result
+	}
+
+	// Uncompressed buffers are padded to the buffer size.
+	padding := bytes.Repeat([]byte{0xff}, testBufferSize-len(result))
+	return append(result, padding...)
+}
+
+func buildTraceLoggingItems() []extendedItem {
+	traits := []byte{0, 0}
+	traits = append(traits, "Test.TraceLogging\x00"...)
+	binary.LittleEndian.PutUint16(traits, uint16(len(traits)))
+
+	schema := []byte{0, 0, 0}
+	schema = append(schema, "ProcessStarted\x00"...)
+	schema = append(schema, "Image\x00"...)
+	schema = append(schema, INTYPE_ANSISTRING)
+	schema = append(schema, "Ids\x00"...)
+	schema = append(schema, INTYPE_UINT32|TLG_IN_VCOUNT)
+	schema = append(schema, "Info\x00"...)
+	schema = append(schema, INTYPE_STRUCT|TLG_IN_CHAIN, 2)
+	schema = append(schema, "Flag\x00"...)
+	schema = append(schema, INTYPE_BOOLEAN)
+	schema = append(schema, "Guid\x00"...)
+	schema = append(schema, INTYPE_GUID)
+	binary.LittleEndian.PutUint16(schema, uint16(len(schema)))
+
+	return []extendedItem{
+		{EXT_TYPE_PROV_TRAITS, traits},
+		{EXT_TYPE_EVENT_SCHEMA_TL, schema},
+	}
+}
+
+func buildTestETL() []byte {
+	// The manifest event payload.
+	sid := []byte{1, 2, 0, 0, 0, 0, 0, 5, 32, 0, 0, 0, 0x20, 2, 0, 0}
+	manifest_payload := utf16z(`C:\Windows\notepad.exe`)
+	manifest_payload = append(manifest_payload, 2, 0, 80, 0, 0xbb, 1)
+	manifest_payload = append(manifest_payload, sid...)
+	manifest_payload = append(manifest_payload, 0x10, 0x27, 0, 0)
+
+	// The TraceLogging event payload.
+	tlg_payload := []byte("cmd.exe\x00")
+	tlg_payload = append(tlg_payload, 2, 0, 1, 0, 0, 0, 2, 0, 0, 0)
+	tlg_payload = append(tlg_payload, 1, 0, 0, 0)
+	tlg_payload = append(tlg_payload, guidBytes(testUnknownProvider)...)
+
+	events := buildSystemEvent(0, buildLogfileHeader())
+	events = append(events, buildEventHeader(
+		testManifestProvider, 7, 1, 1, nil, manifest_payload)...)
+	events = append(events, buildEventHeader(
+		testTLGProvider, 1, 0, 2, buildTraceLoggingItems(), tlg_payload)...)
+	events = append(events, buildEventHeader(
+		testUnknownProvider, 3, 0, 3, nil, []byte{0xde, 0xad, 0xbe, 0xef})...)
+
+	result := buildBuffer(events, 0, false)
+
+	// A compressed buffer follows.
+	compressed := buildEventHeader(
+		testUnknownProvider, 4, 0, 4, nil, []byte{0xca, 0xfe})
+	return append(result, buildBuffer(
+		compressed, ETW_BUFFER_FLAG_COMPRESSED, true)...)
+}
+
+type ETLTestSuite struct {
+	suite.Suite
+}
+
+func (self *ETLTestSuite) parseETL(data []byte, manifest string) []*ordereddict.Dict {
+	ctx := context.Background()
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	scope.SetLogger(log.New(os.Stderr, "", 0))
+	defer scope.Close()
+
+	args := ordereddict.NewDict().
+		Set("filename", string(data)).
+		Set("accessor", "data")
+	if manifest != "" {
+		args.Set("manifest", manifest)
+	}
+
+	result := []*ordereddict.Dict{}
+	for row := range (ETLPlugin{}).Call(ctx, scope, args) {
+		result = append(result, row.(*ordereddict.Dict))
+	}
+	return result
+}
+
+func get(dict *ordereddict.Dict, path ...string) interface{} {
+	var value interface{} = dict
+	for _, p := range path {
+		d, ok := value.(*ordereddict.Dict)
+		if !ok {
+			return nil
+		}
+		value, _ = d.Get(p)
+	}
+	return value
+}
+
+func (self *ETLTestSuite) TestParseETL() {
+	rows := self.parseETL(buildTestETL(), testManifest)
+	assert.Equal(self.T(), 5, len(rows))
+
+	// The logfile header.
+	header := rows[0]
+	assert.Equal(self.T(), "Kernel", get(header, "ProviderName"))
+	assert.Equal(self.T(), "EventTrace", get(header, "EventName"))
+	assert.Equal(self.T(), "Test Logger", get(header, "EventData", "LoggerName"))
+	assert.Equal(self.T(), `C:\test.etl`, get(header, "EventData", "LogFileName"))
+	assert.Equal(self.T(), "QPC", get(header, "EventData", "ClockType"))
+	assert.Equal(self.T(), testStartTime, get(header, "Time"))
+
+	// A manifest based event decoded with the supplied manifest.
+	event := rows[1]
+	assert.Equal(self.T(), testManifestProvider, get(event, "Provider"))
+	assert.Equal(self.T(), "Test-Provider", get(event, "ProviderName"))
+	assert.Equal(self.T(), "FileOpened", get(event, "EventName"))
+	assert.Equal(self.T(), uint16(7), get(event, "EventId"))
+	assert.Equal(self.T(), uint32(5678), get(event, "ProcessId"))
+	assert.Equal(self.T(), testStartTime.Add(time.Second), get(event, "Time"))
+	assert.Equal(self.T(), `C:\Windows\notepad.exe`,
+		get(event, "EventData", "Path"))
+	assert.Equal(self.T(), []interface{}{uint16(80), uint16(443)},
+		get(event, "EventData", "Ports"))
+	assert.Equal(self.T(), "S-1-5-32-544",
+		get(event, "EventData", "Owner", "Sid"))
+	assert.Equal(self.T(), uint32(10000),
+		get(event, "EventData", "Owner", "Pid"))
+	assert.Equal(self.T(), "Opened C:\\Windows\\notepad.exe (2 ports)\n",
+		get(event, "Message"))
+	assert.Nil(self.T(), get(event, "UserData"))
+
+	// A TraceLogging event decoded from its own metadata.
+	event = rows[2]
+	assert.Equal(self.T(), "Test.TraceLogging", get(event, "ProviderName"))
+	assert.Equal(self.T(), "ProcessStarted", get(event, "EventName"))
+	assert.Equal(self.T(), "cmd.exe", get(event, "EventData", "Image"))
+	assert.Equal(self.T(), []interface{}{uint32(1), uint32(2)},
+		get(event, "EventData", "Ids"))
+	assert.Equal(self.T(), true, get(event, "EventData", "Info", "Flag"))
+	assert.Equal(self.T(), testUnknownProvider,
+		get(event, "EventData", "Info", "Guid"))
+
+	// Unknown providers fall back to the raw payload.
+	event = rows[3]
+	assert.Equal(self.T(), testUnknownProvider, get(event, "Provider"))
+	assert.Nil(self.T(), get(event, "EventData"))
+	assert.Equal(self.T(), "deadbeef", get(event, "UserData"))
+
+	// The event in the compressed buffer.
+	event = rows[4]
+	assert.Equal(self.T(), uint16(4), get(event, "EventId"))
+	assert.Equal(self.T(), "cafe", get(event, "UserData"))
+	assert.Equal(self.T(), testStartTime.Add(4*time.Second), get(event, "Time"))
+
+	// Without the manifest the event is not decoded.
+	rows = self.parseETL(buildTestETL(), "")
+	assert.Equal(self.T(), 5, len(rows))
+	assert.Nil(self.T(), get(rows[1], "EventData"))
+	assert.Equal(self.T(), "", get(rows[1], "ProviderName"))
+}
+
+func (self *ETLTestSuite) TestFormatMessage() {
+	assert.Equal(self.T(), "a 1 b two 100%\tc",
+		formatMessage("a %1 b %2!s! 100%%%tc", []interface{}{1, "two"}))
+	assert.Equal(self.T(), "missing ",
+		formatMessage("missing %3", []interface{}{1}))
+}
+
+func TestETL(t *testing.T) {
+	suite.Run(t, &ETLTestSuite{})
+}
@@ -0,0 +1,401 @@
+package etl
+
+import (
+	"fmt"
+)
+
+const (
+	// Header types (the third byte of the marker).
+	TRACE_HEADER_TYPE_SYSTEM32       = 1
+	TRACE_HEADER_TYPE_SYSTEM64       = 2
+	TRACE_HEADER_TYPE_COMPACT32      = 3
+	TRACE_HEADER_TYPE_COMPACT64      = 4
+	TRACE_HEADER_TYPE_FULL_HEADER32  = 10
+	TRACE_HEADER_TYPE_INSTANCE32     = 11
+	TRACE_HEADER_TYPE_TIMED          = 12
+	TRACE_HEADER_TYPE_ERROR          = 13
+	TRACE_HEADER_TYPE_WNODE_HEADER   = 14
+	TRACE_HEADER_TYPE_MESSAGE        = 15
+	TRACE_HEADER_TYPE_PERFINFO32     = 16
+	TRACE_HEADER_TYPE_PERFINFO64     = 17
+	TRACE_HEADER_TYPE_EVENT_HEADER32 = 18
+	TRACE_HEADER_TYPE_EVENT_HEADER64 = 19
+	TRACE_HEADER_TYPE_FULL_HEADER64  = 20
+	TRACE_HEADER_TYPE_INSTANCE64     = 21
+
+	// Flags in the last byte of the marker.
+	TRACE_HEADER_FLAG = 0x80
+	TRACE_MESSAGE     = 0x10
+
+	// Unused space at the end of a buffer.
+	PADDING_MARKER = 0xffffffff
+
+	SYSTEM_HEADER_SIZE       = 32
+	COMPACT_HEADER_SIZE      = 24
+	PERFINFO_HEADER_SIZE     = 16
+	FULL_HEADER_SIZE         = 48
+	INSTANCE_HEADER_SIZE     = 56
+	EVENT_HEADER_SIZE        = 80
+	MESSAGE_HEADER_SIZE      = 8
+	EXTENDED_ITEM_SIZE       = 8
+	MAX_EXTENDED_DATA_ITEMS  = 32
+	EVENT_HEADER_FLAG_EXT    = 0x0001
+	EVENT_HEADER_FLAG_32_BIT = 0x0020
+	EVENT_HEADER_FLAG_64_BIT = 0x0040
+
+	// WPP message options.
+	TRACE_MESSAGE_SEQUENCE              = 0x01
+	TRACE_MESSAGE_GUID                  = 0x02
+	TRACE_MESSAGE_COMPONENTID           = 0x04
+	TRACE_MESSAGE_TIMESTAMP             = 0x08
+	TRACE_MESSAGE_PERFORMANCE_TIMESTAMP = 0x10
+	TRACE_MESSAGE_SYSTEMINFO            = 0x20
+
+	// Extended data item types.
+	EXT_TYPE_RELATED_ACTIVITYID = 0x0001
+	EXT_TYPE_SID                = 0x0002
+	EXT_TYPE_TS_ID              = 0x0003
+	EXT_TYPE_INSTANCE_INFO      = 0x0004
+	EXT_TYPE_STACK_TRACE32      = 0x0005
+	EXT_TYPE_STACK_TRACE64      = 0x0006
+	EXT_TYPE_EVENT_KEY          = 0x000a
+	EXT_TYPE_EVENT_SCHEMA_TL    = 0x000b
+	EXT_TYPE_PROV_TRAITS        = 0x000c
+	EXT_TYPE_PROCESS_START_KEY  = 0x000d
+	EXT_TYPE_CONTAINER_ID       = 0x0010
+
+	// The group of the kernel events describing the trace itself.
+	EVENT_TRACE_GROUP_HEADER = 0x00
+
+	// The type of the event carrying the TRACE_LOGFILE_HEADER.
+	EVENT_TRACE_TYPE_INFO = 0x00
+)
+
+var (
+	headerTypes = map[uint8]string{
+		TRACE_HEADER_TYPE_SYSTEM32:       "System32",
+		TRACE_HEADER_TYPE_SYSTEM64:       "System64",
+		TRACE_HEADER_TYPE_COMPACT32:      "Compact32",
+		TRACE_HEADER_TYPE_COMPACT64:      "Compact64",
+		TRACE_HEADER_TYPE_FULL_HEADER32:  "FullHeader32",
+		TRACE_HEADER_TYPE_INSTANCE32:     "Instance32",
+		TRACE_HEADER_TYPE_MESSAGE:        "Message",
+		TRACE_HEADER_TYPE_PERFINFO32:     "PerfInfo32",
+		TRACE_HEADER_TYPE_PERFINFO64:     "PerfInfo64",
+		TRACE_HEADER_TYPE_EVENT_HEADER32: "EventHeader32",
+		TRACE_HEADER_TYPE_EVENT_HEADER64: "EventHeader64",
+		TRACE_HEADER_TYPE_FULL_HEADER64:  "FullHeader64",
+		TRACE_HEADER_TYPE_INSTANCE64:     "Instance64",
+	}
+
+	// The kernel logger identifies events by a group and type
+	// (HookId) rather than a provider.
+	kernelGroups = map[uint8]string{
+		0x00: "EventTrace",
+		0x01: "DiskIo",
+		0x02: "PageFault",
+		0x03: "Process",
+		0x04: "FileIo",
+		0x05: "Thread",
+		0x06: "TcpIp",
+		0x07: "Job",
+		0x08: "UdpIp",
+		0x09: "Registry",
+		0x0a: "DbgPrint",
+		0x0b: "Config",
+		0x0d: "Wnf",
+		0x0e: "Pool",
+		0x0f: "PerfInfo",
+		0x10: "Heap",
+		0x11: "Object",
+		0x12: "Power",
+		0x13: "ModBound",
+		0x14: "Image",
+		0x15: "Dpc",
+		0x16: "CacheManager",
+		0x17: "CritSec",
+		0x18: "StackWalk",
+		0x19: "Ums",
+		0x1a: "Alpc",
+		0x1b: "SplitIo",
+		0x1c: "ThreadPool",
+		0x1d: "Hypervisor",
+		0x1e: "HypervisorX",
+	}
+)
+
+// An extended data item attached to an EVENT_HEADER event.
+type ExtendedItem struct {
+	Type uint16
+	Data buffer
+}
+
+// A single event decoded from any of the header types. Fields
+// which are not present in the header are left as zero.
+type Event struct {
+	HeaderType  uint8
+	Flags       uint16
+	PointerSize int
+
+	ProviderId string
+	HookId     uint16
+	InstanceId uint32
+
+	EventId uint16
+	Version uint8
+	Channel uint8
+	Level   uint8
+	Opcode  uint8
+	Task    uint16
+	Keyword uint64
+
+	ProcessId  uint32
+	ThreadId   uint32
+	Timestamp  int64
+	KernelTime uint32
+	UserTime   uint32
+
+	ActivityId string
+
+	// WPP messages only carry a message number.
+	MessageNumber uint16
+
+	Extended []*ExtendedItem
+	UserData buffer
+}
+
+func (self *Event) HeaderTypeName() string {
+	name, pres := headerTypes[self.HeaderType]
+	if !pres {
+		return fmt.Sprintf("%#x", self.HeaderType)
+	}
+	return name
+}
+
+// Kernel events are identified by their group.
+func (self *Event) IsKernelEvent() bool {
+	switch self.HeaderType {
+	case TRACE_HEADER_TYPE_SYSTEM32, TRACE_HEADER_TYPE_SYSTEM64,
+		TRACE_HEADER_TYPE_COMPACT32, TRACE_HEADER_TYPE_COMPACT64,
+		TRACE_HEADER_TYPE_PERFINFO32, TRACE_HEADER_TYPE_PERFINFO64:
+		return true
+	}
+	return false
+}
+
+func (self *Event) GroupName() string {
+	group := uint8(self.HookId >> 8)
+	name, pres := kernelGroups[group]
+	if !pres {
+		return fmt.Sprintf("%#x", group)
+	}
+	return name
+}
+
+func (self *Event) IsLogfileHeader() bool {
+	return self.IsKernelEvent() &&
+		self.HookId>>8 == EVENT_TRACE_GROUP_HEADER &&
+		self.HookId&0xff == EVENT_TRACE_TYPE_INFO
+}
+
+func (self *Event) ExtendedItem(ext_type uint16) buffer {
+	for _, item := range self.Extended {
+		if item.Type == ext_type {
+			return item.Data
+		}
+	}
+	return nil
+}
+
+// Parse the event at the start of data. Returns the event and its
+// aligned size. A zero size indicates the end of the events in the
+// buffer.
+func ParseEvent(data buffer, pointer_size int) (*Event, int, error) {
+	marker := data.u32(0)
+	if len(data) < 8 || marker == PADDING_MARKER || marker == 0 {
+		return nil, 0, nil
+	}
+
+	flags := data.u8(3)
+	if flags&TRACE_HEADER_FLAG == 0 {
+		return nil, 0, fmt.Errorf("Invalid event marker %#08x", marker)
+	}
+
+	self := &Event{
+		HeaderType:  data.u8(2),
+		PointerSize: pointer_size,
+	}
+
+	header_size := 0
+	size := int(data.u16(0))
+
+	switch self.HeaderType {
+	case TRACE_HEADER_TYPE_SYSTEM32, TRACE_HEADER_TYPE_SYSTEM64,
+		TRACE_HEADER_TYPE_COMPACT32, TRACE_HEADER_TYPE_COMPACT64:
+		size = int(data.u16(4))
+		self.HookId = data.u16(6)
+		self.Opcode = uint8(self.HookId)
+		self.ThreadId = data.u32(8)
+		self.ProcessId = data.u32(12)
+		self.Timestamp = int64(data.u64(16))
+		header_size = COMPACT_HEADER_SIZE
+		if self.HeaderType == TRACE_HEADER_TYPE_SYSTEM32 ||
+			self.HeaderType == TRACE_HEADER_TYPE_SYSTEM64 {
+			self.KernelTime = data.u32(24)
+			self.UserTime = data.u32(28)
+			header_size = SYSTEM_HEADER_SIZE
+		}
+		self.PointerSize = 8
+		if self.HeaderType == TRACE_HEADER_TYPE_SYSTEM32 ||
+			self.HeaderType == TRACE_HEADER_TYPE_COMPACT32 {
+			self.PointerSize = 4
+		}
+
+	case TRACE_HEADER_TYPE_PERFINFO32, TRACE_HEADER_TYPE_PERFINFO64:
+		size = int(data.u16(4))
+		self.HookId = data.u16(6)
+		self.Opcode = uint8(self.HookId)
+		self.Timestamp = int64(data.u64(8))
+		header_size = PERFINFO_HEADER_SIZE
+		self.PointerSize = 8
+		if self.HeaderType == TRACE_HEADER_TYPE_PERFINFO32 {
+			self.PointerSize = 4
+		}
+
+	// Classic (MOF) events.
+	case TRACE_HEADER_TYPE_FULL_HEADER32, TRACE_HEADER_TYPE_FULL_HEADER64:
+		self.Opcode = data.u8(4)
+		self.Level = data.u8(5)
+		self.Version = uint8(data.u16(6))
+		self.ThreadId = data.u32(8)
+		self.ProcessId = data.u32(12)
+		self.Timestamp = int64(data.u64(16))
+		self.ProviderId = data.guid(24)
+		self.KernelTime = data.u32(40)
+		self.UserTime = data.u32(44)
+		header_size = FULL_HEADER_SIZE
+
+	// Instance events refer to the provider through a registration
+	// handle which is not recorded in the file.
+	case TRACE_HEADER_TYPE_INSTANCE32, TRACE_HEADER_TYPE_INSTANCE64:
+		self.Opcode = data.u8(4)
+		self.Level = data.u8(5)
+		self.Version = uint8(data.u16(6))
+		self.ThreadId = data.u32(8)
+		self.ProcessId = data.u32(12)
+		self.Timestamp = int64(data.u64(16))
+		self.InstanceId = data.u32(32)
+		self.KernelTime = data.u32(40)
+		self.UserTime = data.u32(44)
+		header_size = INSTANCE_HEADER_SIZE
+
+	// Manifest based and TraceLogging events.
+	case TRACE_HEADER_TYPE_EVENT_HEADER32, TRACE_HEADER_TYPE_EVENT_HEADER64:
+		self.Flags = data.u16(4)
+		self.ThreadId = data.u32(8)
+		self.ProcessId = data.u32(12)
+		self.Timestamp = int64(data.u64(16))
+		self.ProviderId = data.guid(24)
+		self.EventId = data.u16(40)
+		self.Version = data.u8(42)
+		self.Channel = data.u8(43)
+		self.Level = data.u8(44)
+		self.Opcode = data.u8(45)
+		self.Task = data.u16(46)
+		self.Keyword = data.u64(48)
+		self.KernelTime = data.u32(56)
+		self.UserTime = data.u32(60)
+		self.ActivityId = data.guid(64)
+		header_size = EVENT_HEADER_SIZE
+
+		if self.Flags&EVENT_HEADER_FLAG_32_BIT != 0 {
+			self.PointerSize = 4
+		} else if self.Flags&EVENT_HEADER_FLAG_64_BIT != 0 {
+			self.PointerSize = 8
+		}
+
+		if self.Flags&EVENT_HEADER_FLAG_EXT != 0 && size <= len(data) {
+			header_size = self.parseExtendedData(
+				data[:size], EVENT_HEADER_SIZE)
+		}
+
+	case TRACE_HEADER_TYPE_MESSAGE:
+		header_size = self.parseMessageHeader(data)
+
+	default:
+		if flags&TRACE_MESSAGE != 0 {
+			self.HeaderType = TRACE_HEADER_TYPE_MESSAGE
+			header_size = self.parseMessageHeader(data)
+			break
+		}
+		return nil, 0, fmt.Errorf("Unsupported header type %#x", self.HeaderType)
+	}
+
+	if size < header_size || size > len(data) {
+		return nil, 0, fmt.Errorf("Invalid event size %#x", size)
+	}
+	self.UserData = data[header_size:size]
+
+	return self, align8(size), nil
+}
+
+// Extended data items follow the EVENT_HEADER. Each item is 8 byte
+// aligned and linked to the next. Returns the offset of the user
+// data.
+func (self *Event) parseExtendedData(data buffer, offset int) int {
+	for i := 0; i < MAX_EXTENDED_DATA_ITEMS; i++ {
+		ext_type := data.u16(offset + 2)
+		linkage := data.u16(offset + 4)
+		data_size := int(data.u16(offset + 6))
+
+		item := data.slice(offset+EXTENDED_ITEM_SIZE, data_size)
+		if item == nil {
+			break
+		}
+
+		self.Extended = append(self.Extended, &ExtendedItem{
+			Type: ext_type,
+			Data: item,
+		})
+		offset = align8(offset + EXTENDED_ITEM_SIZE + data_size)
+
+		if linkage&1 == 0 {
+			break
+		}
+	}
+	return offset
+}
+
+// WPP messages have a variable size header depending on the
+// message options. Returns the header size.
+func (self *Event) parseMessageHeader(data buffer) int {
+	self.MessageNumber = data.u16(4)
+	options := data.u16(6)
+
+	offset := MESSAGE_HEADER_SIZE
+	if options&TRACE_MESSAGE_SEQUENCE != 0 {
+		offset += 4
+	}
+
+	if options&TRACE_MESSAGE_GUID != 0 {
+		self.ProviderId = data.guid(offset)
+		offset += 16
+	} else if options&TRACE_MESSAGE_COMPONENTID != 0 {
+		self.ProviderId = fmt.Sprintf("%#x", data.u32(offset))
+		offset += 4
+	}
+
+	if options&(TRACE_MESSAGE_TIMESTAMP|
+		TRACE_MESSAGE_PERFORMANCE_TIMESTAMP) != 0 {
+		self.Timestamp = int64(data.u64(offset))
+		offset += 8
+	}
+
+	if options&TRACE_MESSAGE_SYSTEMINFO != 0 {
+		self.ThreadId = data.u32(offset)
+		self.ProcessId = data.u32(offset + 4)
+		offset += 8
+	}
+
+	return offset
+}
@@ -0,0 +1,177 @@
+package etl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+const (
+	// The size of the TIME_ZONE_INFORMATION in the logfile header.
+	TIME_ZONE_INFORMATION_SIZE = 172
+
+	// Buffer reference times outside this range (2000-2100) are
+	// not trusted.
+	MIN_REFERENCE_TIME = 125911584000000000
+	MAX_REFERENCE_TIME = 157469184000000000
+
+	// The clock types in the ReservedFlags field.
+	EVENT_TRACE_CLOCK_PERFCOUNTER = 1
+	EVENT_TRACE_CLOCK_SYSTEMTIME  = 2
+	EVENT_TRACE_CLOCK_CPUCYCLE    = 3
+)
+
+var (
+	clockTypes = map[uint32]string{
+		EVENT_TRACE_CLOCK_PERFCOUNTER: "QPC",
+		EVENT_TRACE_CLOCK_SYSTEMTIME:  "SystemTime",
+		EVENT_TRACE_CLOCK_CPUCYCLE:    "CPUCycle",
+	}
+)
+
+// The TRACE_LOGFILE_HEADER carried by the first event of the file.
+type LogfileHeader struct {
+	BufferSize         uint32
+	Version            string
+	ProviderVersion    uint32
+	NumberOfProcessors uint32
+	EndTime            int64
+	TimerResolution    uint32
+	MaximumFileSize    uint32
+	LogFileMode        uint32
+	BuffersWritten     uint32
+	PointerSize        int
+	EventsLost         uint32
+	CpuSpeedInMHz      uint32
+	BootTime           int64
+	PerfFreq           int64
+	StartTime          int64
+	ClockType          uint32
+	BuffersLost        uint32
+	LoggerName         string
+	LogFileName        string
+}
+
+func ParseLogfileHeader(data buffer) *LogfileHeader {
+	self := &LogfileHeader{
+		BufferSize: data.u32(0),
+		Version: fmt.Sprintf("%d.%d.%d.%d", data.u8(4), data.u8(5),
+			data.u8(6), data.u8(7)),
+		ProviderVersion:    data.u32(8),
+		NumberOfProcessors: data.u32(12),
+		EndTime:            int64(data.u64(16)),
+		TimerResolution:    data.u32(24),
+		MaximumFileSize:    data.u32(28),
+		LogFileMode:        data.u32(32),
+		BuffersWritten:     data.u32(36),
+		PointerSize:        int(data.u32(44)),
+		EventsLost:         data.u32(48),
+		CpuSpeedInMHz:      data.u32(52),
+	}
+
+	if self.PointerSize != 4 {
+		self.PointerSize = 8
+	}
+
+	// The logger name and file name pointers are followed by the
+	// time zone and the 8 byte aligned remaining fields.
+	offset := align8(56 + 2*self.PointerSize + TIME_ZONE_INFORMATION_SIZE)
+	self.BootTime = int64(data.u64(offset))
+	self.PerfFreq = int64(data.u64(offset + 8))
+	self.StartTime = int64(data.u64(offset + 16))
+	self.ClockType = data.u32(offset + 24)
+	self.BuffersLost = data.u32(offset + 28)
+
+	// The strings follow the header.
+	offset += 32
+	name, size := data.utf16z(offset)
+	self.LoggerName = name
+	self.LogFileName, _ = data.utf16z(offset + size)
+
+	return self
+}
+
+func (self *LogfileHeader) ToDict() *ordereddict.Dict {
+	return ordereddict.NewDict().
+		Set("LoggerName", self.LoggerName).
+		Set("LogFileName", self.LogFileName).
+		Set("Version", self.Version).
+		Set("ProviderVersion", self.ProviderVersion).
+		Set("NumberOfProcessors", self.NumberOfProcessors).
+		Set("CpuSpeedInMHz", self.CpuSpeedInMHz).
+		Set("BufferSize", self.BufferSize).
+		Set("MaximumFileSize", self.MaximumFileSize).
+		Set("LogFileMode", fmt.Sprintf("%#x", self.LogFileMode)).
+		Set("BuffersWritten", self.BuffersWritten).
+		Set("BuffersLost", self.BuffersLost).
+		Set("EventsLost", self.EventsLost).
+		Set("PointerSize", self.PointerSize).
+		Set("ClockType", self.ClockTypeName()).
+		Set("PerfFreq", self.PerfFreq).
+		Set("BootTime", filetimeToTime(self.BootTime)).
+		Set("StartTime", filetimeToTime(self.StartTime)).
+		Set("EndTime", filetimeToTime(self.EndTime))
+}
+
+func (self *LogfileHeader) ClockTypeName() string {
+	name, pres := clockTypes[self.ClockType]
+	if !pres {
+		return fmt.Sprintf("%#x", self.ClockType)
+	}
+	return name
+}
+
+// Converts raw event timestamps to times. Depending on the clock
+// type the timestamps are either FILETIMEs or ticks of a counter
+// which must be related to a reference time.
+type Clock struct {
+	clock_type uint32
+	frequency  int64
+
+	// A FILETIME and the raw clock value at the same instant.
+	reference_time  int64
+	reference_clock int64
+}
+
+func NewClock(header *LogfileHeader, header_timestamp int64) *Clock {
+	self := &Clock{
+		clock_type:      header.ClockType,
+		frequency:       header.PerfFreq,
+		reference_time:  header.StartTime,
+		reference_clock: header_timestamp,
+	}
+
+	if self.clock_type == EVENT_TRACE_CLOCK_CPUCYCLE {
+		self.frequency = int64(header.CpuSpeedInMHz) * 1000000
+	}
+	return self
+}
+
+// Buffers may carry their own reference time which is more accurate
+// for long running traces.
+func (self *Clock) SetReference(reference_time, reference_clock int64) {
+	if reference_time > MIN_REFERENCE_TIME &&
+		reference_time < MAX_REFERENCE_TIME && reference_clock > 0 {
+		self.reference_time = reference_time
+		self.reference_clock = reference_clock
+	}
+}
+
+func (self *Clock) Time(timestamp int64) time.Time {
+	// Without a logfile header or with a system time clock the
+	// timestamps are FILETIMEs.
+	if self == nil || self.clock_type == EVENT_TRACE_CLOCK_SYSTEMTIME ||
+		self.frequency <= 0 {
+		return filetimeToTime(timestamp)
+	}
+
+	delta := timestamp - self.reference_clock
+
+	// Avoid overflow by splitting the delta into whole seconds and
+	// the remainder.
+	seconds := delta / self.frequency
+	remainder := delta % self.frequency
+	return filetimeToTime(self.reference_time + seconds*10000000 +
+		remainder*10000000/self.frequency)
+}
@@ -0,0 +1,374 @@
+package etl
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+var (
+	manifestInTypes = map[string]int{
+		"win:UnicodeString":        INTYPE_UNICODESTRING,
+		"win:AnsiString":           INTYPE_ANSISTRING,
+		"win:Int8":                 INTYPE_INT8,
+		"win:UInt8":                INTYPE_UINT8,
+		"win:Int16":                INTYPE_INT16,
+		"win:UInt16":               INTYPE_UINT16,
+		"win:Int32":                INTYPE_INT32,
+		"win:UInt32":               INTYPE_UINT32,
+		"win:Int64":                INTYPE_INT64,
+		"win:UInt64":               INTYPE_UINT64,
+		"win:Float":                INTYPE_FLOAT,
+		"win:Double":               INTYPE_DOUBLE,
+		"win:Boolean":              INTYPE_BOOLEAN,
+		"win:Binary":               INTYPE_BINARY,
+		"win:GUID":                 INTYPE_GUID,
+		"win:Pointer":              INTYPE_POINTER,
+		"win:FILETIME":             INTYPE_FILETIME,
+		"win:SYSTEMTIME":           INTYPE_SYSTEMTIME,
+		"win:SID":                  INTYPE_SID,
+		"win:HexInt32":             INTYPE_HEXINT32,
+		"win:HexInt64":             INTYPE_HEXINT64,
+		"win:CountedString":        INTYPE_COUNTEDSTRING,
+		"win:CountedUnicodeString": INTYPE_COUNTEDSTRING,
+		"win:CountedAnsiString":    INTYPE_COUNTEDANSISTRING,
+		"win:CountedBinary":        INTYPE_COUNTEDBINARY,
+	}
+)
+
+// The parts of an instrumentation manifest (the XML source TDH
+// schemas are compiled from) needed to decode events.
+type xmlManifest struct {
+	Providers []*xmlProvider  `xml:"instrumentation>events>provider"`
+	Resources []*xmlResources `xml:"localization>resources"`
+}
+
+type xmlProvider struct {
+	Name      string         `xml:"name,attr"`
+	GUID      string         `xml:"guid,attr"`
+	Events    []*xmlEvent    `xml:"events>event"`
+	Templates []*xmlTemplate `xml:"templates>template"`
+}
+
+type xmlEvent struct {
+	Value    string `xml:"value,attr"`
+	Version  string `xml:"version,attr"`
+	Symbol   string `xml:"symbol,attr"`
+	Template string `xml:"template,attr"`
+	Message  string `xml:"message,attr"`
+}
+
+type xmlTemplate struct {
+	Tid    string      `xml:"tid,attr"`
+	Fields []*xmlField `xml:",any"`
+}
+
+// Template fields are data or struct elements which must be kept
+// in order.
+type xmlField struct {
+	XMLName xml.Name
+	Name    string      `xml:"name,attr"`
+	InType  string      `xml:"inType,attr"`
+	Length  string      `xml:"length,attr"`
+	Count   string      `xml:"count,attr"`
+	Fields  []*xmlField `xml:",any"`
+}
+
+type xmlResources struct {
+	Culture string       `xml:"culture,attr"`
+	Strings []*xmlString `xml:"stringTable>string"`
+}
+
+type xmlString struct {
+	Id    string `xml:"id,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type eventKey struct {
+	provider string
+	id       uint16
+	version  uint8
+}
+
+type manifestEvent struct {
+	Provider string
+	Symbol   string
+	Message  string
+	Fields   []*xmlField
+}
+
+// A set of events loaded from manifests.
+type Manifests struct {
+	providers map[string]string
+	events    map[eventKey]*manifestEvent
+
+	// Events keyed by id only, for events logged with a different
+	// version than the manifest describes.
+	any_version map[eventKey]*manifestEvent
+}
+
+func NewManifests() *Manifests {
+	return &Manifests{
+		providers:   make(map[string]string),
+		events:      make(map[eventKey]*manifestEvent),
+		any_version: make(map[eventKey]*manifestEvent),
+	}
+}
+
+func (self *Manifests) Load(data []byte) error {
+	manifest := &xmlManifest{}
+	err := xml.Unmarshal(data, manifest)
+	if err != nil {
+		return err
+	}
+
+	strings_table := make(map[string]string)
+	for _, resources := range manifest.Resources {
+		for _, s := range resources.Strings {
+			// Prefer the first culture (usually en-US).
+			_, pres := strings_table[s.Id]
+			if !pres {
+				strings_table[s.Id] = s.Value
+			}
+		}
+	}
+
+	if len(manifest.Providers) == 0 {
+		return fmt.Errorf("No providers found in manifest")
+	}
+
+	for _, provider := range manifest.Providers {
+		guid := normalizeGUID(provider.GUID)
+		self.providers[guid] = provider.Name
+
+		templates := make(map[string]*xmlTemplate)
+		for _, t := range provider.Templates {
+			templates[t.Tid] = t
+		}
+
+		for _, event := range provider.Events {
+			id, err := strconv.ParseUint(event.Value, 0, 16)
+			if err != nil {
+				continue
+			}
+			version, _ := strconv.ParseUint(event.Version, 0, 8)
+
+			item := &manifestEvent{
+				Provider: provider.Name,
+				Symbol:   event.Symbol,
+				Message:  resolveString(event.Message, strings_table),
+			}
+
+			template, pres := templates[event.Template]
+			if pres {
+				item.Fields = template.Fields
+			}
+
+			key := eventKey{provider: guid, id: uint16(id), version: uint8(version)}
+			self.events[key] = item
+
+			key.version = 0
+			self.any_version[key] = item
+		}
+	}
+
+	return nil
+}
+
+// Messages refer to the string table as $(string.Id).
+func resolveString(message string, table map[string]string) string {
+	if strings.HasPrefix(message, "$(string.") && strings.HasSuffix(message, ")") {
+		value, pres := table[message[9:len(message)-1]]
+		if pres {
+			return value
+		}
+	}
+	return message
+}
+
+func (self *Manifests) ProviderName(guid string) string {
+	if self == nil {
+		return ""
+	}
+	return self.providers[guid]
+}
+
+func (self *Manifests) Lookup(event *Event) *manifestEvent {
+	if self == nil {
+		return nil
+	}
+
+	key := eventKey{provider: event.ProviderId, id: event.EventId,
+		version: event.Version}
+	result, pres := self.events[key]
+	if pres {
+		return result
+	}
+
+	key.version = 0
+	return self.any_version[key]
+}
+
+// Decode the payload using the template. Returns the fields and
+// their values in order (for message formatting).
+func (self *manifestEvent) Decode(data buffer, pointer_size int) (
+	*ordereddict.Dict, []interface{}, error) {
+	result, values, _, err := decodeManifestFields(data, self.Fields, pointer_size)
+	return result, values, err
+}
+
+// Returns the decoded fields, their values in order and the number
+// of bytes consumed.
+func decodeManifestFields(data buffer, fields []*xmlField,
+	pointer_size int) (*ordereddict.Dict, []interface{}, int, error) {
+	result := ordereddict.NewDict()
+	values := []interface{}{}
+	offset := 0
+
+	for _, field := range fields {
+		name := field.XMLName.Local
+		if name != "data" && name != "struct" {
+			continue
+		}
+
+		count, err := fieldSize(field.Count, result)
+		if err != nil {
+			return result, values, offset, err
+		}
+
+		length, err := fieldSize(field.Length, result)
+		if err != nil {
+			return result, values, offset, err
+		}
+
+		decode_one := func(data buffer) (interface{}, int, error) {
+			if name == "struct" {
+				value, _, n, err := decodeManifestFields(
+					data, field.Fields, pointer_size)
+				return value, n, err
+			}
+
+			in_type, pres := manifestInTypes[field.InType]
+			if !pres {
+				return nil, 0, fmt.Errorf("Unsupported inType %v", field.InType)
+			}
+			return decodeValue(data, in_type, length, pointer_size)
+		}
+
+		var value interface{}
+		if count < 0 {
+			var n int
+			value, n, err = decode_one(data.from(offset))
+			if err != nil {
+				return result, values, offset, fmt.Errorf("%v: %w", field.Name, err)
+			}
+			offset += n
+
+		} else {
+			if count > MAX_ARRAY_COUNT {
+				return result, values, offset, fmt.Errorf(
+					"%v: Array too large (%d)", field.Name, count)
+			}
+
+			array := make([]interface{}, 0, count)
+			for i := 0; i < count; i++ {
+				item, n, err := decode_one(data.from(offset))
+				if err != nil {
+					return result, values, offset, fmt.Errorf("%v: %w", field.Name, err)
+				}
+				array = append(array, item)
+				offset += n
+			}
+			value = array
+		}
+
+		result.Set(field.Name, value)
+		values = append(values, value)
+	}
+
+	return result, values, offset, nil
+}
+
+// Lengths and counts are either a number or the name of a previous
+// field. Returns -1 when not specified.
+func fieldSize(spec string, previous *ordereddict.Dict) (int, error) {
+	if spec == "" {
+		return -1, nil
+	}
+
+	size, err := strconv.ParseUint(spec, 0, 32)
+	if err == nil {
+		return int(size), nil
+	}
+
+	value, pres := previous.Get(spec)
+	if pres {
+		result, ok := toInt(value)
+		if ok {
+			return result, nil
+		}
+	}
+	return 0, fmt.Errorf("Unable to resolve size %v", spec)
+}
+
+// Expand the %1 style inserts in a manifest message.
+func formatMessage(message string, values []interface{}) string {
+	if message == "" {
+		return ""
+	}
+
+	result := strings.Builder{}
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c != '%' || i+1 >= len(message) {
+			result.WriteByte(c)
+			continue
+		}
+
+		next := message[i+1]
+		switch {
+		case next == 'n':
+			result.WriteByte('\n')
+			i++
+			continue
+
+		case next == 't':
+			result.WriteByte('\t')
+			i++
+			continue
+
+		case next == '%':
+			result.WriteByte('%')
+			i++
+			continue
+
+		case next < '1' || next > '9':
+			result.WriteByte(c)
+			continue
+		}
+
+		// Parse the insert number and skip any printf style
+		// format specification (%1!s!).
+		end := i + 1
+		for end < len(message) && message[end] >= '0' && message[end] <= '9' {
+			end++
+		}
+		idx, _ := strconv.Atoi(message[i+1 : end])
+		if end < len(message) && message[end] == '!' {
+			close_idx := strings.IndexByte(message[end+1:], '!')
+			if close_idx >= 0 {
+				end += close_idx + 2
+			}
+		}
+
+		if idx >= 1 && idx <= len(values) {
+			result.WriteString(fmt.Sprintf("%v", values[idx-1]))
+		}
+		i = end - 1
+	}
+
+	return result.String()
+}
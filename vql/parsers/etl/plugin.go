@@ -0,0 +1,131 @@
+package etl
+
+import (
+	"context"
+	"io"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type ETLPluginArgs struct {
+	Filenames []*accessors.OSPath `vfilter:"required,field=filename,doc=A list of ETL files to parse."`
+	Manifests []*accessors.OSPath `vfilter:"optional,field=manifest,doc=Instrumentation manifests (XML) used to decode events of manifest based providers."`
+	Accessor  string              `vfilter:"optional,field=accessor,doc=The accessor to use."`
+}
+
+type ETLPlugin struct{}
+
+func (self ETLPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("parse_etl", args)()
+
+		arg := &ETLPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("parse_etl: %v", err)
+			return
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("parse_etl: %v", err)
+			return
+		}
+
+		accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+		if err != nil {
+			scope.Log("parse_etl: %v", err)
+			return
+		}
+
+		manifests := NewManifests()
+		for _, filename := range arg.Manifests {
+			err := loadManifest(accessor, filename, manifests)
+			if err != nil {
+				scope.Log("parse_etl: %v: %v", filename, err)
+			}
+		}
+
+		log := func(format string, args ...interface{}) {
+			scope.Log(format, args...)
+		}
+
+		for _, filename := range arg.Filenames {
+			err := parseETLFile(ctx, accessor, filename, manifests, log,
+				func(row *ordereddict.Dict) error {
+					row.Set("OSPath", filename)
+
+					select {
+					case <-ctx.Done():
+						return stopIteration
+					case output_chan <- row:
+					}
+					return nil
+				})
+			if err != nil {
+				scope.Log("parse_etl: %v: %v", filename, err)
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func loadManifest(accessor accessors.FileSystemAccessor,
+	filename *accessors.OSPath, manifests *Manifests) error {
+	fd, err := accessor.OpenWithOSPath(filename)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	data, err := io.ReadAll(io.LimitReader(fd, constants.MAX_MEMORY))
+	if err != nil {
+		return err
+	}
+
+	return manifests.Load(data)
+}
+
+func parseETLFile(ctx context.Context,
+	accessor accessors.FileSystemAccessor, filename *accessors.OSPath,
+	manifests *Manifests,
+	log func(format string, args ...interface{}),
+	cb func(row *ordereddict.Dict) error) error {
+
+	fd, err := accessor.OpenWithOSPath(filename)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	return ParseETL(ctx, utils.MakeReaderAtter(fd), manifests, log, cb)
+}
+
+func (self ETLPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "parse_etl",
+		Doc:      "Parse events from an Event Tracing for Windows (ETL) trace file.",
+		ArgType:  type_map.AddType(scope, &ETLPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&ETLPlugin{})
+}
@@ -0,0 +1,192 @@
+package etl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// A bounds checked view of a structure. All reads are relative to
+// the start of the structure and reads past the end return zero
+// values.
+type buffer []byte
+
+func (self buffer) slice(offset, length int) buffer {
+	if offset < 0 || length < 0 || offset+length > len(self) {
+		return nil
+	}
+	return self[offset : offset+length]
+}
+
+func (self buffer) from(offset int) buffer {
+	if offset < 0 || offset > len(self) {
+		return nil
+	}
+	return self[offset:]
+}
+
+func (self buffer) u8(offset int) uint8 {
+	b := self.slice(offset, 1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (self buffer) u16(offset int) uint16 {
+	b := self.slice(offset, 2)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+func (self buffer) u32(offset int) uint32 {
+	b := self.slice(offset, 4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (self buffer) u64(offset int) uint64 {
+	b := self.slice(offset, 8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (self buffer) f32(offset int) float32 {
+	return math.Float32frombits(self.u32(offset))
+}
+
+func (self buffer) f64(offset int) float64 {
+	return math.Float64frombits(self.u64(offset))
+}
+
+// A pointer sized unsigned integer.
+func (self buffer) pointer(offset, pointer_size int) uint64 {
+	if pointer_size == 4 {
+		return uint64(self.u32(offset))
+	}
+	return self.u64(offset)
+}
+
+// A Windows FILETIME. Unset times are returned as the zero time.
+func (self buffer) filetime(offset int) time.Time {
+	return filetimeToTime(int64(self.u64(offset)))
+}
+
+func filetimeToTime(value int64) time.Time {
+	if value <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(value/10000000-11644473600,
+		(value%10000000)*100).UTC()
+}
+
+// A Windows SYSTEMTIME structure.
+func (self buffer) systemtime(offset int) time.Time {
+	b := self.slice(offset, 16)
+	if b == nil || b.u16(0) == 0 {
+		return time.Time{}
+	}
+
+	return time.Date(int(b.u16(0)), time.Month(b.u16(2)), int(b.u16(6)),
+		int(b.u16(8)), int(b.u16(10)), int(b.u16(12)),
+		int(b.u16(14))*int(time.Millisecond), time.UTC)
+}
+
+func (self buffer) guid(offset int) string {
+	b := self.slice(offset, 16)
+	if b == nil {
+		return ""
+	}
+	return formatGUID(b)
+}
+
+func formatGUID(b []byte) string {
+	return fmt.Sprintf("{%08X-%04X-%04X-%X-%X}",
+		binary.LittleEndian.Uint32(b[0:4]),
+		binary.LittleEndian.Uint16(b[4:6]),
+		binary.LittleEndian.Uint16(b[6:8]),
+		b[8:10], b[10:16])
+}
+
+// GUIDs are compared in their upper case braced form.
+func normalizeGUID(guid string) string {
+	guid = strings.ToUpper(strings.TrimSpace(guid))
+	if guid != "" && !strings.HasPrefix(guid, "{") {
+		guid = "{" + guid + "}"
+	}
+	return guid
+}
+
+// A binary SID. Returns the string form and the size of the SID.
+func (self buffer) sid(offset int) (string, int) {
+	count := int(self.u8(offset + 1))
+	b := self.slice(offset, 8+4*count)
+	if b == nil {
+		return "", 0
+	}
+
+	authority := uint64(0)
+	for i := 2; i < 8; i++ {
+		authority = authority<<8 | uint64(b[i])
+	}
+
+	result := fmt.Sprintf("S-%d-%d", b[0], authority)
+	for i := 0; i < count; i++ {
+		result += fmt.Sprintf("-%d", b.u32(8+4*i))
+	}
+	return result, len(b)
+}
+
+// A NUL terminated ASCII string. Returns the string and the number
+// of bytes consumed (including the terminator).
+func (self buffer) asciiz(offset int) (string, int) {
+	b := self.from(offset)
+	idx := bytes.IndexByte(b, 0)
+	if idx < 0 {
+		return string(b), len(b)
+	}
+	return string(b[:idx]), idx + 1
+}
+
+// A NUL terminated UTF16 string. Returns the string and the number
+// of bytes consumed (including the terminator).
+func (self buffer) utf16z(offset int) (string, int) {
+	b := self.from(offset)
+	result := []uint16{}
+	for i := 0; i+1 < len(b); i += 2 {
+		c := binary.LittleEndian.Uint16(b[i:])
+		if c == 0 {
+			return string(utf16.Decode(result)), i + 2
+		}
+		result = append(result, c)
+	}
+	return string(utf16.Decode(result)), len(b)
+}
+
+// A UTF16 string of a fixed number of characters.
+func (self buffer) utf16(offset, count int) string {
+	b := self.slice(offset, 2*count)
+	if b == nil {
+		return ""
+	}
+
+	result := make([]uint16, 0, count)
+	for i := 0; i < count; i++ {
+		result = append(result, binary.LittleEndian.Uint16(b[2*i:]))
+	}
+	return strings.TrimRight(string(utf16.Decode(result)), "\x00")
+}
+
+func align8(value int) int {
+	return (value + 7) &^ 7
+}
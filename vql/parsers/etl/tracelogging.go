@@ -0,0 +1,215 @@
+package etl
+
+import (
+	"fmt"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+const (
+	// Flags in the TraceLogging InType byte.
+	TLG_IN_TYPE_MASK = 0x1f
+	TLG_IN_CCOUNT    = 0x20
+	TLG_IN_VCOUNT    = 0x40
+	TLG_IN_CUSTOM    = 0x60
+	TLG_IN_CHAIN     = 0x80
+
+	// Flags in the TraceLogging OutType byte.
+	TLG_OUT_CHAIN = 0x80
+
+	// Sanity limit on the number of fields in an event.
+	MAX_TLG_FIELDS = 1000
+)
+
+// A field described by TraceLogging metadata.
+type tlgField struct {
+	Name    string
+	InType  int
+	OutType uint8
+	Flags   uint8
+	Count   int
+
+	// Struct fields contain the following fields.
+	Fields []*tlgField
+}
+
+// TraceLogging events are self describing: the EVENT_SCHEMA_TL
+// extended item describes the event name and the fields in the
+// payload.
+type tlgSchema struct {
+	Name   string
+	Fields []*tlgField
+}
+
+func parseTraceLoggingSchema(meta buffer) (*tlgSchema, error) {
+	size := int(meta.u16(0))
+	if size < 2 || size > len(meta) {
+		return nil, fmt.Errorf("Invalid TraceLogging metadata size %#x", size)
+	}
+	meta = meta[:size]
+
+	offset := skipTags(meta, 2)
+	name, n := meta.asciiz(offset)
+	offset += n
+
+	flat := []*tlgField{}
+	for offset < len(meta) && len(flat) < MAX_TLG_FIELDS {
+		field := &tlgField{}
+		field.Name, n = meta.asciiz(offset)
+		offset += n
+
+		in_type := meta.u8(offset)
+		offset++
+
+		field.InType = int(in_type & TLG_IN_TYPE_MASK)
+		field.Flags = in_type & TLG_IN_CUSTOM
+
+		if in_type&TLG_IN_CHAIN != 0 {
+			field.OutType = meta.u8(offset)
+			offset++
+			if field.OutType&TLG_OUT_CHAIN != 0 {
+				offset = skipTags(meta, offset)
+			}
+		}
+
+		switch field.Flags {
+		case TLG_IN_CCOUNT:
+			field.Count = int(meta.u16(offset))
+			offset += 2
+
+		case TLG_IN_CUSTOM:
+			// Custom fields carry an opaque type description.
+			offset += 2 + int(meta.u16(offset))
+		}
+
+		if offset > len(meta) {
+			return nil, fmt.Errorf("Truncated TraceLogging metadata")
+		}
+		flat = append(flat, field)
+	}
+
+	fields, _ := nestTraceLoggingFields(flat, len(flat))
+	return &tlgSchema{Name: name, Fields: fields}, nil
+}
+
+// Tags are a sequence of bytes with the top bit indicating another
+// byte follows.
+func skipTags(meta buffer, offset int) int {
+	for offset < len(meta) {
+		tag := meta.u8(offset)
+		offset++
+		if tag&0x80 == 0 {
+			break
+		}
+	}
+	return offset
+}
+
+// Struct fields are followed by their member fields. The number of
+// members is stored in the OutType.
+func nestTraceLoggingFields(flat []*tlgField, count int) ([]*tlgField, int) {
+	result := []*tlgField{}
+	consumed := 0
+	for len(result) < count && consumed < len(flat) {
+		field := flat[consumed]
+		consumed++
+
+		if field.InType == INTYPE_STRUCT {
+			members, n := nestTraceLoggingFields(
+				flat[consumed:], int(field.OutType&^TLG_OUT_CHAIN))
+			field.Fields = members
+			consumed += n
+		}
+		result = append(result, field)
+	}
+	return result, consumed
+}
+
+// Decode the payload according to the schema.
+func (self *tlgSchema) Decode(data buffer, pointer_size int) (
+	*ordereddict.Dict, error) {
+	result, _, err := decodeTraceLoggingFields(data, self.Fields, pointer_size)
+	return result, err
+}
+
+func decodeTraceLoggingFields(data buffer, fields []*tlgField,
+	pointer_size int) (*ordereddict.Dict, int, error) {
+	result := ordereddict.NewDict()
+	offset := 0
+	for _, field := range fields {
+		value, n, err := decodeTraceLoggingField(
+			data.from(offset), field, pointer_size)
+		if err != nil {
+			return result, offset, fmt.Errorf("%v: %w", field.Name, err)
+		}
+		result.Set(field.Name, value)
+		offset += n
+	}
+	return result, offset, nil
+}
+
+func decodeTraceLoggingField(data buffer, field *tlgField,
+	pointer_size int) (interface{}, int, error) {
+	offset := 0
+	count := 1
+
+	switch field.Flags {
+	case TLG_IN_CCOUNT:
+		count = field.Count
+
+	case TLG_IN_VCOUNT:
+		if len(data) < 2 {
+			return nil, 0, shortDataError
+		}
+		count = int(data.u16(0))
+		offset = 2
+
+		// Variable length binary is a single blob.
+		if field.InType == INTYPE_BINARY {
+			value, n, err := decodeValue(data.from(offset),
+				INTYPE_BINARY, count, pointer_size)
+			return value, offset + n, err
+		}
+
+	case TLG_IN_CUSTOM:
+		if len(data) < 2 {
+			return nil, 0, shortDataError
+		}
+		size := int(data.u16(0))
+		value, n, err := decodeValue(data.from(2),
+			INTYPE_BINARY, size, pointer_size)
+		return value, 2 + n, err
+	}
+
+	decode_one := func(data buffer) (interface{}, int, error) {
+		if field.InType == INTYPE_STRUCT {
+			return decodeTraceLoggingFields(data, field.Fields, pointer_size)
+		}
+		return decodeValue(data, field.InType, -1, pointer_size)
+	}
+
+	if field.Flags == 0 {
+		return decode_one(data)
+	}
+
+	if count > MAX_ARRAY_COUNT {
+		return nil, 0, fmt.Errorf("Array too large (%d)", count)
+	}
+
+	values := make([]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		value, n, err := decode_one(data.from(offset))
+		if err != nil {
+			return values, offset, err
+		}
+		values = append(values, value)
+		offset += n
+	}
+	return values, offset, nil
+}
+
+// The PROV_TRAITS extended item starts with the provider name.
+func parseProviderTraits(traits buffer) string {
+	name, _ := traits.asciiz(2)
+	return name
+}
@@ -0,0 +1,225 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The input types shared by TraceLogging metadata and manifests
+// (the TDH_INTYPE values).
+const (
+	INTYPE_NULL              = 0
+	INTYPE_UNICODESTRING     = 1
+	INTYPE_ANSISTRING        = 2
+	INTYPE_INT8              = 3
+	INTYPE_UINT8             = 4
+	INTYPE_INT16             = 5
+	INTYPE_UINT16            = 6
+	INTYPE_INT32             = 7
+	INTYPE_UINT32            = 8
+	INTYPE_INT64             = 9
+	INTYPE_UINT64            = 10
+	INTYPE_FLOAT             = 11
+	INTYPE_DOUBLE            = 12
+	INTYPE_BOOLEAN           = 13
+	INTYPE_BINARY            = 14
+	INTYPE_GUID              = 15
+	INTYPE_POINTER           = 16
+	INTYPE_FILETIME          = 17
+	INTYPE_SYSTEMTIME        = 18
+	INTYPE_SID               = 19
+	INTYPE_HEXINT32          = 20
+	INTYPE_HEXINT64          = 21
+	INTYPE_COUNTEDSTRING     = 22
+	INTYPE_COUNTEDANSISTRING = 23
+	INTYPE_STRUCT            = 24
+	INTYPE_COUNTEDBINARY     = 25
+
+	// Sanity limit on the number of elements in an array.
+	MAX_ARRAY_COUNT = 0x10000
+)
+
+var (
+	shortDataError = errors.New("Payload is too short")
+)
+
+// Decode a single value of the input type from the start of
+// data. A length of -1 means the value is self sizing (NUL
+// terminated strings), otherwise it is the number of characters
+// or bytes. Returns the value and the number of bytes consumed.
+func decodeValue(data buffer, in_type int, length int,
+	pointer_size int) (interface{}, int, error) {
+	fixed := func(size int) error {
+		if size > len(data) {
+			return shortDataError
+		}
+		return nil
+	}
+
+	switch in_type {
+	case INTYPE_NULL:
+		return nil, 0, nil
+
+	case INTYPE_UNICODESTRING:
+		if length >= 0 {
+			if err := fixed(2 * length); err != nil {
+				return nil, 0, err
+			}
+			return data.utf16(0, length), 2 * length, nil
+		}
+		if len(data) < 2 {
+			return nil, 0, shortDataError
+		}
+		value, size := data.utf16z(0)
+		return value, size, nil
+
+	case INTYPE_ANSISTRING:
+		if length >= 0 {
+			if err := fixed(length); err != nil {
+				return nil, 0, err
+			}
+			value, _ := buffer(data[:length]).asciiz(0)
+			return value, length, nil
+		}
+		if len(data) < 1 {
+			return nil, 0, shortDataError
+		}
+		value, size := data.asciiz(0)
+		return value, size, nil
+
+	case INTYPE_INT8, INTYPE_UINT8:
+		if err := fixed(1); err != nil {
+			return nil, 0, err
+		}
+		if in_type == INTYPE_INT8 {
+			return int8(data.u8(0)), 1, nil
+		}
+		return data.u8(0), 1, nil
+
+	case INTYPE_INT16, INTYPE_UINT16:
+		if err := fixed(2); err != nil {
+			return nil, 0, err
+		}
+		if in_type == INTYPE_INT16 {
+			return int16(data.u16(0)), 2, nil
+		}
+		return data.u16(0), 2, nil
+
+	case INTYPE_INT32, INTYPE_UINT32, INTYPE_HEXINT32,
+		INTYPE_BOOLEAN, INTYPE_FLOAT:
+		if err := fixed(4); err != nil {
+			return nil, 0, err
+		}
+		switch in_type {
+		case INTYPE_INT32:
+			return int32(data.u32(0)), 4, nil
+		case INTYPE_HEXINT32:
+			return fmt.Sprintf("%#x", data.u32(0)), 4, nil
+		case INTYPE_BOOLEAN:
+			return data.u32(0) != 0, 4, nil
+		case INTYPE_FLOAT:
+			return data.f32(0), 4, nil
+		}
+		return data.u32(0), 4, nil
+
+	case INTYPE_INT64, INTYPE_UINT64, INTYPE_HEXINT64, INTYPE_DOUBLE:
+		if err := fixed(8); err != nil {
+			return nil, 0, err
+		}
+		switch in_type {
+		case INTYPE_INT64:
+			return int64(data.u64(0)), 8, nil
+		case INTYPE_HEXINT64:
+			return fmt.Sprintf("%#x", data.u64(0)), 8, nil
+		case INTYPE_DOUBLE:
+			return data.f64(0), 8, nil
+		}
+		return data.u64(0), 8, nil
+
+	case INTYPE_POINTER:
+		if err := fixed(pointer_size); err != nil {
+			return nil, 0, err
+		}
+		return fmt.Sprintf("%#x", data.pointer(0, pointer_size)),
+			pointer_size, nil
+
+	case INTYPE_FILETIME:
+		if err := fixed(8); err != nil {
+			return nil, 0, err
+		}
+		return data.filetime(0), 8, nil
+
+	case INTYPE_SYSTEMTIME:
+		if err := fixed(16); err != nil {
+			return nil, 0, err
+		}
+		return data.systemtime(0), 16, nil
+
+	case INTYPE_GUID:
+		if err := fixed(16); err != nil {
+			return nil, 0, err
+		}
+		return data.guid(0), 16, nil
+
+	case INTYPE_SID:
+		value, size := data.sid(0)
+		if size == 0 {
+			return nil, 0, shortDataError
+		}
+		return value, size, nil
+
+	case INTYPE_BINARY:
+		if length < 0 {
+			return nil, 0, fmt.Errorf("Binary field without a length")
+		}
+		if err := fixed(length); err != nil {
+			return nil, 0, err
+		}
+		return fmt.Sprintf("%x", []byte(data[:length])), length, nil
+
+	// Counted types are prefixed by their size in bytes.
+	case INTYPE_COUNTEDSTRING, INTYPE_COUNTEDANSISTRING,
+		INTYPE_COUNTEDBINARY:
+		if err := fixed(2); err != nil {
+			return nil, 0, err
+		}
+		size := int(data.u16(0))
+		if err := fixed(2 + size); err != nil {
+			return nil, 0, err
+		}
+
+		switch in_type {
+		case INTYPE_COUNTEDSTRING:
+			return data.utf16(2, size/2), 2 + size, nil
+		case INTYPE_COUNTEDANSISTRING:
+			return string(data[2 : 2+size]), 2 + size, nil
+		}
+		return fmt.Sprintf("%x", []byte(data[2:2+size])), 2 + size, nil
+	}
+
+	return nil, 0, fmt.Errorf("Unsupported input type %d", in_type)
+}
+
+// Convert a decoded value to an integer (used for lengths and
+// counts which refer to other fields).
+func toInt(value interface{}) (int, bool) {
+	switch t := value.(type) {
+	case int8:
+		return int(t), true
+	case uint8:
+		return int(t), true
+	case int16:
+		return int(t), true
+	case uint16:
+		return int(t), true
+	case int32:
+		return int(t), true
+	case uint32:
+		return int(t), true
+	case int64:
+		return int(t), true
+	case uint64:
+		return int(t), true
+	}
+	return 0, false
+}
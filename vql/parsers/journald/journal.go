@@ -0,0 +1,479 @@
+package journald
+
+// A parser for the systemd journal file format.
+// https://systemd.io/JOURNAL_FILE_FORMAT/
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const (
+	HEADER_SIGNATURE = "LPKSHHRH"
+
+	HEADER_INCOMPATIBLE_COMPRESSED_XZ   = 1 << 0
+	HEADER_INCOMPATIBLE_COMPRESSED_LZ4  = 1 << 1
+	HEADER_INCOMPATIBLE_KEYED_HASH      = 1 << 2
+	HEADER_INCOMPATIBLE_COMPRESSED_ZSTD = 1 << 3
+	HEADER_INCOMPATIBLE_COMPACT         = 1 << 4
+
+	HEADER_INCOMPATIBLE_SUPPORTED = HEADER_INCOMPATIBLE_COMPRESSED_XZ |
+		HEADER_INCOMPATIBLE_COMPRESSED_LZ4 |
+		HEADER_INCOMPATIBLE_KEYED_HASH |
+		HEADER_INCOMPATIBLE_COMPRESSED_ZSTD |
+		HEADER_INCOMPATIBLE_COMPACT
+
+	OBJECT_COMPRESSED_XZ   = 1 << 0
+	OBJECT_COMPRESSED_LZ4  = 1 << 1
+	OBJECT_COMPRESSED_ZSTD = 1 << 2
+
+	OBJECT_UNUSED           = 0
+	OBJECT_DATA             = 1
+	OBJECT_FIELD            = 2
+	OBJECT_ENTRY            = 3
+	OBJECT_DATA_HASH_TABLE  = 4
+	OBJECT_FIELD_HASH_TABLE = 5
+	OBJECT_ENTRY_ARRAY      = 6
+	OBJECT_TAG              = 7
+
+	STATE_OFFLINE  = 0
+	STATE_ONLINE   = 1
+	STATE_ARCHIVED = 2
+
+	// The size of the original header. Newer versions append fields.
+	MIN_HEADER_SIZE = 208
+
+	OBJECT_HEADER_SIZE = 16
+
+	// Minimum sizes of the objects we parse.
+	MIN_DATA_OBJECT_SIZE         = 64
+	MIN_COMPACT_DATA_OBJECT_SIZE = 72
+	MIN_ENTRY_OBJECT_SIZE        = 64
+	MIN_ENTRY_ARRAY_OBJECT_SIZE  = 24
+
+	// Refuse to read or decompress objects larger than this.
+	MAX_OBJECT_SIZE = 64 * 1024 * 1024
+
+	// How many decoded data objects to cache per file.
+	DATA_CACHE_SIZE = 10000
+)
+
+var (
+	zstd_mu      sync.Mutex
+	zstd_decoder *zstd.Decoder
+)
+
+type Header struct {
+	CompatibleFlags    uint32
+	IncompatibleFlags  uint32
+	State              uint8
+	FileId             [16]byte
+	MachineId          [16]byte
+	TailEntryBootId    [16]byte
+	SeqnumId           [16]byte
+	HeaderSize         uint64
+	ArenaSize          uint64
+	NObjects           uint64
+	NEntries           uint64
+	TailEntrySeqnum    uint64
+	HeadEntrySeqnum    uint64
+	EntryArrayOffset   uint64
+	HeadEntryRealtime  uint64
+	TailEntryRealtime  uint64
+	TailEntryMonotonic uint64
+}
+
+func (self *Header) IsCompact() bool {
+	return self.IncompatibleFlags&HEADER_INCOMPATIBLE_COMPACT != 0
+}
+
+func (self *Header) StateString() string {
+	switch self.State {
+	case STATE_OFFLINE:
+		return "OFFLINE"
+	case STATE_ONLINE:
+		return "ONLINE"
+	case STATE_ARCHIVED:
+		return "ARCHIVED"
+	}
+	return fmt.Sprintf("UNKNOWN (%d)", self.State)
+}
+
+func (self *Header) Compression() []string {
+	result := []string{}
+	if self.IncompatibleFlags&HEADER_INCOMPATIBLE_COMPRESSED_XZ != 0 {
+		result = append(result, "XZ")
+	}
+	if self.IncompatibleFlags&HEADER_INCOMPATIBLE_COMPRESSED_LZ4 != 0 {
+		result = append(result, "LZ4")
+	}
+	if self.IncompatibleFlags&HEADER_INCOMPATIBLE_COMPRESSED_ZSTD != 0 {
+		result = append(result, "ZSTD")
+	}
+	return result
+}
+
+type Entry struct {
+	Offset    uint64
+	Seqnum    uint64
+	Realtime  uint64
+	Monotonic uint64
+	BootId    [16]byte
+
+	// Offsets of the data objects making up this entry.
+	Items []uint64
+}
+
+func (self *Entry) Timestamp() time.Time {
+	return time.UnixMicro(int64(self.Realtime)).UTC()
+}
+
+type JournalFile struct {
+	reader io.ReaderAt
+	Header *Header
+
+	// The end of the arena - no objects may extend past this.
+	end uint64
+
+	data_cache map[uint64]*dataField
+}
+
+type dataField struct {
+	name  string
+	value interface{}
+}
+
+func OpenJournalFile(reader io.ReaderAt) (*JournalFile, error) {
+	buf := make([]byte, MIN_HEADER_SIZE)
+	_, err := reader.ReadAt(buf, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(buf[:8]) != HEADER_SIGNATURE {
+		return nil, errors.New("Not a journal file: invalid signature")
+	}
+
+	header := &Header{
+		CompatibleFlags:    binary.LittleEndian.Uint32(buf[8:]),
+		IncompatibleFlags:  binary.LittleEndian.Uint32(buf[12:]),
+		State:              buf[16],
+		HeaderSize:         binary.LittleEndian.Uint64(buf[88:]),
+		ArenaSize:          binary.LittleEndian.Uint64(buf[96:]),
+		NObjects:           binary.LittleEndian.Uint64(buf[144:]),
+		NEntries:           binary.LittleEndian.Uint64(buf[152:]),
+		TailEntrySeqnum:    binary.LittleEndian.Uint64(buf[160:]),
+		HeadEntrySeqnum:    binary.LittleEndian.Uint64(buf[168:]),
+		EntryArrayOffset:   binary.LittleEndian.Uint64(buf[176:]),
+		HeadEntryRealtime:  binary.LittleEndian.Uint64(buf[184:]),
+		TailEntryRealtime:  binary.LittleEndian.Uint64(buf[192:]),
+		TailEntryMonotonic: binary.LittleEndian.Uint64(buf[200:]),
+	}
+	copy(header.FileId[:], buf[24:40])
+	copy(header.MachineId[:], buf[40:56])
+	copy(header.TailEntryBootId[:], buf[56:72])
+	copy(header.SeqnumId[:], buf[72:88])
+
+	unsupported := header.IncompatibleFlags & ^uint32(HEADER_INCOMPATIBLE_SUPPORTED)
+	if unsupported != 0 {
+		return nil, fmt.Errorf(
+			"Journal file uses unsupported features: %#x", unsupported)
+	}
+
+	if header.HeaderSize < MIN_HEADER_SIZE || header.HeaderSize%8 != 0 {
+		return nil, fmt.Errorf(
+			"Journal file corrupted: invalid header size %v", header.HeaderSize)
+	}
+
+	return &JournalFile{
+		reader:     reader,
+		Header:     header,
+		end:        header.HeaderSize + header.ArenaSize,
+		data_cache: make(map[uint64]*dataField),
+	}, nil
+}
+
+// Read the object at offset and verify it is of the expected type.
+func (self *JournalFile) readObject(
+	offset uint64, object_type uint8, min_size uint64) (uint8, []byte, error) {
+	if offset%8 != 0 || offset < self.Header.HeaderSize ||
+		offset+OBJECT_HEADER_SIZE > self.end {
+		return 0, nil, fmt.Errorf("Invalid object offset %#x", offset)
+	}
+
+	header := make([]byte, OBJECT_HEADER_SIZE)
+	_, err := self.reader.ReadAt(header, int64(offset))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	flags := header[1]
+	size := binary.LittleEndian.Uint64(header[8:])
+
+	if header[0] != object_type {
+		return 0, nil, fmt.Errorf(
+			"Object at %#x has type %v, expected %v", offset,
+			header[0], object_type)
+	}
+
+	if size < min_size || size > MAX_OBJECT_SIZE || offset+size > self.end {
+		return 0, nil, fmt.Errorf(
+			"Object at %#x has invalid size %v", offset, size)
+	}
+
+	buf := make([]byte, size)
+	_, err = self.reader.ReadAt(buf, int64(offset))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return flags, buf, nil
+}
+
+func (self *JournalFile) ReadEntry(offset uint64) (*Entry, error) {
+	_, buf, err := self.readObject(offset, OBJECT_ENTRY, MIN_ENTRY_OBJECT_SIZE)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{
+		Offset:    offset,
+		Seqnum:    binary.LittleEndian.Uint64(buf[16:]),
+		Realtime:  binary.LittleEndian.Uint64(buf[24:]),
+		Monotonic: binary.LittleEndian.Uint64(buf[32:]),
+	}
+	copy(entry.BootId[:], buf[40:56])
+
+	items := buf[MIN_ENTRY_OBJECT_SIZE:]
+	if self.Header.IsCompact() {
+		for i := 0; i+4 <= len(items); i += 4 {
+			entry.Items = append(entry.Items,
+				uint64(binary.LittleEndian.Uint32(items[i:])))
+		}
+	} else {
+		// Regular entries store the object offset and its hash.
+		for i := 0; i+16 <= len(items); i += 16 {
+			entry.Items = append(entry.Items,
+				binary.LittleEndian.Uint64(items[i:]))
+		}
+	}
+
+	return entry, nil
+}
+
+// Read the data object and split it into the field name and value.
+func (self *JournalFile) readData(offset uint64) (*dataField, error) {
+	cached, pres := self.data_cache[offset]
+	if pres {
+		return cached, nil
+	}
+
+	min_size := uint64(MIN_DATA_OBJECT_SIZE)
+	if self.Header.IsCompact() {
+		min_size = MIN_COMPACT_DATA_OBJECT_SIZE
+	}
+
+	flags, buf, err := self.readObject(offset, OBJECT_DATA, min_size)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := decompress(flags, buf[min_size:])
+	if err != nil {
+		return nil, fmt.Errorf("Data object at %#x: %w", offset, err)
+	}
+
+	result := &dataField{}
+	idx := bytes.IndexByte(payload, '=')
+	if idx < 0 {
+		result.name = string(payload)
+		result.value = ""
+	} else {
+		result.name = string(payload[:idx])
+		value := payload[idx+1:]
+
+		// Binary fields are returned as is.
+		if utf8.Valid(value) {
+			result.value = string(value)
+		} else {
+			result.value = value
+		}
+	}
+
+	if len(self.data_cache) > DATA_CACHE_SIZE {
+		self.data_cache = make(map[uint64]*dataField)
+	}
+	self.data_cache[offset] = result
+
+	return result, nil
+}
+
+func decompress(flags uint8, payload []byte) ([]byte, error) {
+	switch {
+	case flags&OBJECT_COMPRESSED_XZ != 0:
+		reader, err := xz.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		return readAllLimited(reader)
+
+	case flags&OBJECT_COMPRESSED_LZ4 != 0:
+		return decompressLZ4(payload, MAX_OBJECT_SIZE)
+
+	case flags&OBJECT_COMPRESSED_ZSTD != 0:
+		decoder, err := getZstdDecoder()
+		if err != nil {
+			return nil, err
+		}
+		return decoder.DecodeAll(payload, nil)
+	}
+
+	return payload, nil
+}
+
+func readAllLimited(reader io.Reader) ([]byte, error) {
+	result, err := io.ReadAll(io.LimitReader(reader, MAX_OBJECT_SIZE+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(result) > MAX_OBJECT_SIZE {
+		return nil, errors.New("Decompressed data too large")
+	}
+	return result, nil
+}
+
+func getZstdDecoder() (*zstd.Decoder, error) {
+	zstd_mu.Lock()
+	defer zstd_mu.Unlock()
+
+	if zstd_decoder == nil {
+		decoder, err := zstd.NewReader(nil,
+			zstd.WithDecoderMaxMemory(MAX_OBJECT_SIZE))
+		if err != nil {
+			return nil, err
+		}
+		zstd_decoder = decoder
+	}
+	return zstd_decoder, nil
+}
+
+// Decode all the fields in the entry.
+func (self *JournalFile) EntryData(entry *Entry) (*ordereddict.Dict, error) {
+	result := ordereddict.NewDict()
+	for _, offset := range entry.Items {
+		field, err := self.readData(offset)
+		if err != nil {
+			return result, err
+		}
+		result.Set(field.name, field.value)
+	}
+	return result, nil
+}
+
+// Decide if an entire entry array can be skipped based on its last
+// entry. Returning true skips the array.
+type SkipFunc func(last *Entry) bool
+
+// Walk the global entry array chain and call cb for each entry. The
+// chain is a linked list of arrays holding the offsets of all entries
+// in the file ordered by seqnum. Arrays may be preallocated so only
+// the first header.NEntries items are valid.
+func (self *JournalFile) Entries(ctx context.Context,
+	skip SkipFunc, cb func(entry *Entry) error) error {
+
+	item_size := uint64(8)
+	if self.Header.IsCompact() {
+		item_size = 4
+	}
+
+	remaining := self.Header.NEntries
+	offset := self.Header.EntryArrayOffset
+
+	for offset != 0 && remaining > 0 {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		_, buf, err := self.readObject(offset, OBJECT_ENTRY_ARRAY,
+			MIN_ENTRY_ARRAY_OBJECT_SIZE)
+		if err != nil {
+			return err
+		}
+
+		next := binary.LittleEndian.Uint64(buf[16:])
+		items := readEntryArrayItems(buf[MIN_ENTRY_ARRAY_OBJECT_SIZE:],
+			item_size, remaining)
+		remaining -= uint64(len(items))
+
+		// Arrays are always appended so the chain must move forward.
+		if next != 0 && next <= offset {
+			return fmt.Errorf("Journal file corrupted: entry array at %#x "+
+				"links back to %#x", offset, next)
+		}
+		offset = next
+
+		if len(items) == 0 {
+			continue
+		}
+
+		if skip != nil {
+			last, err := self.ReadEntry(items[len(items)-1])
+			if err == nil && skip(last) {
+				continue
+			}
+		}
+
+		for _, entry_offset := range items {
+			entry, err := self.ReadEntry(entry_offset)
+			if err != nil {
+				return err
+			}
+
+			err = cb(entry)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the valid items in the entry array. An item of 0 marks the
+// end of the used part of the array.
+func readEntryArrayItems(buf []byte, item_size, remaining uint64) []uint64 {
+	result := []uint64{}
+	for i := uint64(0); i+item_size <= uint64(len(buf)) &&
+		uint64(len(result)) < remaining; i += item_size {
+		var item uint64
+		if item_size == 4 {
+			item = uint64(binary.LittleEndian.Uint32(buf[i:]))
+		} else {
+			item = binary.LittleEndian.Uint64(buf[i:])
+		}
+
+		if item == 0 {
+			break
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+func formatId(id [16]byte) string {
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,381 @@
+package journald
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/suite"
+	"github.com/ulikunitz/xz"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/data"
+)
+
+const testHeaderSize = 272
+
+// Builds journal files for tests.
+type testJournal struct {
+	buf         []byte
+	compact     bool
+	compression uint8
+
+	file_id, seqnum_id [16]byte
+
+	data    map[string]uint64
+	entries []uint64
+
+	seqnum, head_seqnum uint64
+	head_realtime       uint64
+	tail_realtime       uint64
+	tail_monotonic      uint64
+	state               uint8
+}
+
+func newTestJournal(file_id, seqnum_id byte) *testJournal {
+	result := &testJournal{
+		buf:   make([]byte, testHeaderSize),
+		data:  make(map[string]uint64),
+		state: STATE_ARCHIVED,
+	}
+	result.file_id[0] = file_id
+	result.seqnum_id[0] = seqnum_id
+	return result
+}
+
+func (self *testJournal) appendObject(
+	object_type, flags uint8, payload []byte) uint64 {
+	offset := uint64(len(self.buf))
+
+	header := make([]byte, OBJECT_HEADER_SIZE)
+	header[0] = object_type
+	header[1] = flags
+	binary.LittleEndian.PutUint64(header[8:],
+		uint64(OBJECT_HEADER_SIZE+len(payload)))
+
+	self.buf = append(self.buf, header...)
+	self.buf = append(self.buf, payload...)
+	for len(self.buf)%8 != 0 {
+		self.buf = append(self.buf, 0)
+	}
+	return offset
+}
+
+func (self *testJournal) compress(data []byte) []byte {
+	switch self.compression {
+	case OBJECT_COMPRESSED_ZSTD:
+		encoder, _ := zstd.NewWriter(nil)
+		return encoder.EncodeAll(data, nil)
+
+	case OBJECT_COMPRESSED_XZ:
+		out := &bytes.Buffer{}
+		writer, _ := xz.NewWriter(out)
+		writer.Write(data)
+		writer.Close()
+		return out.Bytes()
+
+	case OBJECT_COMPRESSED_LZ4:
+		// A block made of a single literal run.
+		out := make([]byte, 8)
+		binary.LittleEndian.PutUint64(out, uint64(len(data)))
+		length := len(data)
+		if length < 15 {
+			return append(append(out, byte(length<<4)), data...)
+		}
+		out = append(out, 0xf0)
+		length -= 15
+		for ; length >= 255; length -= 255 {
+			out = append(out, 255)
+		}
+		return append(append(out, byte(length)), data...)
+	}
+	return data
+}
+
+func (self *testJournal) addData(field string) uint64 {
+	offset, pres := self.data[field]
+	if pres {
+		return offset
+	}
+
+	prefix := MIN_DATA_OBJECT_SIZE - OBJECT_HEADER_SIZE
+	if self.compact {
+		prefix = MIN_COMPACT_DATA_OBJECT_SIZE - OBJECT_HEADER_SIZE
+	}
+
+	payload := append(make([]byte, prefix), self.compress([]byte(field))...)
+	offset = self.appendObject(OBJECT_DATA, self.compression, payload)
+	self.data[field] = offset
+	return offset
+}
+
+func (self *testJournal) AddEntry(realtime, monotonic uint64,
+	boot_id byte, fields ...string) {
+	items := []uint64{}
+	for _, field := range fields {
+		items = append(items, self.addData(field))
+	}
+
+	self.seqnum++
+	if self.head_seqnum == 0 {
+		self.head_seqnum = self.seqnum
+		self.head_realtime = realtime
+	}
+	self.tail_realtime = realtime
+	self.tail_monotonic = monotonic
+
+	payload := make([]byte, MIN_ENTRY_OBJECT_SIZE-OBJECT_HEADER_SIZE)
+	binary.LittleEndian.PutUint64(payload[0:], self.seqnum)
+	binary.LittleEndian.PutUint64(payload[8:], realtime)
+	binary.LittleEndian.PutUint64(payload[16:], monotonic)
+	payload[24] = boot_id
+
+	for _, item := range items {
+		if self.compact {
+			payload = binary.LittleEndian.AppendUint32(payload, uint32(item))
+		} else {
+			payload = binary.LittleEndian.AppendUint64(payload, item)
+			payload = binary.LittleEndian.AppendUint64(payload, 0)
+		}
+	}
+
+	self.entries = append(self.entries,
+		self.appendObject(OBJECT_ENTRY, 0, payload))
+}
+
+// Write the entry array chain with arrays of the specified capacity
+// and return the file. The journal can be extended further after
+// this.
+func (self *testJournal) Bytes(capacity int) []byte {
+	saved := self.buf
+	self.buf = append([]byte{}, saved...)
+	defer func() {
+		self.buf = saved
+	}()
+
+	item_size := 8
+	if self.compact {
+		item_size = 4
+	}
+
+	offsets := []uint64{}
+	for start := 0; start < len(self.entries); start += capacity {
+		payload := make([]byte, 8+capacity*item_size)
+		for i := 0; i < capacity && start+i < len(self.entries); i++ {
+			item := self.entries[start+i]
+			if self.compact {
+				binary.LittleEndian.PutUint32(payload[8+i*4:], uint32(item))
+			} else {
+				binary.LittleEndian.PutUint64(payload[8+i*8:], item)
+			}
+		}
+		offsets = append(offsets,
+			self.appendObject(OBJECT_ENTRY_ARRAY, 0, payload))
+	}
+
+	result := self.buf
+	for i := 0; i+1 < len(offsets); i++ {
+		binary.LittleEndian.PutUint64(result[offsets[i]+16:], offsets[i+1])
+	}
+
+	copy(result, HEADER_SIGNATURE)
+	flags := uint32(0)
+	if self.compact {
+		flags |= HEADER_INCOMPATIBLE_COMPACT
+	}
+	switch self.compression {
+	case OBJECT_COMPRESSED_XZ:
+		flags |= HEADER_INCOMPATIBLE_COMPRESSED_XZ
+	case OBJECT_COMPRESSED_LZ4:
+		flags |= HEADER_INCOMPATIBLE_COMPRESSED_LZ4
+	case OBJECT_COMPRESSED_ZSTD:
+		flags |= HEADER_INCOMPATIBLE_COMPRESSED_ZSTD
+	}
+	binary.LittleEndian.PutUint32(result[12:], flags)
+	result[16] = self.state
+	copy(result[24:], self.file_id[:])
+	copy(result[72:], self.seqnum_id[:])
+	binary.LittleEndian.PutUint64(result[88:], testHeaderSize)
+	binary.LittleEndian.PutUint64(result[96:], uint64(len(result)-testHeaderSize))
+	binary.LittleEndian.PutUint64(result[152:], uint64(len(self.entries)))
+	binary.LittleEndian.PutUint64(result[160:], self.seqnum)
+	binary.LittleEndian.PutUint64(result[168:], self.head_seqnum)
+	if len(offsets) > 0 {
+		binary.LittleEndian.PutUint64(result[176:], offsets[0])
+	}
+	binary.LittleEndian.PutUint64(result[184:], self.head_realtime)
+	binary.LittleEndian.PutUint64(result[192:], self.tail_realtime)
+	binary.LittleEndian.PutUint64(result[200:], self.tail_monotonic)
+
+	return result
+}
+
+type JournalTestSuite struct {
+	suite.Suite
+}
+
+func (self *JournalTestSuite) parse(data []byte,
+	args *ordereddict.Dict) []*ordereddict.Dict {
+	ctx := context.Background()
+	scope := vql_subsystem.MakeScope()
+	scope.SetLogger(log.New(os.Stderr, "", 0))
+	defer scope.Close()
+
+	args.Set("filename", string(data)).Set("accessor", "data")
+
+	result := []*ordereddict.Dict{}
+	for row := range (JournalPlugin{}).Call(ctx, scope, args) {
+		result = append(result, row.(*ordereddict.Dict))
+	}
+	return result
+}
+
+func messages(rows []*ordereddict.Dict) []string {
+	result := []string{}
+	for _, row := range rows {
+		data, _ := row.Get("Data")
+		message, _ := data.(*ordereddict.Dict).Get("MESSAGE")
+		result = append(result, fmt.Sprintf("%v", message))
+	}
+	return result
+}
+
+func buildTestJournal(compact bool, compression uint8) []byte {
+	journal := newTestJournal(1, 1)
+	journal.compact = compact
+	journal.compression = compression
+
+	for i := 0; i < 7; i++ {
+		unit := "ssh.service"
+		if i%2 == 1 {
+			unit = "cron.service"
+		}
+		journal.AddEntry(uint64(1000000*(i+1)), uint64(i+1), 1,
+			fmt.Sprintf("MESSAGE=Message %d %s", i,
+				"with a long repeated payload payload payload payload"),
+			"_SYSTEMD_UNIT="+unit,
+			"_HOSTNAME=test")
+	}
+
+	// Small arrays exercise the entry array chain.
+	return journal.Bytes(3)
+}
+
+func (self *JournalTestSuite) TestFormats() {
+	for _, tc := range []struct {
+		name        string
+		compact     bool
+		compression uint8
+	}{
+		{"Regular", false, 0},
+		{"Compact", true, 0},
+		{"XZ", false, OBJECT_COMPRESSED_XZ},
+		{"LZ4", true, OBJECT_COMPRESSED_LZ4},
+		{"ZSTD", true, OBJECT_COMPRESSED_ZSTD},
+	} {
+		rows := self.parse(buildTestJournal(tc.compact, tc.compression),
+			ordereddict.NewDict())
+		assert.Equal(self.T(), 7, len(rows), tc.name)
+
+		data, _ := rows[6].Get("Data")
+		message, _ := data.(*ordereddict.Dict).Get("MESSAGE")
+		assert.Equal(self.T(),
+			"Message 6 with a long repeated payload payload payload payload",
+			message, tc.name)
+
+		timestamp, _ := rows[6].Get("Timestamp")
+		assert.Equal(self.T(), time.Unix(7, 0).UTC(), timestamp, tc.name)
+
+		seqnum, _ := rows[6].Get("Seqnum")
+		assert.Equal(self.T(), uint64(7), seqnum, tc.name)
+	}
+}
+
+func (self *JournalTestSuite) TestSeek() {
+	data := buildTestJournal(true, OBJECT_COMPRESSED_ZSTD)
+
+	rows := self.parse(data, ordereddict.NewDict().
+		Set("start_time", 4).
+		Set("end_time", 6))
+	assert.Equal(self.T(), []string{
+		"Message 3 with a long repeated payload payload payload payload",
+		"Message 4 with a long repeated payload payload payload payload",
+		"Message 5 with a long repeated payload payload payload payload",
+	}, messages(rows))
+
+	rows = self.parse(data, ordereddict.NewDict().
+		Set("boot_id", fmt.Sprintf("%032x", []byte{1, 0, 0, 0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0, 0, 0, 0})).
+		Set("start_monotonic", 6))
+	assert.Equal(self.T(), 2, len(rows))
+
+	rows = self.parse(data, ordereddict.NewDict().
+		Set("matches", ordereddict.NewDict().
+			Set("_SYSTEMD_UNIT", "cron.service")))
+	assert.Equal(self.T(), 3, len(rows))
+}
+
+// The realtime clock may step backwards so entries past the time
+// range do not end the search.
+func (self *JournalTestSuite) TestClockStep() {
+	journal := newTestJournal(1, 1)
+	for i, realtime := range []int{1, 9, 2, 3, 4} {
+		journal.AddEntry(uint64(1000000*realtime), uint64(i+1), 1,
+			fmt.Sprintf("MESSAGE=Message %d", realtime))
+	}
+	data := journal.Bytes(3)
+
+	rows := self.parse(data, ordereddict.NewDict().Set("end_time", 5))
+	assert.Equal(self.T(), []string{
+		"Message 1", "Message 2", "Message 3", "Message 4",
+	}, messages(rows))
+
+	rows = self.parse(data, ordereddict.NewDict().Set("start_time", 5))
+	assert.Equal(self.T(), []string{"Message 9"}, messages(rows))
+}
+
+func (self *JournalTestSuite) TestCorruption() {
+	// Not a journal file at all
+	_, err := OpenJournalFile(bytes.NewReader(make([]byte, 1024)))
+	assert.Error(self.T(), err)
+
+	data := buildTestJournal(false, 0)
+
+	// Unsupported features are detected.
+	bad := append([]byte{}, data...)
+	binary.LittleEndian.PutUint32(bad[12:], 1<<10)
+	_, err = OpenJournalFile(bytes.NewReader(bad))
+	assert.Error(self.T(), err)
+
+	// An entry array that links back on itself is detected.
+	bad = append([]byte{}, data...)
+	journal, err := OpenJournalFile(bytes.NewReader(bad))
+	assert.NoError(self.T(), err)
+
+	first := journal.Header.EntryArrayOffset
+	second := binary.LittleEndian.Uint64(bad[first+16:])
+	binary.LittleEndian.PutUint64(bad[second+16:], first)
+
+	journal, err = OpenJournalFile(bytes.NewReader(bad))
+	assert.NoError(self.T(), err)
+
+	count := 0
+	err = journal.Entries(context.Background(), nil, func(entry *Entry) error {
+		count++
+		return nil
+	})
+	assert.Error(self.T(), err)
+	assert.Equal(self.T(), 3, count)
+}
+
+func TestJournal(t *testing.T) {
+	suite.Run(t, &JournalTestSuite{})
+}
@@ -0,0 +1,264 @@
+package journald
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/functions"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+var (
+	stopIteration = errors.New("Stop")
+)
+
+type JournalPluginArgs struct {
+	Filenames      []*accessors.OSPath `vfilter:"required,field=filename,doc=A list of journal files to parse."`
+	Accessor       string              `vfilter:"optional,field=accessor,doc=The accessor to use."`
+	StartTime      vfilter.Any         `vfilter:"optional,field=start_time,doc=Only show entries logged after this time."`
+	EndTime        vfilter.Any         `vfilter:"optional,field=end_time,doc=Only show entries logged before this time."`
+	BootId         string              `vfilter:"optional,field=boot_id,doc=Only show entries from this boot."`
+	StartMonotonic uint64              `vfilter:"optional,field=start_monotonic,doc=Only show entries with a monotonic timestamp (in microseconds since boot) after this (requires boot_id)."`
+	EndMonotonic   uint64              `vfilter:"optional,field=end_monotonic,doc=Only show entries with a monotonic timestamp (in microseconds since boot) before this (requires boot_id)."`
+	Matches        *ordereddict.Dict   `vfilter:"optional,field=matches,doc=A dict of field names and values the entries must match (e.g. dict(_SYSTEMD_UNIT='ssh.service'))."`
+}
+
+// Select the entries to emit.
+type entryFilter struct {
+	start_realtime, end_realtime uint64
+	boot_id                      string
+
+	start_monotonic, end_monotonic uint64
+	matches                        *ordereddict.Dict
+}
+
+func newEntryFilter(ctx context.Context, scope vfilter.Scope,
+	arg *JournalPluginArgs) (*entryFilter, error) {
+	result := &entryFilter{
+		boot_id:         strings.ReplaceAll(strings.ToLower(arg.BootId), "-", ""),
+		start_monotonic: arg.StartMonotonic,
+		end_monotonic:   arg.EndMonotonic,
+		matches:         arg.Matches,
+	}
+
+	if (result.start_monotonic > 0 || result.end_monotonic > 0) &&
+		result.boot_id == "" {
+		return nil, errors.New(
+			"boot_id must be specified with monotonic timestamps")
+	}
+
+	if !utils.IsNil(arg.StartTime) {
+		start, err := functions.TimeFromAny(ctx, scope, arg.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		if !start.IsZero() {
+			result.start_realtime = uint64(start.UnixMicro())
+		}
+	}
+
+	if !utils.IsNil(arg.EndTime) {
+		end, err := functions.TimeFromAny(ctx, scope, arg.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		if !end.IsZero() {
+			result.end_realtime = uint64(end.UnixMicro())
+		}
+	}
+
+	return result, nil
+}
+
+// Entries are ordered by seqnum but the realtime clock may step
+// backwards so only the monotonic clock of a single boot can be used
+// to skip entire entry arrays.
+func (self *entryFilter) Skip(last *Entry) bool {
+	return self.start_monotonic > 0 &&
+		formatId(last.BootId) == self.boot_id &&
+		last.Monotonic < self.start_monotonic
+}
+
+func (self *entryFilter) Match(entry *Entry) bool {
+	if entry.Realtime < self.start_realtime {
+		return false
+	}
+
+	if self.end_realtime > 0 && entry.Realtime > self.end_realtime {
+		return false
+	}
+
+	if self.boot_id != "" {
+		if formatId(entry.BootId) != self.boot_id {
+			return false
+		}
+
+		if entry.Monotonic < self.start_monotonic {
+			return false
+		}
+
+		if self.end_monotonic > 0 && entry.Monotonic > self.end_monotonic {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (self *entryFilter) MatchData(data *ordereddict.Dict) bool {
+	if self.matches == nil {
+		return true
+	}
+
+	for _, k := range self.matches.Keys() {
+		expected, _ := self.matches.Get(k)
+		value, pres := data.Get(k)
+		if !pres || utils.ToString(value) != utils.ToString(expected) {
+			return false
+		}
+	}
+	return true
+}
+
+func entryToRow(entry *Entry, data *ordereddict.Dict) *ordereddict.Dict {
+	return ordereddict.NewDict().
+		Set("Offset", entry.Offset).
+		Set("Seqnum", entry.Seqnum).
+		Set("Timestamp", entry.Timestamp()).
+		Set("Monotonic", entry.Monotonic).
+		Set("BootId", formatId(entry.BootId)).
+		Set("Data", data)
+}
+
+// Warn about journal files which may not be consistent.
+func checkJournalState(scope vfilter.Scope, name string,
+	filename *accessors.OSPath, journal *JournalFile) {
+
+	// Journald renames files it detects as corrupted with a ~
+	// suffix.
+	if strings.HasSuffix(filename.Basename(), "~") {
+		scope.Log("%v: %v was marked as corrupted by journald",
+			name, filename)
+	}
+
+	if journal.Header.State == STATE_ONLINE {
+		scope.Log("%v: %v was not closed cleanly - it is either still "+
+			"being written or corrupted", name, filename)
+	}
+}
+
+func parseJournalFile(
+	ctx context.Context, scope vfilter.Scope,
+	filename *accessors.OSPath, accessor accessors.FileSystemAccessor,
+	filter *entryFilter, output_chan chan vfilter.Row) error {
+
+	fd, err := accessor.OpenWithOSPath(filename)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	journal, err := OpenJournalFile(utils.MakeReaderAtter(fd))
+	if err != nil {
+		return err
+	}
+
+	checkJournalState(scope, "parse_journal", filename, journal)
+
+	err = journal.Entries(ctx, filter.Skip, func(entry *Entry) error {
+		if !filter.Match(entry) {
+			return nil
+		}
+
+		data, err := journal.EntryData(entry)
+		if err != nil {
+			scope.Log("parse_journal: %v: Entry at %#x: %v",
+				filename, entry.Offset, err)
+		}
+
+		if !filter.MatchData(data) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return stopIteration
+		case output_chan <- entryToRow(entry, data):
+		}
+		return nil
+	})
+	if err == stopIteration {
+		return nil
+	}
+	return err
+}
+
+type JournalPlugin struct{}
+
+func (self JournalPlugin) Call(
+	ctx context.Context, scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("parse_journal", args)()
+
+		arg := &JournalPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("parse_journal: %v", err)
+			return
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("parse_journal: %s", err)
+			return
+		}
+
+		filter, err := newEntryFilter(ctx, scope, arg)
+		if err != nil {
+			scope.Log("parse_journal: %v", err)
+			return
+		}
+
+		accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+		if err != nil {
+			scope.Log("parse_journal: %v", err)
+			return
+		}
+
+		for _, filename := range arg.Filenames {
+			err := parseJournalFile(
+				ctx, scope, filename, accessor, filter, output_chan)
+			if err != nil {
+				scope.Log("parse_journal: %v: %v", filename, err)
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self JournalPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "parse_journal",
+		Doc:      "Parse a systemd journal file.",
+		ArgType:  type_map.AddType(scope, &JournalPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&JournalPlugin{})
+}
@@ -0,0 +1,29 @@
+package journald
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// Journald stores LZ4 compressed data as a little endian 64 bit
+// uncompressed size followed by a single raw LZ4 block.
+func decompressLZ4(data []byte, max_size uint64) ([]byte, error) {
+	if len(data) < 8 {
+		return nil, errors.New("Invalid LZ4 data")
+	}
+
+	size := binary.LittleEndian.Uint64(data)
+	if size > max_size {
+		return nil, errors.New("LZ4 data too large")
+	}
+
+	dst := make([]byte, size)
+	n, err := utils.DecodeLZ4Block(data[8:], dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return dst[:n], nil
+}
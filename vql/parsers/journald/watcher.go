@@ -0,0 +1,452 @@
+package journald
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/artifacts"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+var (
+	mu              sync.Mutex
+	gJournalService *JournalWatcherService
+)
+
+func GlobalJournalService(config_obj *config_proto.Config) *JournalWatcherService {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if gJournalService == nil {
+		gJournalService = NewJournalWatcherService(config_obj)
+	}
+	return gJournalService
+}
+
+// This service watches one or more active journal files and
+// multiplexes new entries to multiple readers. When journald rotates
+// the active file, the remaining entries are read from the archived
+// file before following the new active file.
+type JournalWatcherService struct {
+	mu sync.Mutex
+
+	config_obj    *config_proto.Config
+	registrations map[string][]*Handle
+
+	sleep_time time.Duration
+
+	monitor_count int
+}
+
+func NewJournalWatcherService(config_obj *config_proto.Config) *JournalWatcherService {
+	sleep_time := 3 * time.Second
+	if config_obj.Defaults != nil &&
+		config_obj.Defaults.WatchPluginFrequency > 0 {
+		sleep_time = time.Second * time.Duration(
+			config_obj.Defaults.WatchPluginFrequency)
+	}
+
+	return &JournalWatcherService{
+		sleep_time:    sleep_time,
+		config_obj:    config_obj,
+		registrations: make(map[string][]*Handle),
+	}
+}
+
+func (self *JournalWatcherService) Register(
+	filename *accessors.OSPath,
+	accessor string,
+	ctx context.Context,
+	scope vfilter.Scope,
+	output_chan chan vfilter.Row) func() {
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	subctx, cancel := context.WithCancel(ctx)
+
+	handle := &Handle{
+		ctx:         subctx,
+		output_chan: output_chan,
+		scope:       scope}
+
+	key := filename.String() + accessor
+	registration, pres := self.registrations[key]
+	if !pres {
+		registration = []*Handle{}
+		self.registrations[key] = registration
+
+		go self.StartMonitoring(scope, filename, accessor)
+	}
+
+	registration = append(registration, handle)
+	self.registrations[key] = registration
+
+	scope.Log("Registering journal watcher for %v", filename)
+
+	return cancel
+}
+
+// Monitor the filename for new entries and emit them to all
+// interested listeners. If no listeners exist we terminate.
+func (self *JournalWatcherService) StartMonitoring(
+	base_scope vfilter.Scope, filename *accessors.OSPath,
+	accessor_name string) {
+
+	defer utils.CheckForPanic("StartMonitoring")
+
+	manager, err := services.GetRepositoryManager(self.config_obj)
+	if err != nil {
+		return
+	}
+
+	// Build a new scope with totally different lifetime than the
+	// watching scope so we can outlast them. We still want things
+	// like ACL managers etc though.
+	builder := services.ScopeBuilderFromScope(base_scope)
+	scope := manager.BuildScope(builder)
+	defer scope.Close()
+
+	accessor, err := accessors.GetAccessor(accessor_name, scope)
+	if err != nil {
+		scope.Log("Registering journal watcher error: %v", err)
+		return
+	}
+
+	cursor := self.findTail(filename, accessor)
+	key := filename.String() + accessor_name
+	for {
+		self.mu.Lock()
+		registration, pres := self.registrations[key]
+		self.mu.Unlock()
+
+		// No more listeners left, we are done.
+		if !pres || len(registration) == 0 {
+			return
+		}
+
+		cursor = self.monitorOnce(filename, accessor_name, accessor, cursor)
+
+		time.Sleep(self.sleep_time)
+	}
+}
+
+// Start following after the last entry currently in the file.
+func (self *JournalWatcherService) findTail(
+	filename *accessors.OSPath,
+	accessor accessors.FileSystemAccessor) *Cursor {
+
+	cursor := &Cursor{}
+
+	header, err := readJournalHeader(filename, accessor)
+	if err != nil {
+		return cursor
+	}
+
+	cursor.file_id = header.FileId
+	cursor.seqnum_id = header.SeqnumId
+	cursor.seqnum = header.TailEntrySeqnum
+
+	return cursor
+}
+
+func readJournalHeader(filename *accessors.OSPath,
+	accessor accessors.FileSystemAccessor) (*Header, error) {
+	fd, err := accessor.OpenWithOSPath(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	journal, err := OpenJournalFile(utils.MakeReaderAtter(fd))
+	if err != nil {
+		return nil, err
+	}
+	return journal.Header, nil
+}
+
+func (self *JournalWatcherService) monitorOnce(
+	filename *accessors.OSPath,
+	accessor_name string,
+	accessor accessors.FileSystemAccessor,
+	cursor *Cursor) *Cursor {
+
+	self.mu.Lock()
+	defer func() {
+		self.monitor_count++
+		self.mu.Unlock()
+	}()
+
+	key := filename.String() + accessor_name
+	handles, pres := self.registrations[key]
+	if !pres {
+		return cursor
+	}
+
+	fd, err := accessor.OpenWithOSPath(filename)
+	if err != nil {
+		return cursor
+	}
+	defer fd.Close()
+
+	journal, err := OpenJournalFile(utils.MakeReaderAtter(fd))
+	if err != nil {
+		return cursor
+	}
+
+	// The active file was rotated since last time. Read the rest of
+	// the entries from the archived file before we switch over.
+	if journal.Header.FileId != cursor.file_id {
+		if cursor.file_id != [16]byte{} {
+			handles = self.drainArchivedJournal(
+				filename, accessor, key, cursor, handles)
+			if len(handles) == 0 {
+				return cursor
+			}
+		}
+
+		// Seqnums only continue across files in the same seqnum
+		// domain.
+		if journal.Header.SeqnumId != cursor.seqnum_id {
+			cursor.seqnum = 0
+		}
+		cursor.file_id = journal.Header.FileId
+		cursor.seqnum_id = journal.Header.SeqnumId
+	}
+
+	// Nothing to do - no new entries since last time.
+	if journal.Header.TailEntrySeqnum <= cursor.seqnum {
+		return cursor
+	}
+
+	self.readNewEntries(filename, journal, key, cursor, handles)
+	return cursor
+}
+
+// Find the archived file the active file was rotated into and read
+// the entries we have not seen yet.
+func (self *JournalWatcherService) drainArchivedJournal(
+	filename *accessors.OSPath,
+	accessor accessors.FileSystemAccessor,
+	key string, cursor *Cursor, handles []*Handle) []*Handle {
+
+	children, err := accessor.ReadDirWithOSPath(filename.Dirname())
+	if err != nil {
+		return handles
+	}
+
+	for _, child := range children {
+		name := child.Name()
+		if child.IsDir() || name == filename.Basename() ||
+			!strings.Contains(name, ".journal") {
+			continue
+		}
+
+		archived_filename := child.OSPath()
+		fd, err := accessor.OpenWithOSPath(archived_filename)
+		if err != nil {
+			continue
+		}
+
+		journal, err := OpenJournalFile(utils.MakeReaderAtter(fd))
+		if err != nil || journal.Header.FileId != cursor.file_id {
+			fd.Close()
+			continue
+		}
+
+		handles = self.readNewEntries(
+			archived_filename, journal, key, cursor, handles)
+		fd.Close()
+		return handles
+	}
+
+	logger := logging.GetLogger(self.config_obj, &logging.ClientComponent)
+	logger.Info("watch_journal: Unable to find rotated journal file for %v - some entries may be missed.",
+		filename)
+
+	return handles
+}
+
+// Send all entries after the cursor to the listeners.
+func (self *JournalWatcherService) readNewEntries(
+	filename *accessors.OSPath, journal *JournalFile,
+	key string, cursor *Cursor, handles []*Handle) []*Handle {
+
+	skip := func(last *Entry) bool {
+		return last.Seqnum <= cursor.seqnum
+	}
+
+	err := journal.Entries(context.Background(), skip, func(entry *Entry) error {
+		if entry.Seqnum <= cursor.seqnum {
+			return nil
+		}
+
+		data, err := journal.EntryData(entry)
+		if err != nil {
+			// The entry may still be written - try again next time.
+			return err
+		}
+
+		cursor.seqnum = entry.Seqnum
+
+		handles = self.distributeEntry(
+			entryToRow(entry, data), key, handles)
+
+		// No more listeners - we dont care any more.
+		if len(handles) == 0 {
+			return stopIteration
+		}
+		return nil
+	})
+
+	// Errors are expected while journald is writing the file.
+	if err != nil && err != stopIteration {
+		logger := logging.GetLogger(self.config_obj, &logging.ClientComponent)
+		logger.Debug("watch_journal: %v: %v", filename, err)
+	}
+
+	return handles
+}
+
+// Send the entry to all listeners.
+func (self *JournalWatcherService) distributeEntry(
+	event *ordereddict.Dict,
+	key string,
+	handles []*Handle) []*Handle {
+
+	new_handles := make([]*Handle, 0, len(handles))
+	for _, handle := range handles {
+		select {
+		case <-handle.ctx.Done():
+			// If context is done, drop the event.
+
+		case handle.output_chan <- event:
+			new_handles = append(new_handles, handle)
+		}
+	}
+
+	// Update the registrations - possibly omitting finished
+	// listeners.
+	if len(new_handles) == 0 {
+		delete(self.registrations, key)
+		return new_handles
+	}
+	self.registrations[key] = new_handles
+
+	return new_handles
+}
+
+// Track the last entry we sent. Entries are identified by their
+// seqnum within the seqnum domain of the journal files.
+type Cursor struct {
+	file_id   [16]byte
+	seqnum_id [16]byte
+	seqnum    uint64
+}
+
+// A handle is given for each interested party. We write the event on
+// to the output_chan unless the context is done. When all interested
+// parties are done we may destroy the monitoring go routine and remove
+// the registration.
+type Handle struct {
+	ctx         context.Context
+	output_chan chan vfilter.Row
+	scope       vfilter.Scope
+}
+
+type WatchJournalPluginArgs struct {
+	Filenames []*accessors.OSPath `vfilter:"required,field=filename,doc=A list of active journal files to watch."`
+	Accessor  string              `vfilter:"optional,field=accessor,doc=The accessor to use."`
+}
+
+type WatchJournalPlugin struct{}
+
+func (self WatchJournalPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer vql_subsystem.RegisterMonitor("watch_journal", args)()
+
+		arg := &WatchJournalPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("watch_journal: %v", err)
+			return
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("watch_journal: %v", err)
+			return
+		}
+
+		// This plugin needs to be running on clients which have no
+		// server config object.
+		client_config_obj, ok := artifacts.GetConfig(scope)
+		if !ok {
+			scope.Log("watch_journal: unable to get config")
+			return
+		}
+
+		config_obj := &config_proto.Config{Client: client_config_obj}
+
+		event_channel := make(chan vfilter.Row)
+
+		// Register the output channel as a listener to the
+		// global event.
+		for _, filename := range arg.Filenames {
+			cancel := GlobalJournalService(config_obj).Register(
+				filename, arg.Accessor, ctx, scope,
+				event_channel)
+
+			defer cancel()
+		}
+
+		// Wait until the query is complete.
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case event := <-event_channel:
+				select {
+				case <-ctx.Done():
+					return
+
+				case output_chan <- event:
+				}
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self WatchJournalPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "watch_journal",
+		Doc:      "Watch active systemd journal files and stream new entries from them.",
+		ArgType:  type_map.AddType(scope, &WatchJournalPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&WatchJournalPlugin{})
+}
@@ -0,0 +1,187 @@
+package journald
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
+	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+	"www.velocidex.com/golang/vfilter"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+type JournalWatcherTestSuite struct {
+	test_utils.TestSuite
+
+	mu          sync.Mutex
+	result      []*ordereddict.Dict
+	output_chan chan vfilter.Row
+	scope       vfilter.Scope
+	temp_dir    string
+	filename    *accessors.OSPath
+	wg          sync.WaitGroup
+	cancel      func()
+}
+
+func (self *JournalWatcherTestSuite) SetupTest() {
+	self.TestSuite.SetupTest()
+
+	// Change the normal frequency to very long so it does not
+	// interfere with our test.
+	self.ConfigObj.Defaults = &config_proto.Defaults{
+		WatchPluginFrequency: 10000,
+	}
+
+	self.output_chan = make(chan vfilter.Row)
+
+	ctx, cancel := context.WithCancel(self.Ctx)
+	self.cancel = cancel
+
+	self.wg.Add(1)
+	go func() {
+		defer self.wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case item := <-self.output_chan:
+				self.mu.Lock()
+				self.result = append(self.result, item.(*ordereddict.Dict))
+				self.mu.Unlock()
+			}
+		}
+	}()
+
+	builder := services.ScopeBuilder{
+		Config:     self.ConfigObj,
+		ACLManager: acl_managers.NullACLManager{},
+		Logger: logging.NewPlainLogger(
+			self.ConfigObj, &logging.FrontendComponent),
+		Env: ordereddict.NewDict(),
+	}
+
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	self.scope = manager.BuildScope(builder)
+
+	self.temp_dir, err = os.MkdirTemp("", "journal")
+	assert.NoError(self.T(), err)
+
+	self.filename, err = accessors.NewGenericOSPath(
+		filepath.Join(self.temp_dir, "system.journal"))
+	assert.NoError(self.T(), err)
+}
+
+func (self *JournalWatcherTestSuite) TearDownTest() {
+	self.cancel()
+	self.wg.Wait()
+	os.RemoveAll(self.temp_dir)
+}
+
+func (self *JournalWatcherTestSuite) writeFile(name string, data []byte) {
+	err := os.WriteFile(filepath.Join(self.temp_dir, name), data, 0600)
+	assert.NoError(self.T(), err)
+}
+
+func (self *JournalWatcherTestSuite) getMessages() []string {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return messages(self.result)
+}
+
+func addMessages(journal *testJournal, messages ...string) {
+	for _, message := range messages {
+		journal.AddEntry(uint64(journal.seqnum+1)*1000000,
+			journal.seqnum+1, 1, "MESSAGE="+message)
+	}
+}
+
+func (self *JournalWatcherTestSuite) TestJournalRotation() {
+	service := NewJournalWatcherService(self.ConfigObj)
+
+	accessor, err := accessors.GetAccessor("file", self.scope)
+	assert.NoError(self.T(), err)
+
+	active := newTestJournal(1, 1)
+	active.state = STATE_ONLINE
+	addMessages(active, "Old 1", "Old 2")
+	self.writeFile("system.journal", active.Bytes(2))
+
+	closer := service.Register(self.filename, "file",
+		context.Background(), self.scope, self.output_chan)
+	defer closer()
+
+	// Registering a watcher will scan the file once. We need to wait
+	// until it is over before we start the test.
+	vtesting.WaitUntil(time.Second, self.T(), func() bool {
+		service.mu.Lock()
+		defer service.mu.Unlock()
+
+		return service.monitor_count > 0
+	})
+
+	// We start following from the end of the file.
+	cursor := service.findTail(self.filename, accessor)
+	assert.Equal(self.T(), uint64(2), cursor.seqnum)
+
+	cursor = service.monitorOnce(self.filename, "file", accessor, cursor)
+	assert.Equal(self.T(), 0, len(self.getMessages()))
+
+	// New entries are written to the active file.
+	addMessages(active, "New 1", "New 2")
+	self.writeFile("system.journal", active.Bytes(2))
+
+	cursor = service.monitorOnce(self.filename, "file", accessor, cursor)
+	assert.Equal(self.T(), uint64(4), cursor.seqnum)
+
+	vtesting.WaitUntil(time.Second, self.T(), func() bool {
+		return len(self.getMessages()) == 2
+	})
+
+	// Now journald writes one more entry and rotates the file. The
+	// new active file continues the seqnums.
+	addMessages(active, "Before rotation")
+	active.state = STATE_ARCHIVED
+	self.writeFile(fmt.Sprintf("system@%032x-%016x-%016x.journal",
+		active.seqnum_id, 1, 1000000), active.Bytes(2))
+
+	rotated := newTestJournal(2, 1)
+	rotated.state = STATE_ONLINE
+	rotated.seqnum = active.seqnum
+	addMessages(rotated, "After rotation 1", "After rotation 2")
+	self.writeFile("system.journal", rotated.Bytes(2))
+
+	cursor = service.monitorOnce(self.filename, "file", accessor, cursor)
+	assert.Equal(self.T(), uint64(7), cursor.seqnum)
+	assert.Equal(self.T(), rotated.file_id, cursor.file_id)
+
+	vtesting.WaitUntil(time.Second, self.T(), func() bool {
+		return len(self.getMessages()) == 5
+	})
+
+	assert.Equal(self.T(), []string{
+		"New 1", "New 2", "Before rotation",
+		"After rotation 1", "After rotation 2",
+	}, self.getMessages())
+}
+
+func TestJournalWatcher(t *testing.T) {
+	suite.Run(t, &JournalWatcherTestSuite{})
+}
@@ -16,13 +16,15 @@ import (
 	"www.velocidex.com/golang/velociraptor/vql"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/velociraptor/vql/filesystem"
+	leveldb_parser "www.velocidex.com/golang/velociraptor/vql/parsers/leveldb"
 	vfilter "www.velocidex.com/golang/vfilter"
 	"www.velocidex.com/golang/vfilter/arg_parser"
 )
 
 type LevelDBPluginArgs struct {
-	Filename *accessors.OSPath `vfilter:"optional,field=file, doc=The path to the leveldb file."`
-	Accessor string            `vfilter:"optional,field=accessor,doc=The accessor to use."`
+	Filename   *accessors.OSPath `vfilter:"optional,field=file, doc=The path to the leveldb file."`
+	Accessor   string            `vfilter:"optional,field=accessor,doc=The accessor to use."`
+	AllRecords bool              `vfilter:"optional,field=all_records,doc=Parse the log and table files directly to include deleted and superseded records."`
 }
 
 type LevelDBPlugin struct{}
@@ -55,6 +57,11 @@ func (self LevelDBPlugin) Call(
 			return
 		}
 
+		if arg.AllRecords {
+			readAllRecords(ctx, scope, arg, output_chan)
+			return
+		}
+
 		db, err := getLevelDBHandle(ctx, scope, arg.Accessor, arg.Filename)
 		if err != nil {
 			return
@@ -91,6 +98,34 @@ func (self LevelDBPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *
 	}
 }
 
+// Emit every record still present in the log and table files, with
+// its sequence number and state.
+func readAllRecords(
+	ctx context.Context, scope vfilter.Scope,
+	arg *LevelDBPluginArgs, output_chan chan vfilter.Row) {
+
+	accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+	if err != nil {
+		scope.Log("leveldb: %v", err)
+		return
+	}
+
+	records, err := leveldb_parser.ReadDatabase(
+		ctx, accessor, arg.Filename, scope.Log)
+	if err != nil {
+		scope.Log("leveldb: %v: %v", arg.Filename, err)
+		return
+	}
+
+	for _, record := range records {
+		select {
+		case <-ctx.Done():
+			return
+		case output_chan <- record.ToDict():
+		}
+	}
+}
+
 func getLevelDBHandle(
 	ctx context.Context, scope vfilter.Scope,
 	accessor string, filename *accessors.OSPath) (
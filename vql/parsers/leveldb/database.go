@@ -0,0 +1,187 @@
+package leveldb
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// The state of a record relative to the other records with the same
+// key.
+const (
+	// The newest value of the key.
+	STATE_LIVE = "Live"
+
+	// An older value of the key that was overwritten.
+	STATE_SUPERSEDED = "Superseded"
+
+	// A value that was subsequently deleted.
+	STATE_DELETED = "Deleted"
+
+	// The deletion marker itself.
+	STATE_TOMBSTONE = "Tombstone"
+)
+
+type Record struct {
+	Key   []byte
+	Value []byte
+	Seq   uint64
+	Type  int
+	State string
+
+	// The file and offset (of the write batch or block) the record
+	// was found in.
+	File   string
+	Offset int64
+}
+
+func (self *Record) ToDict() *ordereddict.Dict {
+	return ordereddict.NewDict().
+		Set("Key", string(self.Key)).
+		Set("Value", string(self.Value)).
+		Set("Seq", self.Seq).
+		Set("State", self.State).
+		Set("File", self.File).
+		Set("Offset", self.Offset)
+}
+
+type recordKey struct {
+	key         string
+	seq         uint64
+	record_type int
+}
+
+// Read the records from all the log and table files in the database
+// directory, including deleted and overwritten records that have not
+// been compacted away yet. The records are sorted by key, newest
+// first.
+func ReadDatabase(ctx context.Context,
+	accessor accessors.FileSystemAccessor, dirname *accessors.OSPath,
+	log func(format string, args ...interface{})) ([]*Record, error) {
+
+	files, err := accessor.ReadDirWithOSPath(dirname)
+	if err != nil {
+		return nil, err
+	}
+
+	// Obsolete files may still be present and contain records that
+	// were compacted away.
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name() < files[j].Name()
+	})
+
+	seen := make(map[recordKey]bool)
+	result := []*Record{}
+
+	add := func(record *Record) {
+		key := recordKey{
+			key:         string(record.Key),
+			seq:         record.Seq,
+			record_type: record.Type,
+		}
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, record)
+		}
+	}
+
+	for _, file := range files {
+		select {
+		case <-ctx.Done():
+			return result, nil
+		default:
+		}
+
+		name := file.Name()
+		is_log := strings.HasSuffix(name, ".log")
+		is_table := strings.HasSuffix(name, ".ldb") || strings.HasSuffix(name, ".sst")
+		if !is_log && !is_table {
+			continue
+		}
+
+		fd, err := accessor.OpenWithOSPath(file.OSPath())
+		if err != nil {
+			log("leveldb: %v: %v", file.OSPath(), err)
+			continue
+		}
+		reader := utils.MakeReaderAtter(fd)
+
+		if is_log {
+			err = ParseLog(reader, file.Size(),
+				func(offset int64, batch []byte) error {
+					return ParseBatch(batch, func(
+						seq uint64, record_type int, key, value []byte) error {
+						add(&Record{
+							Key:    key,
+							Value:  value,
+							Seq:    seq,
+							Type:   record_type,
+							File:   name,
+							Offset: offset,
+						})
+						return nil
+					})
+				})
+		} else {
+			err = ParseTable(reader, file.Size(), func(
+				offset int64, seq uint64, record_type int, key, value []byte) error {
+				add(&Record{
+					Key:    key,
+					Value:  value,
+					Seq:    seq,
+					Type:   record_type,
+					File:   name,
+					Offset: offset,
+				})
+				return nil
+			})
+		}
+		fd.Close()
+
+		if err != nil {
+			log("leveldb: %v: %v", file.OSPath(), err)
+		}
+	}
+
+	SetStates(result)
+	return result, nil
+}
+
+// Sort the records by key, newest first, and work out which are
+// live.
+func SetStates(records []*Record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		cmp := bytes.Compare(records[i].Key, records[j].Key)
+		if cmp == 0 {
+			return records[i].Seq > records[j].Seq
+		}
+		return cmp < 0
+	})
+
+	for i, record := range records {
+		// The newer record for the same key.
+		var newer *Record
+		if i > 0 && bytes.Equal(records[i-1].Key, record.Key) {
+			newer = records[i-1]
+		}
+
+		switch {
+		case record.Type == RECORD_TYPE_DELETION:
+			record.State = STATE_TOMBSTONE
+
+		case newer == nil:
+			record.State = STATE_LIVE
+
+		case newer.Type == RECORD_TYPE_DELETION:
+			record.State = STATE_DELETED
+
+		default:
+			record.State = STATE_SUPERSEDED
+		}
+	}
+}
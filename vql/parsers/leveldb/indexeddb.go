@@ -0,0 +1,356 @@
+package leveldb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf16"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/klauspost/compress/snappy"
+)
+
+// Chromium stores IndexedDB databases in LevelDB with keys prefixed
+// by the database, object store and index ids
+// (content/browser/indexed_db/indexed_db_leveldb_coding.cc)
+const (
+	// Global metadata type for database names.
+	IDB_DATABASE_NAME = 201
+
+	// Database metadata type for object store metadata.
+	IDB_OBJECT_STORE_META_DATA = 50
+	IDB_OBJECT_STORE_NAME      = 0
+
+	// The index id of object store records.
+	IDB_OBJECT_STORE_DATA_INDEX = 1
+
+	// IDB key types
+	IDB_KEY_NULL   = 0
+	IDB_KEY_STRING = 1
+	IDB_KEY_DATE   = 2
+	IDB_KEY_NUMBER = 3
+	IDB_KEY_ARRAY  = 4
+	IDB_KEY_MIN    = 5
+	IDB_KEY_BINARY = 6
+
+	// Large values are wrapped by Blink
+	// (third_party/blink/renderer/modules/indexeddb/idb_value_wrapping.cc)
+	IDB_WRAPPER_TAG            = 0x11
+	IDB_REPLACE_WITH_BLOB      = 1
+	IDB_COMPRESSED_WITH_SNAPPY = 2
+
+	// Blink's envelope may contain a trailer offset
+	BLINK_TRAILER_OFFSET_TAG  = 0xFE
+	BLINK_TRAILER_OFFSET_SIZE = 12
+
+	MAX_IDB_KEY_DEPTH = 20
+)
+
+type keyPrefix struct {
+	database_id     uint64
+	object_store_id uint64
+	index_id        uint64
+}
+
+// The first byte encodes the lengths of the ids which follow it as
+// little endian integers.
+func decodeKeyPrefix(data []byte) (*keyPrefix, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, truncatedError
+	}
+
+	lengths := []int{
+		int(data[0]>>5) + 1,
+		int(data[0]>>2&0x7) + 1,
+		int(data[0]&0x3) + 1,
+	}
+	data = data[1:]
+
+	ids := []uint64{}
+	for _, length := range lengths {
+		if len(data) < length {
+			return nil, nil, truncatedError
+		}
+
+		var id uint64
+		for i := length - 1; i >= 0; i-- {
+			id = id<<8 | uint64(data[i])
+		}
+		ids = append(ids, id)
+		data = data[length:]
+	}
+
+	return &keyPrefix{
+		database_id:     ids[0],
+		object_store_id: ids[1],
+		index_id:        ids[2],
+	}, data, nil
+}
+
+func decodeUTF16BE(data []byte) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = binary.BigEndian.Uint16(data[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// A varint length (in UTF-16 code units) followed by the string.
+func decodeStringWithLength(data []byte) (string, []byte, error) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n)/2 < length {
+		return "", nil, truncatedError
+	}
+
+	end := n + int(length)*2
+	return decodeUTF16BE(data[n:end]), data[end:], nil
+}
+
+func decodeDouble(data []byte) (float64, []byte, error) {
+	if len(data) < 8 {
+		return 0, nil, truncatedError
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(data)), data[8:], nil
+}
+
+// Decode an encoded IDBKey into a value.
+func DecodeIDBKey(data []byte) (interface{}, []byte, error) {
+	return decodeIDBKey(data, 0)
+}
+
+func decodeIDBKey(data []byte, depth int) (interface{}, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, truncatedError
+	}
+
+	if depth > MAX_IDB_KEY_DEPTH {
+		return nil, nil, errors.New("IDB key nested too deeply")
+	}
+
+	key_type := data[0]
+	data = data[1:]
+
+	switch key_type {
+	case IDB_KEY_NULL, IDB_KEY_MIN:
+		return nil, data, nil
+
+	case IDB_KEY_STRING:
+		return decodeStringWithLength(data)
+
+	case IDB_KEY_DATE:
+		value, data, err := decodeDouble(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return time.UnixMilli(int64(value)).UTC(), data, nil
+
+	case IDB_KEY_NUMBER:
+		return decodeDouble(data)
+
+	case IDB_KEY_ARRAY:
+		length, n := binary.Uvarint(data)
+		if n <= 0 || length > uint64(len(data)) {
+			return nil, nil, truncatedError
+		}
+		data = data[n:]
+
+		result := []interface{}{}
+		for i := uint64(0); i < length; i++ {
+			var item interface{}
+			var err error
+
+			item, data, err = decodeIDBKey(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			result = append(result, item)
+		}
+		return result, data, nil
+
+	case IDB_KEY_BINARY:
+		value, n := readLengthPrefixed(data)
+		if n == 0 {
+			return nil, nil, truncatedError
+		}
+		return string(value), data[n:], nil
+	}
+
+	return nil, nil, fmt.Errorf("Unsupported IDB key type %v", key_type)
+}
+
+// Decode an object store value. Values are prefixed by a version and
+// serialized by Blink and V8.
+func DecodeIDBValue(data []byte) (interface{}, error) {
+	_, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, truncatedError
+	}
+	data = data[n:]
+
+	if len(data) >= 3 && data[0] == V8_VERSION && data[1] == IDB_WRAPPER_TAG {
+		switch data[2] {
+		case IDB_REPLACE_WITH_BLOB:
+			// The value is stored in an external blob file.
+			size, n := binary.Uvarint(data[3:])
+			if n <= 0 {
+				return nil, truncatedError
+			}
+			index, m := binary.Uvarint(data[3+n:])
+			if m <= 0 {
+				return nil, truncatedError
+			}
+			return ordereddict.NewDict().
+				Set("BlobSize", size).
+				Set("BlobIndex", index), nil
+
+		case IDB_COMPRESSED_WITH_SNAPPY:
+			decompressed, err := snappy.Decode(nil, data[3:])
+			if err != nil {
+				return nil, err
+			}
+			data = decompressed
+		}
+	}
+
+	// Skip Blink's envelope up to the V8 version header.
+	version_offset := 0
+envelope:
+	for offset := 0; offset < len(data); {
+		switch data[offset] {
+		case V8_VERSION:
+			version_offset = offset
+			_, n := binary.Uvarint(data[offset+1:])
+			if n <= 0 {
+				return nil, truncatedError
+			}
+			offset += n + 1
+
+		case BLINK_TRAILER_OFFSET_TAG:
+			offset += BLINK_TRAILER_OFFSET_SIZE + 1
+
+		default:
+			break envelope
+		}
+	}
+
+	if version_offset >= len(data) {
+		return nil, truncatedError
+	}
+
+	return DecodeV8Value(data[version_offset:])
+}
+
+type IndexedDBObjectStore struct {
+	Database    string
+	Origin      string
+	ObjectStore string
+}
+
+// Resolve the database and object store names from the metadata
+// records.
+func GetObjectStores(records []*Record) map[keyPrefix]*IndexedDBObjectStore {
+	databases := make(map[uint64]*IndexedDBObjectStore)
+	result := make(map[keyPrefix]*IndexedDBObjectStore)
+
+	for _, record := range records {
+		if record.State != STATE_LIVE {
+			continue
+		}
+
+		prefix, rest, err := decodeKeyPrefix(record.Key)
+		if err != nil || len(rest) == 0 ||
+			prefix.database_id != 0 || rest[0] != IDB_DATABASE_NAME {
+			continue
+		}
+
+		origin, rest, err := decodeStringWithLength(rest[1:])
+		if err != nil {
+			continue
+		}
+
+		name, _, err := decodeStringWithLength(rest)
+		if err != nil {
+			continue
+		}
+
+		id, n := binary.Uvarint(record.Value)
+		if n <= 0 {
+			continue
+		}
+
+		databases[id] = &IndexedDBObjectStore{Database: name, Origin: origin}
+	}
+
+	for _, record := range records {
+		if record.State != STATE_LIVE {
+			continue
+		}
+
+		prefix, rest, err := decodeKeyPrefix(record.Key)
+		if err != nil || len(rest) == 0 ||
+			prefix.object_store_id != 0 ||
+			rest[0] != IDB_OBJECT_STORE_META_DATA {
+			continue
+		}
+
+		database, pres := databases[prefix.database_id]
+		if !pres {
+			continue
+		}
+
+		object_store_id, n := binary.Uvarint(rest[1:])
+		if n <= 0 || 1+n >= len(rest) || rest[1+n] != IDB_OBJECT_STORE_NAME {
+			continue
+		}
+
+		result[keyPrefix{
+			database_id:     prefix.database_id,
+			object_store_id: object_store_id,
+			index_id:        IDB_OBJECT_STORE_DATA_INDEX,
+		}] = &IndexedDBObjectStore{
+			Database:    database.Database,
+			Origin:      database.Origin,
+			ObjectStore: decodeUTF16BE(record.Value),
+		}
+	}
+
+	return result
+}
+
+// Decode the object store records.
+func ParseIndexedDB(records []*Record,
+	cb func(object_store *IndexedDBObjectStore,
+		key interface{}, value interface{}, record *Record)) {
+	object_stores := GetObjectStores(records)
+
+	for _, record := range records {
+		prefix, rest, err := decodeKeyPrefix(record.Key)
+		if err != nil {
+			continue
+		}
+
+		object_store, pres := object_stores[*prefix]
+		if !pres {
+			continue
+		}
+
+		key, _, err := DecodeIDBKey(rest)
+		if err != nil {
+			key = string(rest)
+		}
+
+		var value interface{}
+		if record.Type == RECORD_TYPE_VALUE {
+			value, err = DecodeIDBValue(record.Value)
+			if err != nil {
+				value = ordereddict.NewDict().
+					Set("Error", err.Error()).
+					Set("Data", string(record.Value))
+			}
+		}
+
+		cb(object_store, key, value, record)
+	}
+}
@@ -0,0 +1,335 @@
+package leveldb
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/klauspost/compress/snappy"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/json"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+type testEntry struct {
+	key         string
+	value       string
+	seq         uint64
+	record_type int
+}
+
+func maskCRC(crc uint32) uint32 {
+	return (crc>>15 | crc<<17) + CRC_MASK_DELTA
+}
+
+func buildBatch(entries ...testEntry) []byte {
+	result := binary.LittleEndian.AppendUint64(nil, entries[0].seq)
+	result = binary.LittleEndian.AppendUint32(result, uint32(len(entries)))
+	for _, e := range entries {
+		result = append(result, byte(e.record_type))
+		result = binary.AppendUvarint(result, uint64(len(e.key)))
+		result = append(result, e.key...)
+		if e.record_type == RECORD_TYPE_VALUE {
+			result = binary.AppendUvarint(result, uint64(len(e.value)))
+			result = append(result, e.value...)
+		}
+	}
+	return result
+}
+
+// Append a batch to the log, fragmenting it across blocks.
+func appendLogRecord(log []byte, payload []byte) []byte {
+	first := true
+	for {
+		leftover := LOG_BLOCK_SIZE - len(log)%LOG_BLOCK_SIZE
+		if leftover < LOG_HEADER_SIZE {
+			log = append(log, make([]byte, leftover)...)
+			continue
+		}
+
+		fragment := payload
+		if len(fragment) > leftover-LOG_HEADER_SIZE {
+			fragment = fragment[:leftover-LOG_HEADER_SIZE]
+		}
+		payload = payload[len(fragment):]
+		last := len(payload) == 0
+
+		record_type := byte(LOG_RECORD_MIDDLE)
+		switch {
+		case first && last:
+			record_type = LOG_RECORD_FULL
+		case first:
+			record_type = LOG_RECORD_FIRST
+		case last:
+			record_type = LOG_RECORD_LAST
+		}
+
+		crc := crc32.Checksum(append([]byte{record_type}, fragment...), crc32c)
+		log = binary.LittleEndian.AppendUint32(log, maskCRC(crc))
+		log = binary.LittleEndian.AppendUint16(log, uint16(len(fragment)))
+		log = append(log, record_type)
+		log = append(log, fragment...)
+
+		if last {
+			return log
+		}
+		first = false
+	}
+}
+
+// A block with a single restart point so all keys after the first
+// are prefix compressed.
+func buildBlock(keys, values [][]byte) []byte {
+	result := []byte{}
+	var last []byte
+	for i, key := range keys {
+		shared := 0
+		for shared < len(last) && shared < len(key) && last[shared] == key[shared] {
+			shared++
+		}
+		result = binary.AppendUvarint(result, uint64(shared))
+		result = binary.AppendUvarint(result, uint64(len(key)-shared))
+		result = binary.AppendUvarint(result, uint64(len(values[i])))
+		result = append(result, key[shared:]...)
+		result = append(result, values[i]...)
+		last = key
+	}
+	result = binary.LittleEndian.AppendUint32(result, 0)
+	return binary.LittleEndian.AppendUint32(result, 1)
+}
+
+func appendBlock(file []byte, block []byte, compress bool) ([]byte, []byte) {
+	handle := binary.AppendUvarint(nil, uint64(len(file)))
+	compression := byte(BLOCK_NO_COMPRESSION)
+	if compress {
+		block = snappy.Encode(nil, block)
+		compression = BLOCK_SNAPPY_COMPRESSION
+	}
+	handle = binary.AppendUvarint(handle, uint64(len(block)))
+
+	file = append(file, block...)
+	file = append(file, compression, 0, 0, 0, 0)
+	return file, handle
+}
+
+func internalKey(e testEntry) []byte {
+	return binary.LittleEndian.AppendUint64([]byte(e.key),
+		e.seq<<8|uint64(e.record_type))
+}
+
+func buildTable(entries ...testEntry) []byte {
+	file := []byte{}
+	index_keys := [][]byte{}
+	index_values := [][]byte{}
+
+	// One block per entry, alternating compression.
+	for i, e := range entries {
+		var handle []byte
+		file, handle = appendBlock(file, buildBlock(
+			[][]byte{internalKey(e)}, [][]byte{[]byte(e.value)}), i%2 == 0)
+		index_keys = append(index_keys, internalKey(e))
+		index_values = append(index_values, handle)
+	}
+
+	file, metaindex := appendBlock(file, buildBlock(nil, nil), false)
+	file, index := appendBlock(file, buildBlock(index_keys, index_values), true)
+
+	footer := append(metaindex, index...)
+	footer = append(footer, make([]byte, 40-len(footer))...)
+	footer = binary.LittleEndian.AppendUint64(footer, TABLE_MAGIC)
+	return append(file, footer...)
+}
+
+func TestParseBlock(t *testing.T) {
+	keys := [][]byte{[]byte("prefix_a"), []byte("prefix_b"), []byte("prefix_bc")}
+	values := [][]byte{[]byte("1"), []byte("2"), []byte("3")}
+
+	result := []string{}
+	err := parseBlock(buildBlock(keys, values), func(key, value []byte) error {
+		result = append(result, string(key)+"="+string(value))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prefix_a=1", "prefix_b=2", "prefix_bc=3"}, result)
+}
+
+func TestReadDatabase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "leveldb")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	table := buildTable(
+		testEntry{"a", "old-a", 1, RECORD_TYPE_VALUE},
+		testEntry{"b", "b1", 2, RECORD_TYPE_VALUE},
+		testEntry{"c", "c1", 3, RECORD_TYPE_VALUE})
+	assert.NoError(t, ioutil.WriteFile(
+		filepath.Join(dir, "000005.ldb"), table, 0600))
+
+	large := strings.Repeat("d", 40000)
+	log := appendLogRecord(nil, buildBatch(
+		testEntry{"a", "new-a", 10, RECORD_TYPE_VALUE},
+		testEntry{"b", "", 11, RECORD_TYPE_DELETION}))
+
+	// A corrupted record is skipped along with the rest of its block.
+	corrupt := appendLogRecord(nil, buildBatch(
+		testEntry{"e", "corrupt", 12, RECORD_TYPE_VALUE}))
+	corrupt[0] ^= 0xff
+	log = append(log, corrupt...)
+	log = append(log, make([]byte, LOG_BLOCK_SIZE-len(log))...)
+
+	// Spans several blocks.
+	log = appendLogRecord(log, buildBatch(
+		testEntry{"d", large, 13, RECORD_TYPE_VALUE}))
+	assert.NoError(t, ioutil.WriteFile(
+		filepath.Join(dir, "000006.log"), log, 0600))
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	accessor, err := accessors.GetAccessor("file", scope)
+	assert.NoError(t, err)
+
+	dirname, err := accessor.ParsePath(dir)
+	assert.NoError(t, err)
+
+	records, err := ReadDatabase(context.Background(), accessor, dirname, scope.Log)
+	assert.NoError(t, err)
+
+	result := []string{}
+	for _, r := range records {
+		value := string(r.Value)
+		if value == large {
+			value = "large"
+		}
+		result = append(result, fmt.Sprintf("%v %v %v %v %v",
+			string(r.Key), r.Seq, r.State, value, r.File))
+	}
+
+	assert.Equal(t, []string{
+		"a 10 Live new-a 000006.log",
+		"a 1 Superseded old-a 000005.ldb",
+		"b 11 Tombstone  000006.log",
+		"b 2 Deleted b1 000005.ldb",
+		"c 3 Live c1 000005.ldb",
+		"d 13 Live large 000006.log",
+	}, result)
+}
+
+func utf16be(s string) []byte {
+	result := []byte{}
+	for _, c := range s {
+		result = binary.BigEndian.AppendUint16(result, uint16(c))
+	}
+	return result
+}
+
+func stringWithLength(s string) []byte {
+	return append(binary.AppendUvarint(nil, uint64(len(s))), utf16be(s)...)
+}
+
+func TestDecodeV8Value(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected string
+	}{
+		{"\xff\x0fT", "true"},
+		{"\xff\x0fI\x54", "42"},
+		{"\xff\x0fI\x53", "-42"},
+		{"\xff\x0fN\x00\x00\x00\x00\x00\x00\xf8\x3f", "1.5"},
+		{"\xff\x0f\"\x04caf\xe9", `"café"`},
+		{"\xff\x0fc\x04h\x00i\x00", `"hi"`},
+		{"\xff\x0fZ\x10\x01\x00\x00\x00\x00\x00\x00\x00", `"1"`},
+		{"\xff\x0fA\x02I\x02I\x04$\x00\x02", "[1,2]"},
+		{"\xff\x0fa\x03I\x02S\x01x@\x01\x03", `[null,"x",null]`},
+		{"\xff\x0f;\"\x01aT:\x02", `{"a":true}`},
+		{"\xff\x0f'I\x02I\x04,\x02", "[1,2]"},
+		{"\xff\x0fD\x00\x00\x00\x00\x00\x00\x00\x00", `"1970-01-01T00:00:00Z"`},
+		{"\xff\x0fB\x03abcV?\x01\x02\x00", `"bc"`},
+		{"\xff\x0fo\"\x01xo{\x00\"\x01y^\x01{\x02", `{"x":{},"y":{}}`},
+		{"\xff\x0frRm\"\x03bad.", `{"Name":"RangeError","Message":"bad"}`},
+	} {
+		value, err := DecodeV8Value([]byte(test.data))
+		assert.NoError(t, err, test.data)
+		assert.Equal(t, test.expected, json.MustMarshalString(value), test.data)
+	}
+
+	// Host objects are not supported.
+	_, err := DecodeV8Value([]byte("\xff\x0f\\"))
+	assert.Error(t, err)
+}
+
+func TestDecodeIDBKey(t *testing.T) {
+	number := binary.LittleEndian.AppendUint64([]byte{IDB_KEY_NUMBER},
+		math.Float64bits(5))
+	date := binary.LittleEndian.AppendUint64([]byte{IDB_KEY_DATE},
+		math.Float64bits(1.6e12))
+
+	key := append([]byte{IDB_KEY_ARRAY, 3, IDB_KEY_STRING}, stringWithLength("k")...)
+	key = append(key, number...)
+	key = append(key, date...)
+
+	value, rest, err := DecodeIDBKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(rest))
+	assert.Equal(t, []interface{}{"k", float64(5),
+		time.UnixMilli(1.6e12).UTC()}, value)
+}
+
+func TestIndexedDB(t *testing.T) {
+	database_name := append([]byte{0, 0, 0, 0, IDB_DATABASE_NAME},
+		stringWithLength("https_example.com_0")...)
+	database_name = append(database_name, stringWithLength("mydb")...)
+
+	object_store_name := []byte{0, 1, 0, 0, IDB_OBJECT_STORE_META_DATA, 1,
+		IDB_OBJECT_STORE_NAME}
+
+	data_prefix := []byte{0, 1, 1, IDB_OBJECT_STORE_DATA_INDEX}
+	key1 := append(append([]byte{}, data_prefix...), IDB_KEY_STRING)
+	key1 = append(key1, stringWithLength("k1")...)
+
+	key2 := append(append([]byte{}, data_prefix...), IDB_KEY_NUMBER)
+	key2 = binary.LittleEndian.AppendUint64(key2, math.Float64bits(5))
+
+	// A Blink envelope with a trailer offset around the V8 value.
+	value1 := []byte("\x03\xff\x15\xfe\x00\x00\x00\x00\x00\x00\x00\x00" +
+		"\x00\x00\x00\x00\xff\x0fo\"\x04name\"\x05hello{\x01")
+	value2 := append([]byte("\x01\xff\x11\x02"),
+		snappy.Encode(nil, []byte("\xff\x14\xff\x0f\"\x03abc"))...)
+
+	records := []*Record{
+		{Key: database_name, Value: []byte{1}, Seq: 1, Type: RECORD_TYPE_VALUE},
+		{Key: object_store_name, Value: utf16be("notes"), Seq: 2, Type: RECORD_TYPE_VALUE},
+		{Key: key1, Value: []byte("\x01\xff\x0f\"\x03old"), Seq: 3, Type: RECORD_TYPE_VALUE},
+		{Key: key1, Value: value1, Seq: 4, Type: RECORD_TYPE_VALUE},
+		{Key: key2, Value: value2, Seq: 5, Type: RECORD_TYPE_VALUE},
+	}
+	SetStates(records)
+
+	result := []string{}
+	ParseIndexedDB(records, func(object_store *IndexedDBObjectStore,
+		key interface{}, value interface{}, record *Record) {
+		result = append(result, fmt.Sprintf("%v %v %v %v %v %v",
+			object_store.Origin, object_store.Database, object_store.ObjectStore,
+			key, json.MustMarshalString(value), record.State))
+	})
+
+	assert.Equal(t, []string{
+		`https_example.com_0 mydb notes k1 {"name":"hello"} Live`,
+		`https_example.com_0 mydb notes k1 "old" Superseded`,
+		`https_example.com_0 mydb notes 5 "abc" Live`,
+	}, result)
+}
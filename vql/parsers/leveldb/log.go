@@ -0,0 +1,171 @@
+package leveldb
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// The write ahead log is a sequence of 32kb blocks. Write batches
+// are stored in records that may be fragmented across blocks.
+const (
+	LOG_BLOCK_SIZE  = 32768
+	LOG_HEADER_SIZE = 7
+
+	LOG_RECORD_ZERO   = 0
+	LOG_RECORD_FULL   = 1
+	LOG_RECORD_FIRST  = 2
+	LOG_RECORD_MIDDLE = 3
+	LOG_RECORD_LAST   = 4
+
+	BATCH_HEADER_SIZE = 12
+
+	RECORD_TYPE_DELETION = 0
+	RECORD_TYPE_VALUE    = 1
+
+	CRC_MASK_DELTA = 0xa282ead8
+)
+
+var (
+	crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+	truncatedError = errors.New("Truncated data")
+)
+
+func unmaskCRC(masked uint32) uint32 {
+	rot := masked - CRC_MASK_DELTA
+	return rot>>17 | rot<<15
+}
+
+// Call cb with each complete write batch in the log. Records with
+// bad checksums are skipped.
+func ParseLog(reader io.ReaderAt, size int64,
+	cb func(offset int64, batch []byte) error) error {
+	block := make([]byte, LOG_BLOCK_SIZE)
+
+	var pending []byte
+	pending_offset := int64(-1)
+
+	for block_offset := int64(0); block_offset < size; block_offset += LOG_BLOCK_SIZE {
+		n, err := reader.ReadAt(block, block_offset)
+		if n == 0 {
+			if err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+			break
+		}
+
+		data := block[:n]
+		for offset := 0; offset+LOG_HEADER_SIZE <= len(data); {
+			checksum := binary.LittleEndian.Uint32(data[offset:])
+			length := int(binary.LittleEndian.Uint16(data[offset+4:]))
+			record_type := data[offset+6]
+
+			// Zero filled space at the end of the log.
+			if record_type == LOG_RECORD_ZERO && length == 0 {
+				break
+			}
+
+			start := offset + LOG_HEADER_SIZE
+			end := start + length
+			if end > len(data) ||
+				crc32.Checksum(data[offset+6:end], crc32c) != unmaskCRC(checksum) {
+				// Corrupt - skip the rest of the block.
+				pending = nil
+				break
+			}
+			payload := data[start:end]
+			record_offset := block_offset + int64(offset)
+			offset = end
+
+			switch record_type {
+			case LOG_RECORD_FULL:
+				pending = nil
+				err := cb(record_offset, append([]byte{}, payload...))
+				if err != nil {
+					return err
+				}
+
+			case LOG_RECORD_FIRST:
+				pending = append([]byte{}, payload...)
+				pending_offset = record_offset
+
+			case LOG_RECORD_MIDDLE:
+				if pending != nil {
+					pending = append(pending, payload...)
+				}
+
+			case LOG_RECORD_LAST:
+				if pending != nil {
+					err := cb(pending_offset, append(pending, payload...))
+					if err != nil {
+						return err
+					}
+				}
+				pending = nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// Call cb with each entry in a write batch. Entries are numbered
+// sequentially from the batch's sequence number.
+func ParseBatch(batch []byte,
+	cb func(seq uint64, record_type int, key, value []byte) error) error {
+	if len(batch) < BATCH_HEADER_SIZE {
+		return truncatedError
+	}
+
+	seq := binary.LittleEndian.Uint64(batch)
+	count := int(binary.LittleEndian.Uint32(batch[8:]))
+	offset := BATCH_HEADER_SIZE
+
+	for i := 0; i < count; i++ {
+		if offset >= len(batch) {
+			return truncatedError
+		}
+		record_type := int(batch[offset])
+		offset++
+
+		key, n := readLengthPrefixed(batch[offset:])
+		if n == 0 {
+			return truncatedError
+		}
+		offset += n
+
+		var value []byte
+		switch record_type {
+		case RECORD_TYPE_VALUE:
+			value, n = readLengthPrefixed(batch[offset:])
+			if n == 0 {
+				return truncatedError
+			}
+			offset += n
+
+		case RECORD_TYPE_DELETION:
+
+		default:
+			return errors.New("Invalid batch record type")
+		}
+
+		err := cb(seq+uint64(i), record_type, key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the data and the number of bytes consumed (0 on error).
+func readLengthPrefixed(data []byte) ([]byte, int) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < length {
+		return nil, 0
+	}
+	end := n + int(length)
+	return data[n:end], end
+}
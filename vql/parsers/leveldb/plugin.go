@@ -0,0 +1,106 @@
+package leveldb
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type IndexedDBPluginArgs struct {
+	Path       *accessors.OSPath `vfilter:"required,field=path,doc=The path to the IndexedDB leveldb directory."`
+	Accessor   string            `vfilter:"optional,field=accessor,doc=The accessor to use."`
+	AllRecords bool              `vfilter:"optional,field=all_records,doc=Also emit deleted and superseded records."`
+}
+
+type IndexedDBPlugin struct{}
+
+func (self IndexedDBPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("indexeddb", args)()
+
+		arg := &IndexedDBPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("indexeddb: %v", err)
+			return
+		}
+
+		if arg.Accessor == "" {
+			arg.Accessor = "auto"
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("indexeddb: %s", err)
+			return
+		}
+
+		accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+		if err != nil {
+			scope.Log("indexeddb: %v", err)
+			return
+		}
+
+		records, err := ReadDatabase(ctx, accessor, arg.Path, scope.Log)
+		if err != nil {
+			scope.Log("indexeddb: %v: %v", arg.Path, err)
+			return
+		}
+
+		rows := []*ordereddict.Dict{}
+		ParseIndexedDB(records, func(object_store *IndexedDBObjectStore,
+			key interface{}, value interface{}, record *Record) {
+			if record.State == STATE_TOMBSTONE ||
+				(!arg.AllRecords && record.State != STATE_LIVE) {
+				return
+			}
+
+			rows = append(rows, ordereddict.NewDict().
+				Set("Origin", object_store.Origin).
+				Set("Database", object_store.Database).
+				Set("ObjectStore", object_store.ObjectStore).
+				Set("Key", key).
+				Set("Value", value).
+				Set("Seq", record.Seq).
+				Set("State", record.State).
+				Set("File", record.File))
+		})
+
+		for _, row := range rows {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self IndexedDBPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "indexeddb",
+		Doc:      "Decode the object stores of a Chromium IndexedDB database.",
+		ArgType:  type_map.AddType(scope, &IndexedDBPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&IndexedDBPlugin{})
+}
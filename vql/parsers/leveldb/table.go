@@ -0,0 +1,196 @@
+package leveldb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/snappy"
+)
+
+// Sorted table (.ldb or .sst) files.
+const (
+	TABLE_FOOTER_SIZE   = 48
+	TABLE_MAGIC         = 0xdb4775248b80fb57
+	BLOCK_TRAILER_SIZE  = 5
+	INTERNAL_KEY_SUFFIX = 8
+
+	BLOCK_NO_COMPRESSION     = 0
+	BLOCK_SNAPPY_COMPRESSION = 1
+
+	// Sanity limit on the size of a single block.
+	MAX_BLOCK_SIZE = 64 * 1024 * 1024
+)
+
+type blockHandle struct {
+	offset uint64
+	size   uint64
+}
+
+func decodeBlockHandle(data []byte) (*blockHandle, int, error) {
+	offset, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, 0, truncatedError
+	}
+
+	size, m := binary.Uvarint(data[n:])
+	if m <= 0 {
+		return nil, 0, truncatedError
+	}
+
+	return &blockHandle{offset: offset, size: size}, n + m, nil
+}
+
+type table struct {
+	reader io.ReaderAt
+	size   int64
+}
+
+func (self *table) readBlock(handle *blockHandle) ([]byte, error) {
+	if handle.size > MAX_BLOCK_SIZE ||
+		int64(handle.offset+handle.size+BLOCK_TRAILER_SIZE) > self.size {
+		return nil, fmt.Errorf("Invalid block handle %#x (%v bytes)",
+			handle.offset, handle.size)
+	}
+
+	data := make([]byte, handle.size+BLOCK_TRAILER_SIZE)
+	n, err := self.reader.ReadAt(data, int64(handle.offset))
+	if n < len(data) {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	switch data[handle.size] {
+	case BLOCK_NO_COMPRESSION:
+		return data[:handle.size], nil
+
+	case BLOCK_SNAPPY_COMPRESSION:
+		return snappy.Decode(nil, data[:handle.size])
+
+	default:
+		return nil, fmt.Errorf("Unsupported block compression %v",
+			data[handle.size])
+	}
+}
+
+// Call cb with each key and value in a block. Keys are prefix
+// compressed against the previous key.
+func parseBlock(block []byte, cb func(key, value []byte) error) error {
+	if len(block) < 4 {
+		return truncatedError
+	}
+
+	restarts := int(binary.LittleEndian.Uint32(block[len(block)-4:]))
+	limit := len(block) - 4 - restarts*4
+	if restarts < 0 || limit < 0 {
+		return errors.New("Invalid block restarts")
+	}
+
+	var key []byte
+	for offset := 0; offset < limit; {
+		shared, n1 := binary.Uvarint(block[offset:limit])
+		if n1 <= 0 {
+			return truncatedError
+		}
+		non_shared, n2 := binary.Uvarint(block[offset+n1 : limit])
+		if n2 <= 0 {
+			return truncatedError
+		}
+		value_length, n3 := binary.Uvarint(block[offset+n1+n2 : limit])
+		if n3 <= 0 {
+			return truncatedError
+		}
+		offset += n1 + n2 + n3
+
+		if shared > uint64(len(key)) ||
+			uint64(limit-offset) < non_shared ||
+			uint64(limit-offset)-non_shared < value_length {
+			return truncatedError
+		}
+
+		key = append(key[:shared], block[offset:offset+int(non_shared)]...)
+		offset += int(non_shared)
+
+		value := block[offset : offset+int(value_length)]
+		offset += int(value_length)
+
+		err := cb(append([]byte{}, key...), value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Call cb with each record in the table. The records are stored with
+// internal keys (the user key followed by the sequence number and
+// type).
+func ParseTable(reader io.ReaderAt, size int64,
+	cb func(offset int64, seq uint64, record_type int, key, value []byte) error) error {
+	if size < TABLE_FOOTER_SIZE {
+		return truncatedError
+	}
+
+	footer := make([]byte, TABLE_FOOTER_SIZE)
+	n, err := reader.ReadAt(footer, size-TABLE_FOOTER_SIZE)
+	if n < TABLE_FOOTER_SIZE {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	if binary.LittleEndian.Uint64(footer[40:]) != TABLE_MAGIC {
+		return errors.New("Not a LevelDB table")
+	}
+
+	// Skip the metaindex handle
+	_, n, err = decodeBlockHandle(footer)
+	if err != nil {
+		return err
+	}
+
+	index_handle, _, err := decodeBlockHandle(footer[n:])
+	if err != nil {
+		return err
+	}
+
+	self := &table{reader: reader, size: size}
+	index, err := self.readBlock(index_handle)
+	if err != nil {
+		return err
+	}
+
+	return parseBlock(index, func(_, value []byte) error {
+		handle, _, err := decodeBlockHandle(value)
+		if err != nil {
+			return err
+		}
+
+		block, err := self.readBlock(handle)
+		if err != nil {
+			// Skip corrupt blocks.
+			return nil
+		}
+
+		// Keep the records before any corruption in the block.
+		var cb_err error
+		parseBlock(block, func(key, value []byte) error {
+			if len(key) < INTERNAL_KEY_SUFFIX {
+				return nil
+			}
+
+			user_key_len := len(key) - INTERNAL_KEY_SUFFIX
+			tag := binary.LittleEndian.Uint64(key[user_key_len:])
+
+			cb_err = cb(int64(handle.offset), tag>>8, int(tag&0xff),
+				key[:user_key_len], append([]byte{}, value...))
+			return cb_err
+		})
+		return cb_err
+	})
+}
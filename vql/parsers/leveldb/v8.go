@@ -0,0 +1,606 @@
+package leveldb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+	"unicode/utf16"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+// Tags used by V8's ValueSerializer (v8/src/objects/value-serializer.cc)
+const (
+	V8_VERSION             = 0xFF
+	V8_PADDING             = 0x00
+	V8_VERIFY_OBJECT_COUNT = '?'
+	V8_THE_HOLE            = '-'
+	V8_UNDEFINED           = '_'
+	V8_NULL                = '0'
+	V8_TRUE                = 'T'
+	V8_FALSE               = 'F'
+	V8_INT32               = 'I'
+	V8_UINT32              = 'U'
+	V8_DOUBLE              = 'N'
+	V8_BIGINT              = 'Z'
+	V8_UTF8_STRING         = 'S'
+	V8_ONE_BYTE_STRING     = '"'
+	V8_TWO_BYTE_STRING     = 'c'
+	V8_OBJECT_REFERENCE    = '^'
+	V8_BEGIN_JS_OBJECT     = 'o'
+	V8_END_JS_OBJECT       = '{'
+	V8_BEGIN_SPARSE_ARRAY  = 'a'
+	V8_END_SPARSE_ARRAY    = '@'
+	V8_BEGIN_DENSE_ARRAY   = 'A'
+	V8_END_DENSE_ARRAY     = '$'
+	V8_DATE                = 'D'
+	V8_TRUE_OBJECT         = 'y'
+	V8_FALSE_OBJECT        = 'x'
+	V8_NUMBER_OBJECT       = 'n'
+	V8_BIGINT_OBJECT       = 'z'
+	V8_STRING_OBJECT       = 's'
+	V8_REGEXP              = 'R'
+	V8_BEGIN_MAP           = ';'
+	V8_END_MAP             = ':'
+	V8_BEGIN_SET           = '\''
+	V8_END_SET             = ','
+	V8_ARRAY_BUFFER        = 'B'
+	V8_ARRAY_BUFFER_VIEW   = 'V'
+	V8_ERROR               = 'r'
+
+	// Errors are a sequence of sub tags.
+	V8_ERROR_MESSAGE = 'm'
+	V8_ERROR_STACK   = 's'
+	V8_ERROR_END     = '.'
+
+	// Sanity limit on nesting
+	MAX_V8_DEPTH = 100
+)
+
+var (
+	v8TruncatedError = errors.New("Truncated V8 value")
+
+	// Error prototypes
+	v8ErrorTypes = map[byte]string{
+		'E': "EvalError",
+		'R': "RangeError",
+		'F': "ReferenceError",
+		'S': "SyntaxError",
+		'T': "TypeError",
+		'U': "URIError",
+	}
+)
+
+// Decodes values serialized with V8's ValueSerializer. Objects are
+// returned as dicts, arrays as slices and binary data as strings.
+type v8Deserializer struct {
+	data    []byte
+	offset  int
+	version uint64
+
+	// Objects in the order they were read, for back references.
+	objects []interface{}
+	depth   int
+
+	// The last array buffer read, for views onto it.
+	last_buffer []byte
+}
+
+func DecodeV8Value(data []byte) (interface{}, error) {
+	self := &v8Deserializer{data: data}
+
+	// An optional version header
+	if len(data) > 0 && data[0] == V8_VERSION {
+		self.offset++
+		version, err := self.readVarint()
+		if err != nil {
+			return nil, err
+		}
+		self.version = version
+	}
+
+	return self.readValue()
+}
+
+func (self *v8Deserializer) readByte() (byte, error) {
+	if self.offset >= len(self.data) {
+		return 0, v8TruncatedError
+	}
+	b := self.data[self.offset]
+	self.offset++
+	return b, nil
+}
+
+func (self *v8Deserializer) readBytes(n uint64) ([]byte, error) {
+	if uint64(len(self.data)-self.offset) < n {
+		return nil, v8TruncatedError
+	}
+	result := self.data[self.offset : self.offset+int(n)]
+	self.offset += int(n)
+	return result, nil
+}
+
+func (self *v8Deserializer) readVarint() (uint64, error) {
+	value, n := binary.Uvarint(self.data[self.offset:])
+	if n <= 0 {
+		return 0, v8TruncatedError
+	}
+	self.offset += n
+	return value, nil
+}
+
+func (self *v8Deserializer) readZigZag() (int64, error) {
+	value, err := self.readVarint()
+	if err != nil {
+		return 0, err
+	}
+	return int64(value>>1) ^ -int64(value&1), nil
+}
+
+func (self *v8Deserializer) readDouble() (float64, error) {
+	data, err := self.readBytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(data)), nil
+}
+
+func (self *v8Deserializer) readTag() (byte, error) {
+	for {
+		tag, err := self.readByte()
+		if err != nil || tag != V8_PADDING {
+			return tag, err
+		}
+	}
+}
+
+func (self *v8Deserializer) peekTag() (byte, error) {
+	offset := self.offset
+	tag, err := self.readTag()
+	self.offset = offset
+	return tag, err
+}
+
+func (self *v8Deserializer) addObject(obj interface{}) int {
+	self.objects = append(self.objects, obj)
+	return len(self.objects) - 1
+}
+
+func (self *v8Deserializer) readValue() (interface{}, error) {
+	self.depth++
+	defer func() { self.depth-- }()
+
+	if self.depth > MAX_V8_DEPTH {
+		return nil, errors.New("V8 value nested too deeply")
+	}
+
+	tag, err := self.readTag()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case V8_VERIFY_OBJECT_COUNT:
+		_, err := self.readVarint()
+		if err != nil {
+			return nil, err
+		}
+		return self.readValue()
+
+	case V8_UNDEFINED, V8_NULL, V8_THE_HOLE:
+		return nil, nil
+
+	case V8_TRUE:
+		return true, nil
+
+	case V8_FALSE:
+		return false, nil
+
+	case V8_INT32:
+		return self.readZigZag()
+
+	case V8_UINT32:
+		return self.readVarint()
+
+	case V8_DOUBLE:
+		return self.readDouble()
+
+	case V8_BIGINT:
+		return self.readBigInt()
+
+	case V8_UTF8_STRING, V8_ONE_BYTE_STRING, V8_TWO_BYTE_STRING:
+		return self.readString(tag)
+
+	case V8_OBJECT_REFERENCE:
+		id, err := self.readVarint()
+		if err != nil {
+			return nil, err
+		}
+		if id >= uint64(len(self.objects)) {
+			return nil, fmt.Errorf("Invalid V8 object reference %v", id)
+		}
+		return self.objects[id], nil
+
+	case V8_BEGIN_JS_OBJECT:
+		result := ordereddict.NewDict()
+		self.addObject(result)
+		return result, self.readProperties(result, V8_END_JS_OBJECT)
+
+	case V8_BEGIN_SPARSE_ARRAY:
+		return self.readSparseArray()
+
+	case V8_BEGIN_DENSE_ARRAY:
+		return self.readDenseArray()
+
+	case V8_DATE:
+		value, err := self.readDouble()
+		if err != nil {
+			return nil, err
+		}
+		result := time.UnixMilli(int64(value)).UTC()
+		self.addObject(result)
+		return result, nil
+
+	case V8_TRUE_OBJECT, V8_FALSE_OBJECT:
+		result := tag == V8_TRUE_OBJECT
+		self.addObject(result)
+		return result, nil
+
+	case V8_NUMBER_OBJECT:
+		result, err := self.readDouble()
+		self.addObject(result)
+		return result, err
+
+	case V8_BIGINT_OBJECT:
+		result, err := self.readBigInt()
+		self.addObject(result)
+		return result, err
+
+	case V8_STRING_OBJECT:
+		result, err := self.readValue()
+		self.addObject(result)
+		return result, err
+
+	case V8_REGEXP:
+		pattern, err := self.readValue()
+		if err != nil {
+			return nil, err
+		}
+		flags, err := self.readVarint()
+		if err != nil {
+			return nil, err
+		}
+		result := fmt.Sprintf("/%v/%v", pattern, regexpFlags(flags))
+		self.addObject(result)
+		return result, nil
+
+	case V8_BEGIN_MAP:
+		return self.readMap()
+
+	case V8_BEGIN_SET:
+		return self.readSet()
+
+	case V8_ARRAY_BUFFER:
+		length, err := self.readVarint()
+		if err != nil {
+			return nil, err
+		}
+		data, err := self.readBytes(length)
+		if err != nil {
+			return nil, err
+		}
+		self.last_buffer = data
+		self.addObject(string(data))
+
+		// A view may follow the buffer.
+		next, err := self.peekTag()
+		if err == nil && next == V8_ARRAY_BUFFER_VIEW {
+			return self.readValue()
+		}
+		return string(data), nil
+
+	case V8_ARRAY_BUFFER_VIEW:
+		return self.readArrayBufferView()
+
+	case V8_ERROR:
+		return self.readError()
+	}
+
+	return nil, fmt.Errorf("Unsupported V8 tag %#x at %#x", tag, self.offset-1)
+}
+
+func (self *v8Deserializer) readString(tag byte) (string, error) {
+	length, err := self.readVarint()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := self.readBytes(length)
+	if err != nil {
+		return "", err
+	}
+
+	switch tag {
+	case V8_ONE_BYTE_STRING:
+		// Latin1
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes), nil
+
+	case V8_TWO_BYTE_STRING:
+		units := make([]uint16, len(data)/2)
+		for i := range units {
+			units[i] = binary.LittleEndian.Uint16(data[i*2:])
+		}
+		return string(utf16.Decode(units)), nil
+	}
+
+	return string(data), nil
+}
+
+func (self *v8Deserializer) readBigInt() (string, error) {
+	bitfield, err := self.readVarint()
+	if err != nil {
+		return "", err
+	}
+
+	digits, err := self.readBytes(bitfield >> 1)
+	if err != nil {
+		return "", err
+	}
+
+	// Little endian digits
+	big_endian := make([]byte, len(digits))
+	for i, d := range digits {
+		big_endian[len(digits)-1-i] = d
+	}
+
+	result := new(big.Int).SetBytes(big_endian)
+	if bitfield&1 != 0 {
+		result.Neg(result)
+	}
+	return result.String(), nil
+}
+
+// Read key/value pairs until the end tag. The end tag is followed by
+// the number of properties (and for arrays the length).
+func (self *v8Deserializer) readProperties(
+	result *ordereddict.Dict, end_tag byte) error {
+	for {
+		tag, err := self.peekTag()
+		if err != nil {
+			return err
+		}
+
+		if tag == end_tag {
+			self.readTag()
+			_, err = self.readVarint()
+			if err != nil {
+				return err
+			}
+			if end_tag != V8_END_JS_OBJECT {
+				_, err = self.readVarint()
+			}
+			return err
+		}
+
+		key, err := self.readValue()
+		if err != nil {
+			return err
+		}
+
+		value, err := self.readValue()
+		if err != nil {
+			return err
+		}
+
+		result.Set(fmt.Sprintf("%v", key), value)
+	}
+}
+
+// Arrays with extra properties are returned as dicts.
+func arrayResult(elements []interface{}, properties *ordereddict.Dict) interface{} {
+	if properties.Len() == 0 {
+		return elements
+	}
+
+	for i, e := range elements {
+		if e != nil {
+			properties.Set(fmt.Sprintf("%v", i), e)
+		}
+	}
+	return properties
+}
+
+func (self *v8Deserializer) readDenseArray() (interface{}, error) {
+	length, err := self.readVarint()
+	if err != nil {
+		return nil, err
+	}
+
+	if length > uint64(len(self.data)) {
+		return nil, v8TruncatedError
+	}
+
+	elements := make([]interface{}, 0, length)
+	id := self.addObject(elements)
+
+	for i := uint64(0); i < length; i++ {
+		value, err := self.readValue()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, value)
+	}
+	self.objects[id] = elements
+
+	properties := ordereddict.NewDict()
+	err = self.readProperties(properties, V8_END_DENSE_ARRAY)
+	return arrayResult(elements, properties), err
+}
+
+func (self *v8Deserializer) readSparseArray() (interface{}, error) {
+	length, err := self.readVarint()
+	if err != nil {
+		return nil, err
+	}
+
+	properties := ordereddict.NewDict()
+	self.addObject(properties)
+
+	err = self.readProperties(properties, V8_END_SPARSE_ARRAY)
+	if err != nil {
+		return nil, err
+	}
+
+	// Small sparse arrays are returned as arrays.
+	if length > 1024 {
+		return properties, nil
+	}
+
+	elements := make([]interface{}, length)
+	for i := range elements {
+		elements[i], _ = properties.Get(fmt.Sprintf("%v", i))
+		properties.Delete(fmt.Sprintf("%v", i))
+	}
+	return arrayResult(elements, properties), nil
+}
+
+func (self *v8Deserializer) readMap() (interface{}, error) {
+	result := ordereddict.NewDict()
+	self.addObject(result)
+
+	for {
+		tag, err := self.peekTag()
+		if err != nil {
+			return nil, err
+		}
+
+		if tag == V8_END_MAP {
+			self.readTag()
+			_, err = self.readVarint()
+			return result, err
+		}
+
+		key, err := self.readValue()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := self.readValue()
+		if err != nil {
+			return nil, err
+		}
+
+		result.Set(fmt.Sprintf("%v", key), value)
+	}
+}
+
+func (self *v8Deserializer) readSet() (interface{}, error) {
+	result := []interface{}{}
+	id := self.addObject(result)
+
+	for {
+		tag, err := self.peekTag()
+		if err != nil {
+			return nil, err
+		}
+
+		if tag == V8_END_SET {
+			self.readTag()
+			_, err = self.readVarint()
+			self.objects[id] = result
+			return result, err
+		}
+
+		value, err := self.readValue()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+}
+
+func (self *v8Deserializer) readArrayBufferView() (interface{}, error) {
+	// The view type (e.g. Uint8Array)
+	_, err := self.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := self.readVarint()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := self.readVarint()
+	if err != nil {
+		return nil, err
+	}
+
+	if self.version >= 14 {
+		_, err = self.readVarint()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if offset+length > uint64(len(self.last_buffer)) {
+		return nil, v8TruncatedError
+	}
+
+	result := string(self.last_buffer[offset : offset+length])
+	self.addObject(result)
+	return result, nil
+}
+
+func (self *v8Deserializer) readError() (interface{}, error) {
+	result := ordereddict.NewDict().Set("Name", "Error")
+	self.addObject(result)
+
+	for {
+		tag, err := self.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tag {
+		case V8_ERROR_END:
+			return result, nil
+
+		case V8_ERROR_MESSAGE, V8_ERROR_STACK:
+			value, err := self.readValue()
+			if err != nil {
+				return nil, err
+			}
+
+			if tag == V8_ERROR_MESSAGE {
+				result.Set("Message", value)
+			} else {
+				result.Set("Stack", value)
+			}
+
+		default:
+			name, pres := v8ErrorTypes[tag]
+			if !pres {
+				return nil, fmt.Errorf("Unsupported V8 error tag %#x", tag)
+			}
+			result.Set("Name", name)
+		}
+	}
+}
+
+func regexpFlags(flags uint64) string {
+	result := ""
+	for i, flag := range "gimyus" {
+		if flags&(1<<i) != 0 {
+			result += string(flag)
+		}
+	}
+	return result
+}
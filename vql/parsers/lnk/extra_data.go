@@ -0,0 +1,173 @@
+package lnk
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+const (
+	ENVIRONMENT_VARIABLES_BLOCK = 0xa0000001
+	CONSOLE_BLOCK               = 0xa0000002
+	TRACKER_BLOCK               = 0xa0000003
+	CONSOLE_FE_BLOCK            = 0xa0000004
+	SPECIAL_FOLDER_BLOCK        = 0xa0000005
+	DARWIN_BLOCK                = 0xa0000006
+	ICON_ENVIRONMENT_BLOCK      = 0xa0000007
+	SHIM_BLOCK                  = 0xa0000008
+	PROPERTY_STORE_BLOCK        = 0xa0000009
+	KNOWN_FOLDER_BLOCK          = 0xa000000b
+	VISTA_ID_LIST_BLOCK         = 0xa000000c
+
+	// Blocks smaller than this terminate the extra data.
+	TERMINAL_BLOCK_SIZE = 4
+
+	// Sanity limit on the number of blocks.
+	MAX_EXTRA_DATA_BLOCKS = 100
+
+	// The number of 100ns intervals between the UUID epoch
+	// (1582-10-15) and the unix epoch.
+	UUID_EPOCH_OFFSET = 0x01b21dd213814000
+)
+
+// Parse the ExtraData blocks which follow the StringData. Returns the
+// blocks keyed by name and the number of bytes consumed.
+func parseExtraData(data buffer) (*ordereddict.Dict, int) {
+	result := ordereddict.NewDict()
+
+	offset := 0
+	for i := 0; i < MAX_EXTRA_DATA_BLOCKS; i++ {
+		size := int(data.u32(offset))
+		if size < TERMINAL_BLOCK_SIZE {
+			// Include the terminal block.
+			return result, offset + TERMINAL_BLOCK_SIZE
+		}
+
+		block := data.slice(offset, size)
+		if block == nil {
+			break
+		}
+		offset += size
+
+		switch block.u32(4) {
+		case ENVIRONMENT_VARIABLES_BLOCK:
+			result.Set("EnvironmentVariables", parseTargetBlock(block))
+
+		case ICON_ENVIRONMENT_BLOCK:
+			result.Set("IconEnvironment", parseTargetBlock(block))
+
+		case DARWIN_BLOCK:
+			result.Set("Darwin", parseTargetBlock(block))
+
+		case CONSOLE_BLOCK:
+			result.Set("Console", parseConsoleBlock(block))
+
+		case CONSOLE_FE_BLOCK:
+			result.Set("ConsoleFE", ordereddict.NewDict().
+				Set("CodePage", block.u32(8)))
+
+		case TRACKER_BLOCK:
+			result.Set("Tracker", parseTrackerBlock(block))
+
+		case SPECIAL_FOLDER_BLOCK:
+			result.Set("SpecialFolder", ordereddict.NewDict().
+				Set("SpecialFolderID", block.u32(8)).
+				Set("Offset", block.u32(12)))
+
+		case KNOWN_FOLDER_BLOCK:
+			guid := block.guid(8)
+			result.Set("KnownFolder", ordereddict.NewDict().
+				Set("KnownFolderID", guid).
+				Set("Name", folderNames[guid]).
+				Set("Offset", block.u32(24)))
+
+		case SHIM_BLOCK:
+			layer, _ := block.utf16z(8)
+			result.Set("Shim", ordereddict.NewDict().
+				Set("LayerName", layer))
+
+		case PROPERTY_STORE_BLOCK:
+			result.Set("PropertyStore", parsePropertyStore(block.from(8)))
+
+		case VISTA_ID_LIST_BLOCK:
+			items, path := parseIDList(block.from(8))
+			result.Set("VistaAndAboveIDList", ordereddict.NewDict().
+				Set("Path", path).
+				Set("ShellItems", items))
+
+		default:
+			result.Set(fmt.Sprintf("Unknown_%#x", block.u32(4)),
+				fmt.Sprintf("%x", []byte(block.from(8))))
+		}
+	}
+
+	// The terminal block is missing.
+	return result, offset
+}
+
+// Several blocks contain an ANSI and a Unicode version of a path.
+func parseTargetBlock(block buffer) *ordereddict.Dict {
+	return ordereddict.NewDict().
+		Set("TargetAnsi", block.asciiField(8, 260)).
+		Set("TargetUnicode", block.utf16Field(268, 520))
+}
+
+func parseConsoleBlock(block buffer) *ordereddict.Dict {
+	return ordereddict.NewDict().
+		Set("FillAttributes", block.u16(8)).
+		Set("PopupFillAttributes", block.u16(10)).
+		Set("ScreenBufferSizeX", block.u16(12)).
+		Set("ScreenBufferSizeY", block.u16(14)).
+		Set("WindowSizeX", block.u16(16)).
+		Set("WindowSizeY", block.u16(18)).
+		Set("WindowOriginX", block.u16(20)).
+		Set("WindowOriginY", block.u16(22)).
+		Set("FontSize", block.u32(32)).
+		Set("FontFamily", block.u32(36)).
+		Set("FontWeight", block.u32(40)).
+		Set("FaceName", block.utf16Field(44, 64)).
+		Set("CursorSize", block.u32(108)).
+		Set("FullScreen", block.u32(112) != 0).
+		Set("QuickEdit", block.u32(116) != 0).
+		Set("InsertMode", block.u32(120) != 0).
+		Set("AutoPosition", block.u32(124) != 0).
+		Set("HistoryBufferSize", block.u32(128)).
+		Set("NumberOfHistoryBuffers", block.u32(132)).
+		Set("HistoryNoDup", block.u32(136) != 0)
+}
+
+// The tracker block records the machine the link was created on and
+// the distributed link tracking ids of the target.
+func parseTrackerBlock(block buffer) *ordereddict.Dict {
+	result := ordereddict.NewDict().
+		Set("MachineID", block.asciiField(16, 16)).
+		Set("VolumeDroid", block.guid(32)).
+		Set("FileDroid", block.guid(48)).
+		Set("BirthVolumeDroid", block.guid(64)).
+		Set("BirthFileDroid", block.guid(80))
+
+	// The file droids are version 1 UUIDs which contain the MAC
+	// address of the machine and the time they were created.
+	droid := block.slice(48, 16)
+	if droid != nil && droid[7]>>4 == 1 {
+		result.Set("MACAddress", net.HardwareAddr(droid[10:16]).String()).
+			Set("Timestamp", uuidTime(droid))
+	}
+
+	return result
+}
+
+func uuidTime(uuid buffer) time.Time {
+	timestamp := uint64(uuid.u32(0)) |
+		uint64(uuid.u16(4))<<32 |
+		uint64(uuid.u16(6)&0x0fff)<<48
+	if timestamp < UUID_EPOCH_OFFSET {
+		return time.Time{}
+	}
+
+	timestamp -= UUID_EPOCH_OFFSET
+	return time.Unix(int64(timestamp/10000000),
+		int64(timestamp%10000000)*100).UTC()
+}
@@ -0,0 +1,88 @@
+package lnk
+
+const (
+	SHELL_LINK_CLSID = "{00021401-0000-0000-C000-000000000046}"
+
+	// Property sets with string named properties.
+	STRING_NAMED_PROPERTIES = "{D5CDD505-2E9C-101B-9397-08002B2CF9AE}"
+)
+
+// Names of common shell folders. These appear as root folder shell
+// items and in the known folder data block.
+var folderNames = map[string]string{
+	"{20D04FE0-3AEA-1069-A2D8-08002B30309D}": "My Computer",
+	"{450D8FBA-AD25-11D0-98A8-0800361B1103}": "My Documents",
+	"{208D2C60-3AEA-1069-A2D7-08002B30309D}": "My Network Places",
+	"{F02C1A0D-BE21-4350-88B0-7367FC96EF3C}": "Network",
+	"{645FF040-5081-101B-9F08-00AA002F954E}": "Recycle Bin",
+	"{21EC2020-3AEA-1069-A2DD-08002B30309D}": "Control Panel",
+	"{26EE0668-A00A-44D7-9371-BEB064C98683}": "Control Panel",
+	"{5399E694-6CE5-4D6C-8FCE-1D8870FDCBA0}": "Control Panel Home",
+	"{2227A280-3AEA-1069-A2DE-08002B30309D}": "Printers",
+	"{59031A47-3F72-44A7-89C5-5595FE6B30EE}": "Users Files",
+	"{871C5380-42A0-1069-A2EA-08002B30309D}": "Internet Explorer",
+	"{679F85CB-0220-4080-B29B-5540CC05AAB6}": "Quick Access",
+	"{F874310E-B6B7-47DC-BC84-B9E6B38F5903}": "Home",
+	"{031E4825-7B94-4DC3-B131-E946B44C8DD5}": "Libraries",
+	"{4234D49B-0245-4DF3-B780-3893943456E1}": "Applications",
+	"{B4BFCC3A-DB2C-424C-B029-7FE99A87C641}": "Desktop",
+	"{FDD39AD0-238F-46AF-ADB4-6C85480369C7}": "Documents",
+	"{374DE290-123F-4565-9164-39C4925E467B}": "Downloads",
+	"{4BD8D571-6D19-48D3-BE97-422220080E43}": "Music",
+	"{33E28130-4E1E-4676-835A-98395C3BC3BB}": "Pictures",
+	"{18989B1D-99B5-455B-841C-AB7C74E4DDFC}": "Videos",
+	"{1777F761-68AD-4D8A-87BD-30B759FA33DD}": "Favorites",
+	"{5E6C858F-0E22-4760-9AFE-EA3317B67173}": "User Profile",
+	"{F38BF404-1D43-42F2-9305-67DE0B28FC23}": "Windows",
+	"{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}": "System32",
+	"{D65231B0-B2F1-4857-A4CE-A8E7C6EA7D27}": "SysWOW64",
+	"{905E63B6-C1BF-494E-B29C-65B732D3D21A}": "Program Files",
+	"{7C5A40EF-A0FB-4BFC-874A-C0F2E0B9FA8E}": "Program Files (x86)",
+	"{6365D5A7-0F0D-45E5-87F6-0DA56B6A4F7D}": "Program Files Common",
+	"{62AB5D82-FDC1-4DC3-A9DD-070D1D495D97}": "Program Data",
+	"{A77F5D77-2E2B-44C3-A6A2-ABA601054A51}": "Programs",
+	"{625B53C3-AB48-4EC1-BA1F-A1EF4146FC19}": "Start Menu",
+	"{B97D20BB-F46A-4C97-BA10-5E3608430854}": "Startup",
+	"{3EB685DB-65F9-4CF6-A03A-E3EF65729F3D}": "Roaming AppData",
+	"{F1B32785-6FBA-4FCF-9D55-7B8E7F157091}": "Local AppData",
+	"{A520A1A4-1780-4FF6-BD18-167343C5AF16}": "LocalLow AppData",
+	"{C4AA340D-F20F-4863-AFEF-F87EF2E6BA25}": "Public Desktop",
+	"{ED4824AF-DCE4-45A8-81E2-FC7965083634}": "Public Documents",
+	"{AE50C081-EBD2-438A-8655-8A092E34987A}": "Recent Items",
+	"{8983036C-27C0-404B-8F08-102D10DCFD74}": "SendTo",
+	"{A63293E8-664E-48DB-A079-DF759E0509F7}": "Templates",
+	"{0762D272-C50A-4BB0-A382-697DCD729B80}": "Users",
+	"{DFDF76A2-C82A-4D63-906A-5644AC457385}": "Public",
+	"{A52BBA46-E9E1-435F-B3D9-28DAA648C0F6}": "OneDrive",
+	"{0DB7E03F-FC29-4DC6-9020-FF41B59E513A}": "3D Objects",
+	"{9E3995AB-1F9C-4F13-B827-48B24B6C7174}": "User Pinned",
+}
+
+type propertyKey struct {
+	format_id string
+	id        uint32
+}
+
+// Names of common properties in the property store.
+var propertyNames = map[propertyKey]string{
+	{"{B725F130-47EF-101A-A5F1-02608C9EEBAC}", 4}:   "System.ItemTypeText",
+	{"{B725F130-47EF-101A-A5F1-02608C9EEBAC}", 10}:  "System.ItemNameDisplay",
+	{"{B725F130-47EF-101A-A5F1-02608C9EEBAC}", 12}:  "System.Size",
+	{"{B725F130-47EF-101A-A5F1-02608C9EEBAC}", 13}:  "System.FileAttributes",
+	{"{B725F130-47EF-101A-A5F1-02608C9EEBAC}", 14}:  "System.DateModified",
+	{"{B725F130-47EF-101A-A5F1-02608C9EEBAC}", 15}:  "System.DateCreated",
+	{"{B725F130-47EF-101A-A5F1-02608C9EEBAC}", 16}:  "System.DateAccessed",
+	{"{28636AA6-953D-11D2-B5D6-00C04FD918D0}", 30}:  "System.ParsingPath",
+	{"{446D16B1-8DAD-4870-A748-402EA43D788C}", 100}: "System.ThumbnailCacheId",
+	{"{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3}", 5}:   "System.AppUserModel.ID",
+	{"{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3}", 2}:   "System.AppUserModel.RelaunchCommand",
+	{"{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3}", 3}:   "System.AppUserModel.RelaunchIconResource",
+	{"{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3}", 4}:   "System.AppUserModel.RelaunchDisplayNameResource",
+	{"{DABD30ED-0043-4789-A7F8-D013A4736622}", 100}: "System.ItemFolderPathDisplayNarrow",
+	{"{E3E0584C-B788-4A5A-BB20-7F5A44C9ACDD}", 6}:   "System.ItemFolderPathDisplay",
+	{"{F29F85E0-4FF9-1068-AB91-08002B27B3D9}", 2}:   "System.Title",
+	{"{F29F85E0-4FF9-1068-AB91-08002B27B3D9}", 4}:   "System.Author",
+	{"{49691C90-7E17-101A-A91C-08002B2ECDA9}", 3}:   "System.Search.Rank",
+	{"{B9B4B3FC-2B51-4A42-B5D8-324146AFCF25}", 2}:   "System.Link.TargetParsingPath",
+	{"{B9B4B3FC-2B51-4A42-B5D8-324146AFCF25}", 8}:   "System.Link.TargetSFGAOFlags",
+}
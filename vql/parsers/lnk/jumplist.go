@@ -0,0 +1,207 @@
+package lnk
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/oleparse"
+)
+
+const (
+	DESTLIST_STREAM      = "DestList"
+	DESTLIST_HEADER_SIZE = 32
+
+	// Sanity limit on the number of entries in a jump list.
+	MAX_JUMPLIST_ENTRIES = 10000
+
+	CUSTOM_CATEGORY_CUSTOM = 0
+	CUSTOM_CATEGORY_KNOWN  = 1
+	CUSTOM_CATEGORY_TASKS  = 2
+
+	CUSTOM_DESTINATIONS_FOOTER = 0xbabffbab
+)
+
+var (
+	knownCategories = map[uint32]string{
+		1: "Frequent",
+		2: "Recent",
+	}
+)
+
+// An entry in a jump list.
+type JumpListEntry struct {
+	// The OLE stream the link was stored in (AutomaticDestinations).
+	Stream string
+
+	// The category of the link (CustomDestinations).
+	Category string
+
+	DestList *ordereddict.Dict
+	Lnk      *ordereddict.Dict
+}
+
+// Parse an AutomaticDestinations-ms jump list. These are OLE files
+// with a stream for each link and a DestList stream describing them.
+func ParseAutomaticDestinations(data []byte) ([]*JumpListEntry, error) {
+	ole, err := oleparse.NewOLEFile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dest_list := make(map[string]*ordereddict.Dict)
+	dest_list_data, err := ole.OpenStreamByName(DESTLIST_STREAM)
+	if err == nil {
+		dest_list = parseDestList(dest_list_data)
+	}
+
+	result := []*JumpListEntry{}
+	for _, dir := range ole.Directory {
+		// Only the numbered streams contain links.
+		_, err := strconv.ParseUint(dir.Name, 16, 64)
+		if err != nil || dir.Header.Mse != 2 {
+			continue
+		}
+
+		entry := &JumpListEntry{
+			Stream:   dir.Name,
+			DestList: dest_list[dir.Name],
+		}
+
+		lnk, _, err := ParseLnk(ole.GetStream(dir.Index))
+		if err == nil {
+			entry.Lnk = lnk
+		}
+		result = append(result, entry)
+
+		if len(result) > MAX_JUMPLIST_ENTRIES {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// The DestList records the access history of each link. Entries are
+// keyed by their stream name (the entry number in hex).
+func parseDestList(data buffer) map[string]*ordereddict.Dict {
+	result := make(map[string]*ordereddict.Dict)
+
+	version := data.u32(0)
+	count := int(data.u32(4))
+
+	offset := DESTLIST_HEADER_SIZE
+	for i := 0; i < count && i < MAX_JUMPLIST_ENTRIES; i++ {
+		entry := data.from(offset)
+		if len(entry) < 114 {
+			break
+		}
+
+		path_offset := 112
+		if version > 1 {
+			path_offset = 128
+		}
+		path_length := int(entry.u16(path_offset))
+		size := path_offset + 2 + 2*path_length
+		if version > 1 {
+			size += 4
+		}
+		if size > len(entry) {
+			break
+		}
+		offset += size
+
+		entry_number := entry.u32(88)
+		item := ordereddict.NewDict().
+			Set("EntryNumber", entry_number).
+			Set("Path", entry.utf16(path_offset+2, path_length)).
+			Set("Hostname", entry.asciiField(72, 16)).
+			Set("LastModified", entry.filetime(100)).
+			Set("Pinned", int32(entry.u32(108)) >= 0).
+			Set("VolumeDroid", entry.guid(8)).
+			Set("FileDroid", entry.guid(24)).
+			Set("BirthVolumeDroid", entry.guid(40)).
+			Set("BirthFileDroid", entry.guid(56))
+
+		if version > 1 {
+			item.Set("AccessCount", entry.u32(116))
+		}
+
+		droid := entry.slice(24, 16)
+		if droid[7]>>4 == 1 {
+			item.Set("Timestamp", uuidTime(droid))
+		}
+
+		result[fmt.Sprintf("%x", entry_number)] = item
+	}
+
+	return result
+}
+
+// Parse a CustomDestinations-ms jump list. These contain categories
+// of links, each link preceded by the shell link CLSID.
+func ParseCustomDestinations(data []byte) ([]*JumpListEntry, error) {
+	file := buffer(data)
+	if len(file) < 12 {
+		return nil, errors.New("CustomDestinations file is too short")
+	}
+	category_count := int(file.u32(4))
+
+	result := []*JumpListEntry{}
+	offset := 12
+	for i := 0; i < category_count; i++ {
+		category_type := file.u32(offset)
+		offset += 4
+
+		category := ""
+		entry_count := 0
+
+		switch category_type {
+		case CUSTOM_CATEGORY_CUSTOM:
+			length := int(file.u16(offset))
+			category = file.utf16(offset+2, length)
+			offset += 2 + 2*length
+			entry_count = int(file.u32(offset))
+			offset += 4
+
+		case CUSTOM_CATEGORY_KNOWN:
+			category = knownCategories[file.u32(offset)]
+			offset += 4
+
+		case CUSTOM_CATEGORY_TASKS:
+			category = "Tasks"
+			entry_count = int(file.u32(offset))
+			offset += 4
+
+		default:
+			return result, fmt.Errorf(
+				"Unknown category type %v at %#x", category_type, offset)
+		}
+
+		for j := 0; j < entry_count && len(result) < MAX_JUMPLIST_ENTRIES; j++ {
+			if file.guid(offset) != SHELL_LINK_CLSID {
+				return result, fmt.Errorf("Invalid entry at %#x", offset)
+			}
+			offset += 16
+
+			lnk, size, err := ParseLnk(file.from(offset))
+			if err != nil {
+				return result, err
+			}
+			offset += size
+
+			result = append(result, &JumpListEntry{
+				Category: category,
+				Lnk:      lnk,
+			})
+		}
+
+		if file.u32(offset) != CUSTOM_DESTINATIONS_FOOTER {
+			return result, fmt.Errorf("Invalid category footer at %#x", offset)
+		}
+		offset += 4
+	}
+
+	return result, nil
+}
@@ -0,0 +1,302 @@
+// A parser for Windows Shell Link (LNK) files.
+//
+// References:
+// https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink
+// https://github.com/libyal/libfwsi/blob/main/documentation/Windows%20Shell%20Item%20format.asciidoc
+
+package lnk
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+const (
+	HEADER_SIZE = 0x4c
+
+	// Link flags
+	HAS_LINK_TARGET_ID_LIST = 0x00000001
+	HAS_LINK_INFO           = 0x00000002
+	HAS_NAME                = 0x00000004
+	HAS_RELATIVE_PATH       = 0x00000008
+	HAS_WORKING_DIR         = 0x00000010
+	HAS_ARGUMENTS           = 0x00000020
+	HAS_ICON_LOCATION       = 0x00000040
+	IS_UNICODE              = 0x00000080
+
+	// LinkInfo flags
+	VOLUME_ID_AND_LOCAL_BASE_PATH                = 0x01
+	COMMON_NETWORK_RELATIVE_LINK_AND_PATH_SUFFIX = 0x02
+
+	// CommonNetworkRelativeLink flags
+	VALID_DEVICE   = 0x01
+	VALID_NET_TYPE = 0x02
+)
+
+var (
+	notLnkError = errors.New("Not a shell link")
+
+	linkFlags = map[uint32]string{
+		0x00000001: "HasLinkTargetIDList",
+		0x00000002: "HasLinkInfo",
+		0x00000004: "HasName",
+		0x00000008: "HasRelativePath",
+		0x00000010: "HasWorkingDir",
+		0x00000020: "HasArguments",
+		0x00000040: "HasIconLocation",
+		0x00000080: "IsUnicode",
+		0x00000100: "ForceNoLinkInfo",
+		0x00000200: "HasExpString",
+		0x00000400: "RunInSeparateProcess",
+		0x00001000: "HasDarwinID",
+		0x00002000: "RunAsUser",
+		0x00004000: "HasExpIcon",
+		0x00008000: "NoPidlAlias",
+		0x00020000: "RunWithShimLayer",
+		0x00040000: "ForceNoLinkTrack",
+		0x00080000: "EnableTargetMetadata",
+		0x00100000: "DisableLinkPathTracking",
+		0x00200000: "DisableKnownFolderTracking",
+		0x00400000: "DisableKnownFolderAlias",
+		0x00800000: "AllowLinkToLink",
+		0x01000000: "UnaliasOnSave",
+		0x02000000: "PreferEnvironmentPath",
+		0x04000000: "KeepLocalIDListForUNCTarget",
+	}
+
+	fileAttributes = map[uint32]string{
+		0x0001: "READONLY",
+		0x0002: "HIDDEN",
+		0x0004: "SYSTEM",
+		0x0010: "DIRECTORY",
+		0x0020: "ARCHIVE",
+		0x0040: "DEVICE",
+		0x0080: "NORMAL",
+		0x0100: "TEMPORARY",
+		0x0200: "SPARSE_FILE",
+		0x0400: "REPARSE_POINT",
+		0x0800: "COMPRESSED",
+		0x1000: "OFFLINE",
+		0x2000: "NOT_CONTENT_INDEXED",
+		0x4000: "ENCRYPTED",
+	}
+
+	showCommands = map[uint32]string{
+		1: "SW_SHOWNORMAL",
+		3: "SW_SHOWMAXIMIZED",
+		7: "SW_SHOWMINNOACTIVE",
+	}
+
+	driveTypes = map[uint32]string{
+		0: "DRIVE_UNKNOWN",
+		1: "DRIVE_NO_ROOT_DIR",
+		2: "DRIVE_REMOVABLE",
+		3: "DRIVE_FIXED",
+		4: "DRIVE_REMOTE",
+		5: "DRIVE_CDROM",
+		6: "DRIVE_RAMDISK",
+	}
+
+	hotKeyModifiers = map[uint32]string{
+		0x01: "SHIFT",
+		0x02: "CTRL",
+		0x04: "ALT",
+	}
+)
+
+// Parse a shell link. Returns the decoded link and its size so
+// links embedded in other files (e.g. jump lists) can be walked.
+func ParseLnk(data []byte) (*ordereddict.Dict, int, error) {
+	lnk := buffer(data)
+	if lnk.u32(0) != HEADER_SIZE || lnk.guid(4) != SHELL_LINK_CLSID {
+		return nil, 0, notLnkError
+	}
+
+	flags := lnk.u32(0x14)
+	result := ordereddict.NewDict().
+		Set("Header", parseHeader(lnk))
+
+	offset := HEADER_SIZE
+	if flags&HAS_LINK_TARGET_ID_LIST != 0 {
+		size := int(lnk.u16(offset))
+		id_list := lnk.slice(offset+2, size)
+		if id_list == nil {
+			return nil, 0, errors.New("LinkTargetIDList is truncated")
+		}
+
+		items, path := parseIDList(id_list)
+		result.Set("LinkTarget", ordereddict.NewDict().
+			Set("Path", path).
+			Set("ShellItems", items))
+		offset += 2 + size
+	}
+
+	if flags&HAS_LINK_INFO != 0 {
+		size := int(lnk.u32(offset))
+		link_info := lnk.slice(offset, size)
+		if link_info == nil {
+			return nil, 0, errors.New("LinkInfo is truncated")
+		}
+		result.Set("LinkInfo", parseLinkInfo(link_info))
+		offset += size
+	}
+
+	string_data := ordereddict.NewDict()
+	for _, field := range []struct {
+		flag uint32
+		name string
+	}{
+		{HAS_NAME, "Name"},
+		{HAS_RELATIVE_PATH, "RelativePath"},
+		{HAS_WORKING_DIR, "WorkingDir"},
+		{HAS_ARGUMENTS, "Arguments"},
+		{HAS_ICON_LOCATION, "IconLocation"},
+	} {
+		if flags&field.flag == 0 {
+			continue
+		}
+
+		count := int(lnk.u16(offset))
+		offset += 2
+
+		if flags&IS_UNICODE != 0 {
+			string_data.Set(field.name, lnk.utf16(offset, count))
+			offset += 2 * count
+		} else {
+			string_data.Set(field.name, string(lnk.slice(offset, count)))
+			offset += count
+		}
+
+		if offset > len(lnk) {
+			return nil, 0, errors.New("StringData is truncated")
+		}
+	}
+	result.Set("StringData", string_data)
+
+	extra_data, size := parseExtraData(lnk.from(offset))
+	result.Set("ExtraData", extra_data)
+	offset += size
+
+	return result, offset, nil
+}
+
+func parseHeader(lnk buffer) *ordereddict.Dict {
+	show_command, pres := showCommands[lnk.u32(0x3c)]
+	if !pres {
+		show_command = fmt.Sprintf("%#x", lnk.u32(0x3c))
+	}
+
+	return ordereddict.NewDict().
+		Set("Flags", flagNames(lnk.u32(0x14), linkFlags)).
+		Set("FileAttributes", flagNames(lnk.u32(0x18), fileAttributes)).
+		Set("CreationTime", lnk.filetime(0x1c)).
+		Set("AccessTime", lnk.filetime(0x24)).
+		Set("WriteTime", lnk.filetime(0x2c)).
+		Set("FileSize", lnk.u32(0x34)).
+		Set("IconIndex", int32(lnk.u32(0x38))).
+		Set("ShowCommand", show_command).
+		Set("HotKey", hotKeyName(lnk.u16(0x40)))
+}
+
+// The low byte is the virtual key code and the high byte the
+// modifiers.
+func hotKeyName(hot_key uint16) string {
+	if hot_key == 0 {
+		return ""
+	}
+
+	key := hot_key & 0xff
+	key_name := fmt.Sprintf("%#02x", key)
+	switch {
+	case key >= 0x30 && key <= 0x5a:
+		key_name = string(rune(key))
+	case key >= 0x70 && key <= 0x87:
+		key_name = fmt.Sprintf("F%d", key-0x6f)
+	}
+
+	return strings.Join(append(flagNames(uint32(hot_key>>8), hotKeyModifiers),
+		key_name), "+")
+}
+
+// The LinkInfo describes how to find the target if it moves.
+func parseLinkInfo(link_info buffer) *ordereddict.Dict {
+	header_size := link_info.u32(4)
+	flags := link_info.u32(8)
+	result := ordereddict.NewDict()
+
+	// Unicode versions of the paths are present in larger headers.
+	getPath := func(ansi_offset, unicode_offset int) string {
+		if header_size >= 0x24 {
+			offset := int(link_info.u32(unicode_offset))
+			if offset != 0 {
+				value, _ := link_info.utf16z(offset)
+				return value
+			}
+		}
+		return link_info.asciiz(int(link_info.u32(ansi_offset)))
+	}
+
+	local_base_path := ""
+	if flags&VOLUME_ID_AND_LOCAL_BASE_PATH != 0 {
+		volume_id := link_info.from(int(link_info.u32(0x0c)))
+
+		label := ""
+		label_offset := int(volume_id.u32(0x0c))
+		if label_offset == 0x14 {
+			label, _ = volume_id.utf16z(int(volume_id.u32(0x10)))
+		} else {
+			label = volume_id.asciiz(label_offset)
+		}
+
+		result.Set("VolumeID", ordereddict.NewDict().
+			Set("DriveType", driveTypes[volume_id.u32(0x04)]).
+			Set("DriveSerialNumber", fmt.Sprintf("%08X", volume_id.u32(0x08))).
+			Set("VolumeLabel", label))
+
+		local_base_path = getPath(0x10, 0x1c)
+		result.Set("LocalBasePath", local_base_path)
+	}
+
+	if flags&COMMON_NETWORK_RELATIVE_LINK_AND_PATH_SUFFIX != 0 {
+		link := link_info.from(int(link_info.u32(0x14)))
+		link_flags := link.u32(0x04)
+		net_name_offset := int(link.u32(0x08))
+		device_name_offset := int(link.u32(0x0c))
+
+		net_name := link.asciiz(net_name_offset)
+		device_name := ""
+		if link_flags&VALID_DEVICE != 0 {
+			device_name = link.asciiz(device_name_offset)
+		}
+
+		if net_name_offset > 0x14 {
+			net_name, _ = link.utf16z(int(link.u32(0x14)))
+			if link_flags&VALID_DEVICE != 0 {
+				device_name, _ = link.utf16z(int(link.u32(0x18)))
+			}
+		}
+
+		network := ordereddict.NewDict().
+			Set("NetName", net_name).
+			Set("DeviceName", device_name)
+		if link_flags&VALID_NET_TYPE != 0 {
+			network.Set("NetworkProviderType",
+				fmt.Sprintf("%#x", link.u32(0x10)))
+		}
+		result.Set("CommonNetworkRelativeLink", network)
+
+		// The path is relative to the network share.
+		if local_base_path == "" {
+			local_base_path = net_name + "\\"
+		}
+	}
+
+	suffix := getPath(0x18, 0x20)
+	result.Set("CommonPathSuffix", suffix).
+		Set("Path", local_base_path+suffix)
+
+	return result
+}
@@ -0,0 +1,295 @@
+package lnk
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"log"
+	"os"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/suite"
+	"www.velocidex.com/golang/oleparse"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/data"
+)
+
+const (
+	testSectorSize = 512
+
+	ENDOFCHAIN = 0xfffffffe
+	FREESECT   = 0xffffffff
+	FATSECT    = 0xfffffffd
+)
+
+func get(dict *ordereddict.Dict, path ...string) interface{} {
+	var value interface{} = dict
+	for _, p := range path {
+		d, ok := value.(*ordereddict.Dict)
+		if !ok {
+			return nil
+		}
+		value, _ = d.Get(p)
+	}
+	return value
+}
+
+func utf16Bytes(value string) []byte {
+	b := &bytes.Buffer{}
+	binary.Write(b, binary.LittleEndian, utf16.Encode([]rune(value)))
+	return b.Bytes()
+}
+
+func pad(data []byte, size int) []byte {
+	for len(data)%size != 0 {
+		data = append(data, 0)
+	}
+	return data
+}
+
+// Build a minimal OLE compound file with the streams stored in
+// regular sectors (the mini stream cutoff is 0).
+func buildOLE(names []string, streams [][]byte) []byte {
+	header := make([]byte, testSectorSize)
+	copy(header, oleparse.OLE_SIGNATURE)
+	binary.LittleEndian.PutUint16(header[0x18:], 0x3e)
+	binary.LittleEndian.PutUint16(header[0x1a:], 3)
+	binary.LittleEndian.PutUint16(header[0x1c:], 0xfffe)
+	binary.LittleEndian.PutUint16(header[0x1e:], 9)
+	binary.LittleEndian.PutUint16(header[0x20:], 6)
+	binary.LittleEndian.PutUint32(header[0x2c:], 1) // FAT sectors
+	binary.LittleEndian.PutUint32(header[0x30:], 1) // Directory start
+	binary.LittleEndian.PutUint32(header[0x38:], 0) // Mini stream cutoff
+	binary.LittleEndian.PutUint32(header[0x3c:], ENDOFCHAIN)
+	binary.LittleEndian.PutUint32(header[0x44:], ENDOFCHAIN)
+	for i := 0; i < 109; i++ {
+		binary.LittleEndian.PutUint32(header[0x4c+4*i:], FREESECT)
+	}
+	binary.LittleEndian.PutUint32(header[0x4c:], 0)
+
+	fat := make([]uint32, testSectorSize/4)
+	for i := range fat {
+		fat[i] = FREESECT
+	}
+	fat[0] = FATSECT
+	fat[1] = ENDOFCHAIN
+
+	directory := make([]byte, testSectorSize)
+	addEntry := func(idx int, name string, mse byte, start, size uint32) {
+		entry := directory[idx*128:]
+		copy(entry, utf16Bytes(name))
+		binary.LittleEndian.PutUint16(entry[0x40:], uint16(2*len(name)+2))
+		entry[0x42] = mse
+		binary.LittleEndian.PutUint32(entry[0x74:], start)
+		binary.LittleEndian.PutUint32(entry[0x78:], size)
+	}
+	addEntry(0, "Root Entry", 5, ENDOFCHAIN, 0)
+
+	data := []byte{}
+	sector := uint32(2)
+	for i, stream := range streams {
+		addEntry(i+1, names[i], 2, sector, uint32(len(stream)))
+
+		count := (len(stream) + testSectorSize - 1) / testSectorSize
+		for j := 0; j < count; j++ {
+			fat[sector] = sector + 1
+			sector++
+		}
+		fat[sector-1] = ENDOFCHAIN
+		data = append(data, pad(stream, testSectorSize)...)
+	}
+
+	b := &bytes.Buffer{}
+	b.Write(header)
+	binary.Write(b, binary.LittleEndian, fat)
+	b.Write(directory)
+	b.Write(data)
+	return b.Bytes()
+}
+
+// A Windows 10 DestList with a single entry.
+func buildDestList(entry_number uint32, path string) []byte {
+	header := make([]byte, DESTLIST_HEADER_SIZE)
+	binary.LittleEndian.PutUint32(header[0:], 4)
+	binary.LittleEndian.PutUint32(header[4:], 1)
+
+	entry := make([]byte, 130)
+	copy(entry[8:], bytes.Repeat([]byte{0xaa}, 16))
+	copy(entry[72:], "workstation")
+	binary.LittleEndian.PutUint32(entry[88:], entry_number)
+	binary.LittleEndian.PutUint64(entry[100:], 132673683122799701)
+	binary.LittleEndian.PutUint32(entry[108:], 0xffffffff)
+	binary.LittleEndian.PutUint32(entry[116:], 3)
+	binary.LittleEndian.PutUint16(entry[128:], uint16(len(path)))
+
+	result := append(header, entry...)
+	result = append(result, utf16Bytes(path)...)
+	return append(result, 0, 0, 0, 0)
+}
+
+type LnkTestSuite struct {
+	suite.Suite
+
+	cmd_lnk, share_lnk []byte
+}
+
+func (self *LnkTestSuite) SetupTest() {
+	var err error
+	self.cmd_lnk, err = os.ReadFile(
+		"../../../artifacts/testdata/files/password.txt.lnk")
+	assert.NoError(self.T(), err)
+
+	self.share_lnk, err = os.ReadFile("../../../artifacts/testdata/files/1.lnk")
+	assert.NoError(self.T(), err)
+}
+
+func (self *LnkTestSuite) parseJumplist(data []byte) []*ordereddict.Dict {
+	ctx := context.Background()
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	scope.SetLogger(log.New(os.Stderr, "", 0))
+	defer scope.Close()
+
+	args := ordereddict.NewDict().
+		Set("filename", string(data)).
+		Set("accessor", "data")
+
+	result := []*ordereddict.Dict{}
+	for row := range (JumpListPlugin{}).Call(ctx, scope, args) {
+		result = append(result, row.(*ordereddict.Dict))
+	}
+	return result
+}
+
+func (self *LnkTestSuite) TestLnk() {
+	lnk, size, err := ParseLnk(self.cmd_lnk)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), len(self.cmd_lnk), size)
+
+	assert.Equal(self.T(), "C:\\Windows\\System32\\cmd.exe",
+		get(lnk, "LinkTarget", "Path"))
+	assert.Equal(self.T(), "C:\\Windows\\System32\\cmd.exe",
+		get(lnk, "LinkInfo", "Path"))
+	assert.Equal(self.T(), "DRIVE_FIXED",
+		get(lnk, "LinkInfo", "VolumeID", "DriveType"))
+	assert.Equal(self.T(), "/c \"echo HeLLO && pAuSe\"",
+		get(lnk, "StringData", "Arguments"))
+	assert.Equal(self.T(), "%windir%\\sYSteM32",
+		get(lnk, "StringData", "WorkingDir"))
+	assert.Equal(self.T(), uint32(331776), get(lnk, "Header", "FileSize"))
+	assert.Equal(self.T(),
+		time.Date(2021, 6, 5, 12, 5, 12, 279970100, time.UTC),
+		get(lnk, "Header", "CreationTime"))
+
+	items := get(lnk, "LinkTarget", "ShellItems").([]*ordereddict.Dict)
+	assert.Equal(self.T(), 5, len(items))
+	assert.Equal(self.T(), "My Computer", get(items[0], "Name"))
+	assert.Equal(self.T(), "File", get(items[4], "Type"))
+	assert.Equal(self.T(), uint64(43282), get(items[4], "MFTEntry"))
+
+	// Extra data blocks
+	assert.Equal(self.T(), "%sYsTemRooT%\\sYSteM32\\cMd.Exe",
+		get(lnk, "ExtraData", "EnvironmentVariables", "TargetUnicode"))
+	assert.Equal(self.T(), "System32",
+		get(lnk, "ExtraData", "KnownFolder", "Name"))
+	assert.Equal(self.T(), "cthdsk",
+		get(lnk, "ExtraData", "Tracker", "MachineID"))
+	assert.Equal(self.T(), "b4:2e:99:af:ad:fa",
+		get(lnk, "ExtraData", "Tracker", "MACAddress"))
+
+	properties := get(lnk, "ExtraData", "PropertyStore").([]*ordereddict.Dict)
+	assert.Equal(self.T(), 9, len(properties))
+	assert.Equal(self.T(), "System.ParsingPath", get(properties[7], "Name"))
+	assert.Equal(self.T(), "C:\\Windows\\System32\\cmd.exe",
+		get(properties[7], "Value"))
+	assert.Equal(self.T(), uint64(331776), get(properties[4], "Value"))
+
+	// A link to a network share.
+	lnk, _, err = ParseLnk(self.share_lnk)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), "F:\\tmp\\1.yaml", get(lnk, "LinkTarget", "Path"))
+	assert.Equal(self.T(), "F:", get(lnk, "LinkInfo",
+		"CommonNetworkRelativeLink", "DeviceName"))
+	assert.Equal(self.T(), "\\\\vmware-host\\Shared Folders\\shared\\tmp\\1.yaml",
+		get(lnk, "LinkInfo", "Path"))
+
+	// Truncated links are rejected.
+	_, _, err = ParseLnk(self.cmd_lnk[:200])
+	assert.Error(self.T(), err)
+}
+
+func (self *LnkTestSuite) TestAutomaticDestinations() {
+	rows := self.parseJumplist(buildOLE(
+		[]string{"1", "DestList"},
+		[][]byte{self.cmd_lnk,
+			buildDestList(1, "C:\\Windows\\System32\\cmd.exe")}))
+	assert.Equal(self.T(), 1, len(rows))
+
+	row := rows[0]
+	assert.Equal(self.T(), "Automatic", get(row, "Type"))
+	assert.Equal(self.T(), "1", get(row, "Stream"))
+	assert.Equal(self.T(), "C:\\Windows\\System32\\cmd.exe",
+		get(row, "Lnk", "LinkTarget", "Path"))
+	assert.Equal(self.T(), "C:\\Windows\\System32\\cmd.exe",
+		get(row, "DestList", "Path"))
+	assert.Equal(self.T(), "workstation", get(row, "DestList", "Hostname"))
+	assert.Equal(self.T(), uint32(3), get(row, "DestList", "AccessCount"))
+	assert.Equal(self.T(), false, get(row, "DestList", "Pinned"))
+	assert.Equal(self.T(),
+		time.Date(2021, 6, 5, 12, 5, 12, 279970100, time.UTC),
+		get(row, "DestList", "LastModified"))
+}
+
+func (self *LnkTestSuite) TestCustomDestinations() {
+	b := &bytes.Buffer{}
+	write := func(values ...interface{}) {
+		for _, v := range values {
+			binary.Write(b, binary.LittleEndian, v)
+		}
+	}
+	clsid := []byte{1, 0x14, 2, 0, 0, 0, 0, 0, 0xc0, 0, 0, 0, 0, 0, 0, 0x46}
+
+	write(uint32(2), uint32(3), uint32(0))
+
+	// A custom category
+	write(uint32(CUSTOM_CATEGORY_CUSTOM), uint16(6), utf16Bytes("Pinned"),
+		uint32(2), clsid, self.cmd_lnk, clsid, self.share_lnk,
+		uint32(CUSTOM_DESTINATIONS_FOOTER))
+
+	// A known category has no entries.
+	write(uint32(CUSTOM_CATEGORY_KNOWN), uint32(2),
+		uint32(CUSTOM_DESTINATIONS_FOOTER))
+
+	write(uint32(CUSTOM_CATEGORY_TASKS), uint32(1), clsid, self.share_lnk,
+		uint32(CUSTOM_DESTINATIONS_FOOTER))
+
+	rows := self.parseJumplist(b.Bytes())
+	assert.Equal(self.T(), 3, len(rows))
+
+	paths := []interface{}{}
+	categories := []interface{}{}
+	for _, row := range rows {
+		assert.Equal(self.T(), "Custom", get(row, "Type"))
+		paths = append(paths, get(row, "Lnk", "LinkTarget", "Path"))
+		categories = append(categories, get(row, "Category"))
+	}
+	assert.Equal(self.T(), []interface{}{
+		"C:\\Windows\\System32\\cmd.exe", "F:\\tmp\\1.yaml", "F:\\tmp\\1.yaml",
+	}, paths)
+	assert.Equal(self.T(), []interface{}{"Pinned", "Pinned", "Tasks"},
+		categories)
+
+	// Entries before the corruption are still emitted.
+	rows = self.parseJumplist(b.Bytes()[:len(b.Bytes())-20])
+	assert.Equal(self.T(), 2, len(rows))
+}
+
+func TestLnk(t *testing.T) {
+	suite.Run(t, &LnkTestSuite{})
+}
@@ -0,0 +1,185 @@
+package lnk
+
+import (
+	"context"
+	"io"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/oleparse"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/constants"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type LnkPluginArgs struct {
+	Filenames []*accessors.OSPath `vfilter:"required,field=filename,doc=A list of files to parse."`
+	Accessor  string              `vfilter:"optional,field=accessor,doc=The accessor to use."`
+}
+
+func readFile(scope vfilter.Scope,
+	accessor_name string, filename *accessors.OSPath) ([]byte, error) {
+	accessor, err := accessors.GetAccessor(accessor_name, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := accessor.OpenWithOSPath(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return io.ReadAll(io.LimitReader(fd, constants.MAX_MEMORY))
+}
+
+type LnkPlugin struct{}
+
+func (self LnkPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("parse_lnk", args)()
+
+		arg := &LnkPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("parse_lnk: %v", err)
+			return
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("parse_lnk: %v", err)
+			return
+		}
+
+		for _, filename := range arg.Filenames {
+			data, err := readFile(scope, arg.Accessor, filename)
+			if err != nil {
+				scope.Log("parse_lnk: %v: %v", filename, err)
+				continue
+			}
+
+			lnk, _, err := ParseLnk(data)
+			if err != nil {
+				scope.Log("parse_lnk: %v: %v", filename, err)
+				continue
+			}
+
+			row := ordereddict.NewDict().Set("OSPath", filename)
+			row.MergeFrom(lnk)
+
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- row:
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self LnkPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "parse_lnk",
+		Doc:      "Parse a Windows Shell Link (LNK) file.",
+		ArgType:  type_map.AddType(scope, &LnkPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+type JumpListPlugin struct{}
+
+func (self JumpListPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("parse_jumplist", args)()
+
+		arg := &LnkPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("parse_jumplist: %v", err)
+			return
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("parse_jumplist: %v", err)
+			return
+		}
+
+		for _, filename := range arg.Filenames {
+			data, err := readFile(scope, arg.Accessor, filename)
+			if err != nil {
+				scope.Log("parse_jumplist: %v: %v", filename, err)
+				continue
+			}
+
+			// AutomaticDestinations are OLE files, CustomDestinations
+			// are not.
+			jumplist_type := "Custom"
+			var entries []*JumpListEntry
+			if len(data) >= len(oleparse.OLE_SIGNATURE) &&
+				string(data[:len(oleparse.OLE_SIGNATURE)]) == oleparse.OLE_SIGNATURE {
+				jumplist_type = "Automatic"
+				entries, err = ParseAutomaticDestinations(data)
+			} else {
+				entries, err = ParseCustomDestinations(data)
+			}
+
+			// Emit the entries we could parse even if the file is
+			// partially corrupt.
+			if err != nil {
+				scope.Log("parse_jumplist: %v: %v", filename, err)
+			}
+
+			for _, entry := range entries {
+				select {
+				case <-ctx.Done():
+					return
+				case output_chan <- ordereddict.NewDict().
+					Set("OSPath", filename).
+					Set("Type", jumplist_type).
+					Set("Stream", entry.Stream).
+					Set("Category", entry.Category).
+					Set("DestList", entry.DestList).
+					Set("Lnk", entry.Lnk):
+				}
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self JumpListPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "parse_jumplist",
+		Doc: "Parse a Windows Jump List (AutomaticDestinations-ms or " +
+			"CustomDestinations-ms) and the links it contains.",
+		ArgType:  type_map.AddType(scope, &LnkPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&LnkPlugin{})
+	vql_subsystem.RegisterPlugin(&JumpListPlugin{})
+}
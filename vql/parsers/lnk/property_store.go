@@ -0,0 +1,143 @@
+package lnk
+
+import (
+	"fmt"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+const (
+	PROPERTY_STORAGE_VERSION = 0x53505331 // "1SPS"
+
+	// Sanity limit on the number of properties.
+	MAX_PROPERTIES = 1000
+
+	// Variant types
+	VT_EMPTY    = 0x00
+	VT_NULL     = 0x01
+	VT_I2       = 0x02
+	VT_I4       = 0x03
+	VT_R4       = 0x04
+	VT_R8       = 0x05
+	VT_BSTR     = 0x08
+	VT_BOOL     = 0x0b
+	VT_I1       = 0x10
+	VT_UI1      = 0x11
+	VT_UI2      = 0x12
+	VT_UI4      = 0x13
+	VT_I8       = 0x14
+	VT_UI8      = 0x15
+	VT_INT      = 0x16
+	VT_UINT     = 0x17
+	VT_LPSTR    = 0x1e
+	VT_LPWSTR   = 0x1f
+	VT_FILETIME = 0x40
+	VT_BLOB     = 0x41
+	VT_CLSID    = 0x48
+)
+
+// Parse a serialized property store (MS-PROPSTORE). Each storage
+// is a set of properties with the same format id.
+func parsePropertyStore(data buffer) []*ordereddict.Dict {
+	result := []*ordereddict.Dict{}
+
+	for offset := 0; offset+4 <= len(data) && len(result) < MAX_PROPERTIES; {
+		size := int(data.u32(offset))
+		if size == 0 {
+			break
+		}
+
+		storage := data.slice(offset, size)
+		if storage == nil || storage.u32(4) != PROPERTY_STORAGE_VERSION {
+			break
+		}
+		offset += size
+
+		format_id := storage.guid(8)
+		for pos := 24; pos+4 <= len(storage) && len(result) < MAX_PROPERTIES; {
+			value_size := int(storage.u32(pos))
+			if value_size == 0 {
+				break
+			}
+
+			property := storage.slice(pos, value_size)
+			if property == nil {
+				break
+			}
+			pos += value_size
+
+			item := ordereddict.NewDict().Set("FormatID", format_id)
+
+			var value buffer
+			if format_id == STRING_NAMED_PROPERTIES {
+				name_size := int(property.u32(4))
+				item.Set("Name", property.utf16Field(9, name_size))
+				value = property.from(9 + name_size)
+
+			} else {
+				id := property.u32(4)
+				item.Set("ID", id).
+					Set("Name", propertyNames[propertyKey{format_id, id}])
+				value = property.from(9)
+			}
+
+			value_type, decoded := parseTypedValue(value)
+			result = append(result, item.
+				Set("Type", value_type).
+				Set("Value", decoded))
+		}
+	}
+
+	return result
+}
+
+// Decode a typed property value (MS-OLEPS).
+func parseTypedValue(value buffer) (string, interface{}) {
+	vt := value.u16(0)
+	data := value.from(4)
+
+	switch vt {
+	case VT_EMPTY, VT_NULL:
+		return "VT_EMPTY", nil
+	case VT_I1:
+		return "VT_I1", int8(data.u8(0))
+	case VT_UI1:
+		return "VT_UI1", data.u8(0)
+	case VT_I2:
+		return "VT_I2", int16(data.u16(0))
+	case VT_UI2:
+		return "VT_UI2", data.u16(0)
+	case VT_I4, VT_INT:
+		return "VT_I4", int32(data.u32(0))
+	case VT_UI4, VT_UINT:
+		return "VT_UI4", data.u32(0)
+	case VT_I8:
+		return "VT_I8", int64(data.u64(0))
+	case VT_UI8:
+		return "VT_UI8", data.u64(0)
+	case VT_R4:
+		return "VT_R4", data.f32(0)
+	case VT_R8:
+		return "VT_R8", data.f64(0)
+	case VT_BOOL:
+		return "VT_BOOL", data.u16(0) != 0
+	case VT_FILETIME:
+		return "VT_FILETIME", data.filetime(0)
+	case VT_CLSID:
+		return "VT_CLSID", data.guid(0)
+
+	case VT_LPSTR:
+		return "VT_LPSTR", data.asciiField(4, int(data.u32(0)))
+
+	case VT_LPWSTR:
+		return "VT_LPWSTR", data.utf16(4, int(data.u32(0)))
+
+	case VT_BSTR:
+		return "VT_BSTR", data.utf16Field(4, int(data.u32(0)))
+
+	case VT_BLOB:
+		return "VT_BLOB", fmt.Sprintf("%x", []byte(data.slice(4, int(data.u32(0)))))
+	}
+
+	return fmt.Sprintf("%#x", vt), fmt.Sprintf("%x", []byte(data))
+}
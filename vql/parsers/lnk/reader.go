@@ -0,0 +1,179 @@
+package lnk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// A bounds checked view of a structure. All reads are relative to
+// the start of the structure and reads past the end return zero
+// values.
+type buffer []byte
+
+func (self buffer) slice(offset, length int) buffer {
+	if offset < 0 || length < 0 || offset+length > len(self) {
+		return nil
+	}
+	return self[offset : offset+length]
+}
+
+func (self buffer) from(offset int) buffer {
+	if offset < 0 || offset > len(self) {
+		return nil
+	}
+	return self[offset:]
+}
+
+func (self buffer) u8(offset int) uint8 {
+	b := self.slice(offset, 1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (self buffer) u16(offset int) uint16 {
+	b := self.slice(offset, 2)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+func (self buffer) u32(offset int) uint32 {
+	b := self.slice(offset, 4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (self buffer) u64(offset int) uint64 {
+	b := self.slice(offset, 8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (self buffer) f32(offset int) float32 {
+	return math.Float32frombits(self.u32(offset))
+}
+
+func (self buffer) f64(offset int) float64 {
+	return math.Float64frombits(self.u64(offset))
+}
+
+// A Windows FILETIME. Unset times are returned as the zero time.
+func (self buffer) filetime(offset int) time.Time {
+	return filetimeToTime(self.u64(offset))
+}
+
+func filetimeToTime(value uint64) time.Time {
+	if value == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(value/10000000)-11644473600,
+		int64(value%10000000)*100).UTC()
+}
+
+// Shell items store times as a FAT date followed by a FAT time.
+func (self buffer) fatDateTime(offset int) time.Time {
+	date := self.u16(offset)
+	tm := self.u16(offset + 2)
+	if date == 0 && tm == 0 {
+		return time.Time{}
+	}
+
+	return time.Date(
+		int(date>>9)+1980, time.Month((date>>5)&0x0f), int(date&0x1f),
+		int(tm>>11), int((tm>>5)&0x3f), int(tm&0x1f)*2, 0, time.UTC)
+}
+
+func (self buffer) guid(offset int) string {
+	b := self.slice(offset, 16)
+	if b == nil {
+		return ""
+	}
+	return formatGUID(b)
+}
+
+func formatGUID(b []byte) string {
+	return fmt.Sprintf("{%08X-%04X-%04X-%X-%X}",
+		binary.LittleEndian.Uint32(b[0:4]),
+		binary.LittleEndian.Uint16(b[4:6]),
+		binary.LittleEndian.Uint16(b[6:8]),
+		b[8:10], b[10:16])
+}
+
+// A NUL terminated ASCII string.
+func (self buffer) asciiz(offset int) string {
+	b := self.from(offset)
+	idx := bytes.IndexByte(b, 0)
+	if idx >= 0 {
+		b = b[:idx]
+	}
+	return string(b)
+}
+
+// A NUL padded fixed size ASCII field.
+func (self buffer) asciiField(offset, size int) string {
+	return buffer(self.slice(offset, size)).asciiz(0)
+}
+
+// A NUL terminated UTF16 string. Returns the string and the number
+// of bytes consumed (including the terminator).
+func (self buffer) utf16z(offset int) (string, int) {
+	b := self.from(offset)
+	result := []uint16{}
+	for i := 0; i+1 < len(b); i += 2 {
+		c := binary.LittleEndian.Uint16(b[i:])
+		if c == 0 {
+			return string(utf16.Decode(result)), i + 2
+		}
+		result = append(result, c)
+	}
+	return string(utf16.Decode(result)), len(b)
+}
+
+// A UTF16 string of a fixed number of characters.
+func (self buffer) utf16(offset, count int) string {
+	b := self.slice(offset, 2*count)
+	if b == nil {
+		return ""
+	}
+
+	result := make([]uint16, 0, count)
+	for i := 0; i < count; i++ {
+		result = append(result, binary.LittleEndian.Uint16(b[2*i:]))
+	}
+	return strings.TrimRight(string(utf16.Decode(result)), "\x00")
+}
+
+// A NUL padded fixed size UTF16 field.
+func (self buffer) utf16Field(offset, size int) string {
+	value, _ := buffer(self.slice(offset, size)).utf16z(0)
+	return value
+}
+
+// Names for the bits set in a flags field.
+func flagNames(value uint32, names map[uint32]string) []string {
+	result := []string{}
+	for bit := uint32(1); bit != 0; bit <<= 1 {
+		if value&bit == 0 {
+			continue
+		}
+
+		name, pres := names[bit]
+		if !pres {
+			name = fmt.Sprintf("%#x", bit)
+		}
+		result = append(result, name)
+	}
+	return result
+}
@@ -0,0 +1,204 @@
+package lnk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+const (
+	// The class type indicators of shell items.
+	SHELL_ITEM_ROOT_FOLDER   = 0x1f
+	SHELL_ITEM_VOLUME        = 0x20
+	SHELL_ITEM_FILE_ENTRY    = 0x30
+	SHELL_ITEM_NETWORK       = 0x40
+	SHELL_ITEM_CONTROL_PANEL = 0x71
+	SHELL_ITEM_DELEGATE      = 0x74
+
+	FILE_ENTRY_IS_DIRECTORY = 0x01
+	FILE_ENTRY_IS_UNICODE   = 0x04
+
+	EXTENSION_BLOCK_FILE_ENTRY = 0xbeef0004
+
+	// Sanity limit on the number of shell items in a list.
+	MAX_SHELL_ITEMS = 1000
+)
+
+// Parse a list of shell items (an IDList). Returns the decoded items
+// and the path they describe.
+func parseIDList(data buffer) ([]*ordereddict.Dict, string) {
+	items := []*ordereddict.Dict{}
+	path := []string{}
+
+	for offset := 0; offset+2 <= len(data) && len(items) < MAX_SHELL_ITEMS; {
+		size := int(data.u16(offset))
+		if size == 0 {
+			break
+		}
+
+		item := data.slice(offset, size)
+		if item == nil || size < 3 {
+			break
+		}
+		offset += size
+
+		decoded, name := parseShellItem(item)
+		items = append(items, decoded)
+
+		switch {
+		case item.u8(2)&0x70 == SHELL_ITEM_VOLUME:
+			// Volumes start a new absolute path.
+			path = []string{strings.TrimRight(name, "\\")}
+
+		case name != "":
+			path = append(path, name)
+		}
+	}
+
+	return items, strings.Join(path, "\\")
+}
+
+// Decode a single shell item. Returns the item and its name for
+// building the path.
+func parseShellItem(item buffer) (*ordereddict.Dict, string) {
+	class_type := item.u8(2)
+	result := ordereddict.NewDict()
+
+	switch {
+	case class_type == SHELL_ITEM_ROOT_FOLDER:
+		guid := item.guid(4)
+		name := folderNames[guid]
+		if name == "" {
+			name = guid
+		}
+		result.Set("Type", "RootFolder").
+			Set("Name", name).
+			Set("GUID", guid)
+		return result, name
+
+	case class_type&0x70 == SHELL_ITEM_VOLUME:
+		name := item.asciiz(3)
+		result.Set("Type", "Volume").Set("Name", name)
+		if class_type == 0x2e && item.slice(4, 16) != nil {
+			// A volume identified by GUID.
+			guid := item.guid(4)
+			name = folderNames[guid]
+			if name == "" {
+				name = guid
+			}
+			result.Update("Name", name)
+			result.Set("GUID", guid)
+		}
+		return result, name
+
+	case class_type&0x70 == SHELL_ITEM_FILE_ENTRY:
+		return parseFileEntry(item, result)
+
+	case class_type&0x70 == SHELL_ITEM_NETWORK:
+		flags := item.u8(4)
+		location := item.asciiz(5)
+		result.Set("Type", "Network").Set("Name", location)
+
+		offset := 5 + len(location) + 1
+		if flags&0x80 != 0 {
+			description := item.asciiz(offset)
+			result.Set("Description", description)
+			offset += len(description) + 1
+		}
+		if flags&0x40 != 0 {
+			result.Set("Comments", item.asciiz(offset))
+		}
+		return result, location
+
+	case class_type == SHELL_ITEM_CONTROL_PANEL:
+		guid := item.guid(14)
+		result.Set("Type", "ControlPanel").
+			Set("Name", folderNames[guid]).
+			Set("GUID", guid)
+		return result, folderNames[guid]
+
+	case class_type == SHELL_ITEM_DELEGATE &&
+		string(item.slice(6, 4)) == "CFSF":
+		// A delegate item wraps a file entry shell item.
+		inner := item.slice(10, int(item.u16(10)))
+		if inner != nil {
+			decoded, name := parseShellItem(inner)
+			decoded.Set("Delegate", item.guid(len(inner)+10))
+			return decoded, name
+		}
+	}
+
+	result.Set("Type", fmt.Sprintf("%#02x", class_type)).
+		Set("Data", fmt.Sprintf("%x", []byte(item)))
+	return result, ""
+}
+
+func parseFileEntry(item buffer, result *ordereddict.Dict) (*ordereddict.Dict, string) {
+	class_type := item.u8(2)
+
+	item_type := "File"
+	if class_type&FILE_ENTRY_IS_DIRECTORY != 0 {
+		item_type = "Directory"
+	}
+
+	short_name := ""
+	offset := 14
+	if class_type&FILE_ENTRY_IS_UNICODE != 0 {
+		name, size := item.utf16z(offset)
+		short_name = name
+		offset += size
+	} else {
+		short_name = item.asciiz(offset)
+		offset += len(short_name) + 1
+	}
+
+	// Names are padded to 2 bytes.
+	offset += offset % 2
+
+	result.Set("Type", item_type).
+		Set("Name", short_name).
+		Set("ShortName", short_name).
+		Set("Size", item.u32(4)).
+		Set("ModificationTime", item.fatDateTime(8)).
+		Set("Attributes", flagNames(uint32(item.u16(12)), fileAttributes))
+
+	name := short_name
+
+	// The extension block holds the long name and other times.
+	block := item.from(offset)
+	if block.u32(4) == EXTENSION_BLOCK_FILE_ENTRY {
+		block = block.slice(0, int(block.u16(0)))
+		version := block.u16(2)
+
+		result.Set("CreationTime", block.fatDateTime(8)).
+			Set("AccessTime", block.fatDateTime(12))
+
+		name_offset := 18
+		if version >= 7 {
+			mft_reference := block.u64(20)
+			result.Set("MFTEntry", mft_reference&0xffffffffffff).
+				Set("MFTSequence", mft_reference>>48)
+			name_offset = 36
+		}
+		if version >= 3 {
+			name_offset += 2
+		}
+		if version >= 9 {
+			name_offset += 4
+		}
+		if version >= 8 {
+			name_offset += 4
+		}
+
+		if version >= 3 {
+			long_name, _ := block.utf16z(name_offset)
+			if long_name != "" {
+				name = long_name
+				result.Update("Name", long_name)
+			}
+		}
+	}
+
+	return result, name
+}
@@ -0,0 +1,497 @@
+// A parser for the NTFS $LogFile.
+
+// The $LogFile is a circular log of the metadata changes NTFS makes
+// to the volume. It starts with two restart pages followed by the
+// log record pages. Each log record contains redo and undo operations
+// which describe the change. Log records are addressed by their
+// Log Sequence Number (LSN) which encodes both the offset of the
+// record in the file and the number of times the log has wrapped.
+//
+// References:
+// https://flatcap.github.io/linux-ntfs/ntfs/files/logfile.html
+// https://github.com/libyal/libfsntfs/blob/main/documentation/New%20Technologies%20File%20System%20(NTFS).asciidoc
+
+package ntfs_logfile
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	RESTART_PAGE_MAGIC = "RSTR"
+	RECORD_PAGE_MAGIC  = "RCRD"
+
+	LOG_RECORD_HEADER_SIZE = 0x30
+	RECORD_PAGE_HEADER     = 0x28
+	SECTOR_SIZE            = 0x200
+
+	MIN_PAGE_SIZE = 0x200
+	MAX_PAGE_SIZE = 0x10000
+
+	// Sanity check for the size of a single log record.
+	MAX_CLIENT_DATA_LENGTH = 0x100000
+
+	RECORD_TYPE_CLIENT  = 1
+	RECORD_TYPE_RESTART = 2
+)
+
+var (
+	invalidPage = errors.New("Invalid page")
+	stopWalk    = errors.New("Stop")
+)
+
+type RestartArea struct {
+	SystemPageSize uint32
+	LogPageSize    uint32
+	MajorVersion   int16
+	MinorVersion   int16
+
+	CurrentLSN         uint64
+	SeqNumberBits      uint32
+	FileSize           int64
+	RecordHeaderLength uint16
+	LogPageDataOffset  uint16
+}
+
+type LogRecord struct {
+	LSN           uint64
+	PreviousLSN   uint64
+	UndoNextLSN   uint64
+	RecordType    uint32
+	TransactionId uint32
+	Flags         uint16
+
+	// The client data which follows the header.
+	Data []byte
+}
+
+func (self *LogRecord) RecordTypeString() string {
+	switch self.RecordType {
+	case RECORD_TYPE_CLIENT:
+		return "ClientRecord"
+	case RECORD_TYPE_RESTART:
+		return "ClientRestart"
+	}
+	return fmt.Sprintf("%#x", self.RecordType)
+}
+
+type recordPage struct {
+	offset int64
+	data   []byte
+
+	last_end_lsn uint64
+}
+
+type LogFile struct {
+	reader  io.ReaderAt
+	Restart *RestartArea
+
+	// The real size of the data. The size in the restart area may
+	// not be trusted.
+	size int64
+
+	// Pages in the order they were written - starting with the
+	// oldest page.
+	pages []int64
+
+	// Version 1.x logs keep copies of the most recent pages after
+	// the restart pages (the tail copies). These may be more recent
+	// than the page in the log itself.
+	tails map[int64]*recordPage
+
+	// Cache the last page we read.
+	last *recordPage
+}
+
+// Open the log from a reader of size bytes.
+func OpenLogFile(
+	ctx context.Context, reader io.ReaderAt, size int64) (*LogFile, error) {
+	self := &LogFile{
+		reader: reader,
+		size:   size,
+		tails:  make(map[int64]*recordPage),
+	}
+
+	// There are two copies of the restart page - use the most
+	// recent valid one.
+	restart, err := self.readRestartPage(0)
+	if err == nil {
+		second, err := self.readRestartPage(int64(restart.SystemPageSize))
+		if err == nil && second.CurrentLSN > restart.CurrentLSN {
+			restart = second
+		}
+	} else {
+		// The first copy may be damaged - the second copy is one
+		// page away.
+		for page_size := int64(MIN_PAGE_SIZE); page_size <= MAX_PAGE_SIZE; page_size *= 2 {
+			restart, err = self.readRestartPage(page_size)
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	self.Restart = restart
+	err = self.findPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return self, nil
+}
+
+func (self *LogFile) readRestartPage(offset int64) (*RestartArea, error) {
+	header := make([]byte, 0x20)
+	n, err := self.reader.ReadAt(header, offset)
+	if n < len(header) {
+		return nil, fmt.Errorf("Unable to read restart page: %w", err)
+	}
+
+	if string(header[:4]) != RESTART_PAGE_MAGIC {
+		return nil, errors.New("Restart page signature not found")
+	}
+
+	result := &RestartArea{
+		SystemPageSize: binary.LittleEndian.Uint32(header[0x10:]),
+		LogPageSize:    binary.LittleEndian.Uint32(header[0x14:]),
+		MinorVersion:   int16(binary.LittleEndian.Uint16(header[0x1a:])),
+		MajorVersion:   int16(binary.LittleEndian.Uint16(header[0x1c:])),
+	}
+
+	if !isValidPageSize(result.SystemPageSize) ||
+		!isValidPageSize(result.LogPageSize) {
+		return nil, fmt.Errorf("Invalid page size %#x/%#x",
+			result.SystemPageSize, result.LogPageSize)
+	}
+
+	page := make([]byte, result.SystemPageSize)
+	n, err = self.reader.ReadAt(page, offset)
+	if n < len(page) {
+		return nil, fmt.Errorf("Unable to read restart page: %w", err)
+	}
+
+	err = applyFixups(page)
+	if err != nil {
+		return nil, err
+	}
+
+	restart_offset := int(binary.LittleEndian.Uint16(page[0x18:]))
+	if restart_offset+0x30 > len(page) {
+		return nil, errors.New("Invalid restart area offset")
+	}
+	area := page[restart_offset:]
+
+	result.CurrentLSN = binary.LittleEndian.Uint64(area[0x00:])
+	result.SeqNumberBits = binary.LittleEndian.Uint32(area[0x10:])
+	result.FileSize = int64(binary.LittleEndian.Uint64(area[0x18:]))
+	result.RecordHeaderLength = binary.LittleEndian.Uint16(area[0x24:])
+	result.LogPageDataOffset = binary.LittleEndian.Uint16(area[0x26:])
+
+	if result.SeqNumberBits <= 3 || result.SeqNumberBits >= 64 {
+		return nil, fmt.Errorf("Invalid sequence number bits %v",
+			result.SeqNumberBits)
+	}
+
+	if result.FileSize < 4*int64(result.LogPageSize) ||
+		result.FileSize > self.size {
+		return nil, fmt.Errorf("Invalid log file size %v", result.FileSize)
+	}
+
+	if result.LogPageDataOffset < RECORD_PAGE_HEADER ||
+		uint32(result.LogPageDataOffset)+LOG_RECORD_HEADER_SIZE > result.LogPageSize {
+		return nil, fmt.Errorf("Invalid log page data offset %#x",
+			result.LogPageDataOffset)
+	}
+
+	return result, nil
+}
+
+func isValidPageSize(size uint32) bool {
+	return size >= MIN_PAGE_SIZE && size <= MAX_PAGE_SIZE &&
+		size&(size-1) == 0
+}
+
+// Pages are protected by an update sequence array: the last two
+// bytes of each sector are replaced by the update sequence number
+// and their real value is stored in the array.
+func applyFixups(page []byte) error {
+	usa_offset := int(binary.LittleEndian.Uint16(page[4:]))
+	usa_count := int(binary.LittleEndian.Uint16(page[6:]))
+
+	if usa_count == 0 || usa_offset+2*usa_count > len(page) ||
+		(usa_count-1)*SECTOR_SIZE > len(page) {
+		return invalidPage
+	}
+
+	usn := page[usa_offset : usa_offset+2]
+	for i := 1; i < usa_count; i++ {
+		end := i*SECTOR_SIZE - 2
+		if page[end] != usn[0] || page[end+1] != usn[1] {
+			return invalidPage
+		}
+		copy(page[end:end+2], page[usa_offset+2*i:])
+	}
+
+	return nil
+}
+
+// Convert an LSN to an offset in the file.
+func (self *LogFile) lsnToOffset(lsn uint64) int64 {
+	bits := self.Restart.SeqNumberBits
+	return int64((lsn << bits) >> (bits - 3))
+}
+
+func (self *LogFile) pageSize() int64 {
+	return int64(self.Restart.LogPageSize)
+}
+
+// Find all the log record pages and order them from oldest to
+// newest.
+func (self *LogFile) findPages(ctx context.Context) error {
+	page_size := self.pageSize()
+	header := make([]byte, RECORD_PAGE_HEADER)
+
+	// Record pages start after the two restart pages. In version
+	// 1.x logs the next two pages are the tail copies.
+	first_page := 2 * int64(self.Restart.SystemPageSize)
+	if self.Restart.MajorVersion < 2 {
+		tails_end := first_page + 2*page_size
+		for offset := first_page; offset < tails_end; offset += page_size {
+			tail, err := self.readPage(offset)
+			if err != nil {
+				continue
+			}
+
+			// The tail copies store the offset of the page they are
+			// a copy of.
+			target := int64(binary.LittleEndian.Uint64(tail.data[0x08:]))
+			if target < tails_end || target%page_size != 0 {
+				continue
+			}
+
+			existing, pres := self.tails[target]
+			if !pres || existing.last_end_lsn < tail.last_end_lsn {
+				tail.offset = target
+				self.tails[target] = tail
+			}
+		}
+		first_page = tails_end
+	}
+
+	var head int
+	var head_lsn uint64
+
+	for offset := first_page; offset+page_size <= self.Restart.FileSize; offset += page_size {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		last_end_lsn := uint64(0)
+		n, _ := self.reader.ReadAt(header, offset)
+		if n == len(header) && string(header[:4]) == RECORD_PAGE_MAGIC {
+			last_end_lsn = binary.LittleEndian.Uint64(header[0x20:])
+		}
+
+		tail, pres := self.tails[offset]
+		if pres && tail.last_end_lsn > last_end_lsn {
+			last_end_lsn = tail.last_end_lsn
+		}
+
+		// Never written.
+		if last_end_lsn == 0 {
+			continue
+		}
+
+		// The page the log was last written to.
+		if last_end_lsn > head_lsn {
+			head_lsn = last_end_lsn
+			head = len(self.pages)
+		}
+		self.pages = append(self.pages, offset)
+	}
+
+	if len(self.pages) == 0 {
+		return errors.New("No log record pages found")
+	}
+
+	// The log is circular so the oldest page is after the head.
+	self.pages = append(self.pages[head+1:], self.pages[:head+1]...)
+
+	return nil
+}
+
+func (self *LogFile) readPage(offset int64) (*recordPage, error) {
+	data := make([]byte, self.pageSize())
+	n, _ := self.reader.ReadAt(data, offset)
+	if n < len(data) || string(data[:4]) != RECORD_PAGE_MAGIC {
+		return nil, invalidPage
+	}
+
+	err := applyFixups(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordPage{
+		offset:       offset,
+		data:         data,
+		last_end_lsn: binary.LittleEndian.Uint64(data[0x20:]),
+	}, nil
+}
+
+// Get the most recent version of the page at this offset.
+func (self *LogFile) getPage(offset int64) (*recordPage, error) {
+	if self.last != nil && self.last.offset == offset {
+		return self.last, nil
+	}
+
+	page, err := self.readPage(offset)
+	tail, pres := self.tails[offset]
+	if pres && (err != nil || tail.last_end_lsn > page.last_end_lsn) {
+		page, err = tail, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	self.last = page
+	return page, nil
+}
+
+// Walk the log records from oldest to newest.
+func (self *LogFile) Records(
+	ctx context.Context, cb func(record *LogRecord) error) error {
+
+	data_offset := int(self.Restart.LogPageDataOffset)
+	page_size := int(self.pageSize())
+
+	// Only emit increasing LSNs - anything else is stale data left
+	// over from the last time the log wrapped.
+	var last_lsn uint64
+
+	for idx := 0; idx < len(self.pages); idx++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		page, err := self.getPage(self.pages[idx])
+		if err != nil {
+			continue
+		}
+
+		pos := data_offset
+		for pos+LOG_RECORD_HEADER_SIZE <= page_size {
+			record, err := self.parseRecordHeader(page, pos)
+			if err != nil || record.LSN <= last_lsn {
+				break
+			}
+
+			// Read the client data which may span multiple pages.
+			next_idx, next_pos, err := self.readRecordData(
+				record, idx, pos+LOG_RECORD_HEADER_SIZE)
+			if err != nil {
+				break
+			}
+
+			last_lsn = record.LSN
+			err = cb(record)
+			if err == stopWalk {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if next_idx != idx {
+				idx = next_idx
+				page, err = self.getPage(self.pages[idx])
+				if err != nil {
+					break
+				}
+			}
+
+			// Records are 8 byte aligned.
+			pos = (next_pos + 7) &^ 7
+		}
+	}
+
+	return nil
+}
+
+func (self *LogFile) parseRecordHeader(
+	page *recordPage, pos int) (*LogRecord, error) {
+	header := page.data[pos : pos+LOG_RECORD_HEADER_SIZE]
+
+	record := &LogRecord{
+		LSN:           binary.LittleEndian.Uint64(header[0x00:]),
+		PreviousLSN:   binary.LittleEndian.Uint64(header[0x08:]),
+		UndoNextLSN:   binary.LittleEndian.Uint64(header[0x10:]),
+		RecordType:    binary.LittleEndian.Uint32(header[0x20:]),
+		TransactionId: binary.LittleEndian.Uint32(header[0x24:]),
+		Flags:         binary.LittleEndian.Uint16(header[0x28:]),
+	}
+	length := binary.LittleEndian.Uint32(header[0x18:])
+
+	// The LSN must point at this record.
+	if record.LSN == 0 ||
+		self.lsnToOffset(record.LSN) != page.offset+int64(pos) {
+		return nil, errors.New("LSN does not match offset")
+	}
+
+	if record.RecordType != RECORD_TYPE_CLIENT &&
+		record.RecordType != RECORD_TYPE_RESTART {
+		return nil, errors.New("Invalid record type")
+	}
+
+	if length > MAX_CLIENT_DATA_LENGTH {
+		return nil, errors.New("Record too large")
+	}
+	record.Data = make([]byte, 0, length)
+
+	return record, nil
+}
+
+// Collect the record's client data starting at pos in the page. If
+// the data spans pages it continues after the header of the next
+// page. Returns the page index and position after the data.
+func (self *LogFile) readRecordData(
+	record *LogRecord, idx, pos int) (int, int, error) {
+	data_offset := int(self.Restart.LogPageDataOffset)
+	page_size := int(self.pageSize())
+	length := cap(record.Data)
+
+	for {
+		page, err := self.getPage(self.pages[idx])
+		if err != nil {
+			return 0, 0, err
+		}
+
+		available := page_size - pos
+		if available > length-len(record.Data) {
+			available = length - len(record.Data)
+		}
+		record.Data = append(record.Data, page.data[pos:pos+available]...)
+		pos += available
+
+		if len(record.Data) == length {
+			return idx, pos, nil
+		}
+
+		idx++
+		if idx >= len(self.pages) {
+			return 0, 0, errors.New("Record is truncated")
+		}
+		pos = data_offset
+	}
+}
@@ -0,0 +1,416 @@
+package ntfs_logfile
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/alecthomas/assert"
+	ntfs "www.velocidex.com/golang/go-ntfs/parser"
+)
+
+const (
+	testPageSize   = 0x1000
+	testSeqBits    = 44
+	testDataOffset = 0x40
+	testUSAOffset  = 0x28
+)
+
+// Builds a $LogFile by writing records into the circular log.
+type logBuilder struct {
+	file_size  int64
+	first_page int64
+	major      uint16
+
+	seq      uint64
+	last_lsn uint64
+
+	// The unprotected content of each page.
+	pages map[int64][]byte
+
+	offset int64
+	pos    int
+}
+
+func newLogBuilder(pages int64, major uint16) *logBuilder {
+	self := &logBuilder{
+		file_size:  pages * testPageSize,
+		first_page: 2 * testPageSize,
+		major:      major,
+		seq:        1,
+		pages:      make(map[int64][]byte),
+	}
+
+	// Version 1.x logs have two tail copy pages.
+	if major < 2 {
+		self.first_page += 2 * testPageSize
+	}
+	self.offset = self.first_page
+	self.pos = testDataOffset
+	self.pages[self.offset] = newPage(RECORD_PAGE_MAGIC, testUSAOffset)
+
+	return self
+}
+
+func newPage(magic string, usa_offset uint16) []byte {
+	page := make([]byte, testPageSize)
+	copy(page, magic)
+	binary.LittleEndian.PutUint16(page[4:], usa_offset)
+	binary.LittleEndian.PutUint16(page[6:], testPageSize/SECTOR_SIZE+1)
+	return page
+}
+
+func (self *logBuilder) nextPage() {
+	self.offset += testPageSize
+	if self.offset >= self.file_size {
+		self.offset = self.first_page
+		self.seq++
+	}
+	self.pos = testDataOffset
+
+	// Pages are reused when the log wraps so any old data remains
+	// after the new records.
+	page, pres := self.pages[self.offset]
+	if !pres {
+		self.pages[self.offset] = newPage(RECORD_PAGE_MAGIC, testUSAOffset)
+		return
+	}
+	binary.LittleEndian.PutUint64(page[0x20:], 0)
+}
+
+func (self *logBuilder) write(record_type uint32, data []byte) uint64 {
+	if self.pos+LOG_RECORD_HEADER_SIZE > testPageSize {
+		self.nextPage()
+	}
+
+	lsn := self.seq<<(64-testSeqBits) | uint64(self.offset+int64(self.pos))>>3
+
+	header := make([]byte, LOG_RECORD_HEADER_SIZE)
+	binary.LittleEndian.PutUint64(header[0x00:], lsn)
+	binary.LittleEndian.PutUint64(header[0x08:], self.last_lsn)
+	binary.LittleEndian.PutUint32(header[0x18:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(header[0x20:], record_type)
+	binary.LittleEndian.PutUint32(header[0x24:], 0x18)
+
+	buf := append(header, data...)
+	for {
+		page := self.pages[self.offset]
+		n := copy(page[self.pos:], buf)
+		self.pos += n
+		buf = buf[n:]
+
+		binary.LittleEndian.PutUint64(page[0x08:], lsn)
+		binary.LittleEndian.PutUint64(page[0x20:], lsn)
+
+		if len(buf) == 0 {
+			break
+		}
+		self.nextPage()
+	}
+
+	self.pos = (self.pos + 7) &^ 7
+	self.last_lsn = lsn
+	return lsn
+}
+
+// Returns the protected page at the offset as it would be on disk.
+func (self *logBuilder) page(offset int64) []byte {
+	return protect(self.pages[offset])
+}
+
+// Apply the update sequence array to the page.
+func protect(page []byte) []byte {
+	result := append([]byte{}, page...)
+	usa_offset := int(binary.LittleEndian.Uint16(result[4:]))
+	usa_count := int(binary.LittleEndian.Uint16(result[6:]))
+
+	binary.LittleEndian.PutUint16(result[usa_offset:], 0x1234)
+	for i := 1; i < usa_count; i++ {
+		end := i*SECTOR_SIZE - 2
+		copy(result[usa_offset+2*i:], result[end:end+2])
+		binary.LittleEndian.PutUint16(result[end:], 0x1234)
+	}
+	return result
+}
+
+func (self *logBuilder) restartPage() []byte {
+	page := newPage(RESTART_PAGE_MAGIC, 0x1e)
+	binary.LittleEndian.PutUint32(page[0x10:], testPageSize)
+	binary.LittleEndian.PutUint32(page[0x14:], testPageSize)
+	binary.LittleEndian.PutUint16(page[0x18:], 0x30)
+	binary.LittleEndian.PutUint16(page[0x1a:], 1)
+	binary.LittleEndian.PutUint16(page[0x1c:], self.major)
+
+	area := page[0x30:]
+	binary.LittleEndian.PutUint64(area[0x00:], self.last_lsn)
+	binary.LittleEndian.PutUint32(area[0x10:], testSeqBits)
+	binary.LittleEndian.PutUint64(area[0x18:], uint64(self.file_size))
+	binary.LittleEndian.PutUint16(area[0x24:], LOG_RECORD_HEADER_SIZE)
+	binary.LittleEndian.PutUint16(area[0x26:], testDataOffset)
+
+	return protect(page)
+}
+
+func (self *logBuilder) build() []byte {
+	result := make([]byte, self.file_size)
+	restart := self.restartPage()
+	copy(result, restart)
+	copy(result[testPageSize:], restart)
+
+	for offset := range self.pages {
+		copy(result[offset:], self.page(offset))
+	}
+	return result
+}
+
+// Build the NTFS client data for a log record.
+func makeClientData(redo_op, undo_op uint16,
+	target_vcn uint64, cluster_block_offset uint16,
+	redo, undo []byte) []byte {
+	result := make([]byte, NTFS_RECORD_HEADER_SIZE)
+	binary.LittleEndian.PutUint16(result[0x00:], redo_op)
+	binary.LittleEndian.PutUint16(result[0x02:], undo_op)
+	binary.LittleEndian.PutUint16(result[0x04:], NTFS_RECORD_HEADER_SIZE)
+	binary.LittleEndian.PutUint16(result[0x06:], uint16(len(redo)))
+	binary.LittleEndian.PutUint16(result[0x08:],
+		uint16(NTFS_RECORD_HEADER_SIZE+len(redo)))
+	binary.LittleEndian.PutUint16(result[0x0a:], uint16(len(undo)))
+	binary.LittleEndian.PutUint16(result[0x14:], cluster_block_offset)
+	binary.LittleEndian.PutUint64(result[0x18:], target_vcn)
+
+	result = append(result, redo...)
+	return append(result, undo...)
+}
+
+// An $I30 index entry for the file.
+func makeIndexEntry(mft_id uint64, parent_id uint64, name string) []byte {
+	name_utf16 := utf16.Encode([]rune(name))
+	key_length := FILE_NAME_SIZE + 2*len(name_utf16)
+
+	result := make([]byte, INDEX_ENTRY_HEADER_SIZE+key_length)
+	binary.LittleEndian.PutUint64(result[0x00:], mft_id|1<<48)
+	binary.LittleEndian.PutUint16(result[0x08:], uint16(len(result)))
+	binary.LittleEndian.PutUint16(result[0x0a:], uint16(key_length))
+
+	file_name := result[INDEX_ENTRY_HEADER_SIZE:]
+	binary.LittleEndian.PutUint64(file_name[0x00:], parent_id|5<<48)
+	file_name[0x40] = byte(len(name_utf16))
+	file_name[0x41] = 1
+	for i, c := range name_utf16 {
+		binary.LittleEndian.PutUint16(file_name[FILE_NAME_SIZE+2*i:], c)
+	}
+	return result
+}
+
+func makeSizes(allocated, initialized, size uint64) []byte {
+	result := make([]byte, 0x20)
+	binary.LittleEndian.PutUint64(result[0x00:], allocated)
+	binary.LittleEndian.PutUint64(result[0x08:], initialized)
+	binary.LittleEndian.PutUint64(result[0x10:], size)
+	return result
+}
+
+func getLSNs(t *testing.T, data []byte) []uint64 {
+	log_file, err := OpenLogFile(context.Background(),
+		bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+
+	result := []uint64{}
+	err = log_file.Records(context.Background(), func(record *LogRecord) error {
+		result = append(result, record.LSN)
+		return nil
+	})
+	assert.NoError(t, err)
+	return result
+}
+
+func TestLogFileRecords(t *testing.T) {
+	builder := newLogBuilder(8, 2)
+
+	// A large record spans three pages.
+	lsns := []uint64{
+		builder.write(RECORD_TYPE_RESTART, make([]byte, 0x40)),
+		builder.write(RECORD_TYPE_CLIENT, makeClientData(
+			UpdateNonresidentValue, Noop, 0, 0,
+			bytes.Repeat([]byte{1}, 2*testPageSize), nil)),
+		builder.write(RECORD_TYPE_CLIENT, makeClientData(
+			AddIndexEntryRoot, DeleteIndexEntryRoot, 1, 2,
+			makeIndexEntry(40, 5, "hello.txt"), nil)),
+		builder.write(RECORD_TYPE_CLIENT, makeClientData(
+			SetNewAttributeSizes, SetNewAttributeSizes, 10, 0,
+			makeSizes(0x2000, 0x1000, 0x1800), makeSizes(0, 0, 0))),
+	}
+
+	data := builder.build()
+	log_file, err := OpenLogFile(context.Background(),
+		bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+	assert.Equal(t, builder.last_lsn, log_file.Restart.CurrentLSN)
+
+	profile := ntfs.NewNTFSProfile()
+	resolver := &pathResolver{}
+	rows := []*ordereddict.Dict{}
+	err = log_file.Records(context.Background(), func(record *LogRecord) error {
+		rows = append(rows, makeRow(profile, resolver, record,
+			DEFAULT_CLUSTER_SIZE, DEFAULT_RECORD_SIZE))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, len(lsns), len(rows))
+
+	for i, row := range rows {
+		lsn, _ := row.Get("LSN")
+		assert.Equal(t, lsns[i], lsn)
+	}
+
+	record_type, _ := rows[0].GetString("RecordType")
+	assert.Equal(t, "ClientRestart", record_type)
+
+	redo, _ := rows[1].GetString("Redo")
+	assert.Equal(t, "UpdateNonresidentValue", redo)
+
+	// Index entries are decoded to their $FILE_NAME.
+	redo, _ = rows[2].GetString("Redo")
+	assert.Equal(t, "AddIndexEntryRoot", redo)
+
+	mft_id, _ := rows[2].GetInt64("MFTId")
+	assert.Equal(t, int64(5), mft_id)
+
+	details, _ := rows[2].Get("RedoDetails")
+	file_name := findFileName(details.(*ordereddict.Dict))
+	assert.NotNil(t, file_name)
+
+	name, _ := file_name.GetString("Name")
+	assert.Equal(t, "hello.txt", name)
+
+	parent, _ := file_name.GetInt64("ParentMFTId")
+	assert.Equal(t, int64(5), parent)
+
+	details, _ = rows[3].Get("RedoDetails")
+	size, _ := details.(*ordereddict.Dict).GetInt64("Size")
+	assert.Equal(t, int64(0x1800), size)
+
+	mft_id, _ = rows[3].GetInt64("MFTId")
+	assert.Equal(t, int64(40), mft_id)
+}
+
+func TestLogFileWrap(t *testing.T) {
+	// Six log pages.
+	builder := newLogBuilder(8, 2)
+
+	lsns := []uint64{}
+	for builder.seq == 1 || builder.offset < builder.first_page+testPageSize {
+		lsns = append(lsns, builder.write(RECORD_TYPE_CLIENT, makeClientData(
+			Noop, Noop, 0, 0, make([]byte, 0x100), nil)))
+	}
+
+	// The records in the first two pages were overwritten when the
+	// log wrapped. The stale data after the new records in the
+	// second page is ignored.
+	expected := []uint64{}
+	for _, lsn := range lsns {
+		offset := int64(lsn<<testSeqBits) >> (testSeqBits - 3)
+		if offset >= builder.offset+testPageSize ||
+			lsn>>(64-testSeqBits) == 2 {
+			expected = append(expected, lsn)
+		}
+	}
+
+	assert.Equal(t, expected, getLSNs(t, builder.build()))
+}
+
+func TestLogFileTailCopies(t *testing.T) {
+	builder := newLogBuilder(8, 1)
+	lsns := []uint64{}
+	for i := 0; i < 3; i++ {
+		lsns = append(lsns, builder.write(RECORD_TYPE_CLIENT, makeClientData(
+			Noop, Noop, 0, 0, make([]byte, 0x100), nil)))
+	}
+
+	// Only the first record made it to the page - the rest are only
+	// in the tail copy.
+	partial := append([]byte{}, builder.pages[builder.offset]...)
+	first_record_end := testDataOffset + LOG_RECORD_HEADER_SIZE +
+		NTFS_RECORD_HEADER_SIZE + 0x100
+	for i := first_record_end; i < len(partial); i++ {
+		partial[i] = 0
+	}
+	binary.LittleEndian.PutUint64(partial[0x20:], lsns[0])
+
+	tail := builder.page(builder.offset)
+	binary.LittleEndian.PutUint64(tail[0x08:], uint64(builder.offset))
+
+	data := builder.build()
+	copy(data[builder.offset:], protect(partial))
+	copy(data[2*testPageSize:], tail)
+
+	assert.Equal(t, lsns, getLSNs(t, data))
+
+	// Without the tail copy we only see the first record.
+	copy(data[2*testPageSize:], make([]byte, testPageSize))
+	assert.Equal(t, lsns[:1], getLSNs(t, data))
+}
+
+func TestLogFileCorruptPage(t *testing.T) {
+	builder := newLogBuilder(8, 2)
+	lsns := []uint64{}
+	for builder.offset < builder.first_page+2*testPageSize {
+		lsns = append(lsns, builder.write(RECORD_TYPE_CLIENT, makeClientData(
+			Noop, Noop, 0, 0, make([]byte, 0x100), nil)))
+	}
+
+	// Break the fixup in the first page - its records are skipped.
+	data := builder.build()
+	data[builder.first_page+SECTOR_SIZE-1] ^= 0xff
+
+	expected := []uint64{}
+	for _, lsn := range lsns {
+		offset := int64(lsn<<testSeqBits) >> (testSeqBits - 3)
+		if offset >= builder.first_page+testPageSize {
+			expected = append(expected, lsn)
+		}
+	}
+	assert.Equal(t, expected, getLSNs(t, data))
+
+	// The restart pages are required.
+	_, err := OpenLogFile(context.Background(),
+		bytes.NewReader(make([]byte, 8*testPageSize)), 8*testPageSize)
+	assert.Error(t, err)
+}
+
+func TestLogFileSize(t *testing.T) {
+	builder := newLogBuilder(8, 2)
+	builder.write(RECORD_TYPE_RESTART, make([]byte, 0x40))
+	data := builder.build()
+
+	// The restart area claims more data than there is.
+	_, err := OpenLogFile(context.Background(),
+		bytes.NewReader(data[:6*testPageSize]), 6*testPageSize)
+	assert.Error(t, err)
+
+	// Scanning for pages stops when the query is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = OpenLogFile(ctx, bytes.NewReader(data), int64(len(data)))
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestIndexEntryKeys(t *testing.T) {
+	profile := ntfs.NewNTFSProfile()
+
+	// Index entries from other indexes are not file names.
+	entry := make([]byte, INDEX_ENTRY_HEADER_SIZE+0x10)
+	binary.LittleEndian.PutUint16(entry[0x0a:], 0x10)
+	details := decodeIndexEntry(profile, entry)
+	_, pres := details.Get("Key")
+	assert.True(t, pres)
+	assert.Nil(t, findFileName(details))
+
+	details = decodeIndexEntry(profile, makeIndexEntry(40, 5, "hello.txt"))
+	assert.NotNil(t, findFileName(details))
+}
@@ -0,0 +1,440 @@
+package ntfs_logfile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Velocidex/ordereddict"
+	ntfs "www.velocidex.com/golang/go-ntfs/parser"
+)
+
+const (
+	NTFS_RECORD_HEADER_SIZE = 0x20
+	INDEX_ENTRY_HEADER_SIZE = 0x10
+
+	// The size of the $FILE_NAME without the name.
+	FILE_NAME_SIZE = 0x42
+
+	// Raw data for operations we do not decode is truncated to this
+	// size.
+	MAX_RAW_DATA = 0x40
+
+	ATTR_TYPE_STANDARD_INFORMATION = 0x10
+	ATTR_TYPE_FILE_NAME            = 0x30
+	ATTR_TYPE_DATA                 = 0x80
+)
+
+// The NTFS operations in the redo and undo fields.
+const (
+	Noop                           = 0x00
+	CompensationLogRecord          = 0x01
+	InitializeFileRecordSegment    = 0x02
+	DeallocateFileRecordSegment    = 0x03
+	WriteEndOfFileRecordSegment    = 0x04
+	CreateAttribute                = 0x05
+	DeleteAttribute                = 0x06
+	UpdateResidentValue            = 0x07
+	UpdateNonresidentValue         = 0x08
+	UpdateMappingPairs             = 0x09
+	DeleteDirtyClusters            = 0x0a
+	SetNewAttributeSizes           = 0x0b
+	AddIndexEntryRoot              = 0x0c
+	DeleteIndexEntryRoot           = 0x0d
+	AddIndexEntryAllocation        = 0x0e
+	DeleteIndexEntryAllocation     = 0x0f
+	WriteEndOfIndexBuffer          = 0x10
+	SetIndexEntryVcnRoot           = 0x11
+	SetIndexEntryVcnAllocation     = 0x12
+	UpdateFileNameRoot             = 0x13
+	UpdateFileNameAllocation       = 0x14
+	SetBitsInNonresidentBitMap     = 0x15
+	ClearBitsInNonresidentBitMap   = 0x16
+	HotFix                         = 0x17
+	EndTopLevelAction              = 0x18
+	PrepareTransaction             = 0x19
+	CommitTransaction              = 0x1a
+	ForgetTransaction              = 0x1b
+	OpenNonresidentAttribute       = 0x1c
+	OpenAttributeTableDump         = 0x1d
+	AttributeNamesDump             = 0x1e
+	DirtyPageTableDump             = 0x1f
+	TransactionTableDump           = 0x20
+	UpdateRecordDataRoot           = 0x21
+	UpdateRecordDataAllocation     = 0x22
+	UpdateRelativeDataInIndex      = 0x23
+	UpdateRelativeDataInIndex2     = 0x24
+	ZeroEndOfFileRecord            = 0x25
+	UpdateRelativeDataInIndex3     = 0x26
+	UpdateRelativeDataInFileRecord = 0x27
+)
+
+var operationNames = map[uint16]string{
+	Noop:                           "Noop",
+	CompensationLogRecord:          "CompensationLogRecord",
+	InitializeFileRecordSegment:    "InitializeFileRecordSegment",
+	DeallocateFileRecordSegment:    "DeallocateFileRecordSegment",
+	WriteEndOfFileRecordSegment:    "WriteEndOfFileRecordSegment",
+	CreateAttribute:                "CreateAttribute",
+	DeleteAttribute:                "DeleteAttribute",
+	UpdateResidentValue:            "UpdateResidentValue",
+	UpdateNonresidentValue:         "UpdateNonresidentValue",
+	UpdateMappingPairs:             "UpdateMappingPairs",
+	DeleteDirtyClusters:            "DeleteDirtyClusters",
+	SetNewAttributeSizes:           "SetNewAttributeSizes",
+	AddIndexEntryRoot:              "AddIndexEntryRoot",
+	DeleteIndexEntryRoot:           "DeleteIndexEntryRoot",
+	AddIndexEntryAllocation:        "AddIndexEntryAllocation",
+	DeleteIndexEntryAllocation:     "DeleteIndexEntryAllocation",
+	WriteEndOfIndexBuffer:          "WriteEndOfIndexBuffer",
+	SetIndexEntryVcnRoot:           "SetIndexEntryVcnRoot",
+	SetIndexEntryVcnAllocation:     "SetIndexEntryVcnAllocation",
+	UpdateFileNameRoot:             "UpdateFileNameRoot",
+	UpdateFileNameAllocation:       "UpdateFileNameAllocation",
+	SetBitsInNonresidentBitMap:     "SetBitsInNonresidentBitMap",
+	ClearBitsInNonresidentBitMap:   "ClearBitsInNonresidentBitMap",
+	HotFix:                         "HotFix",
+	EndTopLevelAction:              "EndTopLevelAction",
+	PrepareTransaction:             "PrepareTransaction",
+	CommitTransaction:              "CommitTransaction",
+	ForgetTransaction:              "ForgetTransaction",
+	OpenNonresidentAttribute:       "OpenNonresidentAttribute",
+	OpenAttributeTableDump:         "OpenAttributeTableDump",
+	AttributeNamesDump:             "AttributeNamesDump",
+	DirtyPageTableDump:             "DirtyPageTableDump",
+	TransactionTableDump:           "TransactionTableDump",
+	UpdateRecordDataRoot:           "UpdateRecordDataRoot",
+	UpdateRecordDataAllocation:     "UpdateRecordDataAllocation",
+	UpdateRelativeDataInIndex:      "UpdateRelativeDataInIndex",
+	UpdateRelativeDataInIndex2:     "UpdateRelativeDataInIndex2",
+	ZeroEndOfFileRecord:            "ZeroEndOfFileRecord",
+	UpdateRelativeDataInIndex3:     "UpdateRelativeDataInIndex3",
+	UpdateRelativeDataInFileRecord: "UpdateRelativeDataInFileRecord",
+}
+
+func OperationName(op uint16) string {
+	name, pres := operationNames[op]
+	if pres {
+		return name
+	}
+	return fmt.Sprintf("%#x", op)
+}
+
+// The NTFS specific part of a client log record.
+type NTFSLogRecord struct {
+	RedoOp, UndoOp     uint16
+	TargetAttribute    uint16
+	RecordOffset       uint16
+	AttributeOffset    uint16
+	ClusterBlockOffset uint16
+	TargetVCN          uint64
+	LCNs               []uint64
+
+	Redo, Undo []byte
+}
+
+func ParseNTFSLogRecord(data []byte) (*NTFSLogRecord, error) {
+	if len(data) < NTFS_RECORD_HEADER_SIZE {
+		return nil, errors.New("Record too short")
+	}
+
+	result := &NTFSLogRecord{
+		RedoOp:             binary.LittleEndian.Uint16(data[0x00:]),
+		UndoOp:             binary.LittleEndian.Uint16(data[0x02:]),
+		TargetAttribute:    binary.LittleEndian.Uint16(data[0x0c:]),
+		RecordOffset:       binary.LittleEndian.Uint16(data[0x10:]),
+		AttributeOffset:    binary.LittleEndian.Uint16(data[0x12:]),
+		ClusterBlockOffset: binary.LittleEndian.Uint16(data[0x14:]),
+		TargetVCN:          binary.LittleEndian.Uint64(data[0x18:]),
+	}
+
+	lcns_to_follow := int(binary.LittleEndian.Uint16(data[0x0e:]))
+	if NTFS_RECORD_HEADER_SIZE+8*lcns_to_follow > len(data) {
+		return nil, errors.New("Too many LCNs")
+	}
+
+	for i := 0; i < lcns_to_follow; i++ {
+		result.LCNs = append(result.LCNs, binary.LittleEndian.Uint64(
+			data[NTFS_RECORD_HEADER_SIZE+8*i:]))
+	}
+
+	var err error
+	result.Redo, err = getSlice(data,
+		binary.LittleEndian.Uint16(data[0x04:]),
+		binary.LittleEndian.Uint16(data[0x06:]))
+	if err != nil {
+		return nil, err
+	}
+
+	result.Undo, err = getSlice(data,
+		binary.LittleEndian.Uint16(data[0x08:]),
+		binary.LittleEndian.Uint16(data[0x0a:]))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func getSlice(data []byte, offset, length uint16) ([]byte, error) {
+	if length == 0 {
+		return nil, nil
+	}
+
+	if int(offset)+int(length) > len(data) {
+		return nil, errors.New("Redo/Undo data out of bounds")
+	}
+	return data[offset : offset+length], nil
+}
+
+// The operation which describes what this record does. Compensation
+// records (written when a transaction is rolled back) only have
+// undo operations.
+func (self *NTFSLogRecord) operation() uint16 {
+	if self.RedoOp == Noop || self.RedoOp == CompensationLogRecord {
+		return self.UndoOp
+	}
+	return self.RedoOp
+}
+
+// Returns true if the record refers to an MFT entry directly. The
+// other operations refer to non resident attributes (e.g. index
+// allocations) through the open attribute table.
+func (self *NTFSLogRecord) TargetsMFT() bool {
+	switch self.operation() {
+	case InitializeFileRecordSegment, DeallocateFileRecordSegment,
+		WriteEndOfFileRecordSegment, CreateAttribute, DeleteAttribute,
+		UpdateResidentValue, UpdateMappingPairs, SetNewAttributeSizes,
+		AddIndexEntryRoot, DeleteIndexEntryRoot, SetIndexEntryVcnRoot,
+		UpdateFileNameRoot, UpdateRecordDataRoot, ZeroEndOfFileRecord,
+		UpdateRelativeDataInFileRecord:
+		return true
+	}
+	return false
+}
+
+// The MFT entry this record refers to. The target VCN is within the
+// $MFT stream.
+func (self *NTFSLogRecord) MFTId(cluster_size, record_size int64) int64 {
+	return (int64(self.TargetVCN)*cluster_size +
+		int64(self.ClusterBlockOffset)*SECTOR_SIZE) / record_size
+}
+
+// Decode the redo or undo data for the operation.
+func decodeOperation(
+	profile *ntfs.NTFSProfile, op uint16, data []byte) *ordereddict.Dict {
+	if len(data) == 0 {
+		return nil
+	}
+
+	switch op {
+	case InitializeFileRecordSegment:
+		return decodeFileRecord(profile, data)
+
+	case CreateAttribute, DeleteAttribute:
+		return decodeAttribute(profile, data)
+
+	case AddIndexEntryRoot, DeleteIndexEntryRoot,
+		AddIndexEntryAllocation, DeleteIndexEntryAllocation:
+		return decodeIndexEntry(profile, data)
+
+	case UpdateFileNameRoot, UpdateFileNameAllocation:
+		return decodeDuplicatedInformation(data)
+
+	case SetNewAttributeSizes:
+		return decodeAttributeSizes(data)
+	}
+
+	if len(data) > MAX_RAW_DATA {
+		data = data[:MAX_RAW_DATA]
+	}
+	return ordereddict.NewDict().Set("Data", fmt.Sprintf("%x", data))
+}
+
+func decodeFileName(profile *ntfs.NTFSProfile,
+	reader *bytes.Reader, offset int64) *ordereddict.Dict {
+	file_name := profile.FILE_NAME(reader, offset)
+	return ordereddict.NewDict().
+		Set("Name", file_name.Name()).
+		Set("NameType", file_name.NameType().Name).
+		Set("ParentMFTId", file_name.MftReference()).
+		Set("ParentSequence", file_name.Seq_num()).
+		Set("Created", file_name.Created().Time).
+		Set("Modified", file_name.File_modified().Time).
+		Set("MFTModified", file_name.Mft_modified().Time).
+		Set("Accessed", file_name.File_accessed().Time).
+		Set("Size", file_name.FilenameSize())
+}
+
+func decodeStandardInformation(profile *ntfs.NTFSProfile,
+	reader *bytes.Reader, offset int64) *ordereddict.Dict {
+	si := profile.STANDARD_INFORMATION(reader, offset)
+	return ordereddict.NewDict().
+		Set("Created", si.Create_time().Time).
+		Set("Modified", si.File_altered_time().Time).
+		Set("MFTModified", si.Mft_altered_time().Time).
+		Set("Accessed", si.File_accessed_time().Time)
+}
+
+// An index entry in a directory. The key is the $FILE_NAME of the
+// file.
+func decodeIndexEntry(
+	profile *ntfs.NTFSProfile, data []byte) *ordereddict.Dict {
+	reader := bytes.NewReader(data)
+	entry := profile.INDEX_RECORD_ENTRY(reader, 0)
+
+	// Entries without a key (e.g. the last entry in a node).
+	if len(data) < INDEX_ENTRY_HEADER_SIZE {
+		return ordereddict.NewDict().Set("Data", fmt.Sprintf("%x", data))
+	}
+
+	// Only directory ($I30) indexes are keyed by $FILE_NAME. Other
+	// indexes (e.g. $ObjId or $Quota) have different keys.
+	key_length := int(binary.LittleEndian.Uint16(data[0x0a:]))
+	if key_length < FILE_NAME_SIZE ||
+		INDEX_ENTRY_HEADER_SIZE+key_length > len(data) ||
+		FILE_NAME_SIZE+2*int(data[INDEX_ENTRY_HEADER_SIZE+0x40]) > key_length {
+		key := data[INDEX_ENTRY_HEADER_SIZE:]
+		if len(key) > MAX_RAW_DATA {
+			key = key[:MAX_RAW_DATA]
+		}
+		return ordereddict.NewDict().Set("Key", fmt.Sprintf("%x", key))
+	}
+
+	return ordereddict.NewDict().
+		Set("MFTId", entry.MftReference()).
+		Set("Sequence", entry.Seq_num()).
+		Set("FileName", decodeFileName(
+			profile, reader, INDEX_ENTRY_HEADER_SIZE))
+}
+
+// An attribute record as stored in the MFT entry.
+func decodeAttribute(
+	profile *ntfs.NTFSProfile, data []byte) *ordereddict.Dict {
+	reader := bytes.NewReader(data)
+	attr := profile.NTFS_ATTRIBUTE(reader, 0)
+
+	result := ordereddict.NewDict().
+		Set("Type", attr.Type().Name).
+		Set("Name", attr.Name()).
+		Set("Resident", attr.IsResident())
+
+	if !attr.IsResident() {
+		return result.Set("Size", attr.Actual_size())
+	}
+
+	content_offset := int64(attr.Content_offset())
+	content_size := int64(attr.Content_size())
+	result.Set("Size", content_size)
+
+	if content_offset+content_size > int64(len(data)) {
+		return result
+	}
+
+	switch attr.Type().Value {
+	case ATTR_TYPE_FILE_NAME:
+		result.Set("FileName", decodeFileName(profile, reader, content_offset))
+
+	case ATTR_TYPE_STANDARD_INFORMATION:
+		result.Set("StandardInformation",
+			decodeStandardInformation(profile, reader, content_offset))
+	}
+
+	return result
+}
+
+// A complete MFT entry.
+func decodeFileRecord(
+	profile *ntfs.NTFSProfile, data []byte) *ordereddict.Dict {
+	reader := bytes.NewReader(data)
+	mft_entry := profile.MFT_ENTRY(reader, 0)
+
+	flags := mft_entry.Flags()
+	flag_names := []string{}
+	for k := range flags.Names {
+		flag_names = append(flag_names, k)
+	}
+	sort.Strings(flag_names)
+
+	result := ordereddict.NewDict().
+		Set("Sequence", mft_entry.Sequence_value()).
+		Set("Flags", flag_names).
+		Set("BaseRecord", mft_entry.Base_record_reference())
+
+	var attributes []*ordereddict.Dict
+	for offset := int(mft_entry.Attribute_offset()); offset+0x18 <= len(data); {
+		attr_type := binary.LittleEndian.Uint32(data[offset:])
+		length := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		if attr_type == 0xffffffff || length == 0 ||
+			offset+length > len(data) {
+			break
+		}
+
+		attributes = append(attributes,
+			decodeAttribute(profile, data[offset:offset+length]))
+		offset += length
+	}
+
+	return result.Set("Attributes", attributes)
+}
+
+// The duplicated information is the part of the $FILE_NAME which
+// is kept up to date in the directory index.
+func decodeDuplicatedInformation(data []byte) *ordereddict.Dict {
+	if len(data) < 0x38 {
+		return ordereddict.NewDict().Set("Data", fmt.Sprintf("%x", data))
+	}
+
+	profile := ntfs.NewNTFSProfile()
+	reader := bytes.NewReader(data)
+	return ordereddict.NewDict().
+		Set("Created", profile.WinFileTime(reader, 0x00).Time).
+		Set("Modified", profile.WinFileTime(reader, 0x08).Time).
+		Set("MFTModified", profile.WinFileTime(reader, 0x10).Time).
+		Set("Accessed", profile.WinFileTime(reader, 0x18).Time).
+		Set("AllocatedSize", binary.LittleEndian.Uint64(data[0x20:])).
+		Set("Size", binary.LittleEndian.Uint64(data[0x28:]))
+}
+
+func decodeAttributeSizes(data []byte) *ordereddict.Dict {
+	if len(data) < 0x18 {
+		return ordereddict.NewDict().Set("Data", fmt.Sprintf("%x", data))
+	}
+
+	return ordereddict.NewDict().
+		Set("AllocatedSize", binary.LittleEndian.Uint64(data[0x00:])).
+		Set("InitializedSize", binary.LittleEndian.Uint64(data[0x08:])).
+		Set("Size", binary.LittleEndian.Uint64(data[0x10:]))
+}
+
+// Find a $FILE_NAME in the decoded data so we can resolve its path.
+func findFileName(details *ordereddict.Dict) *ordereddict.Dict {
+	if details == nil {
+		return nil
+	}
+
+	file_name, pres := details.Get("FileName")
+	if pres {
+		result, ok := file_name.(*ordereddict.Dict)
+		if ok {
+			return result
+		}
+	}
+
+	attributes, pres := details.Get("Attributes")
+	if pres {
+		attributes, ok := attributes.([]*ordereddict.Dict)
+		if ok {
+			for _, attr := range attributes {
+				result := findFileName(attr)
+				if result != nil {
+					return result
+				}
+			}
+		}
+	}
+
+	return nil
+}
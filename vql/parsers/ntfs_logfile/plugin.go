@@ -0,0 +1,311 @@
+package ntfs_logfile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	ntfs "www.velocidex.com/golang/go-ntfs/parser"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/accessors/ntfs/readers"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+const (
+	// Used when we have no NTFS volume to get the geometry from.
+	DEFAULT_CLUSTER_SIZE = 0x1000
+	DEFAULT_RECORD_SIZE  = 0x400
+
+	ROOT_MFT_ID = 5
+)
+
+type LogFilePluginArgs struct {
+	Device   *accessors.OSPath `vfilter:"optional,field=device,doc=The device to parse $LogFile from. This is also used to resolve MFT references to paths."`
+	Filename *accessors.OSPath `vfilter:"optional,field=filename,doc=An extracted $LogFile to parse instead of the one on the device."`
+	Accessor string            `vfilter:"optional,field=accessor,doc=The accessor to use."`
+}
+
+// Resolves MFT references to paths using the NTFS context.
+type pathResolver struct {
+	ntfs_ctx *ntfs.NTFSContext
+	prefix   []string
+}
+
+func (self *pathResolver) mftPath(mft_id int64) string {
+	if self.ntfs_ctx == nil || mft_id < 0 {
+		return ""
+	}
+
+	links := ntfs.GetHardLinks(self.ntfs_ctx, uint64(mft_id), 1)
+	if len(links) == 0 {
+		return ""
+	}
+	return strings.Join(links[0], "\\")
+}
+
+// Resolve the path of a file from its $FILE_NAME. Like the USN
+// journal, the file itself may have been deleted since, so we
+// resolve the parent and add the name to it.
+func (self *pathResolver) fileNamePath(file_name *ordereddict.Dict) string {
+	if self.ntfs_ctx == nil {
+		return ""
+	}
+
+	name, _ := file_name.GetString("Name")
+	parent_id, _ := file_name.GetInt64("ParentMFTId")
+	parent_seq, _ := file_name.GetInt64("ParentSequence")
+
+	parent, err := self.ntfs_ctx.GetMFTSummary(uint64(parent_id))
+	if err != nil {
+		return fmt.Sprintf("<Err>\\<Parent %v Error %v>\\%v",
+			parent_id, err, name)
+	}
+
+	if int64(parent.Sequence) != parent_seq {
+		return fmt.Sprintf("<Err>\\<Parent %v-%v need %v>\\%v",
+			parent_id, parent.Sequence, parent_seq, name)
+	}
+
+	// Files in the root directory.
+	if parent_id == ROOT_MFT_ID {
+		return strings.Join(append(utils.CopySlice(self.prefix), name), "\\")
+	}
+
+	parent_path := self.mftPath(parent_id)
+	if parent_path == "" {
+		return name
+	}
+	return parent_path + "\\" + name
+}
+
+type LogFilePlugin struct{}
+
+func (self LogFilePlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("parse_ntfs_logfile", args)()
+
+		arg := &LogFilePluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("parse_ntfs_logfile: %v", err)
+			return
+		}
+
+		if arg.Device == nil && arg.Filename == nil {
+			scope.Log("parse_ntfs_logfile: One of device or filename must be specified")
+			return
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("parse_ntfs_logfile: %v", err)
+			return
+		}
+
+		resolver := &pathResolver{}
+		if arg.Device != nil {
+			// When parsing an extracted $LogFile the device is only
+			// used for resolving paths so it is always an NTFS path.
+			device_accessor := arg.Accessor
+			if device_accessor == "" || arg.Filename != nil {
+				device_accessor = "ntfs"
+				arg.Device, err = accessors.NewWindowsNTFSPath(
+					arg.Device.String())
+				if err != nil {
+					scope.Log("parse_ntfs_logfile: %v", err)
+					return
+				}
+			}
+
+			device, accessor, err := readers.GetRawDeviceAndAccessor(
+				scope, arg.Device, device_accessor)
+			if err != nil {
+				scope.Log("parse_ntfs_logfile: %v", err)
+				return
+			}
+
+			ntfs_ctx, err := readers.GetNTFSContext(scope, device, accessor)
+			if err != nil {
+				scope.Log("parse_ntfs_logfile: %v", err)
+				return
+			}
+			defer ntfs_ctx.Close()
+
+			options := readers.GetScopeOptions(scope)
+			options.PrefixComponents = arg.Device.Components
+			ntfs_ctx.SetOptions(options)
+
+			resolver.ntfs_ctx = ntfs_ctx
+			resolver.prefix = arg.Device.Components
+		}
+
+		var reader io.ReaderAt
+		var size int64
+		if arg.Filename != nil {
+			accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+			if err != nil {
+				scope.Log("parse_ntfs_logfile: %v", err)
+				return
+			}
+
+			stat, err := accessor.LstatWithOSPath(arg.Filename)
+			if err != nil {
+				scope.Log("parse_ntfs_logfile: %v", err)
+				return
+			}
+			size = stat.Size()
+
+			fd, err := accessor.OpenWithOSPath(arg.Filename)
+			if err != nil {
+				scope.Log("parse_ntfs_logfile: %v", err)
+				return
+			}
+			defer fd.Close()
+
+			reader = utils.MakeReaderAtter(fd)
+
+		} else {
+			data, err := ntfs.GetDataForPath(resolver.ntfs_ctx, "$LogFile")
+			if err != nil {
+				scope.Log("parse_ntfs_logfile: %v", err)
+				return
+			}
+
+			reader = data
+			for _, rng := range data.Ranges() {
+				if rng.Offset+rng.Length > size {
+					size = rng.Offset + rng.Length
+				}
+			}
+		}
+
+		log_file, err := OpenLogFile(ctx, reader, size)
+		if err != nil {
+			scope.Log("parse_ntfs_logfile: %v", err)
+			return
+		}
+
+		cluster_size := int64(DEFAULT_CLUSTER_SIZE)
+		record_size := int64(DEFAULT_RECORD_SIZE)
+		if resolver.ntfs_ctx != nil {
+			cluster_size = resolver.ntfs_ctx.ClusterSize
+			record_size = resolver.ntfs_ctx.GetRecordSize()
+		}
+
+		profile := ntfs.NewNTFSProfile()
+		err = log_file.Records(ctx, func(record *LogRecord) error {
+			row := makeRow(profile, resolver,
+				record, cluster_size, record_size)
+
+			select {
+			case <-ctx.Done():
+				return stopWalk
+			case output_chan <- row:
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, stopWalk) {
+			scope.Log("parse_ntfs_logfile: %v", err)
+		}
+	}()
+
+	return output_chan
+}
+
+func makeRow(profile *ntfs.NTFSProfile, resolver *pathResolver,
+	record *LogRecord, cluster_size, record_size int64) *ordereddict.Dict {
+	result := ordereddict.NewDict().
+		Set("LSN", record.LSN).
+		Set("PreviousLSN", record.PreviousLSN).
+		Set("UndoNextLSN", record.UndoNextLSN).
+		Set("TransactionId", record.TransactionId).
+		Set("RecordType", record.RecordTypeString())
+
+	// Restart records do not carry NTFS operations.
+	if record.RecordType != RECORD_TYPE_CLIENT {
+		return setOperation(result, nil, -1, "", nil, nil)
+	}
+
+	ntfs_record, err := ParseNTFSLogRecord(record.Data)
+	if err != nil {
+		return setOperation(result, nil, -1, "", nil, nil).
+			Set("Error", err.Error())
+	}
+
+	redo := decodeOperation(profile, ntfs_record.RedoOp, ntfs_record.Redo)
+	undo := decodeOperation(profile, ntfs_record.UndoOp, ntfs_record.Undo)
+
+	mft_id := int64(-1)
+	if ntfs_record.TargetsMFT() {
+		mft_id = ntfs_record.MFTId(cluster_size, record_size)
+	}
+
+	// Prefer the name in the record itself since the MFT entry may
+	// have been reused since.
+	os_path := ""
+	file_name := findFileName(redo)
+	if file_name == nil {
+		file_name = findFileName(undo)
+	}
+	if file_name != nil {
+		os_path = resolver.fileNamePath(file_name)
+	} else {
+		os_path = resolver.mftPath(mft_id)
+	}
+
+	return setOperation(result, ntfs_record, mft_id, os_path, redo, undo)
+}
+
+// All rows have the same columns, even if the record has no NTFS
+// operation.
+func setOperation(result *ordereddict.Dict, ntfs_record *NTFSLogRecord,
+	mft_id int64, os_path string, redo, undo *ordereddict.Dict) *ordereddict.Dict {
+	var redo_op, undo_op vfilter.Any
+	if ntfs_record == nil {
+		ntfs_record = &NTFSLogRecord{}
+	} else {
+		redo_op = OperationName(ntfs_record.RedoOp)
+		undo_op = OperationName(ntfs_record.UndoOp)
+	}
+
+	return result.
+		Set("Redo", redo_op).
+		Set("Undo", undo_op).
+		Set("MFTId", mft_id).
+		Set("OSPath", os_path).
+		Set("TargetAttribute", ntfs_record.TargetAttribute).
+		Set("TargetVCN", ntfs_record.TargetVCN).
+		Set("ClusterBlockOffset", ntfs_record.ClusterBlockOffset).
+		Set("RecordOffset", ntfs_record.RecordOffset).
+		Set("AttributeOffset", ntfs_record.AttributeOffset).
+		Set("RedoDetails", redo).
+		Set("UndoDetails", undo)
+}
+
+func (self LogFilePlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "parse_ntfs_logfile",
+		Doc:      "Parse the NTFS $LogFile to recover recent metadata changes.",
+		ArgType:  type_map.AddType(scope, &LogFilePluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&LogFilePlugin{})
+}
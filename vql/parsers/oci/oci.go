@@ -0,0 +1,288 @@
+package oci
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/accessors/overlay"
+	"www.velocidex.com/golang/velociraptor/json"
+)
+
+const (
+	MEDIA_TYPE_OCI_INDEX       = "application/vnd.oci.image.index.v1+json"
+	MEDIA_TYPE_DOCKER_LIST     = "application/vnd.docker.distribution.manifest.list.v2+json"
+	ANNOTATION_REF_NAME        = "org.opencontainers.image.ref.name"
+	ANNOTATION_CONTAINERD_NAME = "io.containerd.image.name"
+
+	// Do not read unreasonably large metadata files.
+	MAX_METADATA_SIZE = 10 * 1024 * 1024
+)
+
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations"`
+	Platform    *struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+		Variant      string `json:"variant"`
+	} `json:"platform"`
+}
+
+type index struct {
+	MediaType string        `json:"mediaType"`
+	Manifests []*descriptor `json:"manifests"`
+}
+
+type manifest struct {
+	MediaType string        `json:"mediaType"`
+	Config    *descriptor   `json:"config"`
+	Layers    []*descriptor `json:"layers"`
+}
+
+// The manifest.json written by docker save.
+type dockerManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+type imageConfig struct {
+	Architecture string              `json:"architecture"`
+	OS           string              `json:"os"`
+	Created      string              `json:"created"`
+	Config       *ordereddict.Dict   `json:"config"`
+	History      []*ordereddict.Dict `json:"history"`
+}
+
+type Image struct {
+	Name     string
+	Digest   string
+	Platform string
+	Created  string
+	Config   *ordereddict.Dict
+	History  []*ordereddict.Dict
+
+	// The layers from the bottom layer up.
+	Layers []*accessors.PathSpec
+}
+
+// The overlay accessor path presenting the image's filesystem.
+func (self *Image) Root() *accessors.OSPath {
+	return overlay.NewOverlayPath(&overlay.OverlaySpec{Lower: self.Layers})
+}
+
+type imageReader struct {
+	accessor      accessors.FileSystemAccessor
+	accessor_name string
+	root          *accessors.OSPath
+}
+
+func (self *imageReader) path(components ...string) *accessors.OSPath {
+	return self.root.Append(components...)
+}
+
+func (self *imageReader) readJSON(path *accessors.OSPath, target interface{}) error {
+	fd, err := self.accessor.OpenWithOSPath(path)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(fd, MAX_METADATA_SIZE))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func (self *imageReader) blobPath(digest string) (*accessors.OSPath, error) {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" ||
+		strings.ContainsAny(digest, "/\\") {
+		return nil, fmt.Errorf("Invalid digest %v", digest)
+	}
+	return self.path("blobs", parts[0], parts[1]), nil
+}
+
+func (self *imageReader) layerSpec(path *accessors.OSPath) *accessors.PathSpec {
+	return &accessors.PathSpec{
+		DelegateAccessor: self.accessor_name,
+		DelegatePath:     path.String(),
+	}
+}
+
+func (self *imageReader) readConfig(path *accessors.OSPath, image *Image) error {
+	config := &imageConfig{}
+	err := self.readJSON(path, config)
+	if err != nil {
+		return err
+	}
+
+	if image.Platform == "" && config.OS != "" {
+		image.Platform = config.OS + "/" + config.Architecture
+	}
+	image.Created = config.Created
+	image.Config = config.Config
+	image.History = config.History
+	return nil
+}
+
+// Read the images in an OCI image layout.
+func (self *imageReader) readIndex(
+	path *accessors.OSPath, name string, depth int) ([]*Image, error) {
+	if depth > 5 {
+		return nil, errors.New("Image indexes are nested too deeply")
+	}
+
+	idx := &index{}
+	err := self.readJSON(path, idx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Image
+	for _, desc := range idx.Manifests {
+		image_name := desc.Annotations[ANNOTATION_CONTAINERD_NAME]
+		if image_name == "" {
+			image_name = desc.Annotations[ANNOTATION_REF_NAME]
+		}
+		if image_name == "" {
+			image_name = name
+		}
+
+		blob, err := self.blobPath(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+
+		switch desc.MediaType {
+		case MEDIA_TYPE_OCI_INDEX, MEDIA_TYPE_DOCKER_LIST:
+			images, err := self.readIndex(blob, image_name, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, images...)
+			continue
+		}
+
+		m := &manifest{}
+		err = self.readJSON(blob, m)
+		if err != nil {
+			// Multi platform images often only contain the blobs
+			// for one platform.
+			continue
+		}
+
+		image := &Image{
+			Name:   image_name,
+			Digest: desc.Digest,
+		}
+		if desc.Platform != nil {
+			image.Platform = desc.Platform.OS + "/" + desc.Platform.Architecture
+		}
+
+		if m.Config != nil {
+			config, err := self.blobPath(m.Config.Digest)
+			if err != nil {
+				return nil, err
+			}
+			err = self.readConfig(config, image)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, l := range m.Layers {
+			layer, err := self.blobPath(l.Digest)
+			if err != nil {
+				return nil, err
+			}
+			image.Layers = append(image.Layers, self.layerSpec(layer))
+		}
+		result = append(result, image)
+	}
+
+	return result, nil
+}
+
+func (self *imageReader) relativePath(name string) (*accessors.OSPath, error) {
+	var components []string
+	for _, c := range strings.Split(name, "/") {
+		if c == ".." {
+			return nil, fmt.Errorf("Invalid path %v", name)
+		}
+		if c != "" && c != "." {
+			components = append(components, c)
+		}
+	}
+	return self.path(components...), nil
+}
+
+// Read the images written by docker save.
+func (self *imageReader) readDockerManifest(
+	path *accessors.OSPath) ([]*Image, error) {
+	var manifests []*dockerManifest
+	err := self.readJSON(path, &manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Image
+	for _, m := range manifests {
+		image := &Image{}
+		if len(m.RepoTags) > 0 {
+			image.Name = m.RepoTags[0]
+		}
+
+		config, err := self.relativePath(m.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		err = self.readConfig(config, image)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, l := range m.Layers {
+			layer, err := self.relativePath(l)
+			if err != nil {
+				return nil, err
+			}
+			image.Layers = append(image.Layers, self.layerSpec(layer))
+		}
+		result = append(result, image)
+	}
+	return result, nil
+}
+
+// Read the images in an OCI image layout or docker save directory.
+func ReadImages(accessor accessors.FileSystemAccessor, accessor_name string,
+	root *accessors.OSPath) ([]*Image, error) {
+	reader := &imageReader{
+		accessor:      accessor,
+		accessor_name: accessor_name,
+		root:          root,
+	}
+
+	index_path := reader.path("index.json")
+	_, err := accessor.LstatWithOSPath(index_path)
+	if err == nil {
+		return reader.readIndex(index_path, "", 0)
+	}
+
+	manifest_path := reader.path("manifest.json")
+	_, err = accessor.LstatWithOSPath(manifest_path)
+	if err == nil {
+		return reader.readDockerManifest(manifest_path)
+	}
+
+	return nil, errors.New("No index.json or manifest.json found")
+}
@@ -0,0 +1,110 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+// Store a blob in the layout and return its digest.
+func writeBlob(t *testing.T, dir string, data []byte) string {
+	hash := sha256.Sum256(data)
+	name := hex.EncodeToString(hash[:])
+	path := filepath.Join(dir, "blobs", "sha256", name)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	assert.NoError(t, os.WriteFile(path, data, 0600))
+	return "sha256:" + name
+}
+
+func buildLayer(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	w := tar.NewWriter(buf)
+	for name, data := range files {
+		assert.NoError(t, w.WriteHeader(&tar.Header{
+			Name: name, Mode: 0644, Size: int64(len(data)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := w.Write([]byte(data))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestReadOCIImage(t *testing.T) {
+	dir := t.TempDir()
+
+	layer1 := writeBlob(t, dir, buildLayer(t, map[string]string{
+		"etc/os-release": "ID=alpine", "bin/sh": "sh"}))
+	layer2 := writeBlob(t, dir, buildLayer(t, map[string]string{
+		"etc/os-release": "ID=custom", "bin/.wh.sh": ""}))
+
+	config := writeBlob(t, dir, []byte(`{"architecture": "amd64", "os": "linux",
+  "created": "2024-01-02T03:04:05Z",
+  "config": {"Entrypoint": ["/app"], "Env": ["PATH=/bin"]}}`))
+
+	manifest := writeBlob(t, dir, []byte(`{"schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {"mediaType": "application/vnd.oci.image.config.v1+json",
+             "digest": "`+config+`"},
+  "layers": [
+    {"mediaType": "application/vnd.oci.image.layer.v1.tar", "digest": "`+layer1+`"},
+    {"mediaType": "application/vnd.oci.image.layer.v1.tar", "digest": "`+layer2+`"}]}`))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "index.json"), []byte(`{
+  "schemaVersion": 2, "manifests": [
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json",
+     "digest": "`+manifest+`",
+     "annotations": {"org.opencontainers.image.ref.name": "app:1"}}]}`), 0600))
+
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	defer scope.Close()
+
+	accessor, err := accessors.GetAccessor("file", scope)
+	assert.NoError(t, err)
+
+	root, err := accessor.ParsePath(dir)
+	assert.NoError(t, err)
+
+	images, err := ReadImages(accessor, "file", root)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(images))
+
+	image := images[0]
+	assert.Equal(t, "app:1", image.Name)
+	assert.Equal(t, manifest, image.Digest)
+	assert.Equal(t, "linux/amd64", image.Platform)
+	assert.Equal(t, 2, len(image.Layers))
+
+	entrypoint, _ := image.Config.Get("Entrypoint")
+	assert.Equal(t, []interface{}{"/app"}, entrypoint)
+
+	// The image's filesystem is available through the overlay
+	// accessor.
+	overlay, err := accessors.GetAccessor("overlay", scope)
+	assert.NoError(t, err)
+
+	fd, err := overlay.OpenWithOSPath(image.Root().Append("etc", "os-release"))
+	assert.NoError(t, err)
+	data, err := ioutil.ReadAll(fd)
+	assert.NoError(t, err)
+	fd.Close()
+	assert.Equal(t, "ID=custom", string(data))
+
+	_, err = overlay.LstatWithOSPath(image.Root().Append("bin", "sh"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package oci
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type OCIImagePluginArgs struct {
+	Path     *accessors.OSPath `vfilter:"required,field=path,doc=The OCI image layout or docker save directory."`
+	Accessor string            `vfilter:"optional,field=accessor,doc=The accessor to use."`
+}
+
+type OCIImagePlugin struct{}
+
+func (self OCIImagePlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("parse_oci_image", args)()
+
+		arg := &OCIImagePluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("parse_oci_image: %v", err)
+			return
+		}
+
+		if arg.Accessor == "" {
+			arg.Accessor = "auto"
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("parse_oci_image: %s", err)
+			return
+		}
+
+		accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+		if err != nil {
+			scope.Log("parse_oci_image: %v", err)
+			return
+		}
+
+		images, err := ReadImages(accessor, arg.Accessor, arg.Path)
+		if err != nil {
+			scope.Log("parse_oci_image: %v: %v", arg.Path, err)
+			return
+		}
+
+		for _, image := range images {
+			select {
+			case <-ctx.Done():
+				return
+
+			case output_chan <- ordereddict.NewDict().
+				Set("Name", image.Name).
+				Set("Digest", image.Digest).
+				Set("Platform", image.Platform).
+				Set("Created", image.Created).
+				Set("Config", image.Config).
+				Set("History", image.History).
+				Set("Layers", image.Layers).
+				Set("Root", image.Root()):
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self OCIImagePlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "parse_oci_image",
+		Doc:      "Parse the images in an OCI image layout or docker save directory.",
+		ArgType:  type_map.AddType(scope, &OCIImagePluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&OCIImagePlugin{})
+}
@@ -0,0 +1,291 @@
+package packages
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/accessors"
+)
+
+const (
+	// Long Description fields need more than the default scanner
+	// buffer.
+	DPKG_MAX_LINE_SIZE = 1024 * 1024
+)
+
+type DpkgFile struct {
+	Path            string
+	Digest          string
+	DigestAlgorithm string
+	Config          bool
+}
+
+type DpkgPackage struct {
+	Name          string
+	Version       string
+	Arch          string
+	Status        string
+	Source        string
+	Maintainer    string
+	Summary       string
+	InstalledSize int64
+	InstallTime   time.Time
+	Files         []*DpkgFile
+}
+
+// A parsed stanza from the status file. Continuation lines are
+// joined with a newline.
+type dpkgParagraph map[string]string
+
+// Parse the RFC822 style paragraphs of a dpkg status file.
+func parseDpkgParagraphs(ctx context.Context,
+	reader io.Reader, cb func(p dpkgParagraph)) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), DPKG_MAX_LINE_SIZE)
+
+	paragraph := dpkgParagraph{}
+	last_key := ""
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.TrimSpace(line) == "" {
+			if len(paragraph) > 0 {
+				cb(paragraph)
+				paragraph = dpkgParagraph{}
+			}
+			last_key = ""
+
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+			}
+			continue
+		}
+
+		if line[0] == ' ' || line[0] == '\t' {
+			if last_key != "" {
+				paragraph[last_key] += "\n" + line[1:]
+			}
+			continue
+		}
+
+		idx := strings.IndexByte(line, ':')
+		if idx <= 0 {
+			continue
+		}
+		last_key = line[:idx]
+		paragraph[last_key] = strings.TrimSpace(line[idx+1:])
+	}
+
+	if len(paragraph) > 0 {
+		cb(paragraph)
+	}
+	return scanner.Err()
+}
+
+func (self dpkgParagraph) newPackage() *DpkgPackage {
+	result := &DpkgPackage{
+		Name:       self["Package"],
+		Version:    self["Version"],
+		Arch:       self["Architecture"],
+		Status:     self["Status"],
+		Source:     self["Source"],
+		Maintainer: self["Maintainer"],
+	}
+
+	// The first line of the description is the summary.
+	result.Summary = strings.SplitN(self["Description"], "\n", 2)[0]
+
+	// Installed-Size is in KiB.
+	size, err := strconv.ParseInt(self["Installed-Size"], 10, 64)
+	if err == nil {
+		result.InstalledSize = size * 1024
+	}
+	return result
+}
+
+// Conffiles are listed in the status file with the digest of the
+// file as shipped: " /etc/foo.conf <md5> [obsolete]"
+func (self dpkgParagraph) conffiles() map[string]string {
+	result := make(map[string]string)
+	for _, line := range strings.Split(self["Conffiles"], "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 {
+			result[fields[0]] = fields[1]
+		}
+	}
+	return result
+}
+
+// The names of the package's files in the info directory. Packages
+// that are Multi-Arch: same include the architecture.
+func dpkgInfoNames(pkg *DpkgPackage, ext string) []string {
+	result := []string{}
+	if pkg.Arch != "" {
+		result = append(result, pkg.Name+":"+pkg.Arch+ext)
+	}
+	return append(result, pkg.Name+ext)
+}
+
+func openFirst(accessor accessors.FileSystemAccessor,
+	dir *accessors.OSPath, names []string) (
+	accessors.ReadSeekCloser, accessors.FileInfo, error) {
+	var err error
+	for _, name := range names {
+		var stat accessors.FileInfo
+		stat, err = accessor.LstatWithOSPath(dir.Append(name))
+		if err != nil {
+			continue
+		}
+
+		var fd accessors.ReadSeekCloser
+		fd, err = accessor.OpenWithOSPath(dir.Append(name))
+		if err == nil {
+			return fd, stat, nil
+		}
+	}
+	return nil, nil, err
+}
+
+func readLines(fd io.Reader, cb func(line string)) error {
+	scanner := bufio.NewScanner(fd)
+	scanner.Buffer(make([]byte, 64*1024), DPKG_MAX_LINE_SIZE)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line != "" {
+			cb(line)
+		}
+	}
+	return scanner.Err()
+}
+
+// Read the digests from an md5sums file. The paths are relative to
+// the root: "<md5>  usr/bin/foo"
+func readDpkgMd5sums(fd io.Reader) (map[string]string, []string, error) {
+	result := make(map[string]string)
+	order := []string{}
+	err := readLines(fd, func(line string) {
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) != 2 {
+			return
+		}
+		p := path.Clean("/" + strings.TrimLeft(fields[1], " *"))
+		result[p] = fields[0]
+		order = append(order, p)
+	})
+	return result, order, err
+}
+
+// Fill in the package's files from the dpkg info directory. The
+// .list file is rewritten whenever the package is installed or
+// upgraded so its modification time is the install time.
+func addDpkgFiles(accessor accessors.FileSystemAccessor,
+	info_dir *accessors.OSPath, paragraph dpkgParagraph, pkg *DpkgPackage) {
+	var paths []string
+
+	fd, stat, err := openFirst(accessor, info_dir, dpkgInfoNames(pkg, ".list"))
+	if err == nil {
+		pkg.InstallTime = stat.ModTime().UTC()
+		_ = readLines(fd, func(line string) {
+			if line != "/." {
+				paths = append(paths, line)
+			}
+		})
+		fd.Close()
+	}
+
+	digests := make(map[string]string)
+	fd, stat, err = openFirst(accessor, info_dir, dpkgInfoNames(pkg, ".md5sums"))
+	if err == nil {
+		var order []string
+		digests, order, _ = readDpkgMd5sums(fd)
+		fd.Close()
+
+		// Some layouts (e.g. distroless images) have no file
+		// lists.
+		if len(paths) == 0 {
+			paths = order
+			pkg.InstallTime = stat.ModTime().UTC()
+		}
+	}
+
+	conffiles := paragraph.conffiles()
+	for _, p := range paths {
+		file := &DpkgFile{Path: p}
+		digest, pres := conffiles[p]
+		if pres {
+			file.Config = true
+		} else {
+			digest, pres = digests[p]
+		}
+
+		// Conffiles which are no longer shipped are marked
+		// "newconffile" or "obsolete" instead of a digest.
+		if pres && len(digest) == 32 {
+			file.Digest = digest
+			file.DigestAlgorithm = "md5"
+		}
+		pkg.Files = append(pkg.Files, file)
+	}
+}
+
+// Parse the dpkg status file and call cb with each package. If
+// filename is a status.d directory (as used in distroless images),
+// each file in it is parsed and the info files are read from the
+// same directory.
+func parseDpkgStatus(ctx context.Context,
+	accessor accessors.FileSystemAccessor, filename *accessors.OSPath,
+	cb func(pkg *DpkgPackage)) error {
+	stat, err := accessor.LstatWithOSPath(filename)
+	if err != nil {
+		return err
+	}
+
+	if !stat.IsDir() {
+		return parseDpkgStatusFile(ctx, accessor, filename,
+			filename.Dirname().Append("info"), cb)
+	}
+
+	children, err := accessor.ReadDirWithOSPath(filename)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if child.IsDir() || strings.HasSuffix(child.Name(), ".md5sums") {
+			continue
+		}
+
+		err = parseDpkgStatusFile(ctx, accessor, child.OSPath(), filename, cb)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseDpkgStatusFile(ctx context.Context,
+	accessor accessors.FileSystemAccessor,
+	filename, info_dir *accessors.OSPath, cb func(pkg *DpkgPackage)) error {
+	fd, err := accessor.OpenWithOSPath(filename)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	return parseDpkgParagraphs(ctx, fd, func(paragraph dpkgParagraph) {
+		pkg := paragraph.newPackage()
+		if pkg.Name == "" {
+			return
+		}
+		addDpkgFiles(accessor, info_dir, paragraph, pkg)
+		cb(pkg)
+	})
+}
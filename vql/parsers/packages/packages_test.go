@@ -0,0 +1,292 @@
+package packages
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/jmoiron/sqlx"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+	"www.velocidex.com/golang/vfilter"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+type testTag struct {
+	tag, typ uint32
+	value    interface{}
+}
+
+// Build a header blob as rpm stores it in the database.
+func buildRPMHeader(tags []testTag) []byte {
+	var index, data []byte
+	for _, t := range tags {
+		var count int
+		offset := len(data)
+		switch v := t.value.(type) {
+		case string:
+			data = append(append(data, v...), 0)
+			count = 1
+		case []string:
+			for _, s := range v {
+				data = append(append(data, s...), 0)
+			}
+			count = len(v)
+		case []uint32:
+			for len(data)%4 != 0 {
+				data = append(data, 0)
+			}
+			offset = len(data)
+			for _, i := range v {
+				data = binary.BigEndian.AppendUint32(data, i)
+			}
+			count = len(v)
+		case []uint16:
+			for len(data)%2 != 0 {
+				data = append(data, 0)
+			}
+			offset = len(data)
+			for _, i := range v {
+				data = binary.BigEndian.AppendUint16(data, i)
+			}
+			count = len(v)
+		}
+
+		index = binary.BigEndian.AppendUint32(index, t.tag)
+		index = binary.BigEndian.AppendUint32(index, t.typ)
+		index = binary.BigEndian.AppendUint32(index, uint32(offset))
+		index = binary.BigEndian.AppendUint32(index, uint32(count))
+	}
+
+	result := binary.BigEndian.AppendUint32(nil, uint32(len(tags)))
+	result = binary.BigEndian.AppendUint32(result, uint32(len(data)))
+	return append(append(result, index...), data...)
+}
+
+func testPackage(name string) []byte {
+	return buildRPMHeader([]testTag{
+		{RPMTAG_NAME, RPM_STRING_TYPE, name},
+		{RPMTAG_VERSION, RPM_STRING_TYPE, "1.2"},
+		{RPMTAG_RELEASE, RPM_STRING_TYPE, "3.el9"},
+		{RPMTAG_ARCH, RPM_STRING_TYPE, "x86_64"},
+		{RPMTAG_SUMMARY, RPM_I18NSTRING_TYPE, []string{"A package"}},
+		{RPMTAG_INSTALLTIME, RPM_INT32_TYPE, []uint32{1700000000}},
+		{RPMTAG_DIRNAMES, RPM_STRING_ARRAY_TYPE, []string{"/usr/bin/", "/etc/"}},
+		{RPMTAG_BASENAMES, RPM_STRING_ARRAY_TYPE, []string{name, name + ".conf"}},
+		{RPMTAG_DIRINDEXES, RPM_INT32_TYPE, []uint32{0, 1}},
+		{RPMTAG_FILESIZES, RPM_INT32_TYPE, []uint32{100, 10}},
+		{RPMTAG_FILEMODES, RPM_INT16_TYPE, []uint16{0100755, 0100644}},
+		{RPMTAG_FILEFLAGS, RPM_INT32_TYPE, []uint32{0, 1 | 1<<4}},
+		{RPMTAG_FILEDIGESTS, RPM_STRING_ARRAY_TYPE, []string{"aa", "bb"}},
+		{RPMTAG_FILEDIGESTALGO, RPM_INT32_TYPE, []uint32{8}},
+	})
+}
+
+// Build a little endian BerkeleyDB hash database with each blob in a
+// chain of overflow pages.
+func buildBDB(blobs [][]byte) []byte {
+	pagesize := 512
+	pages := [][]byte{make([]byte, pagesize), make([]byte, pagesize)}
+
+	hash := pages[1]
+	hash[25] = BDB_P_HASH
+	binary.LittleEndian.PutUint16(hash[20:], uint16(len(blobs)*2))
+
+	end := pagesize
+	for i, blob := range blobs {
+		// The key
+		end -= 8
+		hash[end] = 1
+		binary.LittleEndian.PutUint16(hash[BDB_PAGE_HEADER_SIZE+i*4:], uint16(end))
+
+		// The value points at the overflow pages.
+		end -= 12
+		hash[end] = BDB_H_OFFPAGE
+		binary.LittleEndian.PutUint32(hash[end+4:], uint32(len(pages)))
+		binary.LittleEndian.PutUint32(hash[end+8:], uint32(len(blob)))
+		binary.LittleEndian.PutUint16(hash[BDB_PAGE_HEADER_SIZE+i*4+2:], uint16(end))
+
+		for len(blob) > 0 {
+			page := make([]byte, pagesize)
+			page[25] = BDB_P_OVERFLOW
+			n := copy(page[BDB_PAGE_HEADER_SIZE:], blob)
+			binary.LittleEndian.PutUint16(page[22:], uint16(n))
+			blob = blob[n:]
+			if len(blob) > 0 {
+				binary.LittleEndian.PutUint32(page[16:], uint32(len(pages)+1))
+			}
+			pages = append(pages, page)
+		}
+	}
+
+	meta := pages[0]
+	binary.LittleEndian.PutUint32(meta[12:], BDB_HASH_MAGIC)
+	binary.LittleEndian.PutUint32(meta[20:], uint32(pagesize))
+	binary.LittleEndian.PutUint32(meta[32:], uint32(len(pages)-1))
+
+	var result []byte
+	for _, p := range pages {
+		result = append(result, p...)
+	}
+	return result
+}
+
+func buildNDB(blobs [][]byte) []byte {
+	result := make([]byte, NDB_PAGE_SIZE)
+	binary.LittleEndian.PutUint32(result, NDB_HEADER_MAGIC)
+	binary.LittleEndian.PutUint32(result[12:], 1)
+
+	for i, blob := range blobs {
+		slot := result[NDB_HEADER_SIZE+i*NDB_SLOT_SIZE:]
+		binary.LittleEndian.PutUint32(slot, NDB_SLOT_MAGIC)
+		binary.LittleEndian.PutUint32(slot[4:], uint32(i+1))
+		binary.LittleEndian.PutUint32(slot[8:], uint32(len(result)/NDB_BLK_SIZE))
+
+		result = binary.LittleEndian.AppendUint32(result, NDB_BLOB_MAGIC)
+		result = binary.LittleEndian.AppendUint32(result, uint32(i+1))
+		result = binary.LittleEndian.AppendUint32(result, 0)
+		result = binary.LittleEndian.AppendUint32(result, uint32(len(blob)))
+		result = append(result, blob...)
+		for len(result)%NDB_BLK_SIZE != 0 {
+			result = append(result, 0)
+		}
+	}
+	return result
+}
+
+func makeScope() vfilter.Scope {
+	return vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+}
+
+func readPackages(t *testing.T, scope vfilter.Scope,
+	filename string) []*RPMPackage {
+	var result []*RPMPackage
+	err := walkRPMDatabase(context.Background(), scope, "file",
+		accessors.MustNewLinuxOSPath(filename), func(blob []byte) error {
+			pkg, err := newRPMPackage(blob)
+			assert.NoError(t, err)
+			result = append(result, pkg)
+			return nil
+		})
+	assert.NoError(t, err)
+	return result
+}
+
+func TestRPMDatabase(t *testing.T) {
+	scope := makeScope()
+	defer scope.Close()
+
+	blobs := [][]byte{testPackage("bash"), testPackage("openssh")}
+	dir := t.TempDir()
+
+	bdb := filepath.Join(dir, "bdb")
+	assert.NoError(t, os.MkdirAll(bdb, 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(bdb, "Packages"), buildBDB(blobs), 0600))
+
+	ndb := filepath.Join(dir, "ndb")
+	assert.NoError(t, os.MkdirAll(ndb, 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(ndb, "Packages.db"), buildNDB(blobs), 0600))
+
+	sqlite := filepath.Join(dir, "sqlite")
+	assert.NoError(t, os.MkdirAll(sqlite, 0700))
+	db, err := sqlx.Connect("sqlite3", filepath.Join(sqlite, "rpmdb.sqlite"))
+	assert.NoError(t, err)
+	db.MustExec("CREATE TABLE Packages (hnum INTEGER PRIMARY KEY AUTOINCREMENT, blob BLOB NOT NULL)")
+	for _, blob := range blobs {
+		db.MustExec("INSERT INTO Packages (blob) VALUES (?)", blob)
+	}
+	assert.NoError(t, db.Close())
+
+	for _, backend := range []string{bdb, ndb, sqlite} {
+		packages := readPackages(t, scope, backend)
+		assert.Equal(t, 2, len(packages))
+		assert.Equal(t, "bash", packages[0].Name)
+		assert.Equal(t, "openssh", packages[1].Name)
+
+		pkg := packages[0]
+		assert.Equal(t, "1.2", pkg.Version)
+		assert.Equal(t, "3.el9", pkg.Release)
+		assert.Equal(t, "x86_64", pkg.Arch)
+		assert.Equal(t, "A package", pkg.Summary)
+		assert.Equal(t, int64(1700000000), pkg.InstallTime.Unix())
+
+		assert.Equal(t, 2, len(pkg.Files))
+		assert.Equal(t, &RPMFile{
+			Path: "/usr/bin/bash", Size: 100, Mode: "-rwxr-xr-x",
+			Mtime: pkg.Files[0].Mtime, Digest: "aa", DigestAlgorithm: "sha256",
+		}, pkg.Files[0])
+		assert.Equal(t, "/etc/bash.conf", pkg.Files[1].Path)
+		assert.Equal(t, []string{"config", "noreplace"}, pkg.Files[1].Flags)
+	}
+}
+
+func TestDpkgStatus(t *testing.T) {
+	scope := makeScope()
+	defer scope.Close()
+
+	dir := t.TempDir()
+	info := filepath.Join(dir, "info")
+	assert.NoError(t, os.MkdirAll(info, 0700))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "status"), []byte(`Package: foo
+Status: install ok installed
+Installed-Size: 10
+Architecture: amd64
+Multi-Arch: same
+Version: 1:2.0-1
+Conffiles:
+ /etc/foo.conf 0123456789abcdef0123456789abcdef
+ /etc/old.conf newconffile
+Description: The foo tool
+ Longer description.
+
+Package: bar
+Status: deinstall ok config-files
+Architecture: all
+Version: 3.0
+`), 0600))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(info, "foo:amd64.list"),
+		[]byte("/.\n/etc\n/etc/foo.conf\n/usr/bin/foo\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(info, "foo:amd64.md5sums"),
+		[]byte("fedcba9876543210fedcba9876543210  usr/bin/foo\n"), 0600))
+
+	accessor, err := accessors.GetAccessor("file", scope)
+	assert.NoError(t, err)
+
+	var packages []*DpkgPackage
+	err = parseDpkgStatus(context.Background(), accessor,
+		accessors.MustNewLinuxOSPath(filepath.Join(dir, "status")),
+		func(pkg *DpkgPackage) {
+			packages = append(packages, pkg)
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(packages))
+
+	foo := packages[0]
+	assert.Equal(t, "foo", foo.Name)
+	assert.Equal(t, "1:2.0-1", foo.Version)
+	assert.Equal(t, "amd64", foo.Arch)
+	assert.Equal(t, "The foo tool", foo.Summary)
+	assert.Equal(t, int64(10240), foo.InstalledSize)
+	assert.True(t, !foo.InstallTime.IsZero())
+	assert.Equal(t, []*DpkgFile{
+		{Path: "/etc"},
+		{Path: "/etc/foo.conf", Digest: "0123456789abcdef0123456789abcdef",
+			DigestAlgorithm: "md5", Config: true},
+		{Path: "/usr/bin/foo", Digest: "fedcba9876543210fedcba9876543210",
+			DigestAlgorithm: "md5"},
+	}, foo.Files)
+
+	bar := packages[1]
+	assert.Equal(t, "bar", bar.Name)
+	assert.Equal(t, "deinstall ok config-files", bar.Status)
+	assert.Equal(t, 0, len(bar.Files))
+}
@@ -0,0 +1,161 @@
+package packages
+
+import (
+	"context"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+type RPMDBPluginArgs struct {
+	Filename *accessors.OSPath `vfilter:"optional,field=filename,doc=The rpm database directory or file (default /var/lib/rpm)."`
+	Accessor string            `vfilter:"optional,field=accessor,doc=The accessor to use."`
+}
+
+type RPMDBPlugin struct{}
+
+func (self RPMDBPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("parse_rpmdb", args)()
+
+		arg := &RPMDBPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("parse_rpmdb: %v", err)
+			return
+		}
+
+		if arg.Accessor == "" {
+			arg.Accessor = "auto"
+		}
+
+		if arg.Filename == nil {
+			arg.Filename = accessors.MustNewLinuxOSPath("/var/lib/rpm")
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("parse_rpmdb: %s", err)
+			return
+		}
+
+		err = walkRPMDatabase(ctx, scope, arg.Accessor, arg.Filename,
+			func(blob []byte) error {
+				pkg, err := newRPMPackage(blob)
+				if err != nil {
+					scope.Log("parse_rpmdb: %v: %v", arg.Filename, err)
+					return nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case output_chan <- pkg:
+				}
+				return nil
+			})
+		if err != nil && ctx.Err() == nil {
+			scope.Log("parse_rpmdb: %v: %v", arg.Filename, err)
+		}
+	}()
+
+	return output_chan
+}
+
+func (self RPMDBPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "parse_rpmdb",
+		Doc:      "Parse the installed packages from an rpm database (BerkeleyDB, NDB or SQLite).",
+		ArgType:  type_map.AddType(scope, &RPMDBPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+type DpkgStatusPluginArgs struct {
+	Filename *accessors.OSPath `vfilter:"optional,field=filename,doc=The dpkg status file or status.d directory (default /var/lib/dpkg/status)."`
+	Accessor string            `vfilter:"optional,field=accessor,doc=The accessor to use."`
+}
+
+type DpkgStatusPlugin struct{}
+
+func (self DpkgStatusPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("parse_dpkg_status", args)()
+
+		arg := &DpkgStatusPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("parse_dpkg_status: %v", err)
+			return
+		}
+
+		if arg.Accessor == "" {
+			arg.Accessor = "auto"
+		}
+
+		if arg.Filename == nil {
+			arg.Filename = accessors.MustNewLinuxOSPath("/var/lib/dpkg/status")
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("parse_dpkg_status: %s", err)
+			return
+		}
+
+		accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+		if err != nil {
+			scope.Log("parse_dpkg_status: %v", err)
+			return
+		}
+
+		err = parseDpkgStatus(ctx, accessor, arg.Filename,
+			func(pkg *DpkgPackage) {
+				select {
+				case <-ctx.Done():
+				case output_chan <- pkg:
+				}
+			})
+		if err != nil {
+			scope.Log("parse_dpkg_status: %v: %v", arg.Filename, err)
+		}
+	}()
+
+	return output_chan
+}
+
+func (self DpkgStatusPlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "parse_dpkg_status",
+		Doc:      "Parse the installed packages from the dpkg status file.",
+		ArgType:  type_map.AddType(scope, &DpkgStatusPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&RPMDBPlugin{})
+	vql_subsystem.RegisterPlugin(&DpkgStatusPlugin{})
+}
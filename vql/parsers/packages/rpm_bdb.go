@@ -0,0 +1,159 @@
+package packages
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The legacy rpm database is a BerkeleyDB hash database. The keys
+// are the package index and the values are the package headers,
+// which are always large enough to be stored in overflow pages.
+const (
+	BDB_HASH_MAGIC = 0x061561
+
+	BDB_PAGE_HEADER_SIZE = 26
+
+	BDB_P_HASH_UNSORTED = 2
+	BDB_P_OVERFLOW      = 7
+	BDB_P_HASH          = 13
+
+	BDB_H_OFFPAGE = 3
+
+	// Larger pages are not valid in BerkeleyDB.
+	BDB_MAX_PAGESIZE = 64 * 1024
+)
+
+type bdbReader struct {
+	reader    io.ReaderAt
+	order     binary.ByteOrder
+	pagesize  uint32
+	last_pgno uint32
+}
+
+func isBDBHash(header []byte) bool {
+	if len(header) < 16 {
+		return false
+	}
+	return binary.LittleEndian.Uint32(header[12:]) == BDB_HASH_MAGIC ||
+		binary.BigEndian.Uint32(header[12:]) == BDB_HASH_MAGIC
+}
+
+func newBDBReader(reader io.ReaderAt) (*bdbReader, error) {
+	meta := make([]byte, 36)
+	err := readAt(reader, meta, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &bdbReader{reader: reader}
+	switch {
+	case binary.LittleEndian.Uint32(meta[12:]) == BDB_HASH_MAGIC:
+		result.order = binary.LittleEndian
+	case binary.BigEndian.Uint32(meta[12:]) == BDB_HASH_MAGIC:
+		result.order = binary.BigEndian
+	default:
+		return nil, errors.New("rpm: Not a BerkeleyDB hash database")
+	}
+
+	result.pagesize = result.order.Uint32(meta[20:])
+	if result.pagesize < 512 || result.pagesize > BDB_MAX_PAGESIZE {
+		return nil, fmt.Errorf("rpm: Invalid BerkeleyDB page size %v",
+			result.pagesize)
+	}
+	result.last_pgno = result.order.Uint32(meta[32:])
+
+	return result, nil
+}
+
+func (self *bdbReader) readPage(pgno uint32) ([]byte, error) {
+	page := make([]byte, self.pagesize)
+	err := readAt(self.reader, page, int64(pgno)*int64(self.pagesize))
+	return page, err
+}
+
+// Reassemble a value stored in a chain of overflow pages.
+func (self *bdbReader) readOverflow(pgno, length uint32) ([]byte, error) {
+	if length > RPM_HEADER_MAX_DATA {
+		return nil, errors.New("rpm: Overflow item is too large")
+	}
+
+	result := make([]byte, 0, length)
+	seen := make(map[uint32]bool)
+
+	for pgno != 0 && uint32(len(result)) < length {
+		if seen[pgno] || pgno > self.last_pgno {
+			return nil, fmt.Errorf("rpm: Invalid overflow page %v", pgno)
+		}
+		seen[pgno] = true
+
+		page, err := self.readPage(pgno)
+		if err != nil {
+			return nil, err
+		}
+
+		if page[25] != BDB_P_OVERFLOW {
+			return nil, fmt.Errorf("rpm: Page %v is not an overflow page", pgno)
+		}
+
+		size := uint32(self.order.Uint16(page[22:]))
+		if BDB_PAGE_HEADER_SIZE+size > self.pagesize {
+			return nil, fmt.Errorf("rpm: Invalid overflow page %v", pgno)
+		}
+		result = append(result, page[BDB_PAGE_HEADER_SIZE:BDB_PAGE_HEADER_SIZE+size]...)
+		pgno = self.order.Uint32(page[16:])
+	}
+
+	if uint32(len(result)) < length {
+		return nil, errors.New("rpm: Overflow item is truncated")
+	}
+	return result[:length], nil
+}
+
+// Call cb with every value in the database.
+func (self *bdbReader) Walk(cb func(blob []byte) error) error {
+	for pgno := uint32(1); pgno <= self.last_pgno; pgno++ {
+		page, err := self.readPage(pgno)
+		if err != nil {
+			return err
+		}
+
+		switch page[25] {
+		case BDB_P_HASH, BDB_P_HASH_UNSORTED:
+		default:
+			continue
+		}
+
+		entries := uint32(self.order.Uint16(page[20:]))
+		if BDB_PAGE_HEADER_SIZE+entries*2 > self.pagesize {
+			continue
+		}
+
+		// Entries are key/value pairs - we only want the values.
+		for i := uint32(1); i < entries; i += 2 {
+			offset := uint32(self.order.Uint16(page[BDB_PAGE_HEADER_SIZE+i*2:]))
+			if offset+12 > self.pagesize || page[offset] != BDB_H_OFFPAGE {
+				continue
+			}
+
+			blob, err := self.readOverflow(
+				self.order.Uint32(page[offset+4:]),
+				self.order.Uint32(page[offset+8:]))
+			if err != nil {
+				return err
+			}
+
+			err = cb(blob)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readAt(reader io.ReaderAt, buf []byte, offset int64) error {
+	_, err := io.ReadFull(io.NewSectionReader(reader, offset, int64(len(buf))), buf)
+	return err
+}
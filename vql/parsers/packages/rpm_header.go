@@ -0,0 +1,360 @@
+package packages
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// RPM header tags
+// https://github.com/rpm-software-management/rpm/blob/master/include/rpm/rpmtag.h
+const (
+	RPMTAG_NAME           = 1000
+	RPMTAG_VERSION        = 1001
+	RPMTAG_RELEASE        = 1002
+	RPMTAG_EPOCH          = 1003
+	RPMTAG_SUMMARY        = 1004
+	RPMTAG_BUILDTIME      = 1006
+	RPMTAG_INSTALLTIME    = 1008
+	RPMTAG_SIZE           = 1009
+	RPMTAG_VENDOR         = 1011
+	RPMTAG_LICENSE        = 1014
+	RPMTAG_ARCH           = 1022
+	RPMTAG_OLDFILENAMES   = 1027
+	RPMTAG_FILESIZES      = 1028
+	RPMTAG_FILEMODES      = 1030
+	RPMTAG_FILEMTIMES     = 1034
+	RPMTAG_FILEDIGESTS    = 1035
+	RPMTAG_FILELINKTOS    = 1036
+	RPMTAG_FILEFLAGS      = 1037
+	RPMTAG_FILEUSERNAME   = 1039
+	RPMTAG_FILEGROUPNAME  = 1040
+	RPMTAG_SOURCERPM      = 1044
+	RPMTAG_DIRINDEXES     = 1116
+	RPMTAG_BASENAMES      = 1117
+	RPMTAG_DIRNAMES       = 1118
+	RPMTAG_LONGFILESIZES  = 5008
+	RPMTAG_FILEDIGESTALGO = 5011
+
+	RPM_NULL_TYPE         = 0
+	RPM_CHAR_TYPE         = 1
+	RPM_INT8_TYPE         = 2
+	RPM_INT16_TYPE        = 3
+	RPM_INT32_TYPE        = 4
+	RPM_INT64_TYPE        = 5
+	RPM_STRING_TYPE       = 6
+	RPM_BIN_TYPE          = 7
+	RPM_STRING_ARRAY_TYPE = 8
+	RPM_I18NSTRING_TYPE   = 9
+
+	// Same limits as rpm itself uses.
+	RPM_HEADER_MAX_INDEX = 0xffff
+	RPM_HEADER_MAX_DATA  = 256 * 1024 * 1024
+)
+
+var (
+	rpmDigestAlgorithms = map[int64]string{
+		1:  "md5",
+		2:  "sha1",
+		8:  "sha256",
+		9:  "sha384",
+		10: "sha512",
+	}
+
+	rpmFileFlags = []struct {
+		mask uint64
+		name string
+	}{
+		{1 << 0, "config"},
+		{1 << 1, "doc"},
+		{1 << 3, "missingok"},
+		{1 << 4, "noreplace"},
+		{1 << 6, "ghost"},
+		{1 << 7, "license"},
+		{1 << 8, "readme"},
+		{1 << 12, "artifact"},
+	}
+)
+
+type rpmEntry struct {
+	tag, typ, offset, count uint32
+}
+
+// A parsed RPM header as stored in the rpm database. Unlike headers
+// in .rpm files, the stored blob does not start with the header
+// magic.
+type rpmHeader struct {
+	entries map[uint32]rpmEntry
+	data    []byte
+}
+
+func parseRPMHeader(blob []byte) (*rpmHeader, error) {
+	if len(blob) < 8 {
+		return nil, errors.New("rpm: Header too short")
+	}
+
+	il := binary.BigEndian.Uint32(blob[0:])
+	dl := binary.BigEndian.Uint32(blob[4:])
+	if il > RPM_HEADER_MAX_INDEX || dl > RPM_HEADER_MAX_DATA {
+		return nil, errors.New("rpm: Header is too large")
+	}
+
+	data_start := 8 + uint64(il)*16
+	if data_start+uint64(dl) > uint64(len(blob)) {
+		return nil, errors.New("rpm: Header is truncated")
+	}
+
+	result := &rpmHeader{
+		entries: make(map[uint32]rpmEntry),
+		data:    blob[data_start : data_start+uint64(dl)],
+	}
+
+	for i := uint64(0); i < uint64(il); i++ {
+		offset := 8 + i*16
+		entry := rpmEntry{
+			tag:    binary.BigEndian.Uint32(blob[offset:]),
+			typ:    binary.BigEndian.Uint32(blob[offset+4:]),
+			offset: binary.BigEndian.Uint32(blob[offset+8:]),
+			count:  binary.BigEndian.Uint32(blob[offset+12:]),
+		}
+		if entry.offset > dl {
+			continue
+		}
+		result.entries[entry.tag] = entry
+	}
+
+	return result, nil
+}
+
+// Read count NUL terminated strings.
+func (self *rpmHeader) strings(entry rpmEntry) []string {
+	var result []string
+	data := self.data[entry.offset:]
+	for i := uint32(0); i < entry.count; i++ {
+		end := 0
+		for end < len(data) && data[end] != 0 {
+			end++
+		}
+		if end >= len(data) {
+			break
+		}
+		result = append(result, string(data[:end]))
+		data = data[end+1:]
+	}
+	return result
+}
+
+func (self *rpmHeader) Strings(tag uint32) []string {
+	entry, pres := self.entries[tag]
+	if !pres {
+		return nil
+	}
+
+	switch entry.typ {
+	case RPM_STRING_TYPE, RPM_STRING_ARRAY_TYPE, RPM_I18NSTRING_TYPE:
+		return self.strings(entry)
+	}
+	return nil
+}
+
+func (self *rpmHeader) String(tag uint32) string {
+	// I18N strings contain one string per locale - the first is
+	// the default.
+	result := self.Strings(tag)
+	if len(result) == 0 {
+		return ""
+	}
+	return result[0]
+}
+
+func (self *rpmHeader) Ints(tag uint32) []int64 {
+	entry, pres := self.entries[tag]
+	if !pres {
+		return nil
+	}
+
+	var size uint32
+	switch entry.typ {
+	case RPM_CHAR_TYPE, RPM_INT8_TYPE:
+		size = 1
+	case RPM_INT16_TYPE:
+		size = 2
+	case RPM_INT32_TYPE:
+		size = 4
+	case RPM_INT64_TYPE:
+		size = 8
+	default:
+		return nil
+	}
+
+	data := self.data[entry.offset:]
+	if uint64(entry.count)*uint64(size) > uint64(len(data)) {
+		return nil
+	}
+
+	result := make([]int64, 0, entry.count)
+	for i := uint32(0); i < entry.count; i++ {
+		item := data[i*size:]
+		switch size {
+		case 1:
+			result = append(result, int64(item[0]))
+		case 2:
+			result = append(result, int64(binary.BigEndian.Uint16(item)))
+		case 4:
+			result = append(result, int64(binary.BigEndian.Uint32(item)))
+		case 8:
+			result = append(result, int64(binary.BigEndian.Uint64(item)))
+		}
+	}
+	return result
+}
+
+func (self *rpmHeader) Int(tag uint32) int64 {
+	result := self.Ints(tag)
+	if len(result) == 0 {
+		return 0
+	}
+	return result[0]
+}
+
+func (self *rpmHeader) Time(tag uint32) time.Time {
+	return time.Unix(self.Int(tag), 0).UTC()
+}
+
+type RPMFile struct {
+	Path            string
+	Size            int64
+	Mode            string
+	Mtime           time.Time
+	Digest          string
+	DigestAlgorithm string
+	LinkTo          string
+	User            string
+	Group           string
+	Flags           []string
+}
+
+type RPMPackage struct {
+	Name        string
+	Epoch       int64
+	Version     string
+	Release     string
+	Arch        string
+	Summary     string
+	Vendor      string
+	License     string
+	SourceRPM   string
+	Size        int64
+	InstallTime time.Time
+	BuildTime   time.Time
+	Files       []*RPMFile
+}
+
+func newRPMPackage(blob []byte) (*RPMPackage, error) {
+	header, err := parseRPMHeader(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RPMPackage{
+		Name:        header.String(RPMTAG_NAME),
+		Epoch:       header.Int(RPMTAG_EPOCH),
+		Version:     header.String(RPMTAG_VERSION),
+		Release:     header.String(RPMTAG_RELEASE),
+		Arch:        header.String(RPMTAG_ARCH),
+		Summary:     header.String(RPMTAG_SUMMARY),
+		Vendor:      header.String(RPMTAG_VENDOR),
+		License:     header.String(RPMTAG_LICENSE),
+		SourceRPM:   header.String(RPMTAG_SOURCERPM),
+		Size:        header.Int(RPMTAG_SIZE),
+		InstallTime: header.Time(RPMTAG_INSTALLTIME),
+		BuildTime:   header.Time(RPMTAG_BUILDTIME),
+	}
+
+	// Every package header has a name.
+	if result.Name == "" {
+		return nil, errors.New("rpm: Header has no package name")
+	}
+
+	result.Files, err = rpmFiles(header)
+	return result, err
+}
+
+func rpmFiles(header *rpmHeader) ([]*RPMFile, error) {
+	// Older packages store the full path, newer packages split
+	// them into directory and base name.
+	paths := header.Strings(RPMTAG_OLDFILENAMES)
+	if len(paths) == 0 {
+		basenames := header.Strings(RPMTAG_BASENAMES)
+		dirnames := header.Strings(RPMTAG_DIRNAMES)
+		dirindexes := header.Ints(RPMTAG_DIRINDEXES)
+		if len(dirindexes) != len(basenames) {
+			return nil, fmt.Errorf("rpm: Invalid file list")
+		}
+
+		for i, name := range basenames {
+			idx := dirindexes[i]
+			if idx < 0 || idx >= int64(len(dirnames)) {
+				return nil, fmt.Errorf("rpm: Invalid directory index %v", idx)
+			}
+			paths = append(paths, dirnames[idx]+name)
+		}
+	}
+
+	sizes := header.Ints(RPMTAG_LONGFILESIZES)
+	if len(sizes) == 0 {
+		sizes = header.Ints(RPMTAG_FILESIZES)
+	}
+	modes := header.Ints(RPMTAG_FILEMODES)
+	mtimes := header.Ints(RPMTAG_FILEMTIMES)
+	digests := header.Strings(RPMTAG_FILEDIGESTS)
+	links := header.Strings(RPMTAG_FILELINKTOS)
+	flags := header.Ints(RPMTAG_FILEFLAGS)
+	users := header.Strings(RPMTAG_FILEUSERNAME)
+	groups := header.Strings(RPMTAG_FILEGROUPNAME)
+
+	algorithm, pres := rpmDigestAlgorithms[header.Int(RPMTAG_FILEDIGESTALGO)]
+	if !pres {
+		algorithm = "md5"
+	}
+
+	result := make([]*RPMFile, 0, len(paths))
+	for i, p := range paths {
+		file := &RPMFile{Path: path.Clean(p)}
+		if i < len(sizes) {
+			file.Size = sizes[i]
+		}
+		if i < len(modes) {
+			file.Mode = utils.UnixFileMode(uint32(modes[i])).String()
+		}
+		if i < len(mtimes) {
+			file.Mtime = time.Unix(mtimes[i], 0).UTC()
+		}
+		if i < len(digests) && digests[i] != "" {
+			file.Digest = digests[i]
+			file.DigestAlgorithm = algorithm
+		}
+		if i < len(links) {
+			file.LinkTo = links[i]
+		}
+		if i < len(flags) {
+			for _, f := range rpmFileFlags {
+				if uint64(flags[i])&f.mask != 0 {
+					file.Flags = append(file.Flags, f.name)
+				}
+			}
+		}
+		if i < len(users) {
+			file.User = users[i]
+		}
+		if i < len(groups) {
+			file.Group = groups[i]
+		}
+		result = append(result, file)
+	}
+	return result, nil
+}
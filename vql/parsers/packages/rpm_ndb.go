@@ -0,0 +1,112 @@
+package packages
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The NDB backend is rpm's own database format used by SUSE.
+// https://github.com/rpm-software-management/rpm/blob/master/lib/backend/ndb/rpmpkg.c
+const (
+	NDB_HEADER_MAGIC = 'R' | 'p'<<8 | 'm'<<16 | 'P'<<24
+	NDB_SLOT_MAGIC   = 'S' | 'l'<<8 | 'o'<<16 | 't'<<24
+	NDB_BLOB_MAGIC   = 'B' | 'l'<<8 | 'b'<<16 | 'S'<<24
+
+	NDB_PAGE_SIZE   = 4096
+	NDB_SLOT_SIZE   = 16
+	NDB_BLK_SIZE    = 16
+	NDB_HEADER_SIZE = 32
+
+	// The slot area is limited to avoid reading huge amounts of
+	// data from corrupted files.
+	NDB_MAX_SLOT_PAGES = 2048
+)
+
+func isNDB(header []byte) bool {
+	return len(header) >= 4 &&
+		binary.LittleEndian.Uint32(header) == NDB_HEADER_MAGIC
+}
+
+type ndbReader struct {
+	reader io.ReaderAt
+	slots  []byte
+}
+
+func newNDBReader(reader io.ReaderAt) (*ndbReader, error) {
+	header := make([]byte, NDB_HEADER_SIZE)
+	err := readAt(reader, header, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isNDB(header) {
+		return nil, errors.New("rpm: Not an NDB database")
+	}
+
+	slot_pages := binary.LittleEndian.Uint32(header[12:])
+	if slot_pages == 0 || slot_pages > NDB_MAX_SLOT_PAGES {
+		return nil, fmt.Errorf("rpm: Invalid NDB slot page count %v", slot_pages)
+	}
+
+	result := &ndbReader{
+		reader: reader,
+		slots:  make([]byte, slot_pages*NDB_PAGE_SIZE),
+	}
+	err = readAt(reader, result.slots, 0)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Call cb with every package header blob in the database.
+func (self *ndbReader) Walk(cb func(blob []byte) error) error {
+	// The first slot is taken by the database header.
+	for offset := NDB_HEADER_SIZE; offset+NDB_SLOT_SIZE <= len(self.slots); offset += NDB_SLOT_SIZE {
+		slot := self.slots[offset:]
+		if binary.LittleEndian.Uint32(slot) != NDB_SLOT_MAGIC {
+			continue
+		}
+
+		pkg_idx := binary.LittleEndian.Uint32(slot[4:])
+		blk_offset := binary.LittleEndian.Uint32(slot[8:])
+		if pkg_idx == 0 {
+			continue
+		}
+
+		blob, err := self.readBlob(pkg_idx, int64(blk_offset)*NDB_BLK_SIZE)
+		if err != nil {
+			return err
+		}
+
+		err = cb(blob)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (self *ndbReader) readBlob(pkg_idx uint32, offset int64) ([]byte, error) {
+	header := make([]byte, 16)
+	err := readAt(self.reader, header, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if binary.LittleEndian.Uint32(header) != NDB_BLOB_MAGIC ||
+		binary.LittleEndian.Uint32(header[4:]) != pkg_idx {
+		return nil, fmt.Errorf("rpm: Invalid NDB blob for package %v", pkg_idx)
+	}
+
+	length := binary.LittleEndian.Uint32(header[12:])
+	if length > RPM_HEADER_MAX_DATA {
+		return nil, errors.New("rpm: NDB blob is too large")
+	}
+
+	result := make([]byte, length)
+	err = readAt(self.reader, result, offset+16)
+	return result, err
+}
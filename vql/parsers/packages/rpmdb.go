@@ -0,0 +1,123 @@
+package packages
+
+import (
+	"context"
+	"errors"
+
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql/parsers"
+	"www.velocidex.com/golang/vfilter"
+)
+
+var (
+	// The database files in the rpm database directory, newest
+	// backend first.
+	rpmDatabaseFiles = []string{"rpmdb.sqlite", "Packages.db", "Packages"}
+)
+
+// Find the database file if we were given the rpm database
+// directory.
+func findRPMDatabase(accessor accessors.FileSystemAccessor,
+	filename *accessors.OSPath) (*accessors.OSPath, error) {
+	stat, err := accessor.LstatWithOSPath(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if !stat.IsDir() {
+		return filename, nil
+	}
+
+	for _, name := range rpmDatabaseFiles {
+		path := filename.Append(name)
+		stat, err := accessor.LstatWithOSPath(path)
+		if err == nil && stat.Size() > 0 {
+			return path, nil
+		}
+	}
+
+	return nil, errors.New("rpm: No rpm database found")
+}
+
+// Call cb with every package header blob in the rpm database. The
+// backend is detected from the file header.
+func walkRPMDatabase(ctx context.Context, scope vfilter.Scope,
+	accessor_name string, filename *accessors.OSPath,
+	cb func(blob []byte) error) error {
+	accessor, err := accessors.GetAccessor(accessor_name, scope)
+	if err != nil {
+		return err
+	}
+
+	filename, err = findRPMDatabase(accessor, filename)
+	if err != nil {
+		return err
+	}
+
+	fd, err := accessor.OpenWithOSPath(filename)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	reader := utils.MakeReaderAtter(fd)
+	header := make([]byte, 64)
+	err = readAt(reader, header, 0)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case string(header[:16]) == "SQLite format 3\x00":
+		return walkRPMSqlite(ctx, scope, accessor_name, filename, cb)
+
+	case isNDB(header):
+		db, err := newNDBReader(reader)
+		if err != nil {
+			return err
+		}
+		return db.Walk(cb)
+
+	case isBDBHash(header):
+		db, err := newBDBReader(reader)
+		if err != nil {
+			return err
+		}
+		return db.Walk(cb)
+	}
+
+	return errors.New("rpm: Unknown rpm database format")
+}
+
+func walkRPMSqlite(ctx context.Context, scope vfilter.Scope,
+	accessor_name string, filename *accessors.OSPath,
+	cb func(blob []byte) error) error {
+	handle, err := parsers.GetHandleSqlite(ctx, &parsers.SQLPluginArgs{
+		Filename: filename,
+		Accessor: accessor_name,
+	}, scope)
+	if err != nil {
+		return err
+	}
+
+	rows, err := handle.QueryContext(ctx, "SELECT blob FROM Packages")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var blob []byte
+		err = rows.Scan(&blob)
+		if err != nil {
+			return err
+		}
+
+		err = cb(blob)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
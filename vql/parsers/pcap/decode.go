@@ -0,0 +1,438 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+const (
+	// Link types
+	LINKTYPE_NULL       = 0
+	LINKTYPE_ETHERNET   = 1
+	LINKTYPE_RAW        = 101
+	LINKTYPE_LOOP       = 108
+	LINKTYPE_LINUX_SLL  = 113
+	LINKTYPE_IPV4       = 228
+	LINKTYPE_IPV6       = 229
+	LINKTYPE_LINUX_SLL2 = 276
+
+	// Ether types
+	ETHERTYPE_IPV4 = 0x0800
+	ETHERTYPE_ARP  = 0x0806
+	ETHERTYPE_VLAN = 0x8100
+	ETHERTYPE_QINQ = 0x88a8
+	ETHERTYPE_IPV6 = 0x86dd
+
+	// IP protocols
+	IPPROTO_HOPOPTS  = 0
+	IPPROTO_ICMP     = 1
+	IPPROTO_TCP      = 6
+	IPPROTO_UDP      = 17
+	IPPROTO_ROUTING  = 43
+	IPPROTO_FRAGMENT = 44
+	IPPROTO_AH       = 51
+	IPPROTO_ICMPV6   = 58
+	IPPROTO_DSTOPTS  = 60
+
+	ETHERNET_HEADER_SIZE   = 14
+	VLAN_TAG_SIZE          = 4
+	LINUX_SLL_HEADER_SIZE  = 16
+	LINUX_SLL2_HEADER_SIZE = 20
+	NULL_HEADER_SIZE       = 4
+	IPV4_MIN_HEADER_SIZE   = 20
+	IPV6_HEADER_SIZE       = 40
+	TCP_MIN_HEADER_SIZE    = 20
+	UDP_HEADER_SIZE        = 8
+	ARP_IPV4_SIZE          = 28
+
+	// Sanity limit on IPv6 extension headers.
+	MAX_IPV6_EXTENSIONS = 8
+
+	DNS_PORT  = 53
+	MDNS_PORT = 5353
+)
+
+var (
+	protocolNames = map[uint8]string{
+		IPPROTO_ICMP:   "ICMP",
+		IPPROTO_TCP:    "TCP",
+		IPPROTO_UDP:    "UDP",
+		IPPROTO_ICMPV6: "ICMPv6",
+	}
+
+	tcpFlagNames = []string{
+		"FIN", "SYN", "RST", "PSH", "ACK", "URG", "ECE", "CWR",
+	}
+)
+
+const (
+	TCP_FIN = 0x01
+	TCP_SYN = 0x02
+	TCP_ACK = 0x10
+)
+
+// The decoded layers of a packet. Layers which are not present are
+// left empty.
+type PacketInfo struct {
+	Time           time.Time
+	Interface      int
+	Length         int
+	CapturedLength int
+
+	SrcMAC    string
+	DstMAC    string
+	EtherType uint16
+	VLAN      uint16
+
+	Family   string
+	SrcIP    string
+	DstIP    string
+	TTL      uint8
+	Protocol string
+
+	SrcPort  uint16
+	DstPort  uint16
+	TCPFlags uint8
+	Seq      uint32
+	Ack      uint32
+	Window   uint16
+
+	ICMPType uint8
+	ICMPCode uint8
+
+	Payload []byte
+
+	DNS *ordereddict.Dict
+	TLS *ordereddict.Dict
+}
+
+func DecodePacket(packet *Packet) *PacketInfo {
+	self := &PacketInfo{
+		Time:           packet.Timestamp,
+		Interface:      packet.Interface,
+		Length:         packet.OriginalLength,
+		CapturedLength: len(packet.Data),
+	}
+
+	data := packet.Data
+	switch packet.LinkType {
+	case LINKTYPE_ETHERNET:
+		self.decodeEthernet(data)
+
+	case LINKTYPE_LINUX_SLL:
+		if len(data) >= LINUX_SLL_HEADER_SIZE {
+			self.decodeEtherType(binary.BigEndian.Uint16(data[14:]),
+				data[LINUX_SLL_HEADER_SIZE:])
+		}
+
+	case LINKTYPE_LINUX_SLL2:
+		if len(data) >= LINUX_SLL2_HEADER_SIZE {
+			self.decodeEtherType(binary.BigEndian.Uint16(data),
+				data[LINUX_SLL2_HEADER_SIZE:])
+		}
+
+	// BSD loopback encapsulation has the address family in host
+	// (NULL) or network (LOOP) byte order.
+	case LINKTYPE_NULL, LINKTYPE_LOOP:
+		if len(data) >= NULL_HEADER_SIZE {
+			self.decodeIP(data[NULL_HEADER_SIZE:])
+		}
+
+	case LINKTYPE_RAW, LINKTYPE_IPV4, LINKTYPE_IPV6:
+		self.decodeIP(data)
+	}
+
+	return self
+}
+
+func formatMAC(data []byte) string {
+	return net.HardwareAddr(data).String()
+}
+
+func (self *PacketInfo) decodeEthernet(data []byte) {
+	if len(data) < ETHERNET_HEADER_SIZE {
+		return
+	}
+
+	self.DstMAC = formatMAC(data[0:6])
+	self.SrcMAC = formatMAC(data[6:12])
+	ether_type := binary.BigEndian.Uint16(data[12:])
+	data = data[ETHERNET_HEADER_SIZE:]
+
+	// Skip (possibly stacked) VLAN tags.
+	for (ether_type == ETHERTYPE_VLAN || ether_type == ETHERTYPE_QINQ) &&
+		len(data) >= VLAN_TAG_SIZE {
+		if self.VLAN == 0 {
+			self.VLAN = binary.BigEndian.Uint16(data) & 0x0fff
+		}
+		ether_type = binary.BigEndian.Uint16(data[2:])
+		data = data[VLAN_TAG_SIZE:]
+	}
+
+	self.decodeEtherType(ether_type, data)
+}
+
+func (self *PacketInfo) decodeEtherType(ether_type uint16, data []byte) {
+	self.EtherType = ether_type
+
+	switch ether_type {
+	case ETHERTYPE_IPV4, ETHERTYPE_IPV6:
+		self.decodeIP(data)
+
+	case ETHERTYPE_ARP:
+		self.decodeARP(data)
+	}
+}
+
+// Raw IP packets are identified by their version.
+func (self *PacketInfo) decodeIP(data []byte) {
+	if len(data) < 1 {
+		return
+	}
+
+	switch data[0] >> 4 {
+	case 4:
+		self.decodeIPv4(data)
+	case 6:
+		self.decodeIPv6(data)
+	}
+}
+
+func (self *PacketInfo) decodeARP(data []byte) {
+	// Only Ethernet/IPv4 ARP is decoded.
+	if len(data) < ARP_IPV4_SIZE || data[4] != 6 || data[5] != 4 {
+		return
+	}
+
+	self.Protocol = "ARP"
+	self.Family = "IPv4"
+	self.SrcIP = net.IP(data[14:18]).String()
+	self.DstIP = net.IP(data[24:28]).String()
+}
+
+func (self *PacketInfo) decodeIPv4(data []byte) {
+	header_size := int(data[0]&0x0f) * 4
+	if header_size < IPV4_MIN_HEADER_SIZE || len(data) < header_size {
+		return
+	}
+
+	self.Family = "IPv4"
+	self.TTL = data[8]
+	self.SrcIP = net.IP(data[12:16]).String()
+	self.DstIP = net.IP(data[16:20]).String()
+
+	// Ignore the link layer padding.
+	total_length := int(binary.BigEndian.Uint16(data[2:]))
+	if total_length >= header_size && total_length < len(data) {
+		data = data[:total_length]
+	}
+
+	protocol := data[9]
+
+	// Only the first fragment contains the transport header.
+	fragment_offset := binary.BigEndian.Uint16(data[6:]) & 0x1fff
+	if fragment_offset != 0 {
+		self.Protocol = protocolName(protocol)
+		return
+	}
+
+	self.decodeTransport(protocol, data[header_size:])
+}
+
+func (self *PacketInfo) decodeIPv6(data []byte) {
+	if len(data) < IPV6_HEADER_SIZE {
+		return
+	}
+
+	self.Family = "IPv6"
+	self.TTL = data[7]
+	self.SrcIP = net.IP(data[8:24]).String()
+	self.DstIP = net.IP(data[24:40]).String()
+
+	payload_length := int(binary.BigEndian.Uint16(data[4:]))
+	next_header := data[6]
+	data = data[IPV6_HEADER_SIZE:]
+	if payload_length < len(data) {
+		data = data[:payload_length]
+	}
+
+	// Skip the extension headers.
+	for i := 0; i < MAX_IPV6_EXTENSIONS && len(data) >= 8; i++ {
+		size := 0
+		switch next_header {
+		case IPPROTO_HOPOPTS, IPPROTO_ROUTING, IPPROTO_DSTOPTS:
+			size = (int(data[1]) + 1) * 8
+
+		case IPPROTO_FRAGMENT:
+			// Only the first fragment contains the transport
+			// header.
+			if binary.BigEndian.Uint16(data[2:])&0xfff8 != 0 {
+				self.Protocol = protocolName(data[0])
+				return
+			}
+			size = 8
+
+		case IPPROTO_AH:
+			size = (int(data[1]) + 2) * 4
+		}
+
+		if size == 0 || size > len(data) {
+			break
+		}
+		next_header = data[0]
+		data = data[size:]
+	}
+
+	self.decodeTransport(next_header, data)
+}
+
+func protocolName(protocol uint8) string {
+	name, pres := protocolNames[protocol]
+	if !pres {
+		return fmt.Sprintf("%d", protocol)
+	}
+	return name
+}
+
+func (self *PacketInfo) decodeTransport(protocol uint8, data []byte) {
+	self.Protocol = protocolName(protocol)
+
+	switch protocol {
+	case IPPROTO_TCP:
+		self.decodeTCP(data)
+
+	case IPPROTO_UDP:
+		self.decodeUDP(data)
+
+	case IPPROTO_ICMP, IPPROTO_ICMPV6:
+		if len(data) >= 2 {
+			self.ICMPType = data[0]
+			self.ICMPCode = data[1]
+		}
+	}
+}
+
+func (self *PacketInfo) decodeTCP(data []byte) {
+	if len(data) < TCP_MIN_HEADER_SIZE {
+		return
+	}
+
+	self.SrcPort = binary.BigEndian.Uint16(data[0:])
+	self.DstPort = binary.BigEndian.Uint16(data[2:])
+	self.Seq = binary.BigEndian.Uint32(data[4:])
+	self.Ack = binary.BigEndian.Uint32(data[8:])
+	self.TCPFlags = data[13]
+	self.Window = binary.BigEndian.Uint16(data[14:])
+
+	header_size := int(data[12]>>4) * 4
+	if header_size < TCP_MIN_HEADER_SIZE || header_size > len(data) {
+		return
+	}
+	self.Payload = data[header_size:]
+
+	if len(self.Payload) == 0 {
+		return
+	}
+
+	// DNS over TCP has a length prefix.
+	if self.isDNS() && len(self.Payload) > 2 {
+		self.DNS, _ = parseDNS(self.Payload[2:])
+		return
+	}
+
+	// Only the first segment of the handshake is examined.
+	self.TLS = parseClientHello(self.Payload)
+}
+
+func (self *PacketInfo) decodeUDP(data []byte) {
+	if len(data) < UDP_HEADER_SIZE {
+		return
+	}
+
+	self.SrcPort = binary.BigEndian.Uint16(data[0:])
+	self.DstPort = binary.BigEndian.Uint16(data[2:])
+
+	length := int(binary.BigEndian.Uint16(data[4:]))
+	if length >= UDP_HEADER_SIZE && length < len(data) {
+		data = data[:length]
+	}
+	self.Payload = data[UDP_HEADER_SIZE:]
+
+	if self.isDNS() {
+		self.DNS, _ = parseDNS(self.Payload)
+	}
+}
+
+func (self *PacketInfo) isDNS() bool {
+	return self.SrcPort == DNS_PORT || self.DstPort == DNS_PORT ||
+		self.SrcPort == MDNS_PORT || self.DstPort == MDNS_PORT
+}
+
+func (self *PacketInfo) TCPFlagNames() []string {
+	result := []string{}
+	for i, name := range tcpFlagNames {
+		if self.TCPFlags&(1<<i) != 0 {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func (self *PacketInfo) ToDict() *ordereddict.Dict {
+	result := ordereddict.NewDict().
+		Set("Time", self.Time).
+		Set("Interface", self.Interface).
+		Set("Length", self.Length).
+		Set("CapturedLength", self.CapturedLength).
+		Set("SrcMAC", self.SrcMAC).
+		Set("DstMAC", self.DstMAC).
+		Set("EtherType", fmt.Sprintf("%#04x", self.EtherType)).
+		Set("VLAN", self.VLAN).
+		Set("Family", self.Family).
+		Set("SrcIP", self.SrcIP).
+		Set("DstIP", self.DstIP).
+		Set("TTL", self.TTL).
+		Set("Protocol", self.Protocol).
+		Set("SrcPort", self.SrcPort).
+		Set("DstPort", self.DstPort)
+
+	if self.Protocol == "TCP" {
+		result.Set("TCPFlags", self.TCPFlagNames()).
+			Set("Seq", self.Seq).
+			Set("Ack", self.Ack).
+			Set("Window", self.Window)
+	} else {
+		result.Set("TCPFlags", nil).
+			Set("Seq", nil).
+			Set("Ack", nil).
+			Set("Window", nil)
+	}
+
+	if self.Protocol == "ICMP" || self.Protocol == "ICMPv6" {
+		result.Set("ICMPType", self.ICMPType).
+			Set("ICMPCode", self.ICMPCode)
+	} else {
+		result.Set("ICMPType", nil).
+			Set("ICMPCode", nil)
+	}
+
+	result.Set("PayloadLength", len(self.Payload))
+
+	if self.DNS != nil {
+		result.Set("DNS", self.DNS)
+	} else {
+		result.Set("DNS", nil)
+	}
+
+	if self.TLS != nil {
+		result.Set("TLS", self.TLS)
+	} else {
+		result.Set("TLS", nil)
+	}
+
+	return result
+}
@@ -0,0 +1,226 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+const (
+	DNS_HEADER_SIZE = 12
+
+	// Sanity limits
+	MAX_DNS_RECORDS       = 100
+	MAX_DNS_NAME_LENGTH   = 255
+	MAX_DNS_POINTER_JUMPS = 16
+
+	DNS_TYPE_A     = 1
+	DNS_TYPE_NS    = 2
+	DNS_TYPE_CNAME = 5
+	DNS_TYPE_SOA   = 6
+	DNS_TYPE_PTR   = 12
+	DNS_TYPE_MX    = 15
+	DNS_TYPE_TXT   = 16
+	DNS_TYPE_AAAA  = 28
+	DNS_TYPE_SRV   = 33
+)
+
+var (
+	dnsTypes = map[uint16]string{
+		DNS_TYPE_A:     "A",
+		DNS_TYPE_NS:    "NS",
+		DNS_TYPE_CNAME: "CNAME",
+		DNS_TYPE_SOA:   "SOA",
+		DNS_TYPE_PTR:   "PTR",
+		DNS_TYPE_MX:    "MX",
+		DNS_TYPE_TXT:   "TXT",
+		DNS_TYPE_AAAA:  "AAAA",
+		DNS_TYPE_SRV:   "SRV",
+		41:             "OPT",
+		64:             "SVCB",
+		65:             "HTTPS",
+		255:            "ANY",
+	}
+
+	dnsResponseCodes = map[uint16]string{
+		0: "NOERROR",
+		1: "FORMERR",
+		2: "SERVFAIL",
+		3: "NXDOMAIN",
+		4: "NOTIMP",
+		5: "REFUSED",
+	}
+
+	dnsTruncatedError = errors.New("Truncated DNS message")
+)
+
+func dnsTypeName(value uint16) string {
+	name, pres := dnsTypes[value]
+	if !pres {
+		return fmt.Sprintf("%d", value)
+	}
+	return name
+}
+
+// Parse a DNS message. Returns the questions and answers.
+func parseDNS(data []byte) (*ordereddict.Dict, error) {
+	if len(data) < DNS_HEADER_SIZE {
+		return nil, dnsTruncatedError
+	}
+
+	flags := binary.BigEndian.Uint16(data[2:])
+	question_count := int(binary.BigEndian.Uint16(data[4:]))
+	answer_count := int(binary.BigEndian.Uint16(data[6:]))
+
+	rcode, pres := dnsResponseCodes[flags&0x0f]
+	if !pres {
+		rcode = fmt.Sprintf("%d", flags&0x0f)
+	}
+
+	questions := []*ordereddict.Dict{}
+	answers := []*ordereddict.Dict{}
+	result := ordereddict.NewDict().
+		Set("ID", binary.BigEndian.Uint16(data)).
+		Set("Response", flags&0x8000 != 0).
+		Set("Opcode", (flags>>11)&0x0f).
+		Set("ResponseCode", rcode)
+
+	offset := DNS_HEADER_SIZE
+	for i := 0; i < question_count && i < MAX_DNS_RECORDS; i++ {
+		name, n, err := parseDNSName(data, offset)
+		if err != nil || offset+n+4 > len(data) {
+			break
+		}
+		offset += n
+
+		questions = append(questions, ordereddict.NewDict().
+			Set("Name", name).
+			Set("Type", dnsTypeName(binary.BigEndian.Uint16(data[offset:]))))
+		offset += 4
+	}
+
+	for i := 0; i < answer_count && i < MAX_DNS_RECORDS; i++ {
+		name, n, err := parseDNSName(data, offset)
+		if err != nil || offset+n+10 > len(data) {
+			break
+		}
+		offset += n
+
+		record_type := binary.BigEndian.Uint16(data[offset:])
+		ttl := binary.BigEndian.Uint32(data[offset+4:])
+		length := int(binary.BigEndian.Uint16(data[offset+8:]))
+		offset += 10
+
+		if offset+length > len(data) {
+			break
+		}
+
+		answers = append(answers, ordereddict.NewDict().
+			Set("Name", name).
+			Set("Type", dnsTypeName(record_type)).
+			Set("TTL", ttl).
+			Set("Data", parseDNSData(data, offset, length, record_type)))
+		offset += length
+	}
+
+	return result.
+		Set("Questions", questions).
+		Set("Answers", answers), nil
+}
+
+func parseDNSData(data []byte, offset, length int, record_type uint16) string {
+	rdata := data[offset : offset+length]
+
+	switch record_type {
+	case DNS_TYPE_A, DNS_TYPE_AAAA:
+		if len(rdata) == net.IPv4len || len(rdata) == net.IPv6len {
+			return net.IP(rdata).String()
+		}
+
+	// These contain (possibly compressed) names.
+	case DNS_TYPE_NS, DNS_TYPE_CNAME, DNS_TYPE_PTR:
+		name, _, err := parseDNSName(data, offset)
+		if err == nil {
+			return name
+		}
+
+	case DNS_TYPE_MX:
+		if len(rdata) > 2 {
+			name, _, err := parseDNSName(data, offset+2)
+			if err == nil {
+				return fmt.Sprintf("%d %s",
+					binary.BigEndian.Uint16(rdata), name)
+			}
+		}
+
+	case DNS_TYPE_TXT:
+		parts := []string{}
+		for i := 0; i < len(rdata); {
+			size := int(rdata[i])
+			if i+1+size > len(rdata) {
+				break
+			}
+			parts = append(parts, string(rdata[i+1:i+1+size]))
+			i += 1 + size
+		}
+		return strings.Join(parts, "")
+	}
+
+	return fmt.Sprintf("%x", rdata)
+}
+
+// Parse a possibly compressed name. Returns the name and the number
+// of bytes it occupies at the offset.
+func parseDNSName(data []byte, offset int) (string, int, error) {
+	labels := []string{}
+	begin := offset
+
+	// The end of the name at the original offset (after the first
+	// pointer or the terminator).
+	end := -1
+	length := 0
+
+	for jumps := 0; ; {
+		if offset >= len(data) {
+			return "", 0, dnsTruncatedError
+		}
+
+		size := int(data[offset])
+		switch {
+		case size == 0:
+			if end < 0 {
+				end = offset + 1
+			}
+			return strings.Join(labels, "."), end - begin, nil
+
+		// A pointer to a name elsewhere in the message.
+		case size&0xc0 == 0xc0:
+			if offset+1 >= len(data) {
+				return "", 0, dnsTruncatedError
+			}
+			jumps++
+			if jumps > MAX_DNS_POINTER_JUMPS {
+				return "", 0, fmt.Errorf("Too many DNS name pointers")
+			}
+			if end < 0 {
+				end = offset + 2
+			}
+			offset = int(binary.BigEndian.Uint16(data[offset:]) & 0x3fff)
+
+		default:
+			if offset+1+size > len(data) {
+				return "", 0, dnsTruncatedError
+			}
+			length += size + 1
+			if length > MAX_DNS_NAME_LENGTH {
+				return "", 0, fmt.Errorf("DNS name too long")
+			}
+			labels = append(labels, string(data[offset+1:offset+1+size]))
+			offset += 1 + size
+		}
+	}
+}
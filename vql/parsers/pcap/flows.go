@@ -0,0 +1,180 @@
+package pcap
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+// A flow is keyed by its 5-tuple in the direction it was first
+// seen.
+type flowKey struct {
+	protocol string
+	src_ip   string
+	src_port uint16
+	dst_ip   string
+	dst_port uint16
+}
+
+func (self flowKey) reverse() flowKey {
+	return flowKey{
+		protocol: self.protocol,
+		src_ip:   self.dst_ip,
+		src_port: self.dst_port,
+		dst_ip:   self.src_ip,
+		dst_port: self.src_port,
+	}
+}
+
+type Flow struct {
+	key    flowKey
+	family string
+
+	FirstSeen time.Time
+	LastSeen  time.Time
+
+	// Counts in the direction of the flow (sent) and the reverse
+	// direction (received).
+	SentPackets     int
+	SentBytes       int
+	ReceivedPackets int
+	ReceivedBytes   int
+
+	TCPFlags uint8
+	SNI      string
+	DNS      []string
+}
+
+// Aggregates packets into flows.
+type FlowTracker struct {
+	flows map[flowKey]*Flow
+}
+
+func NewFlowTracker() *FlowTracker {
+	return &FlowTracker{
+		flows: make(map[flowKey]*Flow),
+	}
+}
+
+func (self *FlowTracker) Add(packet *PacketInfo) {
+	if packet.SrcIP == "" || packet.Protocol == "" {
+		return
+	}
+
+	key := flowKey{
+		protocol: packet.Protocol,
+		src_ip:   packet.SrcIP,
+		src_port: packet.SrcPort,
+		dst_ip:   packet.DstIP,
+		dst_port: packet.DstPort,
+	}
+
+	sent := true
+	flow, pres := self.flows[key]
+	if !pres {
+		flow, pres = self.flows[key.reverse()]
+		sent = !pres
+	}
+
+	if !pres {
+		// If we missed the SYN the SYN-ACK tells us who the
+		// client is.
+		if packet.TCPFlags&(TCP_SYN|TCP_ACK) == TCP_SYN|TCP_ACK {
+			key = key.reverse()
+			sent = false
+		}
+
+		flow = &Flow{
+			key:       key,
+			family:    packet.Family,
+			FirstSeen: packet.Time,
+		}
+		self.flows[key] = flow
+	}
+
+	if packet.Time.Before(flow.FirstSeen) {
+		flow.FirstSeen = packet.Time
+	}
+	if packet.Time.After(flow.LastSeen) {
+		flow.LastSeen = packet.Time
+	}
+
+	if sent {
+		flow.SentPackets++
+		flow.SentBytes += packet.Length
+	} else {
+		flow.ReceivedPackets++
+		flow.ReceivedBytes += packet.Length
+	}
+
+	flow.TCPFlags |= packet.TCPFlags
+
+	if packet.TLS != nil && flow.SNI == "" {
+		flow.SNI, _ = packet.TLS.GetString("SNI")
+	}
+
+	if packet.DNS != nil {
+		flow.addDNSQuestions(packet.DNS)
+	}
+}
+
+func (self *Flow) addDNSQuestions(dns *ordereddict.Dict) {
+	questions, _ := dns.Get("Questions")
+	items, _ := questions.([]*ordereddict.Dict)
+	for _, item := range items {
+		name, _ := item.GetString("Name")
+		if name != "" && !utils.InString(self.DNS, name) {
+			self.DNS = append(self.DNS, name)
+		}
+	}
+}
+
+// The flows ordered by the time they were first seen.
+func (self *FlowTracker) Flows() []*Flow {
+	result := make([]*Flow, 0, len(self.flows))
+	for _, flow := range self.flows {
+		result = append(result, flow)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FirstSeen.Before(result[j].FirstSeen)
+	})
+	return result
+}
+
+// Flows use the same address layout as the netstat() plugin so they
+// can be joined on Laddr and Raddr.
+func (self *Flow) ToDict() *ordereddict.Dict {
+	info := &PacketInfo{TCPFlags: self.TCPFlags}
+
+	result := ordereddict.NewDict().
+		Set("Family", self.family).
+		Set("Type", self.key.protocol).
+		Set("Laddr", ordereddict.NewDict().
+			Set("IP", self.key.src_ip).
+			Set("Port", self.key.src_port)).
+		Set("Raddr", ordereddict.NewDict().
+			Set("IP", self.key.dst_ip).
+			Set("Port", self.key.dst_port)).
+		Set("FirstSeen", self.FirstSeen).
+		Set("LastSeen", self.LastSeen).
+		Set("Duration", self.LastSeen.Sub(self.FirstSeen).Seconds()).
+		Set("Packets", self.SentPackets+self.ReceivedPackets).
+		Set("Bytes", self.SentBytes+self.ReceivedBytes).
+		Set("SentPackets", self.SentPackets).
+		Set("SentBytes", self.SentBytes).
+		Set("ReceivedPackets", self.ReceivedPackets).
+		Set("ReceivedBytes", self.ReceivedBytes)
+
+	if self.key.protocol == "TCP" {
+		result.Set("TCPFlags", info.TCPFlagNames())
+	} else {
+		result.Set("TCPFlags", nil)
+	}
+
+	return result.
+		Set("SNI", self.SNI).
+		Set("DNS", self.DNS)
+}
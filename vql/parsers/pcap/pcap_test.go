@@ -0,0 +1,360 @@
+package pcap
+
+import (
+	"context"
+	"encoding/binary"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/suite"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+	"www.velocidex.com/golang/vfilter"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/data"
+)
+
+var (
+	testStart = time.Date(2023, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	clientMAC = []byte{0x00, 0x0c, 0x29, 0x01, 0x02, 0x03}
+	routerMAC = []byte{0x00, 0x50, 0x56, 0x0a, 0x0b, 0x0c}
+)
+
+type testPacket struct {
+	offset time.Duration
+	data   []byte
+}
+
+func u16(value int) []byte {
+	return binary.BigEndian.AppendUint16(nil, uint16(value))
+}
+
+func ethernet(src, dst []byte, payload []byte) []byte {
+	frame := append(append([]byte{}, dst...), src...)
+	frame = append(frame, u16(ETHERTYPE_IPV4)...)
+	return append(frame, payload...)
+}
+
+func ipv4(protocol uint8, src, dst string, payload []byte) []byte {
+	header := make([]byte, IPV4_MIN_HEADER_SIZE)
+	header[0] = 0x45
+	binary.BigEndian.PutUint16(header[2:], uint16(len(header)+len(payload)))
+	header[8] = 64
+	header[9] = protocol
+	copy(header[12:], net.ParseIP(src).To4())
+	copy(header[16:], net.ParseIP(dst).To4())
+	return append(header, payload...)
+}
+
+func tcp(src_port, dst_port int, flags uint8, payload []byte) []byte {
+	header := make([]byte, TCP_MIN_HEADER_SIZE)
+	binary.BigEndian.PutUint16(header[0:], uint16(src_port))
+	binary.BigEndian.PutUint16(header[2:], uint16(dst_port))
+	binary.BigEndian.PutUint32(header[4:], 1000)
+	header[12] = 5 << 4
+	header[13] = flags
+	binary.BigEndian.PutUint16(header[14:], 65535)
+	return append(header, payload...)
+}
+
+func udp(src_port, dst_port int, payload []byte) []byte {
+	header := make([]byte, UDP_HEADER_SIZE)
+	binary.BigEndian.PutUint16(header[0:], uint16(src_port))
+	binary.BigEndian.PutUint16(header[2:], uint16(dst_port))
+	binary.BigEndian.PutUint16(header[4:], uint16(UDP_HEADER_SIZE+len(payload)))
+	return append(header, payload...)
+}
+
+func dnsName(name string) []byte {
+	result := []byte{}
+	for _, label := range strings.Split(name, ".") {
+		result = append(result, byte(len(label)))
+		result = append(result, label...)
+	}
+	return append(result, 0)
+}
+
+func dnsMessage(response bool) []byte {
+	flags := 0x0100
+	answers := 0
+	if response {
+		flags = 0x8180
+		answers = 1
+	}
+
+	message := append(u16(0x1234), u16(flags)...)
+	message = append(message, u16(1)...)
+	message = append(message, u16(answers)...)
+	message = append(message, 0, 0, 0, 0)
+	message = append(message, dnsName("www.example.com")...)
+	message = append(message, u16(DNS_TYPE_A)...)
+	message = append(message, u16(1)...)
+
+	if response {
+		// The answer name points to the question.
+		message = append(message, 0xc0, DNS_HEADER_SIZE)
+		message = append(message, u16(DNS_TYPE_A)...)
+		message = append(message, u16(1)...)
+		message = append(message, 0, 0, 0x0e, 0x10)
+		message = append(message, u16(4)...)
+		message = append(message, 93, 184, 216, 34)
+	}
+	return message
+}
+
+func tlsExtension(ext_type int, data []byte) []byte {
+	return append(append(u16(ext_type), u16(len(data))...), data...)
+}
+
+func clientHello(server_name string) []byte {
+	sni := append([]byte{TLS_SERVER_NAME_HOST}, u16(len(server_name))...)
+	sni = append(sni, server_name...)
+	sni = append(u16(len(sni)), sni...)
+
+	alpn := append([]byte{2}, "h2"...)
+	alpn = append(u16(len(alpn)), alpn...)
+
+	extensions := tlsExtension(TLS_EXTENSION_SERVER_NAME, sni)
+	extensions = append(extensions, tlsExtension(TLS_EXTENSION_ALPN, alpn)...)
+	extensions = append(extensions, tlsExtension(
+		TLS_EXTENSION_SUPPORTED_VERSIONS, []byte{4, 0x03, 0x04, 0x03, 0x03})...)
+
+	body := u16(0x0303)
+	body = append(body, make([]byte, TLS_RANDOM_SIZE)...)
+	body = append(body, 0)
+	body = append(body, u16(2)...)
+	body = append(body, 0x13, 0x01)
+	body = append(body, 1, 0)
+	body = append(body, u16(len(extensions))...)
+	body = append(body, extensions...)
+
+	handshake := []byte{TLS_HANDSHAKE_CLIENT_HELLO,
+		0, byte(len(body) >> 8), byte(len(body))}
+	handshake = append(handshake, body...)
+
+	record := []byte{TLS_RECORD_HANDSHAKE, 0x03, 0x01}
+	record = append(record, u16(len(handshake))...)
+	return append(record, handshake...)
+}
+
+func testPackets() []testPacket {
+	out := func(payload []byte) []byte {
+		return ethernet(clientMAC, routerMAC, payload)
+	}
+	in := func(payload []byte) []byte {
+		return ethernet(routerMAC, clientMAC, payload)
+	}
+
+	return []testPacket{
+		{0, out(ipv4(IPPROTO_TCP, "10.0.0.5", "93.184.216.34",
+			tcp(50000, 443, TCP_SYN, nil)))},
+		{10 * time.Millisecond, in(ipv4(IPPROTO_TCP, "93.184.216.34", "10.0.0.5",
+			tcp(443, 50000, TCP_SYN|TCP_ACK, nil)))},
+		{20 * time.Millisecond, out(ipv4(IPPROTO_TCP, "10.0.0.5", "93.184.216.34",
+			tcp(50000, 443, TCP_ACK|0x08, clientHello("www.example.com"))))},
+		{time.Second, out(ipv4(IPPROTO_UDP, "10.0.0.5", "8.8.8.8",
+			udp(53000, DNS_PORT, dnsMessage(false))))},
+		{time.Second + 5*time.Millisecond, in(ipv4(IPPROTO_UDP, "8.8.8.8", "10.0.0.5",
+			udp(DNS_PORT, 53000, dnsMessage(true))))},
+	}
+}
+
+func buildPcap() []byte {
+	header := make([]byte, PCAP_HEADER_SIZE)
+	binary.LittleEndian.PutUint32(header, PCAP_MAGIC_MICROSECONDS)
+	binary.LittleEndian.PutUint16(header[4:], 2)
+	binary.LittleEndian.PutUint16(header[6:], 4)
+	binary.LittleEndian.PutUint32(header[16:], 65535)
+	binary.LittleEndian.PutUint32(header[20:], LINKTYPE_ETHERNET)
+
+	result := header
+	for _, packet := range testPackets() {
+		ts := testStart.Add(packet.offset)
+		record := make([]byte, PCAP_RECORD_SIZE)
+		binary.LittleEndian.PutUint32(record[0:], uint32(ts.Unix()))
+		binary.LittleEndian.PutUint32(record[4:], uint32(ts.Nanosecond()/1000))
+		binary.LittleEndian.PutUint32(record[8:], uint32(len(packet.data)))
+		binary.LittleEndian.PutUint32(record[12:], uint32(len(packet.data)))
+		result = append(append(result, record...), packet.data...)
+	}
+	return result
+}
+
+func pcapngBlock(order binary.AppendByteOrder, block_type uint32, body []byte) []byte {
+	for len(body)%4 != 0 {
+		body = append(body, 0)
+	}
+	length := uint32(len(body) + 12)
+
+	block := order.AppendUint32(nil, block_type)
+	block = order.AppendUint32(block, length)
+	block = append(block, body...)
+	return order.AppendUint32(block, length)
+}
+
+func buildPcapng(order binary.AppendByteOrder) []byte {
+	shb := order.AppendUint32(nil, PCAPNG_BYTE_ORDER_MAGIC)
+	shb = order.AppendUint16(shb, 1)
+	shb = order.AppendUint16(shb, 0)
+	shb = order.AppendUint64(shb, 0xffffffffffffffff)
+
+	// Nanosecond resolution timestamps.
+	idb := order.AppendUint16(nil, LINKTYPE_ETHERNET)
+	idb = order.AppendUint16(idb, 0)
+	idb = order.AppendUint32(idb, 0)
+	idb = order.AppendUint16(idb, PCAPNG_OPTION_IF_TSRESOL)
+	idb = order.AppendUint16(idb, 1)
+	idb = append(idb, 9, 0, 0, 0)
+	idb = order.AppendUint32(idb, 0)
+
+	result := pcapngBlock(order, PCAPNG_SECTION_HEADER, shb)
+	result = append(result, pcapngBlock(order, PCAPNG_INTERFACE, idb)...)
+
+	for _, packet := range testPackets() {
+		ts := uint64(testStart.Add(packet.offset).UnixNano())
+		epb := order.AppendUint32(nil, 0)
+		epb = order.AppendUint32(epb, uint32(ts>>32))
+		epb = order.AppendUint32(epb, uint32(ts))
+		epb = order.AppendUint32(epb, uint32(len(packet.data)))
+		epb = order.AppendUint32(epb, uint32(len(packet.data)))
+		epb = append(epb, packet.data...)
+		result = append(result, pcapngBlock(order, PCAPNG_ENHANCED_PACKET, epb)...)
+	}
+
+	// Unknown blocks are skipped.
+	return append(result, pcapngBlock(order, 0x0bad, []byte{1, 2, 3, 4})...)
+}
+
+func get(dict *ordereddict.Dict, path ...string) interface{} {
+	var value interface{} = dict
+	for _, p := range path {
+		d, ok := value.(*ordereddict.Dict)
+		if !ok {
+			return nil
+		}
+		value, _ = d.Get(p)
+	}
+	return value
+}
+
+type PcapTestSuite struct {
+	suite.Suite
+}
+
+func (self *PcapTestSuite) runPlugin(plugin vfilter.PluginGeneratorInterface,
+	data []byte) []*ordereddict.Dict {
+	ctx := context.Background()
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	scope.SetLogger(log.New(os.Stderr, "", 0))
+	defer scope.Close()
+
+	args := ordereddict.NewDict().
+		Set("filename", string(data)).
+		Set("accessor", "data")
+
+	result := []*ordereddict.Dict{}
+	for row := range plugin.Call(ctx, scope, args) {
+		result = append(result, row.(*ordereddict.Dict))
+	}
+	return result
+}
+
+func (self *PcapTestSuite) checkPackets(rows []*ordereddict.Dict) {
+	assert.Equal(self.T(), 5, len(rows))
+
+	syn := rows[0]
+	assert.Equal(self.T(), testStart, get(syn, "Time"))
+	assert.Equal(self.T(), "00:0c:29:01:02:03", get(syn, "SrcMAC"))
+	assert.Equal(self.T(), "IPv4", get(syn, "Family"))
+	assert.Equal(self.T(), "10.0.0.5", get(syn, "SrcIP"))
+	assert.Equal(self.T(), "93.184.216.34", get(syn, "DstIP"))
+	assert.Equal(self.T(), "TCP", get(syn, "Protocol"))
+	assert.Equal(self.T(), uint16(50000), get(syn, "SrcPort"))
+	assert.Equal(self.T(), uint16(443), get(syn, "DstPort"))
+	assert.Equal(self.T(), []string{"SYN"}, get(syn, "TCPFlags"))
+
+	assert.Equal(self.T(), []string{"SYN", "ACK"}, get(rows[1], "TCPFlags"))
+	assert.Equal(self.T(), testStart.Add(10*time.Millisecond),
+		get(rows[1], "Time"))
+
+	hello := rows[2]
+	assert.Equal(self.T(), "www.example.com", get(hello, "TLS", "SNI"))
+	assert.Equal(self.T(), "TLS 1.3", get(hello, "TLS", "Version"))
+	assert.Equal(self.T(), []string{"h2"}, get(hello, "TLS", "ALPN"))
+
+	query := rows[3]
+	assert.Equal(self.T(), "UDP", get(query, "Protocol"))
+	assert.Nil(self.T(), get(query, "TCPFlags"))
+	assert.Equal(self.T(), false, get(query, "DNS", "Response"))
+
+	response := rows[4]
+	assert.Equal(self.T(), true, get(response, "DNS", "Response"))
+	assert.Equal(self.T(), "NOERROR", get(response, "DNS", "ResponseCode"))
+	answers := get(response, "DNS", "Answers").([]*ordereddict.Dict)
+	assert.Equal(self.T(), 1, len(answers))
+	assert.Equal(self.T(), "www.example.com", get(answers[0], "Name"))
+	assert.Equal(self.T(), "A", get(answers[0], "Type"))
+	assert.Equal(self.T(), "93.184.216.34", get(answers[0], "Data"))
+}
+
+func (self *PcapTestSuite) TestPcap() {
+	self.checkPackets(self.runPlugin(PcapPlugin{}, buildPcap()))
+}
+
+func (self *PcapTestSuite) TestPcapng() {
+	self.checkPackets(self.runPlugin(PcapPlugin{},
+		buildPcapng(binary.LittleEndian)))
+	self.checkPackets(self.runPlugin(PcapPlugin{},
+		buildPcapng(binary.BigEndian)))
+}
+
+func (self *PcapTestSuite) TestFlows() {
+	rows := self.runPlugin(PcapFlowsPlugin{}, buildPcap())
+	assert.Equal(self.T(), 2, len(rows))
+
+	https := rows[0]
+	assert.Equal(self.T(), "TCP", get(https, "Type"))
+	assert.Equal(self.T(), "10.0.0.5", get(https, "Laddr", "IP"))
+	assert.Equal(self.T(), uint16(50000), get(https, "Laddr", "Port"))
+	assert.Equal(self.T(), "93.184.216.34", get(https, "Raddr", "IP"))
+	assert.Equal(self.T(), uint16(443), get(https, "Raddr", "Port"))
+	assert.Equal(self.T(), 3, get(https, "Packets"))
+	assert.Equal(self.T(), 2, get(https, "SentPackets"))
+	assert.Equal(self.T(), 1, get(https, "ReceivedPackets"))
+	assert.Equal(self.T(), testStart, get(https, "FirstSeen"))
+	assert.Equal(self.T(), testStart.Add(20*time.Millisecond),
+		get(https, "LastSeen"))
+	assert.Equal(self.T(), "www.example.com", get(https, "SNI"))
+
+	dns := rows[1]
+	assert.Equal(self.T(), "UDP", get(dns, "Type"))
+	assert.Equal(self.T(), uint16(53), get(dns, "Raddr", "Port"))
+	assert.Equal(self.T(), 2, get(dns, "Packets"))
+	assert.Equal(self.T(), []string{"www.example.com"}, get(dns, "DNS"))
+
+	// Flows where the SYN was not captured are oriented by the
+	// SYN-ACK.
+	tracker := NewFlowTracker()
+	for _, packet := range testPackets()[1:3] {
+		tracker.Add(DecodePacket(&Packet{
+			LinkType:       LINKTYPE_ETHERNET,
+			OriginalLength: len(packet.data),
+			Data:           packet.data,
+		}))
+	}
+	flows := tracker.Flows()
+	assert.Equal(self.T(), 1, len(flows))
+	assert.Equal(self.T(), "10.0.0.5", get(flows[0].ToDict(), "Laddr", "IP"))
+}
+
+func TestPcap(t *testing.T) {
+	suite.Run(t, &PcapTestSuite{})
+}
@@ -0,0 +1,197 @@
+package pcap
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+var (
+	stopIteration = errors.New("Stop")
+)
+
+type PcapPluginArgs struct {
+	Filenames []*accessors.OSPath `vfilter:"required,field=filename,doc=A list of pcap or pcapng files to parse."`
+	Accessor  string              `vfilter:"optional,field=accessor,doc=The accessor to use."`
+}
+
+// Call cb with each decoded packet in the file.
+func walkPackets(ctx context.Context,
+	accessor accessors.FileSystemAccessor, filename *accessors.OSPath,
+	cb func(packet *PacketInfo) error) error {
+	fd, err := accessor.OpenWithOSPath(filename)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	reader, err := NewPacketReader(fd)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		packet, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = cb(DecodePacket(packet))
+		if err == stopIteration {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type PcapPlugin struct{}
+
+func (self PcapPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("parse_pcap", args)()
+
+		arg := &PcapPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("parse_pcap: %v", err)
+			return
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("parse_pcap: %v", err)
+			return
+		}
+
+		accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+		if err != nil {
+			scope.Log("parse_pcap: %v", err)
+			return
+		}
+
+		for _, filename := range arg.Filenames {
+			err := walkPackets(ctx, accessor, filename,
+				func(packet *PacketInfo) error {
+					select {
+					case <-ctx.Done():
+						return stopIteration
+					case output_chan <- packet.ToDict().Set("OSPath", filename):
+					}
+					return nil
+				})
+			if err != nil {
+				scope.Log("parse_pcap: %v: %v", filename, err)
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self PcapPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "parse_pcap",
+		Doc:      "Parse packets from a pcap or pcapng capture file.",
+		ArgType:  type_map.AddType(scope, &PcapPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+type PcapFlowsPlugin struct{}
+
+func (self PcapFlowsPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("pcap_flows", args)()
+
+		arg := &PcapPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("pcap_flows: %v", err)
+			return
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("pcap_flows: %v", err)
+			return
+		}
+
+		accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+		if err != nil {
+			scope.Log("pcap_flows: %v", err)
+			return
+		}
+
+		// Flows may span several capture files.
+		tracker := NewFlowTracker()
+		for _, filename := range arg.Filenames {
+			err := walkPackets(ctx, accessor, filename,
+				func(packet *PacketInfo) error {
+					tracker.Add(packet)
+					return nil
+				})
+			if err != nil {
+				scope.Log("pcap_flows: %v: %v", filename, err)
+			}
+		}
+
+		for _, flow := range tracker.Flows() {
+			select {
+			case <-ctx.Done():
+				return
+			case output_chan <- flow.ToDict():
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func (self PcapFlowsPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "pcap_flows",
+		Doc: "Summarise the flows (by protocol, address and port) in " +
+			"pcap or pcapng capture files.",
+		ArgType:  type_map.AddType(scope, &PcapPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&PcapPlugin{})
+	vql_subsystem.RegisterPlugin(&PcapFlowsPlugin{})
+}
@@ -0,0 +1,368 @@
+package pcap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"time"
+)
+
+const (
+	// Classic pcap magic numbers (as read in the file's byte order).
+	PCAP_MAGIC_MICROSECONDS = 0xa1b2c3d4
+	PCAP_MAGIC_NANOSECONDS  = 0xa1b23c4d
+
+	// The modified pcap format (Kuznetzov) has a larger record header.
+	PCAP_MAGIC_MODIFIED = 0xa1b2cd34
+
+	PCAP_HEADER_SIZE          = 24
+	PCAP_RECORD_SIZE          = 16
+	PCAP_MODIFIED_RECORD_SIZE = 24
+
+	// pcapng block types.
+	PCAPNG_SECTION_HEADER       = 0x0a0d0d0a
+	PCAPNG_INTERFACE            = 0x00000001
+	PCAPNG_PACKET               = 0x00000002
+	PCAPNG_SIMPLE_PACKET        = 0x00000003
+	PCAPNG_ENHANCED_PACKET      = 0x00000006
+	PCAPNG_BYTE_ORDER_MAGIC     = 0x1a2b3c4d
+	PCAPNG_OPTION_END           = 0
+	PCAPNG_OPTION_IF_TSRESOL    = 9
+	PCAPNG_OPTION_IF_TSOFFSET   = 14
+	PCAPNG_DEFAULT_TICKS_PER_S  = 1000000
+	PCAPNG_MIN_BLOCK_SIZE       = 12
+	PCAPNG_SECTION_HEADER_SIZE  = 16
+	PCAPNG_INTERFACE_SIZE       = 8
+	PCAPNG_ENHANCED_PACKET_SIZE = 20
+	PCAPNG_PACKET_SIZE          = 20
+	PCAPNG_SIMPLE_PACKET_SIZE   = 4
+
+	// Sanity limits on the size of a packet or block.
+	MAX_PACKET_SIZE = 0x100000
+	MAX_BLOCK_SIZE  = 0x1000000
+)
+
+var (
+	notPcapError = errors.New("Not a pcap or pcapng file")
+)
+
+// A captured packet as read from the file.
+type Packet struct {
+	Timestamp      time.Time
+	Interface      int
+	LinkType       uint16
+	OriginalLength int
+	Data           []byte
+}
+
+type PacketReader interface {
+	// Returns io.EOF at the end of the capture.
+	Next() (*Packet, error)
+}
+
+// Detect the file format from its magic and return a reader for
+// the packets.
+func NewPacketReader(reader io.Reader) (PacketReader, error) {
+	buffered := bufio.NewReaderSize(reader, 0x10000)
+	magic, err := buffered.Peek(4)
+	if err != nil {
+		return nil, notPcapError
+	}
+
+	if binary.LittleEndian.Uint32(magic) == PCAPNG_SECTION_HEADER {
+		return &pcapngReader{reader: buffered, order: binary.LittleEndian}, nil
+	}
+
+	for _, order := range []binary.ByteOrder{
+		binary.LittleEndian, binary.BigEndian} {
+		switch order.Uint32(magic) {
+		case PCAP_MAGIC_MICROSECONDS, PCAP_MAGIC_NANOSECONDS,
+			PCAP_MAGIC_MODIFIED:
+			return newPcapReader(buffered, order)
+		}
+	}
+
+	return nil, notPcapError
+}
+
+// The classic libpcap format: a file header followed by packet
+// records.
+type pcapReader struct {
+	reader      *bufio.Reader
+	order       binary.ByteOrder
+	nanoseconds bool
+	record_size int
+	link_type   uint16
+}
+
+func newPcapReader(reader *bufio.Reader, order binary.ByteOrder) (*pcapReader, error) {
+	header := make([]byte, PCAP_HEADER_SIZE)
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return nil, err
+	}
+
+	magic := order.Uint32(header)
+	return &pcapReader{
+		reader:      reader,
+		order:       order,
+		nanoseconds: magic == PCAP_MAGIC_NANOSECONDS,
+		record_size: recordSize(magic),
+
+		// The upper bits of the link type may carry FCS
+		// information.
+		link_type: uint16(order.Uint32(header[20:])),
+	}, nil
+}
+
+func recordSize(magic uint32) int {
+	if magic == PCAP_MAGIC_MODIFIED {
+		return PCAP_MODIFIED_RECORD_SIZE
+	}
+	return PCAP_RECORD_SIZE
+}
+
+func (self *pcapReader) Next() (*Packet, error) {
+	header := make([]byte, self.record_size)
+	_, err := io.ReadFull(self.reader, header)
+	if err != nil {
+		// A truncated record at the end of the file.
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+
+	seconds := int64(self.order.Uint32(header[0:]))
+	fraction := int64(self.order.Uint32(header[4:]))
+	captured := int(self.order.Uint32(header[8:]))
+	original := int(self.order.Uint32(header[12:]))
+
+	if captured > MAX_PACKET_SIZE {
+		return nil, fmt.Errorf("Invalid packet size %#x", captured)
+	}
+
+	data := make([]byte, captured)
+	_, err = io.ReadFull(self.reader, data)
+	if err != nil {
+		return nil, io.EOF
+	}
+
+	if !self.nanoseconds {
+		fraction *= 1000
+	}
+
+	return &Packet{
+		Timestamp:      time.Unix(seconds, fraction).UTC(),
+		LinkType:       self.link_type,
+		OriginalLength: original,
+		Data:           data,
+	}, nil
+}
+
+type pcapngInterface struct {
+	link_type uint16
+	snap_len  uint32
+
+	// Timestamps are in ticks since the epoch plus an offset in
+	// seconds.
+	ticks_per_second uint64
+	offset           int64
+}
+
+// The pcapng format consists of blocks. Section headers may change
+// the byte order and each section defines its own interfaces.
+type pcapngReader struct {
+	reader     *bufio.Reader
+	order      binary.ByteOrder
+	interfaces []*pcapngInterface
+}
+
+func (self *pcapngReader) readBlock() (uint32, []byte, error) {
+	header := make([]byte, 8)
+	_, err := io.ReadFull(self.reader, header)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, io.EOF
+		}
+		return 0, nil, err
+	}
+
+	// The section header block type is a palindrome so it can be
+	// read before the byte order is known.
+	block_type := self.order.Uint32(header)
+	if block_type == PCAPNG_SECTION_HEADER {
+		magic, err := self.reader.Peek(4)
+		if err != nil {
+			return 0, nil, io.EOF
+		}
+
+		switch {
+		case binary.LittleEndian.Uint32(magic) == PCAPNG_BYTE_ORDER_MAGIC:
+			self.order = binary.LittleEndian
+		case binary.BigEndian.Uint32(magic) == PCAPNG_BYTE_ORDER_MAGIC:
+			self.order = binary.BigEndian
+		default:
+			return 0, nil, fmt.Errorf("Invalid section header byte order")
+		}
+		self.interfaces = nil
+	}
+
+	length := self.order.Uint32(header[4:])
+	if length < PCAPNG_MIN_BLOCK_SIZE || length%4 != 0 ||
+		length > MAX_BLOCK_SIZE {
+		return 0, nil, fmt.Errorf("Invalid block length %#x", length)
+	}
+
+	body := make([]byte, length-8)
+	_, err = io.ReadFull(self.reader, body)
+	if err != nil {
+		return 0, nil, io.EOF
+	}
+
+	// Strip the trailing copy of the block length.
+	return block_type, body[:len(body)-4], nil
+}
+
+func (self *pcapngReader) Next() (*Packet, error) {
+	for {
+		block_type, body, err := self.readBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		switch block_type {
+		case PCAPNG_INTERFACE:
+			self.parseInterface(body)
+
+		case PCAPNG_ENHANCED_PACKET:
+			if len(body) < PCAPNG_ENHANCED_PACKET_SIZE {
+				continue
+			}
+			return self.makePacket(
+				int(self.order.Uint32(body[0:])),
+				self.order.Uint32(body[4:]), self.order.Uint32(body[8:]),
+				int(self.order.Uint32(body[12:])),
+				int(self.order.Uint32(body[16:])),
+				body[PCAPNG_ENHANCED_PACKET_SIZE:]), nil
+
+		// The obsolete packet block.
+		case PCAPNG_PACKET:
+			if len(body) < PCAPNG_PACKET_SIZE {
+				continue
+			}
+			return self.makePacket(
+				int(self.order.Uint16(body[0:])),
+				self.order.Uint32(body[4:]), self.order.Uint32(body[8:]),
+				int(self.order.Uint32(body[12:])),
+				int(self.order.Uint32(body[16:])),
+				body[PCAPNG_PACKET_SIZE:]), nil
+
+		// Simple packets have no timestamp and belong to the first
+		// interface.
+		case PCAPNG_SIMPLE_PACKET:
+			if len(body) < PCAPNG_SIMPLE_PACKET_SIZE {
+				continue
+			}
+			original := int(self.order.Uint32(body))
+			packet := self.makePacket(0, 0, 0, original, original,
+				body[PCAPNG_SIMPLE_PACKET_SIZE:])
+			packet.Timestamp = time.Time{}
+			return packet, nil
+		}
+	}
+}
+
+func (self *pcapngReader) parseInterface(body []byte) {
+	iface := &pcapngInterface{
+		ticks_per_second: PCAPNG_DEFAULT_TICKS_PER_S,
+	}
+	self.interfaces = append(self.interfaces, iface)
+
+	if len(body) < PCAPNG_INTERFACE_SIZE {
+		return
+	}
+	iface.link_type = self.order.Uint16(body)
+	iface.snap_len = self.order.Uint32(body[4:])
+
+	for code, value := range self.parseOptions(body[PCAPNG_INTERFACE_SIZE:]) {
+		switch code {
+		case PCAPNG_OPTION_IF_TSRESOL:
+			if len(value) < 1 {
+				continue
+			}
+
+			// The top bit selects a power of 2 rather than 10.
+			resolution := value[0]
+			if resolution&0x80 != 0 && resolution&0x7f < 64 {
+				iface.ticks_per_second = 1 << (resolution & 0x7f)
+			} else if resolution&0x80 == 0 && resolution < 20 {
+				iface.ticks_per_second = 1
+				for i := uint8(0); i < resolution; i++ {
+					iface.ticks_per_second *= 10
+				}
+			}
+
+		case PCAPNG_OPTION_IF_TSOFFSET:
+			if len(value) >= 8 {
+				iface.offset = int64(self.order.Uint64(value))
+			}
+		}
+	}
+}
+
+func (self *pcapngReader) parseOptions(data []byte) map[uint16][]byte {
+	result := make(map[uint16][]byte)
+	for offset := 0; offset+4 <= len(data); {
+		code := self.order.Uint16(data[offset:])
+		length := int(self.order.Uint16(data[offset+2:]))
+		offset += 4
+
+		if code == PCAPNG_OPTION_END || offset+length > len(data) {
+			break
+		}
+
+		result[code] = data[offset : offset+length]
+		offset += (length + 3) &^ 3
+	}
+	return result
+}
+
+func (self *pcapngReader) makePacket(interface_id int,
+	ts_high, ts_low uint32, captured, original int, data []byte) *Packet {
+	if captured > len(data) {
+		captured = len(data)
+	}
+
+	packet := &Packet{
+		Interface:      interface_id,
+		OriginalLength: original,
+		Data:           data[:captured],
+	}
+
+	iface := &pcapngInterface{ticks_per_second: PCAPNG_DEFAULT_TICKS_PER_S}
+	if interface_id >= 0 && interface_id < len(self.interfaces) {
+		iface = self.interfaces[interface_id]
+	}
+	packet.LinkType = iface.link_type
+
+	// Simple packets are truncated to the snap length.
+	if iface.snap_len > 0 && len(packet.Data) > int(iface.snap_len) {
+		packet.Data = packet.Data[:iface.snap_len]
+	}
+
+	ticks := uint64(ts_high)<<32 | uint64(ts_low)
+	seconds := ticks / iface.ticks_per_second
+	remainder := ticks % iface.ticks_per_second
+
+	// Scale the remainder to nanoseconds without overflowing.
+	hi, lo := bits.Mul64(remainder, uint64(time.Second))
+	nanoseconds, _ := bits.Div64(hi, lo, iface.ticks_per_second)
+
+	packet.Timestamp = time.Unix(int64(seconds)+iface.offset,
+		int64(nanoseconds)).UTC()
+	return packet
+}
@@ -0,0 +1,156 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+const (
+	TLS_RECORD_HANDSHAKE       = 0x16
+	TLS_HANDSHAKE_CLIENT_HELLO = 0x01
+	TLS_RECORD_HEADER_SIZE     = 5
+	TLS_HANDSHAKE_HEADER_SIZE  = 4
+	TLS_RANDOM_SIZE            = 32
+
+	TLS_EXTENSION_SERVER_NAME        = 0x0000
+	TLS_EXTENSION_ALPN               = 0x0010
+	TLS_EXTENSION_SUPPORTED_VERSIONS = 0x002b
+
+	TLS_SERVER_NAME_HOST = 0
+	TLS_VERSION_1_3      = 0x0304
+)
+
+var (
+	tlsVersions = map[uint16]string{
+		0x0300: "SSL 3.0",
+		0x0301: "TLS 1.0",
+		0x0302: "TLS 1.1",
+		0x0303: "TLS 1.2",
+		0x0304: "TLS 1.3",
+	}
+)
+
+func tlsVersionName(version uint16) string {
+	name, pres := tlsVersions[version]
+	if !pres {
+		return fmt.Sprintf("%#04x", version)
+	}
+	return name
+}
+
+// A bounds checked reader over the ClientHello.
+type tlsReader struct {
+	data   []byte
+	offset int
+	err    bool
+}
+
+func (self *tlsReader) bytes(n int) []byte {
+	if self.err || n < 0 || self.offset+n > len(self.data) {
+		self.err = true
+		return nil
+	}
+	result := self.data[self.offset : self.offset+n]
+	self.offset += n
+	return result
+}
+
+func (self *tlsReader) u8() int {
+	b := self.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return int(b[0])
+}
+
+func (self *tlsReader) u16() int {
+	b := self.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return int(binary.BigEndian.Uint16(b))
+}
+
+func (self *tlsReader) u24() int {
+	b := self.bytes(3)
+	if b == nil {
+		return 0
+	}
+	return int(b[0])<<16 | int(b[1])<<8 | int(b[2])
+}
+
+// Extract the server name, ALPN and version from a TLS
+// ClientHello. Returns nil if the payload is not a ClientHello.
+func parseClientHello(payload []byte) *ordereddict.Dict {
+	if len(payload) < TLS_RECORD_HEADER_SIZE+TLS_HANDSHAKE_HEADER_SIZE ||
+		payload[0] != TLS_RECORD_HANDSHAKE || payload[1] != 0x03 ||
+		payload[TLS_RECORD_HEADER_SIZE] != TLS_HANDSHAKE_CLIENT_HELLO {
+		return nil
+	}
+
+	reader := &tlsReader{data: payload, offset: TLS_RECORD_HEADER_SIZE + 1}
+	reader.u24()
+
+	version := uint16(reader.u16())
+	reader.bytes(TLS_RANDOM_SIZE)
+	reader.bytes(reader.u8())  // Session id
+	reader.bytes(reader.u16()) // Cipher suites
+	reader.bytes(reader.u8())  // Compression methods
+	if reader.err {
+		return nil
+	}
+
+	server_name := ""
+	alpn := []string{}
+
+	// The ClientHello may be split across segments, in which case
+	// we use what we have.
+	extensions := &tlsReader{data: reader.bytes(reader.u16())}
+	if reader.err {
+		extensions.data = reader.data[reader.offset:]
+	}
+
+	for !extensions.err && extensions.offset < len(extensions.data) {
+		ext_type := extensions.u16()
+		ext := &tlsReader{data: extensions.bytes(extensions.u16())}
+		if extensions.err {
+			break
+		}
+
+		switch ext_type {
+		case TLS_EXTENSION_SERVER_NAME:
+			ext.u16()
+			for !ext.err && ext.offset < len(ext.data) {
+				name_type := ext.u8()
+				name := ext.bytes(ext.u16())
+				if !ext.err && name_type == TLS_SERVER_NAME_HOST {
+					server_name = string(name)
+				}
+			}
+
+		case TLS_EXTENSION_ALPN:
+			ext.u16()
+			for !ext.err && ext.offset < len(ext.data) {
+				protocol := ext.bytes(ext.u8())
+				if !ext.err {
+					alpn = append(alpn, string(protocol))
+				}
+			}
+
+		case TLS_EXTENSION_SUPPORTED_VERSIONS:
+			count := ext.u8() / 2
+			for i := 0; i < count && !ext.err; i++ {
+				if uint16(ext.u16()) == TLS_VERSION_1_3 {
+					version = TLS_VERSION_1_3
+				}
+			}
+		}
+	}
+
+	return ordereddict.NewDict().
+		Set("Version", tlsVersionName(version)).
+		Set("SNI", server_name).
+		Set("ALPN", alpn)
+}
@@ -0,0 +1,124 @@
+package unified_log
+
+import (
+	"errors"
+)
+
+const (
+	CATALOG_HEADER_SIZE        = 24
+	CATALOG_PROCESS_ENTRY_SIZE = 40
+)
+
+// Processes are identified in the firehose chunks by their process
+// ids.
+type procKey struct {
+	first  uint64
+	second uint32
+}
+
+type subsystemInfo struct {
+	subsystem, category string
+}
+
+type catalogProcess struct {
+	main_uuid string
+	dsc_uuid  string
+	pid       uint32
+	euid      uint32
+
+	subsystems map[uint16]subsystemInfo
+}
+
+// The catalog describes the processes which log to the following
+// chunksets.
+type catalog struct {
+	uuids     []string
+	processes map[procKey]*catalogProcess
+}
+
+func (self *catalog) getUUID(idx uint16) string {
+	if int(idx) < len(self.uuids) {
+		return self.uuids[idx]
+	}
+	return ""
+}
+
+func (self *catalog) getProcess(first uint64, second uint32) *catalogProcess {
+	if self == nil {
+		return nil
+	}
+	return self.processes[procKey{first: first, second: second}]
+}
+
+func parseCatalog(data []byte) (*catalog, error) {
+	c := newCursor(data)
+	subsystem_strings_offset := int(c.u16())
+	process_entries_offset := int(c.u16())
+	process_count := int(c.u16())
+	c.skip(18)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	if subsystem_strings_offset > process_entries_offset ||
+		CATALOG_HEADER_SIZE+process_entries_offset > len(data) {
+		return nil, errors.New("Invalid catalog offsets")
+	}
+
+	result := &catalog{
+		processes: make(map[procKey]*catalogProcess),
+	}
+
+	for i := 0; i < subsystem_strings_offset/16; i++ {
+		result.uuids = append(result.uuids, c.uuid())
+	}
+
+	subsystem_strings := data[CATALOG_HEADER_SIZE+subsystem_strings_offset : CATALOG_HEADER_SIZE+process_entries_offset]
+	getString := func(offset uint16) string {
+		if int(offset) >= len(subsystem_strings) {
+			return ""
+		}
+		return cString(subsystem_strings[offset:])
+	}
+
+	c = newCursor(data[CATALOG_HEADER_SIZE+process_entries_offset:])
+	for i := 0; i < process_count; i++ {
+		c.skip(4)
+		main_uuid_index := c.u16()
+		dsc_uuid_index := c.u16()
+		key := procKey{first: c.u64(), second: c.u32()}
+		process := &catalogProcess{
+			main_uuid:  result.getUUID(main_uuid_index),
+			dsc_uuid:   result.getUUID(dsc_uuid_index),
+			pid:        c.u32(),
+			euid:       c.u32(),
+			subsystems: make(map[uint16]subsystemInfo),
+		}
+		c.skip(4)
+		uuid_count := int(c.u32())
+		c.skip(4)
+
+		// Additional images loaded into the process.
+		c.skip(16 * uuid_count)
+
+		subsystem_count := int(c.u32())
+		c.skip(4)
+		for j := 0; j < subsystem_count; j++ {
+			id := c.u16()
+			process.subsystems[id] = subsystemInfo{
+				subsystem: getString(c.u16()),
+				category:  getString(c.u16()),
+			}
+		}
+
+		// The subsystems are padded to 8 bytes.
+		c.skip(align8(6*subsystem_count) - 6*subsystem_count)
+
+		if c.err != nil {
+			return nil, c.err
+		}
+		result.processes[key] = process
+	}
+
+	return result, nil
+}
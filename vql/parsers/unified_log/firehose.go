@@ -0,0 +1,432 @@
+package unified_log
+
+import (
+	"fmt"
+)
+
+const (
+	FIREHOSE_HEADER_SIZE       = 32
+	FIREHOSE_ENTRY_HEADER_SIZE = 24
+
+	// Public data size includes part of the firehose header.
+	FIREHOSE_PUBLIC_DATA_OFFSET = 16
+
+	PRIVATE_DATA_VIRTUAL_END = 0x1000
+
+	ACTIVITY_TYPE_ACTIVITY = 0x02
+	ACTIVITY_TYPE_TRACE    = 0x03
+	ACTIVITY_TYPE_LOG      = 0x04
+	ACTIVITY_TYPE_SIGNPOST = 0x06
+	ACTIVITY_TYPE_LOSS     = 0x07
+
+	// Firehose entry flags
+	FLAG_HAS_CURRENT_AID   = 0x0001
+	FLAG_FORMATTER_MASK    = 0x000e
+	FLAG_UNIQUE_PID        = 0x0010
+	FLAG_HAS_LARGE_OFFSET  = 0x0020
+	FLAG_HAS_PRIVATE_DATA  = 0x0100
+	FLAG_HAS_SUBSYSTEM     = 0x0200
+	FLAG_HAS_OTHER_AID     = 0x0200
+	FLAG_HAS_RULES         = 0x0400
+	FLAG_HAS_OVERSIZE      = 0x0800
+	FLAG_HAS_SIGNPOST_NAME = 0x8000
+
+	// Where the format string is stored (FLAG_FORMATTER_MASK).
+	FORMATTER_MAIN_EXE      = 0x0002
+	FORMATTER_SHARED_CACHE  = 0x0004
+	FORMATTER_ABSOLUTE      = 0x0008
+	FORMATTER_UUID_RELATIVE = 0x000a
+	FORMATTER_LARGE_SHARED  = 0x000c
+)
+
+var (
+	logLevels = map[uint8]string{
+		0x00: "Default",
+		0x01: "Info",
+		0x02: "Debug",
+		0x03: "Useraction",
+		0x10: "Error",
+		0x11: "Fault",
+	}
+
+	signpostTypes = map[uint8]string{
+		0x40: "Thread Signpost Event",
+		0x41: "Thread Signpost Start",
+		0x42: "Thread Signpost End",
+		0x80: "Process Signpost Event",
+		0x81: "Process Signpost Start",
+		0x82: "Process Signpost End",
+		0xc0: "System Signpost Event",
+		0xc1: "System Signpost Start",
+		0xc2: "System Signpost End",
+	}
+)
+
+// Where to find the format string for the entry.
+type formatter struct {
+	flags              uint16
+	large_offset       uint16
+	large_shared_cache uint16
+	alt_index          uint16
+	uuid               string
+}
+
+func parseFormatter(c *cursor, flags uint16) *formatter {
+	result := &formatter{flags: flags}
+
+	switch flags & FLAG_FORMATTER_MASK {
+	case FORMATTER_MAIN_EXE, FORMATTER_SHARED_CACHE:
+		if flags&FLAG_HAS_LARGE_OFFSET != 0 {
+			result.large_offset = c.u16()
+		}
+
+	case FORMATTER_LARGE_SHARED:
+		if flags&FLAG_HAS_LARGE_OFFSET != 0 {
+			result.large_offset = c.u16()
+		}
+		result.large_shared_cache = c.u16()
+
+	case FORMATTER_ABSOLUTE:
+		result.alt_index = c.u16()
+
+	case FORMATTER_UUID_RELATIVE:
+		result.uuid = c.uuid()
+	}
+
+	return result
+}
+
+// Find the format string and the image which logged the entry.
+func (self *tracev3Parser) resolveFormat(process *catalogProcess,
+	formatter *formatter, location uint32) (string, *imageInfo, error) {
+	if process == nil {
+		return "", nil, fmt.Errorf("process not found in catalog")
+	}
+
+	switch formatter.flags & FLAG_FORMATTER_MASK {
+	case FORMATTER_MAIN_EXE:
+		offset := uint64(location)
+		if formatter.large_offset != 0 {
+			offset = largeOffset(uint64(formatter.large_offset), location, 8)
+		}
+		return self.strings.imageFormatString(process.main_uuid, offset)
+
+	case FORMATTER_SHARED_CACHE, FORMATTER_LARGE_SHARED:
+		offset := uint64(location)
+		switch {
+		case formatter.flags&FLAG_FORMATTER_MASK == FORMATTER_SHARED_CACHE &&
+			formatter.large_offset != 0:
+			offset = largeOffset(8, location, 7)
+
+		case formatter.large_shared_cache != 0:
+			offset = largeOffset(
+				uint64(formatter.large_shared_cache/2), location, 7)
+		}
+		return self.strings.sharedCacheFormatString(process.dsc_uuid, offset)
+
+	case FORMATTER_ABSOLUTE:
+		uuid := self.catalog.getUUID(formatter.alt_index)
+		if uuid == "" {
+			uuid = process.main_uuid
+		}
+		return self.strings.imageFormatString(uuid, uint64(location))
+
+	case FORMATTER_UUID_RELATIVE:
+		return self.strings.imageFormatString(formatter.uuid, uint64(location))
+	}
+
+	return "", nil, fmt.Errorf("unknown formatter %#x", formatter.flags)
+}
+
+func (self *tracev3Parser) processFirehose(
+	data []byte, cb func(entry *LogEntry) error) error {
+	c := newCursor(data)
+	first_proc_id := c.u64()
+	second_proc_id := c.u32()
+	c.skip(4)
+	public_data_size := int(c.u16())
+	private_data_virtual_offset := int(c.u16())
+	c.skip(4)
+	base_continuous_time := c.u64()
+	if c.err != nil {
+		return nil
+	}
+
+	public_data_end := FIREHOSE_HEADER_SIZE +
+		public_data_size - FIREHOSE_PUBLIC_DATA_OFFSET
+	if public_data_end > len(data) || public_data_end < FIREHOSE_HEADER_SIZE {
+		return nil
+	}
+	public := data[FIREHOSE_HEADER_SIZE:public_data_end]
+
+	// Private data is stored at the end of the chunk.
+	var private []byte
+	if private_data_virtual_offset < PRIVATE_DATA_VIRTUAL_END {
+		private_size := PRIVATE_DATA_VIRTUAL_END - private_data_virtual_offset
+		if private_size <= len(data)-public_data_end {
+			private = data[len(data)-private_size:]
+		}
+	}
+
+	for pos := 0; pos+FIREHOSE_ENTRY_HEADER_SIZE <= len(public); {
+		c := newCursor(public[pos:])
+		activity_type := c.u8()
+		log_type := c.u8()
+		flags := c.u16()
+		location := c.u32()
+		thread_id := c.u64()
+		delta := uint64(c.u32())
+		delta |= uint64(c.u16()) << 32
+		entry_data := c.bytes(int(c.u16()))
+		if activity_type == 0 || c.err != nil {
+			break
+		}
+		pos = align8(pos + FIREHOSE_ENTRY_HEADER_SIZE + len(entry_data))
+
+		entry, process := self.newEntry(first_proc_id, second_proc_id,
+			base_continuous_time+delta)
+		entry.ThreadId = thread_id
+
+		ok := self.decodeFirehoseEntry(entry, process,
+			procKey{first: first_proc_id, second: second_proc_id}, activity_type,
+			log_type, flags, location, entry_data,
+			private, private_data_virtual_offset)
+		if !ok {
+			continue
+		}
+
+		err := cb(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *tracev3Parser) decodeFirehoseEntry(
+	entry *LogEntry, process *catalogProcess, proc_id procKey,
+	activity_type, log_type uint8, flags uint16, location uint32,
+	data []byte, private []byte, private_data_virtual_offset int) bool {
+
+	c := newCursor(data)
+	private_strings_offset := 0
+	subsystem_id := uint16(0)
+	has_subsystem := false
+	data_ref := uint16(0)
+	has_data_ref := false
+
+	switch activity_type {
+	case ACTIVITY_TYPE_LOG, ACTIVITY_TYPE_SIGNPOST:
+		entry.EventType = "Log"
+		entry.Level = logLevels[log_type]
+		if activity_type == ACTIVITY_TYPE_SIGNPOST {
+			entry.EventType = "Signpost"
+			entry.Level = signpostTypes[log_type]
+		}
+
+		if flags&FLAG_HAS_CURRENT_AID != 0 {
+			c.skip(8)
+		}
+		if flags&FLAG_HAS_PRIVATE_DATA != 0 {
+			private_strings_offset = int(c.u16())
+			c.skip(2)
+		}
+		c.skip(4)
+		formatter := parseFormatter(c, flags)
+
+		if flags&FLAG_HAS_SUBSYSTEM != 0 {
+			subsystem_id = c.u16()
+			has_subsystem = true
+		}
+		if activity_type == ACTIVITY_TYPE_SIGNPOST {
+			// The signpost id
+			c.skip(8)
+		}
+		if flags&FLAG_HAS_RULES != 0 {
+			c.skip(1)
+		}
+		if flags&FLAG_HAS_OVERSIZE != 0 {
+			data_ref = c.u16()
+			has_data_ref = true
+		}
+		if activity_type == ACTIVITY_TYPE_SIGNPOST &&
+			flags&FLAG_HAS_SIGNPOST_NAME != 0 {
+			c.skip(4)
+			if flags&FLAG_HAS_LARGE_OFFSET != 0 {
+				c.skip(2)
+			}
+		}
+		if c.err != nil {
+			return false
+		}
+
+		self.setFormat(entry, process, formatter, location)
+
+	case ACTIVITY_TYPE_ACTIVITY:
+		entry.EventType = "Activity"
+		entry.Level = "Create"
+
+		if flags&FLAG_HAS_CURRENT_AID != 0 {
+			c.skip(8)
+		}
+		if flags&FLAG_UNIQUE_PID != 0 {
+			c.skip(8)
+		}
+		if flags&FLAG_HAS_CURRENT_AID != 0 {
+			c.skip(8)
+		}
+		if flags&FLAG_HAS_OTHER_AID != 0 {
+			c.skip(8)
+		}
+		c.skip(4)
+		formatter := parseFormatter(c, flags)
+		if c.err != nil {
+			return false
+		}
+
+		// Activities do not have arguments.
+		self.setFormat(entry, process, formatter, location)
+		entry.Message = formatMessage(entry.FormatString, nil)
+		return true
+
+	case ACTIVITY_TYPE_LOSS:
+		entry.EventType = "Loss"
+		c.skip(16)
+		count := c.u64()
+		entry.Message = fmt.Sprintf("Lost %d unreliable messages", count)
+		return true
+
+	default:
+		// Trace entries are not supported.
+		return false
+	}
+
+	if process != nil && has_subsystem {
+		subsystem := process.subsystems[subsystem_id]
+		entry.Subsystem = subsystem.subsystem
+		entry.Category = subsystem.category
+	}
+
+	var items []*messageItem
+	if has_data_ref {
+		// The message data is stored in an oversize chunk.
+		oversize, pres := self.oversize[oversizeKey{
+			proc_id:  proc_id,
+			data_ref: uint32(data_ref),
+		}]
+		if !pres {
+			entry.Message = "<Missing message data>"
+			return true
+		}
+		items = oversize.items
+
+	} else if c.remaining() > 0 {
+		items = parseItems(data[c.pos:], private,
+			private_strings_offset-private_data_virtual_offset)
+	}
+
+	if entry.FormatString != "" {
+		entry.Message = formatMessage(entry.FormatString, items)
+	}
+
+	return true
+}
+
+func (self *tracev3Parser) setFormat(entry *LogEntry, process *catalogProcess,
+	formatter *formatter, location uint32) {
+	format, image, err := self.resolveFormat(process, formatter, location)
+	if err != nil {
+		entry.Message = fmt.Sprintf("<Unable to resolve format string: %v>", err)
+	}
+
+	entry.FormatString = format
+	if image != nil {
+		entry.Sender = image.Path
+		entry.SenderUUID = image.UUID
+	}
+}
+
+type oversizeKey struct {
+	proc_id  procKey
+	data_ref uint32
+}
+
+type oversizeEntry struct {
+	key   oversizeKey
+	items []*messageItem
+}
+
+func parseOversize(data []byte) (*oversizeEntry, error) {
+	c := newCursor(data)
+	key := oversizeKey{
+		proc_id: procKey{first: c.u64(), second: c.u32()},
+	}
+	c.skip(12)
+	key.data_ref = c.u32()
+	public_size := int(c.u16())
+	private_size := int(c.u16())
+	public := c.bytes(public_size)
+	private := c.bytes(private_size)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &oversizeEntry{
+		key:   key,
+		items: parseItems(public, private, 0),
+	}, nil
+}
+
+// Statedumps record the state of an object (e.g. a plist).
+func (self *tracev3Parser) parseStatedump(data []byte) (*LogEntry, error) {
+	c := newCursor(data)
+	first_proc_id := c.u64()
+	second_proc_id := c.u32()
+	c.skip(4)
+	continuous_time := c.u64()
+	c.skip(8 + 16)
+	c.skip(4)
+	data_size := int(c.u32())
+	c.skip(64 * 2)
+	title := cString(c.bytes(64))
+	payload := c.bytes(data_size)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	entry, _ := self.newEntry(first_proc_id, second_proc_id, continuous_time)
+	entry.EventType = "Statedump"
+	entry.Message = fmt.Sprintf("title: %s\n%s", title, cString(payload))
+	return entry, nil
+}
+
+// Simpledumps contain a message and subsystem as plain strings.
+func (self *tracev3Parser) parseSimpledump(data []byte) (*LogEntry, error) {
+	c := newCursor(data)
+	first_proc_id := c.u64()
+	second_proc_id := c.u32()
+	c.skip(4)
+	continuous_time := c.u64()
+	thread_id := c.u64()
+	c.skip(8)
+	sender_uuid := c.uuid()
+	c.skip(16)
+	c.skip(4)
+	subsystem_size := int(c.u32())
+	message_size := int(c.u32())
+	subsystem := cString(c.bytes(subsystem_size))
+	message := cString(c.bytes(message_size))
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	entry, _ := self.newEntry(first_proc_id, second_proc_id, continuous_time)
+	entry.EventType = "Simpledump"
+	entry.ThreadId = thread_id
+	entry.Subsystem = subsystem
+	entry.Message = message
+	entry.SenderUUID = sender_uuid
+	entry.Sender = self.strings.imagePath(sender_uuid)
+	return entry, nil
+}
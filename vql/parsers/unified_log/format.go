@@ -0,0 +1,401 @@
+package unified_log
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	ITEM_CLASS_NUMBER    = 0x00
+	ITEM_CLASS_PRECISION = 0x10
+	ITEM_CLASS_STRING    = 0x20
+	ITEM_CLASS_BINARY    = 0x30
+	ITEM_CLASS_OBJECT    = 0x40
+	ITEM_CLASS_SENSITIVE = 0x80
+	ITEM_CLASS_BASE64    = 0xf0
+
+	// Flags in the low nibble of the item type.
+	ITEM_FLAG_PRIVATE   = 0x01
+	ITEM_FLAG_SENSITIVE = 0x04
+
+	PRIVATE_VALUE = "<private>"
+	MISSING_VALUE = "<decode: missing data>"
+)
+
+// An argument to the format string.
+type messageItem struct {
+	item_type uint8
+
+	// Numbers and precision values.
+	number uint64
+	size   int
+
+	// Strings, objects and binary data.
+	data []byte
+
+	// The value was redacted.
+	private bool
+	null    bool
+}
+
+func (self *messageItem) isPrecision() bool {
+	return self.item_type&0xf0 == ITEM_CLASS_PRECISION
+}
+
+func (self *messageItem) isNumber() bool {
+	return self.item_type&0xf0 == ITEM_CLASS_NUMBER
+}
+
+// Parse the message items. Strings are stored after the items and
+// referenced by offset. Private strings are stored in the private
+// data (at private_base).
+func parseItems(data []byte, private []byte, private_base int) []*messageItem {
+	c := newCursor(data)
+	c.skip(1)
+	count := int(c.u8())
+
+	type stringRef struct {
+		item         *messageItem
+		offset, size int
+	}
+	refs := []stringRef{}
+
+	result := make([]*messageItem, 0, count)
+	for i := 0; i < count && c.err == nil; i++ {
+		item := &messageItem{
+			item_type: c.u8(),
+		}
+		size := int(c.u8())
+		value := c.bytes(size)
+		if c.err != nil {
+			break
+		}
+
+		switch item.item_type & 0xf0 {
+		case ITEM_CLASS_NUMBER, ITEM_CLASS_PRECISION:
+			item.size = size
+			item.number = readNumber(value)
+			if item.item_type&ITEM_FLAG_PRIVATE != 0 && item.isNumber() {
+				item.private = true
+			}
+
+		default:
+			if size >= 4 {
+				refs = append(refs, stringRef{
+					item:   item,
+					offset: int(binary.LittleEndian.Uint16(value)),
+					size:   int(binary.LittleEndian.Uint16(value[2:])),
+				})
+			}
+		}
+		result = append(result, item)
+	}
+
+	// The string data follows the items.
+	strings_data := data[c.pos:]
+	if c.pos > len(data) {
+		strings_data = nil
+	}
+
+	for _, ref := range refs {
+		item := ref.item
+		is_private := item.item_type&ITEM_FLAG_PRIVATE != 0 ||
+			item.item_type&ITEM_FLAG_SENSITIVE != 0
+
+		source, start := strings_data, ref.offset
+		if is_private {
+			source, start = private, private_base+ref.offset
+		}
+
+		switch {
+		case ref.size == 0 && is_private:
+			item.private = true
+
+		case ref.size == 0:
+			item.null = true
+
+		case start < 0 || start+ref.size > len(source):
+			item.private = is_private
+
+		default:
+			item.data = source[start : start+ref.size]
+		}
+	}
+
+	return result
+}
+
+func readNumber(value []byte) uint64 {
+	switch len(value) {
+	case 1:
+		return uint64(value[0])
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(value))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(value))
+	case 8:
+		return binary.LittleEndian.Uint64(value)
+	}
+	return 0
+}
+
+// A printf style format specifier.
+type formatSpec struct {
+	annotation string
+	flags      string
+	width      string
+	precision  string
+	length     string
+	conversion byte
+}
+
+// Render the format string with the message items. This
+// implements the printf like format strings used by os_log().
+func formatMessage(format string, items []*messageItem) string {
+	result := &strings.Builder{}
+
+	next := func() *messageItem {
+		if len(items) == 0 {
+			return nil
+		}
+		item := items[0]
+		items = items[1:]
+		return item
+	}
+
+	// Width and precision given as arguments are stored as
+	// precision items.
+	nextPrecision := func() string {
+		if len(items) > 0 && items[0].isPrecision() {
+			return fmt.Sprintf("%d", int32(next().number))
+		}
+		return ""
+	}
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			result.WriteByte(format[i])
+			continue
+		}
+
+		if i+1 < len(format) && format[i+1] == '%' {
+			result.WriteByte('%')
+			i++
+			continue
+		}
+
+		spec, end := parseFormatSpec(format, i+1)
+		if spec == nil {
+			result.WriteString(format[i:])
+			break
+		}
+		i = end
+
+		if spec.width == "*" {
+			spec.width = nextPrecision()
+		}
+		if spec.precision == ".*" {
+			precision := nextPrecision()
+			if precision == "" {
+				spec.precision = ""
+			} else {
+				spec.precision = "." + precision
+			}
+		}
+
+		// %m prints errno and does not consume an argument.
+		if spec.conversion == 'm' {
+			result.WriteString("<errno>")
+			continue
+		}
+
+		item := next()
+		// Skip precision items which were not requested by the
+		// format string.
+		for item != nil && item.isPrecision() {
+			item = next()
+		}
+
+		result.WriteString(formatItem(spec, item))
+	}
+
+	return result.String()
+}
+
+// Parse the specifier after the % at pos. Returns the spec and the
+// position of the conversion character.
+func parseFormatSpec(format string, pos int) (*formatSpec, int) {
+	spec := &formatSpec{}
+
+	if pos < len(format) && format[pos] == '{' {
+		end := strings.IndexByte(format[pos:], '}')
+		if end < 0 {
+			return nil, 0
+		}
+		spec.annotation = format[pos+1 : pos+end]
+		pos += end + 1
+	}
+
+	start := pos
+	for pos < len(format) && strings.IndexByte("-+ #0'", format[pos]) >= 0 {
+		pos++
+	}
+	spec.flags = strings.ReplaceAll(format[start:pos], "'", "")
+
+	start = pos
+	if pos < len(format) && format[pos] == '*' {
+		pos++
+	} else {
+		for pos < len(format) && format[pos] >= '0' && format[pos] <= '9' {
+			pos++
+		}
+	}
+	spec.width = format[start:pos]
+
+	if pos < len(format) && format[pos] == '.' {
+		start = pos
+		pos++
+		if pos < len(format) && format[pos] == '*' {
+			pos++
+		} else {
+			for pos < len(format) && format[pos] >= '0' && format[pos] <= '9' {
+				pos++
+			}
+		}
+		spec.precision = format[start:pos]
+	}
+
+	start = pos
+	for pos < len(format) && strings.IndexByte("hlqLzjtw", format[pos]) >= 0 {
+		pos++
+	}
+	spec.length = format[start:pos]
+
+	if pos >= len(format) ||
+		strings.IndexByte("diouxXcsSpPaAeEfFgGCm@", format[pos]) < 0 {
+		return nil, 0
+	}
+	spec.conversion = format[pos]
+
+	return spec, pos
+}
+
+func (self *formatSpec) goFormat(verb byte) string {
+	return "%" + self.flags + self.width + self.precision + string(verb)
+}
+
+func (self *formatSpec) hasAnnotation(name string) bool {
+	for _, a := range strings.Split(self.annotation, ",") {
+		a = strings.TrimSpace(a)
+		if a == name || strings.HasSuffix(a, ":"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatItem(spec *formatSpec, item *messageItem) string {
+	if item == nil {
+		return MISSING_VALUE
+	}
+
+	if item.private {
+		return PRIVATE_VALUE
+	}
+
+	if item.null {
+		return "(null)"
+	}
+
+	if !item.isNumber() {
+		return formatData(spec, item)
+	}
+
+	switch spec.conversion {
+	case 'd', 'i':
+		value := signExtend(item.number, item.size)
+		switch {
+		case spec.hasAnnotation("BOOL"):
+			if value != 0 {
+				return "YES"
+			}
+			return "NO"
+
+		case spec.hasAnnotation("bool"):
+			if value != 0 {
+				return "true"
+			}
+			return "false"
+
+		case spec.hasAnnotation("time_t"):
+			return time.Unix(value, 0).UTC().Format(time.RFC3339)
+
+		case spec.hasAnnotation("errno"):
+			return fmt.Sprintf("[%d]", value)
+		}
+		return fmt.Sprintf(spec.goFormat('d'), value)
+
+	case 'u':
+		return fmt.Sprintf(spec.goFormat('d'), item.number)
+
+	case 'x', 'X', 'o':
+		return fmt.Sprintf(spec.goFormat(spec.conversion), item.number)
+
+	case 'c', 'C':
+		return string(rune(item.number))
+
+	case 'p':
+		return fmt.Sprintf("0x%x", item.number)
+
+	case 'e', 'E', 'f', 'F', 'g', 'G', 'a', 'A':
+		value := math.Float64frombits(item.number)
+		if item.size == 4 {
+			value = float64(math.Float32frombits(uint32(item.number)))
+		}
+		verb := spec.conversion
+		switch verb {
+		case 'F':
+			verb = 'f'
+		case 'a', 'A':
+			verb = 'x'
+		}
+		return fmt.Sprintf(spec.goFormat(verb), value)
+	}
+
+	return fmt.Sprintf("%d", item.number)
+}
+
+func formatData(spec *formatSpec, item *messageItem) string {
+	switch item.item_type & 0xf0 {
+	case ITEM_CLASS_BINARY, ITEM_CLASS_BASE64:
+		if spec.hasAnnotation("uuid_t") && len(item.data) == 16 {
+			return formatUUIDDashes(item.data)
+		}
+		return fmt.Sprintf("%X", item.data)
+	}
+
+	value := cString(item.data)
+	if spec.conversion == 's' || spec.conversion == 'S' || spec.conversion == '@' {
+		return fmt.Sprintf("%"+spec.flags+spec.width+spec.precision+"s", value)
+	}
+	return value
+}
+
+func formatUUIDDashes(b []byte) string {
+	return fmt.Sprintf("%X-%X-%X-%X-%X", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func signExtend(value uint64, size int) int64 {
+	switch size {
+	case 1:
+		return int64(int8(value))
+	case 2:
+		return int64(int16(value))
+	case 4:
+		return int64(int32(value))
+	}
+	return int64(value)
+}
@@ -0,0 +1,243 @@
+package unified_log
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/acls"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+)
+
+const (
+	MAX_TIMESYNC_SIZE = 0x1000000
+)
+
+type UnifiedLogPluginArgs struct {
+	Path     *accessors.OSPath `vfilter:"required,field=path,doc=The diagnostics directory (e.g. /private/var/db/diagnostics) or a single tracev3 file."`
+	UUIDText *accessors.OSPath `vfilter:"optional,field=uuidtext,doc=The uuidtext directory (default the uuidtext directory next to the diagnostics directory)."`
+	Accessor string            `vfilter:"optional,field=accessor,doc=The accessor to use."`
+}
+
+type UnifiedLogPlugin struct{}
+
+func (self UnifiedLogPlugin) Call(
+	ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+		defer utils.RecoverVQL(scope)
+		defer vql_subsystem.RegisterMonitor("parse_unified_log", args)()
+
+		arg := &UnifiedLogPluginArgs{}
+		err := arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("parse_unified_log: %v", err)
+			return
+		}
+
+		err = vql_subsystem.CheckFilesystemAccess(scope, arg.Accessor)
+		if err != nil {
+			scope.Log("parse_unified_log: %v", err)
+			return
+		}
+
+		accessor, err := accessors.GetAccessor(arg.Accessor, scope)
+		if err != nil {
+			scope.Log("parse_unified_log: %v", err)
+			return
+		}
+
+		stat, err := accessor.LstatWithOSPath(arg.Path)
+		if err != nil {
+			scope.Log("parse_unified_log: %v", err)
+			return
+		}
+
+		// Find the tracev3 files and the diagnostics directory.
+		diagnostics := arg.Path
+		files := []*accessors.OSPath{}
+		if stat.IsDir() {
+			files = findTracev3Files(accessor, diagnostics)
+		} else {
+			files = append(files, arg.Path)
+			diagnostics = arg.Path.Dirname()
+			if len(findFiles(accessor, diagnostics.Append("timesync"),
+				".timesync")) == 0 {
+				diagnostics = diagnostics.Dirname()
+			}
+		}
+
+		uuidtext := arg.UUIDText
+		if uuidtext == nil {
+			uuidtext = diagnostics.Dirname().Append("uuidtext")
+		}
+
+		log := func(format string, args ...interface{}) {
+			scope.Log(format, args...)
+		}
+
+		timesync := loadTimesync(scope, accessor, diagnostics)
+		string_files := newStringFiles(accessor, uuidtext, log)
+		defer string_files.Close()
+
+		for _, filename := range files {
+			err := parseTracev3File(ctx, accessor, filename,
+				string_files, timesync, log,
+				func(entry *LogEntry) error {
+					select {
+					case <-ctx.Done():
+						return stopIteration
+					case output_chan <- entryToRow(entry, filename):
+					}
+					return nil
+				})
+			if err != nil {
+				scope.Log("parse_unified_log: %v: %v", filename, err)
+			}
+		}
+	}()
+
+	return output_chan
+}
+
+func parseTracev3File(ctx context.Context,
+	accessor accessors.FileSystemAccessor, filename *accessors.OSPath,
+	string_files *stringFiles, timesync *Timesync,
+	log func(format string, args ...interface{}),
+	cb func(entry *LogEntry) error) error {
+
+	fd, err := accessor.OpenWithOSPath(filename)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	return ParseTracev3(ctx, utils.MakeReaderAtter(fd),
+		string_files, timesync, log, cb)
+}
+
+func entryToRow(entry *LogEntry, filename *accessors.OSPath) *ordereddict.Dict {
+	return ordereddict.NewDict().
+		Set("Time", entry.Time).
+		Set("EventType", entry.EventType).
+		Set("Level", entry.Level).
+		Set("PID", entry.PID).
+		Set("EUID", entry.EUID).
+		Set("ThreadId", entry.ThreadId).
+		Set("Process", entry.Process).
+		Set("Sender", entry.Sender).
+		Set("Subsystem", entry.Subsystem).
+		Set("Category", entry.Category).
+		Set("Message", entry.Message).
+		Set("FormatString", entry.FormatString).
+		Set("ProcessUUID", entry.ProcessUUID).
+		Set("SenderUUID", entry.SenderUUID).
+		Set("BootUUID", entry.BootUUID).
+		Set("ContinuousTime", entry.ContinuousTime).
+		Set("OSPath", filename)
+}
+
+// Find the files with the extension in the directory, sorted by
+// name.
+func findFiles(accessor accessors.FileSystemAccessor,
+	dir *accessors.OSPath, extension string) []*accessors.OSPath {
+	children, err := accessor.ReadDirWithOSPath(dir)
+	if err != nil {
+		return nil
+	}
+
+	result := []*accessors.OSPath{}
+	for _, child := range children {
+		if !child.IsDir() && strings.HasSuffix(child.Name(), extension) {
+			result = append(result, child.OSPath())
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Basename() < result[j].Basename()
+	})
+	return result
+}
+
+// The tracev3 files are stored in the diagnostics directory and
+// its subdirectories (Persist, Special, Signpost, HighVolume).
+func findTracev3Files(accessor accessors.FileSystemAccessor,
+	diagnostics *accessors.OSPath) []*accessors.OSPath {
+	result := findFiles(accessor, diagnostics, ".tracev3")
+
+	children, err := accessor.ReadDirWithOSPath(diagnostics)
+	if err != nil {
+		return result
+	}
+
+	dirs := []*accessors.OSPath{}
+	for _, child := range children {
+		if child.IsDir() {
+			dirs = append(dirs, child.OSPath())
+		}
+	}
+
+	sort.Slice(dirs, func(i, j int) bool {
+		return dirs[i].Basename() < dirs[j].Basename()
+	})
+
+	for _, dir := range dirs {
+		result = append(result, findFiles(accessor, dir, ".tracev3")...)
+	}
+	return result
+}
+
+func loadTimesync(scope vfilter.Scope,
+	accessor accessors.FileSystemAccessor,
+	diagnostics *accessors.OSPath) *Timesync {
+	result := NewTimesync()
+
+	files := findFiles(accessor, diagnostics.Append("timesync"), ".timesync")
+	if len(files) == 0 {
+		scope.Log("parse_unified_log: No timesync files found in %v - "+
+			"times may be inaccurate", diagnostics)
+	}
+
+	for _, filename := range files {
+		fd, err := accessor.OpenWithOSPath(filename)
+		if err != nil {
+			scope.Log("parse_unified_log: %v: %v", filename, err)
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(fd, MAX_TIMESYNC_SIZE))
+		fd.Close()
+		if err != nil {
+			scope.Log("parse_unified_log: %v: %v", filename, err)
+			continue
+		}
+
+		result.Parse(data)
+	}
+
+	return result
+}
+
+func (self UnifiedLogPlugin) Info(scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name:     "parse_unified_log",
+		Doc:      "Parse the macOS Unified Log (tracev3 files).",
+		ArgType:  type_map.AddType(scope, &UnifiedLogPluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.FILESYSTEM_READ).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&UnifiedLogPlugin{})
+}
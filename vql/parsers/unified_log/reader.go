@@ -0,0 +1,131 @@
+package unified_log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	truncatedError = errors.New("Data is truncated")
+)
+
+// A bounds checked little endian reader over a buffer. Reading past
+// the end returns zero values and sets err.
+type cursor struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func newCursor(data []byte) *cursor {
+	return &cursor{data: data}
+}
+
+func (self *cursor) remaining() int {
+	if self.pos >= len(self.data) {
+		return 0
+	}
+	return len(self.data) - self.pos
+}
+
+func (self *cursor) bytes(n int) []byte {
+	if n < 0 || self.remaining() < n {
+		self.err = truncatedError
+		self.pos = len(self.data)
+		return nil
+	}
+	result := self.data[self.pos : self.pos+n]
+	self.pos += n
+	return result
+}
+
+func (self *cursor) skip(n int) {
+	self.bytes(n)
+}
+
+func (self *cursor) u8() uint8 {
+	b := self.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (self *cursor) u16() uint16 {
+	b := self.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+func (self *cursor) u32() uint32 {
+	b := self.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (self *cursor) u64() uint64 {
+	b := self.bytes(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (self *cursor) uuid() string {
+	b := self.bytes(16)
+	if b == nil {
+		return ""
+	}
+	return formatUUID(b)
+}
+
+// Apple tools name uuidtext files by the upper case UUID.
+func formatUUID(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
+
+func align8(n int) int {
+	return (n + 7) &^ 7
+}
+
+// Read a NUL terminated string from the buffer.
+func cString(data []byte) string {
+	idx := bytes.IndexByte(data, 0)
+	if idx >= 0 {
+		data = data[:idx]
+	}
+	return string(data)
+}
+
+// Read a NUL terminated string from a reader, up to max_length
+// bytes.
+func readCString(reader io.ReaderAt, offset int64, max_length int) string {
+	result := []byte{}
+	buf := make([]byte, 256)
+	for len(result) < max_length {
+		n, _ := reader.ReadAt(buf, offset)
+		if n == 0 {
+			break
+		}
+
+		idx := bytes.IndexByte(buf[:n], 0)
+		if idx >= 0 {
+			return string(append(result, buf[:idx]...))
+		}
+		result = append(result, buf[:n]...)
+		offset += int64(n)
+	}
+
+	if len(result) > max_length {
+		result = result[:max_length]
+	}
+	return string(result)
+}
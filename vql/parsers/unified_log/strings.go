@@ -0,0 +1,354 @@
+package unified_log
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	ntfs "www.velocidex.com/golang/go-ntfs/parser"
+	"www.velocidex.com/golang/velociraptor/accessors"
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	UUIDTEXT_SIGNATURE = 0x66778899
+	DSC_SIGNATURE      = "hcsd"
+
+	// Format strings with this bit set in their offset are dynamic.
+	DYNAMIC_FORMAT_STRING = 0x80000000
+
+	MAX_STRING_LENGTH = 0x10000
+
+	// Sanity limits for the number of entries in the files.
+	MAX_ENTRIES = 0x100000
+)
+
+// A range of format strings in a uuidtext or dsc file.
+type stringRange struct {
+	range_offset uint64
+	data_offset  uint64
+	size         uint64
+
+	// For dsc files the image which contains the range.
+	uuid_index uint64
+}
+
+// The image (library or executable) the strings are from.
+type imageInfo struct {
+	UUID string
+	Path string
+}
+
+// uuidtext files contain the format strings for a single image.
+type uuidTextFile struct {
+	reader io.ReaderAt
+	ranges []stringRange
+	image  imageInfo
+}
+
+func parseUUIDText(reader io.ReaderAt, uuid string) (*uuidTextFile, error) {
+	header := make([]byte, 16)
+	_, err := reader.ReadAt(header, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newCursor(header)
+	if c.u32() != UUIDTEXT_SIGNATURE {
+		return nil, fmt.Errorf("uuidtext %v: Invalid signature", uuid)
+	}
+	c.skip(8)
+	count := int(c.u32())
+	if count > MAX_ENTRIES {
+		return nil, fmt.Errorf("uuidtext %v: Too many entries", uuid)
+	}
+
+	entries := make([]byte, 8*count)
+	_, err = reader.ReadAt(entries, 16)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &uuidTextFile{
+		reader: reader,
+		image:  imageInfo{UUID: uuid},
+	}
+
+	// The strings follow the entries in order.
+	c = newCursor(entries)
+	data_offset := uint64(16 + 8*count)
+	for i := 0; i < count; i++ {
+		r := stringRange{
+			range_offset: uint64(c.u32()),
+			size:         uint64(c.u32()),
+			data_offset:  data_offset,
+		}
+		result.ranges = append(result.ranges, r)
+		data_offset += r.size
+	}
+
+	// The path of the image follows the strings.
+	result.image.Path = readCString(reader, int64(data_offset), MAX_STRING_LENGTH)
+
+	return result, nil
+}
+
+func (self *uuidTextFile) formatString(offset uint64) (string, bool) {
+	for _, r := range self.ranges {
+		if offset >= r.range_offset && offset < r.range_offset+r.size {
+			return readCString(self.reader,
+				int64(r.data_offset+offset-r.range_offset),
+				int(r.range_offset+r.size-offset)), true
+		}
+	}
+	return "", false
+}
+
+// dsc files contain the format strings for all the images in the
+// shared cache.
+type dscFile struct {
+	reader io.ReaderAt
+	ranges []stringRange
+	images []imageInfo
+}
+
+func parseDSC(reader io.ReaderAt, uuid string) (*dscFile, error) {
+	header := make([]byte, 16)
+	_, err := reader.ReadAt(header, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newCursor(header)
+	if string(c.bytes(4)) != DSC_SIGNATURE {
+		return nil, fmt.Errorf("dsc %v: Invalid signature", uuid)
+	}
+
+	major := c.u16()
+	c.skip(2)
+	range_count := int(c.u32())
+	uuid_count := int(c.u32())
+
+	if range_count > MAX_ENTRIES || uuid_count > MAX_ENTRIES {
+		return nil, fmt.Errorf("dsc %v: Too many entries", uuid)
+	}
+
+	range_size, uuid_size := 16, 28
+	if major >= 2 {
+		range_size, uuid_size = 24, 32
+	}
+
+	data := make([]byte, range_count*range_size+uuid_count*uuid_size)
+	_, err = reader.ReadAt(data, 16)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dscFile{reader: reader}
+
+	c = newCursor(data)
+	for i := 0; i < range_count; i++ {
+		r := stringRange{}
+		if major >= 2 {
+			r.range_offset = c.u64()
+			r.data_offset = uint64(c.u32())
+			r.size = uint64(c.u32())
+			r.uuid_index = c.u64()
+		} else {
+			r.uuid_index = uint64(c.u32())
+			r.range_offset = uint64(c.u32())
+			r.data_offset = uint64(c.u32())
+			r.size = uint64(c.u32())
+		}
+		result.ranges = append(result.ranges, r)
+	}
+
+	for i := 0; i < uuid_count; i++ {
+		if major >= 2 {
+			c.skip(12)
+		} else {
+			c.skip(8)
+		}
+		image := imageInfo{UUID: c.uuid()}
+		path_offset := c.u32()
+		image.Path = readCString(reader, int64(path_offset), MAX_STRING_LENGTH)
+		result.images = append(result.images, image)
+	}
+
+	return result, nil
+}
+
+func (self *dscFile) formatString(offset uint64) (string, *imageInfo, bool) {
+	for _, r := range self.ranges {
+		if offset >= r.range_offset && offset < r.range_offset+r.size {
+			var image *imageInfo
+			if r.uuid_index < uint64(len(self.images)) {
+				image = &self.images[r.uuid_index]
+			}
+
+			return readCString(self.reader,
+				int64(r.data_offset+offset-r.range_offset),
+				int(r.range_offset+r.size-offset)), image, true
+		}
+	}
+	return "", nil, false
+}
+
+// Loads the uuidtext and dsc files on demand from the uuidtext
+// directory.
+type stringFiles struct {
+	accessor accessors.FileSystemAccessor
+	root     *accessors.OSPath
+
+	uuidtext map[string]*uuidTextFile
+	dsc      map[string]*dscFile
+	closers  []io.Closer
+
+	log func(format string, args ...interface{})
+}
+
+func newStringFiles(accessor accessors.FileSystemAccessor,
+	root *accessors.OSPath,
+	log func(format string, args ...interface{})) *stringFiles {
+	return &stringFiles{
+		accessor: accessor,
+		root:     root,
+		uuidtext: make(map[string]*uuidTextFile),
+		dsc:      make(map[string]*dscFile),
+		log:      log,
+	}
+}
+
+func (self *stringFiles) Close() {
+	for _, c := range self.closers {
+		c.Close()
+	}
+	self.closers = nil
+}
+
+func (self *stringFiles) open(path *accessors.OSPath) (io.ReaderAt, error) {
+	fd, err := self.accessor.OpenWithOSPath(path)
+	if err != nil {
+		return nil, err
+	}
+	self.closers = append(self.closers, fd)
+
+	return ntfs.NewPagedReader(utils.MakeReaderAtter(fd), 0x1000, 100)
+}
+
+func (self *stringFiles) getUUIDText(uuid string) *uuidTextFile {
+	result, pres := self.uuidtext[uuid]
+	if pres {
+		return result
+	}
+
+	// Failures are also cached.
+	self.uuidtext[uuid] = nil
+	if len(uuid) != 32 || self.root == nil {
+		return nil
+	}
+
+	reader, err := self.open(self.root.Append(uuid[:2], uuid[2:]))
+	if err == nil {
+		result, err = parseUUIDText(reader, uuid)
+	}
+	if err != nil {
+		self.log("parse_unified_log: uuidtext %v: %v", uuid, err)
+		return nil
+	}
+
+	self.uuidtext[uuid] = result
+	return result
+}
+
+func (self *stringFiles) getDSC(uuid string) *dscFile {
+	result, pres := self.dsc[uuid]
+	if pres {
+		return result
+	}
+
+	self.dsc[uuid] = nil
+	if len(uuid) != 32 || self.root == nil {
+		return nil
+	}
+
+	reader, err := self.open(self.root.Append("dsc", uuid))
+	if err == nil {
+		result, err = parseDSC(reader, uuid)
+	}
+	if err != nil {
+		self.log("parse_unified_log: dsc %v: %v", uuid, err)
+		return nil
+	}
+
+	self.dsc[uuid] = result
+	return result
+}
+
+// The path of the image with the uuid.
+func (self *stringFiles) imagePath(uuid string) string {
+	uuidtext := self.getUUIDText(uuid)
+	if uuidtext == nil {
+		return ""
+	}
+	return uuidtext.image.Path
+}
+
+// Find the format string in the image's uuidtext file.
+func (self *stringFiles) imageFormatString(
+	uuid string, offset uint64) (string, *imageInfo, error) {
+	if offset&DYNAMIC_FORMAT_STRING != 0 {
+		return "%s", self.imageInfo(uuid), nil
+	}
+
+	uuidtext := self.getUUIDText(uuid)
+	if uuidtext == nil {
+		return "", nil, fmt.Errorf("uuidtext file %v not found", uuid)
+	}
+
+	format, ok := uuidtext.formatString(offset)
+	if !ok {
+		return "", &uuidtext.image, fmt.Errorf(
+			"format string offset %#x not found in %v", offset, uuid)
+	}
+	return format, &uuidtext.image, nil
+}
+
+func (self *stringFiles) imageInfo(uuid string) *imageInfo {
+	uuidtext := self.getUUIDText(uuid)
+	if uuidtext == nil {
+		return &imageInfo{UUID: uuid}
+	}
+	return &uuidtext.image
+}
+
+// Find the format string in the shared cache strings.
+func (self *stringFiles) sharedCacheFormatString(
+	dsc_uuid string, offset uint64) (string, *imageInfo, error) {
+	if offset&DYNAMIC_FORMAT_STRING != 0 {
+		return "%s", nil, nil
+	}
+
+	dsc := self.getDSC(dsc_uuid)
+	if dsc == nil {
+		return "", nil, fmt.Errorf("dsc file %v not found", dsc_uuid)
+	}
+
+	format, image, ok := dsc.formatString(offset)
+	if !ok {
+		return "", nil, fmt.Errorf(
+			"format string offset %#x not found in dsc %v", offset, dsc_uuid)
+	}
+	return format, image, nil
+}
+
+// Large offsets are combined with the format string location by
+// concatenating their hex representation.
+func largeOffset(high uint64, location uint32, digits int) uint64 {
+	value, err := strconv.ParseUint(
+		fmt.Sprintf("%X%0*X", high, digits, location), 16, 64)
+	if err != nil {
+		return uint64(location)
+	}
+	return value
+}
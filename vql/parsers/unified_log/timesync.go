@@ -0,0 +1,129 @@
+package unified_log
+
+import (
+	"encoding/binary"
+	"sort"
+	"time"
+)
+
+const (
+	TIMESYNC_BOOT_SIGNATURE = 0xbbb0
+	TIMESYNC_SYNC_SIGNATURE = 0x207354
+
+	TIMESYNC_BOOT_SIZE = 48
+	TIMESYNC_SYNC_SIZE = 32
+)
+
+// A sync point between the continuous (mach) time and the wall
+// clock.
+type timesyncRecord struct {
+	kernel_time uint64
+	wall_time   int64
+}
+
+type timesyncBoot struct {
+	numerator, denominator uint32
+
+	// Sorted by kernel_time
+	records []timesyncRecord
+}
+
+// The timesync files in the diagnostics directory map the
+// continuous time of each boot to wall clock time.
+type Timesync struct {
+	boots map[string]*timesyncBoot
+}
+
+func NewTimesync() *Timesync {
+	return &Timesync{boots: make(map[string]*timesyncBoot)}
+}
+
+// Add the records from a timesync file.
+func (self *Timesync) Parse(data []byte) {
+	var boot *timesyncBoot
+
+	for pos := 0; pos+4 <= len(data); {
+		if binary.LittleEndian.Uint16(data[pos:]) == TIMESYNC_BOOT_SIGNATURE &&
+			pos+TIMESYNC_BOOT_SIZE <= len(data) {
+			c := newCursor(data[pos:])
+			c.skip(2)
+			header_size := int(c.u16())
+			c.skip(4)
+			boot_uuid := c.uuid()
+			numerator := c.u32()
+			denominator := c.u32()
+			boot_time := int64(c.u64())
+
+			boot = self.boots[boot_uuid]
+			if boot == nil {
+				boot = &timesyncBoot{}
+				self.boots[boot_uuid] = boot
+			}
+			boot.numerator = numerator
+			boot.denominator = denominator
+			boot.records = append(boot.records, timesyncRecord{
+				wall_time: boot_time,
+			})
+
+			if header_size < TIMESYNC_BOOT_SIZE {
+				header_size = TIMESYNC_BOOT_SIZE
+			}
+			pos += header_size
+			continue
+		}
+
+		if binary.LittleEndian.Uint32(data[pos:]) == TIMESYNC_SYNC_SIGNATURE &&
+			pos+TIMESYNC_SYNC_SIZE <= len(data) {
+			if boot != nil {
+				c := newCursor(data[pos+8:])
+				boot.records = append(boot.records, timesyncRecord{
+					kernel_time: c.u64(),
+					wall_time:   int64(c.u64()),
+				})
+			}
+			pos += TIMESYNC_SYNC_SIZE
+			continue
+		}
+
+		// Unknown record
+		break
+	}
+
+	for _, boot := range self.boots {
+		sort.SliceStable(boot.records, func(i, j int) bool {
+			return boot.records[i].kernel_time < boot.records[j].kernel_time
+		})
+	}
+}
+
+// Convert the continuous time for the boot into a wall clock time.
+func (self *Timesync) Time(boot_uuid string, continuous_time uint64) (time.Time, bool) {
+	boot, pres := self.boots[boot_uuid]
+	if !pres || len(boot.records) == 0 {
+		return time.Time{}, false
+	}
+
+	// Find the last sync point before the time.
+	idx := sort.Search(len(boot.records), func(i int) bool {
+		return boot.records[i].kernel_time > continuous_time
+	})
+	if idx > 0 {
+		idx--
+	}
+	record := boot.records[idx]
+
+	delta := toNanoseconds(int64(continuous_time-record.kernel_time),
+		boot.numerator, boot.denominator)
+	return time.Unix(0, record.wall_time+delta).UTC(), true
+}
+
+// Convert mach ticks to nanoseconds using the timebase.
+func toNanoseconds(ticks int64, numerator, denominator uint32) int64 {
+	if numerator == 0 || denominator == 0 || numerator == denominator {
+		return ticks
+	}
+
+	num := int64(numerator)
+	den := int64(denominator)
+	return ticks/den*num + ticks%den*num/den
+}
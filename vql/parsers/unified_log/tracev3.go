@@ -0,0 +1,357 @@
+// A parser for the macOS Unified Log tracev3 files.
+
+// The tracev3 files in /private/var/db/diagnostics contain a series
+// of chunks. The header chunk describes the boot, catalog chunks
+// describe the logging processes and the chunksets contain the
+// (LZ4 compressed) log entries. The log entries only refer to their
+// format strings by offset - the strings are stored in the uuidtext
+// directory, keyed by the UUID of the image which logged them.
+//
+// References:
+// https://github.com/libyal/dtformats/blob/main/documentation/Apple%20Unified%20Logging%20and%20Activity%20Tracing%20formats.asciidoc
+// https://github.com/mandiant/macos-UnifiedLogs
+
+package unified_log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"www.velocidex.com/golang/velociraptor/utils"
+)
+
+const (
+	CHUNK_HEADER     = 0x1000
+	CHUNK_CATALOG    = 0x600b
+	CHUNK_CHUNKSET   = 0x600d
+	CHUNK_FIREHOSE   = 0x6001
+	CHUNK_OVERSIZE   = 0x6002
+	CHUNK_STATEDUMP  = 0x6003
+	CHUNK_SIMPLEDUMP = 0x6004
+
+	CHUNK_PREAMBLE_SIZE = 16
+
+	// Sanity limits on the chunk sizes.
+	MAX_CHUNK_SIZE        = 0x4000000
+	MAX_UNCOMPRESSED_SIZE = 0x4000000
+
+	HEADER_BOOT_SUBCHUNK = 0x6102
+)
+
+var (
+	stopIteration = errors.New("Stop")
+)
+
+// A single log entry.
+type LogEntry struct {
+	Time           time.Time
+	ContinuousTime uint64
+	BootUUID       string
+
+	// Log, Activity, Signpost, Loss, Statedump or Simpledump
+	EventType string
+
+	// The log level (e.g. Default, Info, Error)
+	Level string
+
+	PID      uint32
+	EUID     uint32
+	ThreadId uint64
+
+	Process     string
+	ProcessUUID string
+	Sender      string
+	SenderUUID  string
+	Subsystem   string
+	Category    string
+
+	FormatString string
+	Message      string
+}
+
+// The header chunk at the start of each tracev3 file.
+type tracev3Header struct {
+	numerator, denominator uint32
+	continuous_time        uint64
+	wall_time              int64
+	boot_uuid              string
+}
+
+func parseHeader(data []byte) (*tracev3Header, error) {
+	c := newCursor(data)
+	result := &tracev3Header{
+		numerator:       c.u32(),
+		denominator:     c.u32(),
+		continuous_time: c.u64(),
+		wall_time:       int64(c.u64()) * int64(time.Second),
+	}
+	c.skip(16)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	// Sub chunks follow the header.
+	for c.remaining() >= 8 {
+		tag := c.u32()
+		size := int(c.u32())
+		sub_chunk := c.bytes(size)
+		if c.err != nil {
+			break
+		}
+
+		if tag == HEADER_BOOT_SUBCHUNK && size >= 16 {
+			result.boot_uuid = formatUUID(sub_chunk[:16])
+		}
+	}
+
+	return result, nil
+}
+
+// Walk the chunks in the data.
+func walkChunks(data []byte, cb func(tag uint32, data []byte) error) error {
+	for pos := 0; pos+CHUNK_PREAMBLE_SIZE <= len(data); {
+		c := newCursor(data[pos:])
+		tag := c.u32()
+		c.skip(4)
+		size := c.u64()
+		if size > uint64(len(data)-pos-CHUNK_PREAMBLE_SIZE) {
+			return truncatedError
+		}
+
+		err := cb(tag, data[pos:pos+CHUNK_PREAMBLE_SIZE+int(size)])
+		if err != nil {
+			return err
+		}
+
+		pos = align8(pos + CHUNK_PREAMBLE_SIZE + int(size))
+	}
+	return nil
+}
+
+// Chunksets are compressed with Apple's LZ4 framing: a series of
+// compressed (bv41) or uncompressed (bv4-) blocks ending with bv4$.
+func decompressChunkset(data []byte) ([]byte, error) {
+	result := []byte{}
+
+	c := newCursor(data)
+	for c.remaining() >= 4 {
+		switch string(c.bytes(4)) {
+		case "bv41":
+			size := int(c.u32())
+			compressed := c.bytes(int(c.u32()))
+			if c.err != nil {
+				return nil, c.err
+			}
+
+			if len(result)+size > MAX_UNCOMPRESSED_SIZE {
+				return nil, errors.New("Chunkset too large")
+			}
+
+			dst := make([]byte, size)
+			n, err := utils.DecodeLZ4Block(compressed, dst)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, dst[:n]...)
+
+		case "bv4-":
+			block := c.bytes(int(c.u32()))
+			if c.err != nil {
+				return nil, c.err
+			}
+			result = append(result, block...)
+
+		case "bv4$":
+			return result, nil
+
+		default:
+			return nil, errors.New("Invalid chunkset block")
+		}
+	}
+
+	return result, nil
+}
+
+// The state required to decode the entries of a tracev3 file.
+type tracev3Parser struct {
+	ctx      context.Context
+	strings  *stringFiles
+	timesync *Timesync
+
+	header  *tracev3Header
+	catalog *catalog
+
+	// Oversize entries hold the data for firehose entries which are
+	// too large for the firehose chunk. They may appear after the
+	// entries which refer to them.
+	oversize map[oversizeKey]*oversizeEntry
+
+	log func(format string, args ...interface{})
+}
+
+// Parse a tracev3 file. The file is read twice - first to find the
+// oversize entries and then to emit all the entries.
+func ParseTracev3(ctx context.Context, reader io.ReaderAt,
+	strings *stringFiles, timesync *Timesync,
+	log func(format string, args ...interface{}),
+	cb func(entry *LogEntry) error) error {
+
+	self := &tracev3Parser{
+		ctx:      ctx,
+		strings:  strings,
+		timesync: timesync,
+		oversize: make(map[oversizeKey]*oversizeEntry),
+		log:      log,
+	}
+
+	err := self.walk(reader, func(tag uint32, chunk []byte) error {
+		if tag == CHUNK_OVERSIZE {
+			entry, err := parseOversize(chunk[CHUNK_PREAMBLE_SIZE:])
+			if err != nil {
+				return nil
+			}
+			self.oversize[entry.key] = entry
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = self.walk(reader, func(tag uint32, chunk []byte) error {
+		return self.processChunk(tag, chunk, cb)
+	})
+	if err == stopIteration {
+		return nil
+	}
+	return err
+}
+
+// Walk all the chunks in the file, including the chunks in the
+// chunksets.
+func (self *tracev3Parser) walk(reader io.ReaderAt,
+	cb func(tag uint32, chunk []byte) error) error {
+	self.header = nil
+	self.catalog = nil
+
+	preamble := make([]byte, CHUNK_PREAMBLE_SIZE)
+	for offset := int64(0); ; {
+		select {
+		case <-self.ctx.Done():
+			return stopIteration
+		default:
+		}
+
+		n, _ := reader.ReadAt(preamble, offset)
+		if n == 0 {
+			return nil
+		}
+		if n < CHUNK_PREAMBLE_SIZE {
+			return truncatedError
+		}
+
+		c := newCursor(preamble)
+		tag := c.u32()
+		c.skip(4)
+		size := c.u64()
+		if size > MAX_CHUNK_SIZE {
+			return fmt.Errorf("Chunk at %#x is too large", offset)
+		}
+
+		data := make([]byte, size)
+		n, _ = reader.ReadAt(data, offset+CHUNK_PREAMBLE_SIZE)
+		if n < len(data) {
+			return truncatedError
+		}
+
+		switch tag {
+		case CHUNK_HEADER:
+			header, err := parseHeader(data)
+			if err != nil {
+				return err
+			}
+			self.header = header
+
+		case CHUNK_CATALOG:
+			catalog, err := parseCatalog(data)
+			if err != nil {
+				self.log("parse_unified_log: Catalog at %#x: %v", offset, err)
+			}
+			self.catalog = catalog
+
+		case CHUNK_CHUNKSET:
+			decompressed, err := decompressChunkset(data)
+			if err != nil {
+				self.log("parse_unified_log: Chunkset at %#x: %v", offset, err)
+				break
+			}
+
+			err = walkChunks(decompressed, cb)
+			if err != nil && err != truncatedError {
+				return err
+			}
+		}
+
+		offset = int64(align8(int(offset) + CHUNK_PREAMBLE_SIZE + int(size)))
+	}
+}
+
+func (self *tracev3Parser) processChunk(tag uint32, chunk []byte,
+	cb func(entry *LogEntry) error) error {
+	data := chunk[CHUNK_PREAMBLE_SIZE:]
+
+	switch tag {
+	case CHUNK_FIREHOSE:
+		return self.processFirehose(data, cb)
+
+	case CHUNK_STATEDUMP:
+		entry, err := self.parseStatedump(data)
+		if err != nil {
+			return nil
+		}
+		return cb(entry)
+
+	case CHUNK_SIMPLEDUMP:
+		entry, err := self.parseSimpledump(data)
+		if err != nil {
+			return nil
+		}
+		return cb(entry)
+	}
+
+	return nil
+}
+
+// Fill in the time and process details for the entry.
+func (self *tracev3Parser) newEntry(first_proc_id uint64,
+	second_proc_id uint32, continuous_time uint64) (*LogEntry, *catalogProcess) {
+	entry := &LogEntry{
+		ContinuousTime: continuous_time,
+	}
+
+	if self.header != nil {
+		entry.BootUUID = self.header.boot_uuid
+
+		ts, ok := self.timesync.Time(entry.BootUUID, continuous_time)
+		if !ok {
+			// Fall back to the time in the header.
+			ts = time.Unix(0, self.header.wall_time+toNanoseconds(
+				int64(continuous_time-self.header.continuous_time),
+				self.header.numerator, self.header.denominator)).UTC()
+		}
+		entry.Time = ts
+	}
+
+	process := self.catalog.getProcess(first_proc_id, second_proc_id)
+	if process != nil {
+		entry.PID = process.pid
+		entry.EUID = process.euid
+		entry.ProcessUUID = process.main_uuid
+		entry.Process = self.strings.imagePath(process.main_uuid)
+	}
+
+	return entry, process
+}
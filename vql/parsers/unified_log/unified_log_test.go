@@ -0,0 +1,445 @@
+package unified_log
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"github.com/stretchr/testify/suite"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+
+	_ "www.velocidex.com/golang/velociraptor/accessors/file"
+)
+
+var (
+	testMainUUID  = bytes.Repeat([]byte{0x11}, 16)
+	testDSCUUID   = bytes.Repeat([]byte{0x22}, 16)
+	testImageUUID = bytes.Repeat([]byte{0x33}, 16)
+	testBootUUID  = bytes.Repeat([]byte{0x44}, 16)
+
+	// The sync point in the timesync file.
+	testSyncKernelTime = uint64(3000000)
+	testSyncWallTime   = time.Unix(1700000100, 0).UTC()
+)
+
+// Builds little endian binary structures for tests.
+type testBuffer struct {
+	bytes.Buffer
+}
+
+func (self *testBuffer) u8(v uint8) *testBuffer {
+	self.WriteByte(v)
+	return self
+}
+
+func (self *testBuffer) u16(v uint16) *testBuffer {
+	binary.Write(self, binary.LittleEndian, v)
+	return self
+}
+
+func (self *testBuffer) u32(v uint32) *testBuffer {
+	binary.Write(self, binary.LittleEndian, v)
+	return self
+}
+
+func (self *testBuffer) u64(v uint64) *testBuffer {
+	binary.Write(self, binary.LittleEndian, v)
+	return self
+}
+
+func (self *testBuffer) raw(data []byte) *testBuffer {
+	self.Write(data)
+	return self
+}
+
+func (self *testBuffer) pad8() *testBuffer {
+	for self.Len()%8 != 0 {
+		self.WriteByte(0)
+	}
+	return self
+}
+
+func chunk(tag uint32, data []byte) []byte {
+	b := &testBuffer{}
+	b.u32(tag).u32(0).u64(uint64(len(data))).raw(data).pad8()
+	return b.Bytes()
+}
+
+// A literal only LZ4 block.
+func lz4Literals(data []byte) []byte {
+	b := &testBuffer{}
+	if len(data) < 15 {
+		return b.u8(uint8(len(data) << 4)).raw(data).Bytes()
+	}
+
+	b.u8(0xf0)
+	remaining := len(data) - 15
+	for ; remaining >= 255; remaining -= 255 {
+		b.u8(255)
+	}
+	return b.u8(uint8(remaining)).raw(data).Bytes()
+}
+
+func chunkset(compress bool, chunks ...[]byte) []byte {
+	data := bytes.Join(chunks, nil)
+
+	b := &testBuffer{}
+	if compress {
+		compressed := lz4Literals(data)
+		b.raw([]byte("bv41")).u32(uint32(len(data))).
+			u32(uint32(len(compressed))).raw(compressed)
+	} else {
+		b.raw([]byte("bv4-")).u32(uint32(len(data))).raw(data)
+	}
+	b.raw([]byte("bv4$"))
+
+	return chunk(CHUNK_CHUNKSET, b.Bytes())
+}
+
+// Message items are followed by the string data.
+type testItem struct {
+	item_type uint8
+	number    []byte
+	str       string
+	offset    uint16
+	size      uint16
+}
+
+func numberItem(item_type uint8, value uint32) testItem {
+	b := &testBuffer{}
+	return testItem{item_type: item_type, number: b.u32(value).Bytes()}
+}
+
+func items(list ...testItem) []byte {
+	b := &testBuffer{}
+	strings := &testBuffer{}
+
+	b.u8(0x02).u8(uint8(len(list)))
+	for _, item := range list {
+		b.u8(item.item_type)
+		if item.number != nil {
+			b.u8(uint8(len(item.number))).raw(item.number)
+			continue
+		}
+
+		offset, size := item.offset, item.size
+		if item.str != "" {
+			offset = uint16(strings.Len())
+			size = uint16(len(item.str) + 1)
+			strings.raw([]byte(item.str)).u8(0)
+		}
+		b.u8(4).u16(offset).u16(size)
+	}
+
+	return b.raw(strings.Bytes()).Bytes()
+}
+
+type testEntry struct {
+	activity_type, log_type uint8
+	flags                   uint16
+	location                uint32
+	delta                   uint32
+	data                    []byte
+}
+
+func firehose(private []byte, entries ...testEntry) []byte {
+	public := &testBuffer{}
+	for _, e := range entries {
+		public.u8(e.activity_type).u8(e.log_type).u16(e.flags).
+			u32(e.location).u64(99).u32(e.delta).u16(0).
+			u16(uint16(len(e.data))).raw(e.data).pad8()
+	}
+
+	b := &testBuffer{}
+	b.u64(1).u32(2).u32(0).
+		u16(uint16(public.Len() + FIREHOSE_PUBLIC_DATA_OFFSET)).
+		u16(uint16(PRIVATE_DATA_VIRTUAL_END - len(private))).
+		u32(0).u64(testSyncKernelTime).
+		raw(public.Bytes()).raw(private)
+
+	return chunk(CHUNK_FIREHOSE, b.Bytes())
+}
+
+func buildTracev3() []byte {
+	header := &testBuffer{}
+	header.u32(1).u32(1).u64(0).u64(1600000000).raw(make([]byte, 16)).
+		u32(HEADER_BOOT_SUBCHUNK).u32(16).raw(testBootUUID)
+
+	strings := []byte("com.example.net\x00connection\x00\x00\x00\x00\x00\x00")
+	catalog := &testBuffer{}
+	catalog.u16(32).u16(uint16(32 + len(strings))).u16(1).raw(make([]byte, 18)).
+		raw(testMainUUID).raw(testDSCUUID).raw(strings).
+		// Process entry
+		u32(0).u16(0).u16(1).u64(1).u32(2).u32(42).u32(501).u32(0).
+		u32(0).u32(0).
+		// Subsystems
+		u32(1).u32(0).u16(5).u16(0).u16(16).pad8()
+
+	private := []byte("alice\x00\x00\x00")
+	private_offset := uint16(PRIVATE_DATA_VIRTUAL_END - len(private))
+
+	log_flags := uint16(FORMATTER_MAIN_EXE | FLAG_HAS_SUBSYSTEM)
+	entries := []testEntry{{
+		activity_type: ACTIVITY_TYPE_LOG,
+		flags:         log_flags,
+		location:      0x100,
+		data: append((&testBuffer{}).u32(0).u16(5).Bytes(), items(
+			testItem{item_type: 0x22, str: "example.com"},
+			numberItem(0x00, 443))...),
+	}, {
+		activity_type: ACTIVITY_TYPE_LOG,
+		log_type:      0x10,
+		flags:         log_flags | FLAG_HAS_PRIVATE_DATA,
+		location:      0x100 + 32,
+		delta:         3,
+		data: append((&testBuffer{}).u16(private_offset).
+			u16(uint16(len(private))).u32(0).u16(5).Bytes(), items(
+			testItem{item_type: 0x21, offset: 0, size: 6},
+			testItem{item_type: 0x21},
+			numberItem(0x01, 501))...),
+	}, {
+		activity_type: ACTIVITY_TYPE_LOG,
+		log_type:      0x01,
+		flags:         FORMATTER_SHARED_CACHE,
+		location:      0x5000 + 4,
+		delta:         6,
+		data: append((&testBuffer{}).u32(0).Bytes(), items(
+			numberItem(0x00, 7))...),
+	}, {
+		activity_type: ACTIVITY_TYPE_LOG,
+		flags:         log_flags | FLAG_HAS_OVERSIZE,
+		location:      0x100 + 64,
+		delta:         9,
+		data:          (&testBuffer{}).u32(0).u16(5).u16(1).Bytes(),
+	}, {
+		activity_type: ACTIVITY_TYPE_LOSS,
+		delta:         12,
+		data:          (&testBuffer{}).raw(make([]byte, 16)).u64(4).Bytes(),
+	}}
+
+	oversize_items := items(testItem{item_type: 0x22, str: "big data"})
+	oversize := &testBuffer{}
+	oversize.u64(1).u32(2).raw(make([]byte, 12)).u32(1).
+		u16(uint16(len(oversize_items))).u16(0).raw(oversize_items)
+
+	simpledump := &testBuffer{}
+	simpledump.u64(1).u32(2).u32(0).u64(testSyncKernelTime + 15).u64(7).
+		u64(0).raw(testMainUUID).raw(make([]byte, 16)).u32(0).
+		u32(19).u32(15).
+		raw([]byte("com.example.simple\x00")).raw([]byte("Simple message\x00"))
+
+	return bytes.Join([][]byte{
+		chunk(CHUNK_HEADER, header.Bytes()),
+		chunk(CHUNK_CATALOG, catalog.Bytes()),
+
+		// The oversize chunk comes after the entry which refers to it.
+		chunkset(true, firehose(private, entries...),
+			chunk(CHUNK_OVERSIZE, oversize.Bytes())),
+		chunkset(false, chunk(CHUNK_SIMPLEDUMP, simpledump.Bytes())),
+	}, nil)
+}
+
+func buildUUIDText() []byte {
+	// Each format string is padded to 32 bytes.
+	formats := []string{
+		"Connected to %{public}s port %d",
+		"User %s from %s uid %d",
+		"Payload: %s",
+	}
+
+	data := &testBuffer{}
+	for _, f := range formats {
+		padded := make([]byte, 32)
+		copy(padded, f)
+		data.raw(padded)
+	}
+
+	b := &testBuffer{}
+	b.u32(UUIDTEXT_SIGNATURE).u32(2).u32(1).u32(1).
+		u32(0x100).u32(uint32(data.Len())).
+		raw(data.Bytes()).raw([]byte("/usr/libexec/testd\x00"))
+	return b.Bytes()
+}
+
+func buildDSC() []byte {
+	strings := []byte("xxx\x00Cache hit %u times\x00")
+	header_size := 16 + 24 + 32
+
+	b := &testBuffer{}
+	b.raw([]byte(DSC_SIGNATURE)).u16(2).u16(0).u32(1).u32(1).
+		u64(0x5000).u32(uint32(header_size)).u32(uint32(len(strings))).u64(0).
+		raw(make([]byte, 12)).raw(testImageUUID).
+		u32(uint32(header_size + len(strings))).
+		raw(strings).raw([]byte("/usr/lib/libtest.dylib\x00"))
+	return b.Bytes()
+}
+
+func buildTimesync() []byte {
+	b := &testBuffer{}
+	b.u16(TIMESYNC_BOOT_SIGNATURE).u16(TIMESYNC_BOOT_SIZE).u32(0).
+		raw(testBootUUID).u32(125).u32(3).
+		u64(uint64(time.Unix(1700000000, 0).UnixNano())).u64(0).
+		u32(TIMESYNC_SYNC_SIGNATURE).u32(0).u64(testSyncKernelTime).
+		u64(uint64(testSyncWallTime.UnixNano())).u64(0)
+	return b.Bytes()
+}
+
+type UnifiedLogTestSuite struct {
+	suite.Suite
+
+	temp_dir string
+}
+
+func (self *UnifiedLogTestSuite) SetupTest() {
+	var err error
+	self.temp_dir, err = os.MkdirTemp("", "unified_log")
+	assert.NoError(self.T(), err)
+
+	main_uuid := formatUUID(testMainUUID)
+	for name, data := range map[string][]byte{
+		"diagnostics/Persist/0000000000000001.tracev3":    buildTracev3(),
+		"diagnostics/timesync/0000000000000001.timesync":  buildTimesync(),
+		"uuidtext/" + main_uuid[:2] + "/" + main_uuid[2:]: buildUUIDText(),
+		"uuidtext/dsc/" + formatUUID(testDSCUUID):         buildDSC(),
+	} {
+		path := filepath.Join(self.temp_dir, name)
+		assert.NoError(self.T(), os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(self.T(), os.WriteFile(path, data, 0600))
+	}
+}
+
+func (self *UnifiedLogTestSuite) TearDownTest() {
+	os.RemoveAll(self.temp_dir)
+}
+
+func (self *UnifiedLogTestSuite) parse(path string) []*ordereddict.Dict {
+	ctx := context.Background()
+	scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+		Set(vql_subsystem.ACL_MANAGER_VAR, acl_managers.NullACLManager{}))
+	scope.SetLogger(log.New(os.Stderr, "", 0))
+	defer scope.Close()
+
+	args := ordereddict.NewDict().
+		Set("path", filepath.Join(self.temp_dir, path)).
+		Set("accessor", "file")
+
+	result := []*ordereddict.Dict{}
+	for row := range (UnifiedLogPlugin{}).Call(ctx, scope, args) {
+		result = append(result, row.(*ordereddict.Dict))
+	}
+	return result
+}
+
+func get(row *ordereddict.Dict, field string) interface{} {
+	value, _ := row.Get(field)
+	return value
+}
+
+func (self *UnifiedLogTestSuite) TestPlugin() {
+	for _, path := range []string{
+		"diagnostics", "diagnostics/Persist/0000000000000001.tracev3"} {
+		rows := self.parse(path)
+		assert.Equal(self.T(), 6, len(rows), path)
+
+		messages := []string{}
+		for _, row := range rows {
+			messages = append(messages, get(row, "Message").(string))
+		}
+		assert.Equal(self.T(), []string{
+			"Connected to example.com port 443",
+			"User alice from <private> uid <private>",
+			"Cache hit 7 times",
+			"Payload: big data",
+			"Lost 4 unreliable messages",
+			"Simple message",
+		}, messages, path)
+
+		row := rows[0]
+		assert.Equal(self.T(), "Log", get(row, "EventType"))
+		assert.Equal(self.T(), "Default", get(row, "Level"))
+		assert.Equal(self.T(), uint32(42), get(row, "PID"))
+		assert.Equal(self.T(), uint32(501), get(row, "EUID"))
+		assert.Equal(self.T(), uint64(99), get(row, "ThreadId"))
+		assert.Equal(self.T(), "/usr/libexec/testd", get(row, "Process"))
+		assert.Equal(self.T(), "/usr/libexec/testd", get(row, "Sender"))
+		assert.Equal(self.T(), "com.example.net", get(row, "Subsystem"))
+		assert.Equal(self.T(), "connection", get(row, "Category"))
+		assert.Equal(self.T(), formatUUID(testBootUUID), get(row, "BootUUID"))
+		assert.Equal(self.T(), testSyncWallTime, get(row, "Time"))
+
+		// Continuous time is converted using the timebase (125/3).
+		row = rows[1]
+		assert.Equal(self.T(), "Error", get(row, "Level"))
+		assert.Equal(self.T(), testSyncWallTime.Add(125), get(row, "Time"))
+
+		row = rows[2]
+		assert.Equal(self.T(), "Info", get(row, "Level"))
+		assert.Equal(self.T(), "/usr/lib/libtest.dylib", get(row, "Sender"))
+		assert.Equal(self.T(), "", get(row, "Subsystem"))
+
+		row = rows[5]
+		assert.Equal(self.T(), "Simpledump", get(row, "EventType"))
+		assert.Equal(self.T(), "com.example.simple", get(row, "Subsystem"))
+		assert.Equal(self.T(), uint64(7), get(row, "ThreadId"))
+		assert.Equal(self.T(), testSyncWallTime.Add(625), get(row, "Time"))
+	}
+}
+
+func (self *UnifiedLogTestSuite) TestFormatMessage() {
+	number := func(item_type uint8, size int, value uint64) *messageItem {
+		return &messageItem{item_type: item_type, size: size, number: value}
+	}
+	str := func(value string) *messageItem {
+		return &messageItem{item_type: ITEM_CLASS_STRING, data: []byte(value)}
+	}
+
+	for _, tc := range []struct {
+		format   string
+		items    []*messageItem
+		expected string
+	}{
+		{"%d%% done", []*messageItem{number(0, 4, 50)}, "50% done"},
+		{"%d", []*messageItem{number(0, 4, 0xffffffff)}, "-1"},
+		{"%u", []*messageItem{number(0, 4, 0xffffffff)}, "4294967295"},
+		{"%08x", []*messageItem{number(0, 4, 0xbeef)}, "0000beef"},
+		{"%lld", []*messageItem{number(0, 8, 12345678901)}, "12345678901"},
+		{"%{BOOL}d %{bool}d", []*messageItem{
+			number(0, 4, 1), number(0, 4, 0)}, "YES false"},
+		{"%{public, time_t}d", []*messageItem{number(0, 8, 0)},
+			"1970-01-01T00:00:00Z"},
+		{"%.*s", []*messageItem{
+			number(ITEM_CLASS_PRECISION, 4, 3), str("abcdef")}, "abc"},
+		{"%{public}@ %s", []*messageItem{str("obj")},
+			"obj " + MISSING_VALUE},
+		{"%{private}s", []*messageItem{{private: true}}, PRIVATE_VALUE},
+		{"%s", []*messageItem{{item_type: ITEM_CLASS_STRING, null: true}},
+			"(null)"},
+		{"%{uuid_t}.16P", []*messageItem{{
+			item_type: ITEM_CLASS_BINARY,
+			data:      testBootUUID}},
+			"44444444-4444-4444-4444-444444444444"},
+		{"%.2f", []*messageItem{number(0, 8, 0x400921fb54442d18)}, "3.14"},
+		{"errno %m", nil, "errno <errno>"},
+		{"trailing %", nil, "trailing %"},
+	} {
+		assert.Equal(self.T(), tc.expected,
+			formatMessage(tc.format, tc.items), tc.format)
+	}
+}
+
+func (self *UnifiedLogTestSuite) TestLargeOffset() {
+	assert.Equal(self.T(), uint64(0x80001234), largeOffset(8, 0x1234, 7))
+	assert.Equal(self.T(), uint64(0x100001234), largeOffset(1, 0x1234, 8))
+}
+
+func TestUnifiedLog(t *testing.T) {
+	suite.Run(t, &UnifiedLogTestSuite{})
+}
@@ -0,0 +1,9 @@
+//go:build freebsd
+// +build freebsd
+
+package psutils
+
+import "github.com/Velocidex/ordereddict"
+
+// Containers are only detected on Linux.
+func addContainerInfo(result *ordereddict.Dict, pid int32) {}
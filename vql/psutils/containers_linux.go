@@ -0,0 +1,568 @@
+//go:build linux
+// +build linux
+
+package psutils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+)
+
+var (
+	// The container ID is usually the last component of the cgroup
+	// path, optionally decorated by the systemd cgroup driver
+	// (e.g. docker-<id>.scope).
+	container_id_regex = regexp.MustCompile(
+		`^(?:(docker|cri-containerd|crio|libpod)-)?([0-9a-f]{64})(?:\.scope)?$`)
+
+	// Kubernetes pod cgroups contain the pod UID. The systemd driver
+	// replaces the dashes with underscores.
+	pod_uid_regex = regexp.MustCompile(
+		`pod([0-9a-f]{8}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{12})`)
+
+	namespace_regex = regexp.MustCompile(`^[a-z_]+:\[(\d+)\]$`)
+
+	runtime_prefixes = map[string]string{
+		"docker":         "docker",
+		"cri-containerd": "containerd",
+		"crio":           "cri-o",
+		"libpod":         "podman",
+	}
+
+	// Namespaces reported for each process.
+	process_namespaces = []struct {
+		name, field string
+	}{
+		{"pid", "Pid"},
+		{"mnt", "Mnt"},
+		{"net", "Net"},
+	}
+
+	containerNotFoundError = errors.New("Container not found")
+)
+
+// Where container runtimes keep their state. These are relative to
+// the state root which is normally /.
+const (
+	DOCKER_STATE_DIR       = "var/lib/docker/containers"
+	CONTAINERD_STATE_DIR   = "run/containerd/io.containerd.runtime.v2.task"
+	CONTAINERS_STORAGE_DIR = "var/lib/containers/storage/overlay-containers"
+)
+
+// The container a process belongs to as derived from its cgroup.
+type ProcessContainer struct {
+	Cgroup      string
+	ContainerId string
+	Runtime     string
+	PodUid      string
+}
+
+// Parse the content of /proc/<pid>/cgroup
+func ParseCgroup(data string) *ProcessContainer {
+	result := &ProcessContainer{}
+
+	var first, unified string
+	for _, line := range strings.Split(data, "\n") {
+		// Lines look like hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		path := parts[2]
+
+		if first == "" {
+			first = path
+		}
+
+		if parts[0] == "0" && parts[1] == "" {
+			unified = path
+		}
+
+		if result.ContainerId == "" && parseCgroupPath(path, result) {
+			result.Cgroup = path
+		}
+	}
+
+	if result.Cgroup == "" {
+		result.Cgroup = unified
+	}
+
+	if result.Cgroup == "" {
+		result.Cgroup = first
+	}
+
+	return result
+}
+
+func parseCgroupPath(path string, result *ProcessContainer) bool {
+	components := strings.Split(path, "/")
+	for i := len(components) - 1; i >= 0; i-- {
+		hits := container_id_regex.FindStringSubmatch(components[i])
+		if len(hits) == 0 {
+			continue
+		}
+
+		result.ContainerId = hits[2]
+		result.Runtime = runtime_prefixes[hits[1]]
+
+		// The cgroupfs driver nests the container under a runtime
+		// specific parent (e.g. /docker/<id>)
+		for _, parent := range components[:i] {
+			if result.Runtime == "" {
+				switch {
+				case parent == "docker":
+					result.Runtime = "docker"
+				case parent == "libpod_parent":
+					result.Runtime = "podman"
+				}
+			}
+
+			pod_hits := pod_uid_regex.FindStringSubmatch(parent)
+			if len(pod_hits) > 0 {
+				result.PodUid = strings.ReplaceAll(pod_hits[1], "_", "-")
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func procRoot() string {
+	return GetEnv("HOST_PROC", "/proc")
+}
+
+func readProcessContainer(proc_root string, pid int32) (*ProcessContainer, error) {
+	data, err := os.ReadFile(filepath.Join(
+		proc_root, strconv.Itoa(int(pid)), "cgroup"))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseCgroup(string(data)), nil
+}
+
+func GetProcessContainer(pid int32) (*ProcessContainer, error) {
+	return readProcessContainer(procRoot(), pid)
+}
+
+// Get the namespace inodes of the process. Processes sharing a
+// namespace have the same inode.
+func GetNamespaces(pid int32) *ordereddict.Dict {
+	result := ordereddict.NewDict()
+	base := GetHostProc(pid)
+	for _, ns := range process_namespaces {
+		link, err := os.Readlink(filepath.Join(base, "ns", ns.name))
+		if err != nil {
+			continue
+		}
+
+		hits := namespace_regex.FindStringSubmatch(link)
+		if len(hits) == 0 {
+			continue
+		}
+
+		inode, err := strconv.ParseUint(hits[1], 10, 64)
+		if err == nil {
+			result.Set(ns.field, inode)
+		}
+	}
+	return result
+}
+
+// Add the container details to the pslist() rows.
+func addContainerInfo(result *ordereddict.Dict, pid int32) {
+	container, err := GetProcessContainer(pid)
+	if err != nil {
+		container = &ProcessContainer{}
+	}
+
+	result.Set("Cgroup", container.Cgroup).
+		Set("ContainerId", container.ContainerId).
+		Set("Namespaces", GetNamespaces(pid))
+}
+
+type Container struct {
+	Id      string
+	Runtime string
+	Name    string
+	Image   string
+
+	// Only set for Kubernetes pods.
+	PodName      string
+	PodNamespace string
+	PodUid       string
+
+	// The container's init process, 0 if the container is not
+	// running.
+	Pid  int32
+	Pids []int32
+
+	Cgroup  string
+	Created time.Time
+	Labels  map[string]string
+
+	// The runtime state file the container was found in.
+	StateFile string
+}
+
+// Fill in missing fields from the other record.
+func (self *Container) merge(other *Container) {
+	if self.Runtime == "" {
+		self.Runtime = other.Runtime
+	}
+	if self.Name == "" {
+		self.Name = other.Name
+	}
+	if self.Image == "" {
+		self.Image = other.Image
+	}
+	if self.PodName == "" {
+		self.PodName = other.PodName
+	}
+	if self.PodNamespace == "" {
+		self.PodNamespace = other.PodNamespace
+	}
+	if self.PodUid == "" {
+		self.PodUid = other.PodUid
+	}
+	if self.Pid == 0 {
+		self.Pid = other.Pid
+	}
+	if self.Created.IsZero() {
+		self.Created = other.Created
+	}
+	if len(self.Labels) == 0 {
+		self.Labels = other.Labels
+	}
+	if self.StateFile == "" {
+		self.StateFile = other.StateFile
+	}
+}
+
+// Containerd uses the CRI annotations while Docker and CRI-O use
+// the kubelet labels.
+func (self *Container) setKubernetesLabels(labels map[string]string) {
+	for _, keys := range [][3]string{
+		{"io.kubernetes.cri.sandbox-name",
+			"io.kubernetes.cri.sandbox-namespace",
+			"io.kubernetes.cri.sandbox-uid"},
+		{"io.kubernetes.pod.name",
+			"io.kubernetes.pod.namespace",
+			"io.kubernetes.pod.uid"},
+	} {
+		if self.PodName == "" {
+			self.PodName = labels[keys[0]]
+		}
+		if self.PodNamespace == "" {
+			self.PodNamespace = labels[keys[1]]
+		}
+		if self.PodUid == "" {
+			self.PodUid = labels[keys[2]]
+		}
+	}
+}
+
+// Discover containers from the cgroups of running processes and the
+// state directories of the common container runtimes.
+func ListContainers(ctx context.Context) ([]*Container, error) {
+	return listContainers(ctx, procRoot(), "/")
+}
+
+func listContainers(
+	ctx context.Context, proc_root, state_root string) ([]*Container, error) {
+	containers := make(map[string]*Container)
+
+	add := func(c *Container) {
+		existing, pres := containers[c.Id]
+		if !pres {
+			containers[c.Id] = c
+			return
+		}
+		existing.merge(c)
+	}
+
+	// Docker also uses containerd so we need to see its state
+	// first.
+	for _, c := range readDockerState(filepath.Join(state_root, DOCKER_STATE_DIR)) {
+		add(c)
+	}
+
+	for _, c := range readContainersStorageState(
+		filepath.Join(state_root, CONTAINERS_STORAGE_DIR)) {
+		add(c)
+	}
+
+	for _, c := range readContainerdState(
+		filepath.Join(state_root, CONTAINERD_STATE_DIR)) {
+		add(c)
+	}
+
+	entries, err := os.ReadDir(proc_root)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		pid, err := strconv.ParseInt(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+
+		process_container, err := readProcessContainer(proc_root, int32(pid))
+		if err != nil || process_container.ContainerId == "" {
+			continue
+		}
+
+		c, pres := containers[process_container.ContainerId]
+		if !pres {
+			c = &Container{Id: process_container.ContainerId}
+			containers[c.Id] = c
+		}
+
+		c.merge(&Container{
+			Runtime: process_container.Runtime,
+			PodUid:  process_container.PodUid,
+		})
+		if c.Cgroup == "" {
+			c.Cgroup = process_container.Cgroup
+		}
+		c.Pids = append(c.Pids, int32(pid))
+	}
+
+	result := make([]*Container, 0, len(containers))
+	for _, c := range containers {
+		sort.Slice(c.Pids, func(i, j int) bool {
+			return c.Pids[i] < c.Pids[j]
+		})
+
+		// The state files may refer to a process that is gone.
+		if c.Pid != 0 && !containsPid(c.Pids, c.Pid) {
+			c.Pid = 0
+		}
+
+		// Without a state file the first process is most likely
+		// the init process.
+		if c.Pid == 0 && len(c.Pids) > 0 {
+			c.Pid = c.Pids[0]
+		}
+		result = append(result, c)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+
+	return result, nil
+}
+
+// Find a running container by ID, unique ID prefix or name.
+func FindRunningContainer(
+	containers []*Container, name string) (*Container, error) {
+	var match *Container
+	for _, c := range containers {
+		if c.Pid == 0 {
+			continue
+		}
+
+		if c.Id == name || c.Name == name {
+			return c, nil
+		}
+
+		if strings.HasPrefix(c.Id, name) {
+			if match != nil {
+				return nil, errors.New("Container ID prefix is ambiguous: " + name)
+			}
+			match = c
+		}
+	}
+
+	if match == nil {
+		return nil, containerNotFoundError
+	}
+	return match, nil
+}
+
+func containsPid(pids []int32, pid int32) bool {
+	for _, p := range pids {
+		if p == pid {
+			return true
+		}
+	}
+	return false
+}
+
+func readJSONFile(path string, target interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func readPidFile(path string) int32 {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 32)
+	return int32(pid)
+}
+
+type dockerConfig struct {
+	ID      string
+	Name    string
+	Created time.Time
+	Config  struct {
+		Image  string
+		Labels map[string]string
+	}
+	State struct {
+		Running bool
+		Pid     int32
+	}
+}
+
+// Docker keeps a config.v2.json for each container, including
+// stopped ones.
+func readDockerState(dir string) []*Container {
+	var result []*Container
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name(), "config.v2.json")
+		config := &dockerConfig{}
+		err := readJSONFile(path, config)
+		if err != nil || config.ID == "" {
+			continue
+		}
+
+		c := &Container{
+			Id:        config.ID,
+			Runtime:   "docker",
+			Name:      strings.TrimPrefix(config.Name, "/"),
+			Image:     config.Config.Image,
+			Created:   config.Created,
+			Labels:    config.Config.Labels,
+			StateFile: path,
+		}
+		if config.State.Running {
+			c.Pid = config.State.Pid
+		}
+		c.setKubernetesLabels(config.Config.Labels)
+		result = append(result, c)
+	}
+	return result
+}
+
+type ociSpec struct {
+	Annotations map[string]string `json:"annotations"`
+}
+
+// Containerd keeps the OCI bundle of running containers under a
+// directory per namespace (e.g. k8s.io or moby).
+func readContainerdState(dir string) []*Container {
+	var result []*Container
+
+	namespaces, _ := os.ReadDir(dir)
+	for _, ns := range namespaces {
+		ns_dir := filepath.Join(dir, ns.Name())
+		entries, _ := os.ReadDir(ns_dir)
+		for _, entry := range entries {
+			path := filepath.Join(ns_dir, entry.Name(), "config.json")
+			spec := &ociSpec{}
+			err := readJSONFile(path, spec)
+			if err != nil {
+				continue
+			}
+
+			c := &Container{
+				Id:        entry.Name(),
+				Runtime:   "containerd",
+				Name:      spec.Annotations["io.kubernetes.cri.container-name"],
+				Image:     spec.Annotations["io.kubernetes.cri.image-name"],
+				Pid:       readPidFile(filepath.Join(ns_dir, entry.Name(), "init.pid")),
+				Labels:    spec.Annotations,
+				StateFile: path,
+			}
+			c.setKubernetesLabels(spec.Annotations)
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+type containersStorageEntry struct {
+	ID      string    `json:"id"`
+	Names   []string  `json:"names"`
+	Image   string    `json:"image"`
+	Created time.Time `json:"created"`
+}
+
+// CRI-O and Podman share the containers/storage library.
+func readContainersStorageState(dir string) []*Container {
+	var result []*Container
+
+	var entries []*containersStorageEntry
+	err := readJSONFile(filepath.Join(dir, "containers.json"), &entries)
+	if err != nil {
+		return nil
+	}
+
+	for _, entry := range entries {
+		if entry == nil || entry.ID == "" {
+			continue
+		}
+
+		c := &Container{
+			Id:        entry.ID,
+			Runtime:   "podman",
+			Image:     entry.Image,
+			Created:   entry.Created,
+			StateFile: filepath.Join(dir, "containers.json"),
+		}
+		if len(entry.Names) > 0 {
+			c.Name = entry.Names[0]
+		}
+
+		userdata := filepath.Join(dir, entry.ID, "userdata")
+		spec := &ociSpec{}
+		err := readJSONFile(filepath.Join(userdata, "config.json"), spec)
+		if err == nil {
+			if spec.Annotations["io.kubernetes.cri-o.ContainerID"] != "" {
+				c.Runtime = "cri-o"
+			}
+
+			image := spec.Annotations["io.kubernetes.cri-o.ImageName"]
+			if image != "" {
+				c.Image = image
+			}
+
+			name := spec.Annotations["io.kubernetes.container.name"]
+			if name != "" {
+				c.Name = name
+			}
+			c.Labels = spec.Annotations
+			c.setKubernetesLabels(spec.Annotations)
+		}
+
+		c.Pid = readPidFile(filepath.Join(userdata, "pidfile"))
+		result = append(result, c)
+	}
+	return result
+}
@@ -0,0 +1,138 @@
+//go:build linux
+// +build linux
+
+package psutils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+)
+
+var (
+	id1 = strings.Repeat("a", 64)
+	id2 = strings.Repeat("b", 64)
+	id3 = strings.Repeat("c", 64)
+)
+
+func TestParseCgroup(t *testing.T) {
+	// Not in a container.
+	assert.Equal(t, &ProcessContainer{Cgroup: "/user.slice"},
+		ParseCgroup("1:name=systemd:/user.slice\n0::/user.slice\n"))
+
+	// Docker with the cgroupfs driver on cgroup v1.
+	assert.Equal(t, &ProcessContainer{
+		Cgroup:      "/docker/" + id1,
+		ContainerId: id1,
+		Runtime:     "docker",
+	}, ParseCgroup("12:pids:/docker/"+id1+"\n0::/\n"))
+
+	// Kubernetes with the systemd driver on cgroup v2.
+	assert.Equal(t, &ProcessContainer{
+		Cgroup: "/kubepods.slice/kubepods-burstable.slice/" +
+			"kubepods-burstable-pod1b2c3d4e_0000_1111_2222_333344445555.slice/" +
+			"cri-containerd-" + id1 + ".scope",
+		ContainerId: id1,
+		Runtime:     "containerd",
+		PodUid:      "1b2c3d4e-0000-1111-2222-333344445555",
+	}, ParseCgroup("0::/kubepods.slice/kubepods-burstable.slice/"+
+		"kubepods-burstable-pod1b2c3d4e_0000_1111_2222_333344445555.slice/"+
+		"cri-containerd-"+id1+".scope\n"))
+
+	// Kubernetes with the cgroupfs driver does not name the runtime.
+	assert.Equal(t, &ProcessContainer{
+		Cgroup:      "/kubepods/besteffort/pod1b2c3d4e-0000-1111-2222-333344445555/" + id1,
+		ContainerId: id1,
+		PodUid:      "1b2c3d4e-0000-1111-2222-333344445555",
+	}, ParseCgroup("0::/kubepods/besteffort/"+
+		"pod1b2c3d4e-0000-1111-2222-333344445555/"+id1+"\n"))
+
+	// Podman nests the container's processes below its scope.
+	assert.Equal(t, id1, ParseCgroup(
+		"0::/machine.slice/libpod-"+id1+".scope/container\n").ContainerId)
+
+	// The CRI-O monitor process is not part of the container.
+	assert.Equal(t, "", ParseCgroup(
+		"0::/machine.slice/crio-conmon-"+id1+".scope\n").ContainerId)
+}
+
+func writeFile(t *testing.T, path, data string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0600))
+}
+
+func TestListContainers(t *testing.T) {
+	proc_root := t.TempDir()
+	state_root := t.TempDir()
+
+	// A running docker container with two processes.
+	writeFile(t, filepath.Join(proc_root, "100", "cgroup"),
+		"0::/system.slice/docker-"+id1+".scope\n")
+	writeFile(t, filepath.Join(proc_root, "120", "cgroup"),
+		"0::/system.slice/docker-"+id1+".scope\n")
+	writeFile(t, filepath.Join(proc_root, "1", "cgroup"), "0::/init.scope\n")
+
+	writeFile(t, filepath.Join(state_root, DOCKER_STATE_DIR, id1, "config.v2.json"), `{
+ "ID": "`+id1+`", "Name": "/web", "Created": "2024-01-02T03:04:05Z",
+ "Config": {"Image": "nginx:latest", "Labels": {"app": "web"}},
+ "State": {"Running": true, "Pid": 100}}`)
+
+	// A stopped docker container.
+	writeFile(t, filepath.Join(state_root, DOCKER_STATE_DIR, id2, "config.v2.json"), `{
+ "ID": "`+id2+`", "Name": "/old", "Config": {"Image": "busybox"},
+ "State": {"Running": false, "Pid": 0}}`)
+
+	// A Kubernetes container run by containerd.
+	writeFile(t, filepath.Join(proc_root, "200", "cgroup"),
+		"0::/kubepods/pod1b2c3d4e-0000-1111-2222-333344445555/"+id3+"\n")
+	task_dir := filepath.Join(state_root, CONTAINERD_STATE_DIR, "k8s.io", id3)
+	writeFile(t, filepath.Join(task_dir, "init.pid"), "200")
+	writeFile(t, filepath.Join(task_dir, "config.json"), `{"annotations": {
+ "io.kubernetes.cri.container-name": "app",
+ "io.kubernetes.cri.image-name": "registry/app:1",
+ "io.kubernetes.cri.sandbox-name": "app-7d9f",
+ "io.kubernetes.cri.sandbox-namespace": "default"}}`)
+
+	containers, err := listContainers(context.Background(), proc_root, state_root)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(containers))
+
+	web := containers[0]
+	assert.Equal(t, id1, web.Id)
+	assert.Equal(t, "web", web.Name)
+	assert.Equal(t, "docker", web.Runtime)
+	assert.Equal(t, "nginx:latest", web.Image)
+	assert.Equal(t, int32(100), web.Pid)
+	assert.Equal(t, []int32{100, 120}, web.Pids)
+	assert.Equal(t, "/system.slice/docker-"+id1+".scope", web.Cgroup)
+
+	old := containers[1]
+	assert.Equal(t, "old", old.Name)
+	assert.Equal(t, int32(0), old.Pid)
+
+	app := containers[2]
+	assert.Equal(t, "app", app.Name)
+	assert.Equal(t, "containerd", app.Runtime)
+	assert.Equal(t, "registry/app:1", app.Image)
+	assert.Equal(t, "app-7d9f", app.PodName)
+	assert.Equal(t, "default", app.PodNamespace)
+	assert.Equal(t, "1b2c3d4e-0000-1111-2222-333344445555", app.PodUid)
+	assert.Equal(t, int32(200), app.Pid)
+
+	// Containers can be found by name or ID prefix but only when
+	// running.
+	c, err := FindRunningContainer(containers, "web")
+	assert.NoError(t, err)
+	assert.Equal(t, id1, c.Id)
+
+	c, err = FindRunningContainer(containers, "ccc")
+	assert.NoError(t, err)
+	assert.Equal(t, id3, c.Id)
+
+	_, err = FindRunningContainer(containers, "old")
+	assert.Error(t, err)
+}
@@ -69,6 +69,8 @@ func getProcessData(process *process.Process) *ordereddict.Dict {
 	memory_info, _ := process.MemoryInfo()
 	result.Set("MemoryInfo", memory_info)
 
+	addContainerInfo(result, process.Pid)
+
 	return result
 }
 
@@ -137,6 +137,9 @@ func getTypedOSPath(path_type string, path string) (*accessors.OSPath, error) {
 	case "windows":
 		return accessors.NewWindowsOSPath(path)
 
+	case "linux":
+		return accessors.NewLinuxOSPath(path)
+
 	case "registry":
 		return accessors.NewWindowsRegistryPath(path)
 
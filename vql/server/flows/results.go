@@ -274,6 +274,8 @@ func getNotebookResultSetReader(
 		Cell(arg.NotebookCellId, arg.NotebookCellVersion).
 		QueryStorage(table)
 
+	result_sets.TrackResultSet(scope, path_manager.Path())
+
 	return result_sets.NewResultSetReader(
 		file_store_factory, path_manager.Path())
 }
@@ -319,6 +321,8 @@ func getResultSetReader(
 			return nil, err
 		}
 
+		result_sets.TrackResultSet(scope, path_manager.Path())
+
 		return result_sets.NewResultSetReader(
 			file_store_factory, path_manager.Path())
 
@@ -461,6 +465,8 @@ func (self FlowResultsPlugin) Call(
 			return
 		}
 
+		result_sets.TrackResultSet(scope, path_manager.Path())
+
 		file_store_factory := file_store.GetFileStore(config_obj)
 		rs_reader, err := result_sets.NewResultSetReader(
 			file_store_factory, path_manager.Path())
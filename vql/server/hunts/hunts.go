@@ -246,6 +246,10 @@ func (self HuntResultsPlugin) Call(
 				return
 			}
 
+			// New clients joining the hunt change the results.
+			result_sets.TrackResultSet(scope,
+				paths.NewHuntPathManager(arg.HuntId).Clients())
+
 			options := result_sets.ResultSetOptions{}
 			flow_chan, _, err := hunt_dispatcher.GetFlows(
 				ctx, org_config_obj, options, scope, arg.HuntId, 0)
@@ -282,6 +286,8 @@ func (self HuntResultsPlugin) Call(
 					continue
 				}
 
+				result_sets.TrackResultSet(scope, path_manager.Path())
+
 				file_store_factory := file_store.GetFileStore(org_config_obj)
 
 				reader, err := result_sets.NewResultSetReader(
@@ -0,0 +1,378 @@
+package notebooks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/Velocidex/ordereddict"
+	"www.velocidex.com/golang/velociraptor/acls"
+	api_proto "www.velocidex.com/golang/velociraptor/api/proto"
+	config_proto "www.velocidex.com/golang/velociraptor/config/proto"
+	"www.velocidex.com/golang/velociraptor/datastore"
+	"www.velocidex.com/golang/velociraptor/file_store"
+	"www.velocidex.com/golang/velociraptor/file_store/api"
+	"www.velocidex.com/golang/velociraptor/file_store/path_specs"
+	"www.velocidex.com/golang/velociraptor/json"
+	"www.velocidex.com/golang/velociraptor/paths"
+	"www.velocidex.com/golang/velociraptor/result_sets"
+	"www.velocidex.com/golang/velociraptor/services"
+	"www.velocidex.com/golang/velociraptor/utils"
+	"www.velocidex.com/golang/velociraptor/vql"
+	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
+	vql_materializer "www.velocidex.com/golang/velociraptor/vql/materializer"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/arg_parser"
+	"www.velocidex.com/golang/vfilter/materializer"
+	"www.velocidex.com/golang/vfilter/types"
+)
+
+// Caches the results of an expensive query in the notebook. The
+// cache is keyed by the query, the values of the scope variables it
+// refers to and the result sets it read (as tracked by the source(),
+// hunt_results() and flow_results() plugins). As long as the query
+// and its variables are the same and none of the result sets
+// changed, the cached rows are replayed instead of running the query
+// again.
+//
+// Queries which do not read any result sets (e.g. clients()) are
+// only cached when a name or a ttl is given.
+type NotebookCachePluginArgs struct {
+	Query vfilter.StoredQuery `vfilter:"required,field=query,doc=The query to cache"`
+	Name  string              `vfilter:"optional,field=name,doc=A name for the cache (default is derived from the query)"`
+	Ttl   int64               `vfilter:"optional,field=ttl,doc=Expire the cache after this many seconds (default never)"`
+}
+
+type NotebookCachePlugin struct{}
+
+func (self NotebookCachePlugin) Call(ctx context.Context,
+	scope vfilter.Scope,
+	args *ordereddict.Dict) <-chan vfilter.Row {
+
+	output_chan := make(chan vfilter.Row)
+
+	go func() {
+		defer close(output_chan)
+
+		err := vql_subsystem.CheckAccess(scope, acls.READ_RESULTS)
+		if err != nil {
+			scope.Log("notebook_cache: %s", err)
+			return
+		}
+
+		arg := &NotebookCachePluginArgs{}
+		err = arg_parser.ExtractArgsWithContext(ctx, scope, args, arg)
+		if err != nil {
+			scope.Log("notebook_cache: %s", err.Error())
+			return
+		}
+
+		config_obj, ok := vql_subsystem.GetServerConfig(scope)
+		if !ok {
+			scope.Log("notebook_cache: Command can only run on the server")
+			return
+		}
+
+		notebook_id_any, _ := scope.Resolve("NotebookId")
+		notebook_id, _ := notebook_id_any.(string)
+		if notebook_id == "" {
+			scope.Log("notebook_cache: Can only be used in a notebook")
+			return
+		}
+
+		// The notebook id may be set by the query itself so make
+		// sure the user has access to the notebook.
+		err = checkNotebookAccess(ctx, config_obj, scope, notebook_id)
+		if err != nil {
+			scope.Log("notebook_cache: %v", err)
+			return
+		}
+
+		// Without a name or ttl a query which reads no result sets
+		// would be cached forever.
+		explicit := arg.Name != "" || arg.Ttl > 0
+
+		query := vfilter.FormatToString(scope, arg.Query)
+		variables := queryVariables(ctx, scope, query)
+		if arg.Name == "" {
+			hash := sha256.Sum256([]byte(query))
+			arg.Name = hex.EncodeToString(hash[:8])
+		}
+
+		db, err := datastore.GetDB(config_obj)
+		if err != nil {
+			scope.Log("notebook_cache: %v", err)
+			return
+		}
+
+		file_store_factory := file_store.GetFileStore(config_obj)
+		path_manager := paths.NewNotebookPathManager(notebook_id)
+		metadata_path := path_manager.QueryCacheMetadata(arg.Name)
+		cache_path := path_manager.QueryCache(arg.Name)
+
+		cache := &api_proto.NotebookQueryCache{}
+		err = db.GetSubject(config_obj, metadata_path, cache)
+		if err == nil && isCacheValid(file_store_factory, cache,
+			query, variables, arg.Ttl) {
+			reader, err := result_sets.NewResultSetReader(
+				file_store_factory, cache_path)
+			if err == nil {
+				defer reader.Close()
+
+				scope.Log("notebook_cache: Using %v rows cached in %v at %v",
+					cache.TotalRows, arg.Name,
+					time.Unix(cache.Timestamp, 0).UTC().Format(time.RFC3339))
+
+				// Nested caches depend on our sources as well.
+				for _, source := range cache.Sources {
+					result_sets.TrackResultSet(scope, sourcePath(source))
+				}
+
+				for row := range reader.Rows(ctx) {
+					select {
+					case <-ctx.Done():
+						return
+					case output_chan <- row:
+					}
+				}
+				return
+			}
+		}
+
+		// The cache is stale: Remove it before we overwrite the
+		// results so a partial result set is never used.
+		_ = db.DeleteSubject(config_obj, metadata_path)
+
+		tracker := result_sets.NewResultSetTracker()
+		subscope := scope.Copy()
+		subscope.AppendVars(ordereddict.NewDict().
+			Set(result_sets.RESULT_SET_TRACKER, tracker))
+		defer subscope.Close()
+
+		writer, err := result_sets.NewResultSetWriter(
+			file_store_factory, cache_path,
+			vql_subsystem.EncOptsFromScope(scope), utils.SyncCompleter,
+			result_sets.TruncateMode)
+		if err != nil {
+			scope.Log("notebook_cache: %v", err)
+			return
+		}
+
+		total_rows := uint64(0)
+		for row := range arg.Query.Eval(ctx, subscope) {
+			row_dict := vfilter.RowToDict(ctx, subscope, row)
+			writer.Write(row_dict)
+			total_rows++
+
+			select {
+			case <-ctx.Done():
+				writer.Close()
+				return
+			case output_chan <- row_dict:
+			}
+		}
+		writer.Close()
+
+		// The query was cancelled so the results are incomplete.
+		if ctx.Err() != nil {
+			return
+		}
+
+		sources := tracker.Paths()
+		if len(sources) == 0 && !explicit {
+			scope.Log("notebook_cache: Query does not read any result sets so it is not cached. Specify a name or ttl to cache it anyway.")
+			_ = file_store_factory.Delete(cache_path)
+			_ = file_store_factory.Delete(
+				cache_path.SetType(api.PATH_TYPE_FILESTORE_JSON_INDEX))
+			return
+		}
+
+		cache = &api_proto.NotebookQueryCache{
+			Name:      arg.Name,
+			Query:     query,
+			Variables: variables,
+			Timestamp: utils.GetTime().Now().Unix(),
+			TotalRows: total_rows,
+		}
+
+		for _, path := range sources {
+			cache.Sources = append(cache.Sources,
+				getCacheSource(file_store_factory, path))
+			result_sets.TrackResultSet(scope, path)
+		}
+
+		err = db.SetSubject(config_obj, metadata_path, cache)
+		if err != nil {
+			scope.Log("notebook_cache: %v", err)
+		}
+	}()
+
+	return output_chan
+}
+
+func sourcePath(source *api_proto.NotebookQueryCacheSource) api.FSPathSpec {
+	return path_specs.NewUnsafeFilestorePath(source.Components...).
+		SetType(api.PATH_TYPE_FILESTORE_JSON)
+}
+
+// Record the current state of the result set. Result sets which do
+// not exist yet are recorded with a size of -1 so we notice when
+// they appear.
+func getCacheSource(file_store_factory api.FileStore,
+	path api.FSPathSpec) *api_proto.NotebookQueryCacheSource {
+	result := &api_proto.NotebookQueryCacheSource{
+		Components: path.Components(),
+		Size:       -1,
+	}
+
+	stat, err := file_store_factory.StatFile(path)
+	if err == nil {
+		result.Mtime = stat.ModTime().UnixNano()
+		result.Size = stat.Size()
+	}
+
+	return result
+}
+
+func checkNotebookAccess(ctx context.Context,
+	config_obj *config_proto.Config,
+	scope vfilter.Scope, notebook_id string) error {
+	notebook_manager, err := services.GetNotebookManager(config_obj)
+	if err != nil {
+		return err
+	}
+
+	notebook, err := notebook_manager.GetNotebook(ctx, notebook_id,
+		services.DO_NOT_INCLUDE_UPLOADS)
+	if err != nil {
+		return err
+	}
+
+	if !notebook_manager.CheckNotebookAccess(
+		notebook, vql_subsystem.GetPrincipal(scope)) {
+		return fmt.Errorf("%w: Notebook is not shared with user.",
+			utils.InvalidStatus)
+	}
+	return nil
+}
+
+var identifierRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// The query may refer to scope variables (e.g. notebook parameters)
+// so their values are part of the cache key. We can not tell
+// variables from other symbols in the query text so every identifier
+// which resolves in the scope is included. Nothing is evaluated to
+// build the key and only its sha256 digest is stored.
+func queryVariables(ctx context.Context,
+	scope vfilter.Scope, query string) string {
+	values := make(map[string]string)
+
+	var visit func(text string)
+	visit = func(text string) {
+		for _, name := range identifierRegex.FindAllString(text, -1) {
+			_, pres := values[name]
+			if pres {
+				continue
+			}
+			values[name] = ""
+
+			value, pres := scope.Resolve(name)
+			if !pres {
+				continue
+			}
+
+			switch t := value.(type) {
+			case *vfilter.StoredExpression:
+				// Stored expressions and queries are not run. Their
+				// text and the variables they refer to are used
+				// instead.
+				text := vfilter.FormatToString(scope, t.Expr)
+				values[name] = text
+				visit(text)
+				continue
+
+			case *materializer.InMemoryMatrializer,
+				*vql_materializer.TempFileMatrializer:
+				// Materialized queries were already run and their
+				// text is not available so only the variable's name
+				// and type are used.
+				values[name] = fmt.Sprintf("%T", t)
+				continue
+
+			case types.StoredQuery:
+				text := vfilter.FormatToString(scope, t)
+				values[name] = text
+				visit(text)
+				continue
+
+			case types.LazyExpr:
+				values[name] = fmt.Sprintf("%T", t)
+				continue
+			}
+
+			serialized, err := json.Marshal(value)
+			if err != nil {
+				serialized = []byte(fmt.Sprintf("%v", value))
+			}
+
+			// Keep only a digest so large values stay small.
+			hash := sha256.Sum256(serialized)
+			values[name] = hex.EncodeToString(hash[:])
+		}
+	}
+	visit(query)
+
+	names := make([]string, 0, len(values))
+	for name, value := range values {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s=%s\n", name, values[name])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func isCacheValid(file_store_factory api.FileStore,
+	cache *api_proto.NotebookQueryCache, query, variables string,
+	ttl int64) bool {
+	if cache.Query != query || cache.Variables != variables {
+		return false
+	}
+
+	if ttl > 0 && utils.GetTime().Now().Unix() >= cache.Timestamp+ttl {
+		return false
+	}
+
+	for _, source := range cache.Sources {
+		current := getCacheSource(file_store_factory, sourcePath(source))
+		if current.Mtime != source.Mtime || current.Size != source.Size {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (self NotebookCachePlugin) Info(
+	scope vfilter.Scope, type_map *vfilter.TypeMap) *vfilter.PluginInfo {
+	return &vfilter.PluginInfo{
+		Name: "notebook_cache",
+		Doc: "Cache the results of a query in the notebook until the " +
+			"result sets it reads change.",
+		ArgType:  type_map.AddType(scope, &NotebookCachePluginArgs{}),
+		Metadata: vql.VQLMetadata().Permissions(acls.READ_RESULTS).Build(),
+	}
+}
+
+func init() {
+	vql_subsystem.RegisterPlugin(&NotebookCachePlugin{})
+}
@@ -2,6 +2,7 @@ package notebooks
 
 import (
 	"archive/zip"
+	"context"
 	"io/ioutil"
 	"strings"
 	"testing"
@@ -14,14 +15,20 @@ import (
 	"www.velocidex.com/golang/velociraptor/file_store/api"
 	"www.velocidex.com/golang/velociraptor/file_store/test_utils"
 	"www.velocidex.com/golang/velociraptor/logging"
+	"www.velocidex.com/golang/velociraptor/paths"
+	artifact_paths "www.velocidex.com/golang/velociraptor/paths/artifacts"
+	"www.velocidex.com/golang/velociraptor/result_sets"
 	"www.velocidex.com/golang/velociraptor/services"
 	"www.velocidex.com/golang/velociraptor/utils"
 	vql_subsystem "www.velocidex.com/golang/velociraptor/vql"
 	"www.velocidex.com/golang/velociraptor/vql/acl_managers"
 	"www.velocidex.com/golang/velociraptor/vtesting"
 	"www.velocidex.com/golang/velociraptor/vtesting/assert"
+	"www.velocidex.com/golang/vfilter"
+	"www.velocidex.com/golang/vfilter/materializer"
 
 	_ "www.velocidex.com/golang/velociraptor/accessors/data"
+	_ "www.velocidex.com/golang/velociraptor/vql/server/flows"
 )
 
 var testArtifacts = []string{`
@@ -38,6 +45,8 @@ sources:
 name: Server.Audit.Logs
 `, `
 name: Server.Internal.ArtifactDescription
+`, `
+name: Test.Results
 `}
 
 type NotebookTestSuite struct {
@@ -265,6 +274,245 @@ func (self *NotebookTestSuite) TestCreateNotebook() {
 	assert.Contains(self.T(), html_export, "downloadFile('aGVsbG8=', 'file.txt')")
 }
 
+func (self *NotebookTestSuite) writeResults(rows ...*ordereddict.Dict) {
+	path_manager, err := artifact_paths.NewArtifactPathManager(self.Ctx,
+		self.ConfigObj, "C.123", "F.123", "Test.Results")
+	assert.NoError(self.T(), err)
+
+	writer, err := result_sets.NewResultSetWriter(
+		file_store.GetFileStore(self.ConfigObj), path_manager.Path(),
+		nil, utils.SyncCompleter, result_sets.AppendMode)
+	assert.NoError(self.T(), err)
+
+	for _, row := range rows {
+		writer.Write(row)
+	}
+	writer.Close()
+}
+
+func (self *NotebookTestSuite) newNotebook(creator string) string {
+	self.LoadArtifacts(testArtifacts...)
+
+	notebook_manager, err := services.GetNotebookManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+
+	// Wait for the notebook workers to start.
+	var notebook *api_proto.NotebookMetadata
+	vtesting.WaitUntil(2*time.Second, self.T(), func() bool {
+		notebook, err = notebook_manager.NewNotebook(self.Ctx, creator,
+			&api_proto.NotebookMetadata{Name: "Cache Notebook"})
+		return err == nil
+	})
+
+	return notebook.NotebookId
+}
+
+// Run the query in a notebook scope and return the number of rows.
+func (self *NotebookTestSuite) runInNotebook(
+	notebook_id string, env *ordereddict.Dict, query string) int {
+	repository := self.LoadArtifacts(testArtifacts...)
+	builder := services.ScopeBuilder{
+		Config:     self.ConfigObj,
+		ACLManager: self.acl_manager,
+		Repository: repository,
+		Logger: logging.NewPlainLogger(
+			self.ConfigObj, &logging.FrontendComponent),
+		Env: env.Set("NotebookId", notebook_id),
+	}
+
+	manager, err := services.GetRepositoryManager(self.ConfigObj)
+	assert.NoError(self.T(), err)
+	scope := manager.BuildScope(builder)
+	defer scope.Close()
+
+	vql, err := vfilter.Parse(query)
+	assert.NoError(self.T(), err)
+
+	count := 0
+	for range vql.Eval(self.Ctx, scope) {
+		count++
+	}
+	return count
+}
+
+func (self *NotebookTestSuite) TestNotebookCache() {
+	notebook_id := self.newNotebook("admin")
+
+	closer := utils.MockTime(utils.NewMockClock(time.Unix(1000, 0)))
+	defer closer()
+
+	self.writeResults(
+		ordereddict.NewDict().Set("Foo", 1),
+		ordereddict.NewDict().Set("Foo", 2))
+
+	query := `
+SELECT * FROM notebook_cache(name="Test",
+  query={
+    SELECT Foo FROM source(client_id="C.123", flow_id="F.123",
+                           artifact="Test.Results")
+    WHERE Foo <= MaxFoo
+  })
+`
+	run := func(max_foo int) int {
+		return self.runInNotebook(notebook_id,
+			ordereddict.NewDict().Set("MaxFoo", max_foo), query)
+	}
+
+	db := test_utils.GetMemoryDataStore(self.T(), self.ConfigObj)
+	metadata_path := paths.NewNotebookPathManager(notebook_id).
+		QueryCacheMetadata("Test")
+
+	// First run populates the cache and records the source.
+	assert.Equal(self.T(), 2, run(10))
+
+	cache := &api_proto.NotebookQueryCache{}
+	err := db.GetSubject(self.ConfigObj, metadata_path, cache)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(1000), cache.Timestamp)
+	assert.Equal(self.T(), uint64(2), cache.TotalRows)
+	assert.Equal(self.T(), 1, len(cache.Sources))
+
+	// Only a digest of the variables is stored.
+	assert.Equal(self.T(), 64, len(cache.Variables))
+	assert.NotContains(self.T(), cache.Variables, "MaxFoo")
+
+	// Second run is served from the cache so the timestamp is not
+	// updated.
+	utils.MockTime(utils.NewMockClock(time.Unix(2000, 0)))
+	assert.Equal(self.T(), 2, run(10))
+
+	err = db.GetSubject(self.ConfigObj, metadata_path, cache)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(1000), cache.Timestamp)
+
+	// Changing a variable the query uses invalidates the cache.
+	utils.MockTime(utils.NewMockClock(time.Unix(2500, 0)))
+	assert.Equal(self.T(), 1, run(1))
+
+	err = db.GetSubject(self.ConfigObj, metadata_path, cache)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(2500), cache.Timestamp)
+	assert.Equal(self.T(), uint64(1), cache.TotalRows)
+
+	// Changing the source result set invalidates the cache.
+	self.writeResults(ordereddict.NewDict().Set("Foo", 3))
+
+	utils.MockTime(utils.NewMockClock(time.Unix(3000, 0)))
+	assert.Equal(self.T(), 3, run(10))
+
+	err = db.GetSubject(self.ConfigObj, metadata_path, cache)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(3000), cache.Timestamp)
+	assert.Equal(self.T(), uint64(3), cache.TotalRows)
+}
+
+func (self *NotebookTestSuite) TestNotebookCacheNoSources() {
+	notebook_id := self.newNotebook("admin")
+
+	closer := utils.MockTime(utils.NewMockClock(time.Unix(1000, 0)))
+	defer closer()
+	db := test_utils.GetMemoryDataStore(self.T(), self.ConfigObj)
+	path_manager := paths.NewNotebookPathManager(notebook_id)
+
+	// A query which reads no result sets is not cached by default.
+	assert.Equal(self.T(), 1, self.runInNotebook(notebook_id,
+		ordereddict.NewDict(), `
+SELECT * FROM notebook_cache(query={ SELECT 1 AS A FROM scope() })
+`))
+
+	children, err := db.ListChildren(self.ConfigObj,
+		path_manager.QueryCacheDSDirectory())
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), 0, len(children))
+
+	// With a ttl it is cached until the ttl expires.
+	query := `
+SELECT * FROM notebook_cache(name="TTL", ttl=100,
+   query={ SELECT 1 AS A FROM scope() })
+`
+	metadata_path := path_manager.QueryCacheMetadata("TTL")
+
+	assert.Equal(self.T(), 1, self.runInNotebook(
+		notebook_id, ordereddict.NewDict(), query))
+
+	cache := &api_proto.NotebookQueryCache{}
+	err = db.GetSubject(self.ConfigObj, metadata_path, cache)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(1000), cache.Timestamp)
+
+	utils.MockTime(utils.NewMockClock(time.Unix(1050, 0)))
+	assert.Equal(self.T(), 1, self.runInNotebook(
+		notebook_id, ordereddict.NewDict(), query))
+
+	err = db.GetSubject(self.ConfigObj, metadata_path, cache)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(1000), cache.Timestamp)
+
+	utils.MockTime(utils.NewMockClock(time.Unix(1100, 0)))
+	assert.Equal(self.T(), 1, self.runInNotebook(
+		notebook_id, ordereddict.NewDict(), query))
+
+	err = db.GetSubject(self.ConfigObj, metadata_path, cache)
+	assert.NoError(self.T(), err)
+	assert.Equal(self.T(), int64(1100), cache.Timestamp)
+}
+
+func (self *NotebookTestSuite) TestNotebookCacheAccess() {
+	// A notebook which is not shared with the admin user.
+	notebook_id := self.newNotebook("fred")
+
+	assert.Equal(self.T(), 0, self.runInNotebook(notebook_id,
+		ordereddict.NewDict(), `
+SELECT * FROM notebook_cache(name="Test",
+   query={ SELECT 1 AS A FROM scope() })
+`))
+
+	db := test_utils.GetMemoryDataStore(self.T(), self.ConfigObj)
+	cache := &api_proto.NotebookQueryCache{}
+	err := db.GetSubject(self.ConfigObj,
+		paths.NewNotebookPathManager(notebook_id).
+			QueryCacheMetadata("Test"), cache)
+	assert.Error(self.T(), err)
+}
+
+type countingLazyExpr struct {
+	count *int
+}
+
+func (self countingLazyExpr) Reduce(ctx context.Context) vfilter.Any {
+	*self.count++
+	return 1
+}
+
+func (self countingLazyExpr) ReduceWithScope(
+	ctx context.Context, scope vfilter.Scope) vfilter.Any {
+	*self.count++
+	return 1
+}
+
+// Building the cache key does not evaluate the variables.
+func TestQueryVariables(t *testing.T) {
+	ctx := context.Background()
+	query := "SELECT * FROM Rows WHERE Lazy AND Name"
+
+	count := 0
+	key := func(name string) string {
+		scope := vql_subsystem.MakeScope().AppendVars(ordereddict.NewDict().
+			Set("Lazy", countingLazyExpr{count: &count}).
+			Set("Rows", materializer.NewInMemoryMatrializer(nil)).
+			Set("Name", name))
+		defer scope.Close()
+
+		return queryVariables(ctx, scope, query)
+	}
+
+	first := key("Bob")
+	assert.Equal(t, 0, count)
+	assert.Equal(t, 64, len(first))
+	assert.Equal(t, first, key("Bob"))
+	assert.True(t, first != key("Alice"))
+}
+
 func TestNotebook(t *testing.T) {
 	suite.Run(t, &NotebookTestSuite{})
 }
@@ -2,15 +2,25 @@ package plugins
 
 import (
 	_ "www.velocidex.com/golang/velociraptor/accessors"
+	_ "www.velocidex.com/golang/velociraptor/accessors/apfs"
+	_ "www.velocidex.com/golang/velociraptor/accessors/bitlocker"
+	_ "www.velocidex.com/golang/velociraptor/accessors/btrfs"
 	_ "www.velocidex.com/golang/velociraptor/accessors/collector"
+	_ "www.velocidex.com/golang/velociraptor/accessors/container"
 	_ "www.velocidex.com/golang/velociraptor/accessors/data"
 	_ "www.velocidex.com/golang/velociraptor/accessors/ewf"
 	_ "www.velocidex.com/golang/velociraptor/accessors/ext4"
 	_ "www.velocidex.com/golang/velociraptor/accessors/fat"
 	_ "www.velocidex.com/golang/velociraptor/accessors/file"
 	_ "www.velocidex.com/golang/velociraptor/accessors/file_store"
+	_ "www.velocidex.com/golang/velociraptor/accessors/hfs"
+	_ "www.velocidex.com/golang/velociraptor/accessors/hiberfil"
+	_ "www.velocidex.com/golang/velociraptor/accessors/lime"
+	_ "www.velocidex.com/golang/velociraptor/accessors/luks"
+	_ "www.velocidex.com/golang/velociraptor/accessors/lvm"
 	_ "www.velocidex.com/golang/velociraptor/accessors/ntfs"
 	_ "www.velocidex.com/golang/velociraptor/accessors/offset"
+	_ "www.velocidex.com/golang/velociraptor/accessors/overlay"
 	_ "www.velocidex.com/golang/velociraptor/accessors/pipe"
 	_ "www.velocidex.com/golang/velociraptor/accessors/process"
 	_ "www.velocidex.com/golang/velociraptor/accessors/raw_file"
@@ -22,5 +32,6 @@ import (
 	_ "www.velocidex.com/golang/velociraptor/accessors/ssh"
 	_ "www.velocidex.com/golang/velociraptor/accessors/vfs"
 	_ "www.velocidex.com/golang/velociraptor/accessors/vhdx"
+	_ "www.velocidex.com/golang/velociraptor/accessors/xfs"
 	_ "www.velocidex.com/golang/velociraptor/accessors/zip"
 )
@@ -35,7 +35,16 @@ import (
 	_ "www.velocidex.com/golang/velociraptor/vql/parsers/csv"
 	_ "www.velocidex.com/golang/velociraptor/vql/parsers/ese"
 	_ "www.velocidex.com/golang/velociraptor/vql/parsers/event_logs"
+	_ "www.velocidex.com/golang/velociraptor/vql/parsers/etl"
+	_ "www.velocidex.com/golang/velociraptor/vql/parsers/journald"
+	_ "www.velocidex.com/golang/velociraptor/vql/parsers/leveldb"
+	_ "www.velocidex.com/golang/velociraptor/vql/parsers/lnk"
+	_ "www.velocidex.com/golang/velociraptor/vql/parsers/ntfs_logfile"
+	_ "www.velocidex.com/golang/velociraptor/vql/parsers/oci"
+	_ "www.velocidex.com/golang/velociraptor/vql/parsers/packages"
+	_ "www.velocidex.com/golang/velociraptor/vql/parsers/pcap"
 	_ "www.velocidex.com/golang/velociraptor/vql/parsers/syslog"
+	_ "www.velocidex.com/golang/velociraptor/vql/parsers/unified_log"
 	_ "www.velocidex.com/golang/velociraptor/vql/parsers/usn"
 	_ "www.velocidex.com/golang/velociraptor/vql/protocols"
 	_ "www.velocidex.com/golang/velociraptor/vql/sigma"
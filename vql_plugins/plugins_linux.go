@@ -18,5 +18,6 @@
 package plugins
 
 import (
+	_ "www.velocidex.com/golang/velociraptor/vql/darwin"
 	_ "www.velocidex.com/golang/velociraptor/vql/linux"
 )